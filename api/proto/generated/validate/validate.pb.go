@@ -0,0 +1,711 @@
+// The subset of the protoc-gen-validate rules (package validate) the service
+// enforces, from bufbuild/protoc-gen-validate validate/validate.proto. Field
+// numbers and types are those of protoc-gen-validate, so the annotations
+// mean the same to its tooling; rules the service does not enforce are left
+// out.
+//
+// Copyright Envoy Project Authors. Licensed under the Apache License,
+// Version 2.0.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v5.29.3
+// source: validate/validate.proto
+
+package validate
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	descriptorpb "google.golang.org/protobuf/types/descriptorpb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// FieldRules encapsulates the rules for each type of field.
+type FieldRules struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Message *MessageRules          `protobuf:"bytes,17,opt,name=message" json:"message,omitempty"`
+	// Types that are valid to be assigned to Type:
+	//
+	//	*FieldRules_Int32
+	//	*FieldRules_Int64
+	//	*FieldRules_String_
+	//	*FieldRules_Repeated
+	Type          isFieldRules_Type `protobuf_oneof:"type"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FieldRules) Reset() {
+	*x = FieldRules{}
+	mi := &file_validate_validate_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FieldRules) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FieldRules) ProtoMessage() {}
+
+func (x *FieldRules) ProtoReflect() protoreflect.Message {
+	mi := &file_validate_validate_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FieldRules.ProtoReflect.Descriptor instead.
+func (*FieldRules) Descriptor() ([]byte, []int) {
+	return file_validate_validate_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *FieldRules) GetMessage() *MessageRules {
+	if x != nil {
+		return x.Message
+	}
+	return nil
+}
+
+func (x *FieldRules) GetType() isFieldRules_Type {
+	if x != nil {
+		return x.Type
+	}
+	return nil
+}
+
+func (x *FieldRules) GetInt32() *Int32Rules {
+	if x != nil {
+		if x, ok := x.Type.(*FieldRules_Int32); ok {
+			return x.Int32
+		}
+	}
+	return nil
+}
+
+func (x *FieldRules) GetInt64() *Int64Rules {
+	if x != nil {
+		if x, ok := x.Type.(*FieldRules_Int64); ok {
+			return x.Int64
+		}
+	}
+	return nil
+}
+
+func (x *FieldRules) GetString_() *StringRules {
+	if x != nil {
+		if x, ok := x.Type.(*FieldRules_String_); ok {
+			return x.String_
+		}
+	}
+	return nil
+}
+
+func (x *FieldRules) GetRepeated() *RepeatedRules {
+	if x != nil {
+		if x, ok := x.Type.(*FieldRules_Repeated); ok {
+			return x.Repeated
+		}
+	}
+	return nil
+}
+
+type isFieldRules_Type interface {
+	isFieldRules_Type()
+}
+
+type FieldRules_Int32 struct {
+	Int32 *Int32Rules `protobuf:"bytes,3,opt,name=int32,oneof"`
+}
+
+type FieldRules_Int64 struct {
+	Int64 *Int64Rules `protobuf:"bytes,4,opt,name=int64,oneof"`
+}
+
+type FieldRules_String_ struct {
+	String_ *StringRules `protobuf:"bytes,14,opt,name=string,oneof"`
+}
+
+type FieldRules_Repeated struct {
+	Repeated *RepeatedRules `protobuf:"bytes,18,opt,name=repeated,oneof"`
+}
+
+func (*FieldRules_Int32) isFieldRules_Type() {}
+
+func (*FieldRules_Int64) isFieldRules_Type() {}
+
+func (*FieldRules_String_) isFieldRules_Type() {}
+
+func (*FieldRules_Repeated) isFieldRules_Type() {}
+
+type Int32Rules struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Lt            *int32                 `protobuf:"varint,2,opt,name=lt" json:"lt,omitempty"`
+	Lte           *int32                 `protobuf:"varint,3,opt,name=lte" json:"lte,omitempty"`
+	Gt            *int32                 `protobuf:"varint,4,opt,name=gt" json:"gt,omitempty"`
+	Gte           *int32                 `protobuf:"varint,5,opt,name=gte" json:"gte,omitempty"`
+	In            []int32                `protobuf:"varint,6,rep,name=in" json:"in,omitempty"`
+	IgnoreEmpty   *bool                  `protobuf:"varint,8,opt,name=ignore_empty,json=ignoreEmpty" json:"ignore_empty,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Int32Rules) Reset() {
+	*x = Int32Rules{}
+	mi := &file_validate_validate_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Int32Rules) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Int32Rules) ProtoMessage() {}
+
+func (x *Int32Rules) ProtoReflect() protoreflect.Message {
+	mi := &file_validate_validate_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Int32Rules.ProtoReflect.Descriptor instead.
+func (*Int32Rules) Descriptor() ([]byte, []int) {
+	return file_validate_validate_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Int32Rules) GetLt() int32 {
+	if x != nil && x.Lt != nil {
+		return *x.Lt
+	}
+	return 0
+}
+
+func (x *Int32Rules) GetLte() int32 {
+	if x != nil && x.Lte != nil {
+		return *x.Lte
+	}
+	return 0
+}
+
+func (x *Int32Rules) GetGt() int32 {
+	if x != nil && x.Gt != nil {
+		return *x.Gt
+	}
+	return 0
+}
+
+func (x *Int32Rules) GetGte() int32 {
+	if x != nil && x.Gte != nil {
+		return *x.Gte
+	}
+	return 0
+}
+
+func (x *Int32Rules) GetIn() []int32 {
+	if x != nil {
+		return x.In
+	}
+	return nil
+}
+
+func (x *Int32Rules) GetIgnoreEmpty() bool {
+	if x != nil && x.IgnoreEmpty != nil {
+		return *x.IgnoreEmpty
+	}
+	return false
+}
+
+type Int64Rules struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Lt            *int64                 `protobuf:"varint,2,opt,name=lt" json:"lt,omitempty"`
+	Lte           *int64                 `protobuf:"varint,3,opt,name=lte" json:"lte,omitempty"`
+	Gt            *int64                 `protobuf:"varint,4,opt,name=gt" json:"gt,omitempty"`
+	Gte           *int64                 `protobuf:"varint,5,opt,name=gte" json:"gte,omitempty"`
+	In            []int64                `protobuf:"varint,6,rep,name=in" json:"in,omitempty"`
+	IgnoreEmpty   *bool                  `protobuf:"varint,8,opt,name=ignore_empty,json=ignoreEmpty" json:"ignore_empty,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Int64Rules) Reset() {
+	*x = Int64Rules{}
+	mi := &file_validate_validate_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Int64Rules) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Int64Rules) ProtoMessage() {}
+
+func (x *Int64Rules) ProtoReflect() protoreflect.Message {
+	mi := &file_validate_validate_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Int64Rules.ProtoReflect.Descriptor instead.
+func (*Int64Rules) Descriptor() ([]byte, []int) {
+	return file_validate_validate_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Int64Rules) GetLt() int64 {
+	if x != nil && x.Lt != nil {
+		return *x.Lt
+	}
+	return 0
+}
+
+func (x *Int64Rules) GetLte() int64 {
+	if x != nil && x.Lte != nil {
+		return *x.Lte
+	}
+	return 0
+}
+
+func (x *Int64Rules) GetGt() int64 {
+	if x != nil && x.Gt != nil {
+		return *x.Gt
+	}
+	return 0
+}
+
+func (x *Int64Rules) GetGte() int64 {
+	if x != nil && x.Gte != nil {
+		return *x.Gte
+	}
+	return 0
+}
+
+func (x *Int64Rules) GetIn() []int64 {
+	if x != nil {
+		return x.In
+	}
+	return nil
+}
+
+func (x *Int64Rules) GetIgnoreEmpty() bool {
+	if x != nil && x.IgnoreEmpty != nil {
+		return *x.IgnoreEmpty
+	}
+	return false
+}
+
+type StringRules struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Lengths count characters (Unicode code points).
+	MinLen *uint64  `protobuf:"varint,2,opt,name=min_len,json=minLen" json:"min_len,omitempty"`
+	MaxLen *uint64  `protobuf:"varint,3,opt,name=max_len,json=maxLen" json:"max_len,omitempty"`
+	In     []string `protobuf:"bytes,10,rep,name=in" json:"in,omitempty"`
+	// Types that are valid to be assigned to WellKnown:
+	//
+	//	*StringRules_Email
+	//	*StringRules_Uuid
+	WellKnown isStringRules_WellKnown `protobuf_oneof:"well_known"`
+	// Skips the rules for an empty value.
+	IgnoreEmpty   *bool `protobuf:"varint,26,opt,name=ignore_empty,json=ignoreEmpty" json:"ignore_empty,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StringRules) Reset() {
+	*x = StringRules{}
+	mi := &file_validate_validate_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StringRules) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StringRules) ProtoMessage() {}
+
+func (x *StringRules) ProtoReflect() protoreflect.Message {
+	mi := &file_validate_validate_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StringRules.ProtoReflect.Descriptor instead.
+func (*StringRules) Descriptor() ([]byte, []int) {
+	return file_validate_validate_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *StringRules) GetMinLen() uint64 {
+	if x != nil && x.MinLen != nil {
+		return *x.MinLen
+	}
+	return 0
+}
+
+func (x *StringRules) GetMaxLen() uint64 {
+	if x != nil && x.MaxLen != nil {
+		return *x.MaxLen
+	}
+	return 0
+}
+
+func (x *StringRules) GetIn() []string {
+	if x != nil {
+		return x.In
+	}
+	return nil
+}
+
+func (x *StringRules) GetWellKnown() isStringRules_WellKnown {
+	if x != nil {
+		return x.WellKnown
+	}
+	return nil
+}
+
+func (x *StringRules) GetEmail() bool {
+	if x != nil {
+		if x, ok := x.WellKnown.(*StringRules_Email); ok {
+			return x.Email
+		}
+	}
+	return false
+}
+
+func (x *StringRules) GetUuid() bool {
+	if x != nil {
+		if x, ok := x.WellKnown.(*StringRules_Uuid); ok {
+			return x.Uuid
+		}
+	}
+	return false
+}
+
+func (x *StringRules) GetIgnoreEmpty() bool {
+	if x != nil && x.IgnoreEmpty != nil {
+		return *x.IgnoreEmpty
+	}
+	return false
+}
+
+type isStringRules_WellKnown interface {
+	isStringRules_WellKnown()
+}
+
+type StringRules_Email struct {
+	Email bool `protobuf:"varint,12,opt,name=email,oneof"`
+}
+
+type StringRules_Uuid struct {
+	Uuid bool `protobuf:"varint,22,opt,name=uuid,oneof"`
+}
+
+func (*StringRules_Email) isStringRules_WellKnown() {}
+
+func (*StringRules_Uuid) isStringRules_WellKnown() {}
+
+type RepeatedRules struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	MinItems *uint64                `protobuf:"varint,1,opt,name=min_items,json=minItems" json:"min_items,omitempty"`
+	MaxItems *uint64                `protobuf:"varint,2,opt,name=max_items,json=maxItems" json:"max_items,omitempty"`
+	Unique   *bool                  `protobuf:"varint,3,opt,name=unique" json:"unique,omitempty"`
+	// Rules for each item.
+	Items         *FieldRules `protobuf:"bytes,4,opt,name=items" json:"items,omitempty"`
+	IgnoreEmpty   *bool       `protobuf:"varint,5,opt,name=ignore_empty,json=ignoreEmpty" json:"ignore_empty,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RepeatedRules) Reset() {
+	*x = RepeatedRules{}
+	mi := &file_validate_validate_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RepeatedRules) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RepeatedRules) ProtoMessage() {}
+
+func (x *RepeatedRules) ProtoReflect() protoreflect.Message {
+	mi := &file_validate_validate_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RepeatedRules.ProtoReflect.Descriptor instead.
+func (*RepeatedRules) Descriptor() ([]byte, []int) {
+	return file_validate_validate_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *RepeatedRules) GetMinItems() uint64 {
+	if x != nil && x.MinItems != nil {
+		return *x.MinItems
+	}
+	return 0
+}
+
+func (x *RepeatedRules) GetMaxItems() uint64 {
+	if x != nil && x.MaxItems != nil {
+		return *x.MaxItems
+	}
+	return 0
+}
+
+func (x *RepeatedRules) GetUnique() bool {
+	if x != nil && x.Unique != nil {
+		return *x.Unique
+	}
+	return false
+}
+
+func (x *RepeatedRules) GetItems() *FieldRules {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *RepeatedRules) GetIgnoreEmpty() bool {
+	if x != nil && x.IgnoreEmpty != nil {
+		return *x.IgnoreEmpty
+	}
+	return false
+}
+
+type MessageRules struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Skips the rules of the fields of the message.
+	Skip          *bool `protobuf:"varint,1,opt,name=skip" json:"skip,omitempty"`
+	Required      *bool `protobuf:"varint,2,opt,name=required" json:"required,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MessageRules) Reset() {
+	*x = MessageRules{}
+	mi := &file_validate_validate_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MessageRules) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MessageRules) ProtoMessage() {}
+
+func (x *MessageRules) ProtoReflect() protoreflect.Message {
+	mi := &file_validate_validate_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MessageRules.ProtoReflect.Descriptor instead.
+func (*MessageRules) Descriptor() ([]byte, []int) {
+	return file_validate_validate_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *MessageRules) GetSkip() bool {
+	if x != nil && x.Skip != nil {
+		return *x.Skip
+	}
+	return false
+}
+
+func (x *MessageRules) GetRequired() bool {
+	if x != nil && x.Required != nil {
+		return *x.Required
+	}
+	return false
+}
+
+var file_validate_validate_proto_extTypes = []protoimpl.ExtensionInfo{
+	{
+		ExtendedType:  (*descriptorpb.FieldOptions)(nil),
+		ExtensionType: (*FieldRules)(nil),
+		Field:         1071,
+		Name:          "validate.rules",
+		Tag:           "bytes,1071,opt,name=rules",
+		Filename:      "validate/validate.proto",
+	},
+}
+
+// Extension fields to descriptorpb.FieldOptions.
+var (
+	// Rules specify the validations to be performed on this field.
+	//
+	// optional validate.FieldRules rules = 1071;
+	E_Rules = &file_validate_validate_proto_extTypes[0]
+)
+
+var File_validate_validate_proto protoreflect.FileDescriptor
+
+const file_validate_validate_proto_rawDesc = "" +
+	"\n" +
+	"\x17validate/validate.proto\x12\bvalidate\x1a google/protobuf/descriptor.proto\"\x8a\x02\n" +
+	"\n" +
+	"FieldRules\x120\n" +
+	"\amessage\x18\x11 \x01(\v2\x16.validate.MessageRulesR\amessage\x12,\n" +
+	"\x05int32\x18\x03 \x01(\v2\x14.validate.Int32RulesH\x00R\x05int32\x12,\n" +
+	"\x05int64\x18\x04 \x01(\v2\x14.validate.Int64RulesH\x00R\x05int64\x12/\n" +
+	"\x06string\x18\x0e \x01(\v2\x15.validate.StringRulesH\x00R\x06string\x125\n" +
+	"\brepeated\x18\x12 \x01(\v2\x17.validate.RepeatedRulesH\x00R\brepeatedB\x06\n" +
+	"\x04type\"\x83\x01\n" +
+	"\n" +
+	"Int32Rules\x12\x0e\n" +
+	"\x02lt\x18\x02 \x01(\x05R\x02lt\x12\x10\n" +
+	"\x03lte\x18\x03 \x01(\x05R\x03lte\x12\x0e\n" +
+	"\x02gt\x18\x04 \x01(\x05R\x02gt\x12\x10\n" +
+	"\x03gte\x18\x05 \x01(\x05R\x03gte\x12\x0e\n" +
+	"\x02in\x18\x06 \x03(\x05R\x02in\x12!\n" +
+	"\fignore_empty\x18\b \x01(\bR\vignoreEmpty\"\x83\x01\n" +
+	"\n" +
+	"Int64Rules\x12\x0e\n" +
+	"\x02lt\x18\x02 \x01(\x03R\x02lt\x12\x10\n" +
+	"\x03lte\x18\x03 \x01(\x03R\x03lte\x12\x0e\n" +
+	"\x02gt\x18\x04 \x01(\x03R\x02gt\x12\x10\n" +
+	"\x03gte\x18\x05 \x01(\x03R\x03gte\x12\x0e\n" +
+	"\x02in\x18\x06 \x03(\x03R\x02in\x12!\n" +
+	"\fignore_empty\x18\b \x01(\bR\vignoreEmpty\"\xae\x01\n" +
+	"\vStringRules\x12\x17\n" +
+	"\amin_len\x18\x02 \x01(\x04R\x06minLen\x12\x17\n" +
+	"\amax_len\x18\x03 \x01(\x04R\x06maxLen\x12\x0e\n" +
+	"\x02in\x18\n" +
+	" \x03(\tR\x02in\x12\x16\n" +
+	"\x05email\x18\f \x01(\bH\x00R\x05email\x12\x14\n" +
+	"\x04uuid\x18\x16 \x01(\bH\x00R\x04uuid\x12!\n" +
+	"\fignore_empty\x18\x1a \x01(\bR\vignoreEmptyB\f\n" +
+	"\n" +
+	"well_known\"\xb0\x01\n" +
+	"\rRepeatedRules\x12\x1b\n" +
+	"\tmin_items\x18\x01 \x01(\x04R\bminItems\x12\x1b\n" +
+	"\tmax_items\x18\x02 \x01(\x04R\bmaxItems\x12\x16\n" +
+	"\x06unique\x18\x03 \x01(\bR\x06unique\x12*\n" +
+	"\x05items\x18\x04 \x01(\v2\x14.validate.FieldRulesR\x05items\x12!\n" +
+	"\fignore_empty\x18\x05 \x01(\bR\vignoreEmpty\">\n" +
+	"\fMessageRules\x12\x12\n" +
+	"\x04skip\x18\x01 \x01(\bR\x04skip\x12\x1a\n" +
+	"\brequired\x18\x02 \x01(\bR\brequired:J\n" +
+	"\x05rules\x12\x1d.google.protobuf.FieldOptions\x18\xaf\b \x01(\v2\x14.validate.FieldRulesR\x05rulesBVZTgithub.com/vagonaizer/authenitfication-service/api/proto/generated/validate;validate"
+
+var (
+	file_validate_validate_proto_rawDescOnce sync.Once
+	file_validate_validate_proto_rawDescData []byte
+)
+
+func file_validate_validate_proto_rawDescGZIP() []byte {
+	file_validate_validate_proto_rawDescOnce.Do(func() {
+		file_validate_validate_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_validate_validate_proto_rawDesc), len(file_validate_validate_proto_rawDesc)))
+	})
+	return file_validate_validate_proto_rawDescData
+}
+
+var file_validate_validate_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_validate_validate_proto_goTypes = []any{
+	(*FieldRules)(nil),                // 0: validate.FieldRules
+	(*Int32Rules)(nil),                // 1: validate.Int32Rules
+	(*Int64Rules)(nil),                // 2: validate.Int64Rules
+	(*StringRules)(nil),               // 3: validate.StringRules
+	(*RepeatedRules)(nil),             // 4: validate.RepeatedRules
+	(*MessageRules)(nil),              // 5: validate.MessageRules
+	(*descriptorpb.FieldOptions)(nil), // 6: google.protobuf.FieldOptions
+}
+var file_validate_validate_proto_depIdxs = []int32{
+	5, // 0: validate.FieldRules.message:type_name -> validate.MessageRules
+	1, // 1: validate.FieldRules.int32:type_name -> validate.Int32Rules
+	2, // 2: validate.FieldRules.int64:type_name -> validate.Int64Rules
+	3, // 3: validate.FieldRules.string:type_name -> validate.StringRules
+	4, // 4: validate.FieldRules.repeated:type_name -> validate.RepeatedRules
+	0, // 5: validate.RepeatedRules.items:type_name -> validate.FieldRules
+	6, // 6: validate.rules:extendee -> google.protobuf.FieldOptions
+	0, // 7: validate.rules:type_name -> validate.FieldRules
+	8, // [8:8] is the sub-list for method output_type
+	8, // [8:8] is the sub-list for method input_type
+	7, // [7:8] is the sub-list for extension type_name
+	6, // [6:7] is the sub-list for extension extendee
+	0, // [0:6] is the sub-list for field type_name
+}
+
+func init() { file_validate_validate_proto_init() }
+func file_validate_validate_proto_init() {
+	if File_validate_validate_proto != nil {
+		return
+	}
+	file_validate_validate_proto_msgTypes[0].OneofWrappers = []any{
+		(*FieldRules_Int32)(nil),
+		(*FieldRules_Int64)(nil),
+		(*FieldRules_String_)(nil),
+		(*FieldRules_Repeated)(nil),
+	}
+	file_validate_validate_proto_msgTypes[3].OneofWrappers = []any{
+		(*StringRules_Email)(nil),
+		(*StringRules_Uuid)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_validate_validate_proto_rawDesc), len(file_validate_validate_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   6,
+			NumExtensions: 1,
+			NumServices:   0,
+		},
+		GoTypes:           file_validate_validate_proto_goTypes,
+		DependencyIndexes: file_validate_validate_proto_depIdxs,
+		MessageInfos:      file_validate_validate_proto_msgTypes,
+		ExtensionInfos:    file_validate_validate_proto_extTypes,
+	}.Build()
+	File_validate_validate_proto = out.File
+	file_validate_validate_proto_goTypes = nil
+	file_validate_validate_proto_depIdxs = nil
+}
@@ -0,0 +1,98 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"flag"
+	"log"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	kafkago "github.com/segmentio/kafka-go"
+	"github.com/vagonaizer/authenitfication-service/internal/config"
+	"github.com/vagonaizer/authenitfication-service/internal/infrastructure/messaging"
+	"github.com/vagonaizer/authenitfication-service/internal/infrastructure/messaging/kafka"
+)
+
+func main() {
+	var (
+		topic  = flag.String("topic", "", "Source topic whose dead letters to replay, e.g. moderation.ban_requested")
+		limit  = flag.Int("limit", 0, "Replay at most this many messages (0 replays all)")
+		idle   = flag.Duration("idle", 10*time.Second, "Stop once no message arrived for this long")
+		dryRun = flag.Bool("dry-run", false, "List dead letters without replaying or committing them")
+	)
+	flag.Parse()
+
+	if *topic == "" {
+		log.Fatal("-topic is required")
+	}
+
+	// Загружаем .env файл
+	loadEnvFile()
+
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatalf("Failed to load config: %v", err)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	dlqTopic := messaging.DeadLetterTopic(strings.TrimSuffix(*topic, messaging.DeadLetterSuffix))
+	log.Printf("Reading dead letters from %s", dlqTopic)
+
+	opts := kafka.ReplayOptions{Limit: *limit, Idle: *idle, DryRun: *dryRun}
+	replayed, err := kafka.Replay(ctx, &cfg.Kafka, dlqTopic, opts, func(message kafkago.Message) {
+		log.Printf("offset=%d key=%s failed_at=%s attempts=%s error=%q",
+			message.Offset, message.Key,
+			kafka.Header(message, messaging.HeaderFailedAt),
+			kafka.Header(message, messaging.HeaderAttempts),
+			kafka.Header(message, messaging.HeaderError))
+	})
+	if err != nil {
+		log.Fatalf("Replay failed after %d messages: %v", replayed, err)
+	}
+
+	if *dryRun {
+		log.Printf("Found %d dead letters", replayed)
+	} else {
+		log.Printf("Replayed %d dead letters", replayed)
+	}
+}
+
+func loadEnvFile() {
+	file, err := os.Open(".env")
+	if err != nil {
+		log.Printf("Warning: .env file not found: %v", err)
+		return
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		parts := strings.SplitN(line, "=", 2)
+		if len(parts) != 2 {
+			continue
+		}
+
+		key := strings.TrimSpace(parts[0])
+		value := strings.TrimSpace(parts[1])
+
+		// Устанавливаем переменную окружения только если она еще не установлена
+		if os.Getenv(key) == "" {
+			os.Setenv(key, value)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		log.Printf("Error reading .env file: %v", err)
+	}
+}
@@ -0,0 +1,146 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"flag"
+	"log"
+	"math"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/vagonaizer/authenitfication-service/internal/config"
+	"github.com/vagonaizer/authenitfication-service/internal/dto/request"
+	"github.com/vagonaizer/authenitfication-service/internal/infrastructure/database/postgres"
+	postgresrepos "github.com/vagonaizer/authenitfication-service/internal/infrastructure/database/postgres/repositories"
+	"github.com/vagonaizer/authenitfication-service/internal/infrastructure/messaging/driver"
+	"github.com/vagonaizer/authenitfication-service/internal/services"
+	"github.com/vagonaizer/authenitfication-service/pkg/logger"
+)
+
+func main() {
+	var (
+		from   = flag.String("from", "", "Replay events logged at or after this time (RFC 3339)")
+		to     = flag.String("to", "", "Replay events logged before this time (RFC 3339, default now)")
+		user   = flag.String("user", "", "Replay only the events of this user ID")
+		topic  = flag.String("topic", "", "Replay only the events of this topic")
+		limit  = flag.Int("limit", 0, "Replay at most this many events (0 replays all)")
+		dryRun = flag.Bool("dry-run", false, "Count matching events without replaying them")
+	)
+	flag.Parse()
+
+	req := &request.ReplayEventsRequest{
+		Topic:  *topic,
+		Limit:  *limit,
+		DryRun: *dryRun,
+	}
+
+	var err error
+	if *from == "" {
+		log.Fatal("-from is required")
+	}
+	if req.From, err = time.Parse(time.RFC3339, *from); err != nil {
+		log.Fatalf("Invalid -from: %v", err)
+	}
+	if *to != "" {
+		toTime, err := time.Parse(time.RFC3339, *to)
+		if err != nil {
+			log.Fatalf("Invalid -to: %v", err)
+		}
+		req.To = &toTime
+	}
+	if *user != "" {
+		userID, err := uuid.Parse(*user)
+		if err != nil {
+			log.Fatalf("Invalid -user: %v", err)
+		}
+		req.UserID = &userID
+	}
+
+	// Загружаем .env файл
+	loadEnvFile()
+
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatalf("Failed to load config: %v", err)
+	}
+
+	appLog := logger.New(
+		cfg.Logger.Level,
+		cfg.Logger.Format,
+		cfg.Logger.Output,
+		cfg.Logger.MaxSize,
+		cfg.Logger.MaxBackups,
+		cfg.Logger.MaxAge,
+		cfg.Logger.Compress,
+	)
+
+	db, err := postgres.NewConnection(&cfg.Database)
+	if err != nil {
+		log.Fatalf("Failed to connect to database: %v", err)
+	}
+	defer db.Close()
+
+	publisher, _, err := driver.New(cfg, appLog)
+	if err != nil {
+		log.Fatalf("Failed to initialize message broker: %v", err)
+	}
+	defer publisher.Close()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	// Ограничение EVENT_LOG_REPLAY_MAX_EVENTS относится только к API
+	replay := services.NewEventReplayService(postgresrepos.NewEventLogRepository(db), publisher, appLog, math.MaxInt)
+	result, err := replay.ReplayEvents(ctx, req)
+	if err != nil {
+		log.Fatalf("Replay failed: %v", err)
+	}
+
+	if result.DryRun {
+		log.Printf("Found %d events", result.Replayed)
+	} else {
+		log.Printf("Replayed %d events", result.Replayed)
+	}
+	if result.Truncated {
+		log.Printf("More events match; continue with -from=%s", result.LastEventAt.Format(time.RFC3339Nano))
+	}
+}
+
+func loadEnvFile() {
+	file, err := os.Open(".env")
+	if err != nil {
+		log.Printf("Warning: .env file not found: %v", err)
+		return
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		parts := strings.SplitN(line, "=", 2)
+		if len(parts) != 2 {
+			continue
+		}
+
+		key := strings.TrimSpace(parts[0])
+		value := strings.TrimSpace(parts[1])
+
+		// Устанавливаем переменную окружения только если она еще не установлена
+		if os.Getenv(key) == "" {
+			os.Setenv(key, value)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		log.Printf("Error reading .env file: %v", err)
+	}
+}
@@ -2,31 +2,27 @@ package main
 
 import (
 	"bufio"
+	"context"
 	"database/sql"
 	"flag"
 	"fmt"
 	"io/fs"
 	"log"
 	"os"
-	"path/filepath"
-	"sort"
 	"strings"
 
-	_ "github.com/lib/pq"
+	_ "github.com/jackc/pgx/v5/stdlib"
 	"github.com/vagonaizer/authenitfication-service/internal/config"
+	"github.com/vagonaizer/authenitfication-service/internal/infrastructure/database/postgres/migrator"
 )
 
-type Migration struct {
-	Version  int
-	Name     string
-	Filename string
-	Content  string
-}
-
 func main() {
 	var (
 		direction = flag.String("direction", "up", "Migration direction: up or down")
 		status    = flag.Bool("status", false, "Show migration status")
+		dryRun    = flag.Bool("dry-run", false, "Print the migrations that would run and their SQL without changing the schema")
+		validate  = flag.Bool("validate", false, "With -dry-run, execute pending migrations in a transaction that is rolled back")
+		force     = flag.Bool("force", false, "Warn instead of failing when an applied migration file was modified")
 	)
 	flag.Parse()
 
@@ -48,28 +44,40 @@ func main() {
 	}
 	defer db.Close()
 
-	if err := createMigrationsTable(db); err != nil {
-		log.Fatalf("Failed to create migrations table: %v", err)
+	ctx := context.Background()
+
+	if *dryRun {
+		if err := dryRunMigrations(ctx, db, migrator.Source(cfg.Database.MigrationsPath), *direction, *validate); err != nil {
+			log.Fatalf("Dry run failed: %v", err)
+		}
+		return
 	}
 
 	if *status {
-		if err := showStatus(db, cfg.Database.MigrationsPath); err != nil {
+		if err := showStatus(ctx, db, migrator.Source(cfg.Database.MigrationsPath)); err != nil {
 			log.Fatalf("Failed to show status: %v", err)
 		}
 		return
 	}
 
+	// Несколько реплик могут запустить миграции одновременно
+	unlock, err := migrator.Lock(ctx, db, log.Printf)
+	if err != nil {
+		log.Fatalf("Failed to lock migrations: %v", err)
+	}
+
 	switch *direction {
 	case "up":
-		if err := migrateUp(db, cfg.Database.MigrationsPath); err != nil {
-			log.Fatalf("Migration up failed: %v", err)
-		}
+		err = migrator.Up(ctx, db, migrator.Source(cfg.Database.MigrationsPath), *force, log.Printf)
 	case "down":
-		if err := migrateDown(db, cfg.Database.MigrationsPath); err != nil {
-			log.Fatalf("Migration down failed: %v", err)
-		}
+		err = migrator.Down(ctx, db, log.Printf)
 	default:
-		log.Fatalf("Invalid direction: %s. Use 'up' or 'down'", *direction)
+		err = fmt.Errorf("invalid direction: %s. Use 'up' or 'down'", *direction)
+	}
+	unlock()
+
+	if err != nil {
+		log.Fatalf("Migration %s failed: %v", *direction, err)
 	}
 }
 
@@ -127,7 +135,7 @@ func connectDB(cfg *config.Config) (*sql.DB, error) {
 	log.Printf("DSN (without password): host=%s port=%s user=%s dbname=%s sslmode=%s",
 		host, cfg.Database.Port, cfg.Database.User, cfg.Database.Name, cfg.Database.SSLMode)
 
-	db, err := sql.Open("postgres", dsn)
+	db, err := sql.Open("pgx", dsn)
 	if err != nil {
 		return nil, err
 	}
@@ -139,190 +147,94 @@ func connectDB(cfg *config.Config) (*sql.DB, error) {
 	return db, nil
 }
 
-func createMigrationsTable(db *sql.DB) error {
-	query := `
-		CREATE TABLE IF NOT EXISTS schema_migrations (
-			version INTEGER PRIMARY KEY,
-			applied_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
-		)
-	`
-	_, err := db.Exec(query)
-	return err
-}
-
-func loadMigrations(migrationsPath string) ([]Migration, error) {
-	var migrations []Migration
-
-	err := filepath.WalkDir(migrationsPath, func(path string, d fs.DirEntry, err error) error {
-		if err != nil {
-			return err
-		}
-
-		if d.IsDir() || !strings.HasSuffix(path, ".sql") {
-			return nil
-		}
-
-		filename := d.Name()
-		parts := strings.SplitN(filename, "_", 2)
-		if len(parts) != 2 {
-			return fmt.Errorf("invalid migration filename format: %s", filename)
-		}
-
-		var version int
-		if _, err := fmt.Sscanf(parts[0], "%d", &version); err != nil {
-			return fmt.Errorf("invalid version in filename %s: %v", filename, err)
-		}
-
-		content, err := os.ReadFile(path)
-		if err != nil {
-			return fmt.Errorf("failed to read migration file %s: %v", path, err)
-		}
-
-		name := strings.TrimSuffix(parts[1], ".sql")
-		migrations = append(migrations, Migration{
-			Version:  version,
-			Name:     name,
-			Filename: filename,
-			Content:  string(content),
-		})
-
-		return nil
-	})
-
-	if err != nil {
-		return nil, err
-	}
-
-	// Sort migrations by version
-	sort.Slice(migrations, func(i, j int) bool {
-		return migrations[i].Version < migrations[j].Version
-	})
-
-	return migrations, nil
-}
-
-func getAppliedMigrations(db *sql.DB) (map[int]bool, error) {
+// dryRunMigrations prints what migrateUp or migrateDown would do. It never
+// creates the migrations table, and with validate the pending migrations are
+// executed in a single transaction that is always rolled back.
+func dryRunMigrations(ctx context.Context, db *sql.DB, source fs.FS, direction string, validate bool) error {
+	// Без таблицы миграций считаем, что ни одна миграция не применена
 	applied := make(map[int]bool)
-
-	rows, err := db.Query("SELECT version FROM schema_migrations ORDER BY version")
-	if err != nil {
-		return nil, err
-	}
-	defer rows.Close()
-
-	for rows.Next() {
-		var version int
-		if err := rows.Scan(&version); err != nil {
-			return nil, err
-		}
-		applied[version] = true
-	}
-
-	return applied, rows.Err()
-}
-
-func migrateUp(db *sql.DB, migrationsPath string) error {
-	migrations, err := loadMigrations(migrationsPath)
-	if err != nil {
-		return err
-	}
-
-	applied, err := getAppliedMigrations(db)
+	exists, err := migrator.MigrationsTableExists(ctx, db)
 	if err != nil {
 		return err
 	}
-
-	var executed int
-	for _, migration := range migrations {
-		if applied[migration.Version] {
-			continue
+	if exists {
+		if applied, err = migrator.AppliedMigrations(ctx, db); err != nil {
+			return err
 		}
+	}
 
-		log.Printf("Applying migration %d: %s", migration.Version, migration.Name)
-
-		tx, err := db.Begin()
+	switch direction {
+	case "up":
+		migrations, err := migrator.PendingMigrations(source, applied)
 		if err != nil {
-			return fmt.Errorf("failed to begin transaction: %v", err)
+			return err
 		}
 
-		if _, err := tx.Exec(migration.Content); err != nil {
-			tx.Rollback()
-			return fmt.Errorf("failed to execute migration %d: %v", migration.Version, err)
+		if len(migrations) == 0 {
+			fmt.Println("No migrations to apply")
+			return nil
 		}
 
-		if _, err := tx.Exec("INSERT INTO schema_migrations (version) VALUES ($1)", migration.Version); err != nil {
-			tx.Rollback()
-			return fmt.Errorf("failed to record migration %d: %v", migration.Version, err)
+		fmt.Printf("%d migrations would be applied:\n", len(migrations))
+		for _, migration := range migrations {
+			fmt.Printf("\n-- %03d %s (%s)\n", migration.Version, migration.Name, migration.Filename)
+			fmt.Println(strings.TrimSpace(migration.Content))
 		}
 
-		if err := tx.Commit(); err != nil {
-			return fmt.Errorf("failed to commit migration %d: %v", migration.Version, err)
+		if validate {
+			return validateMigrations(ctx, db, migrations)
+		}
+	case "down":
+		latestVersion := migrator.LatestApplied(applied)
+		if latestVersion == 0 {
+			fmt.Println("No migrations to rollback")
+			return nil
 		}
 
-		executed++
-		log.Printf("Migration %d applied successfully", migration.Version)
-	}
-
-	if executed == 0 {
-		log.Println("No migrations to apply")
-	} else {
-		log.Printf("Applied %d migrations", executed)
+		fmt.Printf("Migration %d would be rolled back\n", latestVersion)
+		fmt.Println("Note: Only the migration record is removed; no SQL is executed.")
+	default:
+		return fmt.Errorf("invalid direction: %s. Use 'up' or 'down'", direction)
 	}
 
 	return nil
 }
 
-func migrateDown(db *sql.DB, migrationsPath string) error {
-	applied, err := getAppliedMigrations(db)
-	if err != nil {
-		return err
-	}
-
-	if len(applied) == 0 {
-		log.Println("No migrations to rollback")
-		return nil
-	}
-
-	// Find the latest applied migration
-	var latestVersion int
-	for version := range applied {
-		if version > latestVersion {
-			latestVersion = version
-		}
-	}
-
-	log.Printf("Rolling back migration %d", latestVersion)
-
-	tx, err := db.Begin()
+// validateMigrations runs migrations in order inside one transaction, so each
+// one sees the schema left by the previous, then rolls everything back.
+func validateMigrations(ctx context.Context, db *sql.DB, migrations []migrator.Migration) error {
+	tx, err := db.BeginTx(ctx, nil)
 	if err != nil {
 		return fmt.Errorf("failed to begin transaction: %v", err)
 	}
+	defer tx.Rollback()
 
-	if _, err := tx.Exec("DELETE FROM schema_migrations WHERE version = $1", latestVersion); err != nil {
-		tx.Rollback()
-		return fmt.Errorf("failed to remove migration record %d: %v", latestVersion, err)
-	}
-
-	if err := tx.Commit(); err != nil {
-		return fmt.Errorf("failed to commit rollback %d: %v", latestVersion, err)
+	for _, migration := range migrations {
+		if _, err := tx.ExecContext(ctx, migration.Content); err != nil {
+			return fmt.Errorf("migration %d is invalid: %v", migration.Version, err)
+		}
 	}
 
-	log.Printf("Migration %d rolled back successfully", latestVersion)
-	log.Println("Note: This tool only removes the migration record. Manual schema changes may be required.")
-
+	fmt.Printf("\nValidated %d migrations; all changes were rolled back\n", len(migrations))
 	return nil
 }
 
-func showStatus(db *sql.DB, migrationsPath string) error {
-	migrations, err := loadMigrations(migrationsPath)
+func showStatus(ctx context.Context, db *sql.DB, source fs.FS) error {
+	migrations, err := migrator.LoadMigrations(source)
 	if err != nil {
 		return err
 	}
 
-	applied, err := getAppliedMigrations(db)
+	applied := make(map[int]bool)
+	exists, err := migrator.MigrationsTableExists(ctx, db)
 	if err != nil {
 		return err
 	}
+	if exists {
+		if applied, err = migrator.AppliedMigrations(ctx, db); err != nil {
+			return err
+		}
+	}
 
 	fmt.Println("Migration Status:")
 	fmt.Println("================")
@@ -0,0 +1,157 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/vagonaizer/authenitfication-service/internal/config"
+	"github.com/vagonaizer/authenitfication-service/internal/infrastructure/database/postgres"
+	postgresrepos "github.com/vagonaizer/authenitfication-service/internal/infrastructure/database/postgres/repositories"
+	"github.com/vagonaizer/authenitfication-service/internal/infrastructure/database/redis"
+	"github.com/vagonaizer/authenitfication-service/internal/infrastructure/messaging"
+	"github.com/vagonaizer/authenitfication-service/internal/infrastructure/messaging/driver"
+	"github.com/vagonaizer/authenitfication-service/internal/infrastructure/metrics"
+	"github.com/vagonaizer/authenitfication-service/pkg/logger"
+)
+
+// leaderLockKey identifies the advisory lock of the relay leader, and
+// leaderLockName its Redis lock.
+const (
+	leaderLockKey  int64 = 4_021_887_302
+	leaderLockName       = "outbox_relay"
+)
+
+var (
+	version   = "dev"
+	buildTime = "unknown"
+)
+
+func main() {
+	log.Printf("Outbox relay %s (built at %s)", version, buildTime)
+
+	// Загружаем .env файл
+	loadEnvFile()
+
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatalf("Failed to load config: %v", err)
+	}
+
+	appLog := logger.New(
+		cfg.Logger.Level,
+		cfg.Logger.Format,
+		cfg.Logger.Output,
+		cfg.Logger.MaxSize,
+		cfg.Logger.MaxBackups,
+		cfg.Logger.MaxAge,
+		cfg.Logger.Compress,
+	)
+
+	db, err := postgres.NewConnection(&cfg.Database)
+	if err != nil {
+		log.Fatalf("Failed to connect to database: %v", err)
+	}
+	defer db.Close()
+
+	var lock messaging.LeaderLock
+	switch cfg.Outbox.LeaderLock {
+	case "postgres":
+		lock = postgres.NewAdvisoryLock(db, leaderLockKey)
+	case "redis":
+		redisClient, err := redis.NewConnection(&cfg.Redis)
+		if err != nil {
+			log.Fatalf("Failed to connect to redis: %v", err)
+		}
+		defer redisClient.Close()
+		lock = redis.NewLock(redisClient, leaderLockName, cfg.Outbox.LeaderLockTTL)
+	default:
+		log.Fatalf("Unknown outbox leader lock: %s", cfg.Outbox.LeaderLock)
+	}
+
+	publisher, _, err := driver.New(cfg, appLog)
+	if err != nil {
+		log.Fatalf("Failed to initialize message broker: %v", err)
+	}
+	defer publisher.Close()
+
+	// С outbox журнал событий ведёт relay, а не API
+	if cfg.EventLog.Enabled {
+		publisher = messaging.NewLoggingPublisher(publisher, postgresrepos.NewEventLogRepository(db), appLog)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", metrics.Handler())
+	metricsServer := &http.Server{
+		Addr:              cfg.Outbox.RelayMetricsAddr,
+		Handler:           mux,
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+	go func() {
+		if err := metricsServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			appLog.WithError(err).Error("metrics server stopped")
+		}
+	}()
+
+	relay := messaging.NewOutboxRelay(
+		postgresrepos.NewOutboxRepository(db),
+		publisher,
+		lock,
+		appLog,
+		cfg.Outbox.BatchSize,
+		cfg.Outbox.PollInterval,
+		cfg.Outbox.LeaderRetryInterval,
+	)
+
+	appLog.WithField("metrics_addr", cfg.Outbox.RelayMetricsAddr).Info("outbox relay started")
+	relay.Run(ctx)
+	appLog.Info("outbox relay stopped")
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	_ = metricsServer.Shutdown(shutdownCtx)
+}
+
+func loadEnvFile() {
+	file, err := os.Open(".env")
+	if err != nil {
+		log.Printf("Warning: .env file not found: %v", err)
+		return
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		parts := strings.SplitN(line, "=", 2)
+		if len(parts) != 2 {
+			continue
+		}
+
+		key := strings.TrimSpace(parts[0])
+		value := strings.TrimSpace(parts[1])
+
+		// Устанавливаем переменную окружения только если она еще не установлена
+		if os.Getenv(key) == "" {
+			os.Setenv(key, value)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		log.Printf("Error reading .env file: %v", err)
+	}
+}
@@ -3,6 +3,9 @@ package main
 import (
 	"log"
 	"os"
+	// Timezone preferences are validated against the IANA database, which
+	// minimal images do not ship.
+	_ "time/tzdata"
 
 	"github.com/vagonaizer/authenitfication-service/internal/app"
 )
@@ -17,7 +20,7 @@ func main() {
 	log.Printf("Auth Service %s (built at %s)", version, buildTime)
 
 	// Initialize application
-	application, err := app.NewApp()
+	application, err := app.NewApp(version, buildTime)
 	if err != nil {
 		log.Fatalf("Failed to initialize application: %v", err)
 	}
@@ -7,34 +7,58 @@ import (
 	"os/signal"
 	"sync"
 	"syscall"
+	"time"
 
+	"github.com/jackc/pgx/v5/stdlib"
 	"github.com/vagonaizer/authenitfication-service/internal/config"
+	"github.com/vagonaizer/authenitfication-service/internal/domain/repositories"
+	domainservices "github.com/vagonaizer/authenitfication-service/internal/domain/services"
+	"github.com/vagonaizer/authenitfication-service/internal/infrastructure/authz"
 	"github.com/vagonaizer/authenitfication-service/internal/infrastructure/database/postgres"
+	"github.com/vagonaizer/authenitfication-service/internal/infrastructure/database/postgres/migrator"
 	postgresrepos "github.com/vagonaizer/authenitfication-service/internal/infrastructure/database/postgres/repositories"
 	"github.com/vagonaizer/authenitfication-service/internal/infrastructure/database/redis"
+	"github.com/vagonaizer/authenitfication-service/internal/infrastructure/messaging"
+	"github.com/vagonaizer/authenitfication-service/internal/infrastructure/messaging/driver"
 	"github.com/vagonaizer/authenitfication-service/internal/infrastructure/messaging/kafka"
+	"github.com/vagonaizer/authenitfication-service/internal/infrastructure/storage"
 	"github.com/vagonaizer/authenitfication-service/internal/services"
+	"github.com/vagonaizer/authenitfication-service/internal/transport/gateway"
+	"github.com/vagonaizer/authenitfication-service/internal/transport/graphql"
 	grpcserver "github.com/vagonaizer/authenitfication-service/internal/transport/grpc"
 	grpchandlers "github.com/vagonaizer/authenitfication-service/internal/transport/grpc/handlers"
 	grpcinterceptors "github.com/vagonaizer/authenitfication-service/internal/transport/grpc/interceptors"
 	httpserver "github.com/vagonaizer/authenitfication-service/internal/transport/http"
 	httphandlers "github.com/vagonaizer/authenitfication-service/internal/transport/http/handlers"
 	httpmiddleware "github.com/vagonaizer/authenitfication-service/internal/transport/http/middleware"
+	"github.com/vagonaizer/authenitfication-service/internal/transport/pprof"
 	"github.com/vagonaizer/authenitfication-service/pkg/auth"
+	"github.com/vagonaizer/authenitfication-service/pkg/i18n"
 	"github.com/vagonaizer/authenitfication-service/pkg/logger"
+	"github.com/vagonaizer/authenitfication-service/pkg/utils"
 )
 
 type App struct {
-	cfg        *config.Config
-	logger     *logger.Logger
-	db         *postgres.DB
-	redis      *redis.Client
-	producer   *kafka.Producer
-	httpServer *httpserver.Server
-	grpcServer *grpcserver.Server
+	cfg         *config.Config
+	logger      *logger.Logger
+	db          *postgres.DB
+	redis       *redis.Client
+	invalidator *redis.Invalidator
+	userEvents  *redis.UserEventHub
+	producer    messaging.Publisher
+	casbin      *authz.CasbinAuthorizer
+	jobs        *JobRunner
+	consumers   *ConsumerGroup
+	health      *httphandlers.HealthHandler
+	httpServer  *httpserver.Server
+	grpcServer  *grpcserver.Server
+	gateway     *gateway.Server
+	pprof       *pprof.Server
 }
 
-func NewApp() (*App, error) {
+// NewApp wires the service together; version and buildTime identify the
+// build on /health.
+func NewApp(version, buildTime string) (*App, error) {
 	// Load configuration
 	cfg, err := config.Load()
 	if err != nil {
@@ -53,24 +77,118 @@ func NewApp() (*App, error) {
 	)
 
 	// Initialize database
-	db, err := postgres.NewConnection(&cfg.Database)
+	var db *postgres.DB
+	err = connectWithRetry(cfg.Startup, log, "database", func() error {
+		db, err = postgres.NewConnection(&cfg.Database)
+		return err
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to database: %w", err)
 	}
 
+	// Apply pending migrations before anything touches the schema
+	if cfg.Database.AutoMigrate {
+		if err := migrate(db, cfg.Database.MigrationsPath, log); err != nil {
+			return nil, fmt.Errorf("failed to run migrations: %w", err)
+		}
+	}
+
 	// Initialize Redis
-	redisClient, err := redis.NewConnection(&cfg.Redis)
+	var redisClient *redis.Client
+	err = connectWithRetry(cfg.Startup, log, "redis", func() error {
+		redisClient, err = redis.NewConnection(&cfg.Redis)
+		return err
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to redis: %w", err)
 	}
 
-	// Initialize Kafka producer
-	producer := kafka.NewProducer(&cfg.Kafka, log)
+	// Initialize message broker. Events are best effort, so an unreachable
+	// broker only delays startup by up to the startup wait.
+	producer, subscriber, err := driver.New(cfg, log)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize message broker: %w", err)
+	}
+	err = connectWithRetry(cfg.Startup, log, "message broker", func() error {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		return producer.Ping(ctx)
+	})
+	if err != nil {
+		log.WithError(err).Warn("message broker is unreachable, events will not be published until it recovers")
+	}
+
+	// Create missing topics so that the first events of a fresh environment
+	// are not lost. Skipped while the broker is unreachable.
+	if err == nil && cfg.Kafka.CreateTopics && (cfg.Messaging.Driver == "kafka" || cfg.Messaging.Driver == "") {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		err := kafka.EnsureTopics(ctx, &cfg.Kafka, kafka.RequiredTopics(), log)
+		cancel()
+		if err != nil {
+			return nil, fmt.Errorf("failed to create kafka topics: %w", err)
+		}
+	}
+
+	// Initialize cache
+	cache := redis.NewCacheService(redisClient)
+	invalidator := redis.NewInvalidator(redisClient, cfg.Redis.InvalidationChannel, log)
+	localCache := redis.NewLocalCache(cache, invalidator, cfg.Redis.LocalCacheTTL, cfg.Redis.LocalCacheMaxEntries)
 
 	// Initialize repositories
-	userRepo := postgresrepos.NewUserRepository(db)
-	sessionRepo := postgresrepos.NewSessionRepository(db)
-	roleRepo := postgresrepos.NewRoleRepository(db)
+	userRepo := services.NewCachedUserRepository(
+		postgresrepos.NewUserRepository(db),
+		localCache,
+		log,
+		cfg.Redis.UserCacheTTL,
+		cfg.Redis.UserExistsCacheTTL,
+	)
+	var sessionRepo repositories.SessionRepository
+	switch cfg.Sessions.Store {
+	case "postgres":
+		sessionRepo = postgresrepos.NewSessionRepository(db)
+	case "redis":
+		sessionRepo = redis.NewSessionRepository(redisClient)
+	default:
+		return nil, fmt.Errorf("unknown session store: %s", cfg.Sessions.Store)
+	}
+	roleRepo := services.NewCachedRoleRepository(
+		postgresrepos.NewRoleRepository(db),
+		localCache,
+		log,
+		cfg.Authz.UserRolesCacheTTL,
+	)
+	permissionRepo := postgresrepos.NewPermissionRepository(db)
+	orgRepo := postgresrepos.NewOrganizationRepository(db)
+	groupRepo := postgresrepos.NewGroupRepository(db)
+	roleAuditRepo := postgresrepos.NewRoleAuditRepository(db)
+	invitationRepo := postgresrepos.NewInvitationRepository(db)
+	serviceAccountRepo := postgresrepos.NewServiceAccountRepository(db)
+	activityRepo := postgresrepos.NewActivityRepository(db)
+	loginHistoryRepo := postgresrepos.NewLoginHistoryRepository(db)
+	noteRepo := postgresrepos.NewNoteRepository(db)
+	statsRepo := postgresrepos.NewStatsRepository(db)
+	eventLogRepo := postgresrepos.NewEventLogRepository(db)
+	processedEventRepo := postgresrepos.NewProcessedEventRepository(db)
+
+	outboxRepo := postgresrepos.NewOutboxRepository(db)
+
+	// Log published events so they can be replayed. Replays are published
+	// through the broker directly so they are not logged twice. With the
+	// outbox, events are published and logged by cmd/relay.
+	brokerPublisher := producer
+	switch {
+	case cfg.Outbox.Enabled:
+		producer = messaging.NewOutboxPublisher(producer, outboxRepo, log)
+	case cfg.EventLog.Enabled:
+		producer = messaging.NewLoggingPublisher(producer, eventLogRepo, log)
+	}
+
+	// Session and security events are also streamed live to their user
+	var userEvents *redis.UserEventHub
+	if cfg.EventStream.Enabled {
+		userEvents = redis.NewUserEventHub(redisClient, cfg.EventStream.Channel, log)
+		producer = messaging.NewStreamingPublisher(producer, userEvents, kafka.StreamedTopics, log)
+	}
 
 	// Initialize auth utilities
 	passwordHasher := auth.NewPasswordHasher()
@@ -79,63 +197,459 @@ func NewApp() (*App, error) {
 		cfg.JWT.RefreshTokenSecret,
 		cfg.JWT.Issuer,
 		cfg.JWT.Audience,
+		cfg.JWT.MultiTenancy,
 	)
 
+	// Initialize file storage
+	var fileStorage domainservices.FileStorage
+	switch cfg.Storage.Driver {
+	case "local", "":
+		fileStorage, err = storage.NewLocalStorage(cfg.Storage.LocalDir, cfg.Storage.PublicURL)
+	case "s3":
+		fileStorage, err = storage.NewS3Storage(storage.S3Config{
+			Endpoint:  cfg.Storage.S3Endpoint,
+			Region:    cfg.Storage.S3Region,
+			Bucket:    cfg.Storage.S3Bucket,
+			AccessKey: cfg.Storage.S3AccessKey,
+			SecretKey: cfg.Storage.S3SecretKey,
+			PathStyle: cfg.Storage.S3PathStyle,
+			PublicURL: cfg.Storage.PublicURL,
+		})
+	default:
+		err = fmt.Errorf("unknown storage driver: %s", cfg.Storage.Driver)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize storage: %w", err)
+	}
+
 	// Initialize services
+	notificationService := services.NewNotificationService(producer, log)
+	tokenRevocationService := services.NewTokenRevocationService(cache, log, cfg.JWT.AccessTokenExpiry)
+	avatarPolicy := services.AvatarPolicy{
+		MaxSize:      cfg.Storage.AvatarMaxSize,
+		AllowedTypes: cfg.Storage.AvatarAllowedTypes,
+	}
+	permissionService := services.NewPermissionService(permissionRepo, localCache, log, cfg.Authz.PermissionCacheTTL)
+
+	// Initialize authorization engine
+	var authorizer domainservices.Authorizer
+	var casbinAuthorizer *authz.CasbinAuthorizer
+	switch cfg.Authz.Engine {
+	case "casbin":
+		casbinAuthorizer, err = authz.NewCasbinAuthorizer(db, log, cfg.Authz.PolicyReloadInterval)
+		if err != nil {
+			return nil, fmt.Errorf("failed to initialize casbin: %w", err)
+		}
+		authorizer = casbinAuthorizer
+	case "rbac", "":
+		authorizer = services.NewPermissionAuthorizer(permissionService, userRepo, log)
+	default:
+		return nil, fmt.Errorf("unknown authorization engine: %s", cfg.Authz.Engine)
+	}
+
+	userService := services.NewUserService(
+		userRepo,
+		roleRepo,
+		roleAuditRepo,
+		sessionRepo,
+		tokenRevocationService,
+		authorizer,
+		activityRepo,
+		loginHistoryRepo,
+		noteRepo,
+		passwordHasher,
+		fileStorage,
+		avatarPolicy,
+		notificationService,
+		producer,
+		log,
+		cfg.Retention.DeletionDelay,
+	)
+
+	quotaService := services.NewQuotaService(
+		orgRepo,
+		sessionRepo,
+		cache,
+		producer,
+		log,
+		services.QuotaDefaults{
+			MaxMembers:         cfg.Org.DefaultMaxMembers,
+			MaxSessionsPerUser: cfg.Org.DefaultMaxSessionsPerUser,
+			TokensPerMinute:    cfg.Org.DefaultTokensPerMinute,
+		},
+		cfg.Org.QuotaCacheTTL,
+	)
+	orgService := services.NewOrganizationService(orgRepo, userRepo, invitationRepo, quotaService, producer, log, cfg.Org.InvitationTTL)
+
+	verificationService := services.NewVerificationService(
+		userRepo,
+		cache,
+		notificationService,
+		producer,
+		log,
+		cfg.Verification.TokenTTL,
+		cfg.Verification.PasswordResetTTL,
+		cfg.Verification.ResendLimit,
+		cfg.Verification.ResendWindow,
+		cfg.Verification.PhoneCodeTTL,
+		cfg.Verification.PhoneCodeAttempts,
+	)
+
+	// Без консьюмеров письмо отправляется при регистрации, как и раньше
+	consumersEnabled := cfg.Kafka.ConsumerEnabled && cfg.Messaging.Driver != "noop"
+	verificationFromEvent := cfg.Verification.SendFromEvent && consumersEnabled
+	if cfg.Verification.SendFromEvent && !consumersEnabled {
+		log.Warn("consumers are disabled, verification emails are sent during registration")
+	}
+
+	defaultRoleResolver := services.NewConfigDefaultRoleResolver(cfg.Authz.DefaultRoles, cfg.Authz.ClientDefaultRoles)
 	authService := services.NewAuthService(
 		userRepo,
 		sessionRepo,
 		roleRepo,
+		orgRepo,
+		groupRepo,
+		roleAuditRepo,
+		activityRepo,
+		loginHistoryRepo,
+		serviceAccountRepo,
+		orgService,
+		quotaService,
+		defaultRoleResolver,
+		permissionService,
+		authorizer,
+		verificationService,
+		tokenRevocationService,
 		passwordHasher,
 		jwtManager,
 		producer,
 		log,
 		cfg.JWT.AccessTokenExpiry,
 		cfg.JWT.RefreshTokenExpiry,
+		cfg.JWT.MaxPermissionClaims,
+		verificationFromEvent,
+	)
+
+	roleService := services.NewRoleService(roleRepo, permissionRepo, permissionService, producer, log)
+	groupService := services.NewGroupService(groupRepo, userRepo, roleRepo, producer, log)
+	serviceAccountService := services.NewServiceAccountService(userRepo, serviceAccountRepo, producer, log)
+	statsService := services.NewStatsService(statsRepo, sessionRepo, cache, log, cfg.Stats.CacheTTL)
+	eventReplayService := services.NewEventReplayService(eventLogRepo, brokerPublisher, log, cfg.EventLog.ReplayMaxEvents)
+
+	// Initialize background jobs
+	roleExpiry := services.NewRoleExpirySweeper(
+		roleRepo,
+		roleAuditRepo,
+		producer,
+		log,
+		cfg.Authz.RoleExpiryBatchSize,
+	)
+	banExpiry := services.NewBanExpirySweeper(
+		userRepo,
+		producer,
+		log,
+		cfg.Authz.BanExpiryBatchSize,
+	)
+	userPurge := services.NewUserPurgeSweeper(
+		userRepo,
+		sessionRepo,
+		tokenRevocationService,
+		fileStorage,
+		producer,
+		log,
+		cfg.Retention.PurgeAfter,
+		cfg.Retention.BatchSize,
+	)
+	eventLogCleanup := services.NewEventLogSweeper(
+		eventLogRepo,
+		log,
+		cfg.EventLog.Retention,
+		cfg.EventLog.BatchSize,
+	)
+	processedEventCleanup := services.NewProcessedEventSweeper(processedEventRepo, log)
+	loginHistoryCleanup := services.NewLoginHistorySweeper(
+		loginHistoryRepo,
+		log,
+		cfg.LoginHistory.Retention,
+		cfg.LoginHistory.BatchSize,
+	)
+	sessionCleanup := services.NewSessionCleanupSweeper(
+		sessionRepo,
+		log,
+		cfg.Sessions.CleanupBatchSize,
 	)
-	userService := services.NewUserService(userRepo, roleRepo, producer, log)
+
+	jobs := NewJobRunner(log, redisClient)
+	jobs.Register(Job{
+		Name:      "role_expiry",
+		Interval:  cfg.Authz.RoleExpirySweepInterval,
+		Singleton: true,
+		Run:       roleExpiry.Sweep,
+	})
+	jobs.Register(Job{
+		Name:      "ban_expiry",
+		Interval:  cfg.Authz.BanExpirySweepInterval,
+		Singleton: true,
+		Run:       banExpiry.Sweep,
+	})
+	jobs.Register(Job{
+		Name:      "user_purge",
+		Interval:  cfg.Retention.SweepInterval,
+		Singleton: true,
+		Run:       userPurge.Sweep,
+	})
+	jobs.Register(Job{
+		Name:      "session_cleanup",
+		Interval:  cfg.Sessions.CleanupInterval,
+		Jitter:    cfg.Sessions.CleanupJitter,
+		Singleton: true,
+		Run:       sessionCleanup.Sweep,
+	})
+	jobs.Register(Job{
+		Name:      "login_history_cleanup",
+		Interval:  cfg.LoginHistory.SweepInterval,
+		Singleton: true,
+		Run:       loginHistoryCleanup.Sweep,
+	})
+	jobs.Register(Job{
+		Name:      "event_log_cleanup",
+		Interval:  cfg.EventLog.SweepInterval,
+		Singleton: true,
+		Run:       eventLogCleanup.Sweep,
+	})
+	if cfg.Kafka.DedupStore == "postgres" {
+		jobs.Register(Job{
+			Name:      "processed_events_cleanup",
+			Interval:  time.Hour,
+			Singleton: true,
+			Run:       processedEventCleanup.Sweep,
+		})
+	}
+
+	// Initialize consumers
+	var dedup messaging.DedupStore
+	switch cfg.Kafka.DedupStore {
+	case "redis":
+		dedup = cache
+	case "postgres":
+		dedup = processedEventRepo
+	case "":
+	default:
+		return nil, fmt.Errorf("unknown dedup store: %s", cfg.Kafka.DedupStore)
+	}
+	consumers := NewConsumerGroup(subscriber, dedup, cfg.Kafka.DedupWindow, log)
+	if cfg.Kafka.ConsumerEnabled && cfg.Messaging.Driver == "noop" {
+		log.Warn("consumers are disabled with the noop messaging driver")
+	}
+	if consumersEnabled {
+		if err := kafka.CheckUpcasters(); err != nil {
+			return nil, fmt.Errorf("invalid event upcasters: %w", err)
+		}
+		inbound := services.NewInboundEventHandler(userService, userRepo, verificationService, log)
+		consumers.Handle(kafka.TopicModerationBanRequested, inbound.HandleModerationBan)
+		consumers.Handle(kafka.TopicUserDeletionRequested, inbound.HandleDeletionRequest)
+		if verificationFromEvent {
+			consumers.Handle(kafka.TopicUserRegistered, inbound.HandleUserRegistered)
+		}
+	}
+
+	// Error messages are translated for clients by both transports
+	translator, err := i18n.New(cfg.I18n.CatalogDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load i18n catalogs: %w", err)
+	}
 
 	// Initialize HTTP handlers
-	authHandler := httphandlers.NewAuthHandler(authService, log)
+	authHandler := httphandlers.NewAuthHandler(authService, log, cfg.LoginHistory.CountryHeader, cfg.JWT.AccessTokenCookie)
 	userHandler := httphandlers.NewUserHandler(userService, log)
-	healthHandler := httphandlers.NewHealthHandler(db, redisClient, log)
-	authMiddleware := httpmiddleware.NewAuthMiddleware(jwtManager, log)
+	roleHandler := httphandlers.NewRoleHandler(roleService, log)
+	orgHandler := httphandlers.NewOrganizationHandler(orgService, log)
+	groupHandler := httphandlers.NewGroupHandler(groupService, log)
+	serviceAccountHandler := httphandlers.NewServiceAccountHandler(serviceAccountService, log)
+	quotaHandler := httphandlers.NewQuotaHandler(quotaService, log)
+	verificationHandler := httphandlers.NewVerificationHandler(verificationService, log)
+	statsHandler := httphandlers.NewStatsHandler(statsService, log)
+	eventHandler := httphandlers.NewEventHandler(eventReplayService, log)
+
+	// The broker is optional: events are published on a best effort basis
+	healthChecks := []httphandlers.HealthCheck{
+		{Name: "database", Required: true, Check: db.Health},
+		{Name: "redis", Required: true, Check: redisClient.Health},
+	}
+	switch cfg.Messaging.Driver {
+	case "noop":
+	case "":
+		healthChecks = append(healthChecks, httphandlers.HealthCheck{Name: "kafka", Check: brokerPublisher.Ping})
+	default:
+		healthChecks = append(healthChecks, httphandlers.HealthCheck{Name: cfg.Messaging.Driver, Check: brokerPublisher.Ping})
+	}
+	healthHandler := httphandlers.NewHealthHandler(healthChecks, version, buildTime, log)
+
+	var graphqlHandler *httphandlers.GraphQLHandler
+	if cfg.Server.EnableGraphQL {
+		schema, err := graphql.NewSchema(userService, authService, authorizer, log)
+		if err != nil {
+			return nil, err
+		}
+		graphqlHandler = httphandlers.NewGraphQLHandler(schema, log)
+	}
+
+	var userEventHandler *httphandlers.UserEventHandler
+	var userEventStream domainservices.UserEventStream
+	if userEvents != nil {
+		userEventHandler = httphandlers.NewUserEventHandler(userEvents, cfg.EventStream.KeepAlive, log)
+		userEventStream = userEvents
+	}
+
+	authMiddleware := httpmiddleware.NewAuthMiddleware(jwtManager, authorizer, orgService, userService, groupService, tokenRevocationService, log)
+	orgMiddleware := httpmiddleware.NewOrganizationMiddleware(orgService, log)
 
 	// Initialize gRPC handlers
 	authGRPCHandler := grpchandlers.NewAuthGRPCHandler(authService, log)
-	userGRPCHandler := grpchandlers.NewUserGRPCHandler(userService, log)
-	authInterceptor := grpcinterceptors.NewAuthInterceptor(jwtManager, log)
+	userGRPCHandler := grpchandlers.NewUserGRPCHandler(userService, userEventStream, log)
+	roleGRPCHandler := grpchandlers.NewRoleGRPCHandler(roleService, log)
+	var extAuthzHandler *grpchandlers.ExtAuthzGRPCHandler
+	if cfg.Server.EnableExtAuthz {
+		extAuthzHandler = grpchandlers.NewExtAuthzGRPCHandler(authService, cfg.JWT.AccessTokenCookie, log)
+	}
+	authInterceptor := grpcinterceptors.NewAuthInterceptor(jwtManager, authorizer, tokenRevocationService, cfg.Server.GRPCTLSClientIdentities, log)
 	loggingInterceptor := grpcinterceptors.NewLoggingInterceptor(log)
+	tracingInterceptor := grpcinterceptors.NewTracingInterceptor()
+	localizationInterceptor := grpcinterceptors.NewLocalizationInterceptor(translator)
+	validationInterceptor := grpcinterceptors.NewValidationInterceptor()
+	metricsInterceptor := grpcinterceptors.NewMetricsInterceptor()
+	deadlineInterceptor := grpcinterceptors.NewDeadlineInterceptor(cfg.Server.GRPCDefaultTimeout, cfg.Server.GRPCMethodTimeouts)
+
+	// Client IPs, from X-Forwarded-For of trusted proxies only
+	clientIP, err := utils.NewClientIPResolver(cfg.Server.TrustedProxies)
+	if err != nil {
+		return nil, fmt.Errorf("invalid TRUSTED_PROXIES: %w", err)
+	}
+	clientIPInterceptor := grpcinterceptors.NewClientIPInterceptor(clientIP)
+
+	// Initialize rate limiting shared by the replicas
+	var rateLimitMiddleware *httpmiddleware.RateLimitMiddleware
+	var rateLimitInterceptor *grpcinterceptors.RateLimitInterceptor
+	if cfg.Server.EnableRateLimit {
+		switch cfg.Server.RateLimitStore {
+		case "memory":
+		case "redis":
+			limiter, err := redis.NewRateLimiter(redisClient, float64(cfg.Server.RateLimitRPS), cfg.Server.RateLimitBurst)
+			if err != nil {
+				return nil, fmt.Errorf("failed to initialize rate limiting: %w", err)
+			}
+			rateLimitMiddleware, err = httpmiddleware.NewRateLimitMiddleware(limiter, cfg.Server.RateLimitPolicies, jwtManager, cfg.Server.RateLimitPerUser, log)
+			if err != nil {
+				return nil, fmt.Errorf("failed to initialize rate limiting: %w", err)
+			}
+			rateLimitInterceptor, err = grpcinterceptors.NewRateLimitInterceptor(limiter, cfg.Server.RateLimitPolicies, jwtManager, cfg.Server.RateLimitPerUser, log)
+			if err != nil {
+				return nil, fmt.Errorf("failed to initialize rate limiting: %w", err)
+			}
+		default:
+			return nil, fmt.Errorf("unknown rate limit store: %s", cfg.Server.RateLimitStore)
+		}
+	}
 
 	// Initialize servers
 	httpSrv := httpserver.NewServer(
 		cfg,
 		authHandler,
 		userHandler,
+		roleHandler,
+		orgHandler,
+		groupHandler,
+		serviceAccountHandler,
+		quotaHandler,
+		verificationHandler,
+		statsHandler,
+		eventHandler,
 		healthHandler,
+		graphqlHandler,
+		userEventHandler,
 		authMiddleware,
+		orgMiddleware,
+		rateLimitMiddleware,
+		clientIP,
+		translator,
 		log,
 	)
 
+	grpcCreds, err := grpcserver.NewServerCredentials(&cfg.Server)
+	if err != nil {
+		return nil, err
+	}
+
 	grpcSrv := grpcserver.NewServer(
 		authGRPCHandler,
 		userGRPCHandler,
+		roleGRPCHandler,
+		extAuthzHandler,
 		authInterceptor,
 		loggingInterceptor,
+		tracingInterceptor,
+		localizationInterceptor,
+		rateLimitInterceptor,
+		clientIPInterceptor,
+		validationInterceptor,
+		metricsInterceptor,
+		deadlineInterceptor,
+		healthHandler.CheckReady,
+		cfg.Server.GRPCHealthInterval,
+		grpcCreds,
+		grpcserver.NewServerOptions(&cfg.Server),
 		log,
 	)
 
+	var gatewaySrv *gateway.Server
+	if cfg.Server.EnableGateway {
+		gatewaySrv, err = gateway.NewServer(cfg, log)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create gRPC gateway: %w", err)
+		}
+	}
+
+	var pprofSrv *pprof.Server
+	if cfg.Server.EnablePprof {
+		pprofSrv = pprof.NewServer(cfg, log)
+	}
+
 	return &App{
-		cfg:        cfg,
-		logger:     log,
-		db:         db,
-		redis:      redisClient,
-		producer:   producer,
-		httpServer: httpSrv,
-		grpcServer: grpcSrv,
+		cfg:         cfg,
+		logger:      log,
+		db:          db,
+		redis:       redisClient,
+		invalidator: invalidator,
+		userEvents:  userEvents,
+		producer:    producer,
+		casbin:      casbinAuthorizer,
+		jobs:        jobs,
+		consumers:   consumers,
+		health:      healthHandler,
+		httpServer:  httpSrv,
+		grpcServer:  grpcSrv,
+		gateway:     gatewaySrv,
+		pprof:       pprofSrv,
 	}, nil
 }
 
+// migrate runs the pending migrations under the migration advisory lock, so
+// replicas booting together apply them once.
+func migrate(db *postgres.DB, migrationsPath string, log *logger.Logger) error {
+	sqlDB := stdlib.OpenDBFromPool(db.Pool)
+	defer sqlDB.Close()
+
+	ctx := context.Background()
+
+	unlock, err := migrator.Lock(ctx, sqlDB, log.Infof)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	return migrator.Up(ctx, sqlDB, migrator.Source(migrationsPath), false, log.Infof)
+}
+
 func (a *App) Run() error {
 	a.logger.Info("starting application")
 
@@ -143,6 +657,20 @@ func (a *App) Run() error {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
+	// Start background jobs
+	a.jobs.Start(ctx)
+
+	// Receive cache invalidations from other replicas
+	go a.invalidator.Run(ctx)
+
+	// Receive the user events published by every replica
+	if a.userEvents != nil {
+		go a.userEvents.Run(ctx)
+	}
+
+	// Start consumers
+	a.consumers.Start(ctx)
+
 	// Start servers
 	var wg sync.WaitGroup
 
@@ -166,6 +694,30 @@ func (a *App) Run() error {
 		}
 	}()
 
+	// Start gRPC gateway
+	if a.gateway != nil {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := a.gateway.Start(); err != nil {
+				a.logger.WithError(err).Error("gRPC gateway error")
+				cancel()
+			}
+		}()
+	}
+
+	// Start profiling server
+	if a.pprof != nil {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := a.pprof.Start(); err != nil {
+				a.logger.WithError(err).Error("pprof server error")
+				cancel()
+			}
+		}()
+	}
+
 	// Wait for interrupt signal
 	sigChan := make(chan os.Signal, 1)
 	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
@@ -182,12 +734,29 @@ func (a *App) Run() error {
 	return a.shutdown()
 }
 
+// shutdown drains the replica: /ready fails for the drain delay while
+// requests are still served, then the servers stop accepting connections and
+// wait for in-flight requests. Only then are jobs, consumers and the
+// connections they and the requests used closed, flushing the events queued
+// by the producer.
 func (a *App) shutdown() error {
+	a.health.Drain()
+	a.grpcServer.Drain()
+	if a.cfg.Server.DrainDelay > 0 {
+		a.logger.Infof("draining for %s", a.cfg.Server.DrainDelay)
+		time.Sleep(a.cfg.Server.DrainDelay)
+	}
+
 	ctx, cancel := context.WithTimeout(context.Background(), a.cfg.Server.ShutdownTimeout)
 	defer cancel()
 
 	var wg sync.WaitGroup
-	errChan := make(chan error, 3)
+	errChan := make(chan error, 5)
+
+	// Open event streams would hold up the HTTP and gRPC server shutdown
+	if a.userEvents != nil {
+		a.userEvents.Close()
+	}
 
 	// Shutdown HTTP server
 	wg.Add(1)
@@ -198,52 +767,93 @@ func (a *App) shutdown() error {
 		}
 	}()
 
+	// Shutdown gRPC gateway
+	if a.gateway != nil {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := a.gateway.Stop(ctx); err != nil {
+				errChan <- fmt.Errorf("gRPC gateway shutdown error: %w", err)
+			}
+		}()
+	}
+
+	// Shutdown profiling server
+	if a.pprof != nil {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := a.pprof.Stop(ctx); err != nil {
+				errChan <- fmt.Errorf("pprof server shutdown error: %w", err)
+			}
+		}()
+	}
+
 	// Shutdown gRPC server
 	wg.Add(1)
 	go func() {
 		defer wg.Done()
-		a.grpcServer.Stop()
+		a.grpcServer.Stop(ctx)
 	}()
 
-	// Close connections
-	wg.Add(1)
+	// Every server gives up on its requests when ctx is done
+	wg.Wait()
+	a.logger.Info("servers stopped")
+
+	// Close connections, with a timeout of their own so that requests
+	// running until the timeout do not leave queued events unflushed
+	closeCtx, closeCancel := context.WithTimeout(context.Background(), a.cfg.Server.ShutdownTimeout)
+	defer closeCancel()
+
+	done := make(chan struct{})
 	go func() {
-		defer wg.Done()
+		defer close(done)
 		if err := a.closeConnections(); err != nil {
 			errChan <- fmt.Errorf("connections close error: %w", err)
 		}
 	}()
 
-	// Wait for all shutdowns to complete
-	done := make(chan struct{})
-	go func() {
-		wg.Wait()
-		close(done)
-	}()
-
 	select {
 	case <-done:
 		a.logger.Info("application shutdown completed")
-	case <-ctx.Done():
+	case <-closeCtx.Done():
 		a.logger.Warn("shutdown timeout exceeded")
 	}
 
 	// Check for errors
-	close(errChan)
-	for err := range errChan {
-		a.logger.WithError(err).Error("shutdown error")
+	for {
+		select {
+		case err := <-errChan:
+			a.logger.WithError(err).Error("shutdown error")
+		default:
+			return nil
+		}
 	}
-
-	return nil
 }
 
 func (a *App) closeConnections() error {
 	var errors []error
 
-	// Close Kafka producer
+	// Stop background jobs and consumers before closing their dependencies
+	if a.jobs != nil {
+		a.jobs.Stop()
+	}
+
+	if a.consumers != nil {
+		if err := a.consumers.Stop(); err != nil {
+			errors = append(errors, fmt.Errorf("consumers close error: %w", err))
+		}
+	}
+
+	// Stop policy reloading
+	if a.casbin != nil {
+		a.casbin.Close()
+	}
+
+	// Close message broker producer
 	if a.producer != nil {
 		if err := a.producer.Close(); err != nil {
-			errors = append(errors, fmt.Errorf("kafka producer close error: %w", err))
+			errors = append(errors, fmt.Errorf("message broker producer close error: %w", err))
 		}
 	}
 
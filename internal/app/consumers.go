@@ -0,0 +1,72 @@
+package app
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/vagonaizer/authenitfication-service/internal/infrastructure/messaging"
+	"github.com/vagonaizer/authenitfication-service/pkg/logger"
+)
+
+// ConsumerGroup consumes the inbound topics through the subscriber of the
+// configured broker, one goroutine per topic. With a dedup store, handlers
+// skip events they already handled within the dedup window.
+type ConsumerGroup struct {
+	subscriber  messaging.Subscriber
+	dedup       messaging.DedupStore
+	dedupWindow time.Duration
+	logger      *logger.Logger
+	handlers    map[string]messaging.MessageHandler
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+func NewConsumerGroup(
+	subscriber messaging.Subscriber,
+	dedup messaging.DedupStore,
+	dedupWindow time.Duration,
+	logger *logger.Logger,
+) *ConsumerGroup {
+	return &ConsumerGroup{
+		subscriber:  subscriber,
+		dedup:       dedup,
+		dedupWindow: dedupWindow,
+		logger:      logger,
+		handlers:    make(map[string]messaging.MessageHandler),
+	}
+}
+
+// Handle subscribes handler to topic; it must be called before Start.
+func (g *ConsumerGroup) Handle(topic string, handler messaging.MessageHandler) {
+	if g.dedup != nil {
+		handler = messaging.Deduplicate(g.dedup, topic, g.dedupWindow, handler, g.logger)
+	}
+	g.handlers[topic] = handler
+}
+
+func (g *ConsumerGroup) Start(ctx context.Context) {
+	ctx, g.cancel = context.WithCancel(ctx)
+
+	for topic, handler := range g.handlers {
+		g.wg.Add(1)
+		go func(topic string, handler messaging.MessageHandler) {
+			defer g.wg.Done()
+			g.logger.WithField("topic", topic).Info("consumer started")
+			if err := g.subscriber.Consume(ctx, topic, handler); err != nil && ctx.Err() == nil {
+				g.logger.WithError(err).WithField("topic", topic).Error("consumer stopped")
+			}
+		}(topic, handler)
+	}
+}
+
+// Stop lets in-flight messages finish, then closes the subscriber.
+func (g *ConsumerGroup) Stop() error {
+	if g.cancel != nil {
+		g.cancel()
+	}
+	g.wg.Wait()
+
+	return g.subscriber.Close()
+}
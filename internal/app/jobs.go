@@ -0,0 +1,150 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"runtime/debug"
+	"sync"
+	"time"
+
+	"github.com/vagonaizer/authenitfication-service/internal/infrastructure/database/redis"
+	"github.com/vagonaizer/authenitfication-service/internal/infrastructure/metrics"
+	"github.com/vagonaizer/authenitfication-service/pkg/logger"
+)
+
+// Job is a background task run every Interval, plus a random delay of up to
+// Jitter so replicas do not wake up in lockstep. A run is cancelled after
+// Timeout, which defaults to the interval. A Singleton job runs on one
+// replica per interval: the first to wake up takes a Redis lock held for the
+// interval, and the others skip their run.
+type Job struct {
+	Name      string
+	Interval  time.Duration
+	Jitter    time.Duration
+	Timeout   time.Duration
+	Singleton bool
+	Run       func(ctx context.Context)
+}
+
+// JobRunner hosts the background jobs of the application. Each job runs in
+// its own goroutine, starting right after Start, and a panicking run is
+// logged and counted without taking the process down.
+type JobRunner struct {
+	logger *logger.Logger
+	redis  *redis.Client
+	jobs   []Job
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+func NewJobRunner(logger *logger.Logger, redis *redis.Client) *JobRunner {
+	return &JobRunner{logger: logger, redis: redis}
+}
+
+// Register adds a job; it must be called before Start. Jobs with a
+// non-positive interval are skipped.
+func (r *JobRunner) Register(job Job) {
+	if job.Interval <= 0 {
+		r.logger.WithField("job", job.Name).Info("background job disabled")
+		return
+	}
+	if job.Timeout <= 0 {
+		job.Timeout = job.Interval
+	}
+	r.jobs = append(r.jobs, job)
+}
+
+func (r *JobRunner) Start(ctx context.Context) {
+	ctx, r.cancel = context.WithCancel(ctx)
+
+	for _, job := range r.jobs {
+		r.wg.Add(1)
+		go func(job Job) {
+			defer r.wg.Done()
+			r.loop(ctx, job)
+		}(job)
+	}
+}
+
+// Stop cancels running jobs and waits for them to return.
+func (r *JobRunner) Stop() {
+	if r.cancel != nil {
+		r.cancel()
+	}
+	r.wg.Wait()
+}
+
+func (r *JobRunner) loop(ctx context.Context, job Job) {
+	timer := time.NewTimer(jitter(job.Jitter))
+	defer timer.Stop()
+
+	var lock *redis.Lock
+	if job.Singleton {
+		lock = redis.NewLock(r.redis, "jobs:"+job.Name, job.Interval)
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-timer.C:
+		}
+
+		if lock == nil || r.acquire(ctx, job, lock) {
+			r.run(ctx, job)
+		}
+		timer.Reset(job.Interval + jitter(job.Jitter))
+	}
+}
+
+// acquire takes the lock of a singleton job. It is not released after the
+// run but expires with the interval, so replicas waking up later in the same
+// interval skip it.
+func (r *JobRunner) acquire(ctx context.Context, job Job, lock *redis.Lock) bool {
+	acquired, err := lock.TryAcquire(ctx)
+	if err != nil {
+		r.logger.WithError(err).WithField("job", job.Name).Warn("failed to acquire background job lock")
+		return false
+	}
+	return acquired
+}
+
+func (r *JobRunner) run(ctx context.Context, job Job) {
+	ctx, cancel := context.WithTimeout(ctx, job.Timeout)
+	defer cancel()
+
+	start := time.Now()
+	outcome := "success"
+
+	defer func() {
+		if p := recover(); p != nil {
+			outcome = "panic"
+			r.logger.WithField("job", job.Name).
+				WithError(fmt.Errorf("%v", p)).
+				WithField("stack", string(debug.Stack())).
+				Error("background job panicked")
+		}
+
+		metrics.JobRuns.WithLabelValues(job.Name, outcome).Inc()
+		metrics.JobDuration.WithLabelValues(job.Name).Observe(time.Since(start).Seconds())
+		if outcome == "success" {
+			metrics.JobLastSuccess.WithLabelValues(job.Name).SetToCurrentTime()
+		}
+	}()
+
+	job.Run(ctx)
+
+	if ctx.Err() == context.DeadlineExceeded {
+		outcome = "timeout"
+		r.logger.WithField("job", job.Name).Warnf("background job exceeded its %s timeout", job.Timeout)
+	}
+}
+
+func jitter(max time.Duration) time.Duration {
+	if max <= 0 {
+		return 0
+	}
+	return time.Duration(rand.Int63n(int64(max)))
+}
@@ -0,0 +1,36 @@
+package app
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/vagonaizer/authenitfication-service/internal/config"
+	"github.com/vagonaizer/authenitfication-service/pkg/logger"
+)
+
+// connectWithRetry calls connect until it succeeds, doubling the delay between
+// attempts up to RetryMaxDelay, and gives up once the next attempt would start
+// after MaxWait. A zero MaxWait means a single attempt.
+func connectWithRetry(cfg config.StartupConfig, log *logger.Logger, name string, connect func() error) error {
+	deadline := time.Now().Add(cfg.MaxWait)
+	delay := cfg.RetryInitialDelay
+
+	for attempt := 1; ; attempt++ {
+		err := connect()
+		if err == nil {
+			return nil
+		}
+
+		if time.Now().Add(delay).After(deadline) {
+			return fmt.Errorf("%s unavailable after %d attempts: %w", name, attempt, err)
+		}
+
+		log.WithError(err).WithField("attempt", attempt).Warnf("%s unavailable, retrying in %s", name, delay)
+		time.Sleep(delay)
+
+		delay *= 2
+		if delay > cfg.RetryMaxDelay {
+			delay = cfg.RetryMaxDelay
+		}
+	}
+}
@@ -0,0 +1,38 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	ActivityLogin           = "login"
+	ActivityLogout          = "logout"
+	ActivityPasswordChanged = "password_changed"
+	ActivityRoleAssigned    = "role_assigned"
+	ActivityRoleRemoved     = "role_removed"
+	ActivityProfileUpdated  = "profile_updated"
+	ActivityAccountMerged   = "account_merged"
+
+	ActivityPasswordChangeRequired = "password_change_required"
+	ActivitySessionsRevoked        = "sessions_revoked"
+)
+
+// UserActivity is one entry of a user's activity timeline. ActorID is set
+// when someone other than the user caused it.
+type UserActivity struct {
+	ID        uuid.UUID         `json:"id" db:"id"`
+	UserID    uuid.UUID         `json:"user_id" db:"user_id"`
+	Type      string            `json:"type" db:"type"`
+	ActorID   *uuid.UUID        `json:"actor_id" db:"actor_id"`
+	IPAddress *string           `json:"ip_address" db:"ip_address"`
+	UserAgent *string           `json:"user_agent" db:"user_agent"`
+	Details   map[string]string `json:"details" db:"details"`
+	CreatedAt time.Time         `json:"created_at" db:"created_at"`
+}
+
+type ActivityFilter struct {
+	UserID uuid.UUID
+	Type   string
+}
@@ -0,0 +1,32 @@
+package entities
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// EventRecord is an event as it was handed to the message broker. UserID is
+// the user_id field of the payload, when it has one.
+type EventRecord struct {
+	ID        uuid.UUID       `json:"id" db:"id"`
+	Topic     string          `json:"topic" db:"topic"`
+	Key       string          `json:"key" db:"key"`
+	UserID    *uuid.UUID      `json:"user_id" db:"user_id"`
+	Payload   json.RawMessage `json:"payload" db:"payload"`
+	CreatedAt time.Time       `json:"created_at" db:"created_at"`
+}
+
+// EventLogFilter selects logged events. Zero fields do not filter; From is
+// inclusive and To exclusive. AfterAt and AfterID continue a listing after
+// the last record of the previous page.
+type EventLogFilter struct {
+	From   time.Time
+	To     time.Time
+	UserID *uuid.UUID
+	Topic  string
+
+	AfterAt time.Time
+	AfterID uuid.UUID
+}
@@ -0,0 +1,32 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Reasons a login attempt was refused.
+const (
+	LoginFailureUnknownUser     = "unknown_user"
+	LoginFailureInvalidPassword = "invalid_password"
+	LoginFailureInactive        = "inactive"
+	LoginFailureBanned          = "banned"
+	LoginFailureServiceAccount  = "service_account"
+	LoginFailurePasswordNotSet  = "password_not_set"
+)
+
+// LoginAttempt is one entry of the login history. UserID is nil when the
+// email did not match an account; Country is the ISO 3166-1 alpha-2 code
+// reported by the proxy in front of the service, if any.
+type LoginAttempt struct {
+	ID            uuid.UUID  `json:"id" db:"id"`
+	UserID        *uuid.UUID `json:"user_id" db:"user_id"`
+	Email         string     `json:"email" db:"email"`
+	Success       bool       `json:"success" db:"success"`
+	FailureReason *string    `json:"failure_reason" db:"failure_reason"`
+	IPAddress     string     `json:"ip_address" db:"ip_address"`
+	UserAgent     string     `json:"user_agent" db:"user_agent"`
+	Country       *string    `json:"country" db:"country"`
+	CreatedAt     time.Time  `json:"created_at" db:"created_at"`
+}
@@ -0,0 +1,24 @@
+package entities
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// OutboxMessage is an event waiting in the outbox for the relay to publish
+// it. IDs grow with insertion, so they give the publishing order.
+type OutboxMessage struct {
+	ID        int64             `json:"id" db:"id"`
+	Topic     string            `json:"topic" db:"topic"`
+	Key       string            `json:"key" db:"key"`
+	Payload   json.RawMessage   `json:"payload" db:"payload"`
+	Headers   map[string]string `json:"headers" db:"headers"`
+	CreatedAt time.Time         `json:"created_at" db:"created_at"`
+}
+
+// OutboxStats describes the backlog of the outbox. OldestAt is nil when the
+// outbox is empty.
+type OutboxStats struct {
+	Pending  int
+	OldestAt *time.Time
+}
@@ -0,0 +1,64 @@
+package entities
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	PermissionUsersRead       = "users:read"
+	PermissionUsersManage     = "users:manage"
+	PermissionUsersActivate   = "users:activate"
+	PermissionUsersDeactivate = "users:deactivate"
+	PermissionUsersBan        = "users:ban"
+	PermissionUsersNotes      = "users:notes"
+	PermissionRolesRead       = "roles:read"
+	PermissionRolesManage     = "roles:manage"
+	PermissionRolesAssign     = "roles:assign"
+	PermissionGroupsRead      = "groups:read"
+	PermissionGroupsManage    = "groups:manage"
+	PermissionAccessCheck     = "access:check"
+
+	PermissionServiceAccountsRead   = "service_accounts:read"
+	PermissionServiceAccountsManage = "service_accounts:manage"
+
+	PermissionQuotasRead   = "quotas:read"
+	PermissionQuotasManage = "quotas:manage"
+
+	PermissionEventsReplay = "events:replay"
+)
+
+type Permission struct {
+	ID          uuid.UUID `json:"id" db:"id"`
+	Name        string    `json:"name" db:"name"`
+	Description *string   `json:"description" db:"description"`
+	CreatedAt   time.Time `json:"created_at" db:"created_at"`
+}
+
+type RolePermission struct {
+	ID           uuid.UUID `json:"id" db:"id"`
+	RoleID       uuid.UUID `json:"role_id" db:"role_id"`
+	PermissionID uuid.UUID `json:"permission_id" db:"permission_id"`
+	Condition    *string   `json:"condition" db:"condition"`
+	CreatedAt    time.Time `json:"created_at" db:"created_at"`
+}
+
+// PermissionGrant is a permission held by a role, optionally restricted by an
+// ABAC condition. An empty Condition means the grant always applies.
+type PermissionGrant struct {
+	Permission string `json:"permission"`
+	Condition  string `json:"condition,omitempty"`
+}
+
+// PermissionName joins a resource and action into a permission name such as "users:read".
+func PermissionName(resource, action string) string {
+	return resource + ":" + action
+}
+
+// ParsePermission splits a permission name into its resource and action.
+func ParsePermission(name string) (resource, action string) {
+	resource, action, _ = strings.Cut(name, ":")
+	return resource, action
+}
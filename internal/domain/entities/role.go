@@ -15,8 +15,30 @@ type Role struct {
 }
 
 type UserRole struct {
-	ID        uuid.UUID `json:"id" db:"id"`
-	UserID    uuid.UUID `json:"user_id" db:"user_id"`
-	RoleID    uuid.UUID `json:"role_id" db:"role_id"`
-	CreatedAt time.Time `json:"created_at" db:"created_at"`
+	ID        uuid.UUID  `json:"id" db:"id"`
+	UserID    uuid.UUID  `json:"user_id" db:"user_id"`
+	RoleID    uuid.UUID  `json:"role_id" db:"role_id"`
+	ScopeID   *uuid.UUID `json:"scope_id" db:"scope_id"`
+	ExpiresAt *time.Time `json:"expires_at" db:"expires_at"`
+	CreatedAt time.Time  `json:"created_at" db:"created_at"`
+}
+
+// ExpiredRoleAssignment describes a time-bound grant removed after expiry.
+type ExpiredRoleAssignment struct {
+	UserID    uuid.UUID
+	RoleID    uuid.UUID
+	RoleName  string
+	ScopeID   *uuid.UUID
+	ExpiresAt time.Time
+}
+
+// BulkRoleChange grants or revokes one role for many users at once.
+type BulkRoleChange struct {
+	RoleID    uuid.UUID
+	RoleName  string
+	ScopeID   *uuid.UUID
+	ExpiresAt *time.Time
+	ActorID   *uuid.UUID
+	Reason    *string
+	UserIDs   []uuid.UUID
 }
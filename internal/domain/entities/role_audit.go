@@ -0,0 +1,34 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	RoleAuditActionGranted = "granted"
+	RoleAuditActionRevoked = "revoked"
+	RoleAuditActionExpired = "expired"
+)
+
+// RoleAssignmentAudit records a single change to a user's role assignments.
+// ActorID is nil for changes made by the system, such as expiry.
+type RoleAssignmentAudit struct {
+	ID        uuid.UUID  `json:"id" db:"id"`
+	UserID    uuid.UUID  `json:"user_id" db:"user_id"`
+	RoleID    uuid.UUID  `json:"role_id" db:"role_id"`
+	RoleName  string     `json:"role_name" db:"role_name"`
+	ScopeID   *uuid.UUID `json:"scope_id" db:"scope_id"`
+	Action    string     `json:"action" db:"action"`
+	ActorID   *uuid.UUID `json:"actor_id" db:"actor_id"`
+	Reason    *string    `json:"reason" db:"reason"`
+	ExpiresAt *time.Time `json:"expires_at" db:"expires_at"`
+	CreatedAt time.Time  `json:"created_at" db:"created_at"`
+}
+
+type RoleAuditFilter struct {
+	UserID *uuid.UUID
+	RoleID *uuid.UUID
+	Action string
+}
@@ -0,0 +1,24 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ServiceAccountKey is a key/secret credential of a service account user.
+// Only a hash of the secret is stored.
+type ServiceAccountKey struct {
+	ID         uuid.UUID  `json:"id" db:"id"`
+	UserID     uuid.UUID  `json:"user_id" db:"user_id"`
+	KeyID      string     `json:"key_id" db:"key_id"`
+	SecretHash string     `json:"-" db:"secret_hash"`
+	CreatedBy  *uuid.UUID `json:"created_by" db:"created_by"`
+	LastUsedAt *time.Time `json:"last_used_at" db:"last_used_at"`
+	RevokedAt  *time.Time `json:"revoked_at" db:"revoked_at"`
+	CreatedAt  time.Time  `json:"created_at" db:"created_at"`
+}
+
+func (k *ServiceAccountKey) IsRevoked() bool {
+	return k.RevokedAt != nil
+}
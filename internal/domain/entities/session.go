@@ -6,14 +6,28 @@ import (
 	"github.com/google/uuid"
 )
 
+// Reasons sessions are revoked other than by the user logging out of them.
+const (
+	SessionRevokedLogoutAll              = "logout_all"
+	SessionRevokedByUser                 = "revoked_by_user"
+	SessionRevokedPasswordChanged        = "password_changed"
+	SessionRevokedPasswordChangeRequired = "password_change_required"
+	SessionRevokedDeactivated            = "deactivated"
+	SessionRevokedBanned                 = "banned"
+	SessionRevokedMerged                 = "merged"
+	SessionRevokedByAdmin                = "revoked_by_admin"
+	SessionRevokedPurged                 = "purged"
+)
+
 type Session struct {
-	ID           uuid.UUID `json:"id" db:"id"`
-	UserID       uuid.UUID `json:"user_id" db:"user_id"`
-	RefreshToken string    `json:"refresh_token" db:"refresh_token"`
-	UserAgent    string    `json:"user_agent" db:"user_agent"`
-	IPAddress    string    `json:"ip_address" db:"ip_address"`
-	IsActive     bool      `json:"is_active" db:"is_active"`
-	ExpiresAt    time.Time `json:"expires_at" db:"expires_at"`
-	CreatedAt    time.Time `json:"created_at" db:"created_at"`
-	UpdatedAt    time.Time `json:"updated_at" db:"updated_at"`
+	ID             uuid.UUID  `json:"id" db:"id"`
+	UserID         uuid.UUID  `json:"user_id" db:"user_id"`
+	OrganizationID *uuid.UUID `json:"organization_id" db:"organization_id"`
+	RefreshToken   string     `json:"refresh_token" db:"refresh_token"`
+	UserAgent      string     `json:"user_agent" db:"user_agent"`
+	IPAddress      string     `json:"ip_address" db:"ip_address"`
+	IsActive       bool       `json:"is_active" db:"is_active"`
+	ExpiresAt      time.Time  `json:"expires_at" db:"expires_at"`
+	CreatedAt      time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at" db:"updated_at"`
 }
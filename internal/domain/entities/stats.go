@@ -0,0 +1,18 @@
+package entities
+
+import "time"
+
+// UserStats are aggregate figures over human accounts; service accounts are
+// left out. Registrations has one entry per UTC day, including empty days.
+type UserStats struct {
+	TotalUsers     int64
+	VerifiedUsers  int64
+	ActiveUsers7d  int64
+	ActiveUsers30d int64
+	Registrations  []DailyCount
+}
+
+type DailyCount struct {
+	Date  time.Time
+	Count int64
+}
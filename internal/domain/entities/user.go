@@ -7,16 +7,89 @@ import (
 )
 
 type User struct {
-	ID           uuid.UUID  `json:"id" db:"id"`
-	Email        string     `json:"email" db:"email"`
-	Username     string     `json:"username" db:"username"`
-	PasswordHash string     `json:"-" db:"password_hash"`
-	FirstName    *string    `json:"first_name" db:"first_name"`
-	LastName     *string    `json:"last_name" db:"last_name"`
-	IsActive     bool       `json:"is_active" db:"is_active"`
-	IsVerified   bool       `json:"is_verified" db:"is_verified"`
-	LastLoginAt  *time.Time `json:"last_login_at" db:"last_login_at"`
-	CreatedAt    time.Time  `json:"created_at" db:"created_at"`
-	UpdatedAt    time.Time  `json:"updated_at" db:"updated_at"`
-	DeletedAt    *time.Time `json:"deleted_at" db:"deleted_at"`
+	ID               uuid.UUID  `json:"id" db:"id"`
+	Email            string     `json:"email" db:"email"`
+	Username         string     `json:"username" db:"username"`
+	PasswordHash     string     `json:"-" db:"password_hash"`
+	FirstName        *string    `json:"first_name" db:"first_name"`
+	LastName         *string    `json:"last_name" db:"last_name"`
+	AvatarURL        *string    `json:"avatar_url" db:"avatar_url"`
+	IsActive         bool       `json:"is_active" db:"is_active"`
+	IsVerified       bool       `json:"is_verified" db:"is_verified"`
+	IsServiceAccount bool       `json:"is_service_account" db:"is_service_account"`
+	LastLoginAt      *time.Time `json:"last_login_at" db:"last_login_at"`
+	CreatedAt        time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt        time.Time  `json:"updated_at" db:"updated_at"`
+	DeletedAt        *time.Time `json:"deleted_at" db:"deleted_at"`
+
+	PasswordChangeRequired bool `json:"password_change_required" db:"password_change_required"`
+
+	BannedAt    *time.Time `json:"banned_at,omitempty" db:"banned_at"`
+	BannedUntil *time.Time `json:"banned_until,omitempty" db:"banned_until"`
+	BanReason   *string    `json:"ban_reason,omitempty" db:"ban_reason"`
+	BannedBy    *uuid.UUID `json:"banned_by,omitempty" db:"banned_by"`
+
+	// Phone is only set once the number has been verified by OTP.
+	Phone           *string    `json:"phone,omitempty" db:"phone"`
+	PhoneVerifiedAt *time.Time `json:"phone_verified_at,omitempty" db:"phone_verified_at"`
+
+	// Locale is a BCP 47 tag and Timezone an IANA zone name.
+	Locale   string `json:"locale" db:"locale"`
+	Timezone string `json:"timezone" db:"timezone"`
+
+	// DeletionScheduledAt is when a deletion the user requested takes
+	// effect. The account works normally until then.
+	DeletionRequestedAt *time.Time `json:"deletion_requested_at,omitempty" db:"deletion_requested_at"`
+	DeletionScheduledAt *time.Time `json:"deletion_scheduled_at,omitempty" db:"deletion_scheduled_at"`
+}
+
+const (
+	DefaultLocale   = "en"
+	DefaultTimezone = "UTC"
+)
+
+// IsBanned reports whether a ban is in force at t. Bans past BannedUntil no
+// longer count even before the expiry sweeper has lifted them.
+func (u *User) IsBanned(t time.Time) bool {
+	if u.BannedAt == nil {
+		return false
+	}
+	return u.BannedUntil == nil || t.Before(*u.BannedUntil)
+}
+
+const (
+	UserPurgeReasonRequested = "user_request"
+	UserPurgeReasonRetention = "retention"
+	UserPurgeReasonScheduled = "scheduled_deletion"
+)
+
+// UserImport is one row of a bulk import: the user to create and the roles
+// granted to it.
+type UserImport struct {
+	User  *User
+	Roles []*Role
+}
+
+// UserMergeResult counts what a merge moved onto the primary account. Grants
+// the primary already had are not counted.
+type UserMergeResult struct {
+	RolesMoved         int
+	OrganizationsMoved int
+	GroupsMoved        int
+}
+
+// UserListFilter narrows and orders a paginated user listing. Search matches
+// email, username and name case-insensitively.
+type UserListFilter struct {
+	Search  string
+	SortBy  string
+	SortDir string
+}
+
+// UserFilter selects users for bulk operations. Empty fields match everyone.
+type UserFilter struct {
+	OrganizationID *uuid.UUID
+	GroupID        *uuid.UUID
+	RoleID         *uuid.UUID
+	IsActive       *bool
 }
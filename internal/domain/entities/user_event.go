@@ -0,0 +1,16 @@
+package entities
+
+import (
+	"encoding/json"
+
+	"github.com/google/uuid"
+)
+
+// UserEvent is an event delivered live to the clients of the user it
+// concerns, such as a session created or revoked elsewhere. Type is the
+// topic the event was published to and Data its payload.
+type UserEvent struct {
+	UserID uuid.UUID       `json:"user_id"`
+	Type   string          `json:"type"`
+	Data   json.RawMessage `json:"data"`
+}
@@ -0,0 +1,13 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/vagonaizer/authenitfication-service/internal/domain/entities"
+)
+
+type ActivityRepository interface {
+	Create(ctx context.Context, activity *entities.UserActivity) error
+	// List returns matching entries, newest first, with the total match count.
+	List(ctx context.Context, filter entities.ActivityFilter, limit, offset int) ([]*entities.UserActivity, int64, error)
+}
@@ -0,0 +1,17 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/vagonaizer/authenitfication-service/internal/domain/entities"
+)
+
+type EventLogRepository interface {
+	Create(ctx context.Context, record *entities.EventRecord) error
+	// List returns up to limit matching events, oldest first.
+	List(ctx context.Context, filter entities.EventLogFilter, limit int) ([]*entities.EventRecord, error)
+	// DeleteBefore removes up to limit events logged before the given time
+	// and returns how many were removed.
+	DeleteBefore(ctx context.Context, before time.Time, limit int) (int, error)
+}
@@ -0,0 +1,31 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/vagonaizer/authenitfication-service/internal/domain/entities"
+)
+
+type GroupRepository interface {
+	Create(ctx context.Context, group *entities.Group) error
+	GetByID(ctx context.Context, id uuid.UUID) (*entities.Group, error)
+	List(ctx context.Context) ([]*entities.Group, error)
+	Update(ctx context.Context, group *entities.Group) error
+	Delete(ctx context.Context, id uuid.UUID) error
+	// GetAncestorIDs returns the IDs of every group above the given one.
+	GetAncestorIDs(ctx context.Context, id uuid.UUID) ([]uuid.UUID, error)
+
+	AddMember(ctx context.Context, groupID, userID uuid.UUID) error
+	RemoveMember(ctx context.Context, groupID, userID uuid.UUID) error
+	ListMembers(ctx context.Context, groupID uuid.UUID) ([]*entities.GroupMember, error)
+
+	AssignRole(ctx context.Context, groupID, roleID uuid.UUID) error
+	RemoveRole(ctx context.Context, groupID, roleID uuid.UUID) error
+	GetGroupRoles(ctx context.Context, groupID uuid.UUID) ([]*entities.Role, error)
+
+	// GetUserGroups returns the groups a user belongs to directly or through nesting.
+	GetUserGroups(ctx context.Context, userID uuid.UUID) ([]*entities.Group, error)
+	// GetUserGroupRoles returns the roles a user inherits from all of their groups.
+	GetUserGroupRoles(ctx context.Context, userID uuid.UUID) ([]*entities.Role, error)
+}
@@ -0,0 +1,17 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/vagonaizer/authenitfication-service/internal/domain/entities"
+)
+
+type InvitationRepository interface {
+	Create(ctx context.Context, invitation *entities.OrganizationInvitation) error
+	GetByID(ctx context.Context, id uuid.UUID) (*entities.OrganizationInvitation, error)
+	GetByTokenHash(ctx context.Context, tokenHash string) (*entities.OrganizationInvitation, error)
+	GetPendingByEmail(ctx context.Context, orgID uuid.UUID, email string) (*entities.OrganizationInvitation, error)
+	ListByOrganization(ctx context.Context, orgID uuid.UUID) ([]*entities.OrganizationInvitation, error)
+	UpdateStatus(ctx context.Context, id uuid.UUID, status string) error
+}
@@ -0,0 +1,19 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/vagonaizer/authenitfication-service/internal/domain/entities"
+)
+
+type LoginHistoryRepository interface {
+	Create(ctx context.Context, attempt *entities.LoginAttempt) error
+	// ListByUser returns the user's attempts, newest first, with the total
+	// count.
+	ListByUser(ctx context.Context, userID uuid.UUID, limit, offset int) ([]*entities.LoginAttempt, int64, error)
+	// DeleteBefore removes up to limit attempts made before the given time
+	// and returns how many were removed.
+	DeleteBefore(ctx context.Context, before time.Time, limit int) (int, error)
+}
@@ -0,0 +1,18 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/vagonaizer/authenitfication-service/internal/domain/entities"
+)
+
+type NoteRepository interface {
+	Create(ctx context.Context, note *entities.UserNote) error
+	GetByID(ctx context.Context, id uuid.UUID) (*entities.UserNote, error)
+	// Update saves the body and refreshes UpdatedAt.
+	Update(ctx context.Context, note *entities.UserNote) error
+	Delete(ctx context.Context, id uuid.UUID) error
+	// ListByUser returns the user's notes, newest first.
+	ListByUser(ctx context.Context, userID uuid.UUID) ([]*entities.UserNote, error)
+}
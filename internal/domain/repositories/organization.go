@@ -0,0 +1,28 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/vagonaizer/authenitfication-service/internal/domain/entities"
+)
+
+type OrganizationRepository interface {
+	Create(ctx context.Context, org *entities.Organization) error
+	GetByID(ctx context.Context, id uuid.UUID) (*entities.Organization, error)
+	GetBySlug(ctx context.Context, slug string) (*entities.Organization, error)
+	ListByUserID(ctx context.Context, userID uuid.UUID) ([]*entities.Organization, error)
+	Update(ctx context.Context, org *entities.Organization) error
+	Delete(ctx context.Context, id uuid.UUID) error
+
+	AddMember(ctx context.Context, member *entities.OrganizationMember) error
+	GetMember(ctx context.Context, orgID, userID uuid.UUID) (*entities.OrganizationMember, error)
+	ListMembers(ctx context.Context, orgID uuid.UUID) ([]*entities.OrganizationMember, error)
+	UpdateMemberRole(ctx context.Context, orgID, userID uuid.UUID, role string) error
+	RemoveMember(ctx context.Context, orgID, userID uuid.UUID) error
+	CountMembersByRole(ctx context.Context, orgID uuid.UUID, role string) (int, error)
+	CountMembers(ctx context.Context, orgID uuid.UUID) (int, error)
+
+	GetQuota(ctx context.Context, orgID uuid.UUID) (*entities.OrganizationQuota, error)
+	UpsertQuota(ctx context.Context, quota *entities.OrganizationQuota) error
+}
@@ -0,0 +1,22 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/vagonaizer/authenitfication-service/internal/domain/entities"
+)
+
+type OutboxRepository interface {
+	Create(ctx context.Context, message *entities.OutboxMessage) error
+	// ListPending returns up to limit messages, oldest first.
+	ListPending(ctx context.Context, limit int) ([]*entities.OutboxMessage, error)
+	// Delete removes published messages.
+	Delete(ctx context.Context, ids []int64) error
+	// DeleteFenced removes published messages unless a leader with a larger
+	// fencing token than token has written, and reports whether it did.
+	DeleteFenced(ctx context.Context, ids []int64, token int64) (bool, error)
+	// Fence records token as the fencing token of the relay leader, and
+	// reports false when a leader with a larger token has already written.
+	Fence(ctx context.Context, token int64) (bool, error)
+	Stats(ctx context.Context) (*entities.OutboxStats, error)
+}
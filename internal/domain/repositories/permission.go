@@ -0,0 +1,19 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/vagonaizer/authenitfication-service/internal/domain/entities"
+)
+
+type PermissionRepository interface {
+	GetByName(ctx context.Context, name string) (*entities.Permission, error)
+	List(ctx context.Context) ([]*entities.Permission, error)
+
+	// GrantToRole creates or replaces the grant, including its condition.
+	GrantToRole(ctx context.Context, roleID, permissionID uuid.UUID, condition *string) error
+	RevokeFromRole(ctx context.Context, roleID, permissionID uuid.UUID) error
+	GetRoleGrants(ctx context.Context, roleID uuid.UUID) ([]*entities.PermissionGrant, error)
+	GetGrantsByRoleName(ctx context.Context, roleName string) ([]*entities.PermissionGrant, error)
+}
@@ -0,0 +1,18 @@
+package repositories
+
+import (
+	"context"
+	"time"
+)
+
+// ProcessedEventRepository remembers which inbound events were handled.
+type ProcessedEventRepository interface {
+	// MarkEventProcessed records the event as handled for window.
+	MarkEventProcessed(ctx context.Context, topic, eventID string, window time.Duration) error
+	// IsEventProcessed reports whether the event was handled and has not
+	// expired yet.
+	IsEventProcessed(ctx context.Context, topic, eventID string) (bool, error)
+	// DeleteExpired removes up to limit expired entries and returns how many
+	// were removed.
+	DeleteExpired(ctx context.Context, limit int) (int, error)
+}
@@ -2,6 +2,7 @@ package repositories
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/vagonaizer/authenitfication-service/internal/domain/entities"
@@ -15,7 +16,21 @@ type RoleRepository interface {
 	Update(ctx context.Context, role *entities.Role) error
 	Delete(ctx context.Context, id uuid.UUID) error
 
-	AssignRoleToUser(ctx context.Context, userID, roleID uuid.UUID) error
-	RemoveRoleFromUser(ctx context.Context, userID, roleID uuid.UUID) error
-	GetUserRoles(ctx context.Context, userID uuid.UUID) ([]*entities.Role, error)
+	// A nil scopeID targets the global assignment; otherwise the assignment
+	// only applies within that organization or project. A nil expiresAt makes
+	// the grant permanent; re-assigning replaces the previous expiry.
+	AssignRoleToUser(ctx context.Context, userID, roleID uuid.UUID, scopeID *uuid.UUID, expiresAt *time.Time) error
+	RemoveRoleFromUser(ctx context.Context, userID, roleID uuid.UUID, scopeID *uuid.UUID) error
+	// GetUserRoles returns the user's unexpired global roles, plus the roles
+	// scoped to scopeID when it is set.
+	GetUserRoles(ctx context.Context, userID uuid.UUID, scopeID *uuid.UUID) ([]*entities.Role, error)
+	// BulkAssignRole grants the role to every existing user in the change and
+	// records the audit entries in the same transaction. It returns the users
+	// that received the role.
+	BulkAssignRole(ctx context.Context, change *entities.BulkRoleChange) ([]uuid.UUID, error)
+	// BulkRemoveRole is the revoking counterpart of BulkAssignRole. It returns
+	// the users that held the role.
+	BulkRemoveRole(ctx context.Context, change *entities.BulkRoleChange) ([]uuid.UUID, error)
+	// DeleteExpiredUserRoles removes up to limit grants that expired before the given time.
+	DeleteExpiredUserRoles(ctx context.Context, before time.Time, limit int) ([]*entities.ExpiredRoleAssignment, error)
 }
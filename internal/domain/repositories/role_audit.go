@@ -0,0 +1,13 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/vagonaizer/authenitfication-service/internal/domain/entities"
+)
+
+type RoleAuditRepository interface {
+	Create(ctx context.Context, entry *entities.RoleAssignmentAudit) error
+	// List returns matching entries, newest first, with the total match count.
+	List(ctx context.Context, filter entities.RoleAuditFilter, limit, offset int) ([]*entities.RoleAssignmentAudit, int64, error)
+}
@@ -0,0 +1,23 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/vagonaizer/authenitfication-service/internal/domain/entities"
+)
+
+// ServiceAccountRepository stores service account credentials. The accounts
+// themselves are users and are created through UserRepository.
+type ServiceAccountRepository interface {
+	List(ctx context.Context, limit, offset int) ([]*entities.User, error)
+
+	CreateKey(ctx context.Context, key *entities.ServiceAccountKey) error
+	// RotateKey creates key and revokes every other active key of its user.
+	RotateKey(ctx context.Context, key *entities.ServiceAccountKey) error
+	GetKeyByKeyID(ctx context.Context, keyID string) (*entities.ServiceAccountKey, error)
+	ListKeys(ctx context.Context, userID uuid.UUID) ([]*entities.ServiceAccountKey, error)
+	RevokeKey(ctx context.Context, userID uuid.UUID, keyID string) error
+	RevokeAllKeys(ctx context.Context, userID uuid.UUID) error
+	TouchKey(ctx context.Context, id uuid.UUID) error
+}
@@ -2,6 +2,7 @@ package repositories
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/vagonaizer/authenitfication-service/internal/domain/entities"
@@ -15,5 +16,10 @@ type SessionRepository interface {
 	Update(ctx context.Context, session *entities.Session) error
 	Delete(ctx context.Context, id uuid.UUID) error
 	DeleteByUserID(ctx context.Context, userID uuid.UUID) error
-	DeleteExpired(ctx context.Context) error
+	// DeleteExpired removes up to limit sessions that expired before the given
+	// time and returns how many were removed.
+	DeleteExpired(ctx context.Context, before time.Time, limit int) (int, error)
+	// CountActive counts the active sessions unexpired at now, and the users
+	// holding them.
+	CountActive(ctx context.Context, now time.Time) (sessions, users int64, err error)
 }
@@ -0,0 +1,14 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/vagonaizer/authenitfication-service/internal/domain/entities"
+)
+
+type StatsRepository interface {
+	// GetUserStats counts registrations per day from since up to now, and
+	// active users as of now. Sessions are counted by the session store.
+	GetUserStats(ctx context.Context, since, now time.Time) (*entities.UserStats, error)
+}
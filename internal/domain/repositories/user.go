@@ -2,6 +2,7 @@ package repositories
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/vagonaizer/authenitfication-service/internal/domain/entities"
@@ -13,8 +14,54 @@ type UserRepository interface {
 	GetByEmail(ctx context.Context, email string) (*entities.User, error)
 	GetByUsername(ctx context.Context, username string) (*entities.User, error)
 	Update(ctx context.Context, user *entities.User) error
+	// UpdateIfUnmodified is Update for a user whose updated_at still equals
+	// unmodifiedSince, and returns PreconditionFailed when it changed since.
+	UpdateIfUnmodified(ctx context.Context, user *entities.User, unmodifiedSince time.Time) error
 	Delete(ctx context.Context, id uuid.UUID) error
-	List(ctx context.Context, limit, offset int) ([]*entities.User, error)
+	List(ctx context.Context, filter entities.UserListFilter, limit, offset int) ([]*entities.User, int64, error)
+	// Search returns up to limit users whose email, username or name
+	// contains query, best trigram matches first.
+	Search(ctx context.Context, query string, limit int) ([]*entities.User, error)
 	ExistsByEmail(ctx context.Context, email string) (bool, error)
 	ExistsByUsername(ctx context.Context, username string) (bool, error)
+	ExistsByPhone(ctx context.Context, phone string) (bool, error)
+	// SetPhone stores a phone number that has just been verified; nil clears it.
+	SetPhone(ctx context.Context, id uuid.UUID, phone *string) error
+	// ImportBatch creates the users of one batch and grants their roles in a
+	// single transaction. Each row runs under its own savepoint, so a failing
+	// row is reported at its index in the returned slice without aborting the
+	// others.
+	ImportBatch(ctx context.Context, batch []*entities.UserImport, actorID *uuid.UUID) ([]error, error)
+	// Purge irreversibly anonymizes the user and removes the memberships,
+	// invitations and audit details that identify them; sessions live in the
+	// SessionRepository and are removed by the caller. It
+	// returns the avatar URL the account had so the file can be removed, and
+	// UserNotFound for unknown or already purged users.
+	Purge(ctx context.Context, id uuid.UUID) (*string, error)
+	// ListPurgeCandidates returns up to limit users soft-deleted before the
+	// given time that have not been purged yet.
+	ListPurgeCandidates(ctx context.Context, deletedBefore time.Time, limit int) ([]uuid.UUID, error)
+	// ScheduleDeletion marks the user to be purged at the given time.
+	ScheduleDeletion(ctx context.Context, id uuid.UUID, at time.Time) error
+	// CancelDeletion reports whether a scheduled deletion was cancelled.
+	CancelDeletion(ctx context.Context, id uuid.UUID) (bool, error)
+	// ListDueDeletions returns up to limit users whose scheduled deletion is
+	// due at the given time.
+	ListDueDeletions(ctx context.Context, before time.Time, limit int) ([]uuid.UUID, error)
+	// Ban records the ban described by the BannedUntil, BanReason and
+	// BannedBy fields of user and fills in BannedAt.
+	Ban(ctx context.Context, user *entities.User) error
+	Unban(ctx context.Context, id uuid.UUID) error
+	// LiftExpiredBans clears up to limit bans that ended before the given
+	// time and returns the affected users.
+	LiftExpiredBans(ctx context.Context, before time.Time, limit int) ([]uuid.UUID, error)
+	// Merge moves the roles, organization and group memberships and activity
+	// of the duplicate onto the primary user in one transaction, then
+	// deactivates and soft-deletes the duplicate. Without moveRoles the roles
+	// of the duplicate are revoked instead of moved. Role changes on both
+	// accounts are audited with actorID and reason. Sessions are left to the
+	// caller, as they may not be stored in the same database.
+	Merge(ctx context.Context, primaryID, duplicateID uuid.UUID, actorID *uuid.UUID, reason string, moveRoles bool) (*entities.UserMergeResult, error)
+	// ListIDs returns up to limit IDs of users matching the filter.
+	ListIDs(ctx context.Context, filter entities.UserFilter, limit int) ([]uuid.UUID, error)
 }
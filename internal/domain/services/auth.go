@@ -3,6 +3,8 @@ package services
 import (
 	"context"
 
+	"github.com/google/uuid"
+
 	"github.com/vagonaizer/authenitfication-service/internal/dto/request"
 	"github.com/vagonaizer/authenitfication-service/internal/dto/response"
 )
@@ -11,10 +13,20 @@ type AuthService interface {
 	Register(ctx context.Context, req *request.RegisterRequest, ipAddress, userAgent string) (*response.AuthResponse, error)
 	Login(ctx context.Context, req *request.LoginRequest, ipAddress, userAgent string) (*response.AuthResponse, error)
 	RefreshToken(ctx context.Context, req *request.RefreshTokenRequest) (*response.TokenResponse, error)
+	AuthenticateServiceAccount(ctx context.Context, req *request.ServiceAccountTokenRequest) (*response.TokenResponse, error)
+	SwitchOrganization(ctx context.Context, req *request.SwitchOrganizationRequest) (*response.TokenResponse, error)
 	Logout(ctx context.Context, req *request.LogoutRequest) error
 	LogoutAll(ctx context.Context, userID string) error
-	VerifyToken(ctx context.Context, token string) (*response.TokenClaimsResponse, error)
+	ListSessions(ctx context.Context, userID uuid.UUID) ([]*response.SessionResponse, error)
+	// RevokeSession signs userID out of one of their sessions; sessions of
+	// other users are not found.
+	RevokeSession(ctx context.Context, userID, sessionID uuid.UUID) error
+	// VerifyToken validates token for a request addressed to tenant, nil
+	// when the request names no organization.
+	VerifyToken(ctx context.Context, token string, tenant *uuid.UUID) (*response.TokenClaimsResponse, error)
 	ChangePassword(ctx context.Context, req *request.ChangePasswordRequest) error
 	ResetPassword(ctx context.Context, req *request.ResetPasswordRequest) error
 	ConfirmResetPassword(ctx context.Context, req *request.ConfirmResetPasswordRequest) error
+	// CheckAccess lets other services authorize a user against this service's policies.
+	CheckAccess(ctx context.Context, req *request.CheckAccessRequest) (*response.CheckAccessResponse, error)
 }
@@ -0,0 +1,17 @@
+package services
+
+import "context"
+
+// Subject identifies the caller an authorization decision is made for.
+type Subject struct {
+	UserID string
+	Roles  []string
+	OrgID  string
+}
+
+// Authorizer is the single authorization entry point shared by the HTTP and
+// gRPC transports. Implementations may use the built-in role permissions or
+// delegate to an external policy engine.
+type Authorizer interface {
+	Authorize(ctx context.Context, subject *Subject, action, resource string) (bool, error)
+}
@@ -0,0 +1,10 @@
+package services
+
+import "context"
+
+// DefaultRoleResolver decides which roles a newly registered user receives.
+type DefaultRoleResolver interface {
+	// ResolveDefaultRoles returns role names for a registration made through
+	// clientID, which may be empty when the client did not identify itself.
+	ResolveDefaultRoles(ctx context.Context, clientID string) ([]string, error)
+}
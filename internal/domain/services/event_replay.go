@@ -0,0 +1,14 @@
+package services
+
+import (
+	"context"
+
+	"github.com/vagonaizer/authenitfication-service/internal/dto/request"
+	"github.com/vagonaizer/authenitfication-service/internal/dto/response"
+)
+
+// EventReplayService republishes events from the event log, so downstream
+// services can rebuild their state after losing events.
+type EventReplayService interface {
+	ReplayEvents(ctx context.Context, req *request.ReplayEventsRequest) (*response.ReplayEventsResponse, error)
+}
@@ -0,0 +1,27 @@
+package services
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/vagonaizer/authenitfication-service/internal/dto/request"
+	"github.com/vagonaizer/authenitfication-service/internal/dto/response"
+)
+
+type GroupService interface {
+	CreateGroup(ctx context.Context, req *request.CreateGroupRequest) (*response.GroupResponse, error)
+	GetGroup(ctx context.Context, groupID uuid.UUID) (*response.GroupResponse, error)
+	ListGroups(ctx context.Context) (*response.GroupsListResponse, error)
+	UpdateGroup(ctx context.Context, req *request.UpdateGroupRequest) (*response.GroupResponse, error)
+	DeleteGroup(ctx context.Context, groupID uuid.UUID) error
+
+	ListMembers(ctx context.Context, groupID uuid.UUID) (*response.GroupMembersResponse, error)
+	AddMember(ctx context.Context, req *request.GroupMemberRequest) error
+	RemoveMember(ctx context.Context, req *request.GroupMemberRequest) error
+
+	GetGroupRoles(ctx context.Context, groupID uuid.UUID) (*response.GroupRolesResponse, error)
+	// GetUserGroupRoles returns the roles a user inherits from their groups.
+	GetUserGroupRoles(ctx context.Context, userID uuid.UUID) (*response.UserRolesResponse, error)
+	AssignRole(ctx context.Context, req *request.GroupRoleRequest) error
+	RemoveRole(ctx context.Context, req *request.GroupRoleRequest) error
+}
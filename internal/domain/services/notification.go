@@ -0,0 +1,17 @@
+package services
+
+import (
+	"context"
+	"time"
+)
+
+// NotificationService hands emails and text messages to the notification
+// pipeline. locale is the recipient's preferred language, which the pipeline
+// uses to pick the template.
+type NotificationService interface {
+	SendWelcomeEmail(ctx context.Context, userID, email, locale string) error
+	SendPasswordResetEmail(ctx context.Context, userID, email, locale, resetToken string) error
+	SendVerificationEmail(ctx context.Context, userID, email, locale, verificationToken string) error
+	SendPhoneVerificationCode(ctx context.Context, userID, phone, locale, code string) error
+	SendDeletionScheduledEmail(ctx context.Context, userID, email, locale string, effectiveAt time.Time) error
+}
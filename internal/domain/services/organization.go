@@ -0,0 +1,34 @@
+package services
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/vagonaizer/authenitfication-service/internal/domain/entities"
+	"github.com/vagonaizer/authenitfication-service/internal/dto/request"
+	"github.com/vagonaizer/authenitfication-service/internal/dto/response"
+)
+
+type OrganizationService interface {
+	CreateOrganization(ctx context.Context, req *request.CreateOrganizationRequest) (*response.OrganizationResponse, error)
+	GetOrganization(ctx context.Context, actorID, orgID uuid.UUID) (*response.OrganizationResponse, error)
+	ListUserOrganizations(ctx context.Context, userID uuid.UUID) (*response.OrganizationsListResponse, error)
+	UpdateOrganization(ctx context.Context, req *request.UpdateOrganizationRequest) (*response.OrganizationResponse, error)
+	DeleteOrganization(ctx context.Context, actorID, orgID uuid.UUID) error
+
+	ListMembers(ctx context.Context, actorID, orgID uuid.UUID) (*response.OrganizationMembersResponse, error)
+	AddMember(ctx context.Context, req *request.AddOrganizationMemberRequest) error
+	UpdateMemberRole(ctx context.Context, req *request.UpdateOrganizationMemberRequest) error
+	RemoveMember(ctx context.Context, req *request.RemoveOrganizationMemberRequest) error
+
+	CreateInvitation(ctx context.Context, req *request.CreateInvitationRequest) (*response.InvitationResponse, error)
+	ListInvitations(ctx context.Context, actorID, orgID uuid.UUID) (*response.InvitationsListResponse, error)
+	RevokeInvitation(ctx context.Context, actorID, orgID, invitationID uuid.UUID) error
+	AcceptInvitation(ctx context.Context, userID uuid.UUID, token string) (*response.OrganizationResponse, error)
+	DeclineInvitation(ctx context.Context, userID uuid.UUID, token string) error
+	// ValidateInvitation checks that token is a pending invitation for email
+	// without consuming it, so registration can fail before creating the user.
+	ValidateInvitation(ctx context.Context, token, email string) error
+
+	GetMembership(ctx context.Context, orgID, userID uuid.UUID) (*entities.OrganizationMember, error)
+}
@@ -0,0 +1,17 @@
+package services
+
+import (
+	"context"
+
+	"github.com/vagonaizer/authenitfication-service/internal/domain/entities"
+)
+
+type PermissionService interface {
+	// ResolvePermissions returns the sorted permissions roles hold unconditionally.
+	ResolvePermissions(ctx context.Context, roles []string) ([]string, error)
+	// HasPermission reports whether any role holds the permission unconditionally.
+	HasPermission(ctx context.Context, roles []string, permission string) (bool, error)
+	// GetGrants returns every grant of the permission across roles, including conditional ones.
+	GetGrants(ctx context.Context, roles []string, permission string) ([]*entities.PermissionGrant, error)
+	InvalidateRole(ctx context.Context, roleName string) error
+}
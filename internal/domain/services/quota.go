@@ -0,0 +1,22 @@
+package services
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/vagonaizer/authenitfication-service/internal/dto/request"
+	"github.com/vagonaizer/authenitfication-service/internal/dto/response"
+)
+
+// QuotaService enforces per-organization limits. The Check methods return a
+// QUOTA_EXCEEDED error when the operation would exceed a limit.
+type QuotaService interface {
+	GetQuota(ctx context.Context, orgID uuid.UUID) (*response.OrganizationQuotaResponse, error)
+	UpdateQuota(ctx context.Context, req *request.UpdateOrganizationQuotaRequest) (*response.OrganizationQuotaResponse, error)
+
+	CheckMemberLimit(ctx context.Context, orgID uuid.UUID) error
+	// CheckSessionLimit is called before sessionID is scoped to orgID.
+	CheckSessionLimit(ctx context.Context, orgID, userID, sessionID uuid.UUID) error
+	// CheckTokenRate counts one token issued within orgID.
+	CheckTokenRate(ctx context.Context, orgID uuid.UUID) error
+}
@@ -0,0 +1,21 @@
+package services
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/vagonaizer/authenitfication-service/internal/dto/request"
+	"github.com/vagonaizer/authenitfication-service/internal/dto/response"
+)
+
+type RoleService interface {
+	CreateRole(ctx context.Context, req *request.CreateRoleRequest) (*response.RoleResponse, error)
+	GetRole(ctx context.Context, roleID uuid.UUID) (*response.RoleResponse, error)
+	ListRoles(ctx context.Context) (*response.RolesListResponse, error)
+	UpdateRole(ctx context.Context, req *request.UpdateRoleRequest) (*response.RoleResponse, error)
+	DeleteRole(ctx context.Context, roleID uuid.UUID) error
+
+	GetRolePermissions(ctx context.Context, roleID uuid.UUID) (*response.RolePermissionsResponse, error)
+	GrantPermission(ctx context.Context, req *request.GrantPermissionRequest) error
+	RevokePermission(ctx context.Context, roleID uuid.UUID, permission string) error
+}
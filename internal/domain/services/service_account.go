@@ -0,0 +1,23 @@
+package services
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/vagonaizer/authenitfication-service/internal/dto/request"
+	"github.com/vagonaizer/authenitfication-service/internal/dto/response"
+)
+
+// ServiceAccountService manages non-human accounts and their key/secret
+// credentials. Roles are assigned to service accounts like to any user.
+type ServiceAccountService interface {
+	CreateServiceAccount(ctx context.Context, req *request.CreateServiceAccountRequest) (*response.ServiceAccountCredentialsResponse, error)
+	GetServiceAccount(ctx context.Context, id uuid.UUID) (*response.ServiceAccountResponse, error)
+	ListServiceAccounts(ctx context.Context, page, pageSize int) (*response.ServiceAccountsListResponse, error)
+	DeleteServiceAccount(ctx context.Context, actorID, id uuid.UUID) error
+
+	CreateKey(ctx context.Context, actorID, id uuid.UUID) (*response.ServiceAccountCredentialsResponse, error)
+	// RotateKey issues a new key and revokes all existing ones.
+	RotateKey(ctx context.Context, actorID, id uuid.UUID) (*response.ServiceAccountCredentialsResponse, error)
+	RevokeKey(ctx context.Context, actorID, id uuid.UUID, keyID string) error
+}
@@ -0,0 +1,14 @@
+package services
+
+import (
+	"context"
+
+	"github.com/vagonaizer/authenitfication-service/internal/dto/response"
+)
+
+// StatsService serves aggregate figures for administrators.
+type StatsService interface {
+	// GetUserStats reports registrations for each of the last days days,
+	// today included.
+	GetUserStats(ctx context.Context, days int) (*response.UserStatsResponse, error)
+}
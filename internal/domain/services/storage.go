@@ -0,0 +1,12 @@
+package services
+
+import "context"
+
+// FileStorage keeps uploaded files and serves them from public URLs.
+type FileStorage interface {
+	// Save stores data under key and returns the public URL of the file.
+	Save(ctx context.Context, key, contentType string, data []byte) (string, error)
+	// Delete removes the file behind a URL returned by Save. URLs that do not
+	// belong to the storage are ignored.
+	Delete(ctx context.Context, url string) error
+}
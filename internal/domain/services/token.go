@@ -10,17 +10,28 @@ import (
 type TokenService interface {
 	GenerateAccessToken(ctx context.Context, userID uuid.UUID, roles []string) (string, error)
 	GenerateRefreshToken(ctx context.Context) (string, error)
-	ValidateAccessToken(ctx context.Context, token string) (*TokenClaims, error)
+	ValidateAccessToken(ctx context.Context, token string, tenant *uuid.UUID) (*TokenClaims, error)
 	ValidateRefreshToken(ctx context.Context, token string) (*TokenClaims, error)
 	RevokeToken(ctx context.Context, token string) error
 	GetTokenExpiration(ctx context.Context, token string) (time.Time, error)
 }
 
+// TokenRevocationService invalidates access tokens before they expire.
+type TokenRevocationService interface {
+	// RevokeUserTokens revokes every access token issued to the user so far.
+	RevokeUserTokens(ctx context.Context, userID uuid.UUID) error
+	// RevokeToken revokes a single access token by its ID until expiresAt.
+	RevokeToken(ctx context.Context, tokenID string, expiresAt time.Time) error
+	IsRevoked(ctx context.Context, userID uuid.UUID, tokenID string, issuedAt time.Time) (bool, error)
+}
+
 type TokenClaims struct {
-	UserID    uuid.UUID `json:"user_id"`
-	Email     string    `json:"email"`
-	Username  string    `json:"username"`
-	Roles     []string  `json:"roles"`
-	ExpiresAt time.Time `json:"expires_at"`
-	IssuedAt  time.Time `json:"issued_at"`
+	UserID    uuid.UUID  `json:"user_id"`
+	Email     string     `json:"email"`
+	Username  string     `json:"username"`
+	Roles     []string   `json:"roles"`
+	OrgID     *uuid.UUID `json:"org_id,omitempty"`
+	Groups    []string   `json:"groups,omitempty"`
+	ExpiresAt time.Time  `json:"expires_at"`
+	IssuedAt  time.Time  `json:"issued_at"`
 }
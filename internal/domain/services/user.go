@@ -9,14 +9,55 @@ import (
 )
 
 type UserService interface {
+	CreateUser(ctx context.Context, req *request.CreateUserRequest) (*response.CreateUserResponse, error)
+	ImportUsers(ctx context.Context, req *request.ImportUsersRequest) (*response.ImportUsersResponse, error)
 	GetProfile(ctx context.Context, userID uuid.UUID) (*response.UserResponse, error)
 	UpdateProfile(ctx context.Context, req *request.UpdateUserRequest) (*response.UserResponse, error)
+	UploadAvatar(ctx context.Context, userID uuid.UUID, data []byte) (*response.UserResponse, error)
+	DeleteAvatar(ctx context.Context, userID uuid.UUID) error
 	DeleteAccount(ctx context.Context, userID uuid.UUID) error
+	// PurgeAccount irreversibly anonymizes the caller's account.
+	PurgeAccount(ctx context.Context, req *request.PurgeAccountRequest) error
+	// ScheduleDeletion purges the caller's account at a later date unless it
+	// is cancelled first.
+	ScheduleDeletion(ctx context.Context, req *request.ScheduleDeletionRequest) (*response.DeletionScheduleResponse, error)
+	CancelDeletion(ctx context.Context, userID uuid.UUID) error
 	ListUsers(ctx context.Context, req *request.ListUsersRequest) (*response.UsersListResponse, error)
+	SearchUsers(ctx context.Context, req *request.SearchUsersRequest) (*response.UserSearchResponse, error)
 	GetUserByID(ctx context.Context, userID uuid.UUID) (*response.UserResponse, error)
-	ActivateUser(ctx context.Context, userID uuid.UUID) error
-	DeactivateUser(ctx context.Context, userID uuid.UUID) error
+	// GetUserDetail is GetUserByID plus the internal notes on the user.
+	GetUserDetail(ctx context.Context, userID uuid.UUID) (*response.AdminUserResponse, error)
+	ListNotes(ctx context.Context, userID uuid.UUID) ([]*response.UserNoteResponse, error)
+	CreateNote(ctx context.Context, req *request.UserNoteRequest) (*response.UserNoteResponse, error)
+	// UpdateNote is limited to the note's author.
+	UpdateNote(ctx context.Context, req *request.UserNoteRequest) (*response.UserNoteResponse, error)
+	DeleteNote(ctx context.Context, userID, noteID uuid.UUID) error
+	ActivateUser(ctx context.Context, actorID *uuid.UUID, userID uuid.UUID) error
+	// DeactivateUser also signs the user out of every session and revokes
+	// the access tokens already issued.
+	DeactivateUser(ctx context.Context, actorID *uuid.UUID, userID uuid.UUID) error
+	// BanUser blocks login until the ban ends and revokes the user's sessions.
+	BanUser(ctx context.Context, req *request.BanUserRequest) (*response.UserBanResponse, error)
+	UnbanUser(ctx context.Context, actorID, userID uuid.UUID) error
+	// RequirePasswordChange restricts the user's next login to changing the
+	// password and signs them out everywhere. WaivePasswordChange lifts it.
+	RequirePasswordChange(ctx context.Context, actorID, userID uuid.UUID) error
+	WaivePasswordChange(ctx context.Context, actorID, userID uuid.UUID) error
+	// RevokeSessions signs the user out of every session and revokes the
+	// access tokens already issued, on behalf of an admin.
+	RevokeSessions(ctx context.Context, actorID, userID uuid.UUID) error
+	// MergeUsers moves the duplicate's roles and memberships onto the primary
+	// user and retires the duplicate.
+	MergeUsers(ctx context.Context, req *request.MergeUsersRequest) (*response.UserMergeResponse, error)
+	MergeOwnAccount(ctx context.Context, req *request.MergeOwnAccountRequest) (*response.UserMergeResponse, error)
 	AssignRole(ctx context.Context, req *request.AssignRoleRequest) error
 	RemoveRole(ctx context.Context, req *request.RemoveRoleRequest) error
-	GetUserRoles(ctx context.Context, userID uuid.UUID) (*response.UserRolesResponse, error)
+	BulkAssignRole(ctx context.Context, req *request.BulkAssignRoleRequest) (*response.BulkRoleResponse, error)
+	BulkRemoveRole(ctx context.Context, req *request.BulkRemoveRoleRequest) (*response.BulkRoleResponse, error)
+	GetUserRoles(ctx context.Context, userID uuid.UUID, scopeID *uuid.UUID) (*response.UserRolesResponse, error)
+	ListRoleAudit(ctx context.Context, req *request.ListRoleAuditRequest) (*response.RoleAuditListResponse, error)
+	// ListActivity returns the user's activity timeline, newest first.
+	ListActivity(ctx context.Context, req *request.ListUserActivityRequest) (*response.UserActivityListResponse, error)
+	// ListLoginHistory returns the user's login attempts, newest first.
+	ListLoginHistory(ctx context.Context, req *request.ListLoginHistoryRequest) (*response.LoginHistoryResponse, error)
 }
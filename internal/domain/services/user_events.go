@@ -0,0 +1,15 @@
+package services
+
+import (
+	"github.com/google/uuid"
+	"github.com/vagonaizer/authenitfication-service/internal/domain/entities"
+)
+
+// UserEventStream delivers the live events of users to the streams open on
+// this replica, whichever replica published them.
+type UserEventStream interface {
+	// Subscribe returns the events of userID published from now on. The
+	// channel is closed by cancel, or when the stream shuts down; events are
+	// dropped rather than queued for a subscriber that falls behind.
+	Subscribe(userID uuid.UUID) (events <-chan *entities.UserEvent, cancel func())
+}
@@ -0,0 +1,33 @@
+package services
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/vagonaizer/authenitfication-service/internal/domain/entities"
+)
+
+// VerificationService issues and redeems email verification tokens, password
+// reset tokens and phone one-time passcodes.
+type VerificationService interface {
+	// SendVerification issues a new token for user and emails it. Tokens
+	// issued earlier stop working.
+	SendVerification(ctx context.Context, user *entities.User) error
+	// ResendVerification succeeds silently for unknown or already verified
+	// emails so it cannot be used to probe for accounts.
+	ResendVerification(ctx context.Context, email string) error
+	VerifyEmail(ctx context.Context, token string) error
+	// SendPasswordReset emails a password reset token to the owner of email.
+	// Like ResendVerification, it succeeds silently for unknown emails; its
+	// rate limit counts requests per email whether or not it is registered.
+	// Tokens issued earlier stop working.
+	SendPasswordReset(ctx context.Context, email string) error
+	// RedeemPasswordReset returns the user a reset token was issued to and
+	// invalidates it, so a token resets a password once.
+	RedeemPasswordReset(ctx context.Context, token string) (uuid.UUID, error)
+	// StartPhoneVerification texts a code to phone. The number is only stored
+	// on the user once VerifyPhone confirms the code.
+	StartPhoneVerification(ctx context.Context, userID uuid.UUID, phone string) error
+	VerifyPhone(ctx context.Context, userID uuid.UUID, code string) error
+	RemovePhone(ctx context.Context, userID uuid.UUID) error
+}
@@ -1,16 +1,27 @@
 package request
 
+import "github.com/google/uuid"
+
 type RegisterRequest struct {
 	Email     string `json:"email" validate:"required,email"`
 	Username  string `json:"username" validate:"required,min=3,max=50"`
 	Password  string `json:"password" validate:"required,min=8"`
 	FirstName string `json:"first_name" validate:"max=100"`
 	LastName  string `json:"last_name" validate:"max=100"`
+	ClientID  string `json:"client_id" validate:"max=100"`
+	// InvitationToken joins the new account to the inviting organization.
+	InvitationToken string `json:"invitation_token" validate:"max=128"`
+	Locale          string `json:"locale" validate:"max=35"`
+	Timezone        string `json:"timezone" validate:"max=64"`
 }
 
 type LoginRequest struct {
 	Email    string `json:"email" validate:"required,email"`
 	Password string `json:"password" validate:"required"`
+	// CancelDeletion cancels a pending scheduled deletion of the account.
+	CancelDeletion bool `json:"cancel_deletion"`
+	// Country is filled in by the transport from a trusted proxy header.
+	Country string `json:"-"`
 }
 
 type RefreshTokenRequest struct {
@@ -27,11 +38,34 @@ type ChangePasswordRequest struct {
 	NewPassword string `json:"new_password" validate:"required,min=8"`
 }
 
+type ResendVerificationRequest struct {
+	Email string `json:"email" validate:"required,email"`
+}
+
+type VerifyEmailRequest struct {
+	Token string `json:"token" validate:"required,max=128"`
+}
+
 type ResetPasswordRequest struct {
 	Email string `json:"email" validate:"required,email"`
 }
 
 type ConfirmResetPasswordRequest struct {
-	Token       string `json:"token" validate:"required"`
+	Token       string `json:"token" validate:"required,max=128"`
 	NewPassword string `json:"new_password" validate:"required,min=8"`
 }
+
+type SwitchOrganizationRequest struct {
+	UserID         string `json:"-"`
+	RefreshToken   string `json:"refresh_token" validate:"required"`
+	OrganizationID string `json:"organization_id" validate:"omitempty,uuid"`
+}
+
+// CheckAccessRequest asks whether a user holds a permission. Resource narrows
+// the check to one object of the permission's resource type, e.g. "users/42".
+type CheckAccessRequest struct {
+	UserID     uuid.UUID  `json:"user_id" validate:"required"`
+	Permission string     `json:"permission" validate:"required,max=100"`
+	Resource   string     `json:"resource" validate:"max=255"`
+	OrgID      *uuid.UUID `json:"org_id"`
+}
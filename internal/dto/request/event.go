@@ -0,0 +1,20 @@
+package request
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ReplayEventsRequest selects logged events to republish: those logged from
+// From up to To, or up to now, optionally only those of one user or topic.
+// At most Limit events are replayed, capped by the configured maximum; with
+// DryRun they are only counted.
+type ReplayEventsRequest struct {
+	From   time.Time  `json:"from" validate:"required"`
+	To     *time.Time `json:"to"`
+	UserID *uuid.UUID `json:"user_id"`
+	Topic  string     `json:"topic" validate:"max=255"`
+	Limit  int        `json:"limit" validate:"min=0"`
+	DryRun bool       `json:"dry_run"`
+}
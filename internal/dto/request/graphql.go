@@ -0,0 +1,8 @@
+package request
+
+// GraphQLRequest is the body of a GraphQL query sent over HTTP.
+type GraphQLRequest struct {
+	Query         string                 `json:"query" validate:"required"`
+	OperationName string                 `json:"operationName"`
+	Variables     map[string]interface{} `json:"variables"`
+}
@@ -0,0 +1,28 @@
+package request
+
+import "github.com/google/uuid"
+
+type CreateGroupRequest struct {
+	Name        string     `json:"name" validate:"required,min=2,max=100"`
+	Description *string    `json:"description" validate:"omitempty,max=500"`
+	ParentID    *uuid.UUID `json:"parent_id"`
+}
+
+type UpdateGroupRequest struct {
+	GroupID     uuid.UUID  `json:"-"`
+	Name        *string    `json:"name" validate:"omitempty,min=2,max=100"`
+	Description *string    `json:"description" validate:"omitempty,max=500"`
+	ParentID    *uuid.UUID `json:"parent_id"`
+	// ClearParent detaches the group from its parent, making it top-level.
+	ClearParent bool `json:"clear_parent"`
+}
+
+type GroupMemberRequest struct {
+	GroupID uuid.UUID `json:"-"`
+	UserID  uuid.UUID `json:"user_id" validate:"required"`
+}
+
+type GroupRoleRequest struct {
+	GroupID uuid.UUID `json:"-"`
+	RoleID  uuid.UUID `json:"role_id" validate:"required"`
+}
@@ -0,0 +1,59 @@
+package request
+
+import "github.com/google/uuid"
+
+type CreateOrganizationRequest struct {
+	OwnerID     uuid.UUID `json:"-"`
+	Name        string    `json:"name" validate:"required,min=2,max=100"`
+	Slug        string    `json:"slug" validate:"required,min=3,max=63"`
+	Description *string   `json:"description" validate:"omitempty,max=500"`
+}
+
+type UpdateOrganizationRequest struct {
+	ActorID        uuid.UUID `json:"-"`
+	OrganizationID uuid.UUID `json:"-"`
+	Name           *string   `json:"name" validate:"omitempty,min=2,max=100"`
+	Slug           *string   `json:"slug" validate:"omitempty,min=3,max=63"`
+	Description    *string   `json:"description" validate:"omitempty,max=500"`
+}
+
+type AddOrganizationMemberRequest struct {
+	ActorID        uuid.UUID `json:"-"`
+	OrganizationID uuid.UUID `json:"-"`
+	UserID         uuid.UUID `json:"user_id" validate:"required"`
+	Role           string    `json:"role" validate:"required,oneof=owner admin member"`
+}
+
+type UpdateOrganizationMemberRequest struct {
+	ActorID        uuid.UUID `json:"-"`
+	OrganizationID uuid.UUID `json:"-"`
+	UserID         uuid.UUID `json:"-"`
+	Role           string    `json:"role" validate:"required,oneof=owner admin member"`
+}
+
+type RemoveOrganizationMemberRequest struct {
+	ActorID        uuid.UUID `json:"-"`
+	OrganizationID uuid.UUID `json:"-"`
+	UserID         uuid.UUID `json:"-"`
+}
+
+type CreateInvitationRequest struct {
+	ActorID        uuid.UUID `json:"-"`
+	OrganizationID uuid.UUID `json:"-"`
+	Email          string    `json:"email" validate:"required,email"`
+	Role           string    `json:"role" validate:"required,oneof=owner admin member"`
+}
+
+type InvitationTokenRequest struct {
+	Token string `json:"token" validate:"required,max=128"`
+}
+
+// UpdateOrganizationQuotaRequest replaces the quota overrides of an
+// organization. Omitted limits fall back to the configured defaults.
+type UpdateOrganizationQuotaRequest struct {
+	ActorID            uuid.UUID `json:"-"`
+	OrganizationID     uuid.UUID `json:"-"`
+	MaxMembers         *int      `json:"max_members" validate:"omitempty,min=0"`
+	MaxSessionsPerUser *int      `json:"max_sessions_per_user" validate:"omitempty,min=0"`
+	TokensPerMinute    *int      `json:"tokens_per_minute" validate:"omitempty,min=0"`
+}
@@ -0,0 +1,20 @@
+package request
+
+import "github.com/google/uuid"
+
+type CreateRoleRequest struct {
+	Name        string  `json:"name" validate:"required,min=2,max=50"`
+	Description *string `json:"description" validate:"omitempty,max=500"`
+}
+
+type UpdateRoleRequest struct {
+	RoleID      uuid.UUID `json:"-"`
+	Name        *string   `json:"name" validate:"omitempty,min=2,max=50"`
+	Description *string   `json:"description" validate:"omitempty,max=500"`
+}
+
+type GrantPermissionRequest struct {
+	RoleID     uuid.UUID `json:"-"`
+	Permission string    `json:"permission" validate:"required,max=100"`
+	Condition  *string   `json:"condition" validate:"omitempty,max=1000"`
+}
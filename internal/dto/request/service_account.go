@@ -0,0 +1,13 @@
+package request
+
+import "github.com/google/uuid"
+
+type CreateServiceAccountRequest struct {
+	ActorID uuid.UUID `json:"-"`
+	Name    string    `json:"name" validate:"required,min=3,max=50"`
+}
+
+type ServiceAccountTokenRequest struct {
+	KeyID  string `json:"key_id" validate:"required,max=64"`
+	Secret string `json:"secret" validate:"required,max=128"`
+}
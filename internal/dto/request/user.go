@@ -1,12 +1,71 @@
 package request
 
-import "github.com/google/uuid"
+import (
+	"time"
 
+	"github.com/google/uuid"
+)
+
+// UpdateUserRequest changes the profile of UserID. A non-empty IfMatch, the
+// If-Match header of the request, makes the update fail with
+// PreconditionFailed unless it lists the current ETag of the profile.
 type UpdateUserRequest struct {
 	UserID    uuid.UUID `json:"-"`
+	IfMatch   string    `json:"-"`
 	FirstName *string   `json:"first_name" validate:"omitempty,max=100"`
 	LastName  *string   `json:"last_name" validate:"omitempty,max=100"`
 	Username  *string   `json:"username" validate:"omitempty,min=3,max=50"`
+	Locale    *string   `json:"locale" validate:"omitempty,max=35"`
+	Timezone  *string   `json:"timezone" validate:"omitempty,max=64"`
+}
+
+// CreateUserRequest is an administrator creating an account directly. An
+// empty Password generates a temporary one that must be changed on first login.
+type CreateUserRequest struct {
+	ActorID    *uuid.UUID  `json:"-"`
+	ActorRoles []string    `json:"-"`
+	Email      string      `json:"email" validate:"required,email"`
+	Username   string      `json:"username" validate:"required,min=3,max=50"`
+	Password   string      `json:"password" validate:"omitempty,min=8"`
+	FirstName  *string     `json:"first_name" validate:"omitempty,max=100"`
+	LastName   *string     `json:"last_name" validate:"omitempty,max=100"`
+	RoleIDs    []uuid.UUID `json:"role_ids" validate:"max=20"`
+	IsVerified bool        `json:"is_verified"`
+
+	RequirePasswordChange bool `json:"require_password_change"`
+}
+
+// ImportUserRecord is one row of a bulk import. Rows are validated one by one
+// so a bad row is reported instead of rejecting the whole import. Without a
+// PasswordHash the account cannot log in until a password is set.
+type ImportUserRecord struct {
+	Email        string   `json:"email"`
+	Username     string   `json:"username"`
+	FirstName    *string  `json:"first_name"`
+	LastName     *string  `json:"last_name"`
+	Roles        []string `json:"roles"`
+	PasswordHash string   `json:"password_hash"`
+}
+
+type ImportUsersRequest struct {
+	ActorID    *uuid.UUID          `json:"-"`
+	ActorRoles []string            `json:"-"`
+	Users      []*ImportUserRecord `json:"users" validate:"required,min=1,max=5000"`
+}
+
+// PurgeAccountRequest confirms an irreversible account purge with the
+// current password, when the account has one.
+type PurgeAccountRequest struct {
+	UserID   uuid.UUID `json:"-"`
+	Password string    `json:"password" validate:"max=128"`
+}
+
+// ScheduleDeletionRequest schedules the caller's account for purging at
+// EffectiveAt, or after the configured delay when it is omitted.
+type ScheduleDeletionRequest struct {
+	UserID      uuid.UUID  `json:"-"`
+	Password    string     `json:"password" validate:"max=128"`
+	EffectiveAt *time.Time `json:"effective_at"`
 }
 
 type ListUsersRequest struct {
@@ -17,12 +76,116 @@ type ListUsersRequest struct {
 	SortDir  string `json:"sort_dir" validate:"oneof=asc desc"`
 }
 
+// SearchUsersRequest needs at least three characters, the shortest query the
+// trigram indexes can serve.
+type SearchUsersRequest struct {
+	Query string `json:"q" validate:"required,min=3,max=255"`
+	Limit int    `json:"limit" validate:"min=1,max=100"`
+}
+
+// BanUserRequest bans a user for Duration (a Go duration such as "72h"), or
+// permanently when Duration is empty. A zero ActorID records a ban requested
+// by another service.
+type BanUserRequest struct {
+	ActorID  uuid.UUID `json:"-"`
+	UserID   uuid.UUID `json:"-"`
+	Reason   string    `json:"reason" validate:"required,max=500"`
+	Duration string    `json:"duration" validate:"max=32"`
+}
+
+// MergeUsersRequest merges DuplicateID into PrimaryID.
+type MergeUsersRequest struct {
+	ActorID     uuid.UUID `json:"-"`
+	ActorRoles  []string  `json:"-"`
+	PrimaryID   uuid.UUID `json:"-"`
+	DuplicateID uuid.UUID `json:"duplicate_id" validate:"required"`
+}
+
+// MergeOwnAccountRequest merges another account into the caller's. Ownership
+// of the duplicate is proven with its email and password.
+type MergeOwnAccountRequest struct {
+	UserID   uuid.UUID `json:"-"`
+	Email    string    `json:"email" validate:"required,email"`
+	Password string    `json:"password" validate:"required,max=128"`
+}
+
+type StartPhoneVerificationRequest struct {
+	Phone string `json:"phone" validate:"required,max=32"`
+}
+
+type VerifyPhoneRequest struct {
+	Code string `json:"code" validate:"required,numeric,len=6"`
+}
+
+// UserNoteRequest creates a note on UserID, or edits NoteID when set.
+type UserNoteRequest struct {
+	ActorID uuid.UUID `json:"-"`
+	UserID  uuid.UUID `json:"-"`
+	NoteID  uuid.UUID `json:"-"`
+	Body    string    `json:"body" validate:"required,max=5000"`
+}
+
 type AssignRoleRequest struct {
-	UserID uuid.UUID `json:"user_id" validate:"required"`
-	RoleID uuid.UUID `json:"role_id" validate:"required"`
+	ActorID   *uuid.UUID `json:"-"`
+	UserID    uuid.UUID  `json:"user_id" validate:"required"`
+	RoleID    uuid.UUID  `json:"role_id" validate:"required"`
+	ScopeID   *uuid.UUID `json:"scope_id"`
+	ExpiresAt *time.Time `json:"expires_at"`
+	Reason    *string    `json:"reason" validate:"omitempty,max=500"`
 }
 
 type RemoveRoleRequest struct {
-	UserID uuid.UUID `json:"user_id" validate:"required"`
-	RoleID uuid.UUID `json:"role_id" validate:"required"`
+	ActorID *uuid.UUID `json:"-"`
+	UserID  uuid.UUID  `json:"user_id" validate:"required"`
+	RoleID  uuid.UUID  `json:"role_id" validate:"required"`
+	ScopeID *uuid.UUID `json:"scope_id"`
+	Reason  *string    `json:"reason" validate:"omitempty,max=500"`
+}
+
+// BulkUserFilter selects users for a bulk role change instead of listing them.
+type BulkUserFilter struct {
+	OrganizationID *uuid.UUID `json:"organization_id"`
+	GroupID        *uuid.UUID `json:"group_id"`
+	RoleID         *uuid.UUID `json:"role_id"`
+	IsActive       *bool      `json:"is_active"`
+}
+
+type BulkAssignRoleRequest struct {
+	ActorID   *uuid.UUID      `json:"-"`
+	RoleID    uuid.UUID       `json:"role_id" validate:"required"`
+	UserIDs   []uuid.UUID     `json:"user_ids" validate:"max=1000"`
+	Filter    *BulkUserFilter `json:"filter"`
+	ScopeID   *uuid.UUID      `json:"scope_id"`
+	ExpiresAt *time.Time      `json:"expires_at"`
+	Reason    *string         `json:"reason" validate:"omitempty,max=500"`
+}
+
+type BulkRemoveRoleRequest struct {
+	ActorID *uuid.UUID      `json:"-"`
+	RoleID  uuid.UUID       `json:"role_id" validate:"required"`
+	UserIDs []uuid.UUID     `json:"user_ids" validate:"max=1000"`
+	Filter  *BulkUserFilter `json:"filter"`
+	ScopeID *uuid.UUID      `json:"scope_id"`
+	Reason  *string         `json:"reason" validate:"omitempty,max=500"`
+}
+
+type ListUserActivityRequest struct {
+	UserID   uuid.UUID `json:"-"`
+	Type     string    `json:"type" validate:"omitempty,oneof=login logout password_changed role_assigned role_removed profile_updated account_merged password_change_required"`
+	Page     int       `json:"page" validate:"min=1"`
+	PageSize int       `json:"page_size" validate:"min=1,max=100"`
+}
+
+type ListLoginHistoryRequest struct {
+	UserID   uuid.UUID `json:"-"`
+	Page     int       `json:"page" validate:"min=1"`
+	PageSize int       `json:"page_size" validate:"min=1,max=100"`
+}
+
+type ListRoleAuditRequest struct {
+	UserID   *uuid.UUID `json:"user_id"`
+	RoleID   *uuid.UUID `json:"role_id"`
+	Action   string     `json:"action" validate:"omitempty,oneof=granted revoked expired"`
+	Page     int        `json:"page" validate:"min=1"`
+	PageSize int        `json:"page_size" validate:"min=1,max=100"`
 }
@@ -1,6 +1,11 @@
 package request
 
 import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
 	"github.com/go-playground/validator/v10"
 )
 
@@ -8,6 +13,15 @@ var validate *validator.Validate
 
 func init() {
 	validate = validator.New()
+
+	// Поля в ошибках называются как в JSON
+	validate.RegisterTagNameFunc(func(field reflect.StructField) string {
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			return ""
+		}
+		return name
+	})
 }
 
 func ValidateStruct(s interface{}) error {
@@ -17,3 +31,57 @@ func ValidateStruct(s interface{}) error {
 func GetValidator() *validator.Validate {
 	return validate
 }
+
+// ValidationDetails maps the JSON path of each field that failed validation,
+// such as "email" or "users[0].email", to a message naming the broken rule,
+// for ErrorResponse.Details. It returns nil for other errors.
+func ValidationDetails(err error) map[string]string {
+	var fieldErrors validator.ValidationErrors
+	if !errors.As(err, &fieldErrors) {
+		return nil
+	}
+
+	details := make(map[string]string, len(fieldErrors))
+	for _, fe := range fieldErrors {
+		// Namespace начинается с имени структуры запроса
+		_, path, _ := strings.Cut(fe.Namespace(), ".")
+		if _, ok := details[path]; !ok {
+			details[path] = fieldMessage(fe)
+		}
+	}
+	return details
+}
+
+// fieldMessage describes the rule fe broke.
+func fieldMessage(fe validator.FieldError) string {
+	unit := ""
+	switch fe.Kind() {
+	case reflect.String:
+		unit = " characters"
+	case reflect.Slice, reflect.Array, reflect.Map:
+		unit = " items"
+	}
+
+	switch fe.Tag() {
+	case "required":
+		return "is required"
+	case "email":
+		return "must be a valid email address"
+	case "uuid", "uuid4":
+		return "must be a valid UUID"
+	case "url":
+		return "must be a valid URL"
+	case "min":
+		return fmt.Sprintf("must be at least %s%s", fe.Param(), unit)
+	case "max":
+		return fmt.Sprintf("must be at most %s%s", fe.Param(), unit)
+	case "len":
+		return fmt.Sprintf("must be exactly %s%s", fe.Param(), unit)
+	case "oneof":
+		return "must be one of: " + strings.Join(strings.Fields(fe.Param()), ", ")
+	case "eqfield":
+		return "must match " + fe.Param()
+	default:
+		return fmt.Sprintf("failed the %q rule", fe.Tag())
+	}
+}
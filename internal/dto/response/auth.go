@@ -1,6 +1,10 @@
 package response
 
-import "time"
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
 
 type AuthResponse struct {
 	AccessToken  string        `json:"access_token"`
@@ -17,10 +21,40 @@ type TokenResponse struct {
 }
 
 type TokenClaimsResponse struct {
-	UserID    string    `json:"user_id"`
-	Email     string    `json:"email"`
-	Username  string    `json:"username"`
-	Roles     []string  `json:"roles"`
-	ExpiresAt time.Time `json:"expires_at"`
-	IssuedAt  time.Time `json:"issued_at"`
+	UserID   string   `json:"user_id"`
+	Email    string   `json:"email"`
+	Username string   `json:"username"`
+	Roles    []string `json:"roles"`
+	// ScopedRoles are granted only within OrgID; Roles apply everywhere.
+	ScopedRoles []string `json:"scoped_roles,omitempty"`
+	OrgID       string   `json:"org_id,omitempty"`
+	Groups      []string `json:"groups,omitempty"`
+	// Permissions is empty when the token omits them; see PermissionsOmitted.
+	Permissions        []string  `json:"permissions,omitempty"`
+	PermissionsOmitted bool      `json:"permissions_omitted,omitempty"`
+	ServiceAccount     bool      `json:"service_account,omitempty"`
+	ExpiresAt          time.Time `json:"expires_at"`
+	IssuedAt           time.Time `json:"issued_at"`
+}
+
+type CheckAccessResponse struct {
+	Allowed bool   `json:"allowed"`
+	Reason  string `json:"reason,omitempty"`
+}
+
+// CSRFTokenResponse carries the token browsers send back in the X-CSRF-Token
+// header of state-changing requests.
+type CSRFTokenResponse struct {
+	Token string `json:"csrf_token"`
+}
+
+// SessionResponse describes an active session without its refresh token.
+type SessionResponse struct {
+	ID             uuid.UUID  `json:"id"`
+	OrganizationID *uuid.UUID `json:"organization_id,omitempty"`
+	UserAgent      string     `json:"user_agent"`
+	IPAddress      string     `json:"ip_address"`
+	ExpiresAt      time.Time  `json:"expires_at"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
 }
@@ -1,10 +1,13 @@
 package response
 
+// ErrorResponse is the body of every error of the API. RequestID is the ID
+// of the request in the logs, filled in as the response is written.
 type ErrorResponse struct {
-	Error   string            `json:"error"`
-	Message string            `json:"message"`
-	Code    int               `json:"code"`
-	Details map[string]string `json:"details,omitempty"`
+	Error     string            `json:"error"`
+	Message   string            `json:"message"`
+	Code      int               `json:"code"`
+	Details   map[string]string `json:"details,omitempty"`
+	RequestID string            `json:"request_id,omitempty"`
 }
 
 type SuccessResponse struct {
@@ -12,8 +15,29 @@ type SuccessResponse struct {
 	Data    interface{} `json:"data,omitempty"`
 }
 
+// HealthResponse reports each dependency checked. Status is "unhealthy"
+// when a required dependency is down, and "degraded" when only optional ones
+// are; /ready reports "draining", checking nothing, while the replica shuts
+// down. Build is only reported by /health.
 type HealthResponse struct {
-	Status    string            `json:"status"`
-	Timestamp string            `json:"timestamp"`
-	Services  map[string]string `json:"services"`
+	Status    string                      `json:"status"`
+	Timestamp string                      `json:"timestamp"`
+	Build     *BuildInfo                  `json:"build,omitempty"`
+	Services  map[string]DependencyHealth `json:"services"`
+}
+
+// DependencyHealth is the result of checking one dependency, with the time
+// the check took.
+type DependencyHealth struct {
+	Status    string  `json:"status"`
+	Required  bool    `json:"required"`
+	LatencyMs float64 `json:"latency_ms"`
+}
+
+// BuildInfo identifies the running build of the service.
+type BuildInfo struct {
+	Version   string `json:"version"`
+	BuildTime string `json:"build_time"`
+	GoVersion string `json:"go_version"`
+	Uptime    string `json:"uptime"`
 }
@@ -0,0 +1,13 @@
+package response
+
+import "time"
+
+// ReplayEventsResponse reports a replay. Truncated means more events matched
+// than the limit allowed; a new replay from LastEventAt continues it, sending
+// the events logged at that instant again.
+type ReplayEventsResponse struct {
+	Replayed    int        `json:"replayed"`
+	DryRun      bool       `json:"dry_run"`
+	Truncated   bool       `json:"truncated"`
+	LastEventAt *time.Time `json:"last_event_at,omitempty"`
+}
@@ -0,0 +1,35 @@
+package response
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type GroupResponse struct {
+	ID          uuid.UUID  `json:"id"`
+	Name        string     `json:"name"`
+	Description *string    `json:"description"`
+	ParentID    *uuid.UUID `json:"parent_id,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+}
+
+type GroupsListResponse struct {
+	Groups []*GroupResponse `json:"groups"`
+}
+
+type GroupMemberResponse struct {
+	UserID    uuid.UUID `json:"user_id"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type GroupMembersResponse struct {
+	GroupID uuid.UUID              `json:"group_id"`
+	Members []*GroupMemberResponse `json:"members"`
+}
+
+type GroupRolesResponse struct {
+	GroupID uuid.UUID       `json:"group_id"`
+	Roles   []*RoleResponse `json:"roles"`
+}
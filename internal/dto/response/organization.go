@@ -0,0 +1,64 @@
+package response
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type OrganizationResponse struct {
+	ID          uuid.UUID `json:"id"`
+	Name        string    `json:"name"`
+	Slug        string    `json:"slug"`
+	Description *string   `json:"description"`
+	IsActive    bool      `json:"is_active"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+type OrganizationsListResponse struct {
+	Organizations []*OrganizationResponse `json:"organizations"`
+}
+
+type OrganizationMemberResponse struct {
+	UserID    uuid.UUID `json:"user_id"`
+	Role      string    `json:"role"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type OrganizationMembersResponse struct {
+	OrganizationID uuid.UUID                     `json:"organization_id"`
+	Members        []*OrganizationMemberResponse `json:"members"`
+}
+
+type InvitationResponse struct {
+	ID             uuid.UUID  `json:"id"`
+	OrganizationID uuid.UUID  `json:"organization_id"`
+	Email          string     `json:"email"`
+	Role           string     `json:"role"`
+	Status         string     `json:"status"`
+	InvitedBy      *uuid.UUID `json:"invited_by"`
+	ExpiresAt      time.Time  `json:"expires_at"`
+	RespondedAt    *time.Time `json:"responded_at"`
+	CreatedAt      time.Time  `json:"created_at"`
+	// Token is only returned when the invitation is created.
+	Token string `json:"token,omitempty"`
+}
+
+type InvitationsListResponse struct {
+	OrganizationID uuid.UUID             `json:"organization_id"`
+	Invitations    []*InvitationResponse `json:"invitations"`
+}
+
+// OrganizationQuotaResponse reports the effective limits (0 is unlimited)
+// and the current member count of an organization.
+type OrganizationQuotaResponse struct {
+	OrganizationID     uuid.UUID  `json:"organization_id"`
+	MaxMembers         int        `json:"max_members"`
+	MaxSessionsPerUser int        `json:"max_sessions_per_user"`
+	TokensPerMinute    int        `json:"tokens_per_minute"`
+	Members            int        `json:"members"`
+	IsOverridden       bool       `json:"is_overridden"`
+	UpdatedBy          *uuid.UUID `json:"updated_by,omitempty"`
+	UpdatedAt          *time.Time `json:"updated_at,omitempty"`
+}
@@ -0,0 +1,39 @@
+package response
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ServiceAccountResponse struct {
+	ID        uuid.UUID                    `json:"id"`
+	Name      string                       `json:"name"`
+	IsActive  bool                         `json:"is_active"`
+	CreatedAt time.Time                    `json:"created_at"`
+	UpdatedAt time.Time                    `json:"updated_at"`
+	Keys      []*ServiceAccountKeyResponse `json:"keys,omitempty"`
+}
+
+type ServiceAccountKeyResponse struct {
+	KeyID      string     `json:"key_id"`
+	CreatedBy  *uuid.UUID `json:"created_by"`
+	LastUsedAt *time.Time `json:"last_used_at"`
+	RevokedAt  *time.Time `json:"revoked_at"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+type ServiceAccountsListResponse struct {
+	ServiceAccounts []*ServiceAccountResponse `json:"service_accounts"`
+	Page            int                       `json:"page"`
+	PageSize        int                       `json:"page_size"`
+}
+
+// ServiceAccountCredentialsResponse is the only place a secret is ever
+// returned; it cannot be retrieved again later.
+type ServiceAccountCredentialsResponse struct {
+	ServiceAccountID uuid.UUID `json:"service_account_id"`
+	KeyID            string    `json:"key_id"`
+	Secret           string    `json:"secret"`
+	CreatedAt        time.Time `json:"created_at"`
+}
@@ -0,0 +1,20 @@
+package response
+
+import "time"
+
+type UserStatsResponse struct {
+	TotalUsers        int64            `json:"total_users"`
+	VerifiedUsers     int64            `json:"verified_users"`
+	VerifiedRatio     float64          `json:"verified_ratio"`
+	ActiveUsers7d     int64            `json:"active_users_7d"`
+	ActiveUsers30d    int64            `json:"active_users_30d"`
+	ActiveSessions    int64            `json:"active_sessions"`
+	UsersWithSessions int64            `json:"users_with_sessions"`
+	Registrations     []DailyCountItem `json:"registrations"`
+	GeneratedAt       time.Time        `json:"generated_at"`
+}
+
+type DailyCountItem struct {
+	Date  string `json:"date"`
+	Count int64  `json:"count"`
+}
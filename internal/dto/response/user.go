@@ -4,6 +4,7 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/vagonaizer/authenitfication-service/pkg/utils"
 )
 
 type UserResponse struct {
@@ -12,11 +13,74 @@ type UserResponse struct {
 	Username    string     `json:"username"`
 	FirstName   *string    `json:"first_name"`
 	LastName    *string    `json:"last_name"`
+	AvatarURL   *string    `json:"avatar_url"`
 	IsActive    bool       `json:"is_active"`
 	IsVerified  bool       `json:"is_verified"`
 	LastLoginAt *time.Time `json:"last_login_at"`
 	CreatedAt   time.Time  `json:"created_at"`
 	UpdatedAt   time.Time  `json:"updated_at"`
+
+	PasswordChangeRequired bool `json:"password_change_required,omitempty"`
+
+	Phone           *string    `json:"phone,omitempty"`
+	PhoneVerifiedAt *time.Time `json:"phone_verified_at,omitempty"`
+
+	Locale   string `json:"locale"`
+	Timezone string `json:"timezone"`
+
+	DeletionScheduledAt *time.Time `json:"deletion_scheduled_at,omitempty"`
+}
+
+// ETag identifies the version of the profile for If-Match.
+func (r *UserResponse) ETag() string {
+	return utils.ETag(r.UpdatedAt)
+}
+
+// CreateUserResponse carries the generated temporary password, which is only
+// ever returned here.
+type CreateUserResponse struct {
+	User              *UserResponse `json:"user"`
+	Roles             []string      `json:"roles"`
+	TemporaryPassword string        `json:"temporary_password,omitempty"`
+}
+
+type DeletionScheduleResponse struct {
+	EffectiveAt time.Time `json:"effective_at"`
+}
+
+type UserBanResponse struct {
+	UserID      uuid.UUID  `json:"user_id"`
+	Reason      string     `json:"reason"`
+	BannedAt    time.Time  `json:"banned_at"`
+	BannedUntil *time.Time `json:"banned_until,omitempty"`
+	BannedBy    *uuid.UUID `json:"banned_by,omitempty"`
+}
+
+type UserMergeResponse struct {
+	PrimaryUserID      uuid.UUID `json:"primary_user_id"`
+	DuplicateUserID    uuid.UUID `json:"duplicate_user_id"`
+	RolesMoved         int       `json:"roles_moved"`
+	OrganizationsMoved int       `json:"organizations_moved"`
+	GroupsMoved        int       `json:"groups_moved"`
+}
+
+// ImportUsersResponse reports every row of an import by its 1-based position.
+type ImportUsersResponse struct {
+	Total   int                  `json:"total"`
+	Created []*ImportUserResult  `json:"created"`
+	Failed  []*ImportUserFailure `json:"failed"`
+}
+
+type ImportUserResult struct {
+	Row    int       `json:"row"`
+	UserID uuid.UUID `json:"user_id"`
+	Email  string    `json:"email"`
+}
+
+type ImportUserFailure struct {
+	Row   int    `json:"row"`
+	Email string `json:"email"`
+	Error string `json:"error"`
 }
 
 type UsersListResponse struct {
@@ -27,9 +91,97 @@ type UsersListResponse struct {
 	TotalPages int             `json:"total_pages"`
 }
 
+type UserSearchResponse struct {
+	Users []*UserResponse `json:"users"`
+}
+
 type UserRolesResponse struct {
-	UserID uuid.UUID       `json:"user_id"`
-	Roles  []*RoleResponse `json:"roles"`
+	UserID  uuid.UUID       `json:"user_id"`
+	ScopeID *uuid.UUID      `json:"scope_id,omitempty"`
+	Roles   []*RoleResponse `json:"roles"`
+}
+
+type BulkRoleFailure struct {
+	UserID uuid.UUID `json:"user_id"`
+	Error  string    `json:"error"`
+}
+
+type BulkRoleResponse struct {
+	RoleID    uuid.UUID          `json:"role_id"`
+	Succeeded []uuid.UUID        `json:"succeeded"`
+	Failed    []*BulkRoleFailure `json:"failed"`
+}
+
+type RoleAuditEntryResponse struct {
+	ID        uuid.UUID  `json:"id"`
+	UserID    uuid.UUID  `json:"user_id"`
+	RoleID    uuid.UUID  `json:"role_id"`
+	RoleName  string     `json:"role_name"`
+	ScopeID   *uuid.UUID `json:"scope_id,omitempty"`
+	Action    string     `json:"action"`
+	ActorID   *uuid.UUID `json:"actor_id"`
+	Reason    *string    `json:"reason"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+type RoleAuditListResponse struct {
+	Entries    []*RoleAuditEntryResponse `json:"entries"`
+	Total      int64                     `json:"total"`
+	Page       int                       `json:"page"`
+	PageSize   int                       `json:"page_size"`
+	TotalPages int                       `json:"total_pages"`
+}
+
+type UserActivityResponse struct {
+	ID        uuid.UUID         `json:"id"`
+	Type      string            `json:"type"`
+	ActorID   *uuid.UUID        `json:"actor_id,omitempty"`
+	IPAddress *string           `json:"ip_address,omitempty"`
+	UserAgent *string           `json:"user_agent,omitempty"`
+	Details   map[string]string `json:"details,omitempty"`
+	CreatedAt time.Time         `json:"created_at"`
+}
+
+type UserNoteResponse struct {
+	ID        uuid.UUID  `json:"id"`
+	AuthorID  *uuid.UUID `json:"author_id,omitempty"`
+	Body      string     `json:"body"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+}
+
+// AdminUserResponse is the admin view of a user, including internal notes
+// that are never shown to the user themselves.
+type AdminUserResponse struct {
+	*UserResponse
+	Notes []*UserNoteResponse `json:"notes"`
+}
+
+type UserActivityListResponse struct {
+	Activities []*UserActivityResponse `json:"activities"`
+	Total      int64                   `json:"total"`
+	Page       int                     `json:"page"`
+	PageSize   int                     `json:"page_size"`
+	TotalPages int                     `json:"total_pages"`
+}
+
+type LoginAttemptResponse struct {
+	ID            uuid.UUID `json:"id"`
+	Success       bool      `json:"success"`
+	FailureReason *string   `json:"failure_reason,omitempty"`
+	IPAddress     string    `json:"ip_address"`
+	UserAgent     string    `json:"user_agent"`
+	Country       *string   `json:"country,omitempty"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+type LoginHistoryResponse struct {
+	Attempts   []*LoginAttemptResponse `json:"attempts"`
+	Total      int64                   `json:"total"`
+	Page       int                     `json:"page"`
+	PageSize   int                     `json:"page_size"`
+	TotalPages int                     `json:"total_pages"`
 }
 
 type RoleResponse struct {
@@ -37,4 +189,19 @@ type RoleResponse struct {
 	Name        string    `json:"name"`
 	Description *string   `json:"description"`
 	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+type RolesListResponse struct {
+	Roles []*RoleResponse `json:"roles"`
+}
+
+type PermissionGrantResponse struct {
+	Permission string `json:"permission"`
+	Condition  string `json:"condition,omitempty"`
+}
+
+type RolePermissionsResponse struct {
+	RoleID      uuid.UUID                  `json:"role_id"`
+	Permissions []*PermissionGrantResponse `json:"permissions"`
 }
@@ -0,0 +1,20 @@
+package response
+
+// OpenIDConfigurationResponse is the discovery document of the service, in
+// the format of OpenID Connect Discovery. The service is not a full OpenID
+// provider: the document lists the endpoints and token properties it has.
+type OpenIDConfigurationResponse struct {
+	Issuer                           string   `json:"issuer"`
+	JWKSURI                          string   `json:"jwks_uri"`
+	TokenEndpoint                    string   `json:"token_endpoint"`
+	UserinfoEndpoint                 string   `json:"userinfo_endpoint"`
+	SubjectTypesSupported            []string `json:"subject_types_supported"`
+	IDTokenSigningAlgValuesSupported []string `json:"id_token_signing_alg_values_supported"`
+	ClaimsSupported                  []string `json:"claims_supported"`
+	ServiceDocumentation             string   `json:"service_documentation,omitempty"`
+}
+
+// JWKSResponse is a JSON Web Key Set.
+type JWKSResponse struct {
+	Keys []map[string]string `json:"keys"`
+}
@@ -0,0 +1,67 @@
+package authz
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/casbin/casbin/v2/model"
+	"github.com/casbin/casbin/v2/persist"
+	"github.com/vagonaizer/authenitfication-service/internal/infrastructure/database/postgres"
+)
+
+var errReadOnlyAdapter = errors.New("casbin policies are managed in the casbin_rules table")
+
+// dbAdapter loads Casbin policies from the casbin_rules table. It is
+// read-only: policies are changed in the database and picked up on reload.
+type dbAdapter struct {
+	db *postgres.DB
+}
+
+func newDBAdapter(db *postgres.DB) *dbAdapter {
+	return &dbAdapter{db: db}
+}
+
+func (a *dbAdapter) LoadPolicy(m model.Model) error {
+	query := `SELECT ptype, v0, v1, v2, v3, v4, v5 FROM casbin_rules ORDER BY id`
+
+	rows, err := a.db.Query(context.Background(), query)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var ptype, v0, v1, v2, v3, v4, v5 string
+		if err := rows.Scan(&ptype, &v0, &v1, &v2, &v3, &v4, &v5); err != nil {
+			return err
+		}
+
+		rule := []string{ptype, v0, v1, v2, v3, v4, v5}
+		for len(rule) > 1 && strings.TrimSpace(rule[len(rule)-1]) == "" {
+			rule = rule[:len(rule)-1]
+		}
+
+		if err := persist.LoadPolicyArray(rule, m); err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
+
+func (a *dbAdapter) SavePolicy(m model.Model) error {
+	return errReadOnlyAdapter
+}
+
+func (a *dbAdapter) AddPolicy(sec string, ptype string, rule []string) error {
+	return errReadOnlyAdapter
+}
+
+func (a *dbAdapter) RemovePolicy(sec string, ptype string, rule []string) error {
+	return errReadOnlyAdapter
+}
+
+func (a *dbAdapter) RemoveFilteredPolicy(sec string, ptype string, fieldIndex int, fieldValues ...string) error {
+	return errReadOnlyAdapter
+}
@@ -0,0 +1,100 @@
+package authz
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/casbin/casbin/v2"
+	"github.com/casbin/casbin/v2/model"
+	"github.com/vagonaizer/authenitfication-service/internal/domain/services"
+	"github.com/vagonaizer/authenitfication-service/internal/infrastructure/database/postgres"
+	"github.com/vagonaizer/authenitfication-service/pkg/logger"
+)
+
+// policyModel matches a policy subject against either the caller's user ID
+// or any of their roles. A policy on a resource type also covers its objects
+// ("users" matches "users/42"). Deny rules take precedence over allow rules.
+const policyModel = `
+[request_definition]
+r = user, roles, act, obj
+
+[policy_definition]
+p = sub, act, obj, eft
+
+[policy_effect]
+e = some(where (p.eft == allow)) && !some(where (p.eft == deny))
+
+[matchers]
+m = (r.user == p.sub || hasRole(r.roles, p.sub)) && (keyMatch(r.obj, p.obj) || keyMatch(r.obj, p.obj + "/*")) && (r.act == p.act || p.act == "*")
+`
+
+type CasbinAuthorizer struct {
+	enforcer *casbin.SyncedEnforcer
+	logger   *logger.Logger
+}
+
+func NewCasbinAuthorizer(db *postgres.DB, log *logger.Logger, reloadInterval time.Duration) (*CasbinAuthorizer, error) {
+	m, err := model.NewModelFromString(policyModel)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse casbin model: %w", err)
+	}
+
+	enforcer, err := casbin.NewSyncedEnforcer(m, newDBAdapter(db))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create casbin enforcer: %w", err)
+	}
+
+	enforcer.AddFunction("hasRole", hasRole)
+
+	if reloadInterval > 0 {
+		enforcer.StartAutoLoadPolicy(reloadInterval)
+	}
+
+	return &CasbinAuthorizer{
+		enforcer: enforcer,
+		logger:   log,
+	}, nil
+}
+
+func (a *CasbinAuthorizer) Authorize(ctx context.Context, subject *services.Subject, action, resource string) (bool, error) {
+	roles := subject.Roles
+	if roles == nil {
+		roles = []string{}
+	}
+
+	return a.enforcer.Enforce(subject.UserID, roles, action, resource)
+}
+
+// Reload re-reads all policies from the database.
+func (a *CasbinAuthorizer) Reload() error {
+	return a.enforcer.LoadPolicy()
+}
+
+func (a *CasbinAuthorizer) Close() {
+	a.enforcer.StopAutoLoadPolicy()
+}
+
+func hasRole(args ...interface{}) (interface{}, error) {
+	if len(args) != 2 {
+		return false, fmt.Errorf("hasRole expects 2 arguments, got %d", len(args))
+	}
+
+	roles, ok := args[0].([]string)
+	if !ok {
+		return false, nil
+	}
+
+	role, ok := args[1].(string)
+	if !ok {
+		return false, nil
+	}
+
+	for _, r := range roles {
+		if r == role {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
@@ -2,59 +2,231 @@ package postgres
 
 import (
 	"context"
-	"database/sql"
+	stderrors "errors"
 	"fmt"
+	"sync"
+	"sync/atomic"
 	"time"
 
-	_ "github.com/lib/pq"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/vagonaizer/authenitfication-service/internal/config"
 )
 
+// DB is the connection pool of the primary (writer). Read-only queries can be
+// sent to replicas through ReadQuery and ReadQueryRow; they fall back to the
+// primary while no replica is reachable.
 type DB struct {
-	*sql.DB
+	*pgxpool.Pool
+
+	replicas []*replica
+	next     atomic.Uint32
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+type replica struct {
+	pool    *pgxpool.Pool
+	healthy atomic.Bool
 }
 
 func NewConnection(cfg *config.DatabaseConfig) (*DB, error) {
-	dsn := fmt.Sprintf("host=%s port=%s user=%s password=%s dbname=%s sslmode=%s",
-		cfg.Host, cfg.Port, cfg.User, cfg.Password, cfg.Name, cfg.SSLMode)
+	dsn := cfg.WriterDSN
+	if dsn == "" {
+		dsn = fmt.Sprintf("host=%s port=%s user=%s password=%s dbname=%s sslmode=%s",
+			cfg.Host, cfg.Port, cfg.User, cfg.Password, cfg.Name, cfg.SSLMode)
+	}
 
-	db, err := sql.Open("postgres", dsn)
+	pool, err := open(cfg, dsn)
 	if err != nil {
 		return nil, fmt.Errorf("failed to open database: %w", err)
 	}
 
-	db.SetMaxOpenConns(cfg.MaxOpenConns)
-	db.SetMaxIdleConns(cfg.MaxIdleConns)
-	db.SetConnMaxLifetime(cfg.ConnMaxLifetime)
+	result := &DB{Pool: pool}
 
-	if err := db.Ping(); err != nil {
+	if err := result.Ping(); err != nil {
+		pool.Close()
 		return nil, fmt.Errorf("failed to ping database: %w", err)
 	}
 
-	return &DB{DB: db}, nil
+	// A replica that is down at startup is only skipped until it answers a
+	// health check.
+	for i, readerDSN := range cfg.ReaderDSNs {
+		replicaPool, err := open(cfg, readerDSN)
+		if err != nil {
+			result.Close()
+			return nil, fmt.Errorf("failed to open read replica %d: %w", i, err)
+		}
+
+		r := &replica{pool: replicaPool}
+		r.healthy.Store(replicaPool.Ping(context.Background()) == nil)
+		result.replicas = append(result.replicas, r)
+	}
+
+	if len(result.replicas) > 0 && cfg.ReplicaCheckInterval > 0 {
+		result.monitorReplicas(cfg.ReplicaCheckInterval)
+	}
+
+	return result, nil
 }
 
-func (db *DB) Close() error {
-	return db.DB.Close()
+// open creates a pool without connecting; pgx dials lazily.
+func open(cfg *config.DatabaseConfig, dsn string) (*pgxpool.Pool, error) {
+	poolCfg, err := pgxpool.ParseConfig(dsn)
+	if err != nil {
+		return nil, err
+	}
+
+	if cfg.MaxOpenConns > 0 {
+		poolCfg.MaxConns = int32(cfg.MaxOpenConns)
+	}
+	if cfg.MaxIdleConns > 0 && cfg.MaxIdleConns <= int(poolCfg.MaxConns) {
+		poolCfg.MinConns = int32(cfg.MaxIdleConns)
+	}
+	if cfg.ConnMaxLifetime > 0 {
+		poolCfg.MaxConnLifetime = cfg.ConnMaxLifetime
+	}
+
+	return pgxpool.NewWithConfig(context.Background(), poolCfg)
 }
 
-func (db *DB) Ping() error {
-	return db.DB.Ping()
+// monitorReplicas pings every replica each interval, so one marked down after
+// a failed query is used again once it recovers.
+func (db *DB) monitorReplicas(interval time.Duration) {
+	ctx, cancel := context.WithCancel(context.Background())
+	db.cancel = cancel
+
+	db.wg.Add(1)
+	go func() {
+		defer db.wg.Done()
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+
+			for _, r := range db.replicas {
+				pingCtx, cancel := context.WithTimeout(ctx, interval)
+				r.healthy.Store(r.pool.Ping(pingCtx) == nil)
+				cancel()
+			}
+		}
+	}()
 }
 
-func (db *DB) Stats() sql.DBStats {
-	return db.DB.Stats()
+// reader picks the next healthy replica, or nil when there is none.
+func (db *DB) reader() *replica {
+	n := uint32(len(db.replicas))
+	if n == 0 {
+		return nil
+	}
+
+	start := db.next.Add(1)
+	for i := uint32(0); i < n; i++ {
+		r := db.replicas[(start+i)%n]
+		if r.healthy.Load() {
+			return r
+		}
+	}
+	return nil
 }
 
-func (db *DB) BeginTx() (*sql.Tx, error) {
-	return db.DB.Begin()
+// ReadQuery runs a read-only query on a replica. Replicas may lag behind the
+// primary, so it is only meant for reads that tolerate that.
+func (db *DB) ReadQuery(ctx context.Context, query string, args ...interface{}) (pgx.Rows, error) {
+	if r := db.reader(); r != nil {
+		rows, err := r.pool.Query(ctx, query, args...)
+		if !db.replicaFailed(ctx, r, err) {
+			return rows, err
+		}
+	}
+	return db.Query(ctx, query, args...)
 }
 
-func (db *DB) Health() error {
+// ReadQueryRow is ReadQuery for queries returning one row.
+func (db *DB) ReadQueryRow(ctx context.Context, query string, args ...interface{}) pgx.Row {
+	if r := db.reader(); r != nil {
+		rows, err := r.pool.Query(ctx, query, args...)
+		if !db.replicaFailed(ctx, r, err) {
+			return &firstRow{rows: rows, err: err}
+		}
+	}
+	return db.QueryRow(ctx, query, args...)
+}
+
+// replicaFailed reports whether err means the replica could not serve the
+// query, and marks it down until the next successful health check. Errors
+// returned by the server itself would fail on the primary too.
+func (db *DB) replicaFailed(ctx context.Context, r *replica, err error) bool {
+	if err == nil || ctx.Err() != nil {
+		return false
+	}
+
+	var pgErr *pgconn.PgError
+	if stderrors.As(err, &pgErr) {
+		return false
+	}
+
+	r.healthy.Store(false)
+	return true
+}
+
+// firstRow scans the first row of rows like pgx.Conn.QueryRow does.
+type firstRow struct {
+	rows pgx.Rows
+	err  error
+}
+
+func (r *firstRow) Scan(dest ...any) error {
+	if r.err != nil {
+		return r.err
+	}
+	defer r.rows.Close()
+
+	if !r.rows.Next() {
+		if err := r.rows.Err(); err != nil {
+			return err
+		}
+		return pgx.ErrNoRows
+	}
+
+	if err := r.rows.Scan(dest...); err != nil {
+		return err
+	}
+	r.rows.Close()
+	return r.rows.Err()
+}
+
+func (db *DB) Close() error {
+	if db.cancel != nil {
+		db.cancel()
+	}
+	db.wg.Wait()
+
+	for _, r := range db.replicas {
+		r.pool.Close()
+	}
+
+	db.Pool.Close()
+	return nil
+}
+
+func (db *DB) Ping() error {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
-	if err := db.DB.PingContext(ctx); err != nil {
+	return db.Pool.Ping(ctx)
+}
+
+func (db *DB) Health(ctx context.Context) error {
+	if err := db.Pool.Ping(ctx); err != nil {
 		return fmt.Errorf("database health check failed: %w", err)
 	}
 
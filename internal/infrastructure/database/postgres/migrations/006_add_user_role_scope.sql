@@ -0,0 +1,10 @@
+ALTER TABLE user_roles ADD COLUMN IF NOT EXISTS scope_id UUID;
+
+-- A role may now be granted once globally and once per scope, so the plain
+-- (user_id, role_id) uniqueness is replaced with a scope-aware one.
+ALTER TABLE user_roles DROP CONSTRAINT IF EXISTS user_roles_user_id_role_id_key;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_user_roles_user_role_scope
+    ON user_roles(user_id, role_id, COALESCE(scope_id, '00000000-0000-0000-0000-000000000000'::uuid));
+
+CREATE INDEX IF NOT EXISTS idx_user_roles_scope_id ON user_roles(scope_id) WHERE scope_id IS NOT NULL;
@@ -0,0 +1,23 @@
+CREATE TABLE IF NOT EXISTS casbin_rules (
+    id SERIAL PRIMARY KEY,
+    ptype VARCHAR(10) NOT NULL,
+    v0 VARCHAR(255) NOT NULL DEFAULT '',
+    v1 VARCHAR(255) NOT NULL DEFAULT '',
+    v2 VARCHAR(255) NOT NULL DEFAULT '',
+    v3 VARCHAR(255) NOT NULL DEFAULT '',
+    v4 VARCHAR(255) NOT NULL DEFAULT '',
+    v5 VARCHAR(255) NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE(ptype, v0, v1, v2, v3, v4, v5)
+);
+
+CREATE INDEX idx_casbin_rules_ptype ON casbin_rules(ptype);
+
+-- Seed policies equivalent to the existing role permissions:
+-- p, <role>, <action>, <resource>, allow
+INSERT INTO casbin_rules (ptype, v0, v1, v2, v3)
+SELECT 'p', r.name, split_part(p.name, ':', 2), split_part(p.name, ':', 1), 'allow'
+FROM role_permissions rp
+INNER JOIN roles r ON r.id = rp.role_id
+INNER JOIN permissions p ON p.id = rp.permission_id
+ON CONFLICT DO NOTHING;
@@ -0,0 +1,2 @@
+-- Optional ABAC condition evaluated when the grant is checked, e.g. 'is_verified == true'.
+ALTER TABLE role_permissions ADD COLUMN IF NOT EXISTS condition TEXT;
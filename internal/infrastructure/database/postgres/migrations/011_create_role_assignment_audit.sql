@@ -0,0 +1,17 @@
+-- No foreign keys: audit entries must outlive the users and roles they mention.
+CREATE TABLE IF NOT EXISTS role_assignment_audit (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL,
+    role_id UUID NOT NULL,
+    role_name VARCHAR(50) NOT NULL,
+    scope_id UUID,
+    action VARCHAR(20) NOT NULL CHECK (action IN ('granted', 'revoked', 'expired')),
+    actor_id UUID,
+    reason TEXT,
+    expires_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_role_assignment_audit_user_id ON role_assignment_audit(user_id, created_at DESC);
+CREATE INDEX idx_role_assignment_audit_role_id ON role_assignment_audit(role_id, created_at DESC);
+CREATE INDEX idx_role_assignment_audit_created_at ON role_assignment_audit(created_at DESC);
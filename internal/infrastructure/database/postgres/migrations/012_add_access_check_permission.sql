@@ -0,0 +1,12 @@
+INSERT INTO permissions (name, description) VALUES
+    ('access:check', 'Check other users'' permissions through the CheckAccess RPC')
+ON CONFLICT (name) DO NOTHING;
+
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT r.id, p.id FROM roles r CROSS JOIN permissions p
+WHERE r.name = 'admin' AND p.name = 'access:check'
+ON CONFLICT (role_id, permission_id) DO NOTHING;
+
+INSERT INTO casbin_rules (ptype, v0, v1, v2, v3)
+VALUES ('p', 'admin', 'check', 'access', 'allow')
+ON CONFLICT DO NOTHING;
@@ -0,0 +1,20 @@
+-- Split activation out of users:manage so it can be delegated on its own.
+INSERT INTO permissions (name, description) VALUES
+    ('users:activate', 'Activate user accounts'),
+    ('users:deactivate', 'Deactivate user accounts')
+ON CONFLICT (name) DO NOTHING;
+
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT rp.role_id, p.id
+FROM role_permissions rp
+INNER JOIN permissions m ON m.id = rp.permission_id AND m.name = 'users:manage'
+CROSS JOIN permissions p
+WHERE p.name IN ('users:activate', 'users:deactivate')
+ON CONFLICT (role_id, permission_id) DO NOTHING;
+
+INSERT INTO casbin_rules (ptype, v0, v1, v2, v3)
+SELECT ptype, v0, a.action, v2, v3
+FROM casbin_rules
+CROSS JOIN (VALUES ('activate'), ('deactivate')) AS a(action)
+WHERE ptype = 'p' AND v1 = 'manage' AND v2 = 'users'
+ON CONFLICT DO NOTHING;
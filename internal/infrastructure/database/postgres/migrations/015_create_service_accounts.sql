@@ -0,0 +1,33 @@
+-- Service accounts are users without a password that authenticate with
+-- key/secret pairs. Roles are assigned to them through user_roles as usual.
+ALTER TABLE users ADD COLUMN IF NOT EXISTS is_service_account BOOLEAN NOT NULL DEFAULT false;
+
+CREATE INDEX IF NOT EXISTS idx_users_service_account ON users(created_at) WHERE is_service_account AND deleted_at IS NULL;
+
+CREATE TABLE IF NOT EXISTS service_account_keys (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    key_id VARCHAR(64) UNIQUE NOT NULL,
+    secret_hash VARCHAR(64) NOT NULL,
+    created_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    last_used_at TIMESTAMP WITH TIME ZONE,
+    revoked_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_service_account_keys_user_id ON service_account_keys(user_id);
+
+INSERT INTO permissions (name, description) VALUES
+    ('service_accounts:read', 'View service accounts and their keys'),
+    ('service_accounts:manage', 'Create, delete and rotate keys of service accounts')
+ON CONFLICT (name) DO NOTHING;
+
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT r.id, p.id FROM roles r CROSS JOIN permissions p
+WHERE r.name = 'admin' AND p.name IN ('service_accounts:read', 'service_accounts:manage')
+ON CONFLICT (role_id, permission_id) DO NOTHING;
+
+INSERT INTO casbin_rules (ptype, v0, v1, v2, v3) VALUES
+    ('p', 'admin', 'read', 'service_accounts', 'allow'),
+    ('p', 'admin', 'manage', 'service_accounts', 'allow')
+ON CONFLICT DO NOTHING;
@@ -0,0 +1,29 @@
+-- Per-organization overrides of the configured default limits. NULL keeps
+-- the default, 0 means unlimited.
+CREATE TABLE IF NOT EXISTS organization_quotas (
+    organization_id UUID PRIMARY KEY REFERENCES organizations(id) ON DELETE CASCADE,
+    max_members INTEGER CHECK (max_members >= 0),
+    max_sessions_per_user INTEGER CHECK (max_sessions_per_user >= 0),
+    tokens_per_minute INTEGER CHECK (tokens_per_minute >= 0),
+    updated_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TRIGGER update_organization_quotas_updated_at BEFORE UPDATE ON organization_quotas
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+INSERT INTO permissions (name, description) VALUES
+    ('quotas:read', 'View organization quotas'),
+    ('quotas:manage', 'Change organization quotas')
+ON CONFLICT (name) DO NOTHING;
+
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT r.id, p.id FROM roles r CROSS JOIN permissions p
+WHERE r.name = 'admin' AND p.name IN ('quotas:read', 'quotas:manage')
+ON CONFLICT (role_id, permission_id) DO NOTHING;
+
+INSERT INTO casbin_rules (ptype, v0, v1, v2, v3) VALUES
+    ('p', 'admin', 'read', 'quotas', 'allow'),
+    ('p', 'admin', 'manage', 'quotas', 'allow')
+ON CONFLICT DO NOTHING;
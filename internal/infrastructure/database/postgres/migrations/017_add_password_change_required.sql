@@ -0,0 +1,3 @@
+-- Accounts created by an administrator with a temporary password must pick
+-- their own password; the flag is cleared on the first password change.
+ALTER TABLE users ADD COLUMN IF NOT EXISTS password_change_required BOOLEAN NOT NULL DEFAULT false;
@@ -0,0 +1,5 @@
+-- Purged users keep their row (and ID) so references stay valid, but every
+-- piece of personal data on it is overwritten.
+ALTER TABLE users ADD COLUMN IF NOT EXISTS purged_at TIMESTAMP WITH TIME ZONE;
+
+CREATE INDEX IF NOT EXISTS idx_users_purge_candidates ON users(deleted_at) WHERE deleted_at IS NOT NULL AND purged_at IS NULL;
@@ -0,0 +1,27 @@
+-- A ban blocks login until banned_until (NULL means permanent). It is kept
+-- apart from is_active so lifting a ban does not reactivate a deactivated
+-- account.
+ALTER TABLE users ADD COLUMN IF NOT EXISTS banned_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE users ADD COLUMN IF NOT EXISTS banned_until TIMESTAMP WITH TIME ZONE;
+ALTER TABLE users ADD COLUMN IF NOT EXISTS ban_reason TEXT;
+ALTER TABLE users ADD COLUMN IF NOT EXISTS banned_by UUID REFERENCES users(id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS idx_users_banned_until ON users(banned_until) WHERE banned_until IS NOT NULL;
+
+INSERT INTO permissions (name, description) VALUES
+    ('users:ban', 'Ban and unban user accounts')
+ON CONFLICT (name) DO NOTHING;
+
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT rp.role_id, p.id
+FROM role_permissions rp
+INNER JOIN permissions m ON m.id = rp.permission_id AND m.name = 'users:deactivate'
+CROSS JOIN permissions p
+WHERE p.name = 'users:ban'
+ON CONFLICT (role_id, permission_id) DO NOTHING;
+
+INSERT INTO casbin_rules (ptype, v0, v1, v2, v3)
+SELECT ptype, v0, 'ban', v2, v3
+FROM casbin_rules
+WHERE ptype = 'p' AND v1 = 'deactivate' AND v2 = 'users'
+ON CONFLICT DO NOTHING;
@@ -0,0 +1,5 @@
+-- A merged duplicate is soft-deleted and points at the account it was
+-- merged into, so lookups by the old ID can be redirected.
+ALTER TABLE users ADD COLUMN IF NOT EXISTS merged_into UUID REFERENCES users(id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS idx_users_merged_into ON users(merged_into) WHERE merged_into IS NOT NULL;
@@ -0,0 +1,33 @@
+-- Internal notes support staff keep about a user. They are only exposed on
+-- admin endpoints and are deleted when the user is purged.
+CREATE TABLE IF NOT EXISTS user_notes (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    author_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    body TEXT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_user_notes_user_id ON user_notes(user_id, created_at DESC);
+
+CREATE TRIGGER update_user_notes_updated_at BEFORE UPDATE ON user_notes
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+INSERT INTO permissions (name, description) VALUES
+    ('users:notes', 'Write internal notes on user accounts')
+ON CONFLICT (name) DO NOTHING;
+
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT rp.role_id, p.id
+FROM role_permissions rp
+INNER JOIN permissions m ON m.id = rp.permission_id AND m.name = 'users:manage'
+CROSS JOIN permissions p
+WHERE p.name = 'users:notes'
+ON CONFLICT (role_id, permission_id) DO NOTHING;
+
+INSERT INTO casbin_rules (ptype, v0, v1, v2, v3)
+SELECT ptype, v0, 'notes', v2, v3
+FROM casbin_rules
+WHERE ptype = 'p' AND v1 = 'manage' AND v2 = 'users'
+ON CONFLICT DO NOTHING;
@@ -0,0 +1,2 @@
+-- Supports the active user counts of the admin statistics endpoint.
+CREATE INDEX IF NOT EXISTS idx_users_last_login_at ON users(last_login_at) WHERE deleted_at IS NULL;
@@ -0,0 +1,11 @@
+-- Trigram indexes behind the ILIKE matching of user search and listings.
+-- The name expression must stay identical to userFullNameExpr in the user
+-- repository for the planner to use its index.
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+CREATE INDEX IF NOT EXISTS idx_users_email_trgm ON users
+    USING gin (email gin_trgm_ops) WHERE deleted_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_users_username_trgm ON users
+    USING gin (username gin_trgm_ops) WHERE deleted_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_users_full_name_trgm ON users
+    USING gin ((COALESCE(first_name, '') || ' ' || COALESCE(last_name, '')) gin_trgm_ops) WHERE deleted_at IS NULL;
@@ -0,0 +1,17 @@
+-- Every password login attempt, successful or not. Attempts for unknown
+-- emails have no user_id. Rows are deleted after LOGIN_HISTORY_RETENTION,
+-- and with the user when they are purged.
+CREATE TABLE IF NOT EXISTS login_history (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID REFERENCES users(id) ON DELETE CASCADE,
+    email VARCHAR(255) NOT NULL,
+    success BOOLEAN NOT NULL,
+    failure_reason VARCHAR(50),
+    ip_address VARCHAR(45),
+    user_agent TEXT,
+    country VARCHAR(2),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_login_history_user_id ON login_history(user_id, created_at DESC);
+CREATE INDEX idx_login_history_created_at ON login_history(created_at);
@@ -0,0 +1,28 @@
+-- Every event handed to the message broker, kept so that events can be
+-- republished after downstream services lost them. user_id is copied from
+-- the payload; rows are deleted after EVENT_LOG_RETENTION, and with the user
+-- when they are purged.
+CREATE TABLE IF NOT EXISTS event_log (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    topic VARCHAR(255) NOT NULL,
+    key VARCHAR(255) NOT NULL,
+    user_id UUID,
+    payload JSONB NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_event_log_created_at ON event_log(created_at, id);
+CREATE INDEX idx_event_log_user_id ON event_log(user_id, created_at, id);
+
+INSERT INTO permissions (name, description) VALUES
+    ('events:replay', 'Republish logged events to the message broker')
+ON CONFLICT (name) DO NOTHING;
+
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT r.id, p.id FROM roles r CROSS JOIN permissions p
+WHERE r.name = 'admin' AND p.name = 'events:replay'
+ON CONFLICT (role_id, permission_id) DO NOTHING;
+
+INSERT INTO casbin_rules (ptype, v0, v1, v2, v3)
+VALUES ('p', 'admin', 'replay', 'events', 'allow')
+ON CONFLICT DO NOTHING;
@@ -0,0 +1,11 @@
+-- Events the inbound consumers handled, used to skip redelivered duplicates
+-- when KAFKA_DEDUP_STORE is postgres. Rows expire after KAFKA_DEDUP_WINDOW.
+CREATE TABLE IF NOT EXISTS processed_events (
+    topic VARCHAR(255) NOT NULL,
+    event_id VARCHAR(255) NOT NULL,
+    processed_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    PRIMARY KEY (topic, event_id)
+);
+
+CREATE INDEX idx_processed_events_expires_at ON processed_events(expires_at);
@@ -0,0 +1,10 @@
+-- Events written by the API when OUTBOX_ENABLED is set, waiting for
+-- cmd/relay to publish them to the message broker. Rows are deleted once
+-- published, so the table only holds the backlog.
+CREATE TABLE IF NOT EXISTS outbox (
+    id BIGSERIAL PRIMARY KEY,
+    topic VARCHAR(255) NOT NULL,
+    key VARCHAR(255) NOT NULL,
+    payload JSONB NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
@@ -0,0 +1,3 @@
+-- Trace context and correlation ID of the request that wrote the event,
+-- published as message headers by the relay.
+ALTER TABLE outbox ADD COLUMN IF NOT EXISTS headers JSONB NOT NULL DEFAULT '{}';
@@ -0,0 +1,8 @@
+-- Highest fencing token a relay leader has published under. A relay whose
+-- lock token is lower lost the lock to a newer leader and must stop.
+CREATE TABLE IF NOT EXISTS outbox_fence (
+    id BOOLEAN PRIMARY KEY DEFAULT TRUE CHECK (id),
+    token BIGINT NOT NULL
+);
+
+INSERT INTO outbox_fence (id, token) VALUES (TRUE, 0) ON CONFLICT DO NOTHING;
@@ -0,0 +1,376 @@
+// Package migrator applies the SQL migrations embedded in the binary, or those
+// in DB_MIGRATIONS_PATH when it is set. It is shared by cmd/migrate and the
+// optional migrate-on-boot mode of the server.
+package migrator
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"io/fs"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/vagonaizer/authenitfication-service/internal/infrastructure/database/postgres/migrations"
+	"github.com/vagonaizer/authenitfication-service/pkg/utils"
+)
+
+// lockKey identifies the advisory lock held while migrations run, so that
+// replicas starting at the same time apply them one after another.
+const lockKey int64 = 4_021_887_301
+
+type Migration struct {
+	Version  int
+	Name     string
+	Filename string
+	Content  string
+}
+
+// Logf receives progress messages; log.Printf and logger.Infof both fit.
+type Logf func(format string, args ...interface{})
+
+// Lock takes the migration advisory lock on a dedicated connection, waiting
+// for any other runner to finish. The returned function releases it.
+func Lock(ctx context.Context, db *sql.DB, logf Logf) (func(), error) {
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire connection: %v", err)
+	}
+
+	var locked bool
+	if err := conn.QueryRowContext(ctx, "SELECT pg_try_advisory_lock($1)", lockKey).Scan(&locked); err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("failed to take migration lock: %v", err)
+	}
+
+	if !locked {
+		logf("Another migration run is in progress, waiting for it to finish")
+		if _, err := conn.ExecContext(ctx, "SELECT pg_advisory_lock($1)", lockKey); err != nil {
+			conn.Close()
+			return nil, fmt.Errorf("failed to take migration lock: %v", err)
+		}
+	}
+
+	return func() {
+		// Соединение может вернуться в общий пул, поэтому снимаем блокировку явно
+		if _, err := conn.ExecContext(context.Background(), "SELECT pg_advisory_unlock($1)", lockKey); err != nil {
+			logf("Failed to release migration lock: %v", err)
+		}
+		conn.Close()
+	}, nil
+}
+
+func CreateMigrationsTable(ctx context.Context, db *sql.DB) error {
+	query := `
+		CREATE TABLE IF NOT EXISTS schema_migrations (
+			version INTEGER PRIMARY KEY,
+			applied_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+			checksum TEXT
+		);
+		ALTER TABLE schema_migrations ADD COLUMN IF NOT EXISTS checksum TEXT
+	`
+	_, err := db.ExecContext(ctx, query)
+	return err
+}
+
+// Checksum identifies the content a migration was applied with.
+func Checksum(migration Migration) string {
+	return utils.HashSHA256(migration.Content)
+}
+
+// VerifyChecksums fails when an applied migration's file no longer matches
+// the checksum recorded for it. With force the mismatch is only logged.
+// Migrations applied before checksums were recorded are not checked.
+func VerifyChecksums(ctx context.Context, db *sql.DB, source fs.FS, force bool, logf Logf) error {
+	migrations, err := LoadMigrations(source)
+	if err != nil {
+		return err
+	}
+
+	recorded, err := recordedChecksums(ctx, db)
+	if err != nil {
+		return err
+	}
+
+	var changed []string
+	for _, migration := range migrations {
+		checksum, ok := recorded[migration.Version]
+		if !ok || checksum == "" {
+			continue
+		}
+		if checksum != Checksum(migration) {
+			changed = append(changed, migration.Filename)
+		}
+	}
+
+	if len(changed) == 0 {
+		return nil
+	}
+
+	if !force {
+		return fmt.Errorf("applied migrations were modified: %s", strings.Join(changed, ", "))
+	}
+
+	logf("Warning: applied migrations were modified: %s", strings.Join(changed, ", "))
+	return nil
+}
+
+// recordedChecksums maps applied versions to their checksum, which is empty
+// for versions applied before checksums were recorded.
+func recordedChecksums(ctx context.Context, db *sql.DB) (map[int]string, error) {
+	checksums := make(map[int]string)
+
+	rows, err := db.QueryContext(ctx, "SELECT version, COALESCE(checksum, '') FROM schema_migrations")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var version int
+		var checksum string
+		if err := rows.Scan(&version, &checksum); err != nil {
+			return nil, err
+		}
+		checksums[version] = checksum
+	}
+
+	return checksums, rows.Err()
+}
+
+// backfillChecksums records the current checksum of migrations applied before
+// checksums existed, so later edits to them are caught.
+func backfillChecksums(ctx context.Context, db *sql.DB, source fs.FS) error {
+	migrations, err := LoadMigrations(source)
+	if err != nil {
+		return err
+	}
+
+	for _, migration := range migrations {
+		query := "UPDATE schema_migrations SET checksum = $2 WHERE version = $1 AND checksum IS NULL"
+		if _, err := db.ExecContext(ctx, query, migration.Version, Checksum(migration)); err != nil {
+			return fmt.Errorf("failed to record checksum of migration %d: %v", migration.Version, err)
+		}
+	}
+
+	return nil
+}
+
+func MigrationsTableExists(ctx context.Context, db *sql.DB) (bool, error) {
+	var exists bool
+	err := db.QueryRowContext(ctx, "SELECT to_regclass('schema_migrations') IS NOT NULL").Scan(&exists)
+	return exists, err
+}
+
+// Source returns the embedded migrations, or the directory at path when one is
+// configured.
+func Source(path string) fs.FS {
+	if path == "" {
+		return migrations.FS
+	}
+	return os.DirFS(path)
+}
+
+func LoadMigrations(source fs.FS) ([]Migration, error) {
+	var migrations []Migration
+
+	err := fs.WalkDir(source, ".", func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if d.IsDir() || !strings.HasSuffix(path, ".sql") {
+			return nil
+		}
+
+		filename := d.Name()
+		parts := strings.SplitN(filename, "_", 2)
+		if len(parts) != 2 {
+			return fmt.Errorf("invalid migration filename format: %s", filename)
+		}
+
+		var version int
+		if _, err := fmt.Sscanf(parts[0], "%d", &version); err != nil {
+			return fmt.Errorf("invalid version in filename %s: %v", filename, err)
+		}
+
+		content, err := fs.ReadFile(source, path)
+		if err != nil {
+			return fmt.Errorf("failed to read migration file %s: %v", path, err)
+		}
+
+		name := strings.TrimSuffix(parts[1], ".sql")
+		migrations = append(migrations, Migration{
+			Version:  version,
+			Name:     name,
+			Filename: filename,
+			Content:  string(content),
+		})
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	// Sort migrations by version
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+
+	return migrations, nil
+}
+
+func AppliedMigrations(ctx context.Context, db *sql.DB) (map[int]bool, error) {
+	applied := make(map[int]bool)
+
+	rows, err := db.QueryContext(ctx, "SELECT version FROM schema_migrations ORDER BY version")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var version int
+		if err := rows.Scan(&version); err != nil {
+			return nil, err
+		}
+		applied[version] = true
+	}
+
+	return applied, rows.Err()
+}
+
+// PendingMigrations returns the migrations not yet recorded in applied, in
+// the order they would run.
+func PendingMigrations(source fs.FS, applied map[int]bool) ([]Migration, error) {
+	migrations, err := LoadMigrations(source)
+	if err != nil {
+		return nil, err
+	}
+
+	var pending []Migration
+	for _, migration := range migrations {
+		if !applied[migration.Version] {
+			pending = append(pending, migration)
+		}
+	}
+
+	return pending, nil
+}
+
+// LatestApplied returns the highest applied version, or 0 when none is.
+func LatestApplied(applied map[int]bool) int {
+	var latestVersion int
+	for version := range applied {
+		if version > latestVersion {
+			latestVersion = version
+		}
+	}
+	return latestVersion
+}
+
+// Up applies every pending migration, each in its own transaction, after
+// checking that applied ones were not modified (see VerifyChecksums). Callers
+// running it concurrently should hold Lock.
+func Up(ctx context.Context, db *sql.DB, source fs.FS, force bool, logf Logf) error {
+	if err := CreateMigrationsTable(ctx, db); err != nil {
+		return fmt.Errorf("failed to create migrations table: %v", err)
+	}
+
+	if err := VerifyChecksums(ctx, db, source, force, logf); err != nil {
+		return err
+	}
+
+	if err := backfillChecksums(ctx, db, source); err != nil {
+		return err
+	}
+
+	applied, err := AppliedMigrations(ctx, db)
+	if err != nil {
+		return err
+	}
+
+	migrations, err := PendingMigrations(source, applied)
+	if err != nil {
+		return err
+	}
+
+	var executed int
+	for _, migration := range migrations {
+		logf("Applying migration %d: %s", migration.Version, migration.Name)
+
+		tx, err := db.BeginTx(ctx, nil)
+		if err != nil {
+			return fmt.Errorf("failed to begin transaction: %v", err)
+		}
+
+		if _, err := tx.ExecContext(ctx, migration.Content); err != nil {
+			tx.Rollback()
+			return fmt.Errorf("failed to execute migration %d: %v", migration.Version, err)
+		}
+
+		if _, err := tx.ExecContext(ctx, "INSERT INTO schema_migrations (version, checksum) VALUES ($1, $2)", migration.Version, Checksum(migration)); err != nil {
+			tx.Rollback()
+			return fmt.Errorf("failed to record migration %d: %v", migration.Version, err)
+		}
+
+		if err := tx.Commit(); err != nil {
+			return fmt.Errorf("failed to commit migration %d: %v", migration.Version, err)
+		}
+
+		executed++
+		logf("Migration %d applied successfully", migration.Version)
+	}
+
+	if executed == 0 {
+		logf("No migrations to apply")
+	} else {
+		logf("Applied %d migrations", executed)
+	}
+
+	return nil
+}
+
+// Down removes the record of the latest applied migration. There are no down
+// scripts, so the schema itself is left as it is.
+func Down(ctx context.Context, db *sql.DB, logf Logf) error {
+	if err := CreateMigrationsTable(ctx, db); err != nil {
+		return fmt.Errorf("failed to create migrations table: %v", err)
+	}
+
+	applied, err := AppliedMigrations(ctx, db)
+	if err != nil {
+		return err
+	}
+
+	if len(applied) == 0 {
+		logf("No migrations to rollback")
+		return nil
+	}
+
+	latestVersion := LatestApplied(applied)
+
+	logf("Rolling back migration %d", latestVersion)
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %v", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, "DELETE FROM schema_migrations WHERE version = $1", latestVersion); err != nil {
+		tx.Rollback()
+		return fmt.Errorf("failed to remove migration record %d: %v", latestVersion, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit rollback %d: %v", latestVersion, err)
+	}
+
+	logf("Migration %d rolled back successfully", latestVersion)
+	logf("Note: This tool only removes the migration record. Manual schema changes may be required.")
+
+	return nil
+}
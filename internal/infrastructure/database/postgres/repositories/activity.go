@@ -0,0 +1,104 @@
+package repositories
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/vagonaizer/authenitfication-service/internal/domain/entities"
+	"github.com/vagonaizer/authenitfication-service/internal/infrastructure/database/postgres"
+	"github.com/vagonaizer/authenitfication-service/pkg/errors"
+)
+
+type activityRepository struct {
+	db *postgres.DB
+}
+
+func NewActivityRepository(db *postgres.DB) *activityRepository {
+	return &activityRepository{db: db}
+}
+
+func (r *activityRepository) Create(ctx context.Context, activity *entities.UserActivity) error {
+	if activity.ID == uuid.Nil {
+		activity.ID = uuid.New()
+	}
+
+	var details *string
+	if len(activity.Details) > 0 {
+		encoded, err := json.Marshal(activity.Details)
+		if err != nil {
+			return errors.InternalWrap(err, "failed to encode activity details")
+		}
+		text := string(encoded)
+		details = &text
+	}
+
+	query := `
+		INSERT INTO user_activity (id, user_id, type, actor_id, ip_address, user_agent, details)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING created_at`
+
+	err := r.db.QueryRow(ctx, query,
+		activity.ID, activity.UserID, activity.Type, activity.ActorID,
+		activity.IPAddress, activity.UserAgent, details,
+	).Scan(&activity.CreatedAt)
+	if err != nil {
+		return errors.DatabaseError(err)
+	}
+
+	return nil
+}
+
+func (r *activityRepository) List(ctx context.Context, filter entities.ActivityFilter, limit, offset int) ([]*entities.UserActivity, int64, error) {
+	args := []interface{}{filter.UserID}
+	where := "WHERE user_id = $1"
+	if filter.Type != "" {
+		args = append(args, filter.Type)
+		where += " AND type = $2"
+	}
+
+	var total int64
+	countQuery := `SELECT COUNT(*) FROM user_activity ` + where
+	if err := r.db.ReadQueryRow(ctx, countQuery, args...).Scan(&total); err != nil {
+		return nil, 0, errors.DatabaseError(err)
+	}
+
+	query := fmt.Sprintf(`
+		SELECT id, user_id, type, actor_id, ip_address, user_agent, details, created_at
+		FROM user_activity
+		%s
+		ORDER BY created_at DESC, id DESC
+		LIMIT $%d OFFSET $%d`, where, len(args)+1, len(args)+2)
+
+	rows, err := r.db.ReadQuery(ctx, query, append(args, limit, offset)...)
+	if err != nil {
+		return nil, 0, errors.DatabaseError(err)
+	}
+	defer rows.Close()
+
+	var activities []*entities.UserActivity
+	for rows.Next() {
+		activity := &entities.UserActivity{}
+		var details []byte
+		err := rows.Scan(
+			&activity.ID, &activity.UserID, &activity.Type, &activity.ActorID,
+			&activity.IPAddress, &activity.UserAgent, &details, &activity.CreatedAt,
+		)
+		if err != nil {
+			return nil, 0, errors.DatabaseError(err)
+		}
+		if len(details) > 0 {
+			if err := json.Unmarshal(details, &activity.Details); err != nil {
+				return nil, 0, errors.InternalWrap(err, "failed to decode activity details")
+			}
+		}
+		activities = append(activities, activity)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, 0, errors.DatabaseError(err)
+	}
+
+	return activities, total, nil
+}
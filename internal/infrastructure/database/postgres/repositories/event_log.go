@@ -0,0 +1,120 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/vagonaizer/authenitfication-service/internal/domain/entities"
+	"github.com/vagonaizer/authenitfication-service/internal/infrastructure/database/postgres"
+	"github.com/vagonaizer/authenitfication-service/pkg/errors"
+)
+
+type eventLogRepository struct {
+	db *postgres.DB
+}
+
+func NewEventLogRepository(db *postgres.DB) *eventLogRepository {
+	return &eventLogRepository{db: db}
+}
+
+func (r *eventLogRepository) Create(ctx context.Context, record *entities.EventRecord) error {
+	if record.ID == uuid.Nil {
+		record.ID = uuid.New()
+	}
+
+	query := `
+		INSERT INTO event_log (id, topic, key, user_id, payload)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING created_at`
+
+	err := r.db.QueryRow(ctx, query,
+		record.ID, record.Topic, record.Key, record.UserID, record.Payload,
+	).Scan(&record.CreatedAt)
+	if err != nil {
+		return errors.DatabaseError(err)
+	}
+
+	return nil
+}
+
+func (r *eventLogRepository) List(ctx context.Context, filter entities.EventLogFilter, limit int) ([]*entities.EventRecord, error) {
+	var conditions []string
+	var args []interface{}
+
+	if !filter.From.IsZero() {
+		args = append(args, filter.From)
+		conditions = append(conditions, fmt.Sprintf("created_at >= $%d", len(args)))
+	}
+	if !filter.To.IsZero() {
+		args = append(args, filter.To)
+		conditions = append(conditions, fmt.Sprintf("created_at < $%d", len(args)))
+	}
+	if filter.UserID != nil {
+		args = append(args, *filter.UserID)
+		conditions = append(conditions, fmt.Sprintf("user_id = $%d", len(args)))
+	}
+	if filter.Topic != "" {
+		args = append(args, filter.Topic)
+		conditions = append(conditions, fmt.Sprintf("topic = $%d", len(args)))
+	}
+	if !filter.AfterAt.IsZero() {
+		args = append(args, filter.AfterAt, filter.AfterID)
+		conditions = append(conditions, fmt.Sprintf("(created_at, id) > ($%d, $%d)", len(args)-1, len(args)))
+	}
+
+	where := ""
+	if len(conditions) > 0 {
+		where = "WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	query := fmt.Sprintf(`
+		SELECT id, topic, key, user_id, payload, created_at
+		FROM event_log
+		%s
+		ORDER BY created_at, id
+		LIMIT $%d`, where, len(args)+1)
+
+	rows, err := r.db.ReadQuery(ctx, query, append(args, limit)...)
+	if err != nil {
+		return nil, errors.DatabaseError(err)
+	}
+	defer rows.Close()
+
+	var records []*entities.EventRecord
+	for rows.Next() {
+		record := &entities.EventRecord{}
+		err := rows.Scan(&record.ID, &record.Topic, &record.Key, &record.UserID, &record.Payload, &record.CreatedAt)
+		if err != nil {
+			return nil, errors.DatabaseError(err)
+		}
+		records = append(records, record)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, errors.DatabaseError(err)
+	}
+
+	return records, nil
+}
+
+func (r *eventLogRepository) DeleteBefore(ctx context.Context, before time.Time, limit int) (int, error) {
+	query := `
+		DELETE FROM event_log
+		WHERE id IN (
+			SELECT id FROM event_log
+			WHERE created_at < $1
+			ORDER BY created_at
+			LIMIT $2
+			FOR UPDATE SKIP LOCKED
+		)`
+
+	result, err := r.db.Exec(ctx, query, before, limit)
+	if err != nil {
+		return 0, errors.DatabaseError(err)
+	}
+
+	return int(result.RowsAffected()), nil
+}
@@ -0,0 +1,328 @@
+package repositories
+
+import (
+	"context"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/vagonaizer/authenitfication-service/internal/domain/entities"
+	"github.com/vagonaizer/authenitfication-service/internal/infrastructure/database/postgres"
+	"github.com/vagonaizer/authenitfication-service/pkg/errors"
+)
+
+// userGroupsCTE expands a user's direct memberships to every ancestor group.
+const userGroupsCTE = `
+	WITH RECURSIVE user_groups AS (
+		SELECT g.id, g.parent_id
+		FROM groups g
+		INNER JOIN group_members gm ON g.id = gm.group_id
+		WHERE gm.user_id = $1
+		UNION
+		SELECT p.id, p.parent_id
+		FROM groups p
+		INNER JOIN user_groups ug ON p.id = ug.parent_id
+	)`
+
+type groupRepository struct {
+	db *postgres.DB
+}
+
+func NewGroupRepository(db *postgres.DB) *groupRepository {
+	return &groupRepository{db: db}
+}
+
+func (r *groupRepository) Create(ctx context.Context, group *entities.Group) error {
+	query := `
+		INSERT INTO groups (id, name, description, parent_id)
+		VALUES ($1, $2, $3, $4)
+		RETURNING created_at, updated_at`
+
+	err := r.db.QueryRow(ctx, query,
+		group.ID, group.Name, group.Description, group.ParentID,
+	).Scan(&group.CreatedAt, &group.UpdatedAt)
+
+	if err != nil {
+		if strings.Contains(err.Error(), "duplicate key") {
+			return errors.AlreadyExists("group name already exists")
+		}
+		return errors.DatabaseError(err)
+	}
+
+	return nil
+}
+
+func (r *groupRepository) GetByID(ctx context.Context, id uuid.UUID) (*entities.Group, error) {
+	group := &entities.Group{}
+	query := `SELECT id, name, description, parent_id, created_at, updated_at FROM groups WHERE id = $1`
+
+	err := r.db.ReadQueryRow(ctx, query, id).Scan(
+		&group.ID, &group.Name, &group.Description, &group.ParentID, &group.CreatedAt, &group.UpdatedAt,
+	)
+
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, errors.NotFound("group not found")
+		}
+		return nil, errors.DatabaseError(err)
+	}
+
+	return group, nil
+}
+
+func (r *groupRepository) List(ctx context.Context) ([]*entities.Group, error) {
+	query := `SELECT id, name, description, parent_id, created_at, updated_at FROM groups ORDER BY name`
+
+	rows, err := r.db.ReadQuery(ctx, query)
+	if err != nil {
+		return nil, errors.DatabaseError(err)
+	}
+	defer rows.Close()
+
+	return scanGroups(rows)
+}
+
+func (r *groupRepository) Update(ctx context.Context, group *entities.Group) error {
+	query := `
+		UPDATE groups
+		SET name = $2, description = $3, parent_id = $4
+		WHERE id = $1
+		RETURNING updated_at`
+
+	err := r.db.QueryRow(ctx, query,
+		group.ID, group.Name, group.Description, group.ParentID,
+	).Scan(&group.UpdatedAt)
+
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return errors.NotFound("group not found")
+		}
+		if strings.Contains(err.Error(), "duplicate key") {
+			return errors.AlreadyExists("group name already exists")
+		}
+		return errors.DatabaseError(err)
+	}
+
+	return nil
+}
+
+func (r *groupRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	query := `DELETE FROM groups WHERE id = $1`
+
+	result, err := r.db.Exec(ctx, query, id)
+	if err != nil {
+		return errors.DatabaseError(err)
+	}
+
+	rowsAffected := result.RowsAffected()
+
+	if rowsAffected == 0 {
+		return errors.NotFound("group not found")
+	}
+
+	return nil
+}
+
+func (r *groupRepository) GetAncestorIDs(ctx context.Context, id uuid.UUID) ([]uuid.UUID, error) {
+	query := `
+		WITH RECURSIVE ancestors AS (
+			SELECT parent_id AS id FROM groups WHERE id = $1 AND parent_id IS NOT NULL
+			UNION
+			SELECT g.parent_id FROM groups g
+			INNER JOIN ancestors a ON g.id = a.id
+			WHERE g.parent_id IS NOT NULL
+		)
+		SELECT id FROM ancestors`
+
+	rows, err := r.db.Query(ctx, query, id)
+	if err != nil {
+		return nil, errors.DatabaseError(err)
+	}
+	defer rows.Close()
+
+	var ids []uuid.UUID
+	for rows.Next() {
+		var ancestorID uuid.UUID
+		if err := rows.Scan(&ancestorID); err != nil {
+			return nil, errors.DatabaseError(err)
+		}
+		ids = append(ids, ancestorID)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, errors.DatabaseError(err)
+	}
+
+	return ids, nil
+}
+
+func (r *groupRepository) AddMember(ctx context.Context, groupID, userID uuid.UUID) error {
+	query := `INSERT INTO group_members (id, group_id, user_id) VALUES ($1, $2, $3) ON CONFLICT (group_id, user_id) DO NOTHING`
+
+	_, err := r.db.Exec(ctx, query, uuid.New(), groupID, userID)
+	if err != nil {
+		return errors.DatabaseError(err)
+	}
+
+	return nil
+}
+
+func (r *groupRepository) RemoveMember(ctx context.Context, groupID, userID uuid.UUID) error {
+	query := `DELETE FROM group_members WHERE group_id = $1 AND user_id = $2`
+
+	result, err := r.db.Exec(ctx, query, groupID, userID)
+	if err != nil {
+		return errors.DatabaseError(err)
+	}
+
+	rowsAffected := result.RowsAffected()
+
+	if rowsAffected == 0 {
+		return errors.NotFound("group member not found")
+	}
+
+	return nil
+}
+
+func (r *groupRepository) ListMembers(ctx context.Context, groupID uuid.UUID) ([]*entities.GroupMember, error) {
+	query := `
+		SELECT id, group_id, user_id, created_at
+		FROM group_members
+		WHERE group_id = $1
+		ORDER BY created_at`
+
+	rows, err := r.db.ReadQuery(ctx, query, groupID)
+	if err != nil {
+		return nil, errors.DatabaseError(err)
+	}
+	defer rows.Close()
+
+	var members []*entities.GroupMember
+	for rows.Next() {
+		member := &entities.GroupMember{}
+		if err := rows.Scan(&member.ID, &member.GroupID, &member.UserID, &member.CreatedAt); err != nil {
+			return nil, errors.DatabaseError(err)
+		}
+		members = append(members, member)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, errors.DatabaseError(err)
+	}
+
+	return members, nil
+}
+
+func (r *groupRepository) AssignRole(ctx context.Context, groupID, roleID uuid.UUID) error {
+	query := `INSERT INTO group_roles (id, group_id, role_id) VALUES ($1, $2, $3) ON CONFLICT (group_id, role_id) DO NOTHING`
+
+	_, err := r.db.Exec(ctx, query, uuid.New(), groupID, roleID)
+	if err != nil {
+		return errors.DatabaseError(err)
+	}
+
+	return nil
+}
+
+func (r *groupRepository) RemoveRole(ctx context.Context, groupID, roleID uuid.UUID) error {
+	query := `DELETE FROM group_roles WHERE group_id = $1 AND role_id = $2`
+
+	result, err := r.db.Exec(ctx, query, groupID, roleID)
+	if err != nil {
+		return errors.DatabaseError(err)
+	}
+
+	rowsAffected := result.RowsAffected()
+
+	if rowsAffected == 0 {
+		return errors.NotFound("group role assignment not found")
+	}
+
+	return nil
+}
+
+func (r *groupRepository) GetGroupRoles(ctx context.Context, groupID uuid.UUID) ([]*entities.Role, error) {
+	query := `
+		SELECT r.id, r.name, r.description, r.created_at, r.updated_at
+		FROM roles r
+		INNER JOIN group_roles gr ON r.id = gr.role_id
+		WHERE gr.group_id = $1
+		ORDER BY r.name`
+
+	rows, err := r.db.Query(ctx, query, groupID)
+	if err != nil {
+		return nil, errors.DatabaseError(err)
+	}
+	defer rows.Close()
+
+	return scanRoles(rows)
+}
+
+func (r *groupRepository) GetUserGroups(ctx context.Context, userID uuid.UUID) ([]*entities.Group, error) {
+	query := userGroupsCTE + `
+		SELECT g.id, g.name, g.description, g.parent_id, g.created_at, g.updated_at
+		FROM groups g
+		WHERE g.id IN (SELECT id FROM user_groups)
+		ORDER BY g.name`
+
+	rows, err := r.db.Query(ctx, query, userID)
+	if err != nil {
+		return nil, errors.DatabaseError(err)
+	}
+	defer rows.Close()
+
+	return scanGroups(rows)
+}
+
+func (r *groupRepository) GetUserGroupRoles(ctx context.Context, userID uuid.UUID) ([]*entities.Role, error) {
+	query := userGroupsCTE + `
+		SELECT DISTINCT r.id, r.name, r.description, r.created_at, r.updated_at
+		FROM roles r
+		INNER JOIN group_roles gr ON r.id = gr.role_id
+		WHERE gr.group_id IN (SELECT id FROM user_groups)
+		ORDER BY r.name`
+
+	rows, err := r.db.Query(ctx, query, userID)
+	if err != nil {
+		return nil, errors.DatabaseError(err)
+	}
+	defer rows.Close()
+
+	return scanRoles(rows)
+}
+
+func scanGroups(rows pgx.Rows) ([]*entities.Group, error) {
+	var groups []*entities.Group
+	for rows.Next() {
+		group := &entities.Group{}
+		err := rows.Scan(&group.ID, &group.Name, &group.Description, &group.ParentID, &group.CreatedAt, &group.UpdatedAt)
+		if err != nil {
+			return nil, errors.DatabaseError(err)
+		}
+		groups = append(groups, group)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, errors.DatabaseError(err)
+	}
+
+	return groups, nil
+}
+
+func scanRoles(rows pgx.Rows) ([]*entities.Role, error) {
+	var roles []*entities.Role
+	for rows.Next() {
+		role := &entities.Role{}
+		err := rows.Scan(&role.ID, &role.Name, &role.Description, &role.CreatedAt, &role.UpdatedAt)
+		if err != nil {
+			return nil, errors.DatabaseError(err)
+		}
+		roles = append(roles, role)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, errors.DatabaseError(err)
+	}
+
+	return roles, nil
+}
@@ -0,0 +1,142 @@
+package repositories
+
+import (
+	"context"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/vagonaizer/authenitfication-service/internal/domain/entities"
+	"github.com/vagonaizer/authenitfication-service/internal/infrastructure/database/postgres"
+	"github.com/vagonaizer/authenitfication-service/pkg/errors"
+)
+
+const invitationColumns = `id, organization_id, email, role, token_hash, invited_by, status,
+		expires_at, responded_at, created_at, updated_at`
+
+type invitationRepository struct {
+	db *postgres.DB
+}
+
+func NewInvitationRepository(db *postgres.DB) *invitationRepository {
+	return &invitationRepository{db: db}
+}
+
+func (r *invitationRepository) Create(ctx context.Context, invitation *entities.OrganizationInvitation) error {
+	query := `
+		INSERT INTO organization_invitations (id, organization_id, email, role, token_hash, invited_by, status, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING created_at, updated_at`
+
+	err := r.db.QueryRow(ctx, query,
+		invitation.ID, invitation.OrganizationID, invitation.Email, invitation.Role,
+		invitation.TokenHash, invitation.InvitedBy, invitation.Status, invitation.ExpiresAt,
+	).Scan(&invitation.CreatedAt, &invitation.UpdatedAt)
+
+	if err != nil {
+		if strings.Contains(err.Error(), "duplicate key") {
+			return errors.AlreadyExists("invitation already pending for this email")
+		}
+		return errors.DatabaseError(err)
+	}
+
+	return nil
+}
+
+func (r *invitationRepository) GetByID(ctx context.Context, id uuid.UUID) (*entities.OrganizationInvitation, error) {
+	query := `SELECT ` + invitationColumns + ` FROM organization_invitations WHERE id = $1`
+	return r.getOne(ctx, query, id)
+}
+
+func (r *invitationRepository) GetByTokenHash(ctx context.Context, tokenHash string) (*entities.OrganizationInvitation, error) {
+	query := `SELECT ` + invitationColumns + ` FROM organization_invitations WHERE token_hash = $1`
+	return r.getOne(ctx, query, tokenHash)
+}
+
+func (r *invitationRepository) GetPendingByEmail(ctx context.Context, orgID uuid.UUID, email string) (*entities.OrganizationInvitation, error) {
+	query := `SELECT ` + invitationColumns + `
+		FROM organization_invitations
+		WHERE organization_id = $1 AND email = $2 AND status = 'pending'`
+	return r.getOne(ctx, query, orgID, email)
+}
+
+func (r *invitationRepository) ListByOrganization(ctx context.Context, orgID uuid.UUID) ([]*entities.OrganizationInvitation, error) {
+	query := `SELECT ` + invitationColumns + `
+		FROM organization_invitations
+		WHERE organization_id = $1
+		ORDER BY created_at DESC`
+
+	rows, err := r.db.Query(ctx, query, orgID)
+	if err != nil {
+		return nil, errors.DatabaseError(err)
+	}
+	defer rows.Close()
+
+	var invitations []*entities.OrganizationInvitation
+	for rows.Next() {
+		invitation, err := scanInvitation(rows)
+		if err != nil {
+			return nil, errors.DatabaseError(err)
+		}
+		invitations = append(invitations, invitation)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, errors.DatabaseError(err)
+	}
+
+	return invitations, nil
+}
+
+// UpdateStatus moves a pending invitation into a final state. Invitations that
+// were already answered are reported as not found so that concurrent accepts
+// cannot both succeed.
+func (r *invitationRepository) UpdateStatus(ctx context.Context, id uuid.UUID, status string) error {
+	query := `
+		UPDATE organization_invitations
+		SET status = $2, responded_at = CURRENT_TIMESTAMP
+		WHERE id = $1 AND status = 'pending'`
+
+	result, err := r.db.Exec(ctx, query, id, status)
+	if err != nil {
+		return errors.DatabaseError(err)
+	}
+
+	rowsAffected := result.RowsAffected()
+
+	if rowsAffected == 0 {
+		return errors.NotFound("pending invitation not found")
+	}
+
+	return nil
+}
+
+func (r *invitationRepository) getOne(ctx context.Context, query string, args ...interface{}) (*entities.OrganizationInvitation, error) {
+	invitation, err := scanInvitation(r.db.QueryRow(ctx, query, args...))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, errors.NotFound("invitation not found")
+		}
+		return nil, errors.DatabaseError(err)
+	}
+
+	return invitation, nil
+}
+
+type invitationScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanInvitation(row invitationScanner) (*entities.OrganizationInvitation, error) {
+	invitation := &entities.OrganizationInvitation{}
+	err := row.Scan(
+		&invitation.ID, &invitation.OrganizationID, &invitation.Email, &invitation.Role,
+		&invitation.TokenHash, &invitation.InvitedBy, &invitation.Status,
+		&invitation.ExpiresAt, &invitation.RespondedAt, &invitation.CreatedAt, &invitation.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return invitation, nil
+}
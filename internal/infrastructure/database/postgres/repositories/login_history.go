@@ -0,0 +1,99 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/vagonaizer/authenitfication-service/internal/domain/entities"
+	"github.com/vagonaizer/authenitfication-service/internal/infrastructure/database/postgres"
+	"github.com/vagonaizer/authenitfication-service/pkg/errors"
+)
+
+type loginHistoryRepository struct {
+	db *postgres.DB
+}
+
+func NewLoginHistoryRepository(db *postgres.DB) *loginHistoryRepository {
+	return &loginHistoryRepository{db: db}
+}
+
+func (r *loginHistoryRepository) Create(ctx context.Context, attempt *entities.LoginAttempt) error {
+	if attempt.ID == uuid.Nil {
+		attempt.ID = uuid.New()
+	}
+
+	query := `
+		INSERT INTO login_history (id, user_id, email, success, failure_reason, ip_address, user_agent, country)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING created_at`
+
+	err := r.db.QueryRow(ctx, query,
+		attempt.ID, attempt.UserID, attempt.Email, attempt.Success, attempt.FailureReason,
+		attempt.IPAddress, attempt.UserAgent, attempt.Country,
+	).Scan(&attempt.CreatedAt)
+	if err != nil {
+		return errors.DatabaseError(err)
+	}
+
+	return nil
+}
+
+func (r *loginHistoryRepository) ListByUser(ctx context.Context, userID uuid.UUID, limit, offset int) ([]*entities.LoginAttempt, int64, error) {
+	var total int64
+	countQuery := `SELECT COUNT(*) FROM login_history WHERE user_id = $1`
+	if err := r.db.ReadQueryRow(ctx, countQuery, userID).Scan(&total); err != nil {
+		return nil, 0, errors.DatabaseError(err)
+	}
+
+	query := `
+		SELECT id, user_id, email, success, failure_reason, COALESCE(ip_address, ''), COALESCE(user_agent, ''), country, created_at
+		FROM login_history
+		WHERE user_id = $1
+		ORDER BY created_at DESC, id DESC
+		LIMIT $2 OFFSET $3`
+
+	rows, err := r.db.ReadQuery(ctx, query, userID, limit, offset)
+	if err != nil {
+		return nil, 0, errors.DatabaseError(err)
+	}
+	defer rows.Close()
+
+	var attempts []*entities.LoginAttempt
+	for rows.Next() {
+		attempt := &entities.LoginAttempt{}
+		err := rows.Scan(
+			&attempt.ID, &attempt.UserID, &attempt.Email, &attempt.Success, &attempt.FailureReason,
+			&attempt.IPAddress, &attempt.UserAgent, &attempt.Country, &attempt.CreatedAt,
+		)
+		if err != nil {
+			return nil, 0, errors.DatabaseError(err)
+		}
+		attempts = append(attempts, attempt)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, 0, errors.DatabaseError(err)
+	}
+
+	return attempts, total, nil
+}
+
+func (r *loginHistoryRepository) DeleteBefore(ctx context.Context, before time.Time, limit int) (int, error) {
+	query := `
+		DELETE FROM login_history
+		WHERE id IN (
+			SELECT id FROM login_history
+			WHERE created_at < $1
+			ORDER BY created_at
+			LIMIT $2
+			FOR UPDATE SKIP LOCKED
+		)`
+
+	result, err := r.db.Exec(ctx, query, before, limit)
+	if err != nil {
+		return 0, errors.DatabaseError(err)
+	}
+
+	return int(result.RowsAffected()), nil
+}
@@ -0,0 +1,120 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/vagonaizer/authenitfication-service/internal/domain/entities"
+	"github.com/vagonaizer/authenitfication-service/internal/infrastructure/database/postgres"
+	"github.com/vagonaizer/authenitfication-service/pkg/errors"
+)
+
+type noteRepository struct {
+	db *postgres.DB
+}
+
+func NewNoteRepository(db *postgres.DB) *noteRepository {
+	return &noteRepository{db: db}
+}
+
+func (r *noteRepository) Create(ctx context.Context, note *entities.UserNote) error {
+	if note.ID == uuid.Nil {
+		note.ID = uuid.New()
+	}
+
+	query := `
+		INSERT INTO user_notes (id, user_id, author_id, body)
+		VALUES ($1, $2, $3, $4)
+		RETURNING created_at, updated_at`
+
+	err := r.db.QueryRow(ctx, query, note.ID, note.UserID, note.AuthorID, note.Body).
+		Scan(&note.CreatedAt, &note.UpdatedAt)
+	if err != nil {
+		return errors.DatabaseError(err)
+	}
+
+	return nil
+}
+
+func (r *noteRepository) GetByID(ctx context.Context, id uuid.UUID) (*entities.UserNote, error) {
+	note := &entities.UserNote{}
+	query := `
+		SELECT id, user_id, author_id, body, created_at, updated_at
+		FROM user_notes
+		WHERE id = $1`
+
+	err := r.db.QueryRow(ctx, query, id).Scan(
+		&note.ID, &note.UserID, &note.AuthorID, &note.Body, &note.CreatedAt, &note.UpdatedAt,
+	)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, errors.NotFound("note not found")
+		}
+		return nil, errors.DatabaseError(err)
+	}
+
+	return note, nil
+}
+
+func (r *noteRepository) Update(ctx context.Context, note *entities.UserNote) error {
+	query := `
+		UPDATE user_notes
+		SET body = $2
+		WHERE id = $1
+		RETURNING updated_at`
+
+	err := r.db.QueryRow(ctx, query, note.ID, note.Body).Scan(&note.UpdatedAt)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return errors.NotFound("note not found")
+		}
+		return errors.DatabaseError(err)
+	}
+
+	return nil
+}
+
+func (r *noteRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	result, err := r.db.Exec(ctx, `DELETE FROM user_notes WHERE id = $1`, id)
+	if err != nil {
+		return errors.DatabaseError(err)
+	}
+
+	rows := result.RowsAffected()
+	if rows == 0 {
+		return errors.NotFound("note not found")
+	}
+
+	return nil
+}
+
+func (r *noteRepository) ListByUser(ctx context.Context, userID uuid.UUID) ([]*entities.UserNote, error) {
+	query := `
+		SELECT id, user_id, author_id, body, created_at, updated_at
+		FROM user_notes
+		WHERE user_id = $1
+		ORDER BY created_at DESC, id DESC`
+
+	rows, err := r.db.Query(ctx, query, userID)
+	if err != nil {
+		return nil, errors.DatabaseError(err)
+	}
+	defer rows.Close()
+
+	var notes []*entities.UserNote
+	for rows.Next() {
+		note := &entities.UserNote{}
+		err := rows.Scan(&note.ID, &note.UserID, &note.AuthorID, &note.Body, &note.CreatedAt, &note.UpdatedAt)
+		if err != nil {
+			return nil, errors.DatabaseError(err)
+		}
+		notes = append(notes, note)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, errors.DatabaseError(err)
+	}
+
+	return notes, nil
+}
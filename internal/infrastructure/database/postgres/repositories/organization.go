@@ -0,0 +1,336 @@
+package repositories
+
+import (
+	"context"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/vagonaizer/authenitfication-service/internal/domain/entities"
+	"github.com/vagonaizer/authenitfication-service/internal/infrastructure/database/postgres"
+	"github.com/vagonaizer/authenitfication-service/pkg/errors"
+)
+
+type organizationRepository struct {
+	db *postgres.DB
+}
+
+func NewOrganizationRepository(db *postgres.DB) *organizationRepository {
+	return &organizationRepository{db: db}
+}
+
+func (r *organizationRepository) Create(ctx context.Context, org *entities.Organization) error {
+	query := `
+		INSERT INTO organizations (id, name, slug, description, is_active)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING created_at, updated_at`
+
+	err := r.db.QueryRow(ctx, query,
+		org.ID, org.Name, org.Slug, org.Description, org.IsActive,
+	).Scan(&org.CreatedAt, &org.UpdatedAt)
+
+	if err != nil {
+		if strings.Contains(err.Error(), "duplicate key") {
+			return errors.AlreadyExists("organization slug already exists")
+		}
+		return errors.DatabaseError(err)
+	}
+
+	return nil
+}
+
+func (r *organizationRepository) GetByID(ctx context.Context, id uuid.UUID) (*entities.Organization, error) {
+	org := &entities.Organization{}
+	query := `
+		SELECT id, name, slug, description, is_active, created_at, updated_at, deleted_at
+		FROM organizations
+		WHERE id = $1 AND deleted_at IS NULL`
+
+	err := r.db.ReadQueryRow(ctx, query, id).Scan(
+		&org.ID, &org.Name, &org.Slug, &org.Description, &org.IsActive,
+		&org.CreatedAt, &org.UpdatedAt, &org.DeletedAt,
+	)
+
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, errors.NotFound("organization not found")
+		}
+		return nil, errors.DatabaseError(err)
+	}
+
+	return org, nil
+}
+
+func (r *organizationRepository) GetBySlug(ctx context.Context, slug string) (*entities.Organization, error) {
+	org := &entities.Organization{}
+	query := `
+		SELECT id, name, slug, description, is_active, created_at, updated_at, deleted_at
+		FROM organizations
+		WHERE slug = $1 AND deleted_at IS NULL`
+
+	err := r.db.QueryRow(ctx, query, slug).Scan(
+		&org.ID, &org.Name, &org.Slug, &org.Description, &org.IsActive,
+		&org.CreatedAt, &org.UpdatedAt, &org.DeletedAt,
+	)
+
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, errors.NotFound("organization not found")
+		}
+		return nil, errors.DatabaseError(err)
+	}
+
+	return org, nil
+}
+
+func (r *organizationRepository) ListByUserID(ctx context.Context, userID uuid.UUID) ([]*entities.Organization, error) {
+	query := `
+		SELECT o.id, o.name, o.slug, o.description, o.is_active, o.created_at, o.updated_at, o.deleted_at
+		FROM organizations o
+		INNER JOIN organization_members om ON o.id = om.organization_id
+		WHERE om.user_id = $1 AND o.deleted_at IS NULL
+		ORDER BY o.name`
+
+	rows, err := r.db.Query(ctx, query, userID)
+	if err != nil {
+		return nil, errors.DatabaseError(err)
+	}
+	defer rows.Close()
+
+	var orgs []*entities.Organization
+	for rows.Next() {
+		org := &entities.Organization{}
+		err := rows.Scan(
+			&org.ID, &org.Name, &org.Slug, &org.Description, &org.IsActive,
+			&org.CreatedAt, &org.UpdatedAt, &org.DeletedAt,
+		)
+		if err != nil {
+			return nil, errors.DatabaseError(err)
+		}
+		orgs = append(orgs, org)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, errors.DatabaseError(err)
+	}
+
+	return orgs, nil
+}
+
+func (r *organizationRepository) Update(ctx context.Context, org *entities.Organization) error {
+	query := `
+		UPDATE organizations
+		SET name = $2, slug = $3, description = $4, is_active = $5
+		WHERE id = $1 AND deleted_at IS NULL
+		RETURNING updated_at`
+
+	err := r.db.QueryRow(ctx, query,
+		org.ID, org.Name, org.Slug, org.Description, org.IsActive,
+	).Scan(&org.UpdatedAt)
+
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return errors.NotFound("organization not found")
+		}
+		if strings.Contains(err.Error(), "duplicate key") {
+			return errors.AlreadyExists("organization slug already exists")
+		}
+		return errors.DatabaseError(err)
+	}
+
+	return nil
+}
+
+func (r *organizationRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	query := `UPDATE organizations SET deleted_at = CURRENT_TIMESTAMP WHERE id = $1 AND deleted_at IS NULL`
+
+	result, err := r.db.Exec(ctx, query, id)
+	if err != nil {
+		return errors.DatabaseError(err)
+	}
+
+	rowsAffected := result.RowsAffected()
+
+	if rowsAffected == 0 {
+		return errors.NotFound("organization not found")
+	}
+
+	return nil
+}
+
+func (r *organizationRepository) AddMember(ctx context.Context, member *entities.OrganizationMember) error {
+	query := `
+		INSERT INTO organization_members (id, organization_id, user_id, role)
+		VALUES ($1, $2, $3, $4)
+		RETURNING created_at, updated_at`
+
+	err := r.db.QueryRow(ctx, query,
+		member.ID, member.OrganizationID, member.UserID, member.Role,
+	).Scan(&member.CreatedAt, &member.UpdatedAt)
+
+	if err != nil {
+		if strings.Contains(err.Error(), "duplicate key") {
+			return errors.AlreadyExists("user is already a member of the organization")
+		}
+		return errors.DatabaseError(err)
+	}
+
+	return nil
+}
+
+func (r *organizationRepository) GetMember(ctx context.Context, orgID, userID uuid.UUID) (*entities.OrganizationMember, error) {
+	member := &entities.OrganizationMember{}
+	query := `
+		SELECT id, organization_id, user_id, role, created_at, updated_at
+		FROM organization_members
+		WHERE organization_id = $1 AND user_id = $2`
+
+	err := r.db.QueryRow(ctx, query, orgID, userID).Scan(
+		&member.ID, &member.OrganizationID, &member.UserID, &member.Role,
+		&member.CreatedAt, &member.UpdatedAt,
+	)
+
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, errors.NotFound("organization member not found")
+		}
+		return nil, errors.DatabaseError(err)
+	}
+
+	return member, nil
+}
+
+func (r *organizationRepository) ListMembers(ctx context.Context, orgID uuid.UUID) ([]*entities.OrganizationMember, error) {
+	query := `
+		SELECT id, organization_id, user_id, role, created_at, updated_at
+		FROM organization_members
+		WHERE organization_id = $1
+		ORDER BY created_at`
+
+	rows, err := r.db.ReadQuery(ctx, query, orgID)
+	if err != nil {
+		return nil, errors.DatabaseError(err)
+	}
+	defer rows.Close()
+
+	var members []*entities.OrganizationMember
+	for rows.Next() {
+		member := &entities.OrganizationMember{}
+		err := rows.Scan(
+			&member.ID, &member.OrganizationID, &member.UserID, &member.Role,
+			&member.CreatedAt, &member.UpdatedAt,
+		)
+		if err != nil {
+			return nil, errors.DatabaseError(err)
+		}
+		members = append(members, member)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, errors.DatabaseError(err)
+	}
+
+	return members, nil
+}
+
+func (r *organizationRepository) UpdateMemberRole(ctx context.Context, orgID, userID uuid.UUID, role string) error {
+	query := `UPDATE organization_members SET role = $3 WHERE organization_id = $1 AND user_id = $2`
+
+	result, err := r.db.Exec(ctx, query, orgID, userID, role)
+	if err != nil {
+		return errors.DatabaseError(err)
+	}
+
+	rowsAffected := result.RowsAffected()
+
+	if rowsAffected == 0 {
+		return errors.NotFound("organization member not found")
+	}
+
+	return nil
+}
+
+func (r *organizationRepository) RemoveMember(ctx context.Context, orgID, userID uuid.UUID) error {
+	query := `DELETE FROM organization_members WHERE organization_id = $1 AND user_id = $2`
+
+	result, err := r.db.Exec(ctx, query, orgID, userID)
+	if err != nil {
+		return errors.DatabaseError(err)
+	}
+
+	rowsAffected := result.RowsAffected()
+
+	if rowsAffected == 0 {
+		return errors.NotFound("organization member not found")
+	}
+
+	return nil
+}
+
+func (r *organizationRepository) CountMembersByRole(ctx context.Context, orgID uuid.UUID, role string) (int, error) {
+	var count int
+	query := `SELECT COUNT(*) FROM organization_members WHERE organization_id = $1 AND role = $2`
+
+	err := r.db.QueryRow(ctx, query, orgID, role).Scan(&count)
+	if err != nil {
+		return 0, errors.DatabaseError(err)
+	}
+
+	return count, nil
+}
+
+func (r *organizationRepository) CountMembers(ctx context.Context, orgID uuid.UUID) (int, error) {
+	var count int
+	query := `SELECT COUNT(*) FROM organization_members WHERE organization_id = $1`
+
+	err := r.db.QueryRow(ctx, query, orgID).Scan(&count)
+	if err != nil {
+		return 0, errors.DatabaseError(err)
+	}
+
+	return count, nil
+}
+
+func (r *organizationRepository) GetQuota(ctx context.Context, orgID uuid.UUID) (*entities.OrganizationQuota, error) {
+	quota := &entities.OrganizationQuota{}
+	query := `
+		SELECT organization_id, max_members, max_sessions_per_user, tokens_per_minute, updated_by, created_at, updated_at
+		FROM organization_quotas
+		WHERE organization_id = $1`
+
+	err := r.db.QueryRow(ctx, query, orgID).Scan(
+		&quota.OrganizationID, &quota.MaxMembers, &quota.MaxSessionsPerUser, &quota.TokensPerMinute,
+		&quota.UpdatedBy, &quota.CreatedAt, &quota.UpdatedAt,
+	)
+
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, errors.NotFound("organization quota not found")
+		}
+		return nil, errors.DatabaseError(err)
+	}
+
+	return quota, nil
+}
+
+func (r *organizationRepository) UpsertQuota(ctx context.Context, quota *entities.OrganizationQuota) error {
+	query := `
+		INSERT INTO organization_quotas (organization_id, max_members, max_sessions_per_user, tokens_per_minute, updated_by)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (organization_id) DO UPDATE
+		SET max_members = EXCLUDED.max_members,
+			max_sessions_per_user = EXCLUDED.max_sessions_per_user,
+			tokens_per_minute = EXCLUDED.tokens_per_minute,
+			updated_by = EXCLUDED.updated_by
+		RETURNING created_at, updated_at`
+
+	err := r.db.QueryRow(ctx, query,
+		quota.OrganizationID, quota.MaxMembers, quota.MaxSessionsPerUser, quota.TokensPerMinute, quota.UpdatedBy,
+	).Scan(&quota.CreatedAt, &quota.UpdatedAt)
+
+	if err != nil {
+		return errors.DatabaseError(err)
+	}
+
+	return nil
+}
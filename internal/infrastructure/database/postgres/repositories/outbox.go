@@ -0,0 +1,114 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/vagonaizer/authenitfication-service/internal/domain/entities"
+	"github.com/vagonaizer/authenitfication-service/internal/infrastructure/database/postgres"
+	"github.com/vagonaizer/authenitfication-service/pkg/errors"
+)
+
+type outboxRepository struct {
+	db *postgres.DB
+}
+
+func NewOutboxRepository(db *postgres.DB) *outboxRepository {
+	return &outboxRepository{db: db}
+}
+
+func (r *outboxRepository) Create(ctx context.Context, message *entities.OutboxMessage) error {
+	query := `
+		INSERT INTO outbox (topic, key, payload, headers)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id, created_at`
+
+	err := r.db.QueryRow(ctx, query, message.Topic, message.Key, message.Payload, message.Headers).Scan(&message.ID, &message.CreatedAt)
+	if err != nil {
+		return errors.DatabaseError(err)
+	}
+
+	return nil
+}
+
+// ListPending reads from the primary: messages are deleted as soon as they
+// are published, and a lagging replica would hand them out again.
+func (r *outboxRepository) ListPending(ctx context.Context, limit int) ([]*entities.OutboxMessage, error) {
+	query := `
+		SELECT id, topic, key, payload, headers, created_at
+		FROM outbox
+		ORDER BY id
+		LIMIT $1`
+
+	rows, err := r.db.Query(ctx, query, limit)
+	if err != nil {
+		return nil, errors.DatabaseError(err)
+	}
+	defer rows.Close()
+
+	var messages []*entities.OutboxMessage
+	for rows.Next() {
+		message := &entities.OutboxMessage{}
+		if err := rows.Scan(&message.ID, &message.Topic, &message.Key, &message.Payload, &message.Headers, &message.CreatedAt); err != nil {
+			return nil, errors.DatabaseError(err)
+		}
+		messages = append(messages, message)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, errors.DatabaseError(err)
+	}
+
+	return messages, nil
+}
+
+func (r *outboxRepository) Delete(ctx context.Context, ids []int64) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	if _, err := r.db.Exec(ctx, `DELETE FROM outbox WHERE id = ANY($1)`, ids); err != nil {
+		return errors.DatabaseError(err)
+	}
+
+	return nil
+}
+
+// DeleteFenced deletes in the same statement that checks the fence. FOR
+// SHARE keeps a newer leader's Fence waiting until the delete commits, or
+// makes the check see its token if it committed first.
+func (r *outboxRepository) DeleteFenced(ctx context.Context, ids []int64, token int64) (bool, error) {
+	query := `
+		WITH fence AS (
+			SELECT token FROM outbox_fence WHERE token <= $2 FOR SHARE
+		), deleted AS (
+			DELETE FROM outbox WHERE id = ANY($1) AND EXISTS (SELECT 1 FROM fence)
+		)
+		SELECT EXISTS (SELECT 1 FROM fence)`
+
+	var current bool
+	if err := r.db.QueryRow(ctx, query, ids, token).Scan(&current); err != nil {
+		return false, errors.DatabaseError(err)
+	}
+
+	return current, nil
+}
+
+func (r *outboxRepository) Fence(ctx context.Context, token int64) (bool, error) {
+	result, err := r.db.Exec(ctx, `UPDATE outbox_fence SET token = $1 WHERE token <= $1`, token)
+	if err != nil {
+		return false, errors.DatabaseError(err)
+	}
+
+	return result.RowsAffected() == 1, nil
+}
+
+func (r *outboxRepository) Stats(ctx context.Context) (*entities.OutboxStats, error) {
+	stats := &entities.OutboxStats{}
+
+	err := r.db.QueryRow(ctx, `SELECT COUNT(*), MIN(created_at) FROM outbox`).Scan(&stats.Pending, &stats.OldestAt)
+	if err != nil {
+		return nil, errors.DatabaseError(err)
+	}
+
+	return stats, nil
+}
@@ -0,0 +1,150 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/vagonaizer/authenitfication-service/internal/domain/entities"
+	"github.com/vagonaizer/authenitfication-service/internal/infrastructure/database/postgres"
+	"github.com/vagonaizer/authenitfication-service/pkg/errors"
+)
+
+type permissionRepository struct {
+	db *postgres.DB
+}
+
+func NewPermissionRepository(db *postgres.DB) *permissionRepository {
+	return &permissionRepository{db: db}
+}
+
+func (r *permissionRepository) GetByName(ctx context.Context, name string) (*entities.Permission, error) {
+	permission := &entities.Permission{}
+	query := `SELECT id, name, description, created_at FROM permissions WHERE name = $1`
+
+	err := r.db.QueryRow(ctx, query, name).Scan(
+		&permission.ID, &permission.Name, &permission.Description, &permission.CreatedAt,
+	)
+
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, errors.NotFound("permission not found")
+		}
+		return nil, errors.DatabaseError(err)
+	}
+
+	return permission, nil
+}
+
+func (r *permissionRepository) List(ctx context.Context) ([]*entities.Permission, error) {
+	query := `SELECT id, name, description, created_at FROM permissions ORDER BY name`
+
+	rows, err := r.db.ReadQuery(ctx, query)
+	if err != nil {
+		return nil, errors.DatabaseError(err)
+	}
+	defer rows.Close()
+
+	return r.scanPermissions(rows)
+}
+
+func (r *permissionRepository) GrantToRole(ctx context.Context, roleID, permissionID uuid.UUID, condition *string) error {
+	query := `
+		INSERT INTO role_permissions (id, role_id, permission_id, condition)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (role_id, permission_id) DO UPDATE SET condition = EXCLUDED.condition`
+
+	_, err := r.db.Exec(ctx, query, uuid.New(), roleID, permissionID, condition)
+	if err != nil {
+		return errors.DatabaseError(err)
+	}
+
+	return nil
+}
+
+func (r *permissionRepository) RevokeFromRole(ctx context.Context, roleID, permissionID uuid.UUID) error {
+	query := `DELETE FROM role_permissions WHERE role_id = $1 AND permission_id = $2`
+
+	result, err := r.db.Exec(ctx, query, roleID, permissionID)
+	if err != nil {
+		return errors.DatabaseError(err)
+	}
+
+	rowsAffected := result.RowsAffected()
+
+	if rowsAffected == 0 {
+		return errors.NotFound("role permission grant not found")
+	}
+
+	return nil
+}
+
+func (r *permissionRepository) GetRoleGrants(ctx context.Context, roleID uuid.UUID) ([]*entities.PermissionGrant, error) {
+	query := `
+		SELECT p.name, COALESCE(rp.condition, '')
+		FROM permissions p
+		INNER JOIN role_permissions rp ON p.id = rp.permission_id
+		WHERE rp.role_id = $1
+		ORDER BY p.name`
+
+	rows, err := r.db.Query(ctx, query, roleID)
+	if err != nil {
+		return nil, errors.DatabaseError(err)
+	}
+	defer rows.Close()
+
+	return r.scanGrants(rows)
+}
+
+func (r *permissionRepository) GetGrantsByRoleName(ctx context.Context, roleName string) ([]*entities.PermissionGrant, error) {
+	query := `
+		SELECT p.name, COALESCE(rp.condition, '')
+		FROM permissions p
+		INNER JOIN role_permissions rp ON p.id = rp.permission_id
+		INNER JOIN roles r ON r.id = rp.role_id
+		WHERE r.name = $1
+		ORDER BY p.name`
+
+	rows, err := r.db.Query(ctx, query, roleName)
+	if err != nil {
+		return nil, errors.DatabaseError(err)
+	}
+	defer rows.Close()
+
+	return r.scanGrants(rows)
+}
+
+func (r *permissionRepository) scanPermissions(rows pgx.Rows) ([]*entities.Permission, error) {
+	var permissions []*entities.Permission
+	for rows.Next() {
+		permission := &entities.Permission{}
+		err := rows.Scan(&permission.ID, &permission.Name, &permission.Description, &permission.CreatedAt)
+		if err != nil {
+			return nil, errors.DatabaseError(err)
+		}
+		permissions = append(permissions, permission)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, errors.DatabaseError(err)
+	}
+
+	return permissions, nil
+}
+
+func (r *permissionRepository) scanGrants(rows pgx.Rows) ([]*entities.PermissionGrant, error) {
+	var grants []*entities.PermissionGrant
+	for rows.Next() {
+		grant := &entities.PermissionGrant{}
+		if err := rows.Scan(&grant.Permission, &grant.Condition); err != nil {
+			return nil, errors.DatabaseError(err)
+		}
+		grants = append(grants, grant)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, errors.DatabaseError(err)
+	}
+
+	return grants, nil
+}
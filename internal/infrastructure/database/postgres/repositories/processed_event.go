@@ -0,0 +1,66 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/vagonaizer/authenitfication-service/internal/infrastructure/database/postgres"
+	"github.com/vagonaizer/authenitfication-service/pkg/errors"
+)
+
+type processedEventRepository struct {
+	db *postgres.DB
+}
+
+func NewProcessedEventRepository(db *postgres.DB) *processedEventRepository {
+	return &processedEventRepository{db: db}
+}
+
+func (r *processedEventRepository) MarkEventProcessed(ctx context.Context, topic, eventID string, window time.Duration) error {
+	query := `
+		INSERT INTO processed_events (topic, event_id, expires_at)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (topic, event_id) DO UPDATE
+		SET processed_at = NOW(), expires_at = EXCLUDED.expires_at`
+
+	if _, err := r.db.Exec(ctx, query, topic, eventID, time.Now().Add(window)); err != nil {
+		return errors.DatabaseError(err)
+	}
+
+	return nil
+}
+
+// IsEventProcessed reads from the primary: a replica may not have seen the
+// mark of a delivery handled moments ago.
+func (r *processedEventRepository) IsEventProcessed(ctx context.Context, topic, eventID string) (bool, error) {
+	query := `
+		SELECT EXISTS(
+			SELECT 1 FROM processed_events
+			WHERE topic = $1 AND event_id = $2 AND expires_at > NOW()
+		)`
+
+	var processed bool
+	if err := r.db.QueryRow(ctx, query, topic, eventID).Scan(&processed); err != nil {
+		return false, errors.DatabaseError(err)
+	}
+
+	return processed, nil
+}
+
+func (r *processedEventRepository) DeleteExpired(ctx context.Context, limit int) (int, error) {
+	query := `
+		DELETE FROM processed_events
+		WHERE (topic, event_id) IN (
+			SELECT topic, event_id FROM processed_events
+			WHERE expires_at <= NOW()
+			LIMIT $1
+			FOR UPDATE SKIP LOCKED
+		)`
+
+	result, err := r.db.Exec(ctx, query, limit)
+	if err != nil {
+		return 0, errors.DatabaseError(err)
+	}
+
+	return int(result.RowsAffected()), nil
+}
@@ -2,9 +2,11 @@ package repositories
 
 import (
 	"context"
-	"database/sql"
+	"strings"
+	"time"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 	"github.com/vagonaizer/authenitfication-service/internal/domain/entities"
 	"github.com/vagonaizer/authenitfication-service/internal/infrastructure/database/postgres"
 	"github.com/vagonaizer/authenitfication-service/pkg/errors"
@@ -24,11 +26,14 @@ func (r *roleRepository) Create(ctx context.Context, role *entities.Role) error
 		VALUES ($1, $2, $3)
 		RETURNING created_at, updated_at`
 
-	err := r.db.QueryRowContext(ctx, query,
+	err := r.db.QueryRow(ctx, query,
 		role.ID, role.Name, role.Description,
 	).Scan(&role.CreatedAt, &role.UpdatedAt)
 
 	if err != nil {
+		if strings.Contains(err.Error(), "duplicate key") {
+			return errors.RoleExists()
+		}
 		return errors.DatabaseError(err)
 	}
 
@@ -39,12 +44,12 @@ func (r *roleRepository) GetByID(ctx context.Context, id uuid.UUID) (*entities.R
 	role := &entities.Role{}
 	query := `SELECT id, name, description, created_at, updated_at FROM roles WHERE id = $1`
 
-	err := r.db.QueryRowContext(ctx, query, id).Scan(
+	err := r.db.ReadQueryRow(ctx, query, id).Scan(
 		&role.ID, &role.Name, &role.Description, &role.CreatedAt, &role.UpdatedAt,
 	)
 
 	if err != nil {
-		if err == sql.ErrNoRows {
+		if err == pgx.ErrNoRows {
 			return nil, errors.NotFound("role not found")
 		}
 		return nil, errors.DatabaseError(err)
@@ -57,12 +62,12 @@ func (r *roleRepository) GetByName(ctx context.Context, name string) (*entities.
 	role := &entities.Role{}
 	query := `SELECT id, name, description, created_at, updated_at FROM roles WHERE name = $1`
 
-	err := r.db.QueryRowContext(ctx, query, name).Scan(
+	err := r.db.QueryRow(ctx, query, name).Scan(
 		&role.ID, &role.Name, &role.Description, &role.CreatedAt, &role.UpdatedAt,
 	)
 
 	if err != nil {
-		if err == sql.ErrNoRows {
+		if err == pgx.ErrNoRows {
 			return nil, errors.NotFound("role not found")
 		}
 		return nil, errors.DatabaseError(err)
@@ -74,7 +79,7 @@ func (r *roleRepository) GetByName(ctx context.Context, name string) (*entities.
 func (r *roleRepository) List(ctx context.Context) ([]*entities.Role, error) {
 	query := `SELECT id, name, description, created_at, updated_at FROM roles ORDER BY name`
 
-	rows, err := r.db.QueryContext(ctx, query)
+	rows, err := r.db.ReadQuery(ctx, query)
 	if err != nil {
 		return nil, errors.DatabaseError(err)
 	}
@@ -104,12 +109,15 @@ func (r *roleRepository) Update(ctx context.Context, role *entities.Role) error
 		WHERE id = $1
 		RETURNING updated_at`
 
-	err := r.db.QueryRowContext(ctx, query, role.ID, role.Name, role.Description).Scan(&role.UpdatedAt)
+	err := r.db.QueryRow(ctx, query, role.ID, role.Name, role.Description).Scan(&role.UpdatedAt)
 
 	if err != nil {
-		if err == sql.ErrNoRows {
+		if err == pgx.ErrNoRows {
 			return errors.NotFound("role not found")
 		}
+		if strings.Contains(err.Error(), "duplicate key") {
+			return errors.RoleExists()
+		}
 		return errors.DatabaseError(err)
 	}
 
@@ -119,15 +127,12 @@ func (r *roleRepository) Update(ctx context.Context, role *entities.Role) error
 func (r *roleRepository) Delete(ctx context.Context, id uuid.UUID) error {
 	query := `DELETE FROM roles WHERE id = $1`
 
-	result, err := r.db.ExecContext(ctx, query, id)
+	result, err := r.db.Exec(ctx, query, id)
 	if err != nil {
 		return errors.DatabaseError(err)
 	}
 
-	rowsAffected, err := result.RowsAffected()
-	if err != nil {
-		return errors.DatabaseError(err)
-	}
+	rowsAffected := result.RowsAffected()
 
 	if rowsAffected == 0 {
 		return errors.NotFound("role not found")
@@ -136,10 +141,14 @@ func (r *roleRepository) Delete(ctx context.Context, id uuid.UUID) error {
 	return nil
 }
 
-func (r *roleRepository) AssignRoleToUser(ctx context.Context, userID, roleID uuid.UUID) error {
-	query := `INSERT INTO user_roles (id, user_id, role_id) VALUES ($1, $2, $3) ON CONFLICT (user_id, role_id) DO NOTHING`
+func (r *roleRepository) AssignRoleToUser(ctx context.Context, userID, roleID uuid.UUID, scopeID *uuid.UUID, expiresAt *time.Time) error {
+	query := `
+		INSERT INTO user_roles (id, user_id, role_id, scope_id, expires_at)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (user_id, role_id, COALESCE(scope_id, '00000000-0000-0000-0000-000000000000'::uuid))
+		DO UPDATE SET expires_at = EXCLUDED.expires_at`
 
-	_, err := r.db.ExecContext(ctx, query, uuid.New(), userID, roleID)
+	_, err := r.db.Exec(ctx, query, uuid.New(), userID, roleID, scopeID, expiresAt)
 	if err != nil {
 		return errors.DatabaseError(err)
 	}
@@ -147,18 +156,15 @@ func (r *roleRepository) AssignRoleToUser(ctx context.Context, userID, roleID uu
 	return nil
 }
 
-func (r *roleRepository) RemoveRoleFromUser(ctx context.Context, userID, roleID uuid.UUID) error {
-	query := `DELETE FROM user_roles WHERE user_id = $1 AND role_id = $2`
+func (r *roleRepository) RemoveRoleFromUser(ctx context.Context, userID, roleID uuid.UUID, scopeID *uuid.UUID) error {
+	query := `DELETE FROM user_roles WHERE user_id = $1 AND role_id = $2 AND scope_id IS NOT DISTINCT FROM $3`
 
-	result, err := r.db.ExecContext(ctx, query, userID, roleID)
+	result, err := r.db.Exec(ctx, query, userID, roleID, scopeID)
 	if err != nil {
 		return errors.DatabaseError(err)
 	}
 
-	rowsAffected, err := result.RowsAffected()
-	if err != nil {
-		return errors.DatabaseError(err)
-	}
+	rowsAffected := result.RowsAffected()
 
 	if rowsAffected == 0 {
 		return errors.NotFound("user role assignment not found")
@@ -167,15 +173,17 @@ func (r *roleRepository) RemoveRoleFromUser(ctx context.Context, userID, roleID
 	return nil
 }
 
-func (r *roleRepository) GetUserRoles(ctx context.Context, userID uuid.UUID) ([]*entities.Role, error) {
+func (r *roleRepository) GetUserRoles(ctx context.Context, userID uuid.UUID, scopeID *uuid.UUID) ([]*entities.Role, error) {
 	query := `
-		SELECT r.id, r.name, r.description, r.created_at, r.updated_at
+		SELECT DISTINCT r.id, r.name, r.description, r.created_at, r.updated_at
 		FROM roles r
 		INNER JOIN user_roles ur ON r.id = ur.role_id
 		WHERE ur.user_id = $1
+			AND (ur.scope_id IS NULL OR ur.scope_id = $2)
+			AND (ur.expires_at IS NULL OR ur.expires_at > NOW())
 		ORDER BY r.name`
 
-	rows, err := r.db.QueryContext(ctx, query, userID)
+	rows, err := r.db.Query(ctx, query, userID, scopeID)
 	if err != nil {
 		return nil, errors.DatabaseError(err)
 	}
@@ -197,3 +205,119 @@ func (r *roleRepository) GetUserRoles(ctx context.Context, userID uuid.UUID) ([]
 
 	return roles, nil
 }
+
+func (r *roleRepository) BulkAssignRole(ctx context.Context, change *entities.BulkRoleChange) ([]uuid.UUID, error) {
+	query := `
+		INSERT INTO user_roles (id, user_id, role_id, scope_id, expires_at)
+		SELECT uuid_generate_v4(), u.id, $2, $3, $4
+		FROM users u
+		WHERE u.id = ANY($1::uuid[]) AND u.deleted_at IS NULL
+		ON CONFLICT (user_id, role_id, COALESCE(scope_id, '00000000-0000-0000-0000-000000000000'::uuid))
+		DO UPDATE SET expires_at = EXCLUDED.expires_at
+		RETURNING user_id`
+
+	return r.bulkChange(ctx, change, entities.RoleAuditActionGranted, query,
+		change.UserIDs, change.RoleID, change.ScopeID, change.ExpiresAt)
+}
+
+func (r *roleRepository) BulkRemoveRole(ctx context.Context, change *entities.BulkRoleChange) ([]uuid.UUID, error) {
+	query := `
+		DELETE FROM user_roles
+		WHERE user_id = ANY($1::uuid[]) AND role_id = $2 AND scope_id IS NOT DISTINCT FROM $3
+		RETURNING user_id`
+
+	return r.bulkChange(ctx, change, entities.RoleAuditActionRevoked, query,
+		change.UserIDs, change.RoleID, change.ScopeID)
+}
+
+// bulkChange runs a user_roles statement returning the affected user IDs and
+// records an audit entry for each of them in the same transaction.
+func (r *roleRepository) bulkChange(ctx context.Context, change *entities.BulkRoleChange, action, query string, args ...interface{}) ([]uuid.UUID, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, errors.DatabaseError(err)
+	}
+	defer tx.Rollback(ctx)
+
+	rows, err := tx.Query(ctx, query, args...)
+	if err != nil {
+		return nil, errors.DatabaseError(err)
+	}
+
+	var userIDs []uuid.UUID
+	for rows.Next() {
+		var userID uuid.UUID
+		if err := rows.Scan(&userID); err != nil {
+			rows.Close()
+			return nil, errors.DatabaseError(err)
+		}
+		userIDs = append(userIDs, userID)
+	}
+	rows.Close()
+
+	if err = rows.Err(); err != nil {
+		return nil, errors.DatabaseError(err)
+	}
+
+	auditQuery := `
+		INSERT INTO role_assignment_audit (user_id, role_id, role_name, scope_id, action, actor_id, reason, expires_at)
+		SELECT unnest($1::uuid[]), $2, $3, $4, $5, $6, $7, $8`
+
+	var expiresAt *time.Time
+	if action == entities.RoleAuditActionGranted {
+		expiresAt = change.ExpiresAt
+	}
+
+	_, err = tx.Exec(ctx, auditQuery,
+		userIDs, change.RoleID, change.RoleName, change.ScopeID,
+		action, change.ActorID, change.Reason, expiresAt,
+	)
+	if err != nil {
+		return nil, errors.DatabaseError(err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, errors.DatabaseError(err)
+	}
+
+	return userIDs, nil
+}
+
+func (r *roleRepository) DeleteExpiredUserRoles(ctx context.Context, before time.Time, limit int) ([]*entities.ExpiredRoleAssignment, error) {
+	query := `
+		WITH expired AS (
+			DELETE FROM user_roles
+			WHERE id IN (
+				SELECT id FROM user_roles
+				WHERE expires_at IS NOT NULL AND expires_at <= $1
+				ORDER BY expires_at
+				LIMIT $2
+				FOR UPDATE SKIP LOCKED
+			)
+			RETURNING user_id, role_id, scope_id, expires_at
+		)
+		SELECT e.user_id, e.role_id, r.name, e.scope_id, e.expires_at
+		FROM expired e
+		INNER JOIN roles r ON r.id = e.role_id`
+
+	rows, err := r.db.Query(ctx, query, before, limit)
+	if err != nil {
+		return nil, errors.DatabaseError(err)
+	}
+	defer rows.Close()
+
+	var assignments []*entities.ExpiredRoleAssignment
+	for rows.Next() {
+		a := &entities.ExpiredRoleAssignment{}
+		if err := rows.Scan(&a.UserID, &a.RoleID, &a.RoleName, &a.ScopeID, &a.ExpiresAt); err != nil {
+			return nil, errors.DatabaseError(err)
+		}
+		assignments = append(assignments, a)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, errors.DatabaseError(err)
+	}
+
+	return assignments, nil
+}
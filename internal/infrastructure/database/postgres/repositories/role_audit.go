@@ -0,0 +1,102 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/vagonaizer/authenitfication-service/internal/domain/entities"
+	"github.com/vagonaizer/authenitfication-service/internal/infrastructure/database/postgres"
+	"github.com/vagonaizer/authenitfication-service/pkg/errors"
+)
+
+type roleAuditRepository struct {
+	db *postgres.DB
+}
+
+func NewRoleAuditRepository(db *postgres.DB) *roleAuditRepository {
+	return &roleAuditRepository{db: db}
+}
+
+func (r *roleAuditRepository) Create(ctx context.Context, entry *entities.RoleAssignmentAudit) error {
+	if entry.ID == uuid.Nil {
+		entry.ID = uuid.New()
+	}
+
+	query := `
+		INSERT INTO role_assignment_audit (id, user_id, role_id, role_name, scope_id, action, actor_id, reason, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		RETURNING created_at`
+
+	err := r.db.QueryRow(ctx, query,
+		entry.ID, entry.UserID, entry.RoleID, entry.RoleName, entry.ScopeID,
+		entry.Action, entry.ActorID, entry.Reason, entry.ExpiresAt,
+	).Scan(&entry.CreatedAt)
+	if err != nil {
+		return errors.DatabaseError(err)
+	}
+
+	return nil
+}
+
+func (r *roleAuditRepository) List(ctx context.Context, filter entities.RoleAuditFilter, limit, offset int) ([]*entities.RoleAssignmentAudit, int64, error) {
+	var conditions []string
+	var args []interface{}
+
+	if filter.UserID != nil {
+		args = append(args, *filter.UserID)
+		conditions = append(conditions, fmt.Sprintf("user_id = $%d", len(args)))
+	}
+	if filter.RoleID != nil {
+		args = append(args, *filter.RoleID)
+		conditions = append(conditions, fmt.Sprintf("role_id = $%d", len(args)))
+	}
+	if filter.Action != "" {
+		args = append(args, filter.Action)
+		conditions = append(conditions, fmt.Sprintf("action = $%d", len(args)))
+	}
+
+	where := ""
+	if len(conditions) > 0 {
+		where = "WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	var total int64
+	countQuery := `SELECT COUNT(*) FROM role_assignment_audit ` + where
+	if err := r.db.ReadQueryRow(ctx, countQuery, args...).Scan(&total); err != nil {
+		return nil, 0, errors.DatabaseError(err)
+	}
+
+	query := fmt.Sprintf(`
+		SELECT id, user_id, role_id, role_name, scope_id, action, actor_id, reason, expires_at, created_at
+		FROM role_assignment_audit
+		%s
+		ORDER BY created_at DESC
+		LIMIT $%d OFFSET $%d`, where, len(args)+1, len(args)+2)
+
+	rows, err := r.db.ReadQuery(ctx, query, append(args, limit, offset)...)
+	if err != nil {
+		return nil, 0, errors.DatabaseError(err)
+	}
+	defer rows.Close()
+
+	var entries []*entities.RoleAssignmentAudit
+	for rows.Next() {
+		entry := &entities.RoleAssignmentAudit{}
+		err := rows.Scan(
+			&entry.ID, &entry.UserID, &entry.RoleID, &entry.RoleName, &entry.ScopeID,
+			&entry.Action, &entry.ActorID, &entry.Reason, &entry.ExpiresAt, &entry.CreatedAt,
+		)
+		if err != nil {
+			return nil, 0, errors.DatabaseError(err)
+		}
+		entries = append(entries, entry)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, 0, errors.DatabaseError(err)
+	}
+
+	return entries, total, nil
+}
@@ -0,0 +1,205 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/vagonaizer/authenitfication-service/internal/domain/entities"
+	"github.com/vagonaizer/authenitfication-service/internal/infrastructure/database/postgres"
+	"github.com/vagonaizer/authenitfication-service/pkg/errors"
+)
+
+type serviceAccountRepository struct {
+	db *postgres.DB
+}
+
+func NewServiceAccountRepository(db *postgres.DB) *serviceAccountRepository {
+	return &serviceAccountRepository{db: db}
+}
+
+func (r *serviceAccountRepository) List(ctx context.Context, limit, offset int) ([]*entities.User, error) {
+	query := `
+		SELECT id, email, username, password_hash, first_name, last_name,
+			   is_active, is_verified, is_service_account, last_login_at, created_at, updated_at, deleted_at
+		FROM users
+		WHERE is_service_account AND deleted_at IS NULL
+		ORDER BY created_at DESC
+		LIMIT $1 OFFSET $2`
+
+	rows, err := r.db.Query(ctx, query, limit, offset)
+	if err != nil {
+		return nil, errors.DatabaseError(err)
+	}
+	defer rows.Close()
+
+	var users []*entities.User
+	for rows.Next() {
+		user := &entities.User{}
+		err := rows.Scan(
+			&user.ID, &user.Email, &user.Username, &user.PasswordHash,
+			&user.FirstName, &user.LastName, &user.IsActive, &user.IsVerified, &user.IsServiceAccount,
+			&user.LastLoginAt, &user.CreatedAt, &user.UpdatedAt, &user.DeletedAt,
+		)
+		if err != nil {
+			return nil, errors.DatabaseError(err)
+		}
+		users = append(users, user)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, errors.DatabaseError(err)
+	}
+
+	return users, nil
+}
+
+func (r *serviceAccountRepository) CreateKey(ctx context.Context, key *entities.ServiceAccountKey) error {
+	query := `
+		INSERT INTO service_account_keys (id, user_id, key_id, secret_hash, created_by)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING created_at`
+
+	err := r.db.QueryRow(ctx, query,
+		key.ID, key.UserID, key.KeyID, key.SecretHash, key.CreatedBy,
+	).Scan(&key.CreatedAt)
+
+	if err != nil {
+		return errors.DatabaseError(err)
+	}
+
+	return nil
+}
+
+func (r *serviceAccountRepository) RotateKey(ctx context.Context, key *entities.ServiceAccountKey) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return errors.DatabaseError(err)
+	}
+	defer tx.Rollback(ctx)
+
+	revokeQuery := `
+		UPDATE service_account_keys
+		SET revoked_at = CURRENT_TIMESTAMP
+		WHERE user_id = $1 AND revoked_at IS NULL`
+
+	if _, err := tx.Exec(ctx, revokeQuery, key.UserID); err != nil {
+		return errors.DatabaseError(err)
+	}
+
+	insertQuery := `
+		INSERT INTO service_account_keys (id, user_id, key_id, secret_hash, created_by)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING created_at`
+
+	err = tx.QueryRow(ctx, insertQuery,
+		key.ID, key.UserID, key.KeyID, key.SecretHash, key.CreatedBy,
+	).Scan(&key.CreatedAt)
+	if err != nil {
+		return errors.DatabaseError(err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return errors.DatabaseError(err)
+	}
+
+	return nil
+}
+
+func (r *serviceAccountRepository) GetKeyByKeyID(ctx context.Context, keyID string) (*entities.ServiceAccountKey, error) {
+	key := &entities.ServiceAccountKey{}
+	query := `
+		SELECT id, user_id, key_id, secret_hash, created_by, last_used_at, revoked_at, created_at
+		FROM service_account_keys
+		WHERE key_id = $1`
+
+	err := r.db.QueryRow(ctx, query, keyID).Scan(
+		&key.ID, &key.UserID, &key.KeyID, &key.SecretHash,
+		&key.CreatedBy, &key.LastUsedAt, &key.RevokedAt, &key.CreatedAt,
+	)
+
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, errors.NotFound("service account key not found")
+		}
+		return nil, errors.DatabaseError(err)
+	}
+
+	return key, nil
+}
+
+func (r *serviceAccountRepository) ListKeys(ctx context.Context, userID uuid.UUID) ([]*entities.ServiceAccountKey, error) {
+	query := `
+		SELECT id, user_id, key_id, secret_hash, created_by, last_used_at, revoked_at, created_at
+		FROM service_account_keys
+		WHERE user_id = $1
+		ORDER BY created_at DESC`
+
+	rows, err := r.db.Query(ctx, query, userID)
+	if err != nil {
+		return nil, errors.DatabaseError(err)
+	}
+	defer rows.Close()
+
+	var keys []*entities.ServiceAccountKey
+	for rows.Next() {
+		key := &entities.ServiceAccountKey{}
+		err := rows.Scan(
+			&key.ID, &key.UserID, &key.KeyID, &key.SecretHash,
+			&key.CreatedBy, &key.LastUsedAt, &key.RevokedAt, &key.CreatedAt,
+		)
+		if err != nil {
+			return nil, errors.DatabaseError(err)
+		}
+		keys = append(keys, key)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, errors.DatabaseError(err)
+	}
+
+	return keys, nil
+}
+
+func (r *serviceAccountRepository) RevokeKey(ctx context.Context, userID uuid.UUID, keyID string) error {
+	query := `
+		UPDATE service_account_keys
+		SET revoked_at = CURRENT_TIMESTAMP
+		WHERE user_id = $1 AND key_id = $2 AND revoked_at IS NULL`
+
+	result, err := r.db.Exec(ctx, query, userID, keyID)
+	if err != nil {
+		return errors.DatabaseError(err)
+	}
+
+	rowsAffected := result.RowsAffected()
+
+	if rowsAffected == 0 {
+		return errors.NotFound("active service account key not found")
+	}
+
+	return nil
+}
+
+func (r *serviceAccountRepository) RevokeAllKeys(ctx context.Context, userID uuid.UUID) error {
+	query := `
+		UPDATE service_account_keys
+		SET revoked_at = CURRENT_TIMESTAMP
+		WHERE user_id = $1 AND revoked_at IS NULL`
+
+	if _, err := r.db.Exec(ctx, query, userID); err != nil {
+		return errors.DatabaseError(err)
+	}
+
+	return nil
+}
+
+func (r *serviceAccountRepository) TouchKey(ctx context.Context, id uuid.UUID) error {
+	query := `UPDATE service_account_keys SET last_used_at = CURRENT_TIMESTAMP WHERE id = $1`
+
+	if _, err := r.db.Exec(ctx, query, id); err != nil {
+		return errors.DatabaseError(err)
+	}
+
+	return nil
+}
@@ -2,10 +2,11 @@ package repositories
 
 import (
 	"context"
-	"database/sql"
 	"net"
+	"time"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 	"github.com/vagonaizer/authenitfication-service/internal/domain/entities"
 	"github.com/vagonaizer/authenitfication-service/internal/infrastructure/database/postgres"
 	"github.com/vagonaizer/authenitfication-service/pkg/errors"
@@ -40,12 +41,12 @@ func (r *SessionRepository) Create(ctx context.Context, session *entities.Sessio
 	}
 
 	query := `
-		INSERT INTO sessions (id, user_id, refresh_token, user_agent, ip_address, is_active, expires_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		INSERT INTO sessions (id, user_id, organization_id, refresh_token, user_agent, ip_address, is_active, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
 		RETURNING created_at, updated_at`
 
-	err := r.db.QueryRowContext(ctx, query,
-		session.ID, session.UserID, session.RefreshToken,
+	err := r.db.QueryRow(ctx, query,
+		session.ID, session.UserID, session.OrganizationID, session.RefreshToken,
 		userAgent, ipAddress, session.IsActive, session.ExpiresAt,
 	).Scan(&session.CreatedAt, &session.UpdatedAt)
 
@@ -62,19 +63,20 @@ func (r *SessionRepository) Create(ctx context.Context, session *entities.Sessio
 
 func (r *SessionRepository) GetByID(ctx context.Context, id uuid.UUID) (*entities.Session, error) {
 	session := &entities.Session{}
+	// inet читаем через host(): pgx не сканирует inet в string
 	query := `
-		SELECT id, user_id, refresh_token, user_agent, ip_address, is_active, expires_at, created_at, updated_at
+		SELECT id, user_id, organization_id, refresh_token, user_agent, host(ip_address), is_active, expires_at, created_at, updated_at
 		FROM sessions 
 		WHERE id = $1`
 
-	err := r.db.QueryRowContext(ctx, query, id).Scan(
-		&session.ID, &session.UserID, &session.RefreshToken,
+	err := r.db.QueryRow(ctx, query, id).Scan(
+		&session.ID, &session.UserID, &session.OrganizationID, &session.RefreshToken,
 		&session.UserAgent, &session.IPAddress, &session.IsActive,
 		&session.ExpiresAt, &session.CreatedAt, &session.UpdatedAt,
 	)
 
 	if err != nil {
-		if err == sql.ErrNoRows {
+		if err == pgx.ErrNoRows {
 			return nil, errors.NotFound("session not found")
 		}
 		return nil, errors.DatabaseError(err)
@@ -86,18 +88,18 @@ func (r *SessionRepository) GetByID(ctx context.Context, id uuid.UUID) (*entitie
 func (r *SessionRepository) GetByRefreshToken(ctx context.Context, refreshToken string) (*entities.Session, error) {
 	session := &entities.Session{}
 	query := `
-		SELECT id, user_id, refresh_token, user_agent, ip_address, is_active, expires_at, created_at, updated_at
+		SELECT id, user_id, organization_id, refresh_token, user_agent, host(ip_address), is_active, expires_at, created_at, updated_at
 		FROM sessions 
 		WHERE refresh_token = $1`
 
-	err := r.db.QueryRowContext(ctx, query, refreshToken).Scan(
-		&session.ID, &session.UserID, &session.RefreshToken,
+	err := r.db.QueryRow(ctx, query, refreshToken).Scan(
+		&session.ID, &session.UserID, &session.OrganizationID, &session.RefreshToken,
 		&session.UserAgent, &session.IPAddress, &session.IsActive,
 		&session.ExpiresAt, &session.CreatedAt, &session.UpdatedAt,
 	)
 
 	if err != nil {
-		if err == sql.ErrNoRows {
+		if err == pgx.ErrNoRows {
 			return nil, errors.NotFound("session not found")
 		}
 		return nil, errors.DatabaseError(err)
@@ -108,12 +110,12 @@ func (r *SessionRepository) GetByRefreshToken(ctx context.Context, refreshToken
 
 func (r *SessionRepository) GetActiveByUserID(ctx context.Context, userID uuid.UUID) ([]*entities.Session, error) {
 	query := `
-		SELECT id, user_id, refresh_token, user_agent, ip_address, is_active, expires_at, created_at, updated_at
+		SELECT id, user_id, organization_id, refresh_token, user_agent, host(ip_address), is_active, expires_at, created_at, updated_at
 		FROM sessions 
 		WHERE user_id = $1 AND is_active = true AND expires_at > CURRENT_TIMESTAMP
 		ORDER BY created_at DESC`
 
-	rows, err := r.db.QueryContext(ctx, query, userID)
+	rows, err := r.db.Query(ctx, query, userID)
 	if err != nil {
 		return nil, errors.DatabaseError(err)
 	}
@@ -123,7 +125,7 @@ func (r *SessionRepository) GetActiveByUserID(ctx context.Context, userID uuid.U
 	for rows.Next() {
 		session := &entities.Session{}
 		err := rows.Scan(
-			&session.ID, &session.UserID, &session.RefreshToken,
+			&session.ID, &session.UserID, &session.OrganizationID, &session.RefreshToken,
 			&session.UserAgent, &session.IPAddress, &session.IsActive,
 			&session.ExpiresAt, &session.CreatedAt, &session.UpdatedAt,
 		)
@@ -143,17 +145,17 @@ func (r *SessionRepository) GetActiveByUserID(ctx context.Context, userID uuid.U
 func (r *SessionRepository) Update(ctx context.Context, session *entities.Session) error {
 	query := `
 		UPDATE sessions 
-		SET user_agent = $2, ip_address = $3, is_active = $4, expires_at = $5
+		SET user_agent = $2, ip_address = $3, is_active = $4, expires_at = $5, organization_id = $6
 		WHERE id = $1
 		RETURNING updated_at`
 
-	err := r.db.QueryRowContext(ctx, query,
+	err := r.db.QueryRow(ctx, query,
 		session.ID, session.UserAgent, session.IPAddress,
-		session.IsActive, session.ExpiresAt,
+		session.IsActive, session.ExpiresAt, session.OrganizationID,
 	).Scan(&session.UpdatedAt)
 
 	if err != nil {
-		if err == sql.ErrNoRows {
+		if err == pgx.ErrNoRows {
 			return errors.NotFound("session not found")
 		}
 		return errors.DatabaseError(err)
@@ -165,15 +167,12 @@ func (r *SessionRepository) Update(ctx context.Context, session *entities.Sessio
 func (r *SessionRepository) Delete(ctx context.Context, id uuid.UUID) error {
 	query := `DELETE FROM sessions WHERE id = $1`
 
-	result, err := r.db.ExecContext(ctx, query, id)
+	result, err := r.db.Exec(ctx, query, id)
 	if err != nil {
 		return errors.DatabaseError(err)
 	}
 
-	rowsAffected, err := result.RowsAffected()
-	if err != nil {
-		return errors.DatabaseError(err)
-	}
+	rowsAffected := result.RowsAffected()
 
 	if rowsAffected == 0 {
 		return errors.NotFound("session not found")
@@ -185,7 +184,7 @@ func (r *SessionRepository) Delete(ctx context.Context, id uuid.UUID) error {
 func (r *SessionRepository) DeleteByUserID(ctx context.Context, userID uuid.UUID) error {
 	query := `DELETE FROM sessions WHERE user_id = $1`
 
-	_, err := r.db.ExecContext(ctx, query, userID)
+	_, err := r.db.Exec(ctx, query, userID)
 	if err != nil {
 		return errors.DatabaseError(err)
 	}
@@ -193,13 +192,33 @@ func (r *SessionRepository) DeleteByUserID(ctx context.Context, userID uuid.UUID
 	return nil
 }
 
-func (r *SessionRepository) DeleteExpired(ctx context.Context) error {
-	query := `DELETE FROM sessions WHERE expires_at < CURRENT_TIMESTAMP`
-
-	_, err := r.db.ExecContext(ctx, query)
+func (r *SessionRepository) DeleteExpired(ctx context.Context, before time.Time, limit int) (int, error) {
+	query := `
+		DELETE FROM sessions
+		WHERE id IN (
+			SELECT id FROM sessions
+			WHERE expires_at < $1
+			LIMIT $2
+		)`
+
+	result, err := r.db.Exec(ctx, query, before, limit)
 	if err != nil {
-		return errors.DatabaseError(err)
+		return 0, errors.DatabaseError(err)
 	}
 
-	return nil
+	return int(result.RowsAffected()), nil
+}
+
+func (r *SessionRepository) CountActive(ctx context.Context, now time.Time) (int64, int64, error) {
+	query := `
+		SELECT COUNT(*), COUNT(DISTINCT user_id)
+		FROM sessions
+		WHERE is_active AND expires_at > $1`
+
+	var sessions, users int64
+	if err := r.db.ReadQueryRow(ctx, query, now).Scan(&sessions, &users); err != nil {
+		return 0, 0, errors.DatabaseError(err)
+	}
+
+	return sessions, users, nil
 }
@@ -0,0 +1,67 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/vagonaizer/authenitfication-service/internal/domain/entities"
+	"github.com/vagonaizer/authenitfication-service/internal/infrastructure/database/postgres"
+	"github.com/vagonaizer/authenitfication-service/pkg/errors"
+)
+
+type statsRepository struct {
+	db *postgres.DB
+}
+
+func NewStatsRepository(db *postgres.DB) *statsRepository {
+	return &statsRepository{db: db}
+}
+
+func (r *statsRepository) GetUserStats(ctx context.Context, since, now time.Time) (*entities.UserStats, error) {
+	stats := &entities.UserStats{}
+
+	err := r.db.ReadQueryRow(ctx, `
+		SELECT COUNT(*),
+			   COUNT(*) FILTER (WHERE is_verified),
+			   COUNT(*) FILTER (WHERE last_login_at >= $1),
+			   COUNT(*) FILTER (WHERE last_login_at >= $2)
+		FROM users
+		WHERE deleted_at IS NULL AND NOT is_service_account`,
+		now.AddDate(0, 0, -7), now.AddDate(0, 0, -30),
+	).Scan(&stats.TotalUsers, &stats.VerifiedUsers, &stats.ActiveUsers7d, &stats.ActiveUsers30d)
+	if err != nil {
+		return nil, errors.DatabaseError(err)
+	}
+
+	// Registrations count every account created in the window, including
+	// ones deleted since.
+	rows, err := r.db.ReadQuery(ctx, `
+		SELECT d.day, COUNT(u.day)
+		FROM generate_series(($1::timestamptz AT TIME ZONE 'UTC')::date, ($2::timestamptz AT TIME ZONE 'UTC')::date, interval '1 day') AS d(day)
+		LEFT JOIN (
+			SELECT (created_at AT TIME ZONE 'UTC')::date AS day
+			FROM users
+			WHERE created_at >= ($1::timestamptz AT TIME ZONE 'UTC')::date AT TIME ZONE 'UTC'
+				AND NOT is_service_account
+		) u ON u.day = d.day
+		GROUP BY d.day
+		ORDER BY d.day`, since, now)
+	if err != nil {
+		return nil, errors.DatabaseError(err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var day entities.DailyCount
+		if err := rows.Scan(&day.Date, &day.Count); err != nil {
+			return nil, errors.DatabaseError(err)
+		}
+		stats.Registrations = append(stats.Registrations, day)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, errors.DatabaseError(err)
+	}
+
+	return stats, nil
+}
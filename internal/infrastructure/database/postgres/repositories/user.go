@@ -2,10 +2,12 @@ package repositories
 
 import (
 	"context"
-	"database/sql"
+	"fmt"
 	"strings"
+	"time"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 	"github.com/vagonaizer/authenitfication-service/internal/domain/entities"
 	"github.com/vagonaizer/authenitfication-service/internal/infrastructure/database/postgres"
 	"github.com/vagonaizer/authenitfication-service/pkg/errors"
@@ -20,26 +22,27 @@ func NewUserRepository(db *postgres.DB) *userRepository {
 }
 
 func (r *userRepository) Create(ctx context.Context, user *entities.User) error {
+	if user.Locale == "" {
+		user.Locale = entities.DefaultLocale
+	}
+	if user.Timezone == "" {
+		user.Timezone = entities.DefaultTimezone
+	}
+
 	query := `
-		INSERT INTO users (id, email, username, password_hash, first_name, last_name, is_active, is_verified)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		INSERT INTO users (id, email, username, password_hash, first_name, last_name, is_active, is_verified, is_service_account, password_change_required,
+			locale, timezone)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
 		RETURNING created_at, updated_at`
 
-	err := r.db.QueryRowContext(ctx, query,
+	err := r.db.QueryRow(ctx, query,
 		user.ID, user.Email, user.Username, user.PasswordHash,
-		user.FirstName, user.LastName, user.IsActive, user.IsVerified,
+		user.FirstName, user.LastName, user.IsActive, user.IsVerified, user.IsServiceAccount, user.PasswordChangeRequired,
+		user.Locale, user.Timezone,
 	).Scan(&user.CreatedAt, &user.UpdatedAt)
 
 	if err != nil {
-		if strings.Contains(err.Error(), "duplicate key") {
-			if strings.Contains(err.Error(), "email") {
-				return errors.EmailExists()
-			}
-			if strings.Contains(err.Error(), "username") {
-				return errors.UsernameExists()
-			}
-		}
-		return errors.DatabaseError(err)
+		return userWriteError(err)
 	}
 
 	return nil
@@ -48,19 +51,23 @@ func (r *userRepository) Create(ctx context.Context, user *entities.User) error
 func (r *userRepository) GetByID(ctx context.Context, id uuid.UUID) (*entities.User, error) {
 	user := &entities.User{}
 	query := `
-		SELECT id, email, username, password_hash, first_name, last_name, 
-			   is_active, is_verified, last_login_at, created_at, updated_at, deleted_at
+		SELECT id, email, username, password_hash, first_name, last_name, avatar_url,
+			   is_active, is_verified, is_service_account, password_change_required, last_login_at, created_at, updated_at, deleted_at,
+			   banned_at, banned_until, ban_reason, banned_by, phone, phone_verified_at, locale, timezone,
+			   deletion_requested_at, deletion_scheduled_at
 		FROM users 
 		WHERE id = $1 AND deleted_at IS NULL`
 
-	err := r.db.QueryRowContext(ctx, query, id).Scan(
+	err := r.db.ReadQueryRow(ctx, query, id).Scan(
 		&user.ID, &user.Email, &user.Username, &user.PasswordHash,
-		&user.FirstName, &user.LastName, &user.IsActive, &user.IsVerified,
+		&user.FirstName, &user.LastName, &user.AvatarURL, &user.IsActive, &user.IsVerified, &user.IsServiceAccount, &user.PasswordChangeRequired,
 		&user.LastLoginAt, &user.CreatedAt, &user.UpdatedAt, &user.DeletedAt,
+		&user.BannedAt, &user.BannedUntil, &user.BanReason, &user.BannedBy, &user.Phone, &user.PhoneVerifiedAt, &user.Locale, &user.Timezone,
+		&user.DeletionRequestedAt, &user.DeletionScheduledAt,
 	)
 
 	if err != nil {
-		if err == sql.ErrNoRows {
+		if err == pgx.ErrNoRows {
 			return nil, errors.UserNotFound()
 		}
 		return nil, errors.DatabaseError(err)
@@ -72,19 +79,23 @@ func (r *userRepository) GetByID(ctx context.Context, id uuid.UUID) (*entities.U
 func (r *userRepository) GetByEmail(ctx context.Context, email string) (*entities.User, error) {
 	user := &entities.User{}
 	query := `
-		SELECT id, email, username, password_hash, first_name, last_name, 
-			   is_active, is_verified, last_login_at, created_at, updated_at, deleted_at
+		SELECT id, email, username, password_hash, first_name, last_name, avatar_url,
+			   is_active, is_verified, is_service_account, password_change_required, last_login_at, created_at, updated_at, deleted_at,
+			   banned_at, banned_until, ban_reason, banned_by, phone, phone_verified_at, locale, timezone,
+			   deletion_requested_at, deletion_scheduled_at
 		FROM users 
 		WHERE email = $1 AND deleted_at IS NULL`
 
-	err := r.db.QueryRowContext(ctx, query, email).Scan(
+	err := r.db.QueryRow(ctx, query, email).Scan(
 		&user.ID, &user.Email, &user.Username, &user.PasswordHash,
-		&user.FirstName, &user.LastName, &user.IsActive, &user.IsVerified,
+		&user.FirstName, &user.LastName, &user.AvatarURL, &user.IsActive, &user.IsVerified, &user.IsServiceAccount, &user.PasswordChangeRequired,
 		&user.LastLoginAt, &user.CreatedAt, &user.UpdatedAt, &user.DeletedAt,
+		&user.BannedAt, &user.BannedUntil, &user.BanReason, &user.BannedBy, &user.Phone, &user.PhoneVerifiedAt, &user.Locale, &user.Timezone,
+		&user.DeletionRequestedAt, &user.DeletionScheduledAt,
 	)
 
 	if err != nil {
-		if err == sql.ErrNoRows {
+		if err == pgx.ErrNoRows {
 			return nil, errors.UserNotFound()
 		}
 		return nil, errors.DatabaseError(err)
@@ -96,19 +107,23 @@ func (r *userRepository) GetByEmail(ctx context.Context, email string) (*entitie
 func (r *userRepository) GetByUsername(ctx context.Context, username string) (*entities.User, error) {
 	user := &entities.User{}
 	query := `
-		SELECT id, email, username, password_hash, first_name, last_name, 
-			   is_active, is_verified, last_login_at, created_at, updated_at, deleted_at
+		SELECT id, email, username, password_hash, first_name, last_name, avatar_url,
+			   is_active, is_verified, is_service_account, password_change_required, last_login_at, created_at, updated_at, deleted_at,
+			   banned_at, banned_until, ban_reason, banned_by, phone, phone_verified_at, locale, timezone,
+			   deletion_requested_at, deletion_scheduled_at
 		FROM users 
 		WHERE username = $1 AND deleted_at IS NULL`
 
-	err := r.db.QueryRowContext(ctx, query, username).Scan(
+	err := r.db.QueryRow(ctx, query, username).Scan(
 		&user.ID, &user.Email, &user.Username, &user.PasswordHash,
-		&user.FirstName, &user.LastName, &user.IsActive, &user.IsVerified,
+		&user.FirstName, &user.LastName, &user.AvatarURL, &user.IsActive, &user.IsVerified, &user.IsServiceAccount, &user.PasswordChangeRequired,
 		&user.LastLoginAt, &user.CreatedAt, &user.UpdatedAt, &user.DeletedAt,
+		&user.BannedAt, &user.BannedUntil, &user.BanReason, &user.BannedBy, &user.Phone, &user.PhoneVerifiedAt, &user.Locale, &user.Timezone,
+		&user.DeletionRequestedAt, &user.DeletionScheduledAt,
 	)
 
 	if err != nil {
-		if err == sql.ErrNoRows {
+		if err == pgx.ErrNoRows {
 			return nil, errors.UserNotFound()
 		}
 		return nil, errors.DatabaseError(err)
@@ -121,28 +136,58 @@ func (r *userRepository) Update(ctx context.Context, user *entities.User) error
 	query := `
 		UPDATE users 
 		SET email = $2, username = $3, password_hash = $4, first_name = $5, 
-			last_name = $6, is_active = $7, is_verified = $8, last_login_at = $9
+			last_name = $6, is_active = $7, is_verified = $8, last_login_at = $9,
+			password_change_required = $10, avatar_url = $11, locale = $12, timezone = $13
 		WHERE id = $1 AND deleted_at IS NULL
 		RETURNING updated_at`
 
-	err := r.db.QueryRowContext(ctx, query,
+	err := r.db.QueryRow(ctx, query,
 		user.ID, user.Email, user.Username, user.PasswordHash,
 		user.FirstName, user.LastName, user.IsActive, user.IsVerified, user.LastLoginAt,
+		user.PasswordChangeRequired, user.AvatarURL, user.Locale, user.Timezone,
 	).Scan(&user.UpdatedAt)
 
 	if err != nil {
-		if err == sql.ErrNoRows {
+		if err == pgx.ErrNoRows {
 			return errors.UserNotFound()
 		}
-		if strings.Contains(err.Error(), "duplicate key") {
-			if strings.Contains(err.Error(), "email") {
-				return errors.EmailExists()
-			}
-			if strings.Contains(err.Error(), "username") {
-				return errors.UsernameExists()
-			}
+		return userWriteError(err)
+	}
+
+	return nil
+}
+
+func (r *userRepository) UpdateIfUnmodified(ctx context.Context, user *entities.User, unmodifiedSince time.Time) error {
+	query := `
+		UPDATE users 
+		SET email = $2, username = $3, password_hash = $4, first_name = $5, 
+			last_name = $6, is_active = $7, is_verified = $8, last_login_at = $9,
+			password_change_required = $10, avatar_url = $11, locale = $12, timezone = $13
+		WHERE id = $1 AND deleted_at IS NULL AND updated_at = $14
+		RETURNING updated_at`
+
+	err := r.db.QueryRow(ctx, query,
+		user.ID, user.Email, user.Username, user.PasswordHash,
+		user.FirstName, user.LastName, user.IsActive, user.IsVerified, user.LastLoginAt,
+		user.PasswordChangeRequired, user.AvatarURL, user.Locale, user.Timezone,
+		unmodifiedSince,
+	).Scan(&user.UpdatedAt)
+
+	if err != nil {
+		if err != pgx.ErrNoRows {
+			return userWriteError(err)
 		}
-		return errors.DatabaseError(err)
+
+		// Строки нет: пользователь удалён или изменён другим запросом
+		var exists bool
+		existsQuery := `SELECT EXISTS(SELECT 1 FROM users WHERE id = $1 AND deleted_at IS NULL)`
+		if err := r.db.QueryRow(ctx, existsQuery, user.ID).Scan(&exists); err != nil {
+			return errors.DatabaseError(err)
+		}
+		if !exists {
+			return errors.UserNotFound()
+		}
+		return errors.PreconditionFailed()
 	}
 
 	return nil
@@ -151,15 +196,12 @@ func (r *userRepository) Update(ctx context.Context, user *entities.User) error
 func (r *userRepository) Delete(ctx context.Context, id uuid.UUID) error {
 	query := `UPDATE users SET deleted_at = CURRENT_TIMESTAMP WHERE id = $1 AND deleted_at IS NULL`
 
-	result, err := r.db.ExecContext(ctx, query, id)
+	result, err := r.db.Exec(ctx, query, id)
 	if err != nil {
 		return errors.DatabaseError(err)
 	}
 
-	rowsAffected, err := result.RowsAffected()
-	if err != nil {
-		return errors.DatabaseError(err)
-	}
+	rowsAffected := result.RowsAffected()
 
 	if rowsAffected == 0 {
 		return errors.UserNotFound()
@@ -168,16 +210,102 @@ func (r *userRepository) Delete(ctx context.Context, id uuid.UUID) error {
 	return nil
 }
 
-func (r *userRepository) List(ctx context.Context, limit, offset int) ([]*entities.User, error) {
-	query := `
-		SELECT id, email, username, password_hash, first_name, last_name, 
-			   is_active, is_verified, last_login_at, created_at, updated_at, deleted_at
+// userSortColumns whitelists the columns a user listing may be ordered by.
+var userSortColumns = map[string]string{
+	"created_at": "created_at",
+	"updated_at": "updated_at",
+	"email":      "email",
+	"username":   "username",
+}
+
+func (r *userRepository) List(ctx context.Context, filter entities.UserListFilter, limit, offset int) ([]*entities.User, int64, error) {
+	conditions := []string{"deleted_at IS NULL"}
+	var args []interface{}
+
+	if search := strings.TrimSpace(filter.Search); search != "" {
+		args = append(args, "%"+escapeLike(search)+"%")
+		conditions = append(conditions, userSearchCondition(len(args)))
+	}
+
+	where := "WHERE " + strings.Join(conditions, " AND ")
+
+	var total int64
+	countQuery := `SELECT COUNT(*) FROM users ` + where
+	if err := r.db.ReadQueryRow(ctx, countQuery, args...).Scan(&total); err != nil {
+		return nil, 0, errors.DatabaseError(err)
+	}
+
+	sortColumn, ok := userSortColumns[filter.SortBy]
+	if !ok {
+		sortColumn = "created_at"
+	}
+	sortDir := "DESC"
+	if strings.EqualFold(filter.SortDir, "asc") {
+		sortDir = "ASC"
+	}
+
+	// id breaks ties so pages stay stable when sort values repeat.
+	query := fmt.Sprintf(`
+		SELECT id, email, username, password_hash, first_name, last_name, avatar_url,
+			   is_active, is_verified, is_service_account, password_change_required, last_login_at, created_at, updated_at, deleted_at,
+			   banned_at, banned_until, ban_reason, banned_by, phone, phone_verified_at, locale, timezone,
+			   deletion_requested_at, deletion_scheduled_at
 		FROM users 
-		WHERE deleted_at IS NULL
-		ORDER BY created_at DESC
-		LIMIT $1 OFFSET $2`
+		%s
+		ORDER BY %s %s, id %s
+		LIMIT $%d OFFSET $%d`, where, sortColumn, sortDir, sortDir, len(args)+1, len(args)+2)
+
+	rows, err := r.db.ReadQuery(ctx, query, append(args, limit, offset)...)
+	if err != nil {
+		return nil, 0, errors.DatabaseError(err)
+	}
+	defer rows.Close()
+
+	var users []*entities.User
+	for rows.Next() {
+		user := &entities.User{}
+		err := rows.Scan(
+			&user.ID, &user.Email, &user.Username, &user.PasswordHash,
+			&user.FirstName, &user.LastName, &user.AvatarURL, &user.IsActive, &user.IsVerified, &user.IsServiceAccount, &user.PasswordChangeRequired,
+			&user.LastLoginAt, &user.CreatedAt, &user.UpdatedAt, &user.DeletedAt,
+			&user.BannedAt, &user.BannedUntil, &user.BanReason, &user.BannedBy, &user.Phone, &user.PhoneVerifiedAt, &user.Locale, &user.Timezone,
+			&user.DeletionRequestedAt, &user.DeletionScheduledAt,
+		)
+		if err != nil {
+			return nil, 0, errors.DatabaseError(err)
+		}
+		users = append(users, user)
+	}
 
-	rows, err := r.db.QueryContext(ctx, query, limit, offset)
+	if err = rows.Err(); err != nil {
+		return nil, 0, errors.DatabaseError(err)
+	}
+
+	return users, total, nil
+}
+
+// userFullNameExpr is the name expression indexed by idx_users_full_name_trgm;
+// it matches "first last" as well as either part alone.
+const userFullNameExpr = `(COALESCE(first_name, '') || ' ' || COALESCE(last_name, ''))`
+
+// userSearchCondition matches the ILIKE pattern in argument n against the
+// columns covered by the trigram indexes.
+func userSearchCondition(n int) string {
+	return fmt.Sprintf("(email ILIKE $%[1]d OR username ILIKE $%[1]d OR %[2]s ILIKE $%[1]d)", n, userFullNameExpr)
+}
+
+func (r *userRepository) Search(ctx context.Context, query string, limit int) ([]*entities.User, error) {
+	sqlQuery := fmt.Sprintf(`
+		SELECT id, email, username, password_hash, first_name, last_name, avatar_url,
+			   is_active, is_verified, is_service_account, password_change_required, last_login_at, created_at, updated_at, deleted_at,
+			   banned_at, banned_until, ban_reason, banned_by, phone, phone_verified_at, locale, timezone,
+			   deletion_requested_at, deletion_scheduled_at
+		FROM users
+		WHERE deleted_at IS NULL AND %s
+		ORDER BY GREATEST(similarity(email, $2), similarity(username, $2), similarity(%s, $2)) DESC, id
+		LIMIT $3`, userSearchCondition(1), userFullNameExpr)
+
+	rows, err := r.db.ReadQuery(ctx, sqlQuery, "%"+escapeLike(query)+"%", query, limit)
 	if err != nil {
 		return nil, errors.DatabaseError(err)
 	}
@@ -188,8 +316,10 @@ func (r *userRepository) List(ctx context.Context, limit, offset int) ([]*entiti
 		user := &entities.User{}
 		err := rows.Scan(
 			&user.ID, &user.Email, &user.Username, &user.PasswordHash,
-			&user.FirstName, &user.LastName, &user.IsActive, &user.IsVerified,
+			&user.FirstName, &user.LastName, &user.AvatarURL, &user.IsActive, &user.IsVerified, &user.IsServiceAccount, &user.PasswordChangeRequired,
 			&user.LastLoginAt, &user.CreatedAt, &user.UpdatedAt, &user.DeletedAt,
+			&user.BannedAt, &user.BannedUntil, &user.BanReason, &user.BannedBy, &user.Phone, &user.PhoneVerifiedAt, &user.Locale, &user.Timezone,
+			&user.DeletionRequestedAt, &user.DeletionScheduledAt,
 		)
 		if err != nil {
 			return nil, errors.DatabaseError(err)
@@ -204,11 +334,465 @@ func (r *userRepository) List(ctx context.Context, limit, offset int) ([]*entiti
 	return users, nil
 }
 
+func (r *userRepository) ImportBatch(ctx context.Context, batch []*entities.UserImport, actorID *uuid.UUID) ([]error, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, errors.DatabaseError(err)
+	}
+	defer tx.Rollback(ctx)
+
+	userQuery := `
+		INSERT INTO users (id, email, username, password_hash, first_name, last_name, is_active, is_verified, is_service_account, password_change_required)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		RETURNING created_at, updated_at`
+	roleQuery := `INSERT INTO user_roles (id, user_id, role_id) VALUES ($1, $2, $3)`
+	auditQuery := `
+		INSERT INTO role_assignment_audit (user_id, role_id, role_name, action, actor_id)
+		VALUES ($1, $2, $3, $4, $5)`
+
+	rowErrs := make([]error, len(batch))
+	for i, row := range batch {
+		if _, err := tx.Exec(ctx, "SAVEPOINT import_row"); err != nil {
+			return nil, errors.DatabaseError(err)
+		}
+
+		if err := importRow(ctx, tx, row, actorID, userQuery, roleQuery, auditQuery); err != nil {
+			rowErrs[i] = userWriteError(err)
+			if _, err := tx.Exec(ctx, "ROLLBACK TO SAVEPOINT import_row"); err != nil {
+				return nil, errors.DatabaseError(err)
+			}
+			continue
+		}
+
+		if _, err := tx.Exec(ctx, "RELEASE SAVEPOINT import_row"); err != nil {
+			return nil, errors.DatabaseError(err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, errors.DatabaseError(err)
+	}
+
+	return rowErrs, nil
+}
+
+func importRow(ctx context.Context, tx pgx.Tx, row *entities.UserImport, actorID *uuid.UUID, userQuery, roleQuery, auditQuery string) error {
+	user := row.User
+	err := tx.QueryRow(ctx, userQuery,
+		user.ID, user.Email, user.Username, user.PasswordHash,
+		user.FirstName, user.LastName, user.IsActive, user.IsVerified, user.IsServiceAccount, user.PasswordChangeRequired,
+	).Scan(&user.CreatedAt, &user.UpdatedAt)
+	if err != nil {
+		return err
+	}
+
+	for _, role := range row.Roles {
+		if _, err := tx.Exec(ctx, roleQuery, uuid.New(), user.ID, role.ID); err != nil {
+			return err
+		}
+		if _, err := tx.Exec(ctx, auditQuery, user.ID, role.ID, role.Name, entities.RoleAuditActionGranted, actorID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *userRepository) Purge(ctx context.Context, id uuid.UUID) (*string, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, errors.DatabaseError(err)
+	}
+	defer tx.Rollback(ctx)
+
+	var email string
+	var avatarURL *string
+	err = tx.QueryRow(ctx,
+		`SELECT email, avatar_url FROM users WHERE id = $1 AND purged_at IS NULL FOR UPDATE`, id,
+	).Scan(&email, &avatarURL)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, errors.UserNotFound()
+		}
+		return nil, errors.DatabaseError(err)
+	}
+
+	// Placeholders derive from the ID so they stay unique without revealing
+	// anything about the former account.
+	_, err = tx.Exec(ctx, `
+		UPDATE users
+		SET email = 'purged-' || id::text || '@purged.invalid',
+			username = 'purged_' || replace(id::text, '-', ''),
+			password_hash = '', first_name = NULL, last_name = NULL, avatar_url = NULL,
+			is_active = false, is_verified = false, last_login_at = NULL,
+			password_change_required = false, ban_reason = NULL, phone = NULL, phone_verified_at = NULL,
+			deletion_requested_at = NULL, deletion_scheduled_at = NULL,
+			deleted_at = COALESCE(deleted_at, NOW()), purged_at = NOW()
+		WHERE id = $1`, id)
+	if err != nil {
+		return nil, errors.DatabaseError(err)
+	}
+
+	statements := []struct {
+		query string
+		arg   interface{}
+	}{
+		{`DELETE FROM user_roles WHERE user_id = $1`, id},
+		{`DELETE FROM organization_members WHERE user_id = $1`, id},
+		{`DELETE FROM group_members WHERE user_id = $1`, id},
+		{`DELETE FROM service_account_keys WHERE user_id = $1`, id},
+		{`DELETE FROM user_activity WHERE user_id = $1`, id},
+		{`DELETE FROM login_history WHERE user_id = $1`, id},
+		{`DELETE FROM event_log WHERE user_id = $1`, id},
+		{`DELETE FROM user_notes WHERE user_id = $1`, id},
+		{`DELETE FROM organization_invitations WHERE lower(email) = lower($1)`, email},
+		{`UPDATE role_assignment_audit SET reason = NULL WHERE user_id = $1 OR actor_id = $1`, id},
+	}
+	for _, stmt := range statements {
+		if _, err := tx.Exec(ctx, stmt.query, stmt.arg); err != nil {
+			return nil, errors.DatabaseError(err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, errors.DatabaseError(err)
+	}
+
+	return avatarURL, nil
+}
+
+func (r *userRepository) ScheduleDeletion(ctx context.Context, id uuid.UUID, at time.Time) error {
+	query := `
+		UPDATE users
+		SET deletion_requested_at = NOW(), deletion_scheduled_at = $2
+		WHERE id = $1 AND deleted_at IS NULL`
+
+	result, err := r.db.Exec(ctx, query, id, at)
+	if err != nil {
+		return errors.DatabaseError(err)
+	}
+
+	rows := result.RowsAffected()
+	if rows == 0 {
+		return errors.UserNotFound()
+	}
+
+	return nil
+}
+
+func (r *userRepository) CancelDeletion(ctx context.Context, id uuid.UUID) (bool, error) {
+	query := `
+		UPDATE users
+		SET deletion_requested_at = NULL, deletion_scheduled_at = NULL
+		WHERE id = $1 AND deletion_scheduled_at IS NOT NULL AND purged_at IS NULL`
+
+	result, err := r.db.Exec(ctx, query, id)
+	if err != nil {
+		return false, errors.DatabaseError(err)
+	}
+
+	rows := result.RowsAffected()
+
+	return rows > 0, nil
+}
+
+func (r *userRepository) ListDueDeletions(ctx context.Context, before time.Time, limit int) ([]uuid.UUID, error) {
+	query := `
+		SELECT id FROM users
+		WHERE deletion_scheduled_at <= $1 AND purged_at IS NULL
+		ORDER BY deletion_scheduled_at
+		LIMIT $2`
+
+	rows, err := r.db.Query(ctx, query, before, limit)
+	if err != nil {
+		return nil, errors.DatabaseError(err)
+	}
+	defer rows.Close()
+
+	var ids []uuid.UUID
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, errors.DatabaseError(err)
+		}
+		ids = append(ids, id)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, errors.DatabaseError(err)
+	}
+
+	return ids, nil
+}
+
+func (r *userRepository) ListPurgeCandidates(ctx context.Context, deletedBefore time.Time, limit int) ([]uuid.UUID, error) {
+	query := `
+		SELECT id FROM users
+		WHERE deleted_at IS NOT NULL AND deleted_at < $1 AND purged_at IS NULL
+		ORDER BY deleted_at
+		LIMIT $2`
+
+	rows, err := r.db.Query(ctx, query, deletedBefore, limit)
+	if err != nil {
+		return nil, errors.DatabaseError(err)
+	}
+	defer rows.Close()
+
+	var ids []uuid.UUID
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, errors.DatabaseError(err)
+		}
+		ids = append(ids, id)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, errors.DatabaseError(err)
+	}
+
+	return ids, nil
+}
+
+func (r *userRepository) Ban(ctx context.Context, user *entities.User) error {
+	query := `
+		UPDATE users
+		SET banned_at = NOW(), banned_until = $2, ban_reason = $3, banned_by = $4
+		WHERE id = $1 AND deleted_at IS NULL
+		RETURNING banned_at, updated_at`
+
+	err := r.db.QueryRow(ctx, query,
+		user.ID, user.BannedUntil, user.BanReason, user.BannedBy,
+	).Scan(&user.BannedAt, &user.UpdatedAt)
+
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return errors.UserNotFound()
+		}
+		return errors.DatabaseError(err)
+	}
+
+	return nil
+}
+
+func (r *userRepository) Unban(ctx context.Context, id uuid.UUID) error {
+	query := `
+		UPDATE users
+		SET banned_at = NULL, banned_until = NULL, ban_reason = NULL, banned_by = NULL
+		WHERE id = $1 AND deleted_at IS NULL`
+
+	result, err := r.db.Exec(ctx, query, id)
+	if err != nil {
+		return errors.DatabaseError(err)
+	}
+
+	rowsAffected := result.RowsAffected()
+
+	if rowsAffected == 0 {
+		return errors.UserNotFound()
+	}
+
+	return nil
+}
+
+func (r *userRepository) LiftExpiredBans(ctx context.Context, before time.Time, limit int) ([]uuid.UUID, error) {
+	query := `
+		UPDATE users
+		SET banned_at = NULL, banned_until = NULL, ban_reason = NULL, banned_by = NULL
+		WHERE id IN (
+			SELECT id FROM users
+			WHERE banned_until IS NOT NULL AND banned_until <= $1
+			ORDER BY banned_until
+			LIMIT $2
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING id`
+
+	rows, err := r.db.Query(ctx, query, before, limit)
+	if err != nil {
+		return nil, errors.DatabaseError(err)
+	}
+	defer rows.Close()
+
+	var ids []uuid.UUID
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, errors.DatabaseError(err)
+		}
+		ids = append(ids, id)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, errors.DatabaseError(err)
+	}
+
+	return ids, nil
+}
+
+func (r *userRepository) Merge(ctx context.Context, primaryID, duplicateID uuid.UUID, actorID *uuid.UUID, reason string, moveRoles bool) (*entities.UserMergeResult, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, errors.DatabaseError(err)
+	}
+	defer tx.Rollback(ctx)
+
+	var locked int
+	err = tx.QueryRow(ctx, `
+		SELECT COUNT(*) FROM (
+			SELECT id FROM users WHERE id IN ($1, $2) AND deleted_at IS NULL FOR UPDATE
+		) u`, primaryID, duplicateID,
+	).Scan(&locked)
+	if err != nil {
+		return nil, errors.DatabaseError(err)
+	}
+	if locked != 2 {
+		return nil, errors.UserNotFound()
+	}
+
+	result := &entities.UserMergeResult{}
+
+	if moveRoles {
+		// Grants the primary already holds conflict and are skipped; only the
+		// ones actually inserted are audited.
+		moved, err := tx.Exec(ctx, `
+			WITH moved AS (
+				INSERT INTO user_roles (user_id, role_id, scope_id, expires_at)
+				SELECT $1, role_id, scope_id, expires_at
+				FROM user_roles WHERE user_id = $2
+				ON CONFLICT DO NOTHING
+				RETURNING role_id, scope_id, expires_at
+			)
+			INSERT INTO role_assignment_audit (user_id, role_id, role_name, scope_id, action, actor_id, reason, expires_at)
+			SELECT $1, m.role_id, r.name, m.scope_id, $3, $4, $5, m.expires_at
+			FROM moved m
+			INNER JOIN roles r ON r.id = m.role_id`,
+			primaryID, duplicateID, entities.RoleAuditActionGranted, actorID, reason,
+		)
+		if err != nil {
+			return nil, errors.DatabaseError(err)
+		}
+		result.RolesMoved = int(moved.RowsAffected())
+	}
+
+	_, err = tx.Exec(ctx, `
+		INSERT INTO role_assignment_audit (user_id, role_id, role_name, scope_id, action, actor_id, reason, expires_at)
+		SELECT ur.user_id, ur.role_id, r.name, ur.scope_id, $2, $3, $4, ur.expires_at
+		FROM user_roles ur
+		INNER JOIN roles r ON r.id = ur.role_id
+		WHERE ur.user_id = $1`,
+		duplicateID, entities.RoleAuditActionRevoked, actorID, reason,
+	)
+	if err != nil {
+		return nil, errors.DatabaseError(err)
+	}
+
+	// The primary keeps the higher of the two organization roles.
+	moved, err := tx.Exec(ctx, `
+		INSERT INTO organization_members (organization_id, user_id, role)
+		SELECT organization_id, $1, role FROM organization_members WHERE user_id = $2
+		ON CONFLICT (organization_id, user_id) DO UPDATE
+		SET role = EXCLUDED.role, updated_at = NOW()
+		WHERE array_position(ARRAY['owner', 'admin', 'member'], EXCLUDED.role::text)
+			< array_position(ARRAY['owner', 'admin', 'member'], organization_members.role::text)`,
+		primaryID, duplicateID,
+	)
+	if err != nil {
+		return nil, errors.DatabaseError(err)
+	}
+	result.OrganizationsMoved = int(moved.RowsAffected())
+
+	moved, err = tx.Exec(ctx, `
+		INSERT INTO group_members (group_id, user_id)
+		SELECT group_id, $1 FROM group_members WHERE user_id = $2
+		ON CONFLICT (group_id, user_id) DO NOTHING`,
+		primaryID, duplicateID,
+	)
+	if err != nil {
+		return nil, errors.DatabaseError(err)
+	}
+	result.GroupsMoved = int(moved.RowsAffected())
+
+	statements := []string{
+		`DELETE FROM user_roles WHERE user_id = $2`,
+		`DELETE FROM organization_members WHERE user_id = $2`,
+		`DELETE FROM group_members WHERE user_id = $2`,
+		`UPDATE user_activity SET user_id = $1 WHERE user_id = $2`,
+		`UPDATE login_history SET user_id = $1 WHERE user_id = $2`,
+		`UPDATE user_notes SET user_id = $1 WHERE user_id = $2`,
+		`UPDATE users SET is_active = false, merged_into = $1, deleted_at = NOW() WHERE id = $2`,
+	}
+	for _, query := range statements {
+		if _, err := tx.Exec(ctx, query, primaryID, duplicateID); err != nil {
+			return nil, errors.DatabaseError(err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, errors.DatabaseError(err)
+	}
+
+	return result, nil
+}
+
+// userWriteError maps unique violations on users to their domain errors.
+func userWriteError(err error) error {
+	if strings.Contains(err.Error(), "duplicate key") {
+		if strings.Contains(err.Error(), "email") {
+			return errors.EmailExists()
+		}
+		if strings.Contains(err.Error(), "username") {
+			return errors.UsernameExists()
+		}
+		if strings.Contains(err.Error(), "phone") {
+			return errors.PhoneExists()
+		}
+	}
+	return errors.DatabaseError(err)
+}
+
+// escapeLike escapes LIKE wildcards so search input is matched literally.
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(s)
+}
+
+// SetPhone stores a verified phone number, or clears it when phone is nil.
+func (r *userRepository) SetPhone(ctx context.Context, id uuid.UUID, phone *string) error {
+	query := `
+		UPDATE users
+		SET phone = $2, phone_verified_at = CASE WHEN $2::text IS NULL THEN NULL ELSE NOW() END
+		WHERE id = $1 AND deleted_at IS NULL`
+
+	result, err := r.db.Exec(ctx, query, id, phone)
+	if err != nil {
+		return userWriteError(err)
+	}
+
+	rows := result.RowsAffected()
+	if rows == 0 {
+		return errors.UserNotFound()
+	}
+
+	return nil
+}
+
+func (r *userRepository) ExistsByPhone(ctx context.Context, phone string) (bool, error) {
+	var exists bool
+	query := `SELECT EXISTS(SELECT 1 FROM users WHERE phone = $1 AND deleted_at IS NULL)`
+
+	err := r.db.ReadQueryRow(ctx, query, phone).Scan(&exists)
+	if err != nil {
+		return false, errors.DatabaseError(err)
+	}
+
+	return exists, nil
+}
+
 func (r *userRepository) ExistsByEmail(ctx context.Context, email string) (bool, error) {
 	var exists bool
 	query := `SELECT EXISTS(SELECT 1 FROM users WHERE email = $1 AND deleted_at IS NULL)`
 
-	err := r.db.QueryRowContext(ctx, query, email).Scan(&exists)
+	err := r.db.ReadQueryRow(ctx, query, email).Scan(&exists)
 	if err != nil {
 		return false, errors.DatabaseError(err)
 	}
@@ -220,10 +804,63 @@ func (r *userRepository) ExistsByUsername(ctx context.Context, username string)
 	var exists bool
 	query := `SELECT EXISTS(SELECT 1 FROM users WHERE username = $1 AND deleted_at IS NULL)`
 
-	err := r.db.QueryRowContext(ctx, query, username).Scan(&exists)
+	err := r.db.ReadQueryRow(ctx, query, username).Scan(&exists)
 	if err != nil {
 		return false, errors.DatabaseError(err)
 	}
 
 	return exists, nil
 }
+
+func (r *userRepository) ListIDs(ctx context.Context, filter entities.UserFilter, limit int) ([]uuid.UUID, error) {
+	conditions := []string{"u.deleted_at IS NULL"}
+	var args []interface{}
+
+	if filter.OrganizationID != nil {
+		args = append(args, *filter.OrganizationID)
+		conditions = append(conditions, fmt.Sprintf(
+			"EXISTS (SELECT 1 FROM organization_members om WHERE om.user_id = u.id AND om.organization_id = $%d)", len(args)))
+	}
+	if filter.GroupID != nil {
+		args = append(args, *filter.GroupID)
+		conditions = append(conditions, fmt.Sprintf(
+			"EXISTS (SELECT 1 FROM group_members gm WHERE gm.user_id = u.id AND gm.group_id = $%d)", len(args)))
+	}
+	if filter.RoleID != nil {
+		args = append(args, *filter.RoleID)
+		conditions = append(conditions, fmt.Sprintf(
+			"EXISTS (SELECT 1 FROM user_roles ur WHERE ur.user_id = u.id AND ur.role_id = $%d AND (ur.expires_at IS NULL OR ur.expires_at > NOW()))", len(args)))
+	}
+	if filter.IsActive != nil {
+		args = append(args, *filter.IsActive)
+		conditions = append(conditions, fmt.Sprintf("u.is_active = $%d", len(args)))
+	}
+
+	args = append(args, limit)
+	query := fmt.Sprintf(`
+		SELECT u.id FROM users u
+		WHERE %s
+		ORDER BY u.created_at
+		LIMIT $%d`, strings.Join(conditions, " AND "), len(args))
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, errors.DatabaseError(err)
+	}
+	defer rows.Close()
+
+	var ids []uuid.UUID
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, errors.DatabaseError(err)
+		}
+		ids = append(ids, id)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, errors.DatabaseError(err)
+	}
+
+	return ids, nil
+}
@@ -39,6 +39,21 @@ func (c *CacheService) Get(ctx context.Context, key string, dest interface{}) er
 	return nil
 }
 
+// Take gets the value of key and deletes it in one step, so only one of
+// concurrent callers gets it.
+func (c *CacheService) Take(ctx context.Context, key string, dest interface{}) error {
+	data, err := c.client.GetDel(ctx, key).Result()
+	if err != nil {
+		return err
+	}
+
+	if err := json.Unmarshal([]byte(data), dest); err != nil {
+		return fmt.Errorf("failed to unmarshal value: %w", err)
+	}
+
+	return nil
+}
+
 func (c *CacheService) Delete(ctx context.Context, keys ...string) error {
 	return c.client.Delete(ctx, keys...)
 }
@@ -72,11 +87,55 @@ func (c *CacheService) IsTokenBlacklisted(ctx context.Context, tokenID string) (
 	return c.client.Exists(ctx, key)
 }
 
+// MarkEventProcessed remembers that the consumer of topic handled the event
+// for window.
+func (c *CacheService) MarkEventProcessed(ctx context.Context, topic, eventID string, window time.Duration) error {
+	key := fmt.Sprintf("processed_event:%s:%s", topic, eventID)
+	return c.client.SetWithExpiration(ctx, key, "1", window)
+}
+
+func (c *CacheService) IsEventProcessed(ctx context.Context, topic, eventID string) (bool, error) {
+	key := fmt.Sprintf("processed_event:%s:%s", topic, eventID)
+	return c.client.Exists(ctx, key)
+}
+
+// SetUserTokensRevokedAt records that every token the user was issued up to
+// at is revoked. The entry only needs to outlive those tokens.
+func (c *CacheService) SetUserTokensRevokedAt(ctx context.Context, userID string, at time.Time, expiration time.Duration) error {
+	key := fmt.Sprintf("revoked_user_tokens:%s", userID)
+	return c.client.SetWithExpiration(ctx, key, at.Unix(), expiration)
+}
+
+// GetUserTokensRevokedAt returns the zero time when no revocation is recorded.
+func (c *CacheService) GetUserTokensRevokedAt(ctx context.Context, userID string) (time.Time, error) {
+	key := fmt.Sprintf("revoked_user_tokens:%s", userID)
+	result, err := c.client.GetString(ctx, key)
+	if err != nil {
+		if err.Error() == "redis: nil" {
+			return time.Time{}, nil
+		}
+		return time.Time{}, err
+	}
+
+	var unix int64
+	if err := json.Unmarshal([]byte(result), &unix); err != nil {
+		return time.Time{}, err
+	}
+
+	return time.Unix(unix, 0), nil
+}
+
 func (c *CacheService) IncrementLoginAttempts(ctx context.Context, identifier string, expiration time.Duration) (int64, error) {
 	key := fmt.Sprintf("login_attempts:%s", identifier)
 	return c.client.IncrementWithExpiration(ctx, key, expiration)
 }
 
+// IncrementCounter increments a counter that disappears after expiration and
+// returns the new value.
+func (c *CacheService) IncrementCounter(ctx context.Context, key string, expiration time.Duration) (int64, error) {
+	return c.client.IncrementWithExpiration(ctx, key, expiration)
+}
+
 func (c *CacheService) GetLoginAttempts(ctx context.Context, identifier string) (int64, error) {
 	key := fmt.Sprintf("login_attempts:%s", identifier)
 	result, err := c.client.GetString(ctx, key)
@@ -0,0 +1,116 @@
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"github.com/vagonaizer/authenitfication-service/pkg/logger"
+)
+
+// Invalidator broadcasts cache keys that changed to every replica over a
+// Redis pub/sub channel, so in-process caches can drop their copies.
+// Pub/sub delivers at most once: messages sent while a replica was
+// disconnected are lost, so its subscribers are told to drop everything
+// whenever the subscription is established again.
+type Invalidator struct {
+	client  *Client
+	channel string
+	origin  string
+	logger  *logger.Logger
+
+	mu          sync.RWMutex
+	subscribers []func(keys []string)
+}
+
+type invalidation struct {
+	Origin string   `json:"origin"`
+	Keys   []string `json:"keys"`
+}
+
+func NewInvalidator(client *Client, channel string, logger *logger.Logger) *Invalidator {
+	return &Invalidator{
+		client:  client,
+		channel: channel,
+		origin:  uuid.NewString(),
+		logger:  logger,
+	}
+}
+
+// Subscribe registers fn to be called with the keys other replicas
+// invalidated, or with nil when every key must be dropped. It must be called
+// before Run.
+func (i *Invalidator) Subscribe(fn func(keys []string)) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.subscribers = append(i.subscribers, fn)
+}
+
+// Publish tells the other replicas that keys changed.
+func (i *Invalidator) Publish(ctx context.Context, keys ...string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+
+	data, err := json.Marshal(invalidation{Origin: i.origin, Keys: keys})
+	if err != nil {
+		return err
+	}
+
+	return i.client.Publish(ctx, i.channel, data).Err()
+}
+
+// Run delivers invalidations to the subscribers until ctx is cancelled.
+func (i *Invalidator) Run(ctx context.Context) {
+	pubsub := i.client.Subscribe(ctx, i.channel)
+	defer pubsub.Close()
+
+	for {
+		received, err := pubsub.Receive(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			i.logger.WithError(err).Warn("cache invalidation subscription failed")
+
+			// Подписка восстановится при следующем Receive
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(time.Second):
+			}
+			continue
+		}
+
+		switch message := received.(type) {
+		case *redis.Subscription:
+			// Пока подписки не было, инвалидации могли потеряться
+			if message.Kind == "subscribe" {
+				i.notify(nil)
+			}
+		case *redis.Message:
+			var event invalidation
+			if err := json.Unmarshal([]byte(message.Payload), &event); err != nil {
+				i.logger.WithError(err).Warn("failed to decode cache invalidation")
+				continue
+			}
+			// Собственные ключи уже сброшены при публикации
+			if event.Origin == i.origin || len(event.Keys) == 0 {
+				continue
+			}
+			i.notify(event.Keys)
+		}
+	}
+}
+
+func (i *Invalidator) notify(keys []string) {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	for _, fn := range i.subscribers {
+		fn(keys)
+	}
+}
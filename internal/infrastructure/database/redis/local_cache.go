@@ -0,0 +1,159 @@
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// LocalCache keeps hot entries of CacheService in process memory for up to
+// ttl, in front of Redis. Deleting a key drops it here and in Redis and
+// broadcasts it through the invalidator, so the other replicas drop their
+// copies too. With a non-positive ttl entries are not kept in process and
+// LocalCache behaves like CacheService. Once maxEntries are held, new
+// entries are only written to Redis until old ones expire.
+type LocalCache struct {
+	cache       *CacheService
+	invalidator *Invalidator
+	ttl         time.Duration
+	maxEntries  int
+
+	mu      sync.RWMutex
+	entries map[string]localEntry
+}
+
+type localEntry struct {
+	data      []byte
+	expiresAt time.Time
+}
+
+func NewLocalCache(cache *CacheService, invalidator *Invalidator, ttl time.Duration, maxEntries int) *LocalCache {
+	l := &LocalCache{
+		cache:       cache,
+		invalidator: invalidator,
+		ttl:         ttl,
+		maxEntries:  maxEntries,
+		entries:     make(map[string]localEntry),
+	}
+	if invalidator != nil {
+		invalidator.Subscribe(l.drop)
+	}
+	return l
+}
+
+func (l *LocalCache) Get(ctx context.Context, key string, dest interface{}) error {
+	if data, ok := l.lookup(key); ok {
+		return unmarshal(data, dest)
+	}
+
+	data, err := l.cache.client.GetString(ctx, key)
+	if err != nil {
+		return err
+	}
+	if err := unmarshal([]byte(data), dest); err != nil {
+		return err
+	}
+
+	// Срок жизни записи в Redis неизвестен, поэтому локально держим не дольше ttl
+	l.store(key, []byte(data), l.ttl)
+	return nil
+}
+
+func (l *LocalCache) Set(ctx context.Context, key string, value interface{}, expiration time.Duration) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("failed to marshal value: %w", err)
+	}
+
+	if err := l.cache.client.SetWithExpiration(ctx, key, data, expiration); err != nil {
+		return err
+	}
+
+	l.store(key, data, min(l.ttl, expiration))
+	return nil
+}
+
+// Delete removes keys here, in Redis and in the other replicas. A failed
+// broadcast leaves their copies to expire after ttl.
+func (l *LocalCache) Delete(ctx context.Context, keys ...string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+	l.drop(keys)
+
+	if err := l.cache.Delete(ctx, keys...); err != nil {
+		return err
+	}
+
+	if l.invalidator != nil {
+		if err := l.invalidator.Publish(ctx, keys...); err != nil {
+			return fmt.Errorf("failed to broadcast cache invalidation: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (l *LocalCache) lookup(key string) ([]byte, bool) {
+	if l.ttl <= 0 {
+		return nil, false
+	}
+
+	l.mu.RLock()
+	entry, ok := l.entries[key]
+	l.mu.RUnlock()
+
+	if !ok || time.Now().After(entry.expiresAt) {
+		return nil, false
+	}
+	return entry.data, true
+}
+
+func (l *LocalCache) store(key string, data []byte, ttl time.Duration) {
+	if ttl <= 0 {
+		return
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if _, ok := l.entries[key]; !ok && len(l.entries) >= l.maxEntries {
+		l.evictExpired()
+		if len(l.entries) >= l.maxEntries {
+			return
+		}
+	}
+	l.entries[key] = localEntry{data: data, expiresAt: time.Now().Add(ttl)}
+}
+
+// drop removes keys from process memory, or every entry when keys is nil.
+func (l *LocalCache) drop(keys []string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if keys == nil {
+		clear(l.entries)
+		return
+	}
+	for _, key := range keys {
+		delete(l.entries, key)
+	}
+}
+
+func (l *LocalCache) evictExpired() {
+	now := time.Now()
+	for key, entry := range l.entries {
+		if now.After(entry.expiresAt) {
+			delete(l.entries, key)
+		}
+	}
+}
+
+func unmarshal(data []byte, dest interface{}) error {
+	if err := json.Unmarshal(data, dest); err != nil {
+		return fmt.Errorf("failed to unmarshal value: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,124 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+)
+
+// acquireLock sets KEYS[1] to the owner ARGV[1] for ARGV[2] milliseconds
+// unless it is set, and then returns the next fencing token of KEYS[2], or 0
+// when the lock is held by someone else.
+var acquireLock = redis.NewScript(`
+if redis.call('SET', KEYS[1], ARGV[1], 'NX', 'PX', ARGV[2]) then
+	return redis.call('INCR', KEYS[2])
+end
+return 0
+`)
+
+// refreshLock extends KEYS[1] to ARGV[2] milliseconds if ARGV[1] still owns it.
+var refreshLock = redis.NewScript(`
+if redis.call('GET', KEYS[1]) == ARGV[1] then
+	return redis.call('PEXPIRE', KEYS[1], ARGV[2])
+end
+return 0
+`)
+
+// releaseLock deletes KEYS[1] if ARGV[1] still owns it.
+var releaseLock = redis.NewScript(`
+if redis.call('GET', KEYS[1]) == ARGV[1] then
+	return redis.call('DEL', KEYS[1])
+end
+return 0
+`)
+
+// Lock is a lock shared by every instance through Redis. It expires after
+// ttl unless refreshed by Check, so a holder that dies or hangs loses it.
+// Each acquisition gets a fencing token larger than all earlier ones, which
+// writers pass along so that stores reject a holder that lost the lock
+// without noticing, as the outbox does for the relay.
+type Lock struct {
+	client *Client
+	key    string
+	ttl    time.Duration
+
+	mu    sync.Mutex
+	owner string
+	token int64
+}
+
+func NewLock(client *Client, key string, ttl time.Duration) *Lock {
+	return &Lock{
+		client: client,
+		key:    "lock:" + key,
+		ttl:    ttl,
+	}
+}
+
+// TryAcquire takes the lock unless another instance holds it.
+func (l *Lock) TryAcquire(ctx context.Context) (bool, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	owner := uuid.NewString()
+
+	token, err := acquireLock.Run(ctx, l.client, []string{l.key, l.key + ":fence"}, owner, l.ttl.Milliseconds()).Int64()
+	if err != nil {
+		return false, fmt.Errorf("failed to take lock %s: %w", l.key, err)
+	}
+	if token == 0 {
+		return false, nil
+	}
+
+	l.owner = owner
+	l.token = token
+	return true, nil
+}
+
+// Check extends the lock by its ttl, and fails once it was lost.
+func (l *Lock) Check(ctx context.Context) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.owner == "" {
+		return fmt.Errorf("lock %s is not held", l.key)
+	}
+
+	refreshed, err := refreshLock.Run(ctx, l.client, []string{l.key}, l.owner, l.ttl.Milliseconds()).Int64()
+	if err != nil {
+		return fmt.Errorf("failed to refresh lock %s: %w", l.key, err)
+	}
+	if refreshed == 0 {
+		l.owner = ""
+		return fmt.Errorf("lock %s was lost", l.key)
+	}
+	return nil
+}
+
+// Token returns the fencing token of the current acquisition.
+func (l *Lock) Token() int64 {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return l.token
+}
+
+// Release gives the lock up unless it was already lost.
+func (l *Lock) Release() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.owner == "" {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	// Не удалось — блокировка истечёт сама
+	_ = releaseLock.Run(ctx, l.client, []string{l.key}, l.owner).Err()
+	l.owner = ""
+}
@@ -0,0 +1,95 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// tokenBucket refills KEYS[1] with ARGV[1] tokens per second up to ARGV[2]
+// and takes one token. The time comes from Redis, so replicas with skewed
+// clocks share one bucket consistently. It returns whether the token was
+// taken, the tokens left and, when it was not, the milliseconds until the
+// next one.
+var tokenBucket = redis.NewScript(`
+local rate = tonumber(ARGV[1])
+local burst = tonumber(ARGV[2])
+
+local time = redis.call('TIME')
+local now = tonumber(time[1]) * 1000 + math.floor(tonumber(time[2]) / 1000)
+
+local state = redis.call('HMGET', KEYS[1], 'tokens', 'updated_at')
+local tokens = tonumber(state[1]) or burst
+local updated = tonumber(state[2]) or now
+
+tokens = math.min(burst, tokens + math.max(0, now - updated) * rate / 1000)
+
+local allowed = 0
+local retry = 0
+if tokens >= 1 then
+	tokens = tokens - 1
+	allowed = 1
+else
+	retry = math.ceil((1 - tokens) * 1000 / rate)
+end
+
+redis.call('HSET', KEYS[1], 'tokens', tokens, 'updated_at', now)
+redis.call('PEXPIRE', KEYS[1], math.ceil(burst * 1000 / rate) + 1000)
+
+return {allowed, math.floor(tokens), retry}
+`)
+
+// RateLimiter is a token bucket per key shared by every replica. Each key
+// allows bursts of up to burst requests, refilled at rate per second.
+type RateLimiter struct {
+	client *Client
+	rate   float64
+	burst  int
+}
+
+// RateLimitResult is the outcome of RateLimiter.Allow. RetryAfter is only
+// set for denied requests.
+type RateLimitResult struct {
+	Allowed    bool
+	Remaining  int
+	RetryAfter time.Duration
+}
+
+// NewRateLimiter fails for a rate that is not a positive finite number, which
+// the bucket script would divide by.
+func NewRateLimiter(client *Client, rate float64, burst int) (*RateLimiter, error) {
+	if !(rate > 0) || math.IsInf(rate, 0) {
+		return nil, fmt.Errorf("invalid rate limit rate: %v", rate)
+	}
+
+	return &RateLimiter{
+		client: client,
+		rate:   rate,
+		burst:  max(burst, 1),
+	}, nil
+}
+
+// WithLimit returns a limiter of the same Redis with another rate and burst.
+func (l *RateLimiter) WithLimit(rate float64, burst int) (*RateLimiter, error) {
+	return NewRateLimiter(l.client, rate, burst)
+}
+
+// Allow takes a token from the bucket of key.
+func (l *RateLimiter) Allow(ctx context.Context, key string) (*RateLimitResult, error) {
+	values, err := tokenBucket.Run(ctx, l.client, []string{"rate_limit:" + key}, l.rate, l.burst).Int64Slice()
+	if err != nil {
+		return nil, fmt.Errorf("failed to check rate limit: %w", err)
+	}
+	if len(values) != 3 {
+		return nil, fmt.Errorf("unexpected rate limit reply: %v", values)
+	}
+
+	return &RateLimitResult{
+		Allowed:    values[0] == 1,
+		Remaining:  int(values[1]),
+		RetryAfter: time.Duration(values[2]) * time.Millisecond,
+	}, nil
+}
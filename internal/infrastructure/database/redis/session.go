@@ -0,0 +1,301 @@
+package redis
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"github.com/vagonaizer/authenitfication-service/internal/domain/entities"
+	"github.com/vagonaizer/authenitfication-service/pkg/errors"
+)
+
+// SessionRepository keeps sessions in Redis instead of Postgres. Each session
+// expires with its refresh token, and is found by refresh token through a
+// lookup key holding its ID. The sessions of a user are indexed in a sorted
+// set scored by expiry, from which expired IDs are pruned as it is read.
+type SessionRepository struct {
+	client *Client
+}
+
+func NewSessionRepository(client *Client) *SessionRepository {
+	return &SessionRepository{client: client}
+}
+
+func (r *SessionRepository) Create(ctx context.Context, session *entities.Session) error {
+	now := time.Now()
+	session.CreatedAt = now
+	session.UpdatedAt = now
+
+	return r.save(ctx, session)
+}
+
+func (r *SessionRepository) GetByID(ctx context.Context, id uuid.UUID) (*entities.Session, error) {
+	data, err := r.client.Get(ctx, sessionKey(id)).Bytes()
+	if err != nil {
+		if err == redis.Nil {
+			return nil, errors.NotFound("session not found")
+		}
+		return nil, errors.CacheError(err)
+	}
+
+	session := &entities.Session{}
+	if err := json.Unmarshal(data, session); err != nil {
+		return nil, errors.CacheError(err)
+	}
+
+	return session, nil
+}
+
+func (r *SessionRepository) GetByRefreshToken(ctx context.Context, refreshToken string) (*entities.Session, error) {
+	value, err := r.client.Get(ctx, sessionTokenKey(refreshToken)).Result()
+	if err != nil {
+		if err == redis.Nil {
+			return nil, errors.NotFound("session not found")
+		}
+		return nil, errors.CacheError(err)
+	}
+
+	id, err := uuid.Parse(value)
+	if err != nil {
+		return nil, errors.CacheError(err)
+	}
+
+	return r.GetByID(ctx, id)
+}
+
+func (r *SessionRepository) GetActiveByUserID(ctx context.Context, userID uuid.UUID) ([]*entities.Session, error) {
+	sessions, err := r.listByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	active := sessions[:0]
+	for _, session := range sessions {
+		if session.IsActive && session.ExpiresAt.After(now) {
+			active = append(active, session)
+		}
+	}
+
+	sort.Slice(active, func(i, j int) bool {
+		return active[i].CreatedAt.After(active[j].CreatedAt)
+	})
+
+	return active, nil
+}
+
+// Update changes the same fields as the Postgres repository; the refresh
+// token of a session never changes.
+func (r *SessionRepository) Update(ctx context.Context, session *entities.Session) error {
+	stored, err := r.GetByID(ctx, session.ID)
+	if err != nil {
+		return err
+	}
+
+	stored.UserAgent = session.UserAgent
+	stored.IPAddress = session.IPAddress
+	stored.IsActive = session.IsActive
+	stored.ExpiresAt = session.ExpiresAt
+	stored.OrganizationID = session.OrganizationID
+	stored.UpdatedAt = time.Now()
+
+	if err := r.save(ctx, stored); err != nil {
+		return err
+	}
+
+	session.UpdatedAt = stored.UpdatedAt
+	return nil
+}
+
+func (r *SessionRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	session, err := r.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Del(ctx, sessionKey(session.ID), sessionTokenKey(session.RefreshToken))
+		pipe.ZRem(ctx, userSessionsKey(session.UserID), session.ID.String())
+		return nil
+	})
+	if err != nil {
+		return errors.CacheError(err)
+	}
+
+	return nil
+}
+
+func (r *SessionRepository) DeleteByUserID(ctx context.Context, userID uuid.UUID) error {
+	sessions, err := r.listByUserID(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	keys := []string{userSessionsKey(userID)}
+	for _, session := range sessions {
+		keys = append(keys, sessionKey(session.ID), sessionTokenKey(session.RefreshToken))
+	}
+
+	if err := r.client.Del(ctx, keys...).Err(); err != nil {
+		return errors.CacheError(err)
+	}
+
+	return nil
+}
+
+// DeleteExpired deletes nothing: Redis expires sessions by itself.
+func (r *SessionRepository) DeleteExpired(ctx context.Context, before time.Time, limit int) (int, error) {
+	return 0, nil
+}
+
+// CountActive scans every session, so it is meant for occasional reports
+// rather than request paths.
+func (r *SessionRepository) CountActive(ctx context.Context, now time.Time) (int64, int64, error) {
+	var sessions int64
+	users := make(map[uuid.UUID]struct{})
+
+	iter := r.client.Scan(ctx, 0, sessionKeyPrefix+"*", 1000).Iterator()
+	var keys []string
+	count := func() error {
+		if len(keys) == 0 {
+			return nil
+		}
+		values, err := r.client.MGet(ctx, keys...).Result()
+		if err != nil {
+			return err
+		}
+		keys = keys[:0]
+
+		for _, value := range values {
+			data, ok := value.(string)
+			if !ok {
+				continue
+			}
+			session := &entities.Session{}
+			if err := json.Unmarshal([]byte(data), session); err != nil {
+				return err
+			}
+			if session.IsActive && session.ExpiresAt.After(now) {
+				sessions++
+				users[session.UserID] = struct{}{}
+			}
+		}
+		return nil
+	}
+
+	for iter.Next(ctx) {
+		keys = append(keys, iter.Val())
+		if len(keys) == 1000 {
+			if err := count(); err != nil {
+				return 0, 0, errors.CacheError(err)
+			}
+		}
+	}
+	if err := iter.Err(); err != nil {
+		return 0, 0, errors.CacheError(err)
+	}
+	if err := count(); err != nil {
+		return 0, 0, errors.CacheError(err)
+	}
+
+	return sessions, int64(len(users)), nil
+}
+
+// save writes the session, its refresh token lookup and its index entry, all
+// expiring with the session.
+func (r *SessionRepository) save(ctx context.Context, session *entities.Session) error {
+	data, err := json.Marshal(session)
+	if err != nil {
+		return errors.CacheError(err)
+	}
+
+	// Истёкшая сессия всё равно сохраняется, чтобы запись не пропала молча
+	ttl := max(time.Until(session.ExpiresAt), time.Second)
+	indexKey := userSessionsKey(session.UserID)
+
+	_, err = r.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Set(ctx, sessionKey(session.ID), data, ttl)
+		pipe.Set(ctx, sessionTokenKey(session.RefreshToken), session.ID.String(), ttl)
+		pipe.ZAdd(ctx, indexKey, redis.Z{
+			Score:  float64(session.ExpiresAt.UnixMilli()),
+			Member: session.ID.String(),
+		})
+		// Индекс живёт, пока жива самая поздняя сессия пользователя
+		pipe.ExpireGT(ctx, indexKey, ttl)
+		pipe.ExpireNX(ctx, indexKey, ttl)
+		return nil
+	})
+	if err != nil {
+		return errors.CacheError(err)
+	}
+
+	return nil
+}
+
+// listByUserID returns the unexpired sessions of the user, active or not,
+// pruning the index of expired ones.
+func (r *SessionRepository) listByUserID(ctx context.Context, userID uuid.UUID) ([]*entities.Session, error) {
+	indexKey := userSessionsKey(userID)
+	now := strconv.FormatInt(time.Now().UnixMilli(), 10)
+
+	if err := r.client.ZRemRangeByScore(ctx, indexKey, "-inf", "("+now).Err(); err != nil {
+		return nil, errors.CacheError(err)
+	}
+
+	ids, err := r.client.ZRange(ctx, indexKey, 0, -1).Result()
+	if err != nil {
+		return nil, errors.CacheError(err)
+	}
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	keys := make([]string, len(ids))
+	for i, id := range ids {
+		keys[i] = sessionKeyPrefix + id
+	}
+
+	values, err := r.client.MGet(ctx, keys...).Result()
+	if err != nil {
+		return nil, errors.CacheError(err)
+	}
+
+	sessions := make([]*entities.Session, 0, len(values))
+	for _, value := range values {
+		// Сессия могла истечь между чтением индекса и MGET
+		data, ok := value.(string)
+		if !ok {
+			continue
+		}
+		session := &entities.Session{}
+		if err := json.Unmarshal([]byte(data), session); err != nil {
+			return nil, errors.CacheError(err)
+		}
+		sessions = append(sessions, session)
+	}
+
+	return sessions, nil
+}
+
+const sessionKeyPrefix = "session:"
+
+func sessionKey(id uuid.UUID) string {
+	return sessionKeyPrefix + id.String()
+}
+
+// sessionTokenKey names the lookup key by a hash of the token, so refresh
+// tokens do not show up in key listings.
+func sessionTokenKey(refreshToken string) string {
+	sum := sha256.Sum256([]byte(refreshToken))
+	return "session_token:" + hex.EncodeToString(sum[:])
+}
+
+func userSessionsKey(userID uuid.UUID) string {
+	return "user_sessions:" + userID.String()
+}
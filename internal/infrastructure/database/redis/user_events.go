@@ -0,0 +1,160 @@
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"github.com/vagonaizer/authenitfication-service/internal/domain/entities"
+	"github.com/vagonaizer/authenitfication-service/internal/domain/services"
+	"github.com/vagonaizer/authenitfication-service/pkg/logger"
+)
+
+// userEventBuffer is how many events a subscriber may fall behind by before
+// further events are dropped for it.
+const userEventBuffer = 16
+
+var _ services.UserEventStream = (*UserEventHub)(nil)
+
+// UserEventHub broadcasts user events to every replica over a Redis pub/sub
+// channel and hands them to the subscribers of the user on each. Like cache
+// invalidations, events are delivered at most once: those sent while a
+// replica is disconnected from Redis are lost to its subscribers.
+type UserEventHub struct {
+	client  *Client
+	channel string
+	logger  *logger.Logger
+
+	mu          sync.Mutex
+	closed      bool
+	subscribers map[uuid.UUID]map[chan *entities.UserEvent]struct{}
+}
+
+func NewUserEventHub(client *Client, channel string, logger *logger.Logger) *UserEventHub {
+	return &UserEventHub{
+		client:      client,
+		channel:     channel,
+		logger:      logger,
+		subscribers: make(map[uuid.UUID]map[chan *entities.UserEvent]struct{}),
+	}
+}
+
+// Broadcast publishes event to the subscribers of its user on every replica.
+func (h *UserEventHub) Broadcast(ctx context.Context, event *entities.UserEvent) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	return h.client.Publish(ctx, h.channel, data).Err()
+}
+
+func (h *UserEventHub) Subscribe(userID uuid.UUID) (<-chan *entities.UserEvent, func()) {
+	events := make(chan *entities.UserEvent, userEventBuffer)
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.closed {
+		close(events)
+		return events, func() {}
+	}
+	if h.subscribers[userID] == nil {
+		h.subscribers[userID] = make(map[chan *entities.UserEvent]struct{})
+	}
+	h.subscribers[userID][events] = struct{}{}
+
+	var once sync.Once
+	return events, func() {
+		once.Do(func() {
+			h.mu.Lock()
+			defer h.mu.Unlock()
+			h.remove(userID, events)
+		})
+	}
+}
+
+// Close ends every subscription and refuses new ones, so that open streams
+// do not hold up the shutdown of the HTTP and gRPC servers.
+func (h *UserEventHub) Close() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.closed = true
+	for userID, subscribers := range h.subscribers {
+		for events := range subscribers {
+			h.remove(userID, events)
+		}
+	}
+}
+
+// remove closes events unless Close already did; the caller holds mu.
+func (h *UserEventHub) remove(userID uuid.UUID, events chan *entities.UserEvent) {
+	subscribers, ok := h.subscribers[userID]
+	if !ok {
+		return
+	}
+	if _, ok := subscribers[events]; !ok {
+		return
+	}
+
+	delete(subscribers, events)
+	if len(subscribers) == 0 {
+		delete(h.subscribers, userID)
+	}
+	close(events)
+}
+
+// Run delivers the events broadcast by every replica to the subscribers of
+// this one until ctx is cancelled.
+func (h *UserEventHub) Run(ctx context.Context) {
+	pubsub := h.client.Subscribe(ctx, h.channel)
+	defer pubsub.Close()
+
+	for {
+		received, err := pubsub.Receive(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			h.logger.WithError(err).Warn("user event subscription failed")
+
+			// Подписка восстановится при следующем Receive
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(time.Second):
+			}
+			continue
+		}
+
+		message, ok := received.(*redis.Message)
+		if !ok {
+			continue
+		}
+
+		var event entities.UserEvent
+		if err := json.Unmarshal([]byte(message.Payload), &event); err != nil {
+			h.logger.WithError(err).Warn("failed to decode user event")
+			continue
+		}
+		h.deliver(&event)
+	}
+}
+
+func (h *UserEventHub) deliver(event *entities.UserEvent) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for events := range h.subscribers[event.UserID] {
+		select {
+		case events <- event:
+		default:
+			// Отстающий клиент теряет событие, а не тормозит остальных
+			h.logger.WithField("user_id", event.UserID).Warn("dropped user event for a slow stream")
+		}
+	}
+}
@@ -0,0 +1,130 @@
+package messaging
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/vagonaizer/authenitfication-service/internal/config"
+	"github.com/vagonaizer/authenitfication-service/pkg/tracing"
+)
+
+// Publisher publishes events to a topic of the message broker. Values are
+// encoded as JSON; key orders the events of one entity where the broker
+// supports it.
+type Publisher interface {
+	PublishMessage(ctx context.Context, topic string, key string, value interface{}) error
+	// Ping checks that the broker accepts connections.
+	Ping(ctx context.Context) error
+	Close() error
+}
+
+// Message is an event whose value is already encoded as JSON. Headers
+// carry the trace context of the request that published it.
+type Message struct {
+	Topic   string
+	Key     string
+	Value   json.RawMessage
+	Headers map[string]string
+}
+
+// BatchPublisher is implemented by publishers able to write several events
+// in one round trip. A failed batch may have been published in part.
+type BatchPublisher interface {
+	PublishBatch(ctx context.Context, messages []Message) error
+}
+
+// PublishBatch publishes messages in order, as one batch when publisher
+// supports it and one by one otherwise, and stops at the first failure.
+func PublishBatch(ctx context.Context, publisher Publisher, messages []Message) error {
+	if batcher, ok := publisher.(BatchPublisher); ok {
+		return batcher.PublishBatch(ctx, messages)
+	}
+	for _, message := range messages {
+		ctx := tracing.Extract(ctx, func(key string) string {
+			return message.Headers[key]
+		})
+		if err := publisher.PublishMessage(ctx, message.Topic, message.Key, message.Value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// MessageHandler processes one message. Returning an error retries it;
+// handlers should return nil for messages that can never succeed, such as
+// malformed payloads, so they do not hold up the topic.
+type MessageHandler func(ctx context.Context, message []byte) error
+
+// Subscriber delivers the messages of topics to handlers, sharing them
+// between the replicas of the service. Messages still failing after the
+// configured attempts are moved to the topic's dead-letter topic.
+type Subscriber interface {
+	// Consume handles messages of topic until ctx is cancelled. A message
+	// being handled when ctx is cancelled is finished first.
+	Consume(ctx context.Context, topic string, handler MessageHandler) error
+	Close() error
+}
+
+// Headers set on dead-lettered messages. The key and value are those of the
+// original message.
+const (
+	HeaderOriginalTopic     = "dlq.original_topic"
+	HeaderOriginalPartition = "dlq.original_partition"
+	HeaderOriginalOffset    = "dlq.original_offset"
+	HeaderError             = "dlq.error"
+	HeaderAttempts          = "dlq.attempts"
+	HeaderFailedAt          = "dlq.failed_at"
+)
+
+// DeadLetterSuffix is appended to a topic to name its dead-letter topic.
+const DeadLetterSuffix = ".dlq"
+
+// DeadLetterTopic returns the topic poison messages of topic are moved to.
+func DeadLetterTopic(topic string) string {
+	return topic + DeadLetterSuffix
+}
+
+// Retry runs an operation up to Attempts times, the delay between attempts
+// doubling from Delay up to MaxDelay.
+type Retry struct {
+	Attempts int
+	Delay    time.Duration
+	MaxDelay time.Duration
+}
+
+// NewRetry returns the retry settings shared by every broker.
+func NewRetry(cfg *config.KafkaConfig) Retry {
+	return Retry{
+		Attempts: max(cfg.RetryAttempts, 1),
+		Delay:    cfg.RetryDelay,
+		MaxDelay: cfg.RetryMaxDelay,
+	}
+}
+
+// Do runs fn until it succeeds or the attempts are exhausted and returns its
+// last error. Waiting for the next attempt stops when ctx is cancelled.
+func (r Retry) Do(ctx context.Context, fn func() error) error {
+	delay := r.Delay
+
+	var err error
+	for attempt := 1; ; attempt++ {
+		if err = fn(); err == nil {
+			return nil
+		}
+		if attempt >= r.Attempts {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(delay):
+		}
+
+		delay *= 2
+		if r.MaxDelay > 0 && delay > r.MaxDelay {
+			delay = r.MaxDelay
+		}
+	}
+}
@@ -0,0 +1,58 @@
+package messaging
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/vagonaizer/authenitfication-service/internal/infrastructure/metrics"
+	"github.com/vagonaizer/authenitfication-service/pkg/logger"
+)
+
+// DedupStore remembers the events a consumer handled.
+type DedupStore interface {
+	IsEventProcessed(ctx context.Context, topic, eventID string) (bool, error)
+	MarkEventProcessed(ctx context.Context, topic, eventID string, window time.Duration) error
+}
+
+// Deduplicate makes handler idempotent under at-least-once delivery: an event
+// handled within window is skipped when it is delivered again. Events are
+// identified by the id of their envelope, and messages without one are always
+// handled. An event is marked only once handled, so failed attempts are still
+// retried. When the store is unavailable the event is handled anyway, since
+// handling twice is better than not at all.
+func Deduplicate(store DedupStore, topic string, window time.Duration, handler MessageHandler, logger *logger.Logger) MessageHandler {
+	return func(ctx context.Context, message []byte) error {
+		var envelope struct {
+			ID string `json:"id"`
+		}
+		if err := json.Unmarshal(message, &envelope); err != nil || envelope.ID == "" {
+			return handler(ctx, message)
+		}
+
+		fields := logrus.Fields{
+			"topic":    topic,
+			"event_id": envelope.ID,
+		}
+
+		processed, err := store.IsEventProcessed(ctx, topic, envelope.ID)
+		if err != nil {
+			logger.WithError(err).WithFields(fields).Warn("failed to check for duplicate event")
+		}
+		if processed {
+			metrics.DuplicateEventsSkipped.WithLabelValues(topic).Inc()
+			logger.WithFields(fields).Debug("skipping duplicate event")
+			return nil
+		}
+
+		if err := handler(ctx, message); err != nil {
+			return err
+		}
+
+		if err := store.MarkEventProcessed(ctx, topic, envelope.ID, window); err != nil {
+			logger.WithError(err).WithFields(fields).Warn("failed to mark event as processed")
+		}
+		return nil
+	}
+}
@@ -0,0 +1,24 @@
+package messaging
+
+import "context"
+
+// DeliveryCallback learns the outcome of publishing one event: err is nil
+// once the broker acknowledged it. With asynchronous publishing it runs on
+// the publisher's goroutine after PublishMessage returned, so it must not
+// block.
+type DeliveryCallback func(topic, key string, err error)
+
+type deliveryCallbackKey struct{}
+
+// WithDeliveryCallback returns a context whose publishes report their
+// outcome to callback.
+func WithDeliveryCallback(ctx context.Context, callback DeliveryCallback) context.Context {
+	return context.WithValue(ctx, deliveryCallbackKey{}, callback)
+}
+
+// NotifyDelivery runs the delivery callback of ctx, if any.
+func NotifyDelivery(ctx context.Context, topic, key string, err error) {
+	if callback, ok := ctx.Value(deliveryCallbackKey{}).(DeliveryCallback); ok {
+		callback(topic, key, err)
+	}
+}
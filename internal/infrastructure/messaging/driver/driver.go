@@ -0,0 +1,42 @@
+// Package driver creates the publisher and subscriber of the configured
+// message broker.
+package driver
+
+import (
+	"fmt"
+
+	"github.com/vagonaizer/authenitfication-service/internal/config"
+	"github.com/vagonaizer/authenitfication-service/internal/infrastructure/messaging"
+	"github.com/vagonaizer/authenitfication-service/internal/infrastructure/messaging/kafka"
+	"github.com/vagonaizer/authenitfication-service/internal/infrastructure/messaging/nats"
+	"github.com/vagonaizer/authenitfication-service/internal/infrastructure/messaging/rabbitmq"
+	"github.com/vagonaizer/authenitfication-service/pkg/logger"
+)
+
+// New creates the publisher and subscriber of the configured message broker.
+// Neither needs the broker to be reachable yet.
+func New(cfg *config.Config, log *logger.Logger) (messaging.Publisher, messaging.Subscriber, error) {
+	retry := messaging.NewRetry(&cfg.Kafka)
+
+	switch cfg.Messaging.Driver {
+	case "kafka", "":
+		producer, err := kafka.NewProducer(&cfg.Kafka, log)
+		if err != nil {
+			return nil, nil, err
+		}
+		return producer, kafka.NewSubscriber(&cfg.Kafka, producer, log), nil
+	case "nats":
+		publisher, err := nats.NewPublisher(&cfg.Messaging, retry, log)
+		if err != nil {
+			return nil, nil, err
+		}
+		return publisher, nats.NewSubscriber(publisher, cfg.Kafka.GroupID, log), nil
+	case "rabbitmq":
+		publisher := rabbitmq.NewPublisher(&cfg.Messaging, retry, log)
+		return publisher, rabbitmq.NewSubscriber(&cfg.Messaging, publisher, cfg.Kafka.GroupID, log), nil
+	case "noop":
+		return messaging.NewNoopPublisher(log), messaging.NoopSubscriber{}, nil
+	default:
+		return nil, nil, fmt.Errorf("unknown messaging driver: %s", cfg.Messaging.Driver)
+	}
+}
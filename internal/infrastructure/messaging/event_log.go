@@ -0,0 +1,77 @@
+package messaging
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"github.com/vagonaizer/authenitfication-service/internal/domain/entities"
+	"github.com/vagonaizer/authenitfication-service/internal/domain/repositories"
+	"github.com/vagonaizer/authenitfication-service/pkg/logger"
+)
+
+// LoggingPublisher records every event in the event log before handing it to
+// the next publisher, so it can be replayed later. Events are recorded even
+// when publishing then fails, which makes the log cover broker outages too.
+// Recording is best effort and never fails the publish.
+type LoggingPublisher struct {
+	Publisher
+	eventLog repositories.EventLogRepository
+	logger   *logger.Logger
+}
+
+func NewLoggingPublisher(next Publisher, eventLog repositories.EventLogRepository, logger *logger.Logger) *LoggingPublisher {
+	return &LoggingPublisher{
+		Publisher: next,
+		eventLog:  eventLog,
+		logger:    logger,
+	}
+}
+
+func (p *LoggingPublisher) PublishMessage(ctx context.Context, topic string, key string, value interface{}) error {
+	payload, err := json.Marshal(value)
+	if err == nil {
+		p.record(ctx, topic, key, payload)
+	} else {
+		p.logger.WithError(err).WithFields(logrus.Fields{
+			"topic": topic,
+			"key":   key,
+		}).Warn("failed to record event")
+	}
+
+	return p.Publisher.PublishMessage(ctx, topic, key, value)
+}
+
+func (p *LoggingPublisher) PublishBatch(ctx context.Context, messages []Message) error {
+	for _, message := range messages {
+		p.record(ctx, message.Topic, message.Key, message.Value)
+	}
+
+	return PublishBatch(ctx, p.Publisher, messages)
+}
+
+func (p *LoggingPublisher) record(ctx context.Context, topic, key string, payload []byte) {
+	record := &entities.EventRecord{
+		Topic:   topic,
+		Key:     key,
+		UserID:  payloadUserID(payload),
+		Payload: payload,
+	}
+	if err := p.eventLog.Create(ctx, record); err != nil {
+		p.logger.WithError(err).WithFields(logrus.Fields{
+			"topic": topic,
+			"key":   key,
+		}).Warn("failed to record event")
+	}
+}
+
+func payloadUserID(payload []byte) *uuid.UUID {
+	var fields struct {
+		UserID *uuid.UUID `json:"user_id"`
+	}
+	if err := json.Unmarshal(payload, &fields); err != nil {
+		return nil
+	}
+	return fields.UserID
+}
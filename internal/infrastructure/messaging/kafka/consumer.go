@@ -2,23 +2,31 @@ package kafka
 
 import (
 	"context"
+	"time"
 
 	"github.com/segmentio/kafka-go"
 	"github.com/sirupsen/logrus"
 	"github.com/vagonaizer/authenitfication-service/internal/config"
+	"github.com/vagonaizer/authenitfication-service/internal/infrastructure/messaging"
+	"github.com/vagonaizer/authenitfication-service/internal/infrastructure/metrics"
 	"github.com/vagonaizer/authenitfication-service/pkg/logger"
+	"github.com/vagonaizer/authenitfication-service/pkg/tracing"
 )
 
 type Consumer struct {
-	reader *kafka.Reader
-	logger *logger.Logger
+	reader      *kafka.Reader
+	deadLetters *Producer
+	logger      *logger.Logger
+	retry       messaging.Retry
 }
 
-type MessageHandler func(ctx context.Context, message []byte) error
-
-func NewConsumer(cfg *config.KafkaConfig, topic string, logger *logger.Logger) *Consumer {
+// NewConsumer reads topic as part of the configured consumer group. Messages
+// still failing after the configured attempts are published to the topic's
+// dead-letter topic through deadLetters.
+func NewConsumer(cfg *config.KafkaConfig, topic string, dialer *kafka.Dialer, deadLetters *Producer, logger *logger.Logger) *Consumer {
 	reader := kafka.NewReader(kafka.ReaderConfig{
 		Brokers:  cfg.Brokers,
+		Dialer:   dialer,
 		Topic:    topic,
 		GroupID:  cfg.GroupID,
 		MinBytes: 10e3,
@@ -26,38 +34,81 @@ func NewConsumer(cfg *config.KafkaConfig, topic string, logger *logger.Logger) *
 	})
 
 	return &Consumer{
-		reader: reader,
-		logger: logger,
+		reader:      reader,
+		deadLetters: deadLetters,
+		logger:      logger,
+		retry:       messaging.NewRetry(cfg),
 	}
 }
 
-func (c *Consumer) Consume(ctx context.Context, handler MessageHandler) error {
+// Consume handles messages until ctx is cancelled. Offsets are committed
+// only once a message was handled or dead-lettered: a message being handled
+// during shutdown is finished, and one waiting for a retry is left for the
+// next consumer.
+func (c *Consumer) Consume(ctx context.Context, handler messaging.MessageHandler) error {
 	for {
-		select {
-		case <-ctx.Done():
-			return ctx.Err()
-		default:
-			message, err := c.reader.ReadMessage(ctx)
-			if err != nil {
-				c.logger.WithError(err).Error("failed to read message")
-				continue
+		message, err := c.reader.FetchMessage(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
 			}
+			c.logger.WithError(err).Error("failed to read message")
+			continue
+		}
+
+		// Обработку начатого сообщения доводим до конца даже при остановке
+		handleCtx := tracing.Extract(context.WithoutCancel(ctx), func(key string) string {
+			return Header(message, key)
+		})
+
+		fields := logrus.Fields{
+			"topic":     message.Topic,
+			"partition": message.Partition,
+			"offset":    message.Offset,
+			"trace_id":  tracing.TraceID(handleCtx),
+		}
+		if id := tracing.CorrelationID(handleCtx); id != "" {
+			fields["request_id"] = id
+		}
+
+		err = c.retry.Do(ctx, func() error {
+			return handler(handleCtx, message.Value)
+		})
+		if err != nil && ctx.Err() != nil {
+			return ctx.Err()
+		}
 
-			err = handler(ctx, message.Value)
-			if err != nil {
-				c.logger.WithError(err).WithFields(logrus.Fields{
-					"topic":     message.Topic,
-					"partition": message.Partition,
-					"offset":    message.Offset,
-				}).Error("failed to handle message")
-				continue
+		if err != nil {
+			c.logger.WithError(err).WithFields(fields).Error("failed to handle message, moving it to the dead-letter topic")
+			if err := c.deadLetter(ctx, message, err); err != nil {
+				return err
 			}
+			metrics.KafkaDeadLetters.WithLabelValues(message.Topic).Inc()
+		} else {
+			c.logger.WithFields(fields).Debug("message processed successfully")
+		}
+
+		if err := c.reader.CommitMessages(handleCtx, message); err != nil {
+			c.logger.WithError(err).WithFields(fields).Error("failed to commit message")
+		}
+	}
+}
 
-			c.logger.WithFields(logger.Fields{
-				"topic":     message.Topic,
-				"partition": message.Partition,
-				"offset":    message.Offset,
-			}).Debug("message processed successfully")
+// deadLetter publishes the message to the dead-letter topic, retrying until
+// it succeeds: committing past a message that is in neither topic would lose
+// it. It gives up only when ctx is cancelled, leaving the message uncommitted.
+func (c *Consumer) deadLetter(ctx context.Context, message kafka.Message, handleErr error) error {
+	for {
+		err := c.deadLetters.PublishDeadLetter(context.WithoutCancel(ctx), message, handleErr, c.retry.Attempts)
+		if err == nil {
+			return nil
+		}
+		c.logger.WithError(err).WithField("topic", message.Topic).Error("failed to publish dead letter")
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(max(c.retry.MaxDelay, c.retry.Delay)):
 		}
 	}
 }
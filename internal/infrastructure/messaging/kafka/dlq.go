@@ -0,0 +1,137 @@
+package kafka
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+	"github.com/vagonaizer/authenitfication-service/internal/config"
+	"github.com/vagonaizer/authenitfication-service/internal/infrastructure/messaging"
+	"github.com/vagonaizer/authenitfication-service/pkg/tracing"
+)
+
+// PublishDeadLetter moves a message its handler gave up on to the topic's
+// dead-letter topic, recording why in the headers. Its trace headers are
+// kept, so a replay continues the original trace.
+func (p *Producer) PublishDeadLetter(ctx context.Context, original kafka.Message, handleErr error, attempts int) error {
+	message := kafka.Message{
+		Topic: messaging.DeadLetterTopic(original.Topic),
+		Key:   original.Key,
+		Value: original.Value,
+		Time:  time.Now(),
+		Headers: []kafka.Header{
+			{Key: messaging.HeaderOriginalTopic, Value: []byte(original.Topic)},
+			{Key: messaging.HeaderOriginalPartition, Value: []byte(strconv.Itoa(original.Partition))},
+			{Key: messaging.HeaderOriginalOffset, Value: []byte(strconv.FormatInt(original.Offset, 10))},
+			{Key: messaging.HeaderError, Value: []byte(handleErr.Error())},
+			{Key: messaging.HeaderAttempts, Value: []byte(strconv.Itoa(attempts))},
+			{Key: messaging.HeaderFailedAt, Value: []byte(time.Now().UTC().Format(time.RFC3339))},
+		},
+	}
+	message.Headers = append(message.Headers, copyTraceHeaders(original)...)
+
+	return p.writer.WriteMessages(ctx, message)
+}
+
+// ReplayOptions controls Replay. Replay stops after Limit messages, when
+// Limit is positive, or once no message arrived for Idle.
+type ReplayOptions struct {
+	Limit  int
+	Idle   time.Duration
+	DryRun bool
+}
+
+// Replay publishes the messages of a dead-letter topic back to the topics
+// they came from and returns how many it replayed. It reads as its own
+// consumer group, so replayed messages are not read again by a later run.
+// With DryRun messages are only reported and offsets are not committed.
+func Replay(ctx context.Context, cfg *config.KafkaConfig, dlqTopic string, opts ReplayOptions, report func(message kafka.Message)) (int, error) {
+	dialer, err := newDialer(cfg)
+	if err != nil {
+		return 0, err
+	}
+
+	reader := kafka.NewReader(kafka.ReaderConfig{
+		Brokers: cfg.Brokers,
+		Dialer:  dialer,
+		Topic:   dlqTopic,
+		GroupID: cfg.GroupID + "-dlq-replay",
+	})
+	defer reader.Close()
+
+	writer := &kafka.Writer{
+		Addr:         kafka.TCP(cfg.Brokers...),
+		Transport:    newTransport(dialer),
+		Balancer:     &kafka.LeastBytes{},
+		RequiredAcks: kafka.RequireOne,
+	}
+	defer writer.Close()
+
+	replayed := 0
+	for opts.Limit <= 0 || replayed < opts.Limit {
+		fetchCtx, cancel := context.WithTimeout(ctx, opts.Idle)
+		message, err := reader.FetchMessage(fetchCtx)
+		cancel()
+		if err != nil {
+			if errors.Is(err, context.DeadlineExceeded) && ctx.Err() == nil {
+				return replayed, nil
+			}
+			return replayed, err
+		}
+
+		report(message)
+
+		if !opts.DryRun {
+			topic := originalTopic(message)
+			err := writer.WriteMessages(ctx, kafka.Message{
+				Topic:   topic,
+				Key:     message.Key,
+				Value:   message.Value,
+				Headers: copyTraceHeaders(message),
+			})
+			if err != nil {
+				return replayed, fmt.Errorf("failed to republish to %s: %w", topic, err)
+			}
+
+			if err := reader.CommitMessages(ctx, message); err != nil {
+				return replayed, fmt.Errorf("failed to commit replayed message: %w", err)
+			}
+		}
+
+		replayed++
+	}
+
+	return replayed, nil
+}
+
+// Header returns the value of the named header, or "" when it is not set.
+func Header(message kafka.Message, key string) string {
+	for _, header := range message.Headers {
+		if header.Key == key {
+			return string(header.Value)
+		}
+	}
+	return ""
+}
+
+// copyTraceHeaders returns the trace headers of message.
+func copyTraceHeaders(message kafka.Message) []kafka.Header {
+	var headers []kafka.Header
+	for _, header := range message.Headers {
+		if header.Key == tracing.HeaderTraceparent || header.Key == tracing.HeaderCorrelationID {
+			headers = append(headers, header)
+		}
+	}
+	return headers
+}
+
+func originalTopic(message kafka.Message) string {
+	if topic := Header(message, messaging.HeaderOriginalTopic); topic != "" {
+		return topic
+	}
+	return strings.TrimSuffix(message.Topic, messaging.DeadLetterSuffix)
+}
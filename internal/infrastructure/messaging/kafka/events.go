@@ -1,28 +1,91 @@
 package kafka
 
 import (
+	"context"
 	"time"
 
 	"github.com/google/uuid"
+
+	"github.com/vagonaizer/authenitfication-service/pkg/tracing"
 )
 
 const (
-	TopicUserRegistered  = "user.registered"
-	TopicUserLoggedIn    = "user.logged_in"
-	TopicUserLoggedOut   = "user.logged_out"
-	TopicPasswordChanged = "user.password_changed"
-	TopicUserActivated   = "user.activated"
-	TopicUserDeactivated = "user.deactivated"
-	TopicUserDeleted     = "user.deleted"
-	TopicRoleAssigned    = "user.role_assigned"
-	TopicRoleRemoved     = "user.role_removed"
+	TopicUserRegistered   = "user.registered"
+	TopicUserLoggedIn     = "user.logged_in"
+	TopicUserLoggedOut    = "user.logged_out"
+	TopicPasswordChanged  = "user.password_changed"
+	TopicUserActivated    = "user.activated"
+	TopicUserDeactivated  = "user.deactivated"
+	TopicUserDeleted      = "user.deleted"
+	TopicRoleAssigned     = "user.role_assigned"
+	TopicRoleRemoved      = "user.role_removed"
+	TopicRoleExpired      = "user.role_expired"
+	TopicRoleBulkAssigned = "user.role_bulk_assigned"
+	TopicRoleBulkRemoved  = "user.role_bulk_removed"
+	TopicRoleCreated      = "role.created"
+	TopicRoleUpdated      = "role.updated"
+	TopicRoleDeleted      = "role.deleted"
+
+	TopicUserCreatedByAdmin = "user.created_by_admin"
+	TopicUserPurged         = "user.purged"
+	TopicUserAvatarUpdated  = "user.avatar_updated"
+	TopicUserVerified       = "user.verified"
+	TopicVerificationResent = "user.verification_resent"
+	TopicUserBanned         = "user.banned"
+	TopicUserUnbanned       = "user.unbanned"
+	TopicUserMerged         = "user.merged"
+	TopicUserPhoneVerified  = "user.phone_verified"
+
+	TopicUserDeletionScheduled = "user.deletion_scheduled"
+	TopicUserDeletionCancelled = "user.deletion_cancelled"
+
+	TopicUserPasswordChangeRequired = "user.password_change_required"
+
+	// Security events for monitoring the auth surface.
+	TopicLoginFailed    = "user.login_failed"
+	TopicSessionRevoked = "user.session_revoked"
+	TopicTokenRefreshed = "user.token_refreshed"
+
+	TopicOrganizationCreated       = "organization.created"
+	TopicOrganizationDeleted       = "organization.deleted"
+	TopicOrganizationMemberAdded   = "organization.member_added"
+	TopicOrganizationMemberUpdated = "organization.member_updated"
+	TopicOrganizationMemberRemoved = "organization.member_removed"
+
+	TopicOrganizationInvitationCreated  = "organization.invitation_created"
+	TopicOrganizationInvitationAccepted = "organization.invitation_accepted"
+	TopicOrganizationInvitationDeclined = "organization.invitation_declined"
+	TopicOrganizationInvitationRevoked  = "organization.invitation_revoked"
+	TopicOrganizationQuotaUpdated       = "organization.quota_updated"
+	TopicOrganizationQuotaExceeded      = "organization.quota_exceeded"
+
+	TopicServiceAccountCreated    = "service_account.created"
+	TopicServiceAccountDeleted    = "service_account.deleted"
+	TopicServiceAccountKeyCreated = "service_account.key_created"
+	TopicServiceAccountKeyRevoked = "service_account.key_revoked"
+
+	TopicGroupCreated       = "group.created"
+	TopicGroupDeleted       = "group.deleted"
+	TopicGroupMemberAdded   = "group.member_added"
+	TopicGroupMemberRemoved = "group.member_removed"
+
+	TopicNotificationsEmail = "notifications.email"
+	TopicNotificationsSMS   = "notifications.sms"
+
+	// Inbound topics published by other services.
+	TopicModerationBanRequested = "moderation.ban_requested"
+	TopicUserDeletionRequested  = "user.deletion_requested"
 )
 
+// BaseEvent is the envelope of every event. Version is the schema version of
+// the event type, see Decode.
 type BaseEvent struct {
 	ID        uuid.UUID `json:"id"`
 	Type      string    `json:"type"`
 	Timestamp time.Time `json:"timestamp"`
 	Version   string    `json:"version"`
+	// CorrelationID is the request ID of the request that caused the event.
+	CorrelationID string `json:"correlation_id,omitempty"`
 }
 
 type UserRegisteredEvent struct {
@@ -34,9 +97,22 @@ type UserRegisteredEvent struct {
 	LastName  *string   `json:"last_name"`
 }
 
+type UserCreatedByAdminEvent struct {
+	BaseEvent
+	UserID                 uuid.UUID  `json:"user_id"`
+	Email                  string     `json:"email"`
+	Username               string     `json:"username"`
+	FirstName              *string    `json:"first_name"`
+	LastName               *string    `json:"last_name"`
+	Roles                  []string   `json:"roles"`
+	CreatedBy              *uuid.UUID `json:"created_by,omitempty"`
+	PasswordChangeRequired bool       `json:"password_change_required"`
+}
+
 type UserLoggedInEvent struct {
 	BaseEvent
 	UserID    uuid.UUID `json:"user_id"`
+	SessionID uuid.UUID `json:"session_id"`
 	Email     string    `json:"email"`
 	IPAddress string    `json:"ip_address"`
 	UserAgent string    `json:"user_agent"`
@@ -49,6 +125,35 @@ type UserLoggedOutEvent struct {
 	SessionID uuid.UUID `json:"session_id"`
 }
 
+// LoginFailedEvent reports a refused login. UserID is nil when the email
+// matched no account; Reason is one of the entities.LoginFailure reasons.
+type LoginFailedEvent struct {
+	BaseEvent
+	UserID    *uuid.UUID `json:"user_id,omitempty"`
+	Email     string     `json:"email"`
+	Reason    string     `json:"reason"`
+	IPAddress string     `json:"ip_address"`
+	UserAgent string     `json:"user_agent"`
+	Country   *string    `json:"country,omitempty"`
+}
+
+// SessionRevokedEvent reports sessions ended other than by the user logging
+// out of them. SessionID is nil when every session of the user was revoked;
+// Reason is one of the entities.SessionRevoked reasons.
+type SessionRevokedEvent struct {
+	BaseEvent
+	UserID    uuid.UUID  `json:"user_id"`
+	SessionID *uuid.UUID `json:"session_id,omitempty"`
+	Reason    string     `json:"reason"`
+}
+
+type TokenRefreshedEvent struct {
+	BaseEvent
+	UserID         uuid.UUID  `json:"user_id"`
+	SessionID      uuid.UUID  `json:"session_id"`
+	OrganizationID *uuid.UUID `json:"organization_id,omitempty"`
+}
+
 type PasswordChangedEvent struct {
 	BaseEvent
 	UserID uuid.UUID `json:"user_id"`
@@ -56,18 +161,123 @@ type PasswordChangedEvent struct {
 }
 
 type UserActivatedEvent struct {
+	BaseEvent
+	UserID  uuid.UUID  `json:"user_id"`
+	Email   string     `json:"email"`
+	ActorID *uuid.UUID `json:"actor_id,omitempty"`
+}
+
+type UserDeactivatedEvent struct {
+	BaseEvent
+	UserID  uuid.UUID  `json:"user_id"`
+	Email   string     `json:"email"`
+	ActorID *uuid.UUID `json:"actor_id,omitempty"`
+}
+
+type UserDeletedEvent struct {
 	BaseEvent
 	UserID uuid.UUID `json:"user_id"`
 	Email  string    `json:"email"`
 }
 
-type UserDeactivatedEvent struct {
+// UserPurgedEvent asks consumers to erase their own data about the user. It
+// deliberately carries no personal data.
+type UserPurgedEvent struct {
+	BaseEvent
+	UserID uuid.UUID `json:"user_id"`
+	Reason string    `json:"reason"`
+}
+
+type UserDeletionScheduledEvent struct {
+	BaseEvent
+	UserID      uuid.UUID `json:"user_id"`
+	EffectiveAt time.Time `json:"effective_at"`
+}
+
+type UserDeletionCancelledEvent struct {
+	BaseEvent
+	UserID uuid.UUID `json:"user_id"`
+}
+
+// UserPasswordChangeRequiredEvent is published when an admin sets or clears
+// the forced password change of a user.
+type UserPasswordChangeRequiredEvent struct {
+	BaseEvent
+	UserID   uuid.UUID `json:"user_id"`
+	Required bool      `json:"required"`
+	ActorID  uuid.UUID `json:"actor_id"`
+}
+
+// UserAvatarUpdatedEvent carries the new avatar URL, or null when the avatar
+// was removed.
+type UserAvatarUpdatedEvent struct {
+	BaseEvent
+	UserID    uuid.UUID `json:"user_id"`
+	AvatarURL *string   `json:"avatar_url"`
+}
+
+type UserBannedEvent struct {
+	BaseEvent
+	UserID      uuid.UUID  `json:"user_id"`
+	Reason      string     `json:"reason"`
+	BannedUntil *time.Time `json:"banned_until,omitempty"`
+	BannedBy    *uuid.UUID `json:"banned_by,omitempty"`
+}
+
+// ModerationBanRequestedEvent is published by the moderation service to ban
+// a user, until BannedUntil or permanently when it is nil. Version 1.0 named
+// the user target_user_id.
+type ModerationBanRequestedEvent struct {
+	BaseEvent
+	UserID      uuid.UUID  `json:"user_id"`
+	Reason      string     `json:"reason"`
+	BannedUntil *time.Time `json:"banned_until,omitempty"`
+}
+
+func init() {
+	RegisterUpcaster(TopicModerationBanRequested, "1.0", "2.0", RenameField("target_user_id", "user_id"))
+}
+
+// UserDeletionRequestedEvent asks for an account to be deleted on behalf of
+// another service, named in Source.
+type UserDeletionRequestedEvent struct {
+	BaseEvent
+	UserID uuid.UUID `json:"user_id"`
+	Source string    `json:"source"`
+}
+
+// UserUnbannedEvent has no UnbannedBy when the ban simply expired.
+type UserUnbannedEvent struct {
+	BaseEvent
+	UserID     uuid.UUID  `json:"user_id"`
+	UnbannedBy *uuid.UUID `json:"unbanned_by,omitempty"`
+	Expired    bool       `json:"expired"`
+}
+
+// UserMergedEvent tells downstream services to re-key data owned by the
+// duplicate onto the primary user.
+type UserMergedEvent struct {
+	BaseEvent
+	PrimaryUserID   uuid.UUID `json:"primary_user_id"`
+	DuplicateUserID uuid.UUID `json:"duplicate_user_id"`
+	MergedBy        uuid.UUID `json:"merged_by"`
+}
+
+type UserPhoneVerifiedEvent struct {
+	BaseEvent
+	UserID uuid.UUID `json:"user_id"`
+	Phone  string    `json:"phone"`
+}
+
+type UserVerifiedEvent struct {
 	BaseEvent
 	UserID uuid.UUID `json:"user_id"`
 	Email  string    `json:"email"`
 }
 
-type UserDeletedEvent struct {
+// VerificationResentEvent records that a user asked for another verification
+// email. The token itself only travels on the notifications topic.
+type VerificationResentEvent struct {
 	BaseEvent
 	UserID uuid.UUID `json:"user_id"`
 	Email  string    `json:"email"`
@@ -75,23 +285,157 @@ type UserDeletedEvent struct {
 
 type RoleAssignedEvent struct {
 	BaseEvent
-	UserID   uuid.UUID `json:"user_id"`
-	RoleID   uuid.UUID `json:"role_id"`
-	RoleName string    `json:"role_name"`
+	UserID    uuid.UUID  `json:"user_id"`
+	RoleID    uuid.UUID  `json:"role_id"`
+	RoleName  string     `json:"role_name"`
+	ScopeID   *uuid.UUID `json:"scope_id,omitempty"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
 }
 
 type RoleRemovedEvent struct {
 	BaseEvent
-	UserID   uuid.UUID `json:"user_id"`
-	RoleID   uuid.UUID `json:"role_id"`
-	RoleName string    `json:"role_name"`
+	UserID   uuid.UUID  `json:"user_id"`
+	RoleID   uuid.UUID  `json:"role_id"`
+	RoleName string     `json:"role_name"`
+	ScopeID  *uuid.UUID `json:"scope_id,omitempty"`
+}
+
+// BulkRoleEvent is published once per bulk assignment or removal and lists
+// every user whose roles actually changed.
+type BulkRoleEvent struct {
+	BaseEvent
+	RoleID    uuid.UUID   `json:"role_id"`
+	RoleName  string      `json:"role_name"`
+	ScopeID   *uuid.UUID  `json:"scope_id,omitempty"`
+	UserIDs   []uuid.UUID `json:"user_ids"`
+	ExpiresAt *time.Time  `json:"expires_at,omitempty"`
+	ActorID   *uuid.UUID  `json:"actor_id,omitempty"`
+}
+
+type RoleExpiredEvent struct {
+	BaseEvent
+	UserID    uuid.UUID  `json:"user_id"`
+	RoleID    uuid.UUID  `json:"role_id"`
+	RoleName  string     `json:"role_name"`
+	ScopeID   *uuid.UUID `json:"scope_id,omitempty"`
+	ExpiresAt time.Time  `json:"expires_at"`
+}
+
+type RoleCreatedEvent struct {
+	BaseEvent
+	RoleID      uuid.UUID `json:"role_id"`
+	Name        string    `json:"name"`
+	Description *string   `json:"description"`
+}
+
+type RoleUpdatedEvent struct {
+	BaseEvent
+	RoleID       uuid.UUID `json:"role_id"`
+	Name         string    `json:"name"`
+	PreviousName string    `json:"previous_name"`
+	Description  *string   `json:"description"`
+}
+
+type RoleDeletedEvent struct {
+	BaseEvent
+	RoleID uuid.UUID `json:"role_id"`
+	Name   string    `json:"name"`
+}
+
+type OrganizationCreatedEvent struct {
+	BaseEvent
+	OrganizationID uuid.UUID `json:"organization_id"`
+	Name           string    `json:"name"`
+	Slug           string    `json:"slug"`
+	OwnerID        uuid.UUID `json:"owner_id"`
+}
+
+type OrganizationDeletedEvent struct {
+	BaseEvent
+	OrganizationID uuid.UUID `json:"organization_id"`
+	DeletedBy      uuid.UUID `json:"deleted_by"`
+}
+
+type OrganizationMemberEvent struct {
+	BaseEvent
+	OrganizationID uuid.UUID `json:"organization_id"`
+	UserID         uuid.UUID `json:"user_id"`
+	Role           string    `json:"role"`
+	ActorID        uuid.UUID `json:"actor_id"`
+}
+
+// OrganizationInvitationEvent carries the raw invitation token only on
+// invitation_created, so that the notification service can deliver it.
+type OrganizationInvitationEvent struct {
+	BaseEvent
+	InvitationID   uuid.UUID  `json:"invitation_id"`
+	OrganizationID uuid.UUID  `json:"organization_id"`
+	Email          string     `json:"email"`
+	Role           string     `json:"role"`
+	ActorID        *uuid.UUID `json:"actor_id,omitempty"`
+	Token          string     `json:"token,omitempty"`
+	ExpiresAt      time.Time  `json:"expires_at"`
+}
+
+type OrganizationQuotaUpdatedEvent struct {
+	BaseEvent
+	OrganizationID     uuid.UUID `json:"organization_id"`
+	MaxMembers         *int      `json:"max_members"`
+	MaxSessionsPerUser *int      `json:"max_sessions_per_user"`
+	TokensPerMinute    *int      `json:"tokens_per_minute"`
+	ActorID            uuid.UUID `json:"actor_id"`
+}
+
+type OrganizationQuotaExceededEvent struct {
+	BaseEvent
+	OrganizationID uuid.UUID  `json:"organization_id"`
+	Quota          string     `json:"quota"`
+	Limit          int        `json:"limit"`
+	UserID         *uuid.UUID `json:"user_id,omitempty"`
+}
+
+type ServiceAccountEvent struct {
+	BaseEvent
+	ServiceAccountID uuid.UUID `json:"service_account_id"`
+	Name             string    `json:"name"`
+	ActorID          uuid.UUID `json:"actor_id"`
+}
+
+// ServiceAccountKeyEvent is published per key; a rotation produces one
+// key_created event with Rotated set.
+type ServiceAccountKeyEvent struct {
+	BaseEvent
+	ServiceAccountID uuid.UUID `json:"service_account_id"`
+	KeyID            string    `json:"key_id"`
+	Rotated          bool      `json:"rotated,omitempty"`
+	ActorID          uuid.UUID `json:"actor_id"`
+}
+
+type GroupCreatedEvent struct {
+	BaseEvent
+	GroupID  uuid.UUID  `json:"group_id"`
+	Name     string     `json:"name"`
+	ParentID *uuid.UUID `json:"parent_id,omitempty"`
+}
+
+type GroupDeletedEvent struct {
+	BaseEvent
+	GroupID uuid.UUID `json:"group_id"`
+	Name    string    `json:"name"`
+}
+
+type GroupMemberEvent struct {
+	BaseEvent
+	GroupID uuid.UUID `json:"group_id"`
+	UserID  uuid.UUID `json:"user_id"`
 }
 
-func NewBaseEvent(eventType string) BaseEvent {
+func NewBaseEvent(ctx context.Context, eventType string) BaseEvent {
 	return BaseEvent{
-		ID:        uuid.New(),
-		Type:      eventType,
-		Timestamp: time.Now().UTC(),
-		Version:   "1.0",
+		ID:            uuid.New(),
+		Type:          eventType,
+		Timestamp:     time.Now().UTC(),
+		Version:       SchemaVersion(eventType),
+		CorrelationID: tracing.CorrelationID(ctx),
 	}
 }
@@ -3,35 +3,111 @@ package kafka
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"sync"
 	"time"
 
 	"github.com/segmentio/kafka-go"
 	"github.com/sirupsen/logrus"
 	"github.com/vagonaizer/authenitfication-service/internal/config"
+	"github.com/vagonaizer/authenitfication-service/internal/infrastructure/messaging"
+	"github.com/vagonaizer/authenitfication-service/internal/infrastructure/metrics"
 	"github.com/vagonaizer/authenitfication-service/pkg/logger"
+	"github.com/vagonaizer/authenitfication-service/pkg/tracing"
 )
 
+// Policies for a full async queue.
+const (
+	AsyncFullDrop  = "drop"
+	AsyncFullBlock = "block"
+)
+
+var (
+	ErrAsyncQueueFull = errors.New("async producer queue is full")
+	ErrProducerClosed = errors.New("producer is closed")
+)
+
+// Producer publishes events to Kafka. Topics configured as asynchronous are
+// queued and written in batches by a background goroutine, so publishing
+// them does not wait for the broker; their outcome is reported to the
+// delivery callback of the publishing context. Other topics are written
+// before PublishMessage returns.
 type Producer struct {
-	writer *kafka.Writer
-	logger *logger.Logger
+	writer  *kafka.Writer
+	dialer  *kafka.Dialer
+	brokers []string
+	logger  *logger.Logger
+	retry   messaging.Retry
+
+	asyncAll    bool
+	asyncTopics map[string]bool
+	blockOnFull bool
+	batchSize   int
+
+	mu     sync.RWMutex
+	closed bool
+	queue  chan queuedMessage
+	done   chan struct{}
+}
+
+type queuedMessage struct {
+	ctx     context.Context
+	message kafka.Message
 }
 
-func NewProducer(cfg *config.KafkaConfig, logger *logger.Logger) *Producer {
+var _ messaging.Publisher = (*Producer)(nil)
+
+func NewProducer(cfg *config.KafkaConfig, logger *logger.Logger) (*Producer, error) {
+	dialer, err := newDialer(cfg)
+	if err != nil {
+		return nil, err
+	}
+
 	writer := &kafka.Writer{
 		Addr:         kafka.TCP(cfg.Brokers...),
+		Transport:    newTransport(dialer),
 		Balancer:     &kafka.LeastBytes{},
 		BatchSize:    cfg.BatchSize,
 		BatchTimeout: cfg.BatchTimeout,
 		RequiredAcks: kafka.RequireOne,
 		Async:        false,
+		// Повторы выполняет PublishMessage, а не writer
+		MaxAttempts: 1,
+	}
+
+	p := &Producer{
+		writer:      writer,
+		dialer:      dialer,
+		brokers:     cfg.Brokers,
+		logger:      logger,
+		retry:       messaging.NewRetry(cfg),
+		asyncTopics: make(map[string]bool),
+		blockOnFull: cfg.AsyncFullPolicy == AsyncFullBlock,
+		batchSize:   max(cfg.BatchSize, 1),
+	}
+
+	for _, topic := range cfg.AsyncTopics {
+		if topic == "*" {
+			p.asyncAll = true
+		}
+		p.asyncTopics[topic] = true
 	}
 
-	return &Producer{
-		writer: writer,
-		logger: logger,
+	if p.asyncAll || len(p.asyncTopics) > 0 {
+		p.queue = make(chan queuedMessage, max(cfg.AsyncQueueSize, 1))
+		p.done = make(chan struct{})
+		go p.run()
 	}
+
+	return p, nil
 }
 
+// PublishMessage publishes value as JSON. A failed write is retried up to the
+// configured number of attempts, the delay doubling up to the maximum; the
+// event is dropped, and counted, once they are exhausted or ctx is done.
+// Asynchronous topics only wait for room in the queue: with the drop policy
+// a full queue drops the event at once, with the block policy it waits until
+// ctx is done.
 func (p *Producer) PublishMessage(ctx context.Context, topic string, key string, value interface{}) error {
 	data, err := json.Marshal(value)
 	if err != nil {
@@ -45,25 +121,193 @@ func (p *Producer) PublishMessage(ctx context.Context, topic string, key string,
 		Value:     data,
 		Time:      time.Now(),
 		Partition: 0,
+		Headers:   traceHeaders(ctx),
+	}
+
+	if p.asyncAll || p.asyncTopics[topic] {
+		return p.enqueue(ctx, message)
+	}
+
+	err = p.write(ctx, message)
+	p.delivered(ctx, message, err)
+	return err
+}
+
+// PublishBatch writes messages in one batch, retried like PublishMessage.
+// It always waits for the write, asynchronous topics included, and leaves
+// counting failures as dropped to the caller, which still has the messages.
+func (p *Producer) PublishBatch(ctx context.Context, messages []messaging.Message) error {
+	p.mu.RLock()
+	closed := p.closed
+	p.mu.RUnlock()
+	if closed {
+		return ErrProducerClosed
+	}
+
+	batch := make([]kafka.Message, len(messages))
+	for i, message := range messages {
+		batch[i] = kafka.Message{
+			Topic: message.Topic,
+			Key:   []byte(message.Key),
+			Value: message.Value,
+			Time:  time.Now(),
+		}
+		for key, value := range message.Headers {
+			batch[i].Headers = append(batch[i].Headers, kafka.Header{Key: key, Value: []byte(value)})
+		}
+	}
+
+	return p.write(ctx, batch...)
+}
+
+// traceHeaders returns the trace context and correlation ID of ctx as
+// message headers.
+func traceHeaders(ctx context.Context) []kafka.Header {
+	var headers []kafka.Header
+	tracing.Inject(ctx, func(key, value string) {
+		headers = append(headers, kafka.Header{Key: key, Value: []byte(value)})
+	})
+	return headers
+}
+
+func (p *Producer) enqueue(ctx context.Context, message kafka.Message) error {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	if p.closed {
+		p.delivered(ctx, message, ErrProducerClosed)
+		return ErrProducerClosed
+	}
+
+	// Запрос завершится раньше записи, поэтому отмена не передаётся
+	queued := queuedMessage{ctx: context.WithoutCancel(ctx), message: message}
+
+	if p.blockOnFull {
+		select {
+		case p.queue <- queued:
+			metrics.KafkaAsyncQueueDepth.Set(float64(len(p.queue)))
+			return nil
+		case <-ctx.Done():
+			p.delivered(ctx, message, ctx.Err())
+			return ctx.Err()
+		}
+	}
+
+	select {
+	case p.queue <- queued:
+		metrics.KafkaAsyncQueueDepth.Set(float64(len(p.queue)))
+		return nil
+	default:
+		p.delivered(ctx, message, ErrAsyncQueueFull)
+		return ErrAsyncQueueFull
+	}
+}
+
+// run writes queued messages until the queue is closed, taking whatever is
+// queued, up to the batch size, into each write.
+func (p *Producer) run() {
+	defer close(p.done)
+
+	for first := range p.queue {
+		batch := []queuedMessage{first}
+	fill:
+		for len(batch) < p.batchSize {
+			select {
+			case next, ok := <-p.queue:
+				if !ok {
+					break fill
+				}
+				batch = append(batch, next)
+			default:
+				break fill
+			}
+		}
+		metrics.KafkaAsyncQueueDepth.Set(float64(len(p.queue)))
+
+		messages := make([]kafka.Message, len(batch))
+		for i, queued := range batch {
+			messages[i] = queued.message
+		}
+
+		err := p.write(context.Background(), messages...)
+		for _, queued := range batch {
+			p.delivered(queued.ctx, queued.message, err)
+		}
+	}
+}
+
+// delivered records the outcome of publishing message and reports it to the
+// delivery callback of ctx.
+func (p *Producer) delivered(ctx context.Context, message kafka.Message, err error) {
+	fields := logrus.Fields{
+		"topic": message.Topic,
+		"key":   string(message.Key),
 	}
 
-	err = p.writer.WriteMessages(ctx, message)
 	if err != nil {
-		p.logger.WithError(err).WithFields(logrus.Fields{
-			"topic": topic,
-			"key":   key,
-		}).Error("failed to publish message")
-		return err
+		metrics.KafkaEventsDropped.WithLabelValues(message.Topic).Inc()
+		p.logger.WithError(err).WithFields(fields).Error("failed to publish message")
+	} else {
+		p.logger.WithFields(fields).Debug("message published successfully")
 	}
 
-	p.logger.WithFields(logger.Fields{
-		"topic": topic,
-		"key":   key,
-	}).Debug("message published successfully")
+	messaging.NotifyDelivery(ctx, message.Topic, string(message.Key), err)
+}
+
+func (p *Producer) write(ctx context.Context, messages ...kafka.Message) error {
+	delay := p.retry.Delay
+
+	for attempt := 1; ; attempt++ {
+		err := p.writer.WriteMessages(ctx, messages...)
+		if err == nil {
+			return nil
+		}
+		if attempt >= p.retry.Attempts || ctx.Err() != nil {
+			return err
+		}
+
+		p.logger.WithError(err).WithFields(logrus.Fields{
+			"messages": len(messages),
+			"attempt":  attempt,
+		}).Warnf("failed to publish message, retrying in %s", delay)
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(delay):
+		}
 
-	return nil
+		delay *= 2
+		if p.retry.MaxDelay > 0 && delay > p.retry.MaxDelay {
+			delay = p.retry.MaxDelay
+		}
+	}
+}
+
+// Ping checks that at least one of the brokers accepts connections.
+func (p *Producer) Ping(ctx context.Context) error {
+	var err error
+	for _, broker := range p.brokers {
+		conn, dialErr := p.dialer.DialContext(ctx, "tcp", broker)
+		if dialErr == nil {
+			return conn.Close()
+		}
+		err = dialErr
+	}
+	return err
 }
 
+// Close writes the queued messages, then closes the writer.
 func (p *Producer) Close() error {
+	p.mu.Lock()
+	alreadyClosed := p.closed
+	p.closed = true
+	p.mu.Unlock()
+
+	if p.queue != nil && !alreadyClosed {
+		close(p.queue)
+		<-p.done
+	}
+
 	return p.writer.Close()
 }
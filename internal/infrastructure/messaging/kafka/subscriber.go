@@ -0,0 +1,57 @@
+package kafka
+
+import (
+	"context"
+	"sync"
+
+	"github.com/vagonaizer/authenitfication-service/internal/config"
+	"github.com/vagonaizer/authenitfication-service/internal/infrastructure/messaging"
+	"github.com/vagonaizer/authenitfication-service/pkg/logger"
+)
+
+// Subscriber runs a Consumer per consumed topic, all in the configured
+// consumer group so replicas share the partitions.
+type Subscriber struct {
+	cfg         *config.KafkaConfig
+	deadLetters *Producer
+	logger      *logger.Logger
+
+	mu        sync.Mutex
+	consumers []*Consumer
+}
+
+var _ messaging.Subscriber = (*Subscriber)(nil)
+
+func NewSubscriber(cfg *config.KafkaConfig, deadLetters *Producer, logger *logger.Logger) *Subscriber {
+	return &Subscriber{
+		cfg:         cfg,
+		deadLetters: deadLetters,
+		logger:      logger,
+	}
+}
+
+func (s *Subscriber) Consume(ctx context.Context, topic string, handler messaging.MessageHandler) error {
+	// Потребители подключаются с теми же SASL/TLS, что и продюсер
+	consumer := NewConsumer(s.cfg, topic, s.deadLetters.dialer, s.deadLetters, s.logger)
+
+	s.mu.Lock()
+	s.consumers = append(s.consumers, consumer)
+	s.mu.Unlock()
+
+	return consumer.Consume(ctx, handler)
+}
+
+// Close leaves the consumer group; Consume must have returned.
+func (s *Subscriber) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var firstErr error
+	for _, consumer := range s.consumers {
+		if err := consumer.Close(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	s.consumers = nil
+	return firstErr
+}
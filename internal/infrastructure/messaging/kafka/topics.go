@@ -0,0 +1,145 @@
+package kafka
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+
+	"github.com/segmentio/kafka-go"
+	"github.com/vagonaizer/authenitfication-service/internal/config"
+	"github.com/vagonaizer/authenitfication-service/internal/infrastructure/messaging"
+	"github.com/vagonaizer/authenitfication-service/pkg/logger"
+)
+
+// Topics lists every topic the service publishes to or consumes from. A new
+// topic constant belongs here too, or EnsureTopics will not create it.
+var Topics = []string{
+	TopicUserRegistered, TopicUserLoggedIn, TopicUserLoggedOut, TopicPasswordChanged,
+	TopicUserActivated, TopicUserDeactivated, TopicUserDeleted,
+	TopicRoleAssigned, TopicRoleRemoved, TopicRoleExpired, TopicRoleBulkAssigned, TopicRoleBulkRemoved,
+	TopicRoleCreated, TopicRoleUpdated, TopicRoleDeleted,
+	TopicUserCreatedByAdmin, TopicUserPurged, TopicUserAvatarUpdated, TopicUserVerified,
+	TopicVerificationResent, TopicUserBanned, TopicUserUnbanned, TopicUserMerged, TopicUserPhoneVerified,
+	TopicUserDeletionScheduled, TopicUserDeletionCancelled, TopicUserPasswordChangeRequired,
+	TopicLoginFailed, TopicSessionRevoked, TopicTokenRefreshed,
+	TopicOrganizationCreated, TopicOrganizationDeleted,
+	TopicOrganizationMemberAdded, TopicOrganizationMemberUpdated, TopicOrganizationMemberRemoved,
+	TopicOrganizationInvitationCreated, TopicOrganizationInvitationAccepted,
+	TopicOrganizationInvitationDeclined, TopicOrganizationInvitationRevoked,
+	TopicOrganizationQuotaUpdated, TopicOrganizationQuotaExceeded,
+	TopicServiceAccountCreated, TopicServiceAccountDeleted, TopicServiceAccountKeyCreated, TopicServiceAccountKeyRevoked,
+	TopicGroupCreated, TopicGroupDeleted, TopicGroupMemberAdded, TopicGroupMemberRemoved,
+	TopicNotificationsEmail, TopicNotificationsSMS,
+	TopicModerationBanRequested, TopicUserDeletionRequested,
+}
+
+// StreamedTopics are the topics whose events are also streamed live to the
+// users they concern: sessions created and ended, changes to the security of
+// the account, and role changes.
+var StreamedTopics = []string{
+	TopicUserLoggedIn, TopicUserLoggedOut, TopicSessionRevoked, TopicLoginFailed,
+	TopicPasswordChanged, TopicUserPasswordChangeRequired, TopicUserBanned, TopicUserDeactivated,
+	TopicRoleAssigned, TopicRoleRemoved, TopicRoleExpired, TopicRoleBulkAssigned, TopicRoleBulkRemoved,
+}
+
+// InboundTopics are the topics consumed from; they have dead-letter topics.
+var InboundTopics = []string{TopicModerationBanRequested, TopicUserDeletionRequested, TopicUserRegistered}
+
+// RequiredTopics returns Topics together with the dead-letter topics of the
+// inbound topics.
+func RequiredTopics() []string {
+	topics := append([]string(nil), Topics...)
+	for _, topic := range InboundTopics {
+		topics = append(topics, messaging.DeadLetterTopic(topic))
+	}
+	return topics
+}
+
+// EnsureTopics creates those of topics that do not exist yet, with the
+// partitions, replication factor and retention of cfg. Existing topics are
+// left as they are, even when their settings differ.
+func EnsureTopics(ctx context.Context, cfg *config.KafkaConfig, topics []string, log *logger.Logger) error {
+	dialer, err := newDialer(cfg)
+	if err != nil {
+		return err
+	}
+
+	conn, err := dialAny(ctx, dialer, cfg.Brokers)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	partitions, err := conn.ReadPartitions()
+	if err != nil {
+		return fmt.Errorf("failed to list topics: %w", err)
+	}
+	existing := make(map[string]bool, len(partitions))
+	for _, partition := range partitions {
+		existing[partition.Topic] = true
+	}
+
+	var missing []kafka.TopicConfig
+	var names []string
+	for _, topic := range topics {
+		if existing[topic] {
+			continue
+		}
+		topicConfig := kafka.TopicConfig{
+			Topic:             topic,
+			NumPartitions:     cfg.TopicPartitions,
+			ReplicationFactor: cfg.TopicReplicationFactor,
+		}
+		// Без явного retention действует настройка брокера
+		if cfg.TopicRetention > 0 {
+			topicConfig.ConfigEntries = []kafka.ConfigEntry{{
+				ConfigName:  "retention.ms",
+				ConfigValue: strconv.FormatInt(cfg.TopicRetention.Milliseconds(), 10),
+			}}
+		}
+		missing = append(missing, topicConfig)
+		names = append(names, topic)
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+
+	// Топики создаются только через контроллер кластера
+	controller, err := conn.Controller()
+	if err != nil {
+		return fmt.Errorf("failed to find controller: %w", err)
+	}
+	controllerConn, err := dialer.DialContext(ctx, "tcp", net.JoinHostPort(controller.Host, strconv.Itoa(controller.Port)))
+	if err != nil {
+		return fmt.Errorf("failed to connect to controller: %w", err)
+	}
+	defer controllerConn.Close()
+	setDeadline(ctx, controllerConn)
+
+	if err := controllerConn.CreateTopics(missing...); err != nil {
+		return fmt.Errorf("failed to create topics: %w", err)
+	}
+
+	log.WithField("topics", names).Info("created kafka topics")
+	return nil
+}
+
+func dialAny(ctx context.Context, dialer *kafka.Dialer, brokers []string) (*kafka.Conn, error) {
+	var err error
+	for _, broker := range brokers {
+		conn, dialErr := dialer.DialContext(ctx, "tcp", broker)
+		if dialErr == nil {
+			setDeadline(ctx, conn)
+			return conn, nil
+		}
+		err = dialErr
+	}
+	return nil, fmt.Errorf("failed to connect to kafka: %w", err)
+}
+
+func setDeadline(ctx context.Context, conn *kafka.Conn) {
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+}
@@ -0,0 +1,151 @@
+package kafka
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// Every event is a flat JSON object whose BaseEvent fields form the envelope:
+// id, type, timestamp and the schema version of the payload. The version of
+// an event type is bumped when its payload changes in a way old readers
+// cannot handle, and an upcaster from the previous version is registered so
+// that Decode keeps accepting events written before the change.
+
+// defaultSchemaVersion is the version of event types never bumped, and the
+// version assumed for events that carry none.
+const defaultSchemaVersion = "1.0"
+
+// schemaVersions holds the current version of every event type whose payload
+// has changed since its first version.
+var schemaVersions = map[string]string{
+	TopicModerationBanRequested: "2.0",
+}
+
+// ErrUnsupportedSchemaVersion is returned by Decode for events that cannot be
+// upcast, typically written by a producer newer than this service. Such events
+// are worth keeping for a replay after an upgrade rather than dropping.
+var ErrUnsupportedSchemaVersion = errors.New("unsupported event schema version")
+
+// Upcaster rewrites the fields of an event from one schema version into the
+// next. It must not touch the envelope fields; Decode updates the version.
+type Upcaster func(fields map[string]json.RawMessage) error
+
+// RenameField returns the upcaster for a version that renamed a field from
+// from to to.
+func RenameField(from, to string) Upcaster {
+	return func(fields map[string]json.RawMessage) error {
+		value, ok := fields[from]
+		if !ok {
+			return nil
+		}
+		if _, ok := fields[to]; ok {
+			return fmt.Errorf("both %s and %s are set", from, to)
+		}
+
+		fields[to] = value
+		delete(fields, from)
+		return nil
+	}
+}
+
+type upcasterKey struct {
+	eventType string
+	version   string
+}
+
+type upcastStep struct {
+	to     string
+	upcast Upcaster
+}
+
+var upcasters = map[upcasterKey]upcastStep{}
+
+// RegisterUpcaster registers the migration of eventType from version from to
+// version to. It is meant to be called from init functions next to the event
+// definitions, together with an update of schemaVersions.
+func RegisterUpcaster(eventType, from, to string, upcast Upcaster) {
+	upcasters[upcasterKey{eventType: eventType, version: from}] = upcastStep{to: to, upcast: upcast}
+}
+
+// SchemaVersion returns the version events of the given type are written
+// with.
+func SchemaVersion(eventType string) string {
+	if version, ok := schemaVersions[eventType]; ok {
+		return version
+	}
+	return defaultSchemaVersion
+}
+
+// Decode unmarshals an event into target after upcasting it to the current
+// schema version of its type. Events of a version that cannot be upcast,
+// including ones newer than this service knows, are rejected.
+func Decode(data []byte, target interface{}) error {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+
+	var envelope BaseEvent
+	if err := json.Unmarshal(data, &envelope); err != nil {
+		return err
+	}
+
+	if err := upcast(envelope.Type, envelope.Version, fields); err != nil {
+		return err
+	}
+
+	upcasted, err := json.Marshal(fields)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(upcasted, target)
+}
+
+func upcast(eventType, version string, fields map[string]json.RawMessage) error {
+	if version == "" {
+		version = defaultSchemaVersion
+	}
+	current := SchemaVersion(eventType)
+
+	// Each step moves to a different version, so a chain longer than the
+	// registry has entries must loop.
+	for steps := 0; version != current; steps++ {
+		step, ok := upcasters[upcasterKey{eventType: eventType, version: version}]
+		if !ok || steps > len(upcasters) {
+			return fmt.Errorf("%w: %s of event %s (current is %s)", ErrUnsupportedSchemaVersion, version, eventType, current)
+		}
+
+		if err := step.upcast(fields); err != nil {
+			return fmt.Errorf("failed to upcast event %s from version %s: %w", eventType, version, err)
+		}
+
+		version = step.to
+		encoded, err := json.Marshal(version)
+		if err != nil {
+			return err
+		}
+		fields["version"] = encoded
+	}
+
+	return nil
+}
+
+// CheckUpcasters verifies that every registered version of every event type
+// upcasts to the current version of that type. It is checked on startup when
+// the consumers are enabled, so a broken chain fails the deployment rather
+// than the first old message.
+func CheckUpcasters() error {
+	for key := range upcasters {
+		version := key.version
+		for steps := 0; version != SchemaVersion(key.eventType); steps++ {
+			step, ok := upcasters[upcasterKey{eventType: key.eventType, version: version}]
+			if !ok || steps > len(upcasters) {
+				return fmt.Errorf("event %s: version %s does not upcast to %s", key.eventType, key.version, SchemaVersion(key.eventType))
+			}
+			version = step.to
+		}
+	}
+	return nil
+}
@@ -0,0 +1,64 @@
+package kafka
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestDecodeUpcastsModerationBanV1(t *testing.T) {
+	userID := uuid.New()
+
+	payloads := map[string]string{
+		"version 1.0":     `{"type":"moderation.ban_requested","version":"1.0","target_user_id":"` + userID.String() + `","reason":"spam"}`,
+		"without version": `{"type":"moderation.ban_requested","target_user_id":"` + userID.String() + `","reason":"spam"}`,
+		"version 2.0":     `{"type":"moderation.ban_requested","version":"2.0","user_id":"` + userID.String() + `","reason":"spam"}`,
+	}
+
+	for name, payload := range payloads {
+		t.Run(name, func(t *testing.T) {
+			var event ModerationBanRequestedEvent
+			if err := Decode([]byte(payload), &event); err != nil {
+				t.Fatalf("Decode: %v", err)
+			}
+			if event.UserID != userID || event.Reason != "spam" {
+				t.Errorf("got user %s and reason %q, want %s and %q", event.UserID, event.Reason, userID, "spam")
+			}
+			if event.Version != SchemaVersion(TopicModerationBanRequested) {
+				t.Errorf("got version %s, want %s", event.Version, SchemaVersion(TopicModerationBanRequested))
+			}
+		})
+	}
+}
+
+func TestDecodeRejectsUnknownVersions(t *testing.T) {
+	payloads := map[string]string{
+		"future version":   `{"type":"moderation.ban_requested","version":"3.0","user_id":"` + uuid.NewString() + `"}`,
+		"unversioned type": `{"type":"user.deletion_requested","version":"2.0","user_id":"` + uuid.NewString() + `"}`,
+	}
+
+	for name, payload := range payloads {
+		t.Run(name, func(t *testing.T) {
+			var event ModerationBanRequestedEvent
+			if err := Decode([]byte(payload), &event); !errors.Is(err, ErrUnsupportedSchemaVersion) {
+				t.Fatalf("got %v, want %v", err, ErrUnsupportedSchemaVersion)
+			}
+		})
+	}
+}
+
+func TestDecodeRejectsConflictingRename(t *testing.T) {
+	payload := `{"type":"moderation.ban_requested","version":"1.0","target_user_id":"` + uuid.NewString() + `","user_id":"` + uuid.NewString() + `"}`
+
+	var event ModerationBanRequestedEvent
+	if err := Decode([]byte(payload), &event); err == nil {
+		t.Fatal("Decode accepted a 1.0 event setting both target_user_id and user_id")
+	}
+}
+
+func TestCheckUpcasters(t *testing.T) {
+	if err := CheckUpcasters(); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,154 @@
+package nats
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+	"github.com/sirupsen/logrus"
+	"github.com/vagonaizer/authenitfication-service/internal/config"
+	"github.com/vagonaizer/authenitfication-service/internal/infrastructure/messaging"
+	"github.com/vagonaizer/authenitfication-service/internal/infrastructure/metrics"
+	"github.com/vagonaizer/authenitfication-service/pkg/logger"
+)
+
+const driverName = "nats"
+
+// Publisher publishes events to NATS JetStream, the topic being the subject.
+// The connection is established in the background and re-established when
+// lost, so a NATS server that is down only fails the publishes meanwhile.
+type Publisher struct {
+	conn   *nats.Conn
+	js     jetstream.JetStream
+	cfg    *config.MessagingConfig
+	logger *logger.Logger
+	retry  messaging.Retry
+
+	streamMu    sync.Mutex
+	streamReady bool
+}
+
+var _ messaging.Publisher = (*Publisher)(nil)
+
+func NewPublisher(cfg *config.MessagingConfig, retry messaging.Retry, logger *logger.Logger) (*Publisher, error) {
+	conn, err := nats.Connect(cfg.NATSURL,
+		nats.Name("auth-service"),
+		nats.RetryOnFailedConnect(true),
+		nats.MaxReconnects(-1),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	js, err := jetstream.New(conn)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	return &Publisher{
+		conn:   conn,
+		js:     js,
+		cfg:    cfg,
+		logger: logger,
+		retry:  retry,
+	}, nil
+}
+
+// PublishMessage publishes value as JSON once the stream acknowledged it,
+// retrying like the Kafka producer. NATS has no partitions, so key is only
+// logged.
+func (p *Publisher) PublishMessage(ctx context.Context, topic string, key string, value interface{}) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		p.logger.WithError(err).Error("failed to marshal message")
+		return err
+	}
+
+	err = p.retry.Do(ctx, func() error {
+		if err := p.ensureStream(ctx); err != nil {
+			return err
+		}
+		_, err := p.js.Publish(ctx, topic, data)
+		return err
+	})
+	if err != nil {
+		metrics.BrokerEventsDropped.WithLabelValues(driverName, topic).Inc()
+		p.logger.WithError(err).WithFields(logrus.Fields{
+			"topic": topic,
+			"key":   key,
+		}).Error("failed to publish message")
+		return err
+	}
+
+	p.logger.WithFields(logger.Fields{
+		"topic": topic,
+		"key":   key,
+	}).Debug("message published successfully")
+
+	return nil
+}
+
+// publishDeadLetter moves a message its handler gave up on to the subject's
+// dead-letter subject, recording why in the headers.
+func (p *Publisher) publishDeadLetter(ctx context.Context, original jetstream.Msg, handleErr error, attempts int) error {
+	message := nats.NewMsg(messaging.DeadLetterTopic(original.Subject()))
+	message.Data = original.Data()
+	message.Header.Set(messaging.HeaderOriginalTopic, original.Subject())
+	if meta, err := original.Metadata(); err == nil {
+		message.Header.Set(messaging.HeaderOriginalOffset, strconv.FormatUint(meta.Sequence.Stream, 10))
+	}
+	message.Header.Set(messaging.HeaderError, handleErr.Error())
+	message.Header.Set(messaging.HeaderAttempts, strconv.Itoa(attempts))
+	message.Header.Set(messaging.HeaderFailedAt, time.Now().UTC().Format(time.RFC3339))
+
+	if err := p.ensureStream(ctx); err != nil {
+		return err
+	}
+	_, err := p.js.PublishMsg(ctx, message)
+	return err
+}
+
+// ensureStream creates or updates the configured stream the first time a
+// message is published, since the server may not be reachable on start.
+func (p *Publisher) ensureStream(ctx context.Context) error {
+	if p.cfg.NATSStream == "" {
+		return nil
+	}
+
+	p.streamMu.Lock()
+	defer p.streamMu.Unlock()
+
+	if p.streamReady {
+		return nil
+	}
+
+	_, err := p.js.CreateOrUpdateStream(ctx, jetstream.StreamConfig{
+		Name:     p.cfg.NATSStream,
+		Subjects: p.cfg.NATSSubjects,
+		Storage:  jetstream.FileStorage,
+	})
+	if err != nil {
+		return err
+	}
+
+	p.streamReady = true
+	return nil
+}
+
+// Ping checks that the server is connected and answers.
+func (p *Publisher) Ping(ctx context.Context) error {
+	if err := p.conn.FlushWithContext(ctx); err != nil {
+		return err
+	}
+	return p.ensureStream(ctx)
+}
+
+// Close waits for pending publishes and closes the connection.
+func (p *Publisher) Close() error {
+	return p.conn.Drain()
+}
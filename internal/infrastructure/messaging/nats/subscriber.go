@@ -0,0 +1,170 @@
+package nats
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/nats-io/nats.go/jetstream"
+	"github.com/sirupsen/logrus"
+	"github.com/vagonaizer/authenitfication-service/internal/infrastructure/messaging"
+	"github.com/vagonaizer/authenitfication-service/internal/infrastructure/metrics"
+	"github.com/vagonaizer/authenitfication-service/pkg/logger"
+)
+
+// ackGrace is added to the longest retry delay to get the ack wait, so
+// JetStream does not redeliver a message that is still being retried.
+const ackGrace = 30 * time.Second
+
+// Subscriber consumes subjects through durable pull consumers named after the
+// consumer group, so replicas share the messages. It uses the connection of
+// the publisher, which also publishes the dead letters.
+type Subscriber struct {
+	publisher *Publisher
+	group     string
+	logger    *logger.Logger
+}
+
+var _ messaging.Subscriber = (*Subscriber)(nil)
+
+func NewSubscriber(publisher *Publisher, group string, logger *logger.Logger) *Subscriber {
+	return &Subscriber{
+		publisher: publisher,
+		group:     group,
+		logger:    logger,
+	}
+}
+
+// Consume handles messages of topic until ctx is cancelled. Messages are
+// acknowledged once handled or dead-lettered; one waiting for a retry when
+// ctx is cancelled is handed back for redelivery.
+func (s *Subscriber) Consume(ctx context.Context, topic string, handler messaging.MessageHandler) error {
+	consumer, err := s.consumer(ctx, topic)
+	if err != nil {
+		return err
+	}
+
+	messages, err := consumer.Messages()
+	if err != nil {
+		return err
+	}
+	defer messages.Stop()
+	stop := context.AfterFunc(ctx, messages.Stop)
+	defer stop()
+
+	retry := s.publisher.retry
+	for {
+		message, err := messages.Next()
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			if errors.Is(err, jetstream.ErrMsgIteratorClosed) {
+				return err
+			}
+			s.logger.WithError(err).WithField("topic", topic).Error("failed to read message")
+			continue
+		}
+
+		fields := logrus.Fields{"topic": topic}
+		if meta, err := message.Metadata(); err == nil {
+			fields["sequence"] = meta.Sequence.Stream
+		}
+
+		// Обработку начатого сообщения доводим до конца даже при остановке
+		handleCtx := context.WithoutCancel(ctx)
+
+		err = retry.Do(ctx, func() error {
+			// Продлеваем ack wait, чтобы сообщение не ушло другому подписчику
+			_ = message.InProgress()
+			return handler(handleCtx, message.Data())
+		})
+		if err != nil && ctx.Err() != nil {
+			_ = message.Nak()
+			return ctx.Err()
+		}
+
+		if err != nil {
+			s.logger.WithError(err).WithFields(fields).Error("failed to handle message, moving it to the dead-letter topic")
+			if err := s.deadLetter(ctx, message, err); err != nil {
+				_ = message.Nak()
+				return err
+			}
+			metrics.BrokerDeadLetters.WithLabelValues(driverName, topic).Inc()
+		} else {
+			s.logger.WithFields(fields).Debug("message processed successfully")
+		}
+
+		if err := message.Ack(); err != nil {
+			s.logger.WithError(err).WithFields(fields).Error("failed to ack message")
+		}
+	}
+}
+
+// consumer creates or updates the durable consumer of topic, waiting for the
+// server and the stream capturing topic to become available.
+func (s *Subscriber) consumer(ctx context.Context, topic string) (jetstream.Consumer, error) {
+	js := s.publisher.js
+	retry := s.publisher.retry
+
+	for {
+		consumer, err := func() (jetstream.Consumer, error) {
+			if err := s.publisher.ensureStream(ctx); err != nil {
+				return nil, err
+			}
+			stream, err := js.StreamNameBySubject(ctx, topic)
+			if err != nil {
+				return nil, err
+			}
+			return js.CreateOrUpdateConsumer(ctx, stream, jetstream.ConsumerConfig{
+				Durable:       durableName(s.group, topic),
+				FilterSubject: topic,
+				AckPolicy:     jetstream.AckExplicitPolicy,
+				AckWait:       max(retry.MaxDelay, retry.Delay) + ackGrace,
+			})
+		}()
+		if err == nil {
+			return consumer, nil
+		}
+
+		s.logger.WithError(err).WithField("topic", topic).Warn("failed to subscribe, retrying")
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(max(retry.MaxDelay, retry.Delay)):
+		}
+	}
+}
+
+// deadLetter publishes the message to the dead-letter subject, retrying until
+// it succeeds or ctx is cancelled.
+func (s *Subscriber) deadLetter(ctx context.Context, message jetstream.Msg, handleErr error) error {
+	retry := s.publisher.retry
+
+	for {
+		err := s.publisher.publishDeadLetter(context.WithoutCancel(ctx), message, handleErr, retry.Attempts)
+		if err == nil {
+			return nil
+		}
+		s.logger.WithError(err).WithField("topic", message.Subject()).Error("failed to publish dead letter")
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(max(retry.MaxDelay, retry.Delay)):
+		}
+	}
+}
+
+// Close is a no-op: the consumers are durable and stop with Consume, and the
+// connection belongs to the publisher.
+func (s *Subscriber) Close() error {
+	return nil
+}
+
+// durableName derives the consumer name, which may not contain dots.
+func durableName(group, topic string) string {
+	return strings.NewReplacer(".", "_", "*", "_", ">", "_").Replace(group + "_" + topic)
+}
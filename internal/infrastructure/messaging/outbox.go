@@ -0,0 +1,281 @@
+package messaging
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/vagonaizer/authenitfication-service/internal/domain/entities"
+	"github.com/vagonaizer/authenitfication-service/internal/domain/repositories"
+	"github.com/vagonaizer/authenitfication-service/internal/infrastructure/metrics"
+	"github.com/vagonaizer/authenitfication-service/pkg/logger"
+	"github.com/vagonaizer/authenitfication-service/pkg/tracing"
+)
+
+// OutboxPublisher writes events to the outbox instead of the broker, leaving
+// their publishing to OutboxRelay. Publishing thus only waits for the
+// database and survives broker outages. Ping and Close are those of the
+// broker publisher it wraps.
+type OutboxPublisher struct {
+	Publisher
+	outbox repositories.OutboxRepository
+	logger *logger.Logger
+}
+
+func NewOutboxPublisher(next Publisher, outbox repositories.OutboxRepository, logger *logger.Logger) *OutboxPublisher {
+	return &OutboxPublisher{
+		Publisher: next,
+		outbox:    outbox,
+		logger:    logger,
+	}
+}
+
+func (p *OutboxPublisher) PublishMessage(ctx context.Context, topic string, key string, value interface{}) error {
+	fields := logrus.Fields{
+		"topic": topic,
+		"key":   key,
+	}
+
+	payload, err := json.Marshal(value)
+	if err != nil {
+		p.logger.WithError(err).WithFields(fields).Error("failed to marshal message")
+		return err
+	}
+
+	message := &entities.OutboxMessage{
+		Topic:   topic,
+		Key:     key,
+		Payload: payload,
+		Headers: tracing.Headers(ctx),
+	}
+	if err := p.outbox.Create(ctx, message); err != nil {
+		p.logger.WithError(err).WithFields(fields).Error("failed to write event to outbox")
+		return err
+	}
+
+	return nil
+}
+
+// LeaderLock is held by the one relay publishing the outbox.
+type LeaderLock interface {
+	// TryAcquire takes the lock unless another relay holds it.
+	TryAcquire(ctx context.Context) (bool, error)
+	// Check fails once the lock was lost.
+	Check(ctx context.Context) error
+	Release()
+}
+
+// FencedLock is a LeaderLock that gives each acquisition a fencing token
+// larger than all earlier ones.
+type FencedLock interface {
+	LeaderLock
+	Token() int64
+}
+
+// OutboxRelay publishes the events of the outbox to the broker in batches.
+// Only the relay holding lock publishes, which keeps events in order; the
+// others stand by to take over. With a FencedLock, each batch first records
+// the token of the lock in the outbox, and deletes the batch only while no
+// larger token was recorded, so that a relay that lost the lock without
+// noticing, say while paused, stops instead of publishing behind the new
+// leader. Events are published at least once: a batch
+// that failed in part, or was published but not yet deleted when the relay
+// stopped, is published again.
+type OutboxRelay struct {
+	outbox    repositories.OutboxRepository
+	publisher Publisher
+	lock      LeaderLock
+	logger    *logger.Logger
+
+	batchSize    int
+	pollInterval time.Duration
+	leaderRetry  time.Duration
+}
+
+func NewOutboxRelay(
+	outbox repositories.OutboxRepository,
+	publisher Publisher,
+	lock LeaderLock,
+	logger *logger.Logger,
+	batchSize int,
+	pollInterval time.Duration,
+	leaderRetry time.Duration,
+) *OutboxRelay {
+	return &OutboxRelay{
+		outbox:       outbox,
+		publisher:    publisher,
+		lock:         lock,
+		logger:       logger,
+		batchSize:    max(batchSize, 1),
+		pollInterval: pollInterval,
+		leaderRetry:  leaderRetry,
+	}
+}
+
+// Run relays the outbox whenever this relay is the leader, until ctx is
+// cancelled.
+func (r *OutboxRelay) Run(ctx context.Context) {
+	for {
+		acquired, err := r.lock.TryAcquire(ctx)
+		if err != nil && ctx.Err() == nil {
+			r.logger.WithError(err).Warn("failed to take outbox relay leadership")
+		}
+
+		if acquired {
+			r.logger.Info("outbox relay became leader")
+			metrics.OutboxRelayLeader.Set(1)
+			err := r.lead(ctx)
+			metrics.OutboxRelayLeader.Set(0)
+			r.lock.Release()
+
+			if ctx.Err() != nil {
+				return
+			}
+			r.logger.WithError(err).Warn("outbox relay lost leadership")
+		}
+
+		if !wait(ctx, r.leaderRetry) {
+			return
+		}
+	}
+}
+
+// lead publishes batches while the lock is held. A full batch is followed by
+// the next one at once; otherwise the relay polls after pollInterval.
+func (r *OutboxRelay) lead(ctx context.Context) error {
+	for {
+		if err := r.lock.Check(ctx); err != nil {
+			return err
+		}
+		if err := r.fence(ctx); err != nil {
+			return err
+		}
+
+		published, err := r.relayBatch(ctx)
+		if errors.Is(err, errFencedOut) {
+			return err
+		}
+		if err != nil && ctx.Err() == nil {
+			r.logger.WithError(err).Error("failed to relay outbox batch")
+		}
+		r.reportLag(ctx)
+
+		if err != nil || published < r.batchSize {
+			if !wait(ctx, r.pollInterval) {
+				return ctx.Err()
+			}
+		} else if ctx.Err() != nil {
+			return ctx.Err()
+		}
+	}
+}
+
+// fence fails once a leader with a larger fencing token has published.
+func (r *OutboxRelay) fence(ctx context.Context) error {
+	lock, ok := r.lock.(FencedLock)
+	if !ok {
+		return nil
+	}
+
+	current, err := r.outbox.Fence(ctx, lock.Token())
+	if err != nil {
+		return err
+	}
+	if !current {
+		return fencedOut(lock.Token())
+	}
+	return nil
+}
+
+// delete removes a published batch. With a FencedLock the delete itself is
+// conditional on the token, since a newer leader may have fenced the outbox
+// after fence passed; the batch then stays for that leader to publish.
+func (r *OutboxRelay) delete(ctx context.Context, ids []int64) error {
+	lock, ok := r.lock.(FencedLock)
+	if !ok {
+		return r.outbox.Delete(ctx, ids)
+	}
+
+	current, err := r.outbox.DeleteFenced(ctx, ids, lock.Token())
+	if err != nil {
+		return err
+	}
+	if !current {
+		return fencedOut(lock.Token())
+	}
+	return nil
+}
+
+// errFencedOut means a leader with a larger fencing token has published.
+var errFencedOut = errors.New("outbox fence is ahead of the fencing token")
+
+func fencedOut(token int64) error {
+	// Также бывает, если Redis потерял счётчик токенов: тогда outbox_fence надо сбросить
+	return fmt.Errorf("%w %d: another relay leads, or the Redis token counter was lost and outbox_fence must be reset", errFencedOut, token)
+}
+
+func (r *OutboxRelay) relayBatch(ctx context.Context) (int, error) {
+	pending, err := r.outbox.ListPending(ctx, r.batchSize)
+	if err != nil || len(pending) == 0 {
+		return 0, err
+	}
+
+	messages := make([]Message, len(pending))
+	ids := make([]int64, len(pending))
+	for i, message := range pending {
+		messages[i] = Message{
+			Topic:   message.Topic,
+			Key:     message.Key,
+			Value:   message.Payload,
+			Headers: message.Headers,
+		}
+		ids[i] = message.ID
+	}
+
+	// Начатый батч доводим до удаления, иначе при остановке он уйдёт повторно
+	ctx = context.WithoutCancel(ctx)
+
+	if err := PublishBatch(ctx, r.publisher, messages); err != nil {
+		return 0, err
+	}
+	if err := r.delete(ctx, ids); err != nil {
+		return 0, err
+	}
+
+	for _, message := range messages {
+		metrics.OutboxPublished.WithLabelValues(message.Topic).Inc()
+	}
+	r.logger.WithField("events", len(messages)).Debug("relayed outbox batch")
+
+	return len(messages), nil
+}
+
+func (r *OutboxRelay) reportLag(ctx context.Context) {
+	stats, err := r.outbox.Stats(ctx)
+	if err != nil {
+		if ctx.Err() == nil {
+			r.logger.WithError(err).Warn("failed to read outbox stats")
+		}
+		return
+	}
+
+	metrics.OutboxPending.Set(float64(stats.Pending))
+	if stats.OldestAt != nil {
+		metrics.OutboxLagSeconds.Set(time.Since(*stats.OldestAt).Seconds())
+	} else {
+		metrics.OutboxLagSeconds.Set(0)
+	}
+}
+
+// wait sleeps for d and reports whether ctx is still live.
+func wait(ctx context.Context, d time.Duration) bool {
+	select {
+	case <-ctx.Done():
+		return false
+	case <-time.After(d):
+		return true
+	}
+}
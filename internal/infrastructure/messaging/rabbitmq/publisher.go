@@ -0,0 +1,186 @@
+package rabbitmq
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"sync"
+	"time"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+	"github.com/sirupsen/logrus"
+	"github.com/vagonaizer/authenitfication-service/internal/config"
+	"github.com/vagonaizer/authenitfication-service/internal/infrastructure/messaging"
+	"github.com/vagonaizer/authenitfication-service/internal/infrastructure/metrics"
+	"github.com/vagonaizer/authenitfication-service/pkg/logger"
+)
+
+const driverName = "rabbitmq"
+
+var errNotConfirmed = errors.New("message was not confirmed by the broker")
+
+// Publisher publishes events to the configured topic exchange, the topic
+// being the routing key. The connection is opened on first use and reopened
+// after it was lost. Events are persistent and a publish succeeds once the
+// broker confirmed it; routing keys without a bound queue drop the event.
+type Publisher struct {
+	cfg    *config.MessagingConfig
+	logger *logger.Logger
+	retry  messaging.Retry
+
+	mu      sync.Mutex
+	conn    *amqp.Connection
+	channel *amqp.Channel
+}
+
+var _ messaging.Publisher = (*Publisher)(nil)
+
+func NewPublisher(cfg *config.MessagingConfig, retry messaging.Retry, logger *logger.Logger) *Publisher {
+	return &Publisher{
+		cfg:    cfg,
+		logger: logger,
+		retry:  retry,
+	}
+}
+
+// PublishMessage publishes value as JSON, retrying like the Kafka producer.
+// key is sent as the message id.
+func (p *Publisher) PublishMessage(ctx context.Context, topic string, key string, value interface{}) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		p.logger.WithError(err).Error("failed to marshal message")
+		return err
+	}
+
+	message := amqp.Publishing{
+		ContentType:  "application/json",
+		DeliveryMode: amqp.Persistent,
+		MessageId:    key,
+		Timestamp:    time.Now(),
+		Body:         data,
+	}
+
+	err = p.retry.Do(ctx, func() error {
+		return p.publish(ctx, topic, message)
+	})
+	if err != nil {
+		metrics.BrokerEventsDropped.WithLabelValues(driverName, topic).Inc()
+		p.logger.WithError(err).WithFields(logrus.Fields{
+			"topic": topic,
+			"key":   key,
+		}).Error("failed to publish message")
+		return err
+	}
+
+	p.logger.WithFields(logger.Fields{
+		"topic": topic,
+		"key":   key,
+	}).Debug("message published successfully")
+
+	return nil
+}
+
+// publishDeadLetter moves a message its handler gave up on to the topic's
+// dead-letter routing key, recording why in the headers.
+func (p *Publisher) publishDeadLetter(ctx context.Context, topic string, original amqp.Delivery, handleErr error, attempts int) error {
+	return p.publish(ctx, messaging.DeadLetterTopic(topic), amqp.Publishing{
+		ContentType:  original.ContentType,
+		DeliveryMode: amqp.Persistent,
+		MessageId:    original.MessageId,
+		Timestamp:    time.Now(),
+		Body:         original.Body,
+		Headers: amqp.Table{
+			messaging.HeaderOriginalTopic: topic,
+			messaging.HeaderError:         handleErr.Error(),
+			messaging.HeaderAttempts:      strconv.Itoa(attempts),
+			messaging.HeaderFailedAt:      time.Now().UTC().Format(time.RFC3339),
+		},
+	})
+}
+
+func (p *Publisher) publish(ctx context.Context, routingKey string, message amqp.Publishing) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	channel, err := p.openChannel()
+	if err != nil {
+		return err
+	}
+
+	confirmation, err := channel.PublishWithDeferredConfirmWithContext(ctx, p.cfg.RabbitMQExchange, routingKey, false, false, message)
+	if err != nil {
+		return err
+	}
+
+	acked, err := confirmation.WaitContext(ctx)
+	if err != nil {
+		return err
+	}
+	if !acked {
+		return errNotConfirmed
+	}
+	return nil
+}
+
+// openChannel returns the publishing channel, reconnecting when needed. The
+// caller holds mu.
+func (p *Publisher) openChannel() (*amqp.Channel, error) {
+	if p.channel != nil && !p.channel.IsClosed() {
+		return p.channel, nil
+	}
+
+	if p.conn == nil || p.conn.IsClosed() {
+		conn, err := amqp.Dial(p.cfg.RabbitMQURL)
+		if err != nil {
+			return nil, err
+		}
+		p.conn = conn
+	}
+
+	channel, err := openExchange(p.conn, p.cfg.RabbitMQExchange)
+	if err != nil {
+		return nil, err
+	}
+	if err := channel.Confirm(false); err != nil {
+		channel.Close()
+		return nil, err
+	}
+
+	p.channel = channel
+	return channel, nil
+}
+
+// Ping checks that a channel to the broker can be opened.
+func (p *Publisher) Ping(ctx context.Context) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	_, err := p.openChannel()
+	return err
+}
+
+func (p *Publisher) Close() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.conn == nil || p.conn.IsClosed() {
+		return nil
+	}
+	return p.conn.Close()
+}
+
+// openExchange opens a channel and declares the durable topic exchange.
+func openExchange(conn *amqp.Connection, exchange string) (*amqp.Channel, error) {
+	channel, err := conn.Channel()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := channel.ExchangeDeclare(exchange, amqp.ExchangeTopic, true, false, false, false, nil); err != nil {
+		channel.Close()
+		return nil, err
+	}
+
+	return channel, nil
+}
@@ -0,0 +1,205 @@
+package rabbitmq
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+	"github.com/sirupsen/logrus"
+	"github.com/vagonaizer/authenitfication-service/internal/config"
+	"github.com/vagonaizer/authenitfication-service/internal/infrastructure/messaging"
+	"github.com/vagonaizer/authenitfication-service/internal/infrastructure/metrics"
+	"github.com/vagonaizer/authenitfication-service/pkg/logger"
+)
+
+var errChannelClosed = errors.New("channel closed by the broker")
+
+// Subscriber consumes topics through durable queues named after the consumer
+// group and bound to the exchange, so replicas share the messages. Each
+// topic's dead-letter routing key gets a queue of its own as well, since the
+// exchange drops messages no queue is bound for. It keeps a connection of its
+// own and publishes dead letters through the publisher.
+type Subscriber struct {
+	cfg       *config.MessagingConfig
+	publisher *Publisher
+	group     string
+	logger    *logger.Logger
+
+	mu   sync.Mutex
+	conn *amqp.Connection
+}
+
+var _ messaging.Subscriber = (*Subscriber)(nil)
+
+func NewSubscriber(cfg *config.MessagingConfig, publisher *Publisher, group string, logger *logger.Logger) *Subscriber {
+	return &Subscriber{
+		cfg:       cfg,
+		publisher: publisher,
+		group:     group,
+		logger:    logger,
+	}
+}
+
+// Consume handles messages of topic until ctx is cancelled, resubscribing
+// when the connection is lost. Messages are acknowledged once handled or
+// dead-lettered; one waiting for a retry when ctx is cancelled is requeued.
+func (s *Subscriber) Consume(ctx context.Context, topic string, handler messaging.MessageHandler) error {
+	retry := s.publisher.retry
+
+	for {
+		err := s.consume(ctx, topic, handler)
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		s.logger.WithError(err).WithField("topic", topic).Warn("rabbitmq subscription lost, resubscribing")
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(max(retry.MaxDelay, retry.Delay)):
+		}
+	}
+}
+
+func (s *Subscriber) consume(ctx context.Context, topic string, handler messaging.MessageHandler) error {
+	channel, err := s.subscribe(topic)
+	if err != nil {
+		return err
+	}
+	defer channel.Close()
+
+	deliveries, err := channel.Consume(s.queueName(topic), "", false, false, false, false, nil)
+	if err != nil {
+		return err
+	}
+
+	retry := s.publisher.retry
+	for {
+		var delivery amqp.Delivery
+		var ok bool
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case delivery, ok = <-deliveries:
+			if !ok {
+				return errChannelClosed
+			}
+		}
+
+		fields := logrus.Fields{
+			"topic":        topic,
+			"delivery_tag": delivery.DeliveryTag,
+		}
+
+		// Обработку начатого сообщения доводим до конца даже при остановке
+		handleCtx := context.WithoutCancel(ctx)
+
+		err := retry.Do(ctx, func() error {
+			return handler(handleCtx, delivery.Body)
+		})
+		if err != nil && ctx.Err() != nil {
+			_ = delivery.Nack(false, true)
+			return ctx.Err()
+		}
+
+		if err != nil {
+			s.logger.WithError(err).WithFields(fields).Error("failed to handle message, moving it to the dead-letter topic")
+			if err := s.deadLetter(ctx, topic, delivery, err); err != nil {
+				_ = delivery.Nack(false, true)
+				return err
+			}
+			metrics.BrokerDeadLetters.WithLabelValues(driverName, topic).Inc()
+		} else {
+			s.logger.WithFields(fields).Debug("message processed successfully")
+		}
+
+		if err := delivery.Ack(false); err != nil {
+			s.logger.WithError(err).WithFields(fields).Error("failed to ack message")
+		}
+	}
+}
+
+// subscribe opens a channel and declares and binds the queues of topic.
+func (s *Subscriber) subscribe(topic string) (*amqp.Channel, error) {
+	conn, err := s.connection()
+	if err != nil {
+		return nil, err
+	}
+
+	channel, err := openExchange(conn, s.cfg.RabbitMQExchange)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, key := range []string{topic, messaging.DeadLetterTopic(topic)} {
+		queue := s.queueName(key)
+		if _, err := channel.QueueDeclare(queue, true, false, false, false, nil); err != nil {
+			channel.Close()
+			return nil, err
+		}
+		if err := channel.QueueBind(queue, key, s.cfg.RabbitMQExchange, false, nil); err != nil {
+			channel.Close()
+			return nil, err
+		}
+	}
+
+	// Сообщения обрабатываются по одному, больше держать незачем
+	if err := channel.Qos(1, 0, false); err != nil {
+		channel.Close()
+		return nil, err
+	}
+
+	return channel, nil
+}
+
+func (s *Subscriber) connection() (*amqp.Connection, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.conn == nil || s.conn.IsClosed() {
+		conn, err := amqp.Dial(s.cfg.RabbitMQURL)
+		if err != nil {
+			return nil, err
+		}
+		s.conn = conn
+	}
+	return s.conn, nil
+}
+
+// deadLetter publishes the message to the dead-letter routing key, retrying
+// until it succeeds or ctx is cancelled.
+func (s *Subscriber) deadLetter(ctx context.Context, topic string, delivery amqp.Delivery, handleErr error) error {
+	retry := s.publisher.retry
+
+	for {
+		err := s.publisher.publishDeadLetter(context.WithoutCancel(ctx), topic, delivery, handleErr, retry.Attempts)
+		if err == nil {
+			return nil
+		}
+		s.logger.WithError(err).WithField("topic", topic).Error("failed to publish dead letter")
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(max(retry.MaxDelay, retry.Delay)):
+		}
+	}
+}
+
+func (s *Subscriber) queueName(topic string) string {
+	return s.group + "." + topic
+}
+
+// Close closes the connection; Consume must have returned.
+func (s *Subscriber) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.conn == nil || s.conn.IsClosed() {
+		return nil
+	}
+	return s.conn.Close()
+}
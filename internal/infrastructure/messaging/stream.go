@@ -0,0 +1,102 @@
+package messaging
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"github.com/vagonaizer/authenitfication-service/internal/domain/entities"
+	"github.com/vagonaizer/authenitfication-service/pkg/logger"
+)
+
+// UserEventBroadcaster delivers user events to the streams of their user on
+// every replica.
+type UserEventBroadcaster interface {
+	Broadcast(ctx context.Context, event *entities.UserEvent) error
+}
+
+// StreamingPublisher hands the events of the streamed topics that carry a
+// user_id to the live streams of that user before handing them to the next
+// publisher; a bulk event is split into one event per user of its user_ids,
+// so no stream learns of other users. Streams are told even when publishing
+// then fails, since the change the event reports has already happened.
+// Broadcasting is best effort and never fails the publish.
+type StreamingPublisher struct {
+	Publisher
+	broadcaster UserEventBroadcaster
+	topics      map[string]bool
+	logger      *logger.Logger
+}
+
+func NewStreamingPublisher(next Publisher, broadcaster UserEventBroadcaster, topics []string, logger *logger.Logger) *StreamingPublisher {
+	streamed := make(map[string]bool, len(topics))
+	for _, topic := range topics {
+		streamed[topic] = true
+	}
+
+	return &StreamingPublisher{
+		Publisher:   next,
+		broadcaster: broadcaster,
+		topics:      streamed,
+		logger:      logger,
+	}
+}
+
+func (p *StreamingPublisher) PublishMessage(ctx context.Context, topic string, key string, value interface{}) error {
+	if p.topics[topic] {
+		payload, err := json.Marshal(value)
+		if err == nil {
+			p.broadcast(ctx, topic, payload)
+		} else {
+			p.logger.WithContext(ctx).WithError(err).WithField("topic", topic).Warn("failed to stream event")
+		}
+	}
+
+	return p.Publisher.PublishMessage(ctx, topic, key, value)
+}
+
+func (p *StreamingPublisher) PublishBatch(ctx context.Context, messages []Message) error {
+	for _, message := range messages {
+		if p.topics[message.Topic] {
+			p.broadcast(ctx, message.Topic, message.Value)
+		}
+	}
+
+	return PublishBatch(ctx, p.Publisher, messages)
+}
+
+func (p *StreamingPublisher) broadcast(ctx context.Context, topic string, payload []byte) {
+	if userID := payloadUserID(payload); userID != nil {
+		p.send(ctx, &entities.UserEvent{UserID: *userID, Type: topic, Data: payload})
+		return
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(payload, &fields); err != nil {
+		return
+	}
+	var userIDs []uuid.UUID
+	if err := json.Unmarshal(fields["user_ids"], &userIDs); err != nil {
+		return
+	}
+	delete(fields, "user_ids")
+
+	for _, userID := range userIDs {
+		fields["user_id"], _ = json.Marshal(userID)
+		data, err := json.Marshal(fields)
+		if err != nil {
+			continue
+		}
+		p.send(ctx, &entities.UserEvent{UserID: userID, Type: topic, Data: data})
+	}
+}
+
+func (p *StreamingPublisher) send(ctx context.Context, event *entities.UserEvent) {
+	if err := p.broadcaster.Broadcast(ctx, event); err != nil {
+		p.logger.WithContext(ctx).WithError(err).WithFields(logrus.Fields{
+			"topic":   event.Type,
+			"user_id": event.UserID,
+		}).Warn("failed to stream event")
+	}
+}
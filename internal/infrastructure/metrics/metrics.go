@@ -0,0 +1,179 @@
+// Package metrics holds the Prometheus collectors exported on /metrics.
+package metrics
+
+import (
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "auth_service"
+
+// ExpiredSessionsDeleted counts sessions removed by the cleanup job.
+var ExpiredSessionsDeleted = promauto.NewCounter(prometheus.CounterOpts{
+	Namespace: namespace,
+	Subsystem: "sessions",
+	Name:      "expired_deleted_total",
+	Help:      "Number of expired sessions deleted by the cleanup job.",
+})
+
+// LoginHistoryDeleted counts login attempts removed after the retention
+// period.
+var LoginHistoryDeleted = promauto.NewCounter(prometheus.CounterOpts{
+	Namespace: namespace,
+	Subsystem: "login_history",
+	Name:      "deleted_total",
+	Help:      "Number of login history entries deleted by the retention job.",
+})
+
+// EventLogDeleted counts logged events removed after the retention period.
+var EventLogDeleted = promauto.NewCounter(prometheus.CounterOpts{
+	Namespace: namespace,
+	Subsystem: "event_log",
+	Name:      "deleted_total",
+	Help:      "Number of event log entries deleted by the retention job.",
+})
+
+// KafkaDeadLetters counts inbound messages moved to a dead-letter topic
+// after their handler kept failing.
+var KafkaDeadLetters = promauto.NewCounterVec(prometheus.CounterOpts{
+	Namespace: namespace,
+	Subsystem: "kafka",
+	Name:      "dead_letters_total",
+	Help:      "Number of consumed messages moved to a dead-letter topic, by source topic.",
+}, []string{"topic"})
+
+// KafkaEventsDropped counts events that could not be published after all
+// retries.
+var KafkaEventsDropped = promauto.NewCounterVec(prometheus.CounterOpts{
+	Namespace: namespace,
+	Subsystem: "kafka",
+	Name:      "events_dropped_total",
+	Help:      "Number of events dropped after publishing failed on every attempt, by topic.",
+}, []string{"topic"})
+
+// KafkaAsyncQueueDepth is the number of events waiting in the queue of the
+// async producer.
+var KafkaAsyncQueueDepth = promauto.NewGauge(prometheus.GaugeOpts{
+	Namespace: namespace,
+	Subsystem: "kafka",
+	Name:      "async_queue_depth",
+	Help:      "Number of events queued by the async producer and not written yet.",
+})
+
+// DuplicateEventsSkipped counts inbound events skipped because they had
+// already been handled.
+var DuplicateEventsSkipped = promauto.NewCounterVec(prometheus.CounterOpts{
+	Namespace: namespace,
+	Subsystem: "messaging",
+	Name:      "duplicate_events_skipped_total",
+	Help:      "Number of redelivered inbound events skipped by deduplication, by topic.",
+}, []string{"topic"})
+
+// BrokerDeadLetters and BrokerEventsDropped are the counterparts of
+// KafkaDeadLetters and KafkaEventsDropped for the other message brokers.
+var BrokerDeadLetters = promauto.NewCounterVec(prometheus.CounterOpts{
+	Namespace: namespace,
+	Subsystem: "messaging",
+	Name:      "dead_letters_total",
+	Help:      "Number of consumed messages moved to a dead-letter topic, by broker and source topic.",
+}, []string{"broker", "topic"})
+
+var BrokerEventsDropped = promauto.NewCounterVec(prometheus.CounterOpts{
+	Namespace: namespace,
+	Subsystem: "messaging",
+	Name:      "events_dropped_total",
+	Help:      "Number of events dropped after publishing failed on every attempt, by broker and topic.",
+}, []string{"broker", "topic"})
+
+// OutboxPending and OutboxLagSeconds describe the backlog of the outbox as
+// seen by the relay leader; a growing lag means the relay cannot keep up or
+// the broker is down.
+var OutboxPending = promauto.NewGauge(prometheus.GaugeOpts{
+	Namespace: namespace,
+	Subsystem: "outbox",
+	Name:      "pending",
+	Help:      "Number of events in the outbox waiting to be published.",
+})
+
+var OutboxLagSeconds = promauto.NewGauge(prometheus.GaugeOpts{
+	Namespace: namespace,
+	Subsystem: "outbox",
+	Name:      "lag_seconds",
+	Help:      "Age of the oldest event in the outbox, zero when it is empty.",
+})
+
+// OutboxPublished counts events the relay published from the outbox.
+var OutboxPublished = promauto.NewCounterVec(prometheus.CounterOpts{
+	Namespace: namespace,
+	Subsystem: "outbox",
+	Name:      "published_total",
+	Help:      "Number of events published from the outbox, by topic.",
+}, []string{"topic"})
+
+// OutboxRelayLeader is 1 on the relay currently publishing the outbox.
+var OutboxRelayLeader = promauto.NewGauge(prometheus.GaugeOpts{
+	Namespace: namespace,
+	Subsystem: "outbox",
+	Name:      "relay_leader",
+	Help:      "Whether this relay is the leader publishing the outbox.",
+})
+
+// JobRuns counts background job runs by outcome: success, timeout or panic.
+var JobRuns = promauto.NewCounterVec(prometheus.CounterOpts{
+	Namespace: namespace,
+	Subsystem: "jobs",
+	Name:      "runs_total",
+	Help:      "Number of background job runs by job and outcome.",
+}, []string{"job", "outcome"})
+
+// JobDuration observes how long background job runs take.
+var JobDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+	Namespace: namespace,
+	Subsystem: "jobs",
+	Name:      "duration_seconds",
+	Help:      "Duration of background job runs.",
+	Buckets:   prometheus.ExponentialBuckets(0.01, 4, 8),
+}, []string{"job"})
+
+// JobLastSuccess is the Unix time of each job's last successful run.
+var JobLastSuccess = promauto.NewGaugeVec(prometheus.GaugeOpts{
+	Namespace: namespace,
+	Subsystem: "jobs",
+	Name:      "last_success_timestamp_seconds",
+	Help:      "Unix time of the last successful run of each background job.",
+}, []string{"job"})
+
+// GRPCRequests counts finished gRPC calls by service, method, call type
+// (unary, client_stream, server_stream or bidi_stream) and status code.
+var GRPCRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+	Namespace: namespace,
+	Subsystem: "grpc",
+	Name:      "requests_total",
+	Help:      "Number of finished gRPC calls by service, method, type and status code.",
+}, []string{"service", "method", "type", "code"})
+
+// GRPCRequestDuration observes how long gRPC calls take; streams are
+// observed when they end.
+var GRPCRequestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+	Namespace: namespace,
+	Subsystem: "grpc",
+	Name:      "request_duration_seconds",
+	Help:      "Duration of gRPC calls by service, method and type.",
+	Buckets:   prometheus.DefBuckets,
+}, []string{"service", "method", "type"})
+
+// GRPCRequestsInFlight is the number of gRPC calls being served, open
+// streams included.
+var GRPCRequestsInFlight = promauto.NewGaugeVec(prometheus.GaugeOpts{
+	Namespace: namespace,
+	Subsystem: "grpc",
+	Name:      "requests_in_flight",
+	Help:      "Number of gRPC calls being served by service and method.",
+}, []string{"service", "method"})
+
+func Handler() http.Handler {
+	return promhttp.Handler()
+}
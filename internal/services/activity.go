@@ -0,0 +1,88 @@
+package services
+
+import (
+	"context"
+	"math"
+
+	"github.com/google/uuid"
+	"github.com/vagonaizer/authenitfication-service/internal/domain/entities"
+	"github.com/vagonaizer/authenitfication-service/internal/domain/repositories"
+	"github.com/vagonaizer/authenitfication-service/internal/dto/request"
+	"github.com/vagonaizer/authenitfication-service/internal/dto/response"
+	"github.com/vagonaizer/authenitfication-service/pkg/logger"
+)
+
+// recordActivity adds an entry to the user's timeline. The timeline is
+// informational, so a failed write never fails the operation it describes.
+func recordActivity(ctx context.Context, activityRepo repositories.ActivityRepository, log *logger.Logger, activity *entities.UserActivity) {
+	if err := activityRepo.Create(ctx, activity); err != nil {
+		log.WithError(err).WithField("user_id", activity.UserID).Warn("failed to record user activity")
+	}
+}
+
+func (s *userService) recordProfileActivity(ctx context.Context, userID uuid.UUID, fields string) {
+	recordActivity(ctx, s.activityRepo, s.logger, &entities.UserActivity{
+		UserID:  userID,
+		Type:    entities.ActivityProfileUpdated,
+		Details: map[string]string{"fields": fields},
+	})
+}
+
+func (s *userService) recordRoleActivity(ctx context.Context, activityType string, userID uuid.UUID, roleName string, scopeID, actorID *uuid.UUID) {
+	details := map[string]string{"role": roleName}
+	if scopeID != nil {
+		details["scope_id"] = scopeID.String()
+	}
+
+	recordActivity(ctx, s.activityRepo, s.logger, &entities.UserActivity{
+		UserID:  userID,
+		Type:    activityType,
+		ActorID: actorID,
+		Details: details,
+	})
+}
+
+func (s *userService) ListActivity(ctx context.Context, req *request.ListUserActivityRequest) (*response.UserActivityListResponse, error) {
+	if req.Page < 1 {
+		req.Page = 1
+	}
+	if req.PageSize < 1 || req.PageSize > 100 {
+		req.PageSize = 20
+	}
+
+	if _, err := s.userRepo.GetByID(ctx, req.UserID); err != nil {
+		return nil, err
+	}
+
+	filter := entities.ActivityFilter{
+		UserID: req.UserID,
+		Type:   req.Type,
+	}
+
+	offset := (req.Page - 1) * req.PageSize
+	activities, total, err := s.activityRepo.List(ctx, filter, req.PageSize, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	activityResponses := make([]*response.UserActivityResponse, len(activities))
+	for i, activity := range activities {
+		activityResponses[i] = &response.UserActivityResponse{
+			ID:        activity.ID,
+			Type:      activity.Type,
+			ActorID:   activity.ActorID,
+			IPAddress: activity.IPAddress,
+			UserAgent: activity.UserAgent,
+			Details:   activity.Details,
+			CreatedAt: activity.CreatedAt,
+		}
+	}
+
+	return &response.UserActivityListResponse{
+		Activities: activityResponses,
+		Total:      total,
+		Page:       req.Page,
+		PageSize:   req.PageSize,
+		TotalPages: int(math.Ceil(float64(total) / float64(req.PageSize))),
+	}, nil
+}
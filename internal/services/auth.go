@@ -2,15 +2,19 @@ package services
 
 import (
 	"context"
+	"crypto/subtle"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/sirupsen/logrus"
 	"github.com/vagonaizer/authenitfication-service/internal/domain/entities"
 	"github.com/vagonaizer/authenitfication-service/internal/domain/repositories"
+	"github.com/vagonaizer/authenitfication-service/internal/domain/services"
 	"github.com/vagonaizer/authenitfication-service/internal/dto/request"
 	"github.com/vagonaizer/authenitfication-service/internal/dto/response"
+	"github.com/vagonaizer/authenitfication-service/internal/infrastructure/messaging"
 	"github.com/vagonaizer/authenitfication-service/internal/infrastructure/messaging/kafka"
 	"github.com/vagonaizer/authenitfication-service/pkg/auth"
 	"github.com/vagonaizer/authenitfication-service/pkg/errors"
@@ -19,38 +23,87 @@ import (
 )
 
 type AuthService struct {
-	userRepo       repositories.UserRepository
-	sessionRepo    repositories.SessionRepository
-	roleRepo       repositories.RoleRepository
-	passwordHasher *auth.PasswordHasher
-	jwtManager     *auth.JWTManager
-	producer       *kafka.Producer
-	logger         *logger.Logger
-	accessExpiry   time.Duration
-	refreshExpiry  time.Duration
+	userRepo           repositories.UserRepository
+	sessionRepo        repositories.SessionRepository
+	roleRepo           repositories.RoleRepository
+	orgRepo            repositories.OrganizationRepository
+	groupRepo          repositories.GroupRepository
+	roleAuditRepo      repositories.RoleAuditRepository
+	activityRepo       repositories.ActivityRepository
+	loginHistoryRepo   repositories.LoginHistoryRepository
+	serviceAccountRepo repositories.ServiceAccountRepository
+	orgService         services.OrganizationService
+	quotas             services.QuotaService
+	defaultRoles       services.DefaultRoleResolver
+	permissions        services.PermissionService
+	authorizer         services.Authorizer
+	verification       services.VerificationService
+	revocations        services.TokenRevocationService
+	passwordHasher     *auth.PasswordHasher
+	jwtManager         *auth.JWTManager
+	producer           messaging.Publisher
+	logger             *logger.Logger
+	accessExpiry       time.Duration
+	refreshExpiry      time.Duration
+	// maxPermissionClaims caps the permissions embedded in access tokens.
+	maxPermissionClaims int
+	// verificationFromEvent leaves the verification email of new users to
+	// the consumer of user.registered.
+	verificationFromEvent bool
 }
 
 func NewAuthService(
 	userRepo repositories.UserRepository,
 	sessionRepo repositories.SessionRepository,
 	roleRepo repositories.RoleRepository,
+	orgRepo repositories.OrganizationRepository,
+	groupRepo repositories.GroupRepository,
+	roleAuditRepo repositories.RoleAuditRepository,
+	activityRepo repositories.ActivityRepository,
+	loginHistoryRepo repositories.LoginHistoryRepository,
+	serviceAccountRepo repositories.ServiceAccountRepository,
+	orgService services.OrganizationService,
+	quotas services.QuotaService,
+	defaultRoles services.DefaultRoleResolver,
+	permissions services.PermissionService,
+	authorizer services.Authorizer,
+	verification services.VerificationService,
+	revocations services.TokenRevocationService,
 	passwordHasher *auth.PasswordHasher,
 	jwtManager *auth.JWTManager,
-	producer *kafka.Producer,
+	producer messaging.Publisher,
 	logger *logger.Logger,
 	accessExpiry time.Duration,
 	refreshExpiry time.Duration,
+	maxPermissionClaims int,
+	verificationFromEvent bool,
 ) *AuthService {
 	return &AuthService{
-		userRepo:       userRepo,
-		sessionRepo:    sessionRepo,
-		roleRepo:       roleRepo,
-		passwordHasher: passwordHasher,
-		jwtManager:     jwtManager,
-		producer:       producer,
-		logger:         logger,
-		accessExpiry:   accessExpiry,
-		refreshExpiry:  refreshExpiry,
+		userRepo:           userRepo,
+		sessionRepo:        sessionRepo,
+		roleRepo:           roleRepo,
+		orgRepo:            orgRepo,
+		groupRepo:          groupRepo,
+		roleAuditRepo:      roleAuditRepo,
+		activityRepo:       activityRepo,
+		loginHistoryRepo:   loginHistoryRepo,
+		serviceAccountRepo: serviceAccountRepo,
+		orgService:         orgService,
+		quotas:             quotas,
+		defaultRoles:       defaultRoles,
+		permissions:        permissions,
+		authorizer:         authorizer,
+		verification:       verification,
+		revocations:        revocations,
+		passwordHasher:     passwordHasher,
+		jwtManager:         jwtManager,
+		producer:           producer,
+		logger:             logger,
+		accessExpiry:       accessExpiry,
+		refreshExpiry:      refreshExpiry,
+
+		maxPermissionClaims:   maxPermissionClaims,
+		verificationFromEvent: verificationFromEvent,
 	}
 }
 
@@ -83,9 +136,30 @@ func (s *AuthService) Register(ctx context.Context, req *request.RegisterRequest
 		return nil, errors.UsernameExists()
 	}
 
+	if req.InvitationToken != "" {
+		if err := s.orgService.ValidateInvitation(ctx, req.InvitationToken, req.Email); err != nil {
+			return nil, err
+		}
+	}
+
+	locale, timezone := entities.DefaultLocale, entities.DefaultTimezone
+	if req.Locale != "" {
+		normalized, ok := utils.NormalizeLocale(req.Locale)
+		if !ok {
+			return nil, errors.Validation("invalid locale")
+		}
+		locale = normalized
+	}
+	if req.Timezone != "" {
+		if !utils.IsValidTimezone(req.Timezone) {
+			return nil, errors.Validation("invalid timezone")
+		}
+		timezone = req.Timezone
+	}
+
 	passwordHash, err := s.passwordHasher.HashPassword(req.Password)
 	if err != nil {
-		s.logger.WithError(err).Error("failed to hash password")
+		s.logger.WithContext(ctx).WithError(err).Error("failed to hash password")
 		return nil, errors.Internal("failed to process password")
 	}
 
@@ -98,26 +172,27 @@ func (s *AuthService) Register(ctx context.Context, req *request.RegisterRequest
 		LastName:     &req.LastName,
 		IsActive:     true,
 		IsVerified:   false,
+		Locale:       locale,
+		Timezone:     timezone,
 	}
 
 	if err := s.userRepo.Create(ctx, user); err != nil {
 		return nil, err
 	}
 
-	// Назначаем роль по умолчанию (игнорируем ошибки)
-	defaultRole, err := s.roleRepo.GetByName(ctx, "user")
-	if err != nil {
-		s.logger.WithError(err).Warn("failed to get default role")
-	} else {
-		if err := s.roleRepo.AssignRoleToUser(ctx, user.ID, defaultRole.ID); err != nil {
-			s.logger.WithError(err).Warn("failed to assign default role")
+	// Назначаем роли по умолчанию (игнорируем ошибки)
+	s.assignDefaultRoles(ctx, user.ID, req.ClientID)
+
+	if req.InvitationToken != "" {
+		if _, err := s.orgService.AcceptInvitation(ctx, user.ID, req.InvitationToken); err != nil {
+			s.logger.WithContext(ctx).WithError(err).WithField("user_id", user.ID).Warn("failed to accept organization invitation on registration")
 		}
 	}
 
 	// Получаем роли пользователя (с обработкой ошибок)
-	userRoles, err := s.roleRepo.GetUserRoles(ctx, user.ID)
+	userRoles, err := s.roleRepo.GetUserRoles(ctx, user.ID, nil)
 	if err != nil {
-		s.logger.WithError(err).Warn("failed to get user roles, using empty roles")
+		s.logger.WithContext(ctx).WithError(err).Warn("failed to get user roles, using empty roles")
 		userRoles = []*entities.Role{}
 	}
 
@@ -125,17 +200,19 @@ func (s *AuthService) Register(ctx context.Context, req *request.RegisterRequest
 	for i, role := range userRoles {
 		roleNames[i] = role.Name
 	}
+	roleNames, groupsOpt := s.withGroupAccess(ctx, user.ID, roleNames)
 
-	accessToken, err := s.jwtManager.GenerateAccessToken(user.ID, user.Email, user.Username, roleNames, s.accessExpiry)
+	accessToken, err := s.jwtManager.GenerateAccessToken(user.ID, user.Email, user.Username, roleNames, s.accessExpiry,
+		groupsOpt, s.withPermissions(ctx, roleNames), auth.WithLocale(user.Locale, user.Timezone))
 	if err != nil {
-		s.logger.WithError(err).Error("failed to generate access token")
+		s.logger.WithContext(ctx).WithError(err).Error("failed to generate access token")
 		return nil, errors.Internal("failed to generate tokens")
 	}
 
 	// Генерируем короткий refresh token
 	refreshToken, err := utils.GenerateSecureToken()
 	if err != nil {
-		s.logger.WithError(err).Error("failed to generate refresh token")
+		s.logger.WithContext(ctx).WithError(err).Error("failed to generate refresh token")
 		return nil, errors.Internal("failed to generate tokens")
 	}
 
@@ -155,7 +232,7 @@ func (s *AuthService) Register(ctx context.Context, req *request.RegisterRequest
 
 	// Публикуем событие (игнорируем ошибки)
 	event := kafka.UserRegisteredEvent{
-		BaseEvent: kafka.NewBaseEvent(kafka.TopicUserRegistered),
+		BaseEvent: kafka.NewBaseEvent(ctx, kafka.TopicUserRegistered),
 		UserID:    user.ID,
 		Email:     user.Email,
 		Username:  user.Username,
@@ -164,7 +241,14 @@ func (s *AuthService) Register(ctx context.Context, req *request.RegisterRequest
 	}
 
 	if err := s.producer.PublishMessage(ctx, kafka.TopicUserRegistered, user.ID.String(), event); err != nil {
-		s.logger.WithError(err).Warn("failed to publish user registered event")
+		s.logger.WithContext(ctx).WithError(err).Warn("failed to publish user registered event")
+	}
+
+	// Письмо с подтверждением можно запросить повторно, поэтому ошибка не фатальна
+	if !user.IsVerified && !s.verificationFromEvent {
+		if err := s.verification.SendVerification(ctx, user); err != nil {
+			s.logger.WithContext(ctx).WithError(err).WithField("user_id", user.ID).Warn("failed to send verification email")
+		}
 	}
 
 	return &response.AuthResponse{
@@ -178,17 +262,21 @@ func (s *AuthService) Register(ctx context.Context, req *request.RegisterRequest
 			Username:    user.Username,
 			FirstName:   user.FirstName,
 			LastName:    user.LastName,
+			AvatarURL:   user.AvatarURL,
 			IsActive:    user.IsActive,
 			IsVerified:  user.IsVerified,
 			LastLoginAt: user.LastLoginAt,
 			CreatedAt:   user.CreatedAt,
 			UpdatedAt:   user.UpdatedAt,
+
+			Locale:   user.Locale,
+			Timezone: user.Timezone,
 		},
 	}, nil
 }
 
 func (s *AuthService) Login(ctx context.Context, req *request.LoginRequest, ipAddress, userAgent string) (*response.AuthResponse, error) {
-	s.logger.WithFields(logger.Fields{
+	s.logger.WithContext(ctx).WithFields(logger.Fields{
 		"email": req.Email,
 		"ip":    ipAddress,
 	}).Info("login attempt started")
@@ -196,43 +284,82 @@ func (s *AuthService) Login(ctx context.Context, req *request.LoginRequest, ipAd
 	// Шаг 1: Получение пользователя
 	user, err := s.userRepo.GetByEmail(ctx, utils.NormalizeEmail(req.Email))
 	if err != nil {
-		s.logger.WithError(err).WithField("email", req.Email).Error("failed to get user by email")
+		s.logger.WithContext(ctx).WithError(err).WithField("email", req.Email).Error("failed to get user by email")
+		s.recordLoginAttempt(ctx, req, nil, ipAddress, userAgent, entities.LoginFailureUnknownUser)
+		return nil, errors.InvalidCredentials()
+	}
+	s.logger.WithContext(ctx).WithField("user_id", user.ID).Info("user found")
+
+	// Сервисные аккаунты не имеют пароля и входят только по ключу
+	if user.IsServiceAccount {
+		s.logger.WithContext(ctx).WithField("user_id", user.ID).Warn("password login attempt for service account")
+		s.recordLoginAttempt(ctx, req, user, ipAddress, userAgent, entities.LoginFailureServiceAccount)
+		return nil, errors.InvalidCredentials()
+	}
+
+	// Импортированные без пароля аккаунты должны сначала задать пароль
+	if user.PasswordHash == "" {
+		s.logger.WithContext(ctx).WithField("user_id", user.ID).Warn("password login attempt for account without password")
+		s.recordLoginAttempt(ctx, req, user, ipAddress, userAgent, entities.LoginFailurePasswordNotSet)
 		return nil, errors.InvalidCredentials()
 	}
-	s.logger.WithField("user_id", user.ID).Info("user found")
 
 	// Шаг 2: Проверка активности пользователя
 	if !user.IsActive {
-		s.logger.WithField("user_id", user.ID).Warn("inactive user login attempt")
+		s.logger.WithContext(ctx).WithField("user_id", user.ID).Warn("inactive user login attempt")
+		s.recordLoginAttempt(ctx, req, user, ipAddress, userAgent, entities.LoginFailureInactive)
 		return nil, errors.UserInactive()
 	}
 
+	// Истекшие блокировки не учитываются, даже если их ещё не снял sweeper
+	if user.IsBanned(time.Now()) {
+		s.logger.WithContext(ctx).WithField("user_id", user.ID).Warn("banned user login attempt")
+		s.recordLoginAttempt(ctx, req, user, ipAddress, userAgent, entities.LoginFailureBanned)
+		return nil, banError(user)
+	}
+
 	// Шаг 3: Проверка пароля
-	s.logger.WithField("user_id", user.ID).Info("verifying password")
+	s.logger.WithContext(ctx).WithField("user_id", user.ID).Info("verifying password")
 	valid, err := s.passwordHasher.VerifyPassword(req.Password, user.PasswordHash)
 	if err != nil {
-		s.logger.WithError(err).WithField("user_id", user.ID).Error("failed to verify password")
+		s.logger.WithContext(ctx).WithError(err).WithField("user_id", user.ID).Error("failed to verify password")
 		return nil, errors.Internal("authentication failed")
 	}
 
 	if !valid {
-		s.logger.WithField("user_id", user.ID).Warn("invalid password")
+		s.logger.WithContext(ctx).WithField("user_id", user.ID).Warn("invalid password")
+		s.recordLoginAttempt(ctx, req, user, ipAddress, userAgent, entities.LoginFailureInvalidPassword)
 		return nil, errors.InvalidCredentials()
 	}
-	s.logger.WithField("user_id", user.ID).Info("password verified successfully")
+	s.logger.WithContext(ctx).WithField("user_id", user.ID).Info("password verified successfully")
+	s.recordLoginAttempt(ctx, req, user, ipAddress, userAgent, "")
+
+	// Запрошенное удаление аккаунта можно отменить при следующем входе
+	if user.DeletionScheduledAt != nil && req.CancelDeletion {
+		if err := cancelScheduledDeletion(ctx, s.userRepo, s.producer, s.logger, user.ID); err != nil {
+			return nil, err
+		}
+		user.DeletionRequestedAt = nil
+		user.DeletionScheduledAt = nil
+	}
+
+	// Пользователь с обязательной сменой пароля получает только токен для ChangePassword
+	if user.PasswordChangeRequired {
+		return s.passwordChangeLogin(user)
+	}
 
 	// Шаг 4: Обновление времени последнего входа
 	now := time.Now()
 	user.LastLoginAt = &now
 	if err := s.userRepo.Update(ctx, user); err != nil {
-		s.logger.WithError(err).WithField("user_id", user.ID).Warn("failed to update last login time")
+		s.logger.WithContext(ctx).WithError(err).WithField("user_id", user.ID).Warn("failed to update last login time")
 	}
 
 	// Шаг 5: Получение ролей пользователя
-	s.logger.WithField("user_id", user.ID).Info("getting user roles")
-	userRoles, err := s.roleRepo.GetUserRoles(ctx, user.ID)
+	s.logger.WithContext(ctx).WithField("user_id", user.ID).Info("getting user roles")
+	userRoles, err := s.roleRepo.GetUserRoles(ctx, user.ID, nil)
 	if err != nil {
-		s.logger.WithError(err).WithField("user_id", user.ID).Error("failed to get user roles")
+		s.logger.WithContext(ctx).WithError(err).WithField("user_id", user.ID).Error("failed to get user roles")
 		return nil, errors.DatabaseError(fmt.Errorf("failed to retrieve user roles: %w", err))
 	}
 
@@ -240,29 +367,31 @@ func (s *AuthService) Login(ctx context.Context, req *request.LoginRequest, ipAd
 	for i, role := range userRoles {
 		roleNames[i] = role.Name
 	}
-	s.logger.WithFields(logger.Fields{
+	roleNames, groupsOpt := s.withGroupAccess(ctx, user.ID, roleNames)
+	s.logger.WithContext(ctx).WithFields(logger.Fields{
 		"user_id": user.ID,
 		"roles":   roleNames,
 	}).Info("user roles retrieved")
 
 	// Шаг 6: Генерация токенов
-	s.logger.WithField("user_id", user.ID).Info("generating access token")
-	accessToken, err := s.jwtManager.GenerateAccessToken(user.ID, user.Email, user.Username, roleNames, s.accessExpiry)
+	s.logger.WithContext(ctx).WithField("user_id", user.ID).Info("generating access token")
+	accessToken, err := s.jwtManager.GenerateAccessToken(user.ID, user.Email, user.Username, roleNames, s.accessExpiry,
+		groupsOpt, s.withPermissions(ctx, roleNames), auth.WithLocale(user.Locale, user.Timezone))
 	if err != nil {
-		s.logger.WithError(err).WithField("user_id", user.ID).Error("failed to generate access token")
+		s.logger.WithContext(ctx).WithError(err).WithField("user_id", user.ID).Error("failed to generate access token")
 		return nil, errors.Internal("failed to generate tokens")
 	}
 
-	s.logger.WithField("user_id", user.ID).Info("generating refresh token")
+	s.logger.WithContext(ctx).WithField("user_id", user.ID).Info("generating refresh token")
 	// Генерируем короткий refresh token
 	refreshToken, err := utils.GenerateSecureToken()
 	if err != nil {
-		s.logger.WithError(err).WithField("user_id", user.ID).Error("failed to generate refresh token")
+		s.logger.WithContext(ctx).WithError(err).WithField("user_id", user.ID).Error("failed to generate refresh token")
 		return nil, errors.Internal("failed to generate tokens")
 	}
 
 	// Шаг 7: Создание сессии
-	s.logger.WithFields(logger.Fields{
+	s.logger.WithContext(ctx).WithFields(logger.Fields{
 		"user_id":              user.ID,
 		"ip_address":           ipAddress,
 		"user_agent":           userAgent,
@@ -280,7 +409,7 @@ func (s *AuthService) Login(ctx context.Context, req *request.LoginRequest, ipAd
 	}
 
 	if err := s.sessionRepo.Create(ctx, session); err != nil {
-		s.logger.WithError(err).WithFields(logrus.Fields{
+		s.logger.WithContext(ctx).WithError(err).WithFields(logrus.Fields{
 			"user_id":              user.ID,
 			"session_id":           session.ID,
 			"ip_address":           ipAddress,
@@ -291,46 +420,89 @@ func (s *AuthService) Login(ctx context.Context, req *request.LoginRequest, ipAd
 		return nil, errors.DatabaseError(fmt.Errorf("failed to create session: %w", err))
 	}
 
-	s.logger.WithFields(logger.Fields{
+	s.logger.WithContext(ctx).WithFields(logger.Fields{
 		"user_id":    user.ID,
 		"session_id": session.ID,
 	}).Info("session created successfully")
 
 	// Шаг 8: Публикация события (игнорируем ошибки)
 	event := kafka.UserLoggedInEvent{
-		BaseEvent: kafka.NewBaseEvent(kafka.TopicUserLoggedIn),
+		BaseEvent: kafka.NewBaseEvent(ctx, kafka.TopicUserLoggedIn),
 		UserID:    user.ID,
+		SessionID: session.ID,
 		Email:     user.Email,
 		IPAddress: ipAddress,
 		UserAgent: userAgent,
 	}
 
 	if err := s.producer.PublishMessage(ctx, kafka.TopicUserLoggedIn, user.ID.String(), event); err != nil {
-		s.logger.WithError(err).Warn("failed to publish user logged in event")
+		s.logger.WithContext(ctx).WithError(err).Warn("failed to publish user logged in event")
 	}
 
-	s.logger.WithField("user_id", user.ID).Info("login completed successfully")
+	recordActivity(ctx, s.activityRepo, s.logger, &entities.UserActivity{
+		UserID:    user.ID,
+		Type:      entities.ActivityLogin,
+		IPAddress: &ipAddress,
+		UserAgent: &userAgent,
+		Details:   map[string]string{"session_id": session.ID.String()},
+	})
+
+	s.logger.WithContext(ctx).WithField("user_id", user.ID).Info("login completed successfully")
 
 	return &response.AuthResponse{
 		AccessToken:  accessToken,
 		RefreshToken: refreshToken,
 		TokenType:    "Bearer",
 		ExpiresIn:    int64(s.accessExpiry.Seconds()),
-		User: &response.UserResponse{
-			ID:          user.ID,
-			Email:       user.Email,
-			Username:    user.Username,
-			FirstName:   user.FirstName,
-			LastName:    user.LastName,
-			IsActive:    user.IsActive,
-			IsVerified:  user.IsVerified,
-			LastLoginAt: user.LastLoginAt,
-			CreatedAt:   user.CreatedAt,
-			UpdatedAt:   user.UpdatedAt,
-		},
+		User:         loginUserResponse(user),
+	}, nil
+}
+
+// passwordChangeLogin answers a login of a user who must change their
+// password. The access token carries no roles and is only accepted by
+// ChangePassword; no session or refresh token is created, so full tokens
+// require logging in again with the new password.
+func (s *AuthService) passwordChangeLogin(user *entities.User) (*response.AuthResponse, error) {
+	accessToken, err := s.jwtManager.GenerateAccessToken(user.ID, user.Email, user.Username, nil, s.accessExpiry,
+		auth.WithPasswordChangeOnly(), auth.WithLocale(user.Locale, user.Timezone))
+	if err != nil {
+		s.logger.WithError(err).WithField("user_id", user.ID).Error("failed to generate access token")
+		return nil, errors.Internal("failed to generate tokens")
+	}
+
+	s.logger.WithField("user_id", user.ID).Info("login restricted until password change")
+
+	return &response.AuthResponse{
+		AccessToken: accessToken,
+		TokenType:   "Bearer",
+		ExpiresIn:   int64(s.accessExpiry.Seconds()),
+		User:        loginUserResponse(user),
 	}, nil
 }
 
+func loginUserResponse(user *entities.User) *response.UserResponse {
+	return &response.UserResponse{
+		ID:          user.ID,
+		Email:       user.Email,
+		Username:    user.Username,
+		FirstName:   user.FirstName,
+		LastName:    user.LastName,
+		AvatarURL:   user.AvatarURL,
+		IsActive:    user.IsActive,
+		IsVerified:  user.IsVerified,
+		LastLoginAt: user.LastLoginAt,
+		CreatedAt:   user.CreatedAt,
+		UpdatedAt:   user.UpdatedAt,
+
+		PasswordChangeRequired: user.PasswordChangeRequired,
+
+		Locale:   user.Locale,
+		Timezone: user.Timezone,
+
+		DeletionScheduledAt: user.DeletionScheduledAt,
+	}
+}
+
 func (s *AuthService) RefreshToken(ctx context.Context, req *request.RefreshTokenRequest) (*response.TokenResponse, error) {
 	// Для простых refresh токенов проверяем через базу данных
 	session, err := s.sessionRepo.GetByRefreshToken(ctx, req.RefreshToken)
@@ -351,10 +523,108 @@ func (s *AuthService) RefreshToken(ctx context.Context, req *request.RefreshToke
 		return nil, errors.UserInactive()
 	}
 
-	// Получаем роли пользователя (с обработкой ошибок)
-	userRoles, err := s.roleRepo.GetUserRoles(ctx, user.ID)
+	if user.IsBanned(time.Now()) {
+		return nil, banError(user)
+	}
+
+	if user.PasswordChangeRequired {
+		return nil, errors.PasswordChangeRequired()
+	}
+
+	var opts []auth.AccessTokenOption
+	if session.OrganizationID != nil {
+		if _, err := s.orgRepo.GetMember(ctx, *session.OrganizationID, user.ID); err != nil {
+			// Membership was revoked since the switch; fall back to a personal token.
+			s.logger.WithContext(ctx).WithError(err).WithField("user_id", user.ID).Warn("organization membership no longer valid, dropping org scope")
+			session.OrganizationID = nil
+			if err := s.sessionRepo.Update(ctx, session); err != nil {
+				s.logger.WithContext(ctx).WithError(err).Warn("failed to clear session organization")
+			}
+		} else {
+			if err := s.quotas.CheckTokenRate(ctx, *session.OrganizationID); err != nil {
+				return nil, err
+			}
+			opts = append(opts, auth.WithOrgID(*session.OrganizationID))
+		}
+	}
+
+	// Роли организации добавляются к глобальным ролям пользователя
+	userRoles, err := s.roleRepo.GetUserRoles(ctx, user.ID, session.OrganizationID)
+	if err != nil {
+		s.logger.WithContext(ctx).WithError(err).WithField("user_id", user.ID).Warn("failed to get user roles, using empty roles")
+		userRoles = []*entities.Role{}
+	}
+
+	roleNames := make([]string, len(userRoles))
+	for i, role := range userRoles {
+		roleNames[i] = role.Name
+	}
+	var scopedRoles []string
+	if session.OrganizationID != nil {
+		scopedRoles = s.scopedRoles(ctx, user.ID, roleNames)
+		opts = append(opts, auth.WithScopedRoles(scopedRoles))
+	}
+	roleNames, groupsOpt := s.withGroupAccess(ctx, user.ID, roleNames)
+	// Права в токене — только глобальных ролей, как и проверки вне организации
+	opts = append(opts, groupsOpt, s.withPermissions(ctx, auth.WithoutRoles(roleNames, scopedRoles)), auth.WithLocale(user.Locale, user.Timezone))
+
+	accessToken, err := s.jwtManager.GenerateAccessToken(user.ID, user.Email, user.Username, roleNames, s.accessExpiry, opts...)
+	if err != nil {
+		s.logger.WithContext(ctx).WithError(err).Error("failed to generate access token")
+		return nil, errors.Internal("failed to generate token")
+	}
+
+	event := kafka.TokenRefreshedEvent{
+		BaseEvent:      kafka.NewBaseEvent(ctx, kafka.TopicTokenRefreshed),
+		UserID:         user.ID,
+		SessionID:      session.ID,
+		OrganizationID: session.OrganizationID,
+	}
+
+	if err := s.producer.PublishMessage(ctx, kafka.TopicTokenRefreshed, user.ID.String(), event); err != nil {
+		s.logger.WithContext(ctx).WithError(err).Warn("failed to publish token refreshed event")
+	}
+
+	return &response.TokenResponse{
+		AccessToken: accessToken,
+		TokenType:   "Bearer",
+		ExpiresIn:   int64(s.accessExpiry.Seconds()),
+	}, nil
+}
+
+// AuthenticateServiceAccount exchanges a service account key and secret for an
+// access token. No session or refresh token is created; callers simply
+// authenticate again once the token expires.
+func (s *AuthService) AuthenticateServiceAccount(ctx context.Context, req *request.ServiceAccountTokenRequest) (*response.TokenResponse, error) {
+	key, err := s.serviceAccountRepo.GetKeyByKeyID(ctx, req.KeyID)
+	if err != nil {
+		if appErr, ok := err.(*errors.AppError); ok && appErr.Code == errors.CodeNotFound {
+			return nil, errors.InvalidCredentials()
+		}
+		return nil, err
+	}
+
+	if key.IsRevoked() || subtle.ConstantTimeCompare([]byte(utils.HashSHA256(req.Secret)), []byte(key.SecretHash)) != 1 {
+		s.logger.WithContext(ctx).WithField("key_id", key.KeyID).Warn("invalid service account credentials")
+		return nil, errors.InvalidCredentials()
+	}
+
+	user, err := s.userRepo.GetByID(ctx, key.UserID)
+	if err != nil {
+		return nil, errors.InvalidCredentials()
+	}
+
+	if !user.IsServiceAccount {
+		return nil, errors.InvalidCredentials()
+	}
+
+	if !user.IsActive {
+		return nil, errors.UserInactive()
+	}
+
+	userRoles, err := s.roleRepo.GetUserRoles(ctx, user.ID, nil)
 	if err != nil {
-		s.logger.WithError(err).WithField("user_id", user.ID).Warn("failed to get user roles, using empty roles")
+		s.logger.WithContext(ctx).WithError(err).WithField("user_id", user.ID).Warn("failed to get user roles, using empty roles")
 		userRoles = []*entities.Role{}
 	}
 
@@ -362,13 +632,19 @@ func (s *AuthService) RefreshToken(ctx context.Context, req *request.RefreshToke
 	for i, role := range userRoles {
 		roleNames[i] = role.Name
 	}
+	roleNames, groupsOpt := s.withGroupAccess(ctx, user.ID, roleNames)
 
-	accessToken, err := s.jwtManager.GenerateAccessToken(user.ID, user.Email, user.Username, roleNames, s.accessExpiry)
+	accessToken, err := s.jwtManager.GenerateAccessToken(user.ID, user.Email, user.Username, roleNames, s.accessExpiry,
+		groupsOpt, s.withPermissions(ctx, roleNames), auth.WithServiceAccount())
 	if err != nil {
-		s.logger.WithError(err).Error("failed to generate access token")
+		s.logger.WithContext(ctx).WithError(err).Error("failed to generate access token")
 		return nil, errors.Internal("failed to generate token")
 	}
 
+	if err := s.serviceAccountRepo.TouchKey(ctx, key.ID); err != nil {
+		s.logger.WithContext(ctx).WithError(err).WithField("key_id", key.KeyID).Warn("failed to update service account key usage")
+	}
+
 	return &response.TokenResponse{
 		AccessToken: accessToken,
 		TokenType:   "Bearer",
@@ -376,6 +652,63 @@ func (s *AuthService) RefreshToken(ctx context.Context, req *request.RefreshToke
 	}, nil
 }
 
+func (s *AuthService) SwitchOrganization(ctx context.Context, req *request.SwitchOrganizationRequest) (*response.TokenResponse, error) {
+	userID, err := uuid.Parse(req.UserID)
+	if err != nil {
+		return nil, errors.Validation("invalid user ID")
+	}
+
+	session, err := s.sessionRepo.GetByRefreshToken(ctx, req.RefreshToken)
+	if err != nil {
+		return nil, errors.TokenInvalid()
+	}
+
+	if session.UserID != userID {
+		return nil, errors.TokenInvalid()
+	}
+
+	if !session.IsActive || time.Now().After(session.ExpiresAt) {
+		return nil, errors.TokenExpired()
+	}
+
+	var orgID *uuid.UUID
+	if req.OrganizationID != "" {
+		id, err := uuid.Parse(req.OrganizationID)
+		if err != nil {
+			return nil, errors.Validation("invalid organization ID")
+		}
+
+		org, err := s.orgRepo.GetByID(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		if !org.IsActive {
+			return nil, errors.Forbidden("organization is inactive")
+		}
+
+		if _, err := s.orgRepo.GetMember(ctx, id, userID); err != nil {
+			if appErr, ok := err.(*errors.AppError); ok && appErr.Code == errors.CodeNotFound {
+				return nil, errors.Forbidden("not a member of the organization")
+			}
+			return nil, err
+		}
+
+		if session.OrganizationID == nil || *session.OrganizationID != id {
+			if err := s.quotas.CheckSessionLimit(ctx, id, userID, session.ID); err != nil {
+				return nil, err
+			}
+		}
+		orgID = &id
+	}
+
+	session.OrganizationID = orgID
+	if err := s.sessionRepo.Update(ctx, session); err != nil {
+		return nil, err
+	}
+
+	return s.RefreshToken(ctx, &request.RefreshTokenRequest{RefreshToken: req.RefreshToken})
+}
+
 func (s *AuthService) Logout(ctx context.Context, req *request.LogoutRequest) error {
 	session, err := s.sessionRepo.GetByRefreshToken(ctx, req.RefreshToken)
 	if err != nil {
@@ -387,15 +720,21 @@ func (s *AuthService) Logout(ctx context.Context, req *request.LogoutRequest) er
 	}
 
 	event := kafka.UserLoggedOutEvent{
-		BaseEvent: kafka.NewBaseEvent(kafka.TopicUserLoggedOut),
+		BaseEvent: kafka.NewBaseEvent(ctx, kafka.TopicUserLoggedOut),
 		UserID:    session.UserID,
 		SessionID: session.ID,
 	}
 
 	if err := s.producer.PublishMessage(ctx, kafka.TopicUserLoggedOut, session.UserID.String(), event); err != nil {
-		s.logger.WithError(err).Warn("failed to publish user logged out event")
+		s.logger.WithContext(ctx).WithError(err).Warn("failed to publish user logged out event")
 	}
 
+	recordActivity(ctx, s.activityRepo, s.logger, &entities.UserActivity{
+		UserID:  session.UserID,
+		Type:    entities.ActivityLogout,
+		Details: map[string]string{"session_id": session.ID.String()},
+	})
+
 	return nil
 }
 
@@ -409,25 +748,231 @@ func (s *AuthService) LogoutAll(ctx context.Context, userID string) error {
 		return err
 	}
 
+	publishSessionRevoked(ctx, s.producer, s.logger, uid, nil, entities.SessionRevokedLogoutAll)
+
+	return nil
+}
+
+func (s *AuthService) RevokeSession(ctx context.Context, userID, sessionID uuid.UUID) error {
+	session, err := s.sessionRepo.GetByID(ctx, sessionID)
+	if err != nil {
+		return err
+	}
+	if session.UserID != userID {
+		return errors.NotFound("session not found")
+	}
+
+	if err := s.sessionRepo.Delete(ctx, session.ID); err != nil {
+		return err
+	}
+
+	publishSessionRevoked(ctx, s.producer, s.logger, userID, &session.ID, entities.SessionRevokedByUser)
+
+	recordActivity(ctx, s.activityRepo, s.logger, &entities.UserActivity{
+		UserID:  userID,
+		Type:    entities.ActivityLogout,
+		Details: map[string]string{"session_id": session.ID.String()},
+	})
+
 	return nil
 }
 
-func (s *AuthService) VerifyToken(ctx context.Context, token string) (*response.TokenClaimsResponse, error) {
-	claims, err := s.jwtManager.ValidateAccessToken(token)
+func (s *AuthService) ListSessions(ctx context.Context, userID uuid.UUID) ([]*response.SessionResponse, error) {
+	sessions, err := s.sessionRepo.GetActiveByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*response.SessionResponse, len(sessions))
+	for i, session := range sessions {
+		result[i] = &response.SessionResponse{
+			ID:             session.ID,
+			OrganizationID: session.OrganizationID,
+			UserAgent:      session.UserAgent,
+			IPAddress:      session.IPAddress,
+			ExpiresAt:      session.ExpiresAt,
+			CreatedAt:      session.CreatedAt,
+			UpdatedAt:      session.UpdatedAt,
+		}
+	}
+
+	return result, nil
+}
+
+func (s *AuthService) VerifyToken(ctx context.Context, token string, tenant *uuid.UUID) (*response.TokenClaimsResponse, error) {
+	claims, err := s.jwtManager.ValidateAccessToken(token, tenant)
+	if err != nil {
+		return nil, errors.TokenInvalid()
+	}
+
+	revoked, err := s.revocations.IsRevoked(ctx, claims.UserID, claims.ID, claims.IssuedAt.Time)
 	if err != nil {
+		s.logger.WithContext(ctx).WithError(err).WithField("user_id", claims.UserID).Warn("failed to check token revocation")
+	} else if revoked {
 		return nil, errors.TokenInvalid()
 	}
 
+	if claims.PasswordChangeOnly {
+		return nil, errors.PasswordChangeRequired()
+	}
+
 	return &response.TokenClaimsResponse{
-		UserID:    claims.UserID.String(),
-		Email:     claims.Email,
-		Username:  claims.Username,
-		Roles:     claims.Roles,
-		ExpiresAt: claims.ExpiresAt.Time,
-		IssuedAt:  claims.IssuedAt.Time,
+		UserID:      claims.UserID.String(),
+		Email:       claims.Email,
+		Username:    claims.Username,
+		Roles:       claims.GlobalRoles(),
+		ScopedRoles: claims.ScopedRoles,
+		OrgID:       orgIDString(claims.OrgID),
+		Groups:      claims.Groups,
+		ExpiresAt:   claims.ExpiresAt.Time,
+		IssuedAt:    claims.IssuedAt.Time,
+
+		Permissions:        claims.Permissions,
+		PermissionsOmitted: claims.PermissionsOmitted,
+		ServiceAccount:     claims.ServiceAccount,
 	}, nil
 }
 
+func (s *AuthService) CheckAccess(ctx context.Context, req *request.CheckAccessRequest) (*response.CheckAccessResponse, error) {
+	resourceType, action := entities.ParsePermission(req.Permission)
+	if resourceType == "" || action == "" {
+		return nil, errors.Validation("permission must have the form resource:action")
+	}
+
+	resource := req.Resource
+	if resource == "" {
+		resource = resourceType
+	} else if resource != resourceType && !strings.HasPrefix(resource, resourceType+"/") {
+		return nil, errors.Validation("resource does not belong to the permission's resource type")
+	}
+
+	user, err := s.userRepo.GetByID(ctx, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !user.IsActive {
+		return &response.CheckAccessResponse{Allowed: false, Reason: "user is inactive"}, nil
+	}
+
+	if req.OrgID != nil {
+		if _, err := s.orgRepo.GetMember(ctx, *req.OrgID, user.ID); err != nil {
+			if appErr, ok := err.(*errors.AppError); ok && appErr.Code == errors.CodeNotFound {
+				return &response.CheckAccessResponse{Allowed: false, Reason: "user is not a member of the organization"}, nil
+			}
+			return nil, err
+		}
+	}
+
+	userRoles, err := s.roleRepo.GetUserRoles(ctx, user.ID, req.OrgID)
+	if err != nil {
+		return nil, err
+	}
+
+	roleNames := make([]string, len(userRoles))
+	for i, role := range userRoles {
+		roleNames[i] = role.Name
+	}
+	roleNames, _ = s.withGroupAccess(ctx, user.ID, roleNames)
+
+	subject := &services.Subject{
+		UserID: user.ID.String(),
+		Roles:  roleNames,
+		OrgID:  orgIDString(req.OrgID),
+	}
+
+	allowed, err := s.authorizer.Authorize(ctx, subject, action, resource)
+	if err != nil {
+		s.logger.WithContext(ctx).WithError(err).WithField("permission", req.Permission).Error("failed to check access")
+		return nil, errors.Internal("failed to check access")
+	}
+
+	if !allowed {
+		return &response.CheckAccessResponse{Allowed: false, Reason: "insufficient permissions"}, nil
+	}
+
+	return &response.CheckAccessResponse{Allowed: true}, nil
+}
+
+// scopedRoles returns which of the org-scoped roleNames the user does not
+// also hold globally. If the global roles cannot be loaded every role is
+// scoped, so delegated rights never widen.
+func (s *AuthService) scopedRoles(ctx context.Context, userID uuid.UUID, roleNames []string) []string {
+	globalRoles, err := s.roleRepo.GetUserRoles(ctx, userID, nil)
+	if err != nil {
+		s.logger.WithContext(ctx).WithError(err).WithField("user_id", userID).Warn("failed to get global roles")
+		return roleNames
+	}
+
+	globalNames := make([]string, len(globalRoles))
+	for i, role := range globalRoles {
+		globalNames[i] = role.Name
+	}
+
+	return auth.WithoutRoles(roleNames, globalNames)
+}
+
+// withPermissions returns the token option embedding the unconditional
+// permissions of roleNames. A lookup failure marks them omitted so that
+// consumers fall back to CheckAccess instead of assuming none are held.
+func (s *AuthService) withPermissions(ctx context.Context, roleNames []string) auth.AccessTokenOption {
+	permissions, err := s.permissions.ResolvePermissions(ctx, roleNames)
+	if err != nil {
+		s.logger.WithContext(ctx).WithError(err).Warn("failed to resolve permissions for token")
+		return func(c *auth.AccessTokenClaims) {
+			c.PermissionsOmitted = true
+		}
+	}
+
+	return auth.WithPermissions(permissions, s.maxPermissionClaims)
+}
+
+// withGroupAccess adds roles inherited through group membership to roleNames
+// and returns the token option carrying the user's group names. Lookup
+// failures degrade to the directly assigned roles.
+func (s *AuthService) withGroupAccess(ctx context.Context, userID uuid.UUID, roleNames []string) ([]string, auth.AccessTokenOption) {
+	groups, err := s.groupRepo.GetUserGroups(ctx, userID)
+	if err != nil {
+		s.logger.WithContext(ctx).WithError(err).WithField("user_id", userID).Warn("failed to get user groups")
+		return roleNames, auth.WithGroups(nil)
+	}
+
+	if len(groups) == 0 {
+		return roleNames, auth.WithGroups(nil)
+	}
+
+	groupNames := make([]string, len(groups))
+	for i, group := range groups {
+		groupNames[i] = group.Name
+	}
+
+	groupRoles, err := s.groupRepo.GetUserGroupRoles(ctx, userID)
+	if err != nil {
+		s.logger.WithContext(ctx).WithError(err).WithField("user_id", userID).Warn("failed to get group roles")
+		return roleNames, auth.WithGroups(groupNames)
+	}
+
+	seen := make(map[string]bool, len(roleNames))
+	for _, name := range roleNames {
+		seen[name] = true
+	}
+	for _, role := range groupRoles {
+		if !seen[role.Name] {
+			seen[role.Name] = true
+			roleNames = append(roleNames, role.Name)
+		}
+	}
+
+	return roleNames, auth.WithGroups(groupNames)
+}
+
+func orgIDString(orgID *uuid.UUID) string {
+	if orgID == nil {
+		return ""
+	}
+	return orgID.String()
+}
+
 func (s *AuthService) ChangePassword(ctx context.Context, req *request.ChangePasswordRequest) error {
 	userID, err := uuid.Parse(req.UserID)
 	if err != nil {
@@ -439,9 +984,17 @@ func (s *AuthService) ChangePassword(ctx context.Context, req *request.ChangePas
 		return err
 	}
 
+	if user.IsServiceAccount {
+		return errors.Forbidden("service accounts do not have a password")
+	}
+
+	if user.PasswordHash == "" {
+		return errors.InvalidCredentials()
+	}
+
 	valid, err := s.passwordHasher.VerifyPassword(req.OldPassword, user.PasswordHash)
 	if err != nil {
-		s.logger.WithError(err).Error("failed to verify old password")
+		s.logger.WithContext(ctx).WithError(err).Error("failed to verify old password")
 		return errors.Internal("password verification failed")
 	}
 
@@ -453,43 +1006,128 @@ func (s *AuthService) ChangePassword(ctx context.Context, req *request.ChangePas
 		return errors.WeakPassword()
 	}
 
-	newPasswordHash, err := s.passwordHasher.HashPassword(req.NewPassword)
+	return s.setPassword(ctx, user, req.NewPassword)
+}
+
+// setPassword replaces the password of user and signs out its sessions.
+func (s *AuthService) setPassword(ctx context.Context, user *entities.User, newPassword string) error {
+	newPasswordHash, err := s.passwordHasher.HashPassword(newPassword)
 	if err != nil {
-		s.logger.WithError(err).Error("failed to hash new password")
+		s.logger.WithContext(ctx).WithError(err).Error("failed to hash new password")
 		return errors.Internal("failed to process new password")
 	}
 
 	user.PasswordHash = newPasswordHash
+	user.PasswordChangeRequired = false
 	if err := s.userRepo.Update(ctx, user); err != nil {
 		return err
 	}
 
 	if err := s.sessionRepo.DeleteByUserID(ctx, user.ID); err != nil {
-		s.logger.WithError(err).Warn("failed to delete user sessions after password change")
+		s.logger.WithContext(ctx).WithError(err).Warn("failed to delete user sessions after password change")
+	} else {
+		publishSessionRevoked(ctx, s.producer, s.logger, user.ID, nil, entities.SessionRevokedPasswordChanged)
 	}
 
 	event := kafka.PasswordChangedEvent{
-		BaseEvent: kafka.NewBaseEvent(kafka.TopicPasswordChanged),
+		BaseEvent: kafka.NewBaseEvent(ctx, kafka.TopicPasswordChanged),
 		UserID:    user.ID,
 		Email:     user.Email,
 	}
 
 	if err := s.producer.PublishMessage(ctx, kafka.TopicPasswordChanged, user.ID.String(), event); err != nil {
-		s.logger.WithError(err).Warn("failed to publish password changed event")
+		s.logger.WithContext(ctx).WithError(err).Warn("failed to publish password changed event")
 	}
 
+	recordActivity(ctx, s.activityRepo, s.logger, &entities.UserActivity{
+		UserID: user.ID,
+		Type:   entities.ActivityPasswordChanged,
+	})
+
 	return nil
 }
 
 func (s *AuthService) ResetPassword(ctx context.Context, req *request.ResetPasswordRequest) error {
-	_, err := s.userRepo.GetByEmail(ctx, utils.NormalizeEmail(req.Email))
+	return s.verification.SendPasswordReset(ctx, req.Email)
+}
+
+func (s *AuthService) ConfirmResetPassword(ctx context.Context, req *request.ConfirmResetPasswordRequest) error {
+	// Слабый пароль не должен сжигать токен
+	if !utils.IsValidPassword(req.NewPassword) {
+		return errors.WeakPassword()
+	}
+
+	userID, err := s.verification.RedeemPasswordReset(ctx, req.Token)
 	if err != nil {
-		return nil
+		return err
+	}
+
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	if !user.IsActive || user.IsServiceAccount {
+		return errors.TokenInvalid()
+	}
+
+	if err := s.setPassword(ctx, user, req.NewPassword); err != nil {
+		return err
+	}
+
+	// Сброс обычно означает, что пароль мог утечь: выданные токены тоже отзываем
+	if err := s.revocations.RevokeUserTokens(ctx, user.ID); err != nil {
+		s.logger.WithContext(ctx).WithError(err).WithField("user_id", user.ID).Warn("failed to revoke tokens after password reset")
 	}
 
 	return nil
 }
 
-func (s *AuthService) ConfirmResetPassword(ctx context.Context, req *request.ConfirmResetPasswordRequest) error {
-	return nil
+// assignDefaultRoles grants the roles resolved for the registering client.
+// Failures are only logged so a misconfigured role never blocks registration.
+func (s *AuthService) assignDefaultRoles(ctx context.Context, userID uuid.UUID, clientID string) {
+	roleNames, err := s.defaultRoles.ResolveDefaultRoles(ctx, clientID)
+	if err != nil {
+		s.logger.WithContext(ctx).WithError(err).Warn("failed to resolve default roles")
+		return
+	}
+
+	for _, name := range roleNames {
+		role, err := s.roleRepo.GetByName(ctx, name)
+		if err != nil {
+			s.logger.WithContext(ctx).WithError(err).WithField("role", name).Warn("failed to get default role")
+			continue
+		}
+
+		if err := s.roleRepo.AssignRoleToUser(ctx, userID, role.ID, nil, nil); err != nil {
+			s.logger.WithContext(ctx).WithError(err).WithField("role", name).Warn("failed to assign default role")
+			continue
+		}
+
+		s.recordDefaultRoleGrant(ctx, userID, role)
+	}
+}
+
+// recordDefaultRoleGrant audits a role granted automatically at registration.
+func (s *AuthService) recordDefaultRoleGrant(ctx context.Context, userID uuid.UUID, role *entities.Role) {
+	reason := "default role on registration"
+	audit := &entities.RoleAssignmentAudit{
+		UserID:   userID,
+		RoleID:   role.ID,
+		RoleName: role.Name,
+		Action:   entities.RoleAuditActionGranted,
+		Reason:   &reason,
+	}
+	if err := s.roleAuditRepo.Create(ctx, audit); err != nil {
+		s.logger.WithContext(ctx).WithError(err).Warn("failed to record default role audit entry")
+	}
+}
+
+// banError describes the ban in force on user.
+func banError(user *entities.User) error {
+	reason := ""
+	if user.BanReason != nil {
+		reason = *user.BanReason
+	}
+	return errors.UserBanned(reason, user.BannedUntil)
 }
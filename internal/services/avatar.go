@@ -0,0 +1,132 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"mime"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/vagonaizer/authenitfication-service/internal/dto/response"
+	"github.com/vagonaizer/authenitfication-service/internal/infrastructure/messaging/kafka"
+	"github.com/vagonaizer/authenitfication-service/pkg/errors"
+	"github.com/vagonaizer/authenitfication-service/pkg/utils"
+)
+
+// AvatarPolicy limits what can be uploaded as a profile picture.
+type AvatarPolicy struct {
+	MaxSize      int64
+	AllowedTypes []string
+}
+
+var avatarExtensions = map[string]string{
+	"image/jpeg": ".jpg",
+	"image/png":  ".png",
+	"image/gif":  ".gif",
+	"image/webp": ".webp",
+}
+
+// UploadAvatar stores a new profile picture and removes the previous one.
+// The content type is sniffed from the data rather than trusted from the
+// client.
+func (s *userService) UploadAvatar(ctx context.Context, userID uuid.UUID, data []byte) (*response.UserResponse, error) {
+	if len(data) == 0 {
+		return nil, errors.Validation("avatar file is empty")
+	}
+	if s.avatars.MaxSize > 0 && int64(len(data)) > s.avatars.MaxSize {
+		return nil, errors.Validation(fmt.Sprintf("avatar must not exceed %d bytes", s.avatars.MaxSize))
+	}
+
+	contentType, _, _ := mime.ParseMediaType(http.DetectContentType(data))
+	if !slices.Contains(s.avatars.AllowedTypes, contentType) {
+		return nil, errors.Validation(fmt.Sprintf("unsupported avatar type %q", contentType))
+	}
+
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	suffix, err := utils.GenerateRandomString(8)
+	if err != nil {
+		return nil, errors.Internal("failed to store avatar")
+	}
+	key := fmt.Sprintf("avatars/%s/%s%s", user.ID, suffix, avatarExtension(contentType))
+
+	url, err := s.storage.Save(ctx, key, contentType, data)
+	if err != nil {
+		s.logger.WithContext(ctx).WithError(err).WithField("user_id", user.ID).Error("failed to store avatar")
+		return nil, errors.Internal("failed to store avatar")
+	}
+
+	previous := user.AvatarURL
+	user.AvatarURL = &url
+	if err := s.userRepo.Update(ctx, user); err != nil {
+		s.removeAvatar(ctx, url)
+		return nil, err
+	}
+
+	if previous != nil {
+		s.removeAvatar(ctx, *previous)
+	}
+
+	s.publishAvatarUpdated(ctx, user.ID, user.AvatarURL)
+
+	return s.GetProfile(ctx, user.ID)
+}
+
+func (s *userService) DeleteAvatar(ctx context.Context, userID uuid.UUID) error {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	if user.AvatarURL == nil {
+		return nil
+	}
+
+	previous := *user.AvatarURL
+	user.AvatarURL = nil
+	if err := s.userRepo.Update(ctx, user); err != nil {
+		return err
+	}
+
+	s.removeAvatar(ctx, previous)
+	s.publishAvatarUpdated(ctx, user.ID, nil)
+
+	return nil
+}
+
+// removeAvatar deletes a stored file; failures only leave an orphaned file
+// behind, so they are logged and otherwise ignored.
+func (s *userService) removeAvatar(ctx context.Context, url string) {
+	if err := s.storage.Delete(ctx, url); err != nil {
+		s.logger.WithContext(ctx).WithError(err).WithField("url", url).Warn("failed to delete avatar file")
+	}
+}
+
+func (s *userService) publishAvatarUpdated(ctx context.Context, userID uuid.UUID, avatarURL *string) {
+	event := kafka.UserAvatarUpdatedEvent{
+		BaseEvent: kafka.NewBaseEvent(ctx, kafka.TopicUserAvatarUpdated),
+		UserID:    userID,
+		AvatarURL: avatarURL,
+	}
+
+	if err := s.producer.PublishMessage(ctx, kafka.TopicUserAvatarUpdated, userID.String(), event); err != nil {
+		s.logger.WithContext(ctx).WithError(err).Warn("failed to publish user avatar updated event")
+	}
+
+	s.recordProfileActivity(ctx, userID, "avatar_url")
+}
+
+func avatarExtension(contentType string) string {
+	if ext, ok := avatarExtensions[contentType]; ok {
+		return ext
+	}
+	if exts, err := mime.ExtensionsByType(contentType); err == nil && len(exts) > 0 {
+		return exts[0]
+	}
+	return "." + strings.TrimPrefix(contentType, "image/")
+}
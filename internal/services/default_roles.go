@@ -0,0 +1,24 @@
+package services
+
+import "context"
+
+// configDefaultRoleResolver resolves default roles from static configuration,
+// letting each client override the deployment-wide defaults.
+type configDefaultRoleResolver struct {
+	defaults  []string
+	perClient map[string][]string
+}
+
+func NewConfigDefaultRoleResolver(defaults []string, perClient map[string][]string) *configDefaultRoleResolver {
+	return &configDefaultRoleResolver{
+		defaults:  defaults,
+		perClient: perClient,
+	}
+}
+
+func (r *configDefaultRoleResolver) ResolveDefaultRoles(ctx context.Context, clientID string) ([]string, error) {
+	if roles, ok := r.perClient[clientID]; ok {
+		return roles, nil
+	}
+	return r.defaults, nil
+}
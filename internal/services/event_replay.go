@@ -0,0 +1,160 @@
+package services
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/vagonaizer/authenitfication-service/internal/domain/entities"
+	"github.com/vagonaizer/authenitfication-service/internal/domain/repositories"
+	"github.com/vagonaizer/authenitfication-service/internal/dto/request"
+	"github.com/vagonaizer/authenitfication-service/internal/dto/response"
+	"github.com/vagonaizer/authenitfication-service/internal/infrastructure/messaging"
+	"github.com/vagonaizer/authenitfication-service/internal/infrastructure/metrics"
+	"github.com/vagonaizer/authenitfication-service/pkg/errors"
+	"github.com/vagonaizer/authenitfication-service/pkg/logger"
+)
+
+const replayBatchSize = 500
+
+// eventReplayService republishes logged events unchanged, ids included, in
+// the order they were logged. It must be given the broker's publisher rather
+// than the logging one, or replays would be logged again.
+type eventReplayService struct {
+	eventLog  repositories.EventLogRepository
+	producer  messaging.Publisher
+	logger    *logger.Logger
+	maxEvents int
+}
+
+func NewEventReplayService(
+	eventLog repositories.EventLogRepository,
+	producer messaging.Publisher,
+	logger *logger.Logger,
+	maxEvents int,
+) *eventReplayService {
+	return &eventReplayService{
+		eventLog:  eventLog,
+		producer:  producer,
+		logger:    logger,
+		maxEvents: maxEvents,
+	}
+}
+
+func (s *eventReplayService) ReplayEvents(ctx context.Context, req *request.ReplayEventsRequest) (*response.ReplayEventsResponse, error) {
+	limit := req.Limit
+	if limit <= 0 || limit > s.maxEvents {
+		limit = s.maxEvents
+	}
+
+	filter := entities.EventLogFilter{
+		From:   req.From,
+		UserID: req.UserID,
+		Topic:  req.Topic,
+	}
+	if req.To != nil {
+		if !req.To.After(req.From) {
+			return nil, errors.Validation("to must be after from")
+		}
+		filter.To = *req.To
+	}
+
+	result := &response.ReplayEventsResponse{DryRun: req.DryRun}
+	for result.Replayed < limit {
+		records, err := s.eventLog.List(ctx, filter, min(replayBatchSize, limit-result.Replayed))
+		if err != nil {
+			return nil, err
+		}
+		if len(records) == 0 {
+			return result, nil
+		}
+
+		for _, record := range records {
+			if !req.DryRun {
+				if err := s.producer.PublishMessage(ctx, record.Topic, record.Key, record.Payload); err != nil {
+					return nil, errors.WithDetails(errors.ExternalServiceError(err, "message broker"), map[string]string{
+						"replayed":      strconv.Itoa(result.Replayed),
+						"last_event_at": lastEventAt(result),
+					})
+				}
+			}
+
+			result.Replayed++
+			createdAt := record.CreatedAt
+			result.LastEventAt = &createdAt
+			filter.AfterAt, filter.AfterID = record.CreatedAt, record.ID
+		}
+	}
+
+	more, err := s.eventLog.List(ctx, filter, 1)
+	if err != nil {
+		return nil, err
+	}
+	result.Truncated = len(more) > 0
+
+	s.logger.WithContext(ctx).WithFields(logger.Fields{
+		"replayed":  result.Replayed,
+		"dry_run":   result.DryRun,
+		"truncated": result.Truncated,
+	}).Info("events replayed")
+
+	return result, nil
+}
+
+func lastEventAt(result *response.ReplayEventsResponse) string {
+	if result.LastEventAt == nil {
+		return ""
+	}
+	return result.LastEventAt.Format(time.RFC3339Nano)
+}
+
+// EventLogSweeper deletes logged events older than the retention period.
+// Batches skip rows locked by other replicas, so every replica can run it.
+type EventLogSweeper struct {
+	eventLog  repositories.EventLogRepository
+	logger    *logger.Logger
+	retention time.Duration
+	batchSize int
+}
+
+func NewEventLogSweeper(
+	eventLog repositories.EventLogRepository,
+	logger *logger.Logger,
+	retention time.Duration,
+	batchSize int,
+) *EventLogSweeper {
+	return &EventLogSweeper{
+		eventLog:  eventLog,
+		logger:    logger,
+		retention: retention,
+		batchSize: batchSize,
+	}
+}
+
+func (s *EventLogSweeper) Sweep(ctx context.Context) {
+	if s.retention <= 0 {
+		return
+	}
+
+	before := time.Now().Add(-s.retention)
+
+	total := 0
+	for ctx.Err() == nil {
+		deleted, err := s.eventLog.DeleteBefore(ctx, before, s.batchSize)
+		if err != nil {
+			s.logger.WithContext(ctx).WithError(err).Error("failed to delete old event log entries")
+			break
+		}
+
+		total += deleted
+		metrics.EventLogDeleted.Add(float64(deleted))
+
+		if deleted < s.batchSize {
+			break
+		}
+	}
+
+	if total > 0 {
+		s.logger.WithContext(ctx).Infof("deleted %d event log entries", total)
+	}
+}
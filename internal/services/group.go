@@ -0,0 +1,303 @@
+package services
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/vagonaizer/authenitfication-service/internal/domain/entities"
+	"github.com/vagonaizer/authenitfication-service/internal/domain/repositories"
+	"github.com/vagonaizer/authenitfication-service/internal/dto/request"
+	"github.com/vagonaizer/authenitfication-service/internal/dto/response"
+	"github.com/vagonaizer/authenitfication-service/internal/infrastructure/messaging"
+	"github.com/vagonaizer/authenitfication-service/internal/infrastructure/messaging/kafka"
+	"github.com/vagonaizer/authenitfication-service/pkg/errors"
+	"github.com/vagonaizer/authenitfication-service/pkg/logger"
+	"github.com/vagonaizer/authenitfication-service/pkg/utils"
+)
+
+type groupService struct {
+	groupRepo repositories.GroupRepository
+	userRepo  repositories.UserRepository
+	roleRepo  repositories.RoleRepository
+	producer  messaging.Publisher
+	logger    *logger.Logger
+}
+
+func NewGroupService(
+	groupRepo repositories.GroupRepository,
+	userRepo repositories.UserRepository,
+	roleRepo repositories.RoleRepository,
+	producer messaging.Publisher,
+	logger *logger.Logger,
+) *groupService {
+	return &groupService{
+		groupRepo: groupRepo,
+		userRepo:  userRepo,
+		roleRepo:  roleRepo,
+		producer:  producer,
+		logger:    logger,
+	}
+}
+
+func (s *groupService) CreateGroup(ctx context.Context, req *request.CreateGroupRequest) (*response.GroupResponse, error) {
+	if req.ParentID != nil {
+		if _, err := s.groupRepo.GetByID(ctx, *req.ParentID); err != nil {
+			return nil, err
+		}
+	}
+
+	group := &entities.Group{
+		ID:          uuid.New(),
+		Name:        utils.SanitizeString(req.Name),
+		Description: req.Description,
+		ParentID:    req.ParentID,
+	}
+
+	if err := s.groupRepo.Create(ctx, group); err != nil {
+		return nil, err
+	}
+
+	event := kafka.GroupCreatedEvent{
+		BaseEvent: kafka.NewBaseEvent(ctx, kafka.TopicGroupCreated),
+		GroupID:   group.ID,
+		Name:      group.Name,
+		ParentID:  group.ParentID,
+	}
+
+	if err := s.producer.PublishMessage(ctx, kafka.TopicGroupCreated, group.ID.String(), event); err != nil {
+		s.logger.WithContext(ctx).WithError(err).Warn("failed to publish group created event")
+	}
+
+	return toGroupResponse(group), nil
+}
+
+func (s *groupService) GetGroup(ctx context.Context, groupID uuid.UUID) (*response.GroupResponse, error) {
+	group, err := s.groupRepo.GetByID(ctx, groupID)
+	if err != nil {
+		return nil, err
+	}
+
+	return toGroupResponse(group), nil
+}
+
+func (s *groupService) ListGroups(ctx context.Context) (*response.GroupsListResponse, error) {
+	groups, err := s.groupRepo.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	groupResponses := make([]*response.GroupResponse, len(groups))
+	for i, group := range groups {
+		groupResponses[i] = toGroupResponse(group)
+	}
+
+	return &response.GroupsListResponse{
+		Groups: groupResponses,
+	}, nil
+}
+
+func (s *groupService) UpdateGroup(ctx context.Context, req *request.UpdateGroupRequest) (*response.GroupResponse, error) {
+	group, err := s.groupRepo.GetByID(ctx, req.GroupID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Name != nil {
+		group.Name = utils.SanitizeString(*req.Name)
+	}
+
+	if req.Description != nil {
+		group.Description = req.Description
+	}
+
+	if req.ClearParent {
+		group.ParentID = nil
+	} else if req.ParentID != nil {
+		if err := s.ensureNoCycle(ctx, group.ID, *req.ParentID); err != nil {
+			return nil, err
+		}
+		group.ParentID = req.ParentID
+	}
+
+	if err := s.groupRepo.Update(ctx, group); err != nil {
+		return nil, err
+	}
+
+	return toGroupResponse(group), nil
+}
+
+func (s *groupService) DeleteGroup(ctx context.Context, groupID uuid.UUID) error {
+	group, err := s.groupRepo.GetByID(ctx, groupID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.groupRepo.Delete(ctx, groupID); err != nil {
+		return err
+	}
+
+	event := kafka.GroupDeletedEvent{
+		BaseEvent: kafka.NewBaseEvent(ctx, kafka.TopicGroupDeleted),
+		GroupID:   group.ID,
+		Name:      group.Name,
+	}
+
+	if err := s.producer.PublishMessage(ctx, kafka.TopicGroupDeleted, group.ID.String(), event); err != nil {
+		s.logger.WithContext(ctx).WithError(err).Warn("failed to publish group deleted event")
+	}
+
+	return nil
+}
+
+func (s *groupService) ListMembers(ctx context.Context, groupID uuid.UUID) (*response.GroupMembersResponse, error) {
+	if _, err := s.groupRepo.GetByID(ctx, groupID); err != nil {
+		return nil, err
+	}
+
+	members, err := s.groupRepo.ListMembers(ctx, groupID)
+	if err != nil {
+		return nil, err
+	}
+
+	memberResponses := make([]*response.GroupMemberResponse, len(members))
+	for i, member := range members {
+		memberResponses[i] = &response.GroupMemberResponse{
+			UserID:    member.UserID,
+			CreatedAt: member.CreatedAt,
+		}
+	}
+
+	return &response.GroupMembersResponse{
+		GroupID: groupID,
+		Members: memberResponses,
+	}, nil
+}
+
+func (s *groupService) AddMember(ctx context.Context, req *request.GroupMemberRequest) error {
+	if _, err := s.groupRepo.GetByID(ctx, req.GroupID); err != nil {
+		return err
+	}
+
+	if _, err := s.userRepo.GetByID(ctx, req.UserID); err != nil {
+		return err
+	}
+
+	if err := s.groupRepo.AddMember(ctx, req.GroupID, req.UserID); err != nil {
+		return err
+	}
+
+	s.publishMemberEvent(ctx, kafka.TopicGroupMemberAdded, req.GroupID, req.UserID)
+
+	return nil
+}
+
+func (s *groupService) RemoveMember(ctx context.Context, req *request.GroupMemberRequest) error {
+	if err := s.groupRepo.RemoveMember(ctx, req.GroupID, req.UserID); err != nil {
+		return err
+	}
+
+	s.publishMemberEvent(ctx, kafka.TopicGroupMemberRemoved, req.GroupID, req.UserID)
+
+	return nil
+}
+
+func (s *groupService) GetGroupRoles(ctx context.Context, groupID uuid.UUID) (*response.GroupRolesResponse, error) {
+	if _, err := s.groupRepo.GetByID(ctx, groupID); err != nil {
+		return nil, err
+	}
+
+	roles, err := s.groupRepo.GetGroupRoles(ctx, groupID)
+	if err != nil {
+		return nil, err
+	}
+
+	roleResponses := make([]*response.RoleResponse, len(roles))
+	for i, role := range roles {
+		roleResponses[i] = toRoleResponse(role)
+	}
+
+	return &response.GroupRolesResponse{
+		GroupID: groupID,
+		Roles:   roleResponses,
+	}, nil
+}
+
+func (s *groupService) GetUserGroupRoles(ctx context.Context, userID uuid.UUID) (*response.UserRolesResponse, error) {
+	roles, err := s.groupRepo.GetUserGroupRoles(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	roleResponses := make([]*response.RoleResponse, len(roles))
+	for i, role := range roles {
+		roleResponses[i] = toRoleResponse(role)
+	}
+
+	return &response.UserRolesResponse{
+		UserID: userID,
+		Roles:  roleResponses,
+	}, nil
+}
+
+func (s *groupService) AssignRole(ctx context.Context, req *request.GroupRoleRequest) error {
+	if _, err := s.groupRepo.GetByID(ctx, req.GroupID); err != nil {
+		return err
+	}
+
+	if _, err := s.roleRepo.GetByID(ctx, req.RoleID); err != nil {
+		return err
+	}
+
+	return s.groupRepo.AssignRole(ctx, req.GroupID, req.RoleID)
+}
+
+func (s *groupService) RemoveRole(ctx context.Context, req *request.GroupRoleRequest) error {
+	return s.groupRepo.RemoveRole(ctx, req.GroupID, req.RoleID)
+}
+
+// ensureNoCycle rejects a parent that is the group itself or one of its descendants.
+func (s *groupService) ensureNoCycle(ctx context.Context, groupID, parentID uuid.UUID) error {
+	if groupID == parentID {
+		return errors.Validation("group cannot be its own parent")
+	}
+
+	if _, err := s.groupRepo.GetByID(ctx, parentID); err != nil {
+		return err
+	}
+
+	ancestors, err := s.groupRepo.GetAncestorIDs(ctx, parentID)
+	if err != nil {
+		return err
+	}
+
+	for _, id := range ancestors {
+		if id == groupID {
+			return errors.Validation("group nesting would create a cycle")
+		}
+	}
+
+	return nil
+}
+
+func (s *groupService) publishMemberEvent(ctx context.Context, topic string, groupID, userID uuid.UUID) {
+	event := kafka.GroupMemberEvent{
+		BaseEvent: kafka.NewBaseEvent(ctx, topic),
+		GroupID:   groupID,
+		UserID:    userID,
+	}
+
+	if err := s.producer.PublishMessage(ctx, topic, groupID.String(), event); err != nil {
+		s.logger.WithContext(ctx).WithError(err).WithField("topic", topic).Warn("failed to publish group member event")
+	}
+}
+
+func toGroupResponse(group *entities.Group) *response.GroupResponse {
+	return &response.GroupResponse{
+		ID:          group.ID,
+		Name:        group.Name,
+		Description: group.Description,
+		ParentID:    group.ParentID,
+		CreatedAt:   group.CreatedAt,
+		UpdatedAt:   group.UpdatedAt,
+	}
+}
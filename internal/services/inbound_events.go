@@ -0,0 +1,167 @@
+package services
+
+import (
+	"context"
+	stderrors "errors"
+	"net/http"
+	"time"
+
+	"github.com/vagonaizer/authenitfication-service/internal/domain/repositories"
+	"github.com/vagonaizer/authenitfication-service/internal/domain/services"
+	"github.com/vagonaizer/authenitfication-service/internal/dto/request"
+	"github.com/vagonaizer/authenitfication-service/internal/infrastructure/messaging/kafka"
+	"github.com/vagonaizer/authenitfication-service/pkg/errors"
+	"github.com/vagonaizer/authenitfication-service/pkg/logger"
+)
+
+// InboundEventHandler applies the requests other services publish to Kafka,
+// and runs the follow-up steps of this service's own events. Its methods are
+// kafka.MessageHandlers. Events that can never succeed, such as malformed
+// payloads or unknown users, are logged and dropped; other failures are
+// returned so the consumer retries them.
+type InboundEventHandler struct {
+	userService  services.UserService
+	userRepo     repositories.UserRepository
+	verification services.VerificationService
+	logger       *logger.Logger
+}
+
+func NewInboundEventHandler(
+	userService services.UserService,
+	userRepo repositories.UserRepository,
+	verification services.VerificationService,
+	logger *logger.Logger,
+) *InboundEventHandler {
+	return &InboundEventHandler{
+		userService:  userService,
+		userRepo:     userRepo,
+		verification: verification,
+		logger:       logger,
+	}
+}
+
+func (h *InboundEventHandler) HandleModerationBan(ctx context.Context, message []byte) error {
+	var event kafka.ModerationBanRequestedEvent
+	if err := kafka.Decode(message, &event); err != nil {
+		return h.dropMalformed(err, "moderation ban request")
+	}
+
+	req := &request.BanUserRequest{
+		UserID: event.UserID,
+		Reason: event.Reason,
+	}
+	if event.BannedUntil != nil {
+		remaining := time.Until(*event.BannedUntil)
+		if remaining <= 0 {
+			h.logger.WithContext(ctx).WithField("user_id", event.UserID).Info("ignoring moderation ban that already ended")
+			return nil
+		}
+		req.Duration = remaining.Round(time.Second).String()
+	}
+
+	if _, err := h.userService.BanUser(ctx, req); err != nil {
+		return h.dropPermanent(err, "moderation ban request", event.UserID.String())
+	}
+
+	return nil
+}
+
+func (h *InboundEventHandler) HandleDeletionRequest(ctx context.Context, message []byte) error {
+	var event kafka.UserDeletionRequestedEvent
+	if err := kafka.Decode(message, &event); err != nil {
+		return h.dropMalformed(err, "user deletion request")
+	}
+
+	if err := h.userService.DeleteAccount(ctx, event.UserID); err != nil {
+		return h.dropPermanent(err, "user deletion request", event.UserID.String())
+	}
+
+	h.logger.WithContext(ctx).WithFields(logger.Fields{
+		"user_id": event.UserID,
+		"source":  event.Source,
+	}).Info("user deleted on request of another service")
+
+	return nil
+}
+
+// HandleUserRegistered sends the verification email of a new user, so that
+// registering does not wait for email delivery. Users verified, deactivated
+// or deleted in the meantime are skipped.
+func (h *InboundEventHandler) HandleUserRegistered(ctx context.Context, message []byte) error {
+	var event kafka.UserRegisteredEvent
+	if err := kafka.Decode(message, &event); err != nil {
+		return h.dropMalformed(err, "user registered event")
+	}
+
+	user, err := h.userRepo.GetByID(ctx, event.UserID)
+	if err != nil {
+		return h.dropPermanent(err, "verification email", event.UserID.String())
+	}
+	if user.IsVerified || !user.IsActive || user.IsServiceAccount {
+		return nil
+	}
+
+	if err := h.verification.SendVerification(ctx, user); err != nil {
+		return h.dropPermanent(err, "verification email", event.UserID.String())
+	}
+
+	h.logger.WithContext(ctx).WithField("user_id", user.ID).Info("verification email sent for new user")
+	return nil
+}
+
+// dropMalformed logs and swallows events that cannot be decoded. Events of an
+// unknown schema version are returned instead, so they end up in the
+// dead-letter topic and can be replayed once this service supports them.
+func (h *InboundEventHandler) dropMalformed(err error, what string) error {
+	if stderrors.Is(err, kafka.ErrUnsupportedSchemaVersion) {
+		return err
+	}
+	h.logger.WithError(err).Warnf("dropping malformed %s", what)
+	return nil
+}
+
+// dropPermanent logs and swallows client errors, which retrying cannot fix,
+// and returns the others.
+func (h *InboundEventHandler) dropPermanent(err error, what, userID string) error {
+	if appErr, ok := err.(*errors.AppError); ok && appErr.StatusCode < http.StatusInternalServerError {
+		h.logger.WithError(err).WithField("user_id", userID).Warnf("dropping %s", what)
+		return nil
+	}
+	return err
+}
+
+const processedEventsBatchSize = 1000
+
+// ProcessedEventSweeper deletes expired entries of the Postgres dedup store.
+// Batches skip rows locked by other replicas, so every replica can run it.
+type ProcessedEventSweeper struct {
+	processedEvents repositories.ProcessedEventRepository
+	logger          *logger.Logger
+}
+
+func NewProcessedEventSweeper(processedEvents repositories.ProcessedEventRepository, logger *logger.Logger) *ProcessedEventSweeper {
+	return &ProcessedEventSweeper{
+		processedEvents: processedEvents,
+		logger:          logger,
+	}
+}
+
+func (s *ProcessedEventSweeper) Sweep(ctx context.Context) {
+	total := 0
+	for ctx.Err() == nil {
+		deleted, err := s.processedEvents.DeleteExpired(ctx, processedEventsBatchSize)
+		if err != nil {
+			s.logger.WithContext(ctx).WithError(err).Error("failed to delete expired processed events")
+			break
+		}
+
+		total += deleted
+		if deleted < processedEventsBatchSize {
+			break
+		}
+	}
+
+	if total > 0 {
+		s.logger.WithContext(ctx).Infof("deleted %d expired processed events", total)
+	}
+}
@@ -0,0 +1,265 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/vagonaizer/authenitfication-service/internal/domain/entities"
+	"github.com/vagonaizer/authenitfication-service/internal/dto/request"
+	"github.com/vagonaizer/authenitfication-service/internal/dto/response"
+	"github.com/vagonaizer/authenitfication-service/internal/infrastructure/messaging/kafka"
+	"github.com/vagonaizer/authenitfication-service/pkg/errors"
+	"github.com/vagonaizer/authenitfication-service/pkg/utils"
+)
+
+func (s *organizationService) CreateInvitation(ctx context.Context, req *request.CreateInvitationRequest) (*response.InvitationResponse, error) {
+	actor, err := s.requireRole(ctx, req.OrganizationID, req.ActorID, entities.OrgRoleAdmin)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Role == entities.OrgRoleOwner && actor.Role != entities.OrgRoleOwner {
+		return nil, errors.Forbidden("only owners can invite owners")
+	}
+
+	email := utils.NormalizeEmail(req.Email)
+
+	user, err := s.userRepo.GetByEmail(ctx, email)
+	if err == nil {
+		if _, err := s.orgRepo.GetMember(ctx, req.OrganizationID, user.ID); err == nil {
+			return nil, errors.AlreadyExists("user is already a member of the organization")
+		}
+	} else if appErr, ok := err.(*errors.AppError); !ok || appErr.Code != errors.CodeUserNotFound {
+		return nil, err
+	}
+
+	pending, err := s.invitationRepo.GetPendingByEmail(ctx, req.OrganizationID, email)
+	if err == nil {
+		if !pending.IsExpired() {
+			return nil, errors.AlreadyExists("invitation already pending for this email")
+		}
+		if err := s.invitationRepo.UpdateStatus(ctx, pending.ID, entities.InvitationStatusExpired); err != nil {
+			return nil, err
+		}
+	} else if appErr, ok := err.(*errors.AppError); !ok || appErr.Code != errors.CodeNotFound {
+		return nil, err
+	}
+
+	// Fail early; the limit is enforced again when the invitation is accepted.
+	if err := s.quotas.CheckMemberLimit(ctx, req.OrganizationID); err != nil {
+		return nil, err
+	}
+
+	token, err := utils.GenerateSecureToken()
+	if err != nil {
+		s.logger.WithContext(ctx).WithError(err).Error("failed to generate invitation token")
+		return nil, errors.Internal("failed to create invitation")
+	}
+
+	actorID := req.ActorID
+	invitation := &entities.OrganizationInvitation{
+		ID:             uuid.New(),
+		OrganizationID: req.OrganizationID,
+		Email:          email,
+		Role:           req.Role,
+		TokenHash:      utils.HashSHA256(token),
+		InvitedBy:      &actorID,
+		Status:         entities.InvitationStatusPending,
+		ExpiresAt:      time.Now().Add(s.invitationTTL),
+	}
+
+	if err := s.invitationRepo.Create(ctx, invitation); err != nil {
+		return nil, err
+	}
+
+	s.publishInvitationEvent(ctx, kafka.TopicOrganizationInvitationCreated, invitation, &actorID, token)
+
+	result := toInvitationResponse(invitation)
+	result.Token = token
+
+	return result, nil
+}
+
+func (s *organizationService) ListInvitations(ctx context.Context, actorID, orgID uuid.UUID) (*response.InvitationsListResponse, error) {
+	if _, err := s.requireRole(ctx, orgID, actorID, entities.OrgRoleAdmin); err != nil {
+		return nil, err
+	}
+
+	invitations, err := s.invitationRepo.ListByOrganization(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	invitationResponses := make([]*response.InvitationResponse, len(invitations))
+	for i, invitation := range invitations {
+		invitationResponses[i] = toInvitationResponse(invitation)
+	}
+
+	return &response.InvitationsListResponse{
+		OrganizationID: orgID,
+		Invitations:    invitationResponses,
+	}, nil
+}
+
+func (s *organizationService) RevokeInvitation(ctx context.Context, actorID, orgID, invitationID uuid.UUID) error {
+	if _, err := s.requireRole(ctx, orgID, actorID, entities.OrgRoleAdmin); err != nil {
+		return err
+	}
+
+	invitation, err := s.invitationRepo.GetByID(ctx, invitationID)
+	if err != nil {
+		return err
+	}
+
+	if invitation.OrganizationID != orgID {
+		return errors.NotFound("invitation not found")
+	}
+
+	if err := s.invitationRepo.UpdateStatus(ctx, invitation.ID, entities.InvitationStatusRevoked); err != nil {
+		return err
+	}
+
+	s.publishInvitationEvent(ctx, kafka.TopicOrganizationInvitationRevoked, invitation, &actorID, "")
+
+	return nil
+}
+
+func (s *organizationService) AcceptInvitation(ctx context.Context, userID uuid.UUID, token string) (*response.OrganizationResponse, error) {
+	invitation, err := s.pendingInvitation(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if utils.NormalizeEmail(user.Email) != invitation.Email {
+		return nil, errors.Forbidden("invitation was issued for a different email")
+	}
+
+	org, err := s.orgRepo.GetByID(ctx, invitation.OrganizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !org.IsActive {
+		return nil, errors.Forbidden("organization is inactive")
+	}
+
+	if err := s.quotas.CheckMemberLimit(ctx, invitation.OrganizationID); err != nil {
+		return nil, err
+	}
+
+	member := &entities.OrganizationMember{
+		ID:             uuid.New(),
+		OrganizationID: invitation.OrganizationID,
+		UserID:         userID,
+		Role:           invitation.Role,
+	}
+
+	if err := s.orgRepo.AddMember(ctx, member); err != nil {
+		return nil, err
+	}
+
+	if err := s.invitationRepo.UpdateStatus(ctx, invitation.ID, entities.InvitationStatusAccepted); err != nil {
+		return nil, err
+	}
+
+	s.publishInvitationEvent(ctx, kafka.TopicOrganizationInvitationAccepted, invitation, &userID, "")
+	s.publishMemberEvent(ctx, kafka.TopicOrganizationMemberAdded, invitation.OrganizationID, userID, invitation.Role, userID)
+
+	return toOrganizationResponse(org), nil
+}
+
+func (s *organizationService) DeclineInvitation(ctx context.Context, userID uuid.UUID, token string) error {
+	invitation, err := s.pendingInvitation(ctx, token)
+	if err != nil {
+		return err
+	}
+
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	if utils.NormalizeEmail(user.Email) != invitation.Email {
+		return errors.Forbidden("invitation was issued for a different email")
+	}
+
+	if err := s.invitationRepo.UpdateStatus(ctx, invitation.ID, entities.InvitationStatusDeclined); err != nil {
+		return err
+	}
+
+	s.publishInvitationEvent(ctx, kafka.TopicOrganizationInvitationDeclined, invitation, &userID, "")
+
+	return nil
+}
+
+func (s *organizationService) ValidateInvitation(ctx context.Context, token, email string) error {
+	invitation, err := s.pendingInvitation(ctx, token)
+	if err != nil {
+		return err
+	}
+
+	if utils.NormalizeEmail(email) != invitation.Email {
+		return errors.Forbidden("invitation was issued for a different email")
+	}
+
+	return nil
+}
+
+// pendingInvitation looks up an invitation by its raw token and makes sure it
+// can still be answered, marking it expired if its deadline has passed.
+func (s *organizationService) pendingInvitation(ctx context.Context, token string) (*entities.OrganizationInvitation, error) {
+	invitation, err := s.invitationRepo.GetByTokenHash(ctx, utils.HashSHA256(token))
+	if err != nil {
+		return nil, err
+	}
+
+	if invitation.Status != entities.InvitationStatusPending {
+		return nil, errors.Validation("invitation is no longer pending")
+	}
+
+	if invitation.IsExpired() {
+		if err := s.invitationRepo.UpdateStatus(ctx, invitation.ID, entities.InvitationStatusExpired); err != nil {
+			s.logger.WithContext(ctx).WithError(err).Warn("failed to mark invitation as expired")
+		}
+		return nil, errors.Validation("invitation has expired")
+	}
+
+	return invitation, nil
+}
+
+func (s *organizationService) publishInvitationEvent(ctx context.Context, topic string, invitation *entities.OrganizationInvitation, actorID *uuid.UUID, token string) {
+	event := kafka.OrganizationInvitationEvent{
+		BaseEvent:      kafka.NewBaseEvent(ctx, topic),
+		InvitationID:   invitation.ID,
+		OrganizationID: invitation.OrganizationID,
+		Email:          invitation.Email,
+		Role:           invitation.Role,
+		ActorID:        actorID,
+		Token:          token,
+		ExpiresAt:      invitation.ExpiresAt,
+	}
+
+	if err := s.producer.PublishMessage(ctx, topic, invitation.OrganizationID.String(), event); err != nil {
+		s.logger.WithContext(ctx).WithError(err).WithField("topic", topic).Warn("failed to publish organization invitation event")
+	}
+}
+
+func toInvitationResponse(invitation *entities.OrganizationInvitation) *response.InvitationResponse {
+	return &response.InvitationResponse{
+		ID:             invitation.ID,
+		OrganizationID: invitation.OrganizationID,
+		Email:          invitation.Email,
+		Role:           invitation.Role,
+		Status:         invitation.Status,
+		InvitedBy:      invitation.InvitedBy,
+		ExpiresAt:      invitation.ExpiresAt,
+		RespondedAt:    invitation.RespondedAt,
+		CreatedAt:      invitation.CreatedAt,
+	}
+}
@@ -0,0 +1,168 @@
+package services
+
+import (
+	"context"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/vagonaizer/authenitfication-service/internal/domain/entities"
+	"github.com/vagonaizer/authenitfication-service/internal/domain/repositories"
+	"github.com/vagonaizer/authenitfication-service/internal/dto/request"
+	"github.com/vagonaizer/authenitfication-service/internal/dto/response"
+	"github.com/vagonaizer/authenitfication-service/internal/infrastructure/messaging/kafka"
+	"github.com/vagonaizer/authenitfication-service/internal/infrastructure/metrics"
+	"github.com/vagonaizer/authenitfication-service/pkg/logger"
+	"github.com/vagonaizer/authenitfication-service/pkg/utils"
+)
+
+// recordLoginAttempt adds a password login attempt to the login history; an
+// empty failureReason marks a success. Failures are also published as login
+// failed events. Like the activity timeline, the history never fails the
+// login it describes.
+func (s *AuthService) recordLoginAttempt(ctx context.Context, req *request.LoginRequest, user *entities.User, ipAddress, userAgent, failureReason string) {
+	attempt := &entities.LoginAttempt{
+		Email:     utils.NormalizeEmail(req.Email),
+		Success:   failureReason == "",
+		IPAddress: ipAddress,
+		UserAgent: userAgent,
+		Country:   countryCode(req.Country),
+	}
+	if user != nil {
+		attempt.UserID = &user.ID
+		attempt.Email = user.Email
+	}
+	if failureReason != "" {
+		attempt.FailureReason = &failureReason
+	}
+
+	if err := s.loginHistoryRepo.Create(ctx, attempt); err != nil {
+		s.logger.WithContext(ctx).WithError(err).WithField("email", attempt.Email).Warn("failed to record login attempt")
+	}
+
+	if failureReason == "" {
+		return
+	}
+
+	event := kafka.LoginFailedEvent{
+		BaseEvent: kafka.NewBaseEvent(ctx, kafka.TopicLoginFailed),
+		UserID:    attempt.UserID,
+		Email:     attempt.Email,
+		Reason:    failureReason,
+		IPAddress: ipAddress,
+		UserAgent: userAgent,
+		Country:   attempt.Country,
+	}
+
+	// Попытки входа под несуществующим email ключуем самим email, чтобы
+	// перебор по одному адресу попадал в одну партицию
+	key := attempt.Email
+	if attempt.UserID != nil {
+		key = attempt.UserID.String()
+	}
+	if err := s.producer.PublishMessage(ctx, kafka.TopicLoginFailed, key, event); err != nil {
+		s.logger.WithContext(ctx).WithError(err).Warn("failed to publish login failed event")
+	}
+}
+
+// countryCode keeps two-letter codes only; proxies use other values for
+// unknown origins.
+func countryCode(country string) *string {
+	country = strings.ToUpper(strings.TrimSpace(country))
+	if len(country) != 2 {
+		return nil
+	}
+	return &country
+}
+
+func (s *userService) ListLoginHistory(ctx context.Context, req *request.ListLoginHistoryRequest) (*response.LoginHistoryResponse, error) {
+	if req.Page < 1 {
+		req.Page = 1
+	}
+	if req.PageSize < 1 || req.PageSize > 100 {
+		req.PageSize = 20
+	}
+
+	if _, err := s.userRepo.GetByID(ctx, req.UserID); err != nil {
+		return nil, err
+	}
+
+	offset := (req.Page - 1) * req.PageSize
+	attempts, total, err := s.loginHistory.ListByUser(ctx, req.UserID, req.PageSize, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	attemptResponses := make([]*response.LoginAttemptResponse, len(attempts))
+	for i, attempt := range attempts {
+		attemptResponses[i] = &response.LoginAttemptResponse{
+			ID:            attempt.ID,
+			Success:       attempt.Success,
+			FailureReason: attempt.FailureReason,
+			IPAddress:     attempt.IPAddress,
+			UserAgent:     attempt.UserAgent,
+			Country:       attempt.Country,
+			CreatedAt:     attempt.CreatedAt,
+		}
+	}
+
+	return &response.LoginHistoryResponse{
+		Attempts:   attemptResponses,
+		Total:      total,
+		Page:       req.Page,
+		PageSize:   req.PageSize,
+		TotalPages: int(math.Ceil(float64(total) / float64(req.PageSize))),
+	}, nil
+}
+
+// LoginHistorySweeper deletes login attempts older than the retention
+// period. Batches skip rows locked by other replicas, so every replica can
+// run it.
+type LoginHistorySweeper struct {
+	loginHistory repositories.LoginHistoryRepository
+	logger       *logger.Logger
+	retention    time.Duration
+	batchSize    int
+}
+
+func NewLoginHistorySweeper(
+	loginHistory repositories.LoginHistoryRepository,
+	logger *logger.Logger,
+	retention time.Duration,
+	batchSize int,
+) *LoginHistorySweeper {
+	return &LoginHistorySweeper{
+		loginHistory: loginHistory,
+		logger:       logger,
+		retention:    retention,
+		batchSize:    batchSize,
+	}
+}
+
+func (s *LoginHistorySweeper) Sweep(ctx context.Context) {
+	if s.retention <= 0 {
+		return
+	}
+
+	before := time.Now().Add(-s.retention)
+
+	total := 0
+	for ctx.Err() == nil {
+		deleted, err := s.loginHistory.DeleteBefore(ctx, before, s.batchSize)
+		if err != nil {
+			s.logger.WithContext(ctx).WithError(err).Error("failed to delete old login history")
+			break
+		}
+
+		total += deleted
+		metrics.LoginHistoryDeleted.Add(float64(deleted))
+
+		if deleted < s.batchSize {
+			break
+		}
+	}
+
+	if total > 0 {
+		s.logger.WithContext(ctx).Infof("deleted %d login history entries", total)
+	}
+}
@@ -2,51 +2,80 @@ package services
 
 import (
 	"context"
+	"time"
 
+	"github.com/vagonaizer/authenitfication-service/internal/infrastructure/messaging"
 	"github.com/vagonaizer/authenitfication-service/internal/infrastructure/messaging/kafka"
 	"github.com/vagonaizer/authenitfication-service/pkg/logger"
 )
 
 type notificationService struct {
-	producer *kafka.Producer
+	producer messaging.Publisher
 	logger   *logger.Logger
 }
 
-func NewNotificationService(producer *kafka.Producer, logger *logger.Logger) *notificationService {
+func NewNotificationService(producer messaging.Publisher, logger *logger.Logger) *notificationService {
 	return &notificationService{
 		producer: producer,
 		logger:   logger,
 	}
 }
 
-func (s *notificationService) SendWelcomeEmail(ctx context.Context, userID, email string) error {
+func (s *notificationService) SendWelcomeEmail(ctx context.Context, userID, email, locale string) error {
 	event := map[string]interface{}{
 		"type":    "welcome_email",
 		"user_id": userID,
 		"email":   email,
+		"locale":  locale,
 	}
 
-	return s.producer.PublishMessage(ctx, "notifications.email", userID, event)
+	return s.producer.PublishMessage(ctx, kafka.TopicNotificationsEmail, userID, event)
 }
 
-func (s *notificationService) SendPasswordResetEmail(ctx context.Context, userID, email, resetToken string) error {
+func (s *notificationService) SendPasswordResetEmail(ctx context.Context, userID, email, locale, resetToken string) error {
 	event := map[string]interface{}{
 		"type":        "password_reset_email",
 		"user_id":     userID,
 		"email":       email,
+		"locale":      locale,
 		"reset_token": resetToken,
 	}
 
-	return s.producer.PublishMessage(ctx, "notifications.email", userID, event)
+	return s.producer.PublishMessage(ctx, kafka.TopicNotificationsEmail, userID, event)
 }
 
-func (s *notificationService) SendVerificationEmail(ctx context.Context, userID, email, verificationToken string) error {
+func (s *notificationService) SendVerificationEmail(ctx context.Context, userID, email, locale, verificationToken string) error {
 	event := map[string]interface{}{
 		"type":               "verification_email",
 		"user_id":            userID,
 		"email":              email,
+		"locale":             locale,
 		"verification_token": verificationToken,
 	}
 
-	return s.producer.PublishMessage(ctx, "notifications.email", userID, event)
+	return s.producer.PublishMessage(ctx, kafka.TopicNotificationsEmail, userID, event)
+}
+
+func (s *notificationService) SendPhoneVerificationCode(ctx context.Context, userID, phone, locale, code string) error {
+	event := map[string]interface{}{
+		"type":    "phone_verification_code",
+		"user_id": userID,
+		"phone":   phone,
+		"locale":  locale,
+		"code":    code,
+	}
+
+	return s.producer.PublishMessage(ctx, kafka.TopicNotificationsSMS, userID, event)
+}
+
+func (s *notificationService) SendDeletionScheduledEmail(ctx context.Context, userID, email, locale string, effectiveAt time.Time) error {
+	event := map[string]interface{}{
+		"type":         "deletion_scheduled_email",
+		"user_id":      userID,
+		"email":        email,
+		"locale":       locale,
+		"effective_at": effectiveAt,
+	}
+
+	return s.producer.PublishMessage(ctx, kafka.TopicNotificationsEmail, userID, event)
 }
@@ -0,0 +1,379 @@
+package services
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/vagonaizer/authenitfication-service/internal/domain/entities"
+	"github.com/vagonaizer/authenitfication-service/internal/domain/repositories"
+	"github.com/vagonaizer/authenitfication-service/internal/domain/services"
+	"github.com/vagonaizer/authenitfication-service/internal/dto/request"
+	"github.com/vagonaizer/authenitfication-service/internal/dto/response"
+	"github.com/vagonaizer/authenitfication-service/internal/infrastructure/messaging"
+	"github.com/vagonaizer/authenitfication-service/internal/infrastructure/messaging/kafka"
+	"github.com/vagonaizer/authenitfication-service/pkg/errors"
+	"github.com/vagonaizer/authenitfication-service/pkg/logger"
+	"github.com/vagonaizer/authenitfication-service/pkg/utils"
+)
+
+type organizationService struct {
+	orgRepo        repositories.OrganizationRepository
+	userRepo       repositories.UserRepository
+	invitationRepo repositories.InvitationRepository
+	quotas         services.QuotaService
+	producer       messaging.Publisher
+	logger         *logger.Logger
+	invitationTTL  time.Duration
+}
+
+func NewOrganizationService(
+	orgRepo repositories.OrganizationRepository,
+	userRepo repositories.UserRepository,
+	invitationRepo repositories.InvitationRepository,
+	quotas services.QuotaService,
+	producer messaging.Publisher,
+	logger *logger.Logger,
+	invitationTTL time.Duration,
+) *organizationService {
+	return &organizationService{
+		orgRepo:        orgRepo,
+		userRepo:       userRepo,
+		invitationRepo: invitationRepo,
+		quotas:         quotas,
+		producer:       producer,
+		logger:         logger,
+		invitationTTL:  invitationTTL,
+	}
+}
+
+func (s *organizationService) CreateOrganization(ctx context.Context, req *request.CreateOrganizationRequest) (*response.OrganizationResponse, error) {
+	slug := strings.ToLower(strings.TrimSpace(req.Slug))
+	if !utils.IsValidSlug(slug) {
+		return nil, errors.Validation("invalid organization slug format")
+	}
+
+	org := &entities.Organization{
+		ID:          uuid.New(),
+		Name:        utils.SanitizeString(req.Name),
+		Slug:        slug,
+		Description: req.Description,
+		IsActive:    true,
+	}
+
+	if err := s.orgRepo.Create(ctx, org); err != nil {
+		return nil, err
+	}
+
+	owner := &entities.OrganizationMember{
+		ID:             uuid.New(),
+		OrganizationID: org.ID,
+		UserID:         req.OwnerID,
+		Role:           entities.OrgRoleOwner,
+	}
+
+	if err := s.orgRepo.AddMember(ctx, owner); err != nil {
+		return nil, err
+	}
+
+	event := kafka.OrganizationCreatedEvent{
+		BaseEvent:      kafka.NewBaseEvent(ctx, kafka.TopicOrganizationCreated),
+		OrganizationID: org.ID,
+		Name:           org.Name,
+		Slug:           org.Slug,
+		OwnerID:        req.OwnerID,
+	}
+
+	if err := s.producer.PublishMessage(ctx, kafka.TopicOrganizationCreated, org.ID.String(), event); err != nil {
+		s.logger.WithContext(ctx).WithError(err).Warn("failed to publish organization created event")
+	}
+
+	return toOrganizationResponse(org), nil
+}
+
+func (s *organizationService) GetOrganization(ctx context.Context, actorID, orgID uuid.UUID) (*response.OrganizationResponse, error) {
+	if _, err := s.requireRole(ctx, orgID, actorID, entities.OrgRoleMember); err != nil {
+		return nil, err
+	}
+
+	org, err := s.orgRepo.GetByID(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	return toOrganizationResponse(org), nil
+}
+
+func (s *organizationService) ListUserOrganizations(ctx context.Context, userID uuid.UUID) (*response.OrganizationsListResponse, error) {
+	orgs, err := s.orgRepo.ListByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	orgResponses := make([]*response.OrganizationResponse, len(orgs))
+	for i, org := range orgs {
+		orgResponses[i] = toOrganizationResponse(org)
+	}
+
+	return &response.OrganizationsListResponse{
+		Organizations: orgResponses,
+	}, nil
+}
+
+func (s *organizationService) UpdateOrganization(ctx context.Context, req *request.UpdateOrganizationRequest) (*response.OrganizationResponse, error) {
+	if _, err := s.requireRole(ctx, req.OrganizationID, req.ActorID, entities.OrgRoleAdmin); err != nil {
+		return nil, err
+	}
+
+	org, err := s.orgRepo.GetByID(ctx, req.OrganizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Name != nil {
+		org.Name = utils.SanitizeString(*req.Name)
+	}
+
+	if req.Slug != nil {
+		slug := strings.ToLower(strings.TrimSpace(*req.Slug))
+		if !utils.IsValidSlug(slug) {
+			return nil, errors.Validation("invalid organization slug format")
+		}
+		org.Slug = slug
+	}
+
+	if req.Description != nil {
+		org.Description = req.Description
+	}
+
+	if err := s.orgRepo.Update(ctx, org); err != nil {
+		return nil, err
+	}
+
+	return toOrganizationResponse(org), nil
+}
+
+func (s *organizationService) DeleteOrganization(ctx context.Context, actorID, orgID uuid.UUID) error {
+	if _, err := s.requireRole(ctx, orgID, actorID, entities.OrgRoleOwner); err != nil {
+		return err
+	}
+
+	if err := s.orgRepo.Delete(ctx, orgID); err != nil {
+		return err
+	}
+
+	event := kafka.OrganizationDeletedEvent{
+		BaseEvent:      kafka.NewBaseEvent(ctx, kafka.TopicOrganizationDeleted),
+		OrganizationID: orgID,
+		DeletedBy:      actorID,
+	}
+
+	if err := s.producer.PublishMessage(ctx, kafka.TopicOrganizationDeleted, orgID.String(), event); err != nil {
+		s.logger.WithContext(ctx).WithError(err).Warn("failed to publish organization deleted event")
+	}
+
+	return nil
+}
+
+func (s *organizationService) ListMembers(ctx context.Context, actorID, orgID uuid.UUID) (*response.OrganizationMembersResponse, error) {
+	if _, err := s.requireRole(ctx, orgID, actorID, entities.OrgRoleMember); err != nil {
+		return nil, err
+	}
+
+	members, err := s.orgRepo.ListMembers(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	memberResponses := make([]*response.OrganizationMemberResponse, len(members))
+	for i, member := range members {
+		memberResponses[i] = &response.OrganizationMemberResponse{
+			UserID:    member.UserID,
+			Role:      member.Role,
+			CreatedAt: member.CreatedAt,
+		}
+	}
+
+	return &response.OrganizationMembersResponse{
+		OrganizationID: orgID,
+		Members:        memberResponses,
+	}, nil
+}
+
+func (s *organizationService) AddMember(ctx context.Context, req *request.AddOrganizationMemberRequest) error {
+	actor, err := s.requireRole(ctx, req.OrganizationID, req.ActorID, entities.OrgRoleAdmin)
+	if err != nil {
+		return err
+	}
+
+	if req.Role == entities.OrgRoleOwner && actor.Role != entities.OrgRoleOwner {
+		return errors.Forbidden("only owners can add owners")
+	}
+
+	if _, err := s.userRepo.GetByID(ctx, req.UserID); err != nil {
+		return err
+	}
+
+	if err := s.quotas.CheckMemberLimit(ctx, req.OrganizationID); err != nil {
+		return err
+	}
+
+	member := &entities.OrganizationMember{
+		ID:             uuid.New(),
+		OrganizationID: req.OrganizationID,
+		UserID:         req.UserID,
+		Role:           req.Role,
+	}
+
+	if err := s.orgRepo.AddMember(ctx, member); err != nil {
+		return err
+	}
+
+	s.publishMemberEvent(ctx, kafka.TopicOrganizationMemberAdded, req.OrganizationID, req.UserID, req.Role, req.ActorID)
+
+	return nil
+}
+
+func (s *organizationService) UpdateMemberRole(ctx context.Context, req *request.UpdateOrganizationMemberRequest) error {
+	actor, err := s.requireRole(ctx, req.OrganizationID, req.ActorID, entities.OrgRoleAdmin)
+	if err != nil {
+		return err
+	}
+
+	member, err := s.orgRepo.GetMember(ctx, req.OrganizationID, req.UserID)
+	if err != nil {
+		return err
+	}
+
+	if (member.Role == entities.OrgRoleOwner || req.Role == entities.OrgRoleOwner) && actor.Role != entities.OrgRoleOwner {
+		return errors.Forbidden("only owners can grant or revoke ownership")
+	}
+
+	if member.Role == entities.OrgRoleOwner && req.Role != entities.OrgRoleOwner {
+		if err := s.ensureAnotherOwner(ctx, req.OrganizationID); err != nil {
+			return err
+		}
+	}
+
+	if err := s.orgRepo.UpdateMemberRole(ctx, req.OrganizationID, req.UserID, req.Role); err != nil {
+		return err
+	}
+
+	s.publishMemberEvent(ctx, kafka.TopicOrganizationMemberUpdated, req.OrganizationID, req.UserID, req.Role, req.ActorID)
+
+	return nil
+}
+
+func (s *organizationService) RemoveMember(ctx context.Context, req *request.RemoveOrganizationMemberRequest) error {
+	member, err := s.orgRepo.GetMember(ctx, req.OrganizationID, req.UserID)
+	if err != nil {
+		return err
+	}
+
+	// Members may always leave; removing someone else requires admin rights.
+	if req.ActorID != req.UserID {
+		actor, err := s.requireRole(ctx, req.OrganizationID, req.ActorID, entities.OrgRoleAdmin)
+		if err != nil {
+			return err
+		}
+		if member.Role == entities.OrgRoleOwner && actor.Role != entities.OrgRoleOwner {
+			return errors.Forbidden("only owners can remove owners")
+		}
+	}
+
+	if member.Role == entities.OrgRoleOwner {
+		if err := s.ensureAnotherOwner(ctx, req.OrganizationID); err != nil {
+			return err
+		}
+	}
+
+	if err := s.orgRepo.RemoveMember(ctx, req.OrganizationID, req.UserID); err != nil {
+		return err
+	}
+
+	s.publishMemberEvent(ctx, kafka.TopicOrganizationMemberRemoved, req.OrganizationID, req.UserID, member.Role, req.ActorID)
+
+	return nil
+}
+
+func (s *organizationService) GetMembership(ctx context.Context, orgID, userID uuid.UUID) (*entities.OrganizationMember, error) {
+	org, err := s.orgRepo.GetByID(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !org.IsActive {
+		return nil, errors.Forbidden("organization is inactive")
+	}
+
+	return s.orgRepo.GetMember(ctx, orgID, userID)
+}
+
+func (s *organizationService) requireRole(ctx context.Context, orgID, userID uuid.UUID, minRole string) (*entities.OrganizationMember, error) {
+	member, err := s.orgRepo.GetMember(ctx, orgID, userID)
+	if err != nil {
+		if appErr, ok := err.(*errors.AppError); ok && appErr.Code == errors.CodeNotFound {
+			return nil, errors.Forbidden("not a member of the organization")
+		}
+		return nil, err
+	}
+
+	if orgRoleRank(member.Role) < orgRoleRank(minRole) {
+		return nil, errors.Forbidden("insufficient organization role")
+	}
+
+	return member, nil
+}
+
+func (s *organizationService) ensureAnotherOwner(ctx context.Context, orgID uuid.UUID) error {
+	owners, err := s.orgRepo.CountMembersByRole(ctx, orgID, entities.OrgRoleOwner)
+	if err != nil {
+		return err
+	}
+
+	if owners <= 1 {
+		return errors.Validation("organization must have at least one owner")
+	}
+
+	return nil
+}
+
+func (s *organizationService) publishMemberEvent(ctx context.Context, topic string, orgID, userID uuid.UUID, role string, actorID uuid.UUID) {
+	event := kafka.OrganizationMemberEvent{
+		BaseEvent:      kafka.NewBaseEvent(ctx, topic),
+		OrganizationID: orgID,
+		UserID:         userID,
+		Role:           role,
+		ActorID:        actorID,
+	}
+
+	if err := s.producer.PublishMessage(ctx, topic, orgID.String(), event); err != nil {
+		s.logger.WithContext(ctx).WithError(err).WithField("topic", topic).Warn("failed to publish organization member event")
+	}
+}
+
+func orgRoleRank(role string) int {
+	switch role {
+	case entities.OrgRoleOwner:
+		return 3
+	case entities.OrgRoleAdmin:
+		return 2
+	case entities.OrgRoleMember:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func toOrganizationResponse(org *entities.Organization) *response.OrganizationResponse {
+	return &response.OrganizationResponse{
+		ID:          org.ID,
+		Name:        org.Name,
+		Slug:        org.Slug,
+		Description: org.Description,
+		IsActive:    org.IsActive,
+		CreatedAt:   org.CreatedAt,
+		UpdatedAt:   org.UpdatedAt,
+	}
+}
@@ -0,0 +1,117 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/vagonaizer/authenitfication-service/internal/domain/entities"
+	"github.com/vagonaizer/authenitfication-service/internal/domain/repositories"
+	"github.com/vagonaizer/authenitfication-service/internal/infrastructure/database/redis"
+	"github.com/vagonaizer/authenitfication-service/pkg/logger"
+)
+
+type permissionService struct {
+	permissionRepo repositories.PermissionRepository
+	cache          *redis.LocalCache
+	logger         *logger.Logger
+	cacheTTL       time.Duration
+}
+
+func NewPermissionService(
+	permissionRepo repositories.PermissionRepository,
+	cache *redis.LocalCache,
+	logger *logger.Logger,
+	cacheTTL time.Duration,
+) *permissionService {
+	return &permissionService{
+		permissionRepo: permissionRepo,
+		cache:          cache,
+		logger:         logger,
+		cacheTTL:       cacheTTL,
+	}
+}
+
+func (s *permissionService) ResolvePermissions(ctx context.Context, roles []string) ([]string, error) {
+	unique := make(map[string]struct{})
+	for _, role := range roles {
+		grants, err := s.getRoleGrants(ctx, role)
+		if err != nil {
+			return nil, err
+		}
+		for _, grant := range grants {
+			if grant.Condition == "" {
+				unique[grant.Permission] = struct{}{}
+			}
+		}
+	}
+
+	result := make([]string, 0, len(unique))
+	for permission := range unique {
+		result = append(result, permission)
+	}
+	sort.Strings(result)
+
+	return result, nil
+}
+
+func (s *permissionService) HasPermission(ctx context.Context, roles []string, permission string) (bool, error) {
+	grants, err := s.GetGrants(ctx, roles, permission)
+	if err != nil {
+		return false, err
+	}
+
+	for _, grant := range grants {
+		if grant.Condition == "" {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+func (s *permissionService) GetGrants(ctx context.Context, roles []string, permission string) ([]*entities.PermissionGrant, error) {
+	var result []*entities.PermissionGrant
+	for _, role := range roles {
+		grants, err := s.getRoleGrants(ctx, role)
+		if err != nil {
+			return nil, err
+		}
+		for _, grant := range grants {
+			if grant.Permission == permission {
+				result = append(result, grant)
+			}
+		}
+	}
+
+	return result, nil
+}
+
+func (s *permissionService) InvalidateRole(ctx context.Context, roleName string) error {
+	return s.cache.Delete(ctx, rolePermissionsCacheKey(roleName))
+}
+
+func (s *permissionService) getRoleGrants(ctx context.Context, roleName string) ([]*entities.PermissionGrant, error) {
+	key := rolePermissionsCacheKey(roleName)
+
+	var cached []*entities.PermissionGrant
+	if err := s.cache.Get(ctx, key, &cached); err == nil {
+		return cached, nil
+	}
+
+	grants, err := s.permissionRepo.GetGrantsByRoleName(ctx, roleName)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.cache.Set(ctx, key, grants, s.cacheTTL); err != nil {
+		s.logger.WithContext(ctx).WithError(err).WithField("role", roleName).Warn("failed to cache role permissions")
+	}
+
+	return grants, nil
+}
+
+func rolePermissionsCacheKey(roleName string) string {
+	return fmt.Sprintf("role_permissions:%s", roleName)
+}
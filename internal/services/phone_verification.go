@@ -0,0 +1,140 @@
+package services
+
+import (
+	"context"
+	"crypto/subtle"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/vagonaizer/authenitfication-service/internal/infrastructure/messaging/kafka"
+	"github.com/vagonaizer/authenitfication-service/pkg/errors"
+	"github.com/vagonaizer/authenitfication-service/pkg/utils"
+)
+
+const phoneCodeLength = 6
+
+// pendingPhone is the number awaiting verification and the hash of the code
+// sent to it.
+type pendingPhone struct {
+	Phone    string `json:"phone"`
+	CodeHash string `json:"code_hash"`
+}
+
+func (s *verificationService) StartPhoneVerification(ctx context.Context, userID uuid.UUID, phone string) error {
+	phone = utils.NormalizePhone(phone)
+	if !utils.IsValidPhone(phone) {
+		return errors.Validation("phone must be in international format, e.g. +14155550123")
+	}
+
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	if user.Phone != nil && *user.Phone == phone {
+		return errors.Validation("phone number is already verified")
+	}
+
+	exists, err := s.userRepo.ExistsByPhone(ctx, phone)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return errors.PhoneExists()
+	}
+
+	if s.resendLimit > 0 {
+		sent, err := s.cache.IncrementCounter(ctx, phoneResendKey(userID), s.resendWindow)
+		if err != nil {
+			// Do not block verification when Redis is unavailable.
+			s.logger.WithContext(ctx).WithError(err).WithField("user_id", userID).Warn("failed to count phone verification codes")
+		} else if sent > int64(s.resendLimit) {
+			return errors.RateLimitExceeded()
+		}
+	}
+
+	code, err := utils.GenerateNumericCode(phoneCodeLength)
+	if err != nil {
+		s.logger.WithContext(ctx).WithError(err).Error("failed to generate phone verification code")
+		return errors.Internal("failed to generate verification code")
+	}
+
+	// A new code replaces the previous one and resets the attempt counter.
+	pending := pendingPhone{Phone: phone, CodeHash: utils.HashSHA256(code)}
+	if err := s.cache.Set(ctx, phonePendingKey(userID), pending, s.phoneCodeTTL); err != nil {
+		s.logger.WithContext(ctx).WithError(err).WithField("user_id", userID).Error("failed to store phone verification code")
+		return errors.Internal("failed to issue verification code")
+	}
+	if err := s.cache.Delete(ctx, phoneAttemptsKey(userID)); err != nil {
+		s.logger.WithContext(ctx).WithError(err).WithField("user_id", userID).Warn("failed to reset phone verification attempts")
+	}
+
+	if err := s.notifications.SendPhoneVerificationCode(ctx, userID.String(), phone, user.Locale, code); err != nil {
+		s.logger.WithContext(ctx).WithError(err).WithField("user_id", userID).Error("failed to send phone verification code")
+		return errors.Internal("failed to send verification code")
+	}
+
+	return nil
+}
+
+func (s *verificationService) VerifyPhone(ctx context.Context, userID uuid.UUID, code string) error {
+	var pending pendingPhone
+	if err := s.cache.Get(ctx, phonePendingKey(userID), &pending); err != nil {
+		return errors.Validation("invalid or expired verification code")
+	}
+
+	if s.phoneAttempts > 0 {
+		attempts, err := s.cache.IncrementCounter(ctx, phoneAttemptsKey(userID), s.phoneCodeTTL)
+		if err != nil {
+			s.logger.WithContext(ctx).WithError(err).WithField("user_id", userID).Warn("failed to count phone verification attempts")
+		} else if attempts > int64(s.phoneAttempts) {
+			s.clearPendingPhone(ctx, userID)
+			return errors.RateLimitExceeded()
+		}
+	}
+
+	if subtle.ConstantTimeCompare([]byte(utils.HashSHA256(code)), []byte(pending.CodeHash)) != 1 {
+		return errors.Validation("invalid or expired verification code")
+	}
+
+	if err := s.userRepo.SetPhone(ctx, userID, &pending.Phone); err != nil {
+		return err
+	}
+
+	s.clearPendingPhone(ctx, userID)
+
+	event := kafka.UserPhoneVerifiedEvent{
+		BaseEvent: kafka.NewBaseEvent(ctx, kafka.TopicUserPhoneVerified),
+		UserID:    userID,
+		Phone:     pending.Phone,
+	}
+
+	if err := s.producer.PublishMessage(ctx, kafka.TopicUserPhoneVerified, userID.String(), event); err != nil {
+		s.logger.WithContext(ctx).WithError(err).Warn("failed to publish user phone verified event")
+	}
+
+	return nil
+}
+
+func (s *verificationService) RemovePhone(ctx context.Context, userID uuid.UUID) error {
+	s.clearPendingPhone(ctx, userID)
+	return s.userRepo.SetPhone(ctx, userID, nil)
+}
+
+func (s *verificationService) clearPendingPhone(ctx context.Context, userID uuid.UUID) {
+	if err := s.cache.Delete(ctx, phonePendingKey(userID), phoneAttemptsKey(userID)); err != nil {
+		s.logger.WithContext(ctx).WithError(err).WithField("user_id", userID).Warn("failed to delete phone verification code")
+	}
+}
+
+func phonePendingKey(userID uuid.UUID) string {
+	return fmt.Sprintf("phone_verification:%s", userID)
+}
+
+func phoneAttemptsKey(userID uuid.UUID) string {
+	return fmt.Sprintf("phone_verification_attempts:%s", userID)
+}
+
+func phoneResendKey(userID uuid.UUID) string {
+	return fmt.Sprintf("phone_verification_resend:%s", userID)
+}
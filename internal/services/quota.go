@@ -0,0 +1,260 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/vagonaizer/authenitfication-service/internal/domain/entities"
+	"github.com/vagonaizer/authenitfication-service/internal/domain/repositories"
+	"github.com/vagonaizer/authenitfication-service/internal/dto/request"
+	"github.com/vagonaizer/authenitfication-service/internal/dto/response"
+	"github.com/vagonaizer/authenitfication-service/internal/infrastructure/database/redis"
+	"github.com/vagonaizer/authenitfication-service/internal/infrastructure/messaging"
+	"github.com/vagonaizer/authenitfication-service/internal/infrastructure/messaging/kafka"
+	"github.com/vagonaizer/authenitfication-service/pkg/errors"
+	"github.com/vagonaizer/authenitfication-service/pkg/logger"
+)
+
+// QuotaDefaults are the limits of organizations without an override.
+// Zero means unlimited.
+type QuotaDefaults struct {
+	MaxMembers         int
+	MaxSessionsPerUser int
+	TokensPerMinute    int
+}
+
+type quotaService struct {
+	orgRepo     repositories.OrganizationRepository
+	sessionRepo repositories.SessionRepository
+	cache       *redis.CacheService
+	producer    messaging.Publisher
+	logger      *logger.Logger
+	defaults    QuotaDefaults
+	cacheTTL    time.Duration
+}
+
+func NewQuotaService(
+	orgRepo repositories.OrganizationRepository,
+	sessionRepo repositories.SessionRepository,
+	cache *redis.CacheService,
+	producer messaging.Publisher,
+	logger *logger.Logger,
+	defaults QuotaDefaults,
+	cacheTTL time.Duration,
+) *quotaService {
+	return &quotaService{
+		orgRepo:     orgRepo,
+		sessionRepo: sessionRepo,
+		cache:       cache,
+		producer:    producer,
+		logger:      logger,
+		defaults:    defaults,
+		cacheTTL:    cacheTTL,
+	}
+}
+
+func (s *quotaService) GetQuota(ctx context.Context, orgID uuid.UUID) (*response.OrganizationQuotaResponse, error) {
+	if _, err := s.orgRepo.GetByID(ctx, orgID); err != nil {
+		return nil, err
+	}
+
+	quota, err := s.getQuota(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	members, err := s.orgRepo.CountMembers(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &response.OrganizationQuotaResponse{
+		OrganizationID:     orgID,
+		MaxMembers:         limitOrDefault(quota.MaxMembers, s.defaults.MaxMembers),
+		MaxSessionsPerUser: limitOrDefault(quota.MaxSessionsPerUser, s.defaults.MaxSessionsPerUser),
+		TokensPerMinute:    limitOrDefault(quota.TokensPerMinute, s.defaults.TokensPerMinute),
+		Members:            members,
+	}
+
+	if !quota.CreatedAt.IsZero() {
+		result.IsOverridden = true
+		result.UpdatedBy = quota.UpdatedBy
+		result.UpdatedAt = &quota.UpdatedAt
+	}
+
+	return result, nil
+}
+
+func (s *quotaService) UpdateQuota(ctx context.Context, req *request.UpdateOrganizationQuotaRequest) (*response.OrganizationQuotaResponse, error) {
+	if _, err := s.orgRepo.GetByID(ctx, req.OrganizationID); err != nil {
+		return nil, err
+	}
+
+	actorID := req.ActorID
+	quota := &entities.OrganizationQuota{
+		OrganizationID:     req.OrganizationID,
+		MaxMembers:         req.MaxMembers,
+		MaxSessionsPerUser: req.MaxSessionsPerUser,
+		TokensPerMinute:    req.TokensPerMinute,
+		UpdatedBy:          &actorID,
+	}
+
+	if err := s.orgRepo.UpsertQuota(ctx, quota); err != nil {
+		return nil, err
+	}
+
+	if err := s.cache.Delete(ctx, quotaCacheKey(req.OrganizationID)); err != nil {
+		s.logger.WithContext(ctx).WithError(err).WithField("organization_id", req.OrganizationID).Warn("failed to invalidate cached organization quota")
+	}
+
+	event := kafka.OrganizationQuotaUpdatedEvent{
+		BaseEvent:          kafka.NewBaseEvent(ctx, kafka.TopicOrganizationQuotaUpdated),
+		OrganizationID:     req.OrganizationID,
+		MaxMembers:         req.MaxMembers,
+		MaxSessionsPerUser: req.MaxSessionsPerUser,
+		TokensPerMinute:    req.TokensPerMinute,
+		ActorID:            req.ActorID,
+	}
+
+	if err := s.producer.PublishMessage(ctx, kafka.TopicOrganizationQuotaUpdated, req.OrganizationID.String(), event); err != nil {
+		s.logger.WithContext(ctx).WithError(err).Warn("failed to publish organization quota updated event")
+	}
+
+	return s.GetQuota(ctx, req.OrganizationID)
+}
+
+func (s *quotaService) CheckMemberLimit(ctx context.Context, orgID uuid.UUID) error {
+	quota, err := s.getQuota(ctx, orgID)
+	if err != nil {
+		return err
+	}
+
+	limit := limitOrDefault(quota.MaxMembers, s.defaults.MaxMembers)
+	if limit == 0 {
+		return nil
+	}
+
+	members, err := s.orgRepo.CountMembers(ctx, orgID)
+	if err != nil {
+		return err
+	}
+
+	if members >= limit {
+		return s.exceeded(ctx, orgID, entities.QuotaMaxMembers, limit, nil)
+	}
+
+	return nil
+}
+
+func (s *quotaService) CheckSessionLimit(ctx context.Context, orgID, userID, sessionID uuid.UUID) error {
+	quota, err := s.getQuota(ctx, orgID)
+	if err != nil {
+		return err
+	}
+
+	limit := limitOrDefault(quota.MaxSessionsPerUser, s.defaults.MaxSessionsPerUser)
+	if limit == 0 {
+		return nil
+	}
+
+	sessions, err := s.sessionRepo.GetActiveByUserID(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	count := 0
+	for _, session := range sessions {
+		if session.ID != sessionID && session.OrganizationID != nil && *session.OrganizationID == orgID {
+			count++
+		}
+	}
+
+	if count >= limit {
+		return s.exceeded(ctx, orgID, entities.QuotaMaxSessionsPerUser, limit, &userID)
+	}
+
+	return nil
+}
+
+func (s *quotaService) CheckTokenRate(ctx context.Context, orgID uuid.UUID) error {
+	quota, err := s.getQuota(ctx, orgID)
+	if err != nil {
+		return err
+	}
+
+	limit := limitOrDefault(quota.TokensPerMinute, s.defaults.TokensPerMinute)
+	if limit == 0 {
+		return nil
+	}
+
+	window := time.Now().Unix() / 60
+	key := fmt.Sprintf("org_token_rate:%s:%d", orgID, window)
+
+	issued, err := s.cache.IncrementCounter(ctx, key, 2*time.Minute)
+	if err != nil {
+		// Do not lock tenants out when Redis is unavailable.
+		s.logger.WithContext(ctx).WithError(err).WithField("organization_id", orgID).Warn("failed to count issued tokens")
+		return nil
+	}
+
+	if issued > int64(limit) {
+		return s.exceeded(ctx, orgID, entities.QuotaTokensPerMinute, limit, nil)
+	}
+
+	return nil
+}
+
+// getQuota returns the overrides of an organization, or an empty quota when
+// it has none. Both cases are cached.
+func (s *quotaService) getQuota(ctx context.Context, orgID uuid.UUID) (*entities.OrganizationQuota, error) {
+	key := quotaCacheKey(orgID)
+
+	var cached entities.OrganizationQuota
+	if err := s.cache.Get(ctx, key, &cached); err == nil {
+		return &cached, nil
+	}
+
+	quota, err := s.orgRepo.GetQuota(ctx, orgID)
+	if err != nil {
+		appErr, ok := err.(*errors.AppError)
+		if !ok || appErr.Code != errors.CodeNotFound {
+			return nil, err
+		}
+		quota = &entities.OrganizationQuota{OrganizationID: orgID}
+	}
+
+	if err := s.cache.Set(ctx, key, quota, s.cacheTTL); err != nil {
+		s.logger.WithContext(ctx).WithError(err).WithField("organization_id", orgID).Warn("failed to cache organization quota")
+	}
+
+	return quota, nil
+}
+
+func (s *quotaService) exceeded(ctx context.Context, orgID uuid.UUID, quota string, limit int, userID *uuid.UUID) error {
+	event := kafka.OrganizationQuotaExceededEvent{
+		BaseEvent:      kafka.NewBaseEvent(ctx, kafka.TopicOrganizationQuotaExceeded),
+		OrganizationID: orgID,
+		Quota:          quota,
+		Limit:          limit,
+		UserID:         userID,
+	}
+
+	if err := s.producer.PublishMessage(ctx, kafka.TopicOrganizationQuotaExceeded, orgID.String(), event); err != nil {
+		s.logger.WithContext(ctx).WithError(err).Warn("failed to publish organization quota exceeded event")
+	}
+
+	return errors.QuotaExceeded(quota, limit)
+}
+
+func limitOrDefault(limit *int, defaultLimit int) int {
+	if limit != nil {
+		return *limit
+	}
+	return defaultLimit
+}
+
+func quotaCacheKey(orgID uuid.UUID) string {
+	return fmt.Sprintf("org_quota:%s", orgID)
+}
@@ -0,0 +1,270 @@
+package services
+
+import (
+	"context"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/vagonaizer/authenitfication-service/internal/domain/entities"
+	"github.com/vagonaizer/authenitfication-service/internal/domain/repositories"
+	"github.com/vagonaizer/authenitfication-service/internal/domain/services"
+	"github.com/vagonaizer/authenitfication-service/internal/dto/request"
+	"github.com/vagonaizer/authenitfication-service/internal/dto/response"
+	"github.com/vagonaizer/authenitfication-service/internal/infrastructure/messaging"
+	"github.com/vagonaizer/authenitfication-service/internal/infrastructure/messaging/kafka"
+	"github.com/vagonaizer/authenitfication-service/pkg/abac"
+	"github.com/vagonaizer/authenitfication-service/pkg/errors"
+	"github.com/vagonaizer/authenitfication-service/pkg/logger"
+	"github.com/vagonaizer/authenitfication-service/pkg/utils"
+)
+
+// systemRoles are relied upon by registration and bootstrap and cannot be renamed or deleted.
+var systemRoles = map[string]bool{
+	"admin": true,
+	"user":  true,
+}
+
+type roleService struct {
+	roleRepo          repositories.RoleRepository
+	permissionRepo    repositories.PermissionRepository
+	permissionService services.PermissionService
+	producer          messaging.Publisher
+	logger            *logger.Logger
+}
+
+func NewRoleService(
+	roleRepo repositories.RoleRepository,
+	permissionRepo repositories.PermissionRepository,
+	permissionService services.PermissionService,
+	producer messaging.Publisher,
+	logger *logger.Logger,
+) *roleService {
+	return &roleService{
+		roleRepo:          roleRepo,
+		permissionRepo:    permissionRepo,
+		permissionService: permissionService,
+		producer:          producer,
+		logger:            logger,
+	}
+}
+
+func (s *roleService) CreateRole(ctx context.Context, req *request.CreateRoleRequest) (*response.RoleResponse, error) {
+	name := utils.NormalizeRoleName(req.Name)
+	if !utils.IsValidRoleName(name) {
+		return nil, errors.Validation("invalid role name format")
+	}
+
+	role := &entities.Role{
+		ID:          uuid.New(),
+		Name:        name,
+		Description: req.Description,
+	}
+
+	if err := s.roleRepo.Create(ctx, role); err != nil {
+		return nil, err
+	}
+
+	event := kafka.RoleCreatedEvent{
+		BaseEvent:   kafka.NewBaseEvent(ctx, kafka.TopicRoleCreated),
+		RoleID:      role.ID,
+		Name:        role.Name,
+		Description: role.Description,
+	}
+
+	if err := s.producer.PublishMessage(ctx, kafka.TopicRoleCreated, role.ID.String(), event); err != nil {
+		s.logger.WithContext(ctx).WithError(err).Warn("failed to publish role created event")
+	}
+
+	return toRoleResponse(role), nil
+}
+
+func (s *roleService) GetRole(ctx context.Context, roleID uuid.UUID) (*response.RoleResponse, error) {
+	role, err := s.roleRepo.GetByID(ctx, roleID)
+	if err != nil {
+		return nil, err
+	}
+
+	return toRoleResponse(role), nil
+}
+
+func (s *roleService) ListRoles(ctx context.Context) (*response.RolesListResponse, error) {
+	roles, err := s.roleRepo.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	roleResponses := make([]*response.RoleResponse, len(roles))
+	for i, role := range roles {
+		roleResponses[i] = toRoleResponse(role)
+	}
+
+	return &response.RolesListResponse{
+		Roles: roleResponses,
+	}, nil
+}
+
+func (s *roleService) UpdateRole(ctx context.Context, req *request.UpdateRoleRequest) (*response.RoleResponse, error) {
+	role, err := s.roleRepo.GetByID(ctx, req.RoleID)
+	if err != nil {
+		return nil, err
+	}
+
+	previousName := role.Name
+
+	if req.Name != nil {
+		name := utils.NormalizeRoleName(*req.Name)
+		if !utils.IsValidRoleName(name) {
+			return nil, errors.Validation("invalid role name format")
+		}
+		if name != role.Name && systemRoles[role.Name] {
+			return nil, errors.Forbidden("system roles cannot be renamed")
+		}
+		role.Name = name
+	}
+
+	if req.Description != nil {
+		role.Description = req.Description
+	}
+
+	if err := s.roleRepo.Update(ctx, role); err != nil {
+		return nil, err
+	}
+
+	if previousName != role.Name {
+		if err := s.permissionService.InvalidateRole(ctx, previousName); err != nil {
+			s.logger.WithContext(ctx).WithError(err).WithField("role", previousName).Warn("failed to invalidate role permissions cache")
+		}
+	}
+
+	event := kafka.RoleUpdatedEvent{
+		BaseEvent:    kafka.NewBaseEvent(ctx, kafka.TopicRoleUpdated),
+		RoleID:       role.ID,
+		Name:         role.Name,
+		PreviousName: previousName,
+		Description:  role.Description,
+	}
+
+	if err := s.producer.PublishMessage(ctx, kafka.TopicRoleUpdated, role.ID.String(), event); err != nil {
+		s.logger.WithContext(ctx).WithError(err).Warn("failed to publish role updated event")
+	}
+
+	return toRoleResponse(role), nil
+}
+
+func (s *roleService) DeleteRole(ctx context.Context, roleID uuid.UUID) error {
+	role, err := s.roleRepo.GetByID(ctx, roleID)
+	if err != nil {
+		return err
+	}
+
+	if systemRoles[role.Name] {
+		return errors.Forbidden("system roles cannot be deleted")
+	}
+
+	if err := s.roleRepo.Delete(ctx, roleID); err != nil {
+		return err
+	}
+
+	if err := s.permissionService.InvalidateRole(ctx, role.Name); err != nil {
+		s.logger.WithContext(ctx).WithError(err).WithField("role", role.Name).Warn("failed to invalidate role permissions cache")
+	}
+
+	event := kafka.RoleDeletedEvent{
+		BaseEvent: kafka.NewBaseEvent(ctx, kafka.TopicRoleDeleted),
+		RoleID:    role.ID,
+		Name:      role.Name,
+	}
+
+	if err := s.producer.PublishMessage(ctx, kafka.TopicRoleDeleted, role.ID.String(), event); err != nil {
+		s.logger.WithContext(ctx).WithError(err).Warn("failed to publish role deleted event")
+	}
+
+	return nil
+}
+
+func (s *roleService) GetRolePermissions(ctx context.Context, roleID uuid.UUID) (*response.RolePermissionsResponse, error) {
+	if _, err := s.roleRepo.GetByID(ctx, roleID); err != nil {
+		return nil, err
+	}
+
+	grants, err := s.permissionRepo.GetRoleGrants(ctx, roleID)
+	if err != nil {
+		return nil, err
+	}
+
+	permissions := make([]*response.PermissionGrantResponse, len(grants))
+	for i, grant := range grants {
+		permissions[i] = &response.PermissionGrantResponse{
+			Permission: grant.Permission,
+			Condition:  grant.Condition,
+		}
+	}
+
+	return &response.RolePermissionsResponse{
+		RoleID:      roleID,
+		Permissions: permissions,
+	}, nil
+}
+
+func (s *roleService) GrantPermission(ctx context.Context, req *request.GrantPermissionRequest) error {
+	role, err := s.roleRepo.GetByID(ctx, req.RoleID)
+	if err != nil {
+		return err
+	}
+
+	permission, err := s.permissionRepo.GetByName(ctx, req.Permission)
+	if err != nil {
+		return err
+	}
+
+	var condition *string
+	if req.Condition != nil && strings.TrimSpace(*req.Condition) != "" {
+		trimmed := strings.TrimSpace(*req.Condition)
+		if err := abac.Validate(trimmed); err != nil {
+			return errors.Validation(err.Error())
+		}
+		condition = &trimmed
+	}
+
+	if err := s.permissionRepo.GrantToRole(ctx, role.ID, permission.ID, condition); err != nil {
+		return err
+	}
+
+	if err := s.permissionService.InvalidateRole(ctx, role.Name); err != nil {
+		s.logger.WithContext(ctx).WithError(err).WithField("role", role.Name).Warn("failed to invalidate role permissions cache")
+	}
+
+	return nil
+}
+
+func (s *roleService) RevokePermission(ctx context.Context, roleID uuid.UUID, permissionName string) error {
+	role, err := s.roleRepo.GetByID(ctx, roleID)
+	if err != nil {
+		return err
+	}
+
+	permission, err := s.permissionRepo.GetByName(ctx, permissionName)
+	if err != nil {
+		return err
+	}
+
+	if err := s.permissionRepo.RevokeFromRole(ctx, role.ID, permission.ID); err != nil {
+		return err
+	}
+
+	if err := s.permissionService.InvalidateRole(ctx, role.Name); err != nil {
+		s.logger.WithContext(ctx).WithError(err).WithField("role", role.Name).Warn("failed to invalidate role permissions cache")
+	}
+
+	return nil
+}
+
+func toRoleResponse(role *entities.Role) *response.RoleResponse {
+	return &response.RoleResponse{
+		ID:          role.ID,
+		Name:        role.Name,
+		Description: role.Description,
+		CreatedAt:   role.CreatedAt,
+		UpdatedAt:   role.UpdatedAt,
+	}
+}
@@ -0,0 +1,142 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/vagonaizer/authenitfication-service/internal/domain/entities"
+	"github.com/vagonaizer/authenitfication-service/internal/domain/repositories"
+	"github.com/vagonaizer/authenitfication-service/internal/infrastructure/database/redis"
+	"github.com/vagonaizer/authenitfication-service/pkg/logger"
+)
+
+// cachedRoleRepository keeps each user's global roles in Redis, since they are
+// resolved on every login, registration and token refresh. Scoped lookups go
+// straight to the database: a change to a global grant would otherwise have
+// to invalidate every scope the user was ever looked up in.
+//
+// Assignment changes made through this repository drop the affected users'
+// entries. Role renames and deletions, and grants moved by account merges,
+// are only picked up once the entry expires, so the TTL should stay short.
+// Entries are also kept in process memory; invalidation reaches the other
+// replicas through the local cache.
+type cachedRoleRepository struct {
+	repositories.RoleRepository
+	cache  *redis.LocalCache
+	logger *logger.Logger
+	ttl    time.Duration
+}
+
+func NewCachedRoleRepository(
+	roleRepo repositories.RoleRepository,
+	cache *redis.LocalCache,
+	logger *logger.Logger,
+	ttl time.Duration,
+) *cachedRoleRepository {
+	return &cachedRoleRepository{
+		RoleRepository: roleRepo,
+		cache:          cache,
+		logger:         logger,
+		ttl:            ttl,
+	}
+}
+
+func (r *cachedRoleRepository) GetUserRoles(ctx context.Context, userID uuid.UUID, scopeID *uuid.UUID) ([]*entities.Role, error) {
+	if scopeID != nil || r.ttl <= 0 {
+		return r.RoleRepository.GetUserRoles(ctx, userID, scopeID)
+	}
+
+	key := userRolesCacheKey(userID)
+
+	var cached []*entities.Role
+	if err := r.cache.Get(ctx, key, &cached); err == nil {
+		return cached, nil
+	}
+
+	roles, err := r.RoleRepository.GetUserRoles(ctx, userID, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := r.cache.Set(ctx, key, roles, r.ttl); err != nil {
+		r.logger.WithContext(ctx).WithError(err).WithField("user_id", userID).Warn("failed to cache user roles")
+	}
+
+	return roles, nil
+}
+
+func (r *cachedRoleRepository) AssignRoleToUser(ctx context.Context, userID, roleID uuid.UUID, scopeID *uuid.UUID, expiresAt *time.Time) error {
+	if err := r.RoleRepository.AssignRoleToUser(ctx, userID, roleID, scopeID, expiresAt); err != nil {
+		return err
+	}
+
+	r.invalidate(ctx, userID)
+	return nil
+}
+
+func (r *cachedRoleRepository) RemoveRoleFromUser(ctx context.Context, userID, roleID uuid.UUID, scopeID *uuid.UUID) error {
+	if err := r.RoleRepository.RemoveRoleFromUser(ctx, userID, roleID, scopeID); err != nil {
+		return err
+	}
+
+	r.invalidate(ctx, userID)
+	return nil
+}
+
+func (r *cachedRoleRepository) BulkAssignRole(ctx context.Context, change *entities.BulkRoleChange) ([]uuid.UUID, error) {
+	affected, err := r.RoleRepository.BulkAssignRole(ctx, change)
+	if err != nil {
+		return nil, err
+	}
+
+	r.invalidate(ctx, affected...)
+	return affected, nil
+}
+
+func (r *cachedRoleRepository) BulkRemoveRole(ctx context.Context, change *entities.BulkRoleChange) ([]uuid.UUID, error) {
+	affected, err := r.RoleRepository.BulkRemoveRole(ctx, change)
+	if err != nil {
+		return nil, err
+	}
+
+	r.invalidate(ctx, affected...)
+	return affected, nil
+}
+
+func (r *cachedRoleRepository) DeleteExpiredUserRoles(ctx context.Context, before time.Time, limit int) ([]*entities.ExpiredRoleAssignment, error) {
+	expired, err := r.RoleRepository.DeleteExpiredUserRoles(ctx, before, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	userIDs := make([]uuid.UUID, len(expired))
+	for i, assignment := range expired {
+		userIDs[i] = assignment.UserID
+	}
+	r.invalidate(ctx, userIDs...)
+
+	return expired, nil
+}
+
+// invalidate is best effort: the write has already succeeded, and a stale
+// entry is bounded by the TTL.
+func (r *cachedRoleRepository) invalidate(ctx context.Context, userIDs ...uuid.UUID) {
+	if len(userIDs) == 0 || r.ttl <= 0 {
+		return
+	}
+
+	keys := make([]string, len(userIDs))
+	for i, userID := range userIDs {
+		keys[i] = userRolesCacheKey(userID)
+	}
+
+	if err := r.cache.Delete(ctx, keys...); err != nil {
+		r.logger.WithContext(ctx).WithError(err).WithField("users", len(userIDs)).Warn("failed to invalidate cached user roles")
+	}
+}
+
+func userRolesCacheKey(userID uuid.UUID) string {
+	return fmt.Sprintf("user_roles:%s", userID)
+}
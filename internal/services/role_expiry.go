@@ -0,0 +1,85 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/vagonaizer/authenitfication-service/internal/domain/entities"
+	"github.com/vagonaizer/authenitfication-service/internal/domain/repositories"
+	"github.com/vagonaizer/authenitfication-service/internal/infrastructure/messaging"
+	"github.com/vagonaizer/authenitfication-service/internal/infrastructure/messaging/kafka"
+	"github.com/vagonaizer/authenitfication-service/pkg/logger"
+)
+
+// RoleExpirySweeper periodically removes time-bound role assignments that
+// have expired and publishes a role_expired event for each of them.
+type RoleExpirySweeper struct {
+	roleRepo      repositories.RoleRepository
+	roleAuditRepo repositories.RoleAuditRepository
+	producer      messaging.Publisher
+	logger        *logger.Logger
+	batchSize     int
+}
+
+func NewRoleExpirySweeper(
+	roleRepo repositories.RoleRepository,
+	roleAuditRepo repositories.RoleAuditRepository,
+	producer messaging.Publisher,
+	logger *logger.Logger,
+	batchSize int,
+) *RoleExpirySweeper {
+	return &RoleExpirySweeper{
+		roleRepo:      roleRepo,
+		roleAuditRepo: roleAuditRepo,
+		producer:      producer,
+		logger:        logger,
+		batchSize:     batchSize,
+	}
+}
+
+// Sweep removes expired assignments in batches until none are left.
+func (s *RoleExpirySweeper) Sweep(ctx context.Context) {
+	for ctx.Err() == nil {
+		expired, err := s.roleRepo.DeleteExpiredUserRoles(ctx, time.Now(), s.batchSize)
+		if err != nil {
+			s.logger.WithContext(ctx).WithError(err).Error("failed to delete expired role assignments")
+			return
+		}
+
+		for _, assignment := range expired {
+			expiresAt := assignment.ExpiresAt
+			audit := &entities.RoleAssignmentAudit{
+				UserID:    assignment.UserID,
+				RoleID:    assignment.RoleID,
+				RoleName:  assignment.RoleName,
+				ScopeID:   assignment.ScopeID,
+				Action:    entities.RoleAuditActionExpired,
+				ExpiresAt: &expiresAt,
+			}
+			if err := s.roleAuditRepo.Create(ctx, audit); err != nil {
+				s.logger.WithContext(ctx).WithError(err).Error("failed to record role expiry audit entry")
+			}
+
+			event := kafka.RoleExpiredEvent{
+				BaseEvent: kafka.NewBaseEvent(ctx, kafka.TopicRoleExpired),
+				UserID:    assignment.UserID,
+				RoleID:    assignment.RoleID,
+				RoleName:  assignment.RoleName,
+				ScopeID:   assignment.ScopeID,
+				ExpiresAt: assignment.ExpiresAt,
+			}
+
+			if err := s.producer.PublishMessage(ctx, kafka.TopicRoleExpired, assignment.UserID.String(), event); err != nil {
+				s.logger.WithContext(ctx).WithError(err).Warn("failed to publish role expired event")
+			}
+		}
+
+		if len(expired) > 0 {
+			s.logger.WithContext(ctx).Infof("removed %d expired role assignments", len(expired))
+		}
+
+		if len(expired) < s.batchSize {
+			return
+		}
+	}
+}
@@ -0,0 +1,293 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/vagonaizer/authenitfication-service/internal/domain/entities"
+	"github.com/vagonaizer/authenitfication-service/internal/domain/repositories"
+	"github.com/vagonaizer/authenitfication-service/internal/dto/request"
+	"github.com/vagonaizer/authenitfication-service/internal/dto/response"
+	"github.com/vagonaizer/authenitfication-service/internal/infrastructure/messaging"
+	"github.com/vagonaizer/authenitfication-service/internal/infrastructure/messaging/kafka"
+	"github.com/vagonaizer/authenitfication-service/pkg/errors"
+	"github.com/vagonaizer/authenitfication-service/pkg/logger"
+	"github.com/vagonaizer/authenitfication-service/pkg/utils"
+)
+
+// serviceAccountEmailDomain gives service accounts a unique, undeliverable
+// address since users.email is required.
+const serviceAccountEmailDomain = "service-accounts.local"
+
+type serviceAccountService struct {
+	userRepo           repositories.UserRepository
+	serviceAccountRepo repositories.ServiceAccountRepository
+	producer           messaging.Publisher
+	logger             *logger.Logger
+}
+
+func NewServiceAccountService(
+	userRepo repositories.UserRepository,
+	serviceAccountRepo repositories.ServiceAccountRepository,
+	producer messaging.Publisher,
+	logger *logger.Logger,
+) *serviceAccountService {
+	return &serviceAccountService{
+		userRepo:           userRepo,
+		serviceAccountRepo: serviceAccountRepo,
+		producer:           producer,
+		logger:             logger,
+	}
+}
+
+func (s *serviceAccountService) CreateServiceAccount(ctx context.Context, req *request.CreateServiceAccountRequest) (*response.ServiceAccountCredentialsResponse, error) {
+	name := utils.NormalizeUsername(req.Name)
+	if !utils.IsValidUsername(name) {
+		return nil, errors.Validation("invalid service account name format")
+	}
+
+	exists, err := s.userRepo.ExistsByUsername(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	if exists {
+		return nil, errors.UsernameExists()
+	}
+
+	account := &entities.User{
+		ID:               uuid.New(),
+		Email:            fmt.Sprintf("%s@%s", name, serviceAccountEmailDomain),
+		Username:         name,
+		IsActive:         true,
+		IsVerified:       true,
+		IsServiceAccount: true,
+	}
+
+	if err := s.userRepo.Create(ctx, account); err != nil {
+		return nil, err
+	}
+
+	key, secret, err := s.newKey(account.ID, req.ActorID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.serviceAccountRepo.CreateKey(ctx, key); err != nil {
+		return nil, err
+	}
+
+	event := kafka.ServiceAccountEvent{
+		BaseEvent:        kafka.NewBaseEvent(ctx, kafka.TopicServiceAccountCreated),
+		ServiceAccountID: account.ID,
+		Name:             account.Username,
+		ActorID:          req.ActorID,
+	}
+
+	if err := s.producer.PublishMessage(ctx, kafka.TopicServiceAccountCreated, account.ID.String(), event); err != nil {
+		s.logger.WithContext(ctx).WithError(err).Warn("failed to publish service account created event")
+	}
+
+	return toServiceAccountCredentials(key, secret), nil
+}
+
+func (s *serviceAccountService) GetServiceAccount(ctx context.Context, id uuid.UUID) (*response.ServiceAccountResponse, error) {
+	account, err := s.getServiceAccount(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	keys, err := s.serviceAccountRepo.ListKeys(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	result := toServiceAccountResponse(account)
+	result.Keys = make([]*response.ServiceAccountKeyResponse, len(keys))
+	for i, key := range keys {
+		result.Keys[i] = &response.ServiceAccountKeyResponse{
+			KeyID:      key.KeyID,
+			CreatedBy:  key.CreatedBy,
+			LastUsedAt: key.LastUsedAt,
+			RevokedAt:  key.RevokedAt,
+			CreatedAt:  key.CreatedAt,
+		}
+	}
+
+	return result, nil
+}
+
+func (s *serviceAccountService) ListServiceAccounts(ctx context.Context, page, pageSize int) (*response.ServiceAccountsListResponse, error) {
+	offset := (page - 1) * pageSize
+
+	accounts, err := s.serviceAccountRepo.List(ctx, pageSize, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	accountResponses := make([]*response.ServiceAccountResponse, len(accounts))
+	for i, account := range accounts {
+		accountResponses[i] = toServiceAccountResponse(account)
+	}
+
+	return &response.ServiceAccountsListResponse{
+		ServiceAccounts: accountResponses,
+		Page:            page,
+		PageSize:        pageSize,
+	}, nil
+}
+
+func (s *serviceAccountService) DeleteServiceAccount(ctx context.Context, actorID, id uuid.UUID) error {
+	account, err := s.getServiceAccount(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if err := s.serviceAccountRepo.RevokeAllKeys(ctx, id); err != nil {
+		return err
+	}
+
+	if err := s.userRepo.Delete(ctx, id); err != nil {
+		return err
+	}
+
+	event := kafka.ServiceAccountEvent{
+		BaseEvent:        kafka.NewBaseEvent(ctx, kafka.TopicServiceAccountDeleted),
+		ServiceAccountID: id,
+		Name:             account.Username,
+		ActorID:          actorID,
+	}
+
+	if err := s.producer.PublishMessage(ctx, kafka.TopicServiceAccountDeleted, id.String(), event); err != nil {
+		s.logger.WithContext(ctx).WithError(err).Warn("failed to publish service account deleted event")
+	}
+
+	return nil
+}
+
+func (s *serviceAccountService) CreateKey(ctx context.Context, actorID, id uuid.UUID) (*response.ServiceAccountCredentialsResponse, error) {
+	if _, err := s.getServiceAccount(ctx, id); err != nil {
+		return nil, err
+	}
+
+	key, secret, err := s.newKey(id, actorID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.serviceAccountRepo.CreateKey(ctx, key); err != nil {
+		return nil, err
+	}
+
+	s.publishKeyEvent(ctx, kafka.TopicServiceAccountKeyCreated, id, key.KeyID, false, actorID)
+
+	return toServiceAccountCredentials(key, secret), nil
+}
+
+func (s *serviceAccountService) RotateKey(ctx context.Context, actorID, id uuid.UUID) (*response.ServiceAccountCredentialsResponse, error) {
+	if _, err := s.getServiceAccount(ctx, id); err != nil {
+		return nil, err
+	}
+
+	key, secret, err := s.newKey(id, actorID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.serviceAccountRepo.RotateKey(ctx, key); err != nil {
+		return nil, err
+	}
+
+	s.publishKeyEvent(ctx, kafka.TopicServiceAccountKeyCreated, id, key.KeyID, true, actorID)
+
+	return toServiceAccountCredentials(key, secret), nil
+}
+
+func (s *serviceAccountService) RevokeKey(ctx context.Context, actorID, id uuid.UUID, keyID string) error {
+	if _, err := s.getServiceAccount(ctx, id); err != nil {
+		return err
+	}
+
+	if err := s.serviceAccountRepo.RevokeKey(ctx, id, keyID); err != nil {
+		return err
+	}
+
+	s.publishKeyEvent(ctx, kafka.TopicServiceAccountKeyRevoked, id, keyID, false, actorID)
+
+	return nil
+}
+
+// getServiceAccount loads a user and hides regular accounts behind a not
+// found error so these endpoints cannot be used on human users.
+func (s *serviceAccountService) getServiceAccount(ctx context.Context, id uuid.UUID) (*entities.User, error) {
+	account, err := s.userRepo.GetByID(ctx, id)
+	if err != nil {
+		if appErr, ok := err.(*errors.AppError); ok && appErr.Code == errors.CodeUserNotFound {
+			return nil, errors.NotFound("service account not found")
+		}
+		return nil, err
+	}
+
+	if !account.IsServiceAccount {
+		return nil, errors.NotFound("service account not found")
+	}
+
+	return account, nil
+}
+
+func (s *serviceAccountService) newKey(userID, actorID uuid.UUID) (*entities.ServiceAccountKey, string, error) {
+	keyID, err := utils.GenerateRandomString(16)
+	if err != nil {
+		s.logger.WithError(err).Error("failed to generate service account key id")
+		return nil, "", errors.Internal("failed to generate credentials")
+	}
+
+	secret, err := utils.GenerateSecureToken()
+	if err != nil {
+		s.logger.WithError(err).Error("failed to generate service account secret")
+		return nil, "", errors.Internal("failed to generate credentials")
+	}
+
+	key := &entities.ServiceAccountKey{
+		ID:         uuid.New(),
+		UserID:     userID,
+		KeyID:      "sa_" + keyID,
+		SecretHash: utils.HashSHA256(secret),
+		CreatedBy:  &actorID,
+	}
+
+	return key, secret, nil
+}
+
+func (s *serviceAccountService) publishKeyEvent(ctx context.Context, topic string, id uuid.UUID, keyID string, rotated bool, actorID uuid.UUID) {
+	event := kafka.ServiceAccountKeyEvent{
+		BaseEvent:        kafka.NewBaseEvent(ctx, topic),
+		ServiceAccountID: id,
+		KeyID:            keyID,
+		Rotated:          rotated,
+		ActorID:          actorID,
+	}
+
+	if err := s.producer.PublishMessage(ctx, topic, id.String(), event); err != nil {
+		s.logger.WithContext(ctx).WithError(err).WithField("topic", topic).Warn("failed to publish service account key event")
+	}
+}
+
+func toServiceAccountResponse(account *entities.User) *response.ServiceAccountResponse {
+	return &response.ServiceAccountResponse{
+		ID:        account.ID,
+		Name:      account.Username,
+		IsActive:  account.IsActive,
+		CreatedAt: account.CreatedAt,
+		UpdatedAt: account.UpdatedAt,
+	}
+}
+
+func toServiceAccountCredentials(key *entities.ServiceAccountKey, secret string) *response.ServiceAccountCredentialsResponse {
+	return &response.ServiceAccountCredentialsResponse{
+		ServiceAccountID: key.UserID,
+		KeyID:            key.KeyID,
+		Secret:           secret,
+		CreatedAt:        key.CreatedAt,
+	}
+}
@@ -0,0 +1,52 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/vagonaizer/authenitfication-service/internal/domain/repositories"
+	"github.com/vagonaizer/authenitfication-service/internal/infrastructure/metrics"
+	"github.com/vagonaizer/authenitfication-service/pkg/logger"
+)
+
+// SessionCleanupSweeper deletes expired sessions.
+type SessionCleanupSweeper struct {
+	sessionRepo repositories.SessionRepository
+	logger      *logger.Logger
+	batchSize   int
+}
+
+func NewSessionCleanupSweeper(
+	sessionRepo repositories.SessionRepository,
+	logger *logger.Logger,
+	batchSize int,
+) *SessionCleanupSweeper {
+	return &SessionCleanupSweeper{
+		sessionRepo: sessionRepo,
+		logger:      logger,
+		batchSize:   batchSize,
+	}
+}
+
+// Sweep deletes expired sessions in batches until none are left.
+func (s *SessionCleanupSweeper) Sweep(ctx context.Context) {
+	total := 0
+	for ctx.Err() == nil {
+		deleted, err := s.sessionRepo.DeleteExpired(ctx, time.Now(), s.batchSize)
+		if err != nil {
+			s.logger.WithContext(ctx).WithError(err).Error("failed to delete expired sessions")
+			break
+		}
+
+		total += deleted
+		metrics.ExpiredSessionsDeleted.Add(float64(deleted))
+
+		if deleted < s.batchSize {
+			break
+		}
+	}
+
+	if total > 0 {
+		s.logger.WithContext(ctx).Infof("deleted %d expired sessions", total)
+	}
+}
@@ -0,0 +1,95 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/vagonaizer/authenitfication-service/internal/domain/repositories"
+	"github.com/vagonaizer/authenitfication-service/internal/dto/response"
+	"github.com/vagonaizer/authenitfication-service/internal/infrastructure/database/redis"
+	"github.com/vagonaizer/authenitfication-service/pkg/errors"
+	"github.com/vagonaizer/authenitfication-service/pkg/logger"
+)
+
+const maxStatsDays = 365
+
+type statsService struct {
+	statsRepo   repositories.StatsRepository
+	sessionRepo repositories.SessionRepository
+	cache       *redis.CacheService
+	logger      *logger.Logger
+	cacheTTL    time.Duration
+}
+
+func NewStatsService(
+	statsRepo repositories.StatsRepository,
+	sessionRepo repositories.SessionRepository,
+	cache *redis.CacheService,
+	logger *logger.Logger,
+	cacheTTL time.Duration,
+) *statsService {
+	return &statsService{
+		statsRepo:   statsRepo,
+		sessionRepo: sessionRepo,
+		cache:       cache,
+		logger:      logger,
+		cacheTTL:    cacheTTL,
+	}
+}
+
+func (s *statsService) GetUserStats(ctx context.Context, days int) (*response.UserStatsResponse, error) {
+	if days < 1 || days > maxStatsDays {
+		return nil, errors.Validation(fmt.Sprintf("days must be between 1 and %d", maxStatsDays))
+	}
+
+	key := userStatsCacheKey(days)
+
+	var cached response.UserStatsResponse
+	if err := s.cache.Get(ctx, key, &cached); err == nil {
+		return &cached, nil
+	}
+
+	now := time.Now().UTC()
+	stats, err := s.statsRepo.GetUserStats(ctx, now.AddDate(0, 0, 1-days), now)
+	if err != nil {
+		return nil, err
+	}
+
+	activeSessions, usersWithSessions, err := s.sessionRepo.CountActive(ctx, now)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &response.UserStatsResponse{
+		TotalUsers:        stats.TotalUsers,
+		VerifiedUsers:     stats.VerifiedUsers,
+		ActiveUsers7d:     stats.ActiveUsers7d,
+		ActiveUsers30d:    stats.ActiveUsers30d,
+		ActiveSessions:    activeSessions,
+		UsersWithSessions: usersWithSessions,
+		Registrations:     make([]response.DailyCountItem, len(stats.Registrations)),
+		GeneratedAt:       now,
+	}
+
+	if stats.TotalUsers > 0 {
+		result.VerifiedRatio = float64(stats.VerifiedUsers) / float64(stats.TotalUsers)
+	}
+
+	for i, day := range stats.Registrations {
+		result.Registrations[i] = response.DailyCountItem{
+			Date:  day.Date.Format("2006-01-02"),
+			Count: day.Count,
+		}
+	}
+
+	if err := s.cache.Set(ctx, key, result, s.cacheTTL); err != nil {
+		s.logger.WithContext(ctx).WithError(err).Warn("failed to cache user stats")
+	}
+
+	return result, nil
+}
+
+func userStatsCacheKey(days int) string {
+	return fmt.Sprintf("user_stats:%d", days)
+}
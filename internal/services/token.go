@@ -31,8 +31,8 @@ func (s *tokenService) GenerateRefreshToken(ctx context.Context) (string, error)
 	return s.jwtManager.GenerateRefreshToken(uuid.New(), 24*time.Hour*7)
 }
 
-func (s *tokenService) ValidateAccessToken(ctx context.Context, token string) (*services.TokenClaims, error) {
-	claims, err := s.jwtManager.ValidateAccessToken(token)
+func (s *tokenService) ValidateAccessToken(ctx context.Context, token string, tenant *uuid.UUID) (*services.TokenClaims, error) {
+	claims, err := s.jwtManager.ValidateAccessToken(token, tenant)
 	if err != nil {
 		return nil, errors.TokenInvalid()
 	}
@@ -42,6 +42,8 @@ func (s *tokenService) ValidateAccessToken(ctx context.Context, token string) (*
 		Email:     claims.Email,
 		Username:  claims.Username,
 		Roles:     claims.Roles,
+		OrgID:     claims.OrgID,
+		Groups:    claims.Groups,
 		ExpiresAt: claims.ExpiresAt.Time,
 		IssuedAt:  claims.IssuedAt.Time,
 	}, nil
@@ -0,0 +1,72 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/vagonaizer/authenitfication-service/internal/infrastructure/database/redis"
+	"github.com/vagonaizer/authenitfication-service/pkg/errors"
+	"github.com/vagonaizer/authenitfication-service/pkg/logger"
+)
+
+type tokenRevocationService struct {
+	cache        *redis.CacheService
+	logger       *logger.Logger
+	accessExpiry time.Duration
+}
+
+// NewTokenRevocationService keeps revocations for accessExpiry, after which
+// the revoked tokens have expired anyway.
+func NewTokenRevocationService(cache *redis.CacheService, logger *logger.Logger, accessExpiry time.Duration) *tokenRevocationService {
+	return &tokenRevocationService{
+		cache:        cache,
+		logger:       logger,
+		accessExpiry: accessExpiry,
+	}
+}
+
+func (s *tokenRevocationService) RevokeUserTokens(ctx context.Context, userID uuid.UUID) error {
+	if err := s.cache.SetUserTokensRevokedAt(ctx, userID.String(), time.Now(), s.accessExpiry); err != nil {
+		s.logger.WithContext(ctx).WithError(err).WithField("user_id", userID).Error("failed to revoke user tokens")
+		return errors.CacheError(err)
+	}
+	return nil
+}
+
+func (s *tokenRevocationService) RevokeToken(ctx context.Context, tokenID string, expiresAt time.Time) error {
+	ttl := time.Until(expiresAt)
+	if ttl <= 0 {
+		return nil
+	}
+
+	if err := s.cache.SetBlacklistedToken(ctx, tokenID, ttl); err != nil {
+		s.logger.WithContext(ctx).WithError(err).WithField("token_id", tokenID).Error("failed to blacklist token")
+		return errors.CacheError(err)
+	}
+	return nil
+}
+
+func (s *tokenRevocationService) IsRevoked(ctx context.Context, userID uuid.UUID, tokenID string, issuedAt time.Time) (bool, error) {
+	revokedAt, err := s.cache.GetUserTokensRevokedAt(ctx, userID.String())
+	if err != nil {
+		return false, errors.CacheError(err)
+	}
+
+	// Token timestamps have second precision, so a token issued in the same
+	// second as the revocation counts as revoked.
+	if !revokedAt.IsZero() && !issuedAt.After(revokedAt) {
+		return true, nil
+	}
+
+	if tokenID == "" {
+		return false, nil
+	}
+
+	blacklisted, err := s.cache.IsTokenBlacklisted(ctx, tokenID)
+	if err != nil {
+		return false, errors.CacheError(err)
+	}
+
+	return blacklisted, nil
+}
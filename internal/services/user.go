@@ -2,36 +2,86 @@ package services
 
 import (
 	"context"
+	"fmt"
 	"math"
+	"strings"
+	"time"
 
 	"github.com/google/uuid"
+	"github.com/vagonaizer/authenitfication-service/internal/domain/entities"
 	"github.com/vagonaizer/authenitfication-service/internal/domain/repositories"
+	"github.com/vagonaizer/authenitfication-service/internal/domain/services"
 	"github.com/vagonaizer/authenitfication-service/internal/dto/request"
 	"github.com/vagonaizer/authenitfication-service/internal/dto/response"
+	"github.com/vagonaizer/authenitfication-service/internal/infrastructure/messaging"
 	"github.com/vagonaizer/authenitfication-service/internal/infrastructure/messaging/kafka"
+	"github.com/vagonaizer/authenitfication-service/pkg/auth"
 	"github.com/vagonaizer/authenitfication-service/pkg/errors"
 	"github.com/vagonaizer/authenitfication-service/pkg/logger"
 	"github.com/vagonaizer/authenitfication-service/pkg/utils"
 )
 
+// maxBulkRoleUsers caps how many users a single bulk role change may touch.
+const maxBulkRoleUsers = 1000
+
+// temporaryPasswordLength is the length of passwords generated for accounts
+// created by an administrator.
+const temporaryPasswordLength = 16
+
 type userService struct {
-	userRepo repositories.UserRepository
-	roleRepo repositories.RoleRepository
-	producer *kafka.Producer
-	logger   *logger.Logger
+	userRepo      repositories.UserRepository
+	roleRepo      repositories.RoleRepository
+	roleAuditRepo repositories.RoleAuditRepository
+	sessionRepo   repositories.SessionRepository
+	revocations   services.TokenRevocationService
+	authorizer    services.Authorizer
+	activityRepo  repositories.ActivityRepository
+	loginHistory  repositories.LoginHistoryRepository
+	noteRepo      repositories.NoteRepository
+	hasher        *auth.PasswordHasher
+	storage       services.FileStorage
+	avatars       AvatarPolicy
+	notifications services.NotificationService
+	producer      messaging.Publisher
+	logger        *logger.Logger
+	deletionDelay time.Duration
 }
 
 func NewUserService(
 	userRepo repositories.UserRepository,
 	roleRepo repositories.RoleRepository,
-	producer *kafka.Producer,
+	roleAuditRepo repositories.RoleAuditRepository,
+	sessionRepo repositories.SessionRepository,
+	revocations services.TokenRevocationService,
+	authorizer services.Authorizer,
+	activityRepo repositories.ActivityRepository,
+	loginHistory repositories.LoginHistoryRepository,
+	noteRepo repositories.NoteRepository,
+	hasher *auth.PasswordHasher,
+	storage services.FileStorage,
+	avatars AvatarPolicy,
+	notifications services.NotificationService,
+	producer messaging.Publisher,
 	logger *logger.Logger,
+	deletionDelay time.Duration,
 ) *userService {
 	return &userService{
-		userRepo: userRepo,
-		roleRepo: roleRepo,
-		producer: producer,
-		logger:   logger,
+		userRepo:      userRepo,
+		roleRepo:      roleRepo,
+		roleAuditRepo: roleAuditRepo,
+		sessionRepo:   sessionRepo,
+		revocations:   revocations,
+		authorizer:    authorizer,
+		activityRepo:  activityRepo,
+		loginHistory:  loginHistory,
+		noteRepo:      noteRepo,
+		hasher:        hasher,
+		storage:       storage,
+		avatars:       avatars,
+		notifications: notifications,
+		producer:      producer,
+		logger:        logger,
+		deletionDelay: deletionDelay,
 	}
 }
 
@@ -47,11 +97,22 @@ func (s *userService) GetProfile(ctx context.Context, userID uuid.UUID) (*respon
 		Username:    user.Username,
 		FirstName:   user.FirstName,
 		LastName:    user.LastName,
+		AvatarURL:   user.AvatarURL,
 		IsActive:    user.IsActive,
 		IsVerified:  user.IsVerified,
 		LastLoginAt: user.LastLoginAt,
 		CreatedAt:   user.CreatedAt,
 		UpdatedAt:   user.UpdatedAt,
+
+		PasswordChangeRequired: user.PasswordChangeRequired,
+
+		Phone:           user.Phone,
+		PhoneVerifiedAt: user.PhoneVerifiedAt,
+
+		Locale:   user.Locale,
+		Timezone: user.Timezone,
+
+		DeletionScheduledAt: user.DeletionScheduledAt,
 	}, nil
 }
 
@@ -61,12 +122,21 @@ func (s *userService) UpdateProfile(ctx context.Context, req *request.UpdateUser
 		return nil, err
 	}
 
+	unmodifiedSince := user.UpdatedAt
+	if req.IfMatch != "" && !utils.ETagMatches(req.IfMatch, utils.ETag(unmodifiedSince)) {
+		return nil, errors.PreconditionFailed()
+	}
+
+	var changed []string
+
 	if req.FirstName != nil {
 		user.FirstName = req.FirstName
+		changed = append(changed, "first_name")
 	}
 
 	if req.LastName != nil {
 		user.LastName = req.LastName
+		changed = append(changed, "last_name")
 	}
 
 	if req.Username != nil {
@@ -84,24 +154,63 @@ func (s *userService) UpdateProfile(ctx context.Context, req *request.UpdateUser
 				return nil, errors.UsernameExists()
 			}
 			user.Username = normalizedUsername
+			changed = append(changed, "username")
 		}
 	}
 
-	if err := s.userRepo.Update(ctx, user); err != nil {
+	if req.Locale != nil {
+		locale, ok := utils.NormalizeLocale(*req.Locale)
+		if !ok {
+			return nil, errors.Validation("invalid locale")
+		}
+		if locale != user.Locale {
+			user.Locale = locale
+			changed = append(changed, "locale")
+		}
+	}
+
+	if req.Timezone != nil && *req.Timezone != user.Timezone {
+		if !utils.IsValidTimezone(*req.Timezone) {
+			return nil, errors.Validation("invalid timezone")
+		}
+		user.Timezone = *req.Timezone
+		changed = append(changed, "timezone")
+	}
+
+	// Без If-Match побеждает последняя запись
+	if req.IfMatch != "" {
+		err = s.userRepo.UpdateIfUnmodified(ctx, user, unmodifiedSince)
+	} else {
+		err = s.userRepo.Update(ctx, user)
+	}
+	if err != nil {
 		return nil, err
 	}
 
+	if len(changed) > 0 {
+		s.recordProfileActivity(ctx, user.ID, strings.Join(changed, ","))
+	}
+
 	return &response.UserResponse{
 		ID:          user.ID,
 		Email:       user.Email,
 		Username:    user.Username,
 		FirstName:   user.FirstName,
 		LastName:    user.LastName,
+		AvatarURL:   user.AvatarURL,
 		IsActive:    user.IsActive,
 		IsVerified:  user.IsVerified,
 		LastLoginAt: user.LastLoginAt,
 		CreatedAt:   user.CreatedAt,
 		UpdatedAt:   user.UpdatedAt,
+
+		Phone:           user.Phone,
+		PhoneVerifiedAt: user.PhoneVerifiedAt,
+
+		Locale:   user.Locale,
+		Timezone: user.Timezone,
+
+		DeletionScheduledAt: user.DeletionScheduledAt,
 	}, nil
 }
 
@@ -113,19 +222,162 @@ func (s *userService) DeleteAccount(ctx context.Context, userID uuid.UUID) error
 	user, _ := s.userRepo.GetByID(ctx, userID)
 	if user != nil {
 		event := kafka.UserDeletedEvent{
-			BaseEvent: kafka.NewBaseEvent(kafka.TopicUserDeleted),
+			BaseEvent: kafka.NewBaseEvent(ctx, kafka.TopicUserDeleted),
 			UserID:    user.ID,
 			Email:     user.Email,
 		}
 
 		if err := s.producer.PublishMessage(ctx, kafka.TopicUserDeleted, user.ID.String(), event); err != nil {
-			s.logger.WithError(err).Warn("failed to publish user deleted event")
+			s.logger.WithContext(ctx).WithError(err).Warn("failed to publish user deleted event")
 		}
 	}
 
 	return nil
 }
 
+func (s *userService) CreateUser(ctx context.Context, req *request.CreateUserRequest) (*response.CreateUserResponse, error) {
+	if !utils.IsValidEmail(req.Email) {
+		return nil, errors.Validation("invalid email format")
+	}
+
+	if !utils.IsValidUsername(req.Username) {
+		return nil, errors.Validation("invalid username format")
+	}
+
+	if len(req.RoleIDs) > 0 {
+		if err := s.requireRoleAssignment(ctx, req.ActorID, req.ActorRoles); err != nil {
+			return nil, err
+		}
+	}
+
+	password := req.Password
+	temporaryPassword := ""
+	if password == "" {
+		generated, err := utils.GenerateTemporaryPassword(temporaryPasswordLength)
+		if err != nil {
+			s.logger.WithContext(ctx).WithError(err).Error("failed to generate temporary password")
+			return nil, errors.Internal("failed to generate password")
+		}
+		password = generated
+		temporaryPassword = generated
+	} else if !utils.IsValidPassword(password) {
+		return nil, errors.WeakPassword()
+	}
+
+	exists, err := s.userRepo.ExistsByEmail(ctx, req.Email)
+	if err != nil {
+		return nil, err
+	}
+	if exists {
+		return nil, errors.EmailExists()
+	}
+
+	exists, err = s.userRepo.ExistsByUsername(ctx, req.Username)
+	if err != nil {
+		return nil, err
+	}
+	if exists {
+		return nil, errors.UsernameExists()
+	}
+
+	// Resolve roles up front so an unknown role fails before the user exists.
+	roles := make([]*entities.Role, 0, len(req.RoleIDs))
+	seen := make(map[uuid.UUID]bool, len(req.RoleIDs))
+	for _, roleID := range req.RoleIDs {
+		if seen[roleID] {
+			continue
+		}
+		seen[roleID] = true
+
+		role, err := s.roleRepo.GetByID(ctx, roleID)
+		if err != nil {
+			return nil, err
+		}
+		roles = append(roles, role)
+	}
+
+	passwordHash, err := s.hasher.HashPassword(password)
+	if err != nil {
+		s.logger.WithContext(ctx).WithError(err).Error("failed to hash password")
+		return nil, errors.Internal("failed to process password")
+	}
+
+	user := &entities.User{
+		ID:           uuid.New(),
+		Email:        utils.NormalizeEmail(req.Email),
+		Username:     utils.NormalizeUsername(req.Username),
+		PasswordHash: passwordHash,
+		FirstName:    req.FirstName,
+		LastName:     req.LastName,
+		IsActive:     true,
+		IsVerified:   req.IsVerified,
+
+		PasswordChangeRequired: req.RequirePasswordChange || temporaryPassword != "",
+	}
+
+	if err := s.userRepo.Create(ctx, user); err != nil {
+		return nil, err
+	}
+
+	roleNames := make([]string, 0, len(roles))
+	for _, role := range roles {
+		if err := s.roleRepo.AssignRoleToUser(ctx, user.ID, role.ID, nil, nil); err != nil {
+			return nil, err
+		}
+
+		audit := &entities.RoleAssignmentAudit{
+			UserID:   user.ID,
+			RoleID:   role.ID,
+			RoleName: role.Name,
+			Action:   entities.RoleAuditActionGranted,
+			ActorID:  req.ActorID,
+		}
+		if err := s.roleAuditRepo.Create(ctx, audit); err != nil {
+			return nil, err
+		}
+
+		roleNames = append(roleNames, role.Name)
+	}
+
+	event := kafka.UserCreatedByAdminEvent{
+		BaseEvent: kafka.NewBaseEvent(ctx, kafka.TopicUserCreatedByAdmin),
+		UserID:    user.ID,
+		Email:     user.Email,
+		Username:  user.Username,
+		FirstName: user.FirstName,
+		LastName:  user.LastName,
+		Roles:     roleNames,
+		CreatedBy: req.ActorID,
+
+		PasswordChangeRequired: user.PasswordChangeRequired,
+	}
+
+	if err := s.producer.PublishMessage(ctx, kafka.TopicUserCreatedByAdmin, user.ID.String(), event); err != nil {
+		s.logger.WithContext(ctx).WithError(err).Warn("failed to publish user created by admin event")
+	}
+
+	return &response.CreateUserResponse{
+		User: &response.UserResponse{
+			ID:         user.ID,
+			Email:      user.Email,
+			Username:   user.Username,
+			FirstName:  user.FirstName,
+			LastName:   user.LastName,
+			IsActive:   user.IsActive,
+			IsVerified: user.IsVerified,
+			CreatedAt:  user.CreatedAt,
+			UpdatedAt:  user.UpdatedAt,
+
+			PasswordChangeRequired: user.PasswordChangeRequired,
+
+			Locale:   user.Locale,
+			Timezone: user.Timezone,
+		},
+		Roles:             roleNames,
+		TemporaryPassword: temporaryPassword,
+	}, nil
+}
+
 func (s *userService) ListUsers(ctx context.Context, req *request.ListUsersRequest) (*response.UsersListResponse, error) {
 	if req.Page < 1 {
 		req.Page = 1
@@ -135,28 +387,21 @@ func (s *userService) ListUsers(ctx context.Context, req *request.ListUsersReque
 	}
 
 	offset := (req.Page - 1) * req.PageSize
-	users, err := s.userRepo.List(ctx, req.PageSize, offset)
+	filter := entities.UserListFilter{
+		Search:  req.Search,
+		SortBy:  req.SortBy,
+		SortDir: req.SortDir,
+	}
+	users, total, err := s.userRepo.List(ctx, filter, req.PageSize, offset)
 	if err != nil {
 		return nil, err
 	}
 
 	userResponses := make([]*response.UserResponse, len(users))
 	for i, user := range users {
-		userResponses[i] = &response.UserResponse{
-			ID:          user.ID,
-			Email:       user.Email,
-			Username:    user.Username,
-			FirstName:   user.FirstName,
-			LastName:    user.LastName,
-			IsActive:    user.IsActive,
-			IsVerified:  user.IsVerified,
-			LastLoginAt: user.LastLoginAt,
-			CreatedAt:   user.CreatedAt,
-			UpdatedAt:   user.UpdatedAt,
-		}
+		userResponses[i] = toUserListItem(user)
 	}
 
-	total := int64(len(users))
 	totalPages := int(math.Ceil(float64(total) / float64(req.PageSize)))
 
 	return &response.UsersListResponse{
@@ -168,11 +413,55 @@ func (s *userService) ListUsers(ctx context.Context, req *request.ListUsersReque
 	}, nil
 }
 
+func (s *userService) SearchUsers(ctx context.Context, req *request.SearchUsersRequest) (*response.UserSearchResponse, error) {
+	query := strings.TrimSpace(req.Query)
+	if len([]rune(query)) < 3 {
+		return nil, errors.Validation("search query must be at least 3 characters")
+	}
+
+	users, err := s.userRepo.Search(ctx, query, req.Limit)
+	if err != nil {
+		return nil, err
+	}
+
+	userResponses := make([]*response.UserResponse, len(users))
+	for i, user := range users {
+		userResponses[i] = toUserListItem(user)
+	}
+
+	return &response.UserSearchResponse{Users: userResponses}, nil
+}
+
+// toUserListItem is the user representation of admin listings and search.
+func toUserListItem(user *entities.User) *response.UserResponse {
+	return &response.UserResponse{
+		ID:          user.ID,
+		Email:       user.Email,
+		Username:    user.Username,
+		FirstName:   user.FirstName,
+		LastName:    user.LastName,
+		AvatarURL:   user.AvatarURL,
+		IsActive:    user.IsActive,
+		IsVerified:  user.IsVerified,
+		LastLoginAt: user.LastLoginAt,
+		CreatedAt:   user.CreatedAt,
+		UpdatedAt:   user.UpdatedAt,
+
+		Phone:           user.Phone,
+		PhoneVerifiedAt: user.PhoneVerifiedAt,
+
+		Locale:   user.Locale,
+		Timezone: user.Timezone,
+
+		DeletionScheduledAt: user.DeletionScheduledAt,
+	}
+}
+
 func (s *userService) GetUserByID(ctx context.Context, userID uuid.UUID) (*response.UserResponse, error) {
 	return s.GetProfile(ctx, userID)
 }
 
-func (s *userService) ActivateUser(ctx context.Context, userID uuid.UUID) error {
+func (s *userService) ActivateUser(ctx context.Context, actorID *uuid.UUID, userID uuid.UUID) error {
 	user, err := s.userRepo.GetByID(ctx, userID)
 	if err != nil {
 		return err
@@ -187,20 +476,30 @@ func (s *userService) ActivateUser(ctx context.Context, userID uuid.UUID) error
 		return err
 	}
 
+	s.logger.WithContext(ctx).WithFields(logger.Fields{
+		"user_id":  user.ID,
+		"actor_id": actorID,
+	}).Info("user activated")
+
 	event := kafka.UserActivatedEvent{
-		BaseEvent: kafka.NewBaseEvent(kafka.TopicUserActivated),
+		BaseEvent: kafka.NewBaseEvent(ctx, kafka.TopicUserActivated),
 		UserID:    user.ID,
 		Email:     user.Email,
+		ActorID:   actorID,
 	}
 
 	if err := s.producer.PublishMessage(ctx, kafka.TopicUserActivated, user.ID.String(), event); err != nil {
-		s.logger.WithError(err).Warn("failed to publish user activated event")
+		s.logger.WithContext(ctx).WithError(err).Warn("failed to publish user activated event")
 	}
 
 	return nil
 }
 
-func (s *userService) DeactivateUser(ctx context.Context, userID uuid.UUID) error {
+func (s *userService) DeactivateUser(ctx context.Context, actorID *uuid.UUID, userID uuid.UUID) error {
+	if actorID != nil && *actorID == userID {
+		return errors.Validation("you cannot deactivate yourself")
+	}
+
 	user, err := s.userRepo.GetByID(ctx, userID)
 	if err != nil {
 		return err
@@ -215,20 +514,84 @@ func (s *userService) DeactivateUser(ctx context.Context, userID uuid.UUID) erro
 		return err
 	}
 
+	s.signOutEverywhere(ctx, user.ID, entities.SessionRevokedDeactivated)
+
+	s.logger.WithContext(ctx).WithFields(logger.Fields{
+		"user_id":  user.ID,
+		"actor_id": actorID,
+	}).Info("user deactivated")
+
 	event := kafka.UserDeactivatedEvent{
-		BaseEvent: kafka.NewBaseEvent(kafka.TopicUserDeactivated),
+		BaseEvent: kafka.NewBaseEvent(ctx, kafka.TopicUserDeactivated),
 		UserID:    user.ID,
 		Email:     user.Email,
+		ActorID:   actorID,
 	}
 
 	if err := s.producer.PublishMessage(ctx, kafka.TopicUserDeactivated, user.ID.String(), event); err != nil {
-		s.logger.WithError(err).Warn("failed to publish user deactivated event")
+		s.logger.WithContext(ctx).WithError(err).Warn("failed to publish user deactivated event")
 	}
 
 	return nil
 }
 
+// signOutEverywhere deletes the user's sessions and revokes the access tokens
+// already issued. Failures are logged; the account change itself stands.
+func (s *userService) signOutEverywhere(ctx context.Context, userID uuid.UUID, reason string) {
+	if err := s.sessionRepo.DeleteByUserID(ctx, userID); err != nil {
+		s.logger.WithContext(ctx).WithError(err).WithField("user_id", userID).Error("failed to revoke user sessions")
+	} else {
+		publishSessionRevoked(ctx, s.producer, s.logger, userID, nil, reason)
+	}
+	if err := s.revocations.RevokeUserTokens(ctx, userID); err != nil {
+		s.logger.WithContext(ctx).WithError(err).WithField("user_id", userID).Error("failed to revoke user tokens")
+	}
+}
+
+// publishSessionRevoked reports revoked sessions of userID; a nil sessionID
+// stands for all of them.
+func publishSessionRevoked(ctx context.Context, producer messaging.Publisher, log *logger.Logger, userID uuid.UUID, sessionID *uuid.UUID, reason string) {
+	event := kafka.SessionRevokedEvent{
+		BaseEvent: kafka.NewBaseEvent(ctx, kafka.TopicSessionRevoked),
+		UserID:    userID,
+		SessionID: sessionID,
+		Reason:    reason,
+	}
+
+	if err := producer.PublishMessage(ctx, kafka.TopicSessionRevoked, userID.String(), event); err != nil {
+		log.WithError(err).Warn("failed to publish session revoked event")
+	}
+}
+
+// requireRoleAssignment fails unless the roles of the actor grant
+// roles:assign, which handing out roles needs whatever else the actor may
+// manage.
+func (s *userService) requireRoleAssignment(ctx context.Context, actorID *uuid.UUID, actorRoles []string) error {
+	allowed, err := s.canAssignRoles(ctx, actorID, actorRoles)
+	if err != nil {
+		return err
+	}
+	if !allowed {
+		return errors.Forbidden("assigning roles requires the roles:assign permission")
+	}
+	return nil
+}
+
+func (s *userService) canAssignRoles(ctx context.Context, actorID *uuid.UUID, actorRoles []string) (bool, error) {
+	subject := &services.Subject{Roles: actorRoles}
+	if actorID != nil {
+		subject.UserID = actorID.String()
+	}
+
+	resource, action := entities.ParsePermission(entities.PermissionRolesAssign)
+	return s.authorizer.Authorize(ctx, subject, action, resource)
+}
+
 func (s *userService) AssignRole(ctx context.Context, req *request.AssignRoleRequest) error {
+	if req.ExpiresAt != nil && !req.ExpiresAt.After(time.Now()) {
+		return errors.Validation("expires_at must be in the future")
+	}
+
 	user, err := s.userRepo.GetByID(ctx, req.UserID)
 	if err != nil {
 		return err
@@ -239,21 +602,39 @@ func (s *userService) AssignRole(ctx context.Context, req *request.AssignRoleReq
 		return err
 	}
 
-	if err := s.roleRepo.AssignRoleToUser(ctx, req.UserID, req.RoleID); err != nil {
+	if err := s.roleRepo.AssignRoleToUser(ctx, req.UserID, req.RoleID, req.ScopeID, req.ExpiresAt); err != nil {
+		return err
+	}
+
+	audit := &entities.RoleAssignmentAudit{
+		UserID:    user.ID,
+		RoleID:    role.ID,
+		RoleName:  role.Name,
+		ScopeID:   req.ScopeID,
+		Action:    entities.RoleAuditActionGranted,
+		ActorID:   req.ActorID,
+		Reason:    req.Reason,
+		ExpiresAt: req.ExpiresAt,
+	}
+	if err := s.roleAuditRepo.Create(ctx, audit); err != nil {
 		return err
 	}
 
 	event := kafka.RoleAssignedEvent{
-		BaseEvent: kafka.NewBaseEvent(kafka.TopicRoleAssigned),
+		BaseEvent: kafka.NewBaseEvent(ctx, kafka.TopicRoleAssigned),
 		UserID:    user.ID,
 		RoleID:    role.ID,
 		RoleName:  role.Name,
+		ScopeID:   req.ScopeID,
+		ExpiresAt: req.ExpiresAt,
 	}
 
 	if err := s.producer.PublishMessage(ctx, kafka.TopicRoleAssigned, user.ID.String(), event); err != nil {
-		s.logger.WithError(err).Warn("failed to publish role assigned event")
+		s.logger.WithContext(ctx).WithError(err).Warn("failed to publish role assigned event")
 	}
 
+	s.recordRoleActivity(ctx, entities.ActivityRoleAssigned, user.ID, role.Name, req.ScopeID, req.ActorID)
+
 	return nil
 }
 
@@ -268,26 +649,200 @@ func (s *userService) RemoveRole(ctx context.Context, req *request.RemoveRoleReq
 		return err
 	}
 
-	if err := s.roleRepo.RemoveRoleFromUser(ctx, req.UserID, req.RoleID); err != nil {
+	if err := s.roleRepo.RemoveRoleFromUser(ctx, req.UserID, req.RoleID, req.ScopeID); err != nil {
+		return err
+	}
+
+	audit := &entities.RoleAssignmentAudit{
+		UserID:   user.ID,
+		RoleID:   role.ID,
+		RoleName: role.Name,
+		ScopeID:  req.ScopeID,
+		Action:   entities.RoleAuditActionRevoked,
+		ActorID:  req.ActorID,
+		Reason:   req.Reason,
+	}
+	if err := s.roleAuditRepo.Create(ctx, audit); err != nil {
 		return err
 	}
 
 	event := kafka.RoleRemovedEvent{
-		BaseEvent: kafka.NewBaseEvent(kafka.TopicRoleRemoved),
+		BaseEvent: kafka.NewBaseEvent(ctx, kafka.TopicRoleRemoved),
 		UserID:    user.ID,
 		RoleID:    role.ID,
 		RoleName:  role.Name,
+		ScopeID:   req.ScopeID,
 	}
 
 	if err := s.producer.PublishMessage(ctx, kafka.TopicRoleRemoved, user.ID.String(), event); err != nil {
-		s.logger.WithError(err).Warn("failed to publish role removed event")
+		s.logger.WithContext(ctx).WithError(err).Warn("failed to publish role removed event")
 	}
 
+	s.recordRoleActivity(ctx, entities.ActivityRoleRemoved, user.ID, role.Name, req.ScopeID, req.ActorID)
+
 	return nil
 }
 
-func (s *userService) GetUserRoles(ctx context.Context, userID uuid.UUID) (*response.UserRolesResponse, error) {
-	roles, err := s.roleRepo.GetUserRoles(ctx, userID)
+func (s *userService) BulkAssignRole(ctx context.Context, req *request.BulkAssignRoleRequest) (*response.BulkRoleResponse, error) {
+	if req.ExpiresAt != nil && !req.ExpiresAt.After(time.Now()) {
+		return nil, errors.Validation("expires_at must be in the future")
+	}
+
+	role, err := s.roleRepo.GetByID(ctx, req.RoleID)
+	if err != nil {
+		return nil, err
+	}
+
+	userIDs, err := s.resolveBulkUsers(ctx, req.UserIDs, req.Filter)
+	if err != nil {
+		return nil, err
+	}
+
+	change := &entities.BulkRoleChange{
+		RoleID:    role.ID,
+		RoleName:  role.Name,
+		ScopeID:   req.ScopeID,
+		ExpiresAt: req.ExpiresAt,
+		ActorID:   req.ActorID,
+		Reason:    req.Reason,
+		UserIDs:   userIDs,
+	}
+
+	assigned, err := s.roleRepo.BulkAssignRole(ctx, change)
+	if err != nil {
+		return nil, err
+	}
+
+	s.publishBulkRoleEvent(ctx, kafka.TopicRoleBulkAssigned, change, assigned)
+	for _, userID := range assigned {
+		s.recordRoleActivity(ctx, entities.ActivityRoleAssigned, userID, role.Name, req.ScopeID, req.ActorID)
+	}
+
+	return bulkRoleResponse(role.ID, userIDs, assigned, "user not found"), nil
+}
+
+func (s *userService) BulkRemoveRole(ctx context.Context, req *request.BulkRemoveRoleRequest) (*response.BulkRoleResponse, error) {
+	role, err := s.roleRepo.GetByID(ctx, req.RoleID)
+	if err != nil {
+		return nil, err
+	}
+
+	userIDs, err := s.resolveBulkUsers(ctx, req.UserIDs, req.Filter)
+	if err != nil {
+		return nil, err
+	}
+
+	change := &entities.BulkRoleChange{
+		RoleID:   role.ID,
+		RoleName: role.Name,
+		ScopeID:  req.ScopeID,
+		ActorID:  req.ActorID,
+		Reason:   req.Reason,
+		UserIDs:  userIDs,
+	}
+
+	removed, err := s.roleRepo.BulkRemoveRole(ctx, change)
+	if err != nil {
+		return nil, err
+	}
+
+	s.publishBulkRoleEvent(ctx, kafka.TopicRoleBulkRemoved, change, removed)
+	for _, userID := range removed {
+		s.recordRoleActivity(ctx, entities.ActivityRoleRemoved, userID, role.Name, req.ScopeID, req.ActorID)
+	}
+
+	return bulkRoleResponse(role.ID, userIDs, removed, "user role assignment not found"), nil
+}
+
+// resolveBulkUsers returns the deduplicated target users of a bulk change,
+// taken either from the explicit list or from the filter.
+func (s *userService) resolveBulkUsers(ctx context.Context, userIDs []uuid.UUID, filter *request.BulkUserFilter) ([]uuid.UUID, error) {
+	if len(userIDs) > 0 && filter != nil {
+		return nil, errors.Validation("specify either user_ids or filter, not both")
+	}
+
+	if filter != nil {
+		userFilter := entities.UserFilter{
+			OrganizationID: filter.OrganizationID,
+			GroupID:        filter.GroupID,
+			RoleID:         filter.RoleID,
+			IsActive:       filter.IsActive,
+		}
+
+		ids, err := s.userRepo.ListIDs(ctx, userFilter, maxBulkRoleUsers+1)
+		if err != nil {
+			return nil, err
+		}
+		if len(ids) > maxBulkRoleUsers {
+			return nil, errors.Validation(fmt.Sprintf("filter matches more than %d users", maxBulkRoleUsers))
+		}
+		if len(ids) == 0 {
+			return nil, errors.Validation("filter matches no users")
+		}
+		return ids, nil
+	}
+
+	if len(userIDs) == 0 {
+		return nil, errors.Validation("user_ids or filter is required")
+	}
+
+	seen := make(map[uuid.UUID]bool, len(userIDs))
+	unique := make([]uuid.UUID, 0, len(userIDs))
+	for _, id := range userIDs {
+		if !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+
+	return unique, nil
+}
+
+func (s *userService) publishBulkRoleEvent(ctx context.Context, topic string, change *entities.BulkRoleChange, userIDs []uuid.UUID) {
+	if len(userIDs) == 0 {
+		return
+	}
+
+	event := kafka.BulkRoleEvent{
+		BaseEvent: kafka.NewBaseEvent(ctx, topic),
+		RoleID:    change.RoleID,
+		RoleName:  change.RoleName,
+		ScopeID:   change.ScopeID,
+		UserIDs:   userIDs,
+		ExpiresAt: change.ExpiresAt,
+		ActorID:   change.ActorID,
+	}
+
+	if err := s.producer.PublishMessage(ctx, topic, change.RoleID.String(), event); err != nil {
+		s.logger.WithContext(ctx).WithError(err).Warn("failed to publish bulk role event")
+	}
+}
+
+// bulkRoleResponse reports every requested user missing from changed as failed.
+func bulkRoleResponse(roleID uuid.UUID, requested, changed []uuid.UUID, failure string) *response.BulkRoleResponse {
+	changedSet := make(map[uuid.UUID]bool, len(changed))
+	for _, id := range changed {
+		changedSet[id] = true
+	}
+
+	result := &response.BulkRoleResponse{
+		RoleID:    roleID,
+		Succeeded: make([]uuid.UUID, 0, len(changed)),
+		Failed:    []*response.BulkRoleFailure{},
+	}
+	for _, id := range requested {
+		if changedSet[id] {
+			result.Succeeded = append(result.Succeeded, id)
+		} else {
+			result.Failed = append(result.Failed, &response.BulkRoleFailure{UserID: id, Error: failure})
+		}
+	}
+
+	return result
+}
+
+func (s *userService) GetUserRoles(ctx context.Context, userID uuid.UUID, scopeID *uuid.UUID) (*response.UserRolesResponse, error) {
+	roles, err := s.roleRepo.GetUserRoles(ctx, userID, scopeID)
 	if err != nil {
 		return nil, err
 	}
@@ -299,11 +854,58 @@ func (s *userService) GetUserRoles(ctx context.Context, userID uuid.UUID) (*resp
 			Name:        role.Name,
 			Description: role.Description,
 			CreatedAt:   role.CreatedAt,
+			UpdatedAt:   role.UpdatedAt,
 		}
 	}
 
 	return &response.UserRolesResponse{
-		UserID: userID,
-		Roles:  roleResponses,
+		UserID:  userID,
+		ScopeID: scopeID,
+		Roles:   roleResponses,
+	}, nil
+}
+
+func (s *userService) ListRoleAudit(ctx context.Context, req *request.ListRoleAuditRequest) (*response.RoleAuditListResponse, error) {
+	if req.Page < 1 {
+		req.Page = 1
+	}
+	if req.PageSize < 1 || req.PageSize > 100 {
+		req.PageSize = 20
+	}
+
+	filter := entities.RoleAuditFilter{
+		UserID: req.UserID,
+		RoleID: req.RoleID,
+		Action: req.Action,
+	}
+
+	offset := (req.Page - 1) * req.PageSize
+	entries, total, err := s.roleAuditRepo.List(ctx, filter, req.PageSize, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	entryResponses := make([]*response.RoleAuditEntryResponse, len(entries))
+	for i, entry := range entries {
+		entryResponses[i] = &response.RoleAuditEntryResponse{
+			ID:        entry.ID,
+			UserID:    entry.UserID,
+			RoleID:    entry.RoleID,
+			RoleName:  entry.RoleName,
+			ScopeID:   entry.ScopeID,
+			Action:    entry.Action,
+			ActorID:   entry.ActorID,
+			Reason:    entry.Reason,
+			ExpiresAt: entry.ExpiresAt,
+			CreatedAt: entry.CreatedAt,
+		}
+	}
+
+	return &response.RoleAuditListResponse{
+		Entries:    entryResponses,
+		Total:      total,
+		Page:       req.Page,
+		PageSize:   req.PageSize,
+		TotalPages: int(math.Ceil(float64(total) / float64(req.PageSize))),
 	}, nil
 }
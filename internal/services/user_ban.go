@@ -0,0 +1,169 @@
+package services
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/vagonaizer/authenitfication-service/internal/domain/entities"
+	"github.com/vagonaizer/authenitfication-service/internal/domain/repositories"
+	"github.com/vagonaizer/authenitfication-service/internal/dto/request"
+	"github.com/vagonaizer/authenitfication-service/internal/dto/response"
+	"github.com/vagonaizer/authenitfication-service/internal/infrastructure/messaging"
+	"github.com/vagonaizer/authenitfication-service/internal/infrastructure/messaging/kafka"
+	"github.com/vagonaizer/authenitfication-service/pkg/errors"
+	"github.com/vagonaizer/authenitfication-service/pkg/logger"
+)
+
+func (s *userService) BanUser(ctx context.Context, req *request.BanUserRequest) (*response.UserBanResponse, error) {
+	if req.ActorID == req.UserID {
+		return nil, errors.Validation("you cannot ban yourself")
+	}
+
+	reason := strings.TrimSpace(req.Reason)
+	if reason == "" {
+		return nil, errors.Validation("reason is required")
+	}
+
+	var bannedUntil *time.Time
+	if req.Duration != "" {
+		duration, err := time.ParseDuration(req.Duration)
+		if err != nil || duration <= 0 {
+			return nil, errors.Validation("duration must be a positive duration such as 72h")
+		}
+		until := time.Now().Add(duration)
+		bannedUntil = &until
+	}
+
+	user, err := s.userRepo.GetByID(ctx, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	var bannedBy *uuid.UUID
+	if req.ActorID != uuid.Nil {
+		bannedBy = &req.ActorID
+	}
+
+	user.BanReason = &reason
+	user.BannedUntil = bannedUntil
+	user.BannedBy = bannedBy
+
+	if err := s.userRepo.Ban(ctx, user); err != nil {
+		return nil, err
+	}
+
+	s.signOutEverywhere(ctx, user.ID, entities.SessionRevokedBanned)
+
+	s.logger.WithContext(ctx).WithFields(logger.Fields{
+		"user_id":  user.ID,
+		"actor_id": bannedBy,
+	}).Info("user banned")
+
+	event := kafka.UserBannedEvent{
+		BaseEvent:   kafka.NewBaseEvent(ctx, kafka.TopicUserBanned),
+		UserID:      user.ID,
+		Reason:      reason,
+		BannedUntil: bannedUntil,
+		BannedBy:    bannedBy,
+	}
+
+	if err := s.producer.PublishMessage(ctx, kafka.TopicUserBanned, user.ID.String(), event); err != nil {
+		s.logger.WithContext(ctx).WithError(err).Warn("failed to publish user banned event")
+	}
+
+	return &response.UserBanResponse{
+		UserID:      user.ID,
+		Reason:      reason,
+		BannedAt:    *user.BannedAt,
+		BannedUntil: bannedUntil,
+		BannedBy:    bannedBy,
+	}, nil
+}
+
+func (s *userService) UnbanUser(ctx context.Context, actorID, userID uuid.UUID) error {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	if user.BannedAt == nil {
+		return nil
+	}
+
+	if err := s.userRepo.Unban(ctx, user.ID); err != nil {
+		return err
+	}
+
+	s.logger.WithContext(ctx).WithFields(logger.Fields{
+		"user_id":  user.ID,
+		"actor_id": actorID,
+	}).Info("user unbanned")
+
+	event := kafka.UserUnbannedEvent{
+		BaseEvent:  kafka.NewBaseEvent(ctx, kafka.TopicUserUnbanned),
+		UserID:     user.ID,
+		UnbannedBy: &actorID,
+	}
+
+	if err := s.producer.PublishMessage(ctx, kafka.TopicUserUnbanned, user.ID.String(), event); err != nil {
+		s.logger.WithContext(ctx).WithError(err).Warn("failed to publish user unbanned event")
+	}
+
+	return nil
+}
+
+// BanExpirySweeper periodically lifts temporary bans that have run out and
+// publishes a user.unbanned event for each of them.
+type BanExpirySweeper struct {
+	userRepo  repositories.UserRepository
+	producer  messaging.Publisher
+	logger    *logger.Logger
+	batchSize int
+}
+
+func NewBanExpirySweeper(
+	userRepo repositories.UserRepository,
+	producer messaging.Publisher,
+	logger *logger.Logger,
+	batchSize int,
+) *BanExpirySweeper {
+	return &BanExpirySweeper{
+		userRepo:  userRepo,
+		producer:  producer,
+		logger:    logger,
+		batchSize: batchSize,
+	}
+}
+
+// Sweep lifts expired bans in batches until none are left.
+func (s *BanExpirySweeper) Sweep(ctx context.Context) {
+	for ctx.Err() == nil {
+		userIDs, err := s.userRepo.LiftExpiredBans(ctx, time.Now(), s.batchSize)
+		if err != nil {
+			s.logger.WithContext(ctx).WithError(err).Error("failed to lift expired bans")
+			return
+		}
+
+		for _, userID := range userIDs {
+			event := kafka.UserUnbannedEvent{
+				BaseEvent: kafka.NewBaseEvent(ctx, kafka.TopicUserUnbanned),
+				UserID:    userID,
+				Expired:   true,
+			}
+
+			if err := s.producer.PublishMessage(ctx, kafka.TopicUserUnbanned, userID.String(), event); err != nil {
+				s.logger.WithContext(ctx).WithError(err).Warn("failed to publish user unbanned event")
+			}
+		}
+
+		if len(userIDs) > 0 {
+			s.logger.WithContext(ctx).Infof("lifted %d expired bans", len(userIDs))
+		}
+
+		if len(userIDs) < s.batchSize {
+			return
+		}
+	}
+}
@@ -0,0 +1,324 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/vagonaizer/authenitfication-service/internal/domain/entities"
+	"github.com/vagonaizer/authenitfication-service/internal/domain/repositories"
+	"github.com/vagonaizer/authenitfication-service/internal/infrastructure/database/redis"
+	"github.com/vagonaizer/authenitfication-service/pkg/logger"
+)
+
+// cachedUserRepository caches users looked up by ID or email, which happens
+// on every login, token refresh and profile request. Email entries only hold
+// the user ID, so a changed email never serves the wrong account: a user
+// whose cached email no longer matches counts as a miss.
+//
+// Every write through this repository drops the affected users' entries.
+// Cached users include the password hash, which JSON encoding of the entity
+// leaves out.
+//
+// Email and username existence checks, which registration runs on every
+// attempt, are cached for existsTTL, misses included. Creating or renaming a
+// user drops the entries of its new email and username; removals are only
+// picked up once entries expire. A stale miss is harmless, since the unique
+// constraints still reject the duplicate.
+type cachedUserRepository struct {
+	repositories.UserRepository
+	cache     *redis.LocalCache
+	logger    *logger.Logger
+	ttl       time.Duration
+	existsTTL time.Duration
+}
+
+// cachedUser is the cache encoding of a user.
+type cachedUser struct {
+	*entities.User
+	PasswordHash string `json:"password_hash"`
+}
+
+func NewCachedUserRepository(
+	userRepo repositories.UserRepository,
+	cache *redis.LocalCache,
+	logger *logger.Logger,
+	ttl time.Duration,
+	existsTTL time.Duration,
+) *cachedUserRepository {
+	return &cachedUserRepository{
+		UserRepository: userRepo,
+		cache:          cache,
+		logger:         logger,
+		ttl:            ttl,
+		existsTTL:      existsTTL,
+	}
+}
+
+func (r *cachedUserRepository) Create(ctx context.Context, user *entities.User) error {
+	if err := r.UserRepository.Create(ctx, user); err != nil {
+		return err
+	}
+
+	r.invalidateExists(ctx, user)
+	return nil
+}
+
+func (r *cachedUserRepository) ImportBatch(ctx context.Context, batch []*entities.UserImport, actorID *uuid.UUID) ([]error, error) {
+	rowErrors, err := r.UserRepository.ImportBatch(ctx, batch, actorID)
+	if err != nil {
+		return nil, err
+	}
+
+	users := make([]*entities.User, len(batch))
+	for i, row := range batch {
+		users[i] = row.User
+	}
+	r.invalidateExists(ctx, users...)
+
+	return rowErrors, nil
+}
+
+func (r *cachedUserRepository) ExistsByEmail(ctx context.Context, email string) (bool, error) {
+	return r.exists(ctx, emailExistsCacheKey(email), func() (bool, error) {
+		return r.UserRepository.ExistsByEmail(ctx, email)
+	})
+}
+
+func (r *cachedUserRepository) ExistsByUsername(ctx context.Context, username string) (bool, error) {
+	return r.exists(ctx, usernameExistsCacheKey(username), func() (bool, error) {
+		return r.UserRepository.ExistsByUsername(ctx, username)
+	})
+}
+
+func (r *cachedUserRepository) GetByID(ctx context.Context, id uuid.UUID) (*entities.User, error) {
+	if r.ttl <= 0 {
+		return r.UserRepository.GetByID(ctx, id)
+	}
+
+	cached := cachedUser{User: &entities.User{}}
+	if err := r.cache.Get(ctx, userCacheKey(id), &cached); err == nil {
+		cached.User.PasswordHash = cached.PasswordHash
+		return cached.User, nil
+	}
+
+	user, err := r.UserRepository.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	r.store(ctx, user)
+	return user, nil
+}
+
+func (r *cachedUserRepository) GetByEmail(ctx context.Context, email string) (*entities.User, error) {
+	if r.ttl <= 0 {
+		return r.UserRepository.GetByEmail(ctx, email)
+	}
+
+	var id uuid.UUID
+	if err := r.cache.Get(ctx, userEmailCacheKey(email), &id); err == nil {
+		if user, err := r.GetByID(ctx, id); err == nil && user.Email == email {
+			return user, nil
+		}
+	}
+
+	user, err := r.UserRepository.GetByEmail(ctx, email)
+	if err != nil {
+		return nil, err
+	}
+
+	r.store(ctx, user)
+	return user, nil
+}
+
+func (r *cachedUserRepository) Update(ctx context.Context, user *entities.User) error {
+	if err := r.UserRepository.Update(ctx, user); err != nil {
+		return err
+	}
+
+	r.invalidate(ctx, user.ID)
+	r.invalidateExists(ctx, user)
+	return nil
+}
+
+func (r *cachedUserRepository) UpdateIfUnmodified(ctx context.Context, user *entities.User, unmodifiedSince time.Time) error {
+	if err := r.UserRepository.UpdateIfUnmodified(ctx, user, unmodifiedSince); err != nil {
+		return err
+	}
+
+	r.invalidate(ctx, user.ID)
+	r.invalidateExists(ctx, user)
+	return nil
+}
+
+func (r *cachedUserRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	if err := r.UserRepository.Delete(ctx, id); err != nil {
+		return err
+	}
+
+	r.invalidate(ctx, id)
+	return nil
+}
+
+func (r *cachedUserRepository) SetPhone(ctx context.Context, id uuid.UUID, phone *string) error {
+	if err := r.UserRepository.SetPhone(ctx, id, phone); err != nil {
+		return err
+	}
+
+	r.invalidate(ctx, id)
+	return nil
+}
+
+func (r *cachedUserRepository) Purge(ctx context.Context, id uuid.UUID) (*string, error) {
+	avatarURL, err := r.UserRepository.Purge(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	r.invalidate(ctx, id)
+	return avatarURL, nil
+}
+
+func (r *cachedUserRepository) ScheduleDeletion(ctx context.Context, id uuid.UUID, at time.Time) error {
+	if err := r.UserRepository.ScheduleDeletion(ctx, id, at); err != nil {
+		return err
+	}
+
+	r.invalidate(ctx, id)
+	return nil
+}
+
+func (r *cachedUserRepository) CancelDeletion(ctx context.Context, id uuid.UUID) (bool, error) {
+	cancelled, err := r.UserRepository.CancelDeletion(ctx, id)
+	if err != nil {
+		return false, err
+	}
+
+	if cancelled {
+		r.invalidate(ctx, id)
+	}
+	return cancelled, nil
+}
+
+func (r *cachedUserRepository) Ban(ctx context.Context, user *entities.User) error {
+	if err := r.UserRepository.Ban(ctx, user); err != nil {
+		return err
+	}
+
+	r.invalidate(ctx, user.ID)
+	return nil
+}
+
+func (r *cachedUserRepository) Unban(ctx context.Context, id uuid.UUID) error {
+	if err := r.UserRepository.Unban(ctx, id); err != nil {
+		return err
+	}
+
+	r.invalidate(ctx, id)
+	return nil
+}
+
+func (r *cachedUserRepository) LiftExpiredBans(ctx context.Context, before time.Time, limit int) ([]uuid.UUID, error) {
+	lifted, err := r.UserRepository.LiftExpiredBans(ctx, before, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	r.invalidate(ctx, lifted...)
+	return lifted, nil
+}
+
+func (r *cachedUserRepository) Merge(ctx context.Context, primaryID, duplicateID uuid.UUID, actorID *uuid.UUID, reason string, moveRoles bool) (*entities.UserMergeResult, error) {
+	result, err := r.UserRepository.Merge(ctx, primaryID, duplicateID, actorID, reason, moveRoles)
+	if err != nil {
+		return nil, err
+	}
+
+	r.invalidate(ctx, primaryID, duplicateID)
+	return result, nil
+}
+
+// store is best effort, like invalidate.
+func (r *cachedUserRepository) store(ctx context.Context, user *entities.User) {
+	cached := cachedUser{User: user, PasswordHash: user.PasswordHash}
+	if err := r.cache.Set(ctx, userCacheKey(user.ID), cached, r.ttl); err != nil {
+		r.logger.WithContext(ctx).WithError(err).WithField("user_id", user.ID).Warn("failed to cache user")
+		return
+	}
+	if err := r.cache.Set(ctx, userEmailCacheKey(user.Email), user.ID, r.ttl); err != nil {
+		r.logger.WithContext(ctx).WithError(err).WithField("user_id", user.ID).Warn("failed to cache user email")
+	}
+}
+
+// invalidate is best effort: the write has already succeeded, and a stale
+// entry is bounded by the TTL. Email entries are left alone, since they are
+// checked against the user they point to.
+func (r *cachedUserRepository) invalidate(ctx context.Context, userIDs ...uuid.UUID) {
+	if len(userIDs) == 0 || r.ttl <= 0 {
+		return
+	}
+
+	keys := make([]string, len(userIDs))
+	for i, userID := range userIDs {
+		keys[i] = userCacheKey(userID)
+	}
+
+	if err := r.cache.Delete(ctx, keys...); err != nil {
+		r.logger.WithContext(ctx).WithError(err).WithField("users", len(userIDs)).Warn("failed to invalidate cached users")
+	}
+}
+
+func (r *cachedUserRepository) exists(ctx context.Context, key string, query func() (bool, error)) (bool, error) {
+	if r.existsTTL <= 0 {
+		return query()
+	}
+
+	var exists bool
+	if err := r.cache.Get(ctx, key, &exists); err == nil {
+		return exists, nil
+	}
+
+	exists, err := query()
+	if err != nil {
+		return false, err
+	}
+
+	if err := r.cache.Set(ctx, key, exists, r.existsTTL); err != nil {
+		r.logger.WithContext(ctx).WithError(err).Warn("failed to cache user existence check")
+	}
+
+	return exists, nil
+}
+
+func (r *cachedUserRepository) invalidateExists(ctx context.Context, users ...*entities.User) {
+	if len(users) == 0 || r.existsTTL <= 0 {
+		return
+	}
+
+	keys := make([]string, 0, 2*len(users))
+	for _, user := range users {
+		keys = append(keys, emailExistsCacheKey(user.Email), usernameExistsCacheKey(user.Username))
+	}
+
+	if err := r.cache.Delete(ctx, keys...); err != nil {
+		r.logger.WithContext(ctx).WithError(err).WithField("users", len(users)).Warn("failed to invalidate cached user existence checks")
+	}
+}
+
+func userCacheKey(userID uuid.UUID) string {
+	return fmt.Sprintf("user_profile:%s", userID)
+}
+
+func userEmailCacheKey(email string) string {
+	return fmt.Sprintf("user_email:%s", email)
+}
+
+func emailExistsCacheKey(email string) string {
+	return fmt.Sprintf("user_exists:email:%s", email)
+}
+
+func usernameExistsCacheKey(username string) string {
+	return fmt.Sprintf("user_exists:username:%s", username)
+}
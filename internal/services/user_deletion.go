@@ -0,0 +1,106 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/vagonaizer/authenitfication-service/internal/domain/repositories"
+	"github.com/vagonaizer/authenitfication-service/internal/dto/request"
+	"github.com/vagonaizer/authenitfication-service/internal/dto/response"
+	"github.com/vagonaizer/authenitfication-service/internal/infrastructure/messaging"
+	"github.com/vagonaizer/authenitfication-service/internal/infrastructure/messaging/kafka"
+	"github.com/vagonaizer/authenitfication-service/pkg/errors"
+	"github.com/vagonaizer/authenitfication-service/pkg/logger"
+)
+
+// maxDeletionDelay bounds how far ahead a deletion can be scheduled.
+const maxDeletionDelay = 365 * 24 * time.Hour
+
+func (s *userService) ScheduleDeletion(ctx context.Context, req *request.ScheduleDeletionRequest) (*response.DeletionScheduleResponse, error) {
+	user, err := s.userRepo.GetByID(ctx, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	if user.IsServiceAccount {
+		return nil, errors.Validation("service accounts cannot schedule their deletion")
+	}
+
+	if user.PasswordHash != "" {
+		valid, err := s.hasher.VerifyPassword(req.Password, user.PasswordHash)
+		if err != nil {
+			s.logger.WithContext(ctx).WithError(err).WithField("user_id", user.ID).Error("failed to verify password")
+			return nil, errors.Internal("password verification failed")
+		}
+		if !valid {
+			return nil, errors.InvalidCredentials()
+		}
+	}
+
+	now := time.Now()
+	effectiveAt := now.Add(s.deletionDelay)
+	if req.EffectiveAt != nil {
+		if !req.EffectiveAt.After(now) {
+			return nil, errors.Validation("effective_at must be in the future")
+		}
+		if req.EffectiveAt.After(now.Add(maxDeletionDelay)) {
+			return nil, errors.Validation("effective_at must be within a year")
+		}
+		effectiveAt = *req.EffectiveAt
+	}
+
+	if err := s.userRepo.ScheduleDeletion(ctx, user.ID, effectiveAt); err != nil {
+		return nil, err
+	}
+
+	s.logger.WithContext(ctx).WithFields(logger.Fields{
+		"user_id":      user.ID,
+		"effective_at": effectiveAt,
+	}).Info("account deletion scheduled")
+
+	if err := s.notifications.SendDeletionScheduledEmail(ctx, user.ID.String(), user.Email, user.Locale, effectiveAt); err != nil {
+		s.logger.WithContext(ctx).WithError(err).WithField("user_id", user.ID).Warn("failed to send deletion scheduled email")
+	}
+
+	event := kafka.UserDeletionScheduledEvent{
+		BaseEvent:   kafka.NewBaseEvent(ctx, kafka.TopicUserDeletionScheduled),
+		UserID:      user.ID,
+		EffectiveAt: effectiveAt,
+	}
+
+	if err := s.producer.PublishMessage(ctx, kafka.TopicUserDeletionScheduled, user.ID.String(), event); err != nil {
+		s.logger.WithContext(ctx).WithError(err).Warn("failed to publish user deletion scheduled event")
+	}
+
+	return &response.DeletionScheduleResponse{EffectiveAt: effectiveAt}, nil
+}
+
+func (s *userService) CancelDeletion(ctx context.Context, userID uuid.UUID) error {
+	return cancelScheduledDeletion(ctx, s.userRepo, s.producer, s.logger, userID)
+}
+
+// cancelScheduledDeletion is shared with login, where users can cancel a
+// pending deletion. Cancelling when nothing is scheduled is a no-op.
+func cancelScheduledDeletion(ctx context.Context, userRepo repositories.UserRepository, producer messaging.Publisher, log *logger.Logger, userID uuid.UUID) error {
+	cancelled, err := userRepo.CancelDeletion(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if !cancelled {
+		return nil
+	}
+
+	log.WithField("user_id", userID).Info("account deletion cancelled")
+
+	event := kafka.UserDeletionCancelledEvent{
+		BaseEvent: kafka.NewBaseEvent(ctx, kafka.TopicUserDeletionCancelled),
+		UserID:    userID,
+	}
+
+	if err := producer.PublishMessage(ctx, kafka.TopicUserDeletionCancelled, userID.String(), event); err != nil {
+		log.WithError(err).Warn("failed to publish user deletion cancelled event")
+	}
+
+	return nil
+}
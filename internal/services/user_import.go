@@ -0,0 +1,214 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/vagonaizer/authenitfication-service/internal/domain/entities"
+	"github.com/vagonaizer/authenitfication-service/internal/dto/request"
+	"github.com/vagonaizer/authenitfication-service/internal/dto/response"
+	"github.com/vagonaizer/authenitfication-service/internal/infrastructure/messaging/kafka"
+	"github.com/vagonaizer/authenitfication-service/pkg/errors"
+	"github.com/vagonaizer/authenitfication-service/pkg/utils"
+)
+
+// importBatchSize is how many rows of an import share one transaction.
+const importBatchSize = 500
+
+// ImportUsers creates users in batches. Rows failing validation or insertion
+// are reported individually; the remaining rows are still imported. An
+// import that assigns roles is refused as a whole unless the actor may
+// assign roles.
+func (s *userService) ImportUsers(ctx context.Context, req *request.ImportUsersRequest) (*response.ImportUsersResponse, error) {
+	if importAssignsRoles(req.Users) {
+		if err := s.requireRoleAssignment(ctx, req.ActorID, req.ActorRoles); err != nil {
+			return nil, err
+		}
+	}
+
+	result := &response.ImportUsersResponse{
+		Total:   len(req.Users),
+		Created: []*response.ImportUserResult{},
+		Failed:  []*response.ImportUserFailure{},
+	}
+
+	roles := make(map[string]*entities.Role)
+	seenEmails := make(map[string]bool, len(req.Users))
+	seenUsernames := make(map[string]bool, len(req.Users))
+
+	var batch []*entities.UserImport
+	var batchRows []int
+
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+
+		rowErrs, err := s.userRepo.ImportBatch(ctx, batch, req.ActorID)
+		if err != nil {
+			return err
+		}
+
+		for i, row := range batch {
+			if rowErrs[i] != nil {
+				result.Failed = append(result.Failed, importFailure(batchRows[i], row.User.Email, rowErrs[i]))
+				continue
+			}
+
+			result.Created = append(result.Created, &response.ImportUserResult{
+				Row:    batchRows[i],
+				UserID: row.User.ID,
+				Email:  row.User.Email,
+			})
+			s.publishImportedUser(ctx, row, req.ActorID)
+		}
+
+		batch, batchRows = nil, nil
+		return nil
+	}
+
+	for i, record := range req.Users {
+		rowNum := i + 1
+
+		row, err := s.prepareImportRow(ctx, record, roles, seenEmails, seenUsernames)
+		if err != nil {
+			if _, ok := err.(*errors.AppError); !ok {
+				return nil, err
+			}
+			result.Failed = append(result.Failed, importFailure(rowNum, record.Email, err))
+			continue
+		}
+
+		batch = append(batch, row)
+		batchRows = append(batchRows, rowNum)
+		if len(batch) == importBatchSize {
+			if err := flush(); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if err := flush(); err != nil {
+		return nil, err
+	}
+
+	s.logger.WithContext(ctx).WithField("created", len(result.Created)).WithField("failed", len(result.Failed)).Info("user import finished")
+
+	return result, nil
+}
+
+// prepareImportRow validates one record and resolves its roles. Row-level
+// problems are returned as AppErrors; anything else aborts the import.
+func (s *userService) prepareImportRow(ctx context.Context, record *request.ImportUserRecord, roles map[string]*entities.Role, seenEmails, seenUsernames map[string]bool) (*entities.UserImport, error) {
+	if record == nil {
+		return nil, errors.Validation("empty row")
+	}
+
+	if !utils.IsValidEmail(record.Email) {
+		return nil, errors.Validation("invalid email format")
+	}
+	if !utils.IsValidUsername(record.Username) {
+		return nil, errors.Validation("invalid username format")
+	}
+	if record.PasswordHash != "" && !s.hasher.IsValidHash(record.PasswordHash) {
+		return nil, errors.Validation("password_hash must be an argon2id hash")
+	}
+
+	email := utils.NormalizeEmail(record.Email)
+	username := utils.NormalizeUsername(record.Username)
+	if seenEmails[email] {
+		return nil, errors.Validation("duplicate email in import")
+	}
+	if seenUsernames[username] {
+		return nil, errors.Validation("duplicate username in import")
+	}
+
+	row := &entities.UserImport{
+		User: &entities.User{
+			ID:           uuid.New(),
+			Email:        email,
+			Username:     username,
+			PasswordHash: record.PasswordHash,
+			FirstName:    record.FirstName,
+			LastName:     record.LastName,
+			IsActive:     true,
+		},
+	}
+
+	seenRoles := make(map[uuid.UUID]bool, len(record.Roles))
+	for _, name := range record.Roles {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+
+		role, ok := roles[name]
+		if !ok {
+			var err error
+			role, err = s.roleRepo.GetByName(ctx, name)
+			if err != nil {
+				if appErr, ok := err.(*errors.AppError); ok && appErr.Code == errors.CodeNotFound {
+					return nil, errors.Validation(fmt.Sprintf("role %q not found", name))
+				}
+				return nil, fmt.Errorf("resolve role %q: %w", name, err)
+			}
+			roles[name] = role
+		}
+
+		if !seenRoles[role.ID] {
+			seenRoles[role.ID] = true
+			row.Roles = append(row.Roles, role)
+		}
+	}
+
+	seenEmails[email] = true
+	seenUsernames[username] = true
+
+	return row, nil
+}
+
+func importAssignsRoles(records []*request.ImportUserRecord) bool {
+	for _, record := range records {
+		if record == nil {
+			continue
+		}
+		for _, name := range record.Roles {
+			if strings.TrimSpace(name) != "" {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (s *userService) publishImportedUser(ctx context.Context, row *entities.UserImport, actorID *uuid.UUID) {
+	roleNames := make([]string, len(row.Roles))
+	for i, role := range row.Roles {
+		roleNames[i] = role.Name
+	}
+
+	event := kafka.UserCreatedByAdminEvent{
+		BaseEvent: kafka.NewBaseEvent(ctx, kafka.TopicUserCreatedByAdmin),
+		UserID:    row.User.ID,
+		Email:     row.User.Email,
+		Username:  row.User.Username,
+		FirstName: row.User.FirstName,
+		LastName:  row.User.LastName,
+		Roles:     roleNames,
+		CreatedBy: actorID,
+	}
+
+	if err := s.producer.PublishMessage(ctx, kafka.TopicUserCreatedByAdmin, row.User.ID.String(), event); err != nil {
+		s.logger.WithContext(ctx).WithError(err).Warn("failed to publish user created by admin event")
+	}
+}
+
+func importFailure(row int, email string, err error) *response.ImportUserFailure {
+	message := "internal error"
+	if appErr, ok := err.(*errors.AppError); ok {
+		message = appErr.Message
+	}
+	return &response.ImportUserFailure{Row: row, Email: email, Error: message}
+}
@@ -0,0 +1,131 @@
+package services
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/vagonaizer/authenitfication-service/internal/domain/entities"
+	"github.com/vagonaizer/authenitfication-service/internal/dto/request"
+	"github.com/vagonaizer/authenitfication-service/internal/dto/response"
+	"github.com/vagonaizer/authenitfication-service/internal/infrastructure/messaging/kafka"
+	"github.com/vagonaizer/authenitfication-service/pkg/errors"
+	"github.com/vagonaizer/authenitfication-service/pkg/logger"
+	"github.com/vagonaizer/authenitfication-service/pkg/utils"
+)
+
+const (
+	mergeReasonAdmin = "account merge"
+	mergeReasonOwner = "account merge requested by owner"
+)
+
+// MergeUsers moves the roles of the duplicate onto the primary only when
+// the actor may assign roles; otherwise they are revoked with the
+// duplicate.
+func (s *userService) MergeUsers(ctx context.Context, req *request.MergeUsersRequest) (*response.UserMergeResponse, error) {
+	if req.DuplicateID == req.ActorID {
+		return nil, errors.Validation("you cannot merge away your own account")
+	}
+
+	duplicate, err := s.userRepo.GetByID(ctx, req.DuplicateID)
+	if err != nil {
+		return nil, err
+	}
+
+	moveRoles, err := s.canAssignRoles(ctx, &req.ActorID, req.ActorRoles)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.mergeUsers(ctx, req.ActorID, req.PrimaryID, duplicate, mergeReasonAdmin, moveRoles)
+}
+
+func (s *userService) MergeOwnAccount(ctx context.Context, req *request.MergeOwnAccountRequest) (*response.UserMergeResponse, error) {
+	duplicate, err := s.userRepo.GetByEmail(ctx, utils.NormalizeEmail(req.Email))
+	if err != nil {
+		if appErr, ok := err.(*errors.AppError); ok && appErr.Code == errors.CodeUserNotFound {
+			return nil, errors.InvalidCredentials()
+		}
+		return nil, err
+	}
+
+	// The same error for a wrong password and for an account without one, so
+	// the endpoint cannot be used to probe which emails are registered.
+	if duplicate.PasswordHash == "" || duplicate.IsServiceAccount {
+		return nil, errors.InvalidCredentials()
+	}
+
+	valid, err := s.hasher.VerifyPassword(req.Password, duplicate.PasswordHash)
+	if err != nil {
+		s.logger.WithContext(ctx).WithError(err).WithField("user_id", duplicate.ID).Error("failed to verify password")
+		return nil, errors.Internal("password verification failed")
+	}
+	if !valid {
+		return nil, errors.InvalidCredentials()
+	}
+
+	// Both accounts are the caller's, and so are the roles of the duplicate.
+	return s.mergeUsers(ctx, req.UserID, req.UserID, duplicate, mergeReasonOwner, true)
+}
+
+func (s *userService) mergeUsers(ctx context.Context, actorID, primaryID uuid.UUID, duplicate *entities.User, reason string, moveRoles bool) (*response.UserMergeResponse, error) {
+	if primaryID == duplicate.ID {
+		return nil, errors.Validation("an account cannot be merged into itself")
+	}
+
+	primary, err := s.userRepo.GetByID(ctx, primaryID)
+	if err != nil {
+		return nil, err
+	}
+
+	if primary.IsServiceAccount || duplicate.IsServiceAccount {
+		return nil, errors.Validation("service accounts cannot be merged")
+	}
+
+	// Сессии удаляются до слияния: если это не удалось, дубликат остаётся как был
+	if err := s.sessionRepo.DeleteByUserID(ctx, duplicate.ID); err != nil {
+		return nil, err
+	}
+
+	result, err := s.userRepo.Merge(ctx, primary.ID, duplicate.ID, &actorID, reason, moveRoles)
+	if err != nil {
+		return nil, err
+	}
+
+	// Sessions are already gone; this revokes the access tokens still in use.
+	publishSessionRevoked(ctx, s.producer, s.logger, duplicate.ID, nil, entities.SessionRevokedMerged)
+	if err := s.revocations.RevokeUserTokens(ctx, duplicate.ID); err != nil {
+		s.logger.WithContext(ctx).WithError(err).WithField("user_id", duplicate.ID).Error("failed to revoke user tokens")
+	}
+
+	recordActivity(ctx, s.activityRepo, s.logger, &entities.UserActivity{
+		UserID:  primary.ID,
+		Type:    entities.ActivityAccountMerged,
+		ActorID: &actorID,
+		Details: map[string]string{"duplicate_id": duplicate.ID.String()},
+	})
+
+	s.logger.WithContext(ctx).WithFields(logger.Fields{
+		"primary_user_id":   primary.ID,
+		"duplicate_user_id": duplicate.ID,
+		"actor_id":          actorID,
+	}).Info("users merged")
+
+	event := kafka.UserMergedEvent{
+		BaseEvent:       kafka.NewBaseEvent(ctx, kafka.TopicUserMerged),
+		PrimaryUserID:   primary.ID,
+		DuplicateUserID: duplicate.ID,
+		MergedBy:        actorID,
+	}
+
+	if err := s.producer.PublishMessage(ctx, kafka.TopicUserMerged, primary.ID.String(), event); err != nil {
+		s.logger.WithContext(ctx).WithError(err).Warn("failed to publish user merged event")
+	}
+
+	return &response.UserMergeResponse{
+		PrimaryUserID:      primary.ID,
+		DuplicateUserID:    duplicate.ID,
+		RolesMoved:         result.RolesMoved,
+		OrganizationsMoved: result.OrganizationsMoved,
+		GroupsMoved:        result.GroupsMoved,
+	}, nil
+}
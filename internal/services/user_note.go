@@ -0,0 +1,130 @@
+package services
+
+import (
+	"context"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/vagonaizer/authenitfication-service/internal/domain/entities"
+	"github.com/vagonaizer/authenitfication-service/internal/dto/request"
+	"github.com/vagonaizer/authenitfication-service/internal/dto/response"
+	"github.com/vagonaizer/authenitfication-service/pkg/errors"
+	"github.com/vagonaizer/authenitfication-service/pkg/logger"
+)
+
+func (s *userService) GetUserDetail(ctx context.Context, userID uuid.UUID) (*response.AdminUserResponse, error) {
+	user, err := s.GetProfile(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	notes, err := s.ListNotes(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &response.AdminUserResponse{
+		UserResponse: user,
+		Notes:        notes,
+	}, nil
+}
+
+func (s *userService) ListNotes(ctx context.Context, userID uuid.UUID) ([]*response.UserNoteResponse, error) {
+	notes, err := s.noteRepo.ListByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*response.UserNoteResponse, len(notes))
+	for i, note := range notes {
+		result[i] = toNoteResponse(note)
+	}
+
+	return result, nil
+}
+
+func (s *userService) CreateNote(ctx context.Context, req *request.UserNoteRequest) (*response.UserNoteResponse, error) {
+	body := strings.TrimSpace(req.Body)
+	if body == "" {
+		return nil, errors.Validation("body is required")
+	}
+
+	if _, err := s.userRepo.GetByID(ctx, req.UserID); err != nil {
+		return nil, err
+	}
+
+	authorID := req.ActorID
+	note := &entities.UserNote{
+		UserID:   req.UserID,
+		AuthorID: &authorID,
+		Body:     body,
+	}
+
+	if err := s.noteRepo.Create(ctx, note); err != nil {
+		return nil, err
+	}
+
+	s.logger.WithContext(ctx).WithFields(logger.Fields{
+		"user_id":  note.UserID,
+		"note_id":  note.ID,
+		"actor_id": authorID,
+	}).Info("user note created")
+
+	return toNoteResponse(note), nil
+}
+
+func (s *userService) UpdateNote(ctx context.Context, req *request.UserNoteRequest) (*response.UserNoteResponse, error) {
+	body := strings.TrimSpace(req.Body)
+	if body == "" {
+		return nil, errors.Validation("body is required")
+	}
+
+	note, err := s.getNote(ctx, req.UserID, req.NoteID)
+	if err != nil {
+		return nil, err
+	}
+
+	if note.AuthorID == nil || *note.AuthorID != req.ActorID {
+		return nil, errors.Forbidden("only the author can edit a note")
+	}
+
+	note.Body = body
+	if err := s.noteRepo.Update(ctx, note); err != nil {
+		return nil, err
+	}
+
+	return toNoteResponse(note), nil
+}
+
+func (s *userService) DeleteNote(ctx context.Context, userID, noteID uuid.UUID) error {
+	if _, err := s.getNote(ctx, userID, noteID); err != nil {
+		return err
+	}
+
+	return s.noteRepo.Delete(ctx, noteID)
+}
+
+// getNote loads a note and checks it belongs to userID, so a note ID cannot
+// be used through another user's path.
+func (s *userService) getNote(ctx context.Context, userID, noteID uuid.UUID) (*entities.UserNote, error) {
+	note, err := s.noteRepo.GetByID(ctx, noteID)
+	if err != nil {
+		return nil, err
+	}
+
+	if note.UserID != userID {
+		return nil, errors.NotFound("note not found")
+	}
+
+	return note, nil
+}
+
+func toNoteResponse(note *entities.UserNote) *response.UserNoteResponse {
+	return &response.UserNoteResponse{
+		ID:        note.ID,
+		AuthorID:  note.AuthorID,
+		Body:      note.Body,
+		CreatedAt: note.CreatedAt,
+		UpdatedAt: note.UpdatedAt,
+	}
+}
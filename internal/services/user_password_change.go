@@ -0,0 +1,81 @@
+package services
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/vagonaizer/authenitfication-service/internal/domain/entities"
+	"github.com/vagonaizer/authenitfication-service/internal/infrastructure/messaging/kafka"
+	"github.com/vagonaizer/authenitfication-service/pkg/errors"
+	"github.com/vagonaizer/authenitfication-service/pkg/logger"
+)
+
+func (s *userService) RequirePasswordChange(ctx context.Context, actorID, userID uuid.UUID) error {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	if user.IsServiceAccount {
+		return errors.Validation("service accounts do not have a password")
+	}
+
+	// Users without a password could not get past the restricted login.
+	if user.PasswordHash == "" {
+		return errors.Validation("user has no password to change")
+	}
+
+	if err := s.setPasswordChangeRequired(ctx, actorID, user, true); err != nil {
+		return err
+	}
+
+	// Tokens issued before the flag was set would bypass it until they expire.
+	s.signOutEverywhere(ctx, user.ID, entities.SessionRevokedPasswordChangeRequired)
+
+	recordActivity(ctx, s.activityRepo, s.logger, &entities.UserActivity{
+		UserID:  user.ID,
+		Type:    entities.ActivityPasswordChangeRequired,
+		ActorID: &actorID,
+	})
+
+	return nil
+}
+
+func (s *userService) WaivePasswordChange(ctx context.Context, actorID, userID uuid.UUID) error {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	if !user.PasswordChangeRequired {
+		return nil
+	}
+
+	return s.setPasswordChangeRequired(ctx, actorID, user, false)
+}
+
+func (s *userService) setPasswordChangeRequired(ctx context.Context, actorID uuid.UUID, user *entities.User, required bool) error {
+	user.PasswordChangeRequired = required
+	if err := s.userRepo.Update(ctx, user); err != nil {
+		return err
+	}
+
+	s.logger.WithContext(ctx).WithFields(logger.Fields{
+		"user_id":  user.ID,
+		"actor_id": actorID,
+		"required": required,
+	}).Info("password change requirement updated")
+
+	event := kafka.UserPasswordChangeRequiredEvent{
+		BaseEvent: kafka.NewBaseEvent(ctx, kafka.TopicUserPasswordChangeRequired),
+		UserID:    user.ID,
+		Required:  required,
+		ActorID:   actorID,
+	}
+
+	if err := s.producer.PublishMessage(ctx, kafka.TopicUserPasswordChangeRequired, user.ID.String(), event); err != nil {
+		s.logger.WithContext(ctx).WithError(err).Warn("failed to publish user password change required event")
+	}
+
+	return nil
+}
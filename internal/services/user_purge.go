@@ -0,0 +1,164 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/vagonaizer/authenitfication-service/internal/domain/entities"
+	"github.com/vagonaizer/authenitfication-service/internal/domain/repositories"
+	"github.com/vagonaizer/authenitfication-service/internal/domain/services"
+	"github.com/vagonaizer/authenitfication-service/internal/dto/request"
+	"github.com/vagonaizer/authenitfication-service/internal/infrastructure/messaging"
+	"github.com/vagonaizer/authenitfication-service/internal/infrastructure/messaging/kafka"
+	"github.com/vagonaizer/authenitfication-service/pkg/errors"
+	"github.com/vagonaizer/authenitfication-service/pkg/logger"
+)
+
+func (s *userService) PurgeAccount(ctx context.Context, req *request.PurgeAccountRequest) error {
+	user, err := s.userRepo.GetByID(ctx, req.UserID)
+	if err != nil {
+		return err
+	}
+
+	if user.PasswordHash != "" {
+		valid, err := s.hasher.VerifyPassword(req.Password, user.PasswordHash)
+		if err != nil {
+			s.logger.WithContext(ctx).WithError(err).WithField("user_id", user.ID).Error("failed to verify password")
+			return errors.Internal("password verification failed")
+		}
+		if !valid {
+			return errors.InvalidCredentials()
+		}
+	}
+
+	return purgeUser(ctx, s.userRepo, s.sessionRepo, s.revocations, s.storage, s.producer, s.logger, user.ID, entities.UserPurgeReasonRequested)
+}
+
+// purgeUser signs the user out everywhere before anonymizing the account, so
+// a failure leaves it to be purged again rather than purged with live
+// sessions or tokens.
+func purgeUser(
+	ctx context.Context,
+	userRepo repositories.UserRepository,
+	sessionRepo repositories.SessionRepository,
+	revocations services.TokenRevocationService,
+	storage services.FileStorage,
+	producer messaging.Publisher,
+	log *logger.Logger,
+	userID uuid.UUID,
+	reason string,
+) error {
+	if err := sessionRepo.DeleteByUserID(ctx, userID); err != nil {
+		return err
+	}
+	if err := revocations.RevokeUserTokens(ctx, userID); err != nil {
+		return err
+	}
+	publishSessionRevoked(ctx, producer, log, userID, nil, entities.SessionRevokedPurged)
+
+	avatarURL, err := userRepo.Purge(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	if avatarURL != nil {
+		if err := storage.Delete(ctx, *avatarURL); err != nil {
+			log.WithError(err).WithField("user_id", userID).Warn("failed to delete avatar of purged user")
+		}
+	}
+
+	log.WithField("user_id", userID).WithField("reason", reason).Info("user purged")
+
+	event := kafka.UserPurgedEvent{
+		BaseEvent: kafka.NewBaseEvent(ctx, kafka.TopicUserPurged),
+		UserID:    userID,
+		Reason:    reason,
+	}
+
+	if err := producer.PublishMessage(ctx, kafka.TopicUserPurged, userID.String(), event); err != nil {
+		log.WithError(err).Warn("failed to publish user purged event")
+	}
+
+	return nil
+}
+
+// UserPurgeSweeper periodically purges accounts whose scheduled deletion is
+// due and accounts that have been soft-deleted for longer than the retention
+// period.
+type UserPurgeSweeper struct {
+	userRepo    repositories.UserRepository
+	sessionRepo repositories.SessionRepository
+	revocations services.TokenRevocationService
+	storage     services.FileStorage
+	producer    messaging.Publisher
+	logger      *logger.Logger
+	retention   time.Duration
+	batchSize   int
+}
+
+func NewUserPurgeSweeper(
+	userRepo repositories.UserRepository,
+	sessionRepo repositories.SessionRepository,
+	revocations services.TokenRevocationService,
+	storage services.FileStorage,
+	producer messaging.Publisher,
+	logger *logger.Logger,
+	retention time.Duration,
+	batchSize int,
+) *UserPurgeSweeper {
+	return &UserPurgeSweeper{
+		userRepo:    userRepo,
+		sessionRepo: sessionRepo,
+		revocations: revocations,
+		storage:     storage,
+		producer:    producer,
+		logger:      logger,
+		retention:   retention,
+		batchSize:   batchSize,
+	}
+}
+
+// Sweep purges due accounts in batches until none are left. A non-positive
+// retention only disables purging of soft-deleted accounts; scheduled
+// deletions still run.
+func (s *UserPurgeSweeper) Sweep(ctx context.Context) {
+	s.sweep(ctx, entities.UserPurgeReasonScheduled, func() ([]uuid.UUID, error) {
+		return s.userRepo.ListDueDeletions(ctx, time.Now(), s.batchSize)
+	})
+
+	if s.retention > 0 {
+		s.sweep(ctx, entities.UserPurgeReasonRetention, func() ([]uuid.UUID, error) {
+			return s.userRepo.ListPurgeCandidates(ctx, time.Now().Add(-s.retention), s.batchSize)
+		})
+	}
+}
+
+func (s *UserPurgeSweeper) sweep(ctx context.Context, reason string, list func() ([]uuid.UUID, error)) {
+	for ctx.Err() == nil {
+		userIDs, err := list()
+		if err != nil {
+			s.logger.WithContext(ctx).WithError(err).Error("failed to list users to purge")
+			return
+		}
+
+		purged := 0
+		for _, userID := range userIDs {
+			if err := purgeUser(ctx, s.userRepo, s.sessionRepo, s.revocations, s.storage, s.producer, s.logger, userID, reason); err != nil {
+				s.logger.WithContext(ctx).WithError(err).WithField("user_id", userID).Error("failed to purge user")
+				continue
+			}
+			purged++
+		}
+
+		if purged > 0 {
+			s.logger.WithContext(ctx).Infof("purged %d users (%s)", purged, reason)
+		}
+
+		// Stop when the batch was short or nothing could be purged, so a
+		// persistently failing row does not spin the loop.
+		if len(userIDs) < s.batchSize || purged == 0 {
+			return
+		}
+	}
+}
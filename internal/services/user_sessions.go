@@ -0,0 +1,40 @@
+package services
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/vagonaizer/authenitfication-service/internal/domain/entities"
+	"github.com/vagonaizer/authenitfication-service/pkg/logger"
+)
+
+// RevokeSessions fails when sessions or tokens cannot be revoked, unlike the
+// sign-out that comes with other account changes: here it is the point.
+func (s *userService) RevokeSessions(ctx context.Context, actorID, userID uuid.UUID) error {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.sessionRepo.DeleteByUserID(ctx, user.ID); err != nil {
+		return err
+	}
+	publishSessionRevoked(ctx, s.producer, s.logger, user.ID, nil, entities.SessionRevokedByAdmin)
+
+	if err := s.revocations.RevokeUserTokens(ctx, user.ID); err != nil {
+		return err
+	}
+
+	s.logger.WithContext(ctx).WithFields(logger.Fields{
+		"user_id":  user.ID,
+		"actor_id": actorID,
+	}).Info("user sessions revoked")
+
+	recordActivity(ctx, s.activityRepo, s.logger, &entities.UserActivity{
+		UserID:  user.ID,
+		Type:    entities.ActivitySessionsRevoked,
+		ActorID: &actorID,
+	})
+
+	return nil
+}
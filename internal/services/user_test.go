@@ -0,0 +1,71 @@
+package services
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/vagonaizer/authenitfication-service/internal/domain/entities"
+	"github.com/vagonaizer/authenitfication-service/internal/domain/services"
+	"github.com/vagonaizer/authenitfication-service/internal/dto/request"
+	"github.com/vagonaizer/authenitfication-service/pkg/errors"
+)
+
+// roleAuthorizer grants the permissions listed for each role of the subject.
+type roleAuthorizer map[string][]string
+
+func (a roleAuthorizer) Authorize(_ context.Context, subject *services.Subject, action, resource string) (bool, error) {
+	for _, role := range subject.Roles {
+		for _, permission := range a[role] {
+			if permission == entities.PermissionName(resource, action) {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
+
+func TestCreateUserRolesRequireRoleAssign(t *testing.T) {
+	service := &userService{
+		authorizer: roleAuthorizer{
+			"user-manager": {entities.PermissionUsersManage},
+		},
+	}
+	actorID := uuid.New()
+
+	_, err := service.CreateUser(context.Background(), &request.CreateUserRequest{
+		ActorID:    &actorID,
+		ActorRoles: []string{"user-manager"},
+		Email:      "new.user@example.com",
+		Username:   "new_user",
+		RoleIDs:    []uuid.UUID{uuid.New()},
+	})
+
+	appErr, ok := err.(*errors.AppError)
+	if !ok || appErr.StatusCode != http.StatusForbidden {
+		t.Fatalf("CreateUser with role_ids by a users:manage-only caller: got %v, want forbidden", err)
+	}
+}
+
+func TestImportUsersRolesRequireRoleAssign(t *testing.T) {
+	service := &userService{
+		authorizer: roleAuthorizer{
+			"user-manager": {entities.PermissionUsersManage},
+		},
+	}
+	actorID := uuid.New()
+
+	_, err := service.ImportUsers(context.Background(), &request.ImportUsersRequest{
+		ActorID:    &actorID,
+		ActorRoles: []string{"user-manager"},
+		Users: []*request.ImportUserRecord{
+			{Email: "new.user@example.com", Username: "new_user", Roles: []string{"admin"}},
+		},
+	})
+
+	appErr, ok := err.(*errors.AppError)
+	if !ok || appErr.StatusCode != http.StatusForbidden {
+		t.Fatalf("ImportUsers with roles by a users:manage-only caller: got %v, want forbidden", err)
+	}
+}
@@ -0,0 +1,287 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/vagonaizer/authenitfication-service/internal/domain/entities"
+	"github.com/vagonaizer/authenitfication-service/internal/domain/repositories"
+	"github.com/vagonaizer/authenitfication-service/internal/domain/services"
+	"github.com/vagonaizer/authenitfication-service/internal/infrastructure/database/redis"
+	"github.com/vagonaizer/authenitfication-service/internal/infrastructure/messaging"
+	"github.com/vagonaizer/authenitfication-service/internal/infrastructure/messaging/kafka"
+	"github.com/vagonaizer/authenitfication-service/pkg/errors"
+	"github.com/vagonaizer/authenitfication-service/pkg/logger"
+	"github.com/vagonaizer/authenitfication-service/pkg/utils"
+)
+
+type verificationService struct {
+	userRepo      repositories.UserRepository
+	cache         *redis.CacheService
+	notifications services.NotificationService
+	producer      messaging.Publisher
+	logger        *logger.Logger
+	tokenTTL      time.Duration
+	resetTTL      time.Duration
+	resendLimit   int
+	resendWindow  time.Duration
+	phoneCodeTTL  time.Duration
+	phoneAttempts int
+}
+
+func NewVerificationService(
+	userRepo repositories.UserRepository,
+	cache *redis.CacheService,
+	notifications services.NotificationService,
+	producer messaging.Publisher,
+	logger *logger.Logger,
+	tokenTTL time.Duration,
+	resetTTL time.Duration,
+	resendLimit int,
+	resendWindow time.Duration,
+	phoneCodeTTL time.Duration,
+	phoneAttempts int,
+) *verificationService {
+	return &verificationService{
+		userRepo:      userRepo,
+		cache:         cache,
+		notifications: notifications,
+		producer:      producer,
+		logger:        logger,
+		tokenTTL:      tokenTTL,
+		resetTTL:      resetTTL,
+		resendLimit:   resendLimit,
+		resendWindow:  resendWindow,
+		phoneCodeTTL:  phoneCodeTTL,
+		phoneAttempts: phoneAttempts,
+	}
+}
+
+func (s *verificationService) SendVerification(ctx context.Context, user *entities.User) error {
+	token, err := utils.GenerateSecureToken()
+	if err != nil {
+		s.logger.WithContext(ctx).WithError(err).Error("failed to generate verification token")
+		return errors.Internal("failed to generate verification token")
+	}
+
+	// Only the hash is stored; the user key points at the current token so
+	// that issuing a new one revokes the previous one.
+	tokenHash := utils.HashSHA256(token)
+	userKey := verificationUserKey(user.ID)
+
+	var previous string
+	if err := s.cache.Get(ctx, userKey, &previous); err == nil {
+		if err := s.cache.Delete(ctx, verificationTokenKey(previous)); err != nil {
+			s.logger.WithContext(ctx).WithError(err).WithField("user_id", user.ID).Warn("failed to revoke previous verification token")
+		}
+	}
+
+	if err := s.cache.Set(ctx, verificationTokenKey(tokenHash), user.ID.String(), s.tokenTTL); err != nil {
+		s.logger.WithContext(ctx).WithError(err).WithField("user_id", user.ID).Error("failed to store verification token")
+		return errors.Internal("failed to issue verification token")
+	}
+	if err := s.cache.Set(ctx, userKey, tokenHash, s.tokenTTL); err != nil {
+		s.logger.WithContext(ctx).WithError(err).WithField("user_id", user.ID).Error("failed to store verification token")
+		return errors.Internal("failed to issue verification token")
+	}
+
+	if err := s.notifications.SendVerificationEmail(ctx, user.ID.String(), user.Email, user.Locale, token); err != nil {
+		s.logger.WithContext(ctx).WithError(err).WithField("user_id", user.ID).Error("failed to send verification email")
+		return errors.Internal("failed to send verification email")
+	}
+
+	return nil
+}
+
+func (s *verificationService) ResendVerification(ctx context.Context, email string) error {
+	email = utils.NormalizeEmail(email)
+
+	// Counted before the lookup, so that a 429 does not reveal registered emails.
+	if s.resendLimit > 0 {
+		sent, err := s.cache.IncrementCounter(ctx, verificationResendKey(email), s.resendWindow)
+		if err != nil {
+			// Do not block verification when Redis is unavailable.
+			s.logger.WithContext(ctx).WithError(err).Warn("failed to count verification resends")
+		} else if sent > int64(s.resendLimit) {
+			return errors.RateLimitExceeded()
+		}
+	}
+
+	user, err := s.userRepo.GetByEmail(ctx, email)
+	if err != nil {
+		if appErr, ok := err.(*errors.AppError); ok && appErr.Code == errors.CodeUserNotFound {
+			return nil
+		}
+		return err
+	}
+
+	if user.IsVerified || !user.IsActive || user.IsServiceAccount {
+		return nil
+	}
+
+	if err := s.SendVerification(ctx, user); err != nil {
+		return err
+	}
+
+	event := kafka.VerificationResentEvent{
+		BaseEvent: kafka.NewBaseEvent(ctx, kafka.TopicVerificationResent),
+		UserID:    user.ID,
+		Email:     user.Email,
+	}
+
+	if err := s.producer.PublishMessage(ctx, kafka.TopicVerificationResent, user.ID.String(), event); err != nil {
+		s.logger.WithContext(ctx).WithError(err).Warn("failed to publish verification resent event")
+	}
+
+	return nil
+}
+
+func (s *verificationService) VerifyEmail(ctx context.Context, token string) error {
+	tokenHash := utils.HashSHA256(token)
+
+	var rawUserID string
+	if err := s.cache.Get(ctx, verificationTokenKey(tokenHash), &rawUserID); err != nil {
+		return errors.TokenInvalid()
+	}
+
+	userID, err := uuid.Parse(rawUserID)
+	if err != nil {
+		return errors.TokenInvalid()
+	}
+
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	if !user.IsVerified {
+		user.IsVerified = true
+
+		if err := s.userRepo.Update(ctx, user); err != nil {
+			return err
+		}
+
+		event := kafka.UserVerifiedEvent{
+			BaseEvent: kafka.NewBaseEvent(ctx, kafka.TopicUserVerified),
+			UserID:    user.ID,
+			Email:     user.Email,
+		}
+
+		if err := s.producer.PublishMessage(ctx, kafka.TopicUserVerified, user.ID.String(), event); err != nil {
+			s.logger.WithContext(ctx).WithError(err).Warn("failed to publish user verified event")
+		}
+	}
+
+	if err := s.cache.Delete(ctx, verificationTokenKey(tokenHash), verificationUserKey(user.ID)); err != nil {
+		s.logger.WithContext(ctx).WithError(err).WithField("user_id", user.ID).Warn("failed to delete verification token")
+	}
+
+	return nil
+}
+
+func (s *verificationService) SendPasswordReset(ctx context.Context, email string) error {
+	email = utils.NormalizeEmail(email)
+
+	// Лимит считается по email до поиска пользователя, иначе 429 выдаёт существующие аккаунты
+	if s.resendLimit > 0 {
+		sent, err := s.cache.IncrementCounter(ctx, passwordResetSendKey(email), s.resendWindow)
+		if err != nil {
+			s.logger.WithContext(ctx).WithError(err).Warn("failed to count password reset emails")
+		} else if sent > int64(s.resendLimit) {
+			return errors.RateLimitExceeded()
+		}
+	}
+
+	user, err := s.userRepo.GetByEmail(ctx, email)
+	if err != nil {
+		if appErr, ok := err.(*errors.AppError); ok && appErr.Code == errors.CodeUserNotFound {
+			return nil
+		}
+		return err
+	}
+
+	if !user.IsActive || user.IsServiceAccount {
+		return nil
+	}
+
+	token, err := utils.GenerateSecureToken()
+	if err != nil {
+		s.logger.WithContext(ctx).WithError(err).Error("failed to generate password reset token")
+		return errors.Internal("failed to generate password reset token")
+	}
+
+	// Как и для подтверждения email, храним только хеш текущего токена
+	tokenHash := utils.HashSHA256(token)
+	userKey := passwordResetUserKey(user.ID)
+
+	var previous string
+	if err := s.cache.Get(ctx, userKey, &previous); err == nil {
+		if err := s.cache.Delete(ctx, passwordResetTokenKey(previous)); err != nil {
+			s.logger.WithContext(ctx).WithError(err).WithField("user_id", user.ID).Warn("failed to revoke previous password reset token")
+		}
+	}
+
+	if err := s.cache.Set(ctx, passwordResetTokenKey(tokenHash), user.ID.String(), s.resetTTL); err != nil {
+		s.logger.WithContext(ctx).WithError(err).WithField("user_id", user.ID).Error("failed to store password reset token")
+		return errors.Internal("failed to issue password reset token")
+	}
+	if err := s.cache.Set(ctx, userKey, tokenHash, s.resetTTL); err != nil {
+		s.logger.WithContext(ctx).WithError(err).WithField("user_id", user.ID).Error("failed to store password reset token")
+		return errors.Internal("failed to issue password reset token")
+	}
+
+	if err := s.notifications.SendPasswordResetEmail(ctx, user.ID.String(), user.Email, user.Locale, token); err != nil {
+		s.logger.WithContext(ctx).WithError(err).WithField("user_id", user.ID).Error("failed to send password reset email")
+		return errors.Internal("failed to send password reset email")
+	}
+
+	return nil
+}
+
+func (s *verificationService) RedeemPasswordReset(ctx context.Context, token string) (uuid.UUID, error) {
+	var rawUserID string
+	if err := s.cache.Take(ctx, passwordResetTokenKey(utils.HashSHA256(token)), &rawUserID); err != nil {
+		return uuid.Nil, errors.TokenInvalid()
+	}
+
+	userID, err := uuid.Parse(rawUserID)
+	if err != nil {
+		return uuid.Nil, errors.TokenInvalid()
+	}
+
+	if err := s.cache.Delete(ctx, passwordResetUserKey(userID)); err != nil {
+		s.logger.WithContext(ctx).WithError(err).WithField("user_id", userID).Warn("failed to delete password reset token")
+	}
+
+	return userID, nil
+}
+
+func verificationTokenKey(tokenHash string) string {
+	return fmt.Sprintf("email_verification:%s", tokenHash)
+}
+
+func verificationUserKey(userID uuid.UUID) string {
+	return fmt.Sprintf("email_verification_user:%s", userID)
+}
+
+// verificationResendKey counts resends per email, hashed like
+// passwordResetSendKey.
+func verificationResendKey(email string) string {
+	return fmt.Sprintf("verification_resend:%s", utils.HashSHA256(email))
+}
+
+func passwordResetTokenKey(tokenHash string) string {
+	return fmt.Sprintf("password_reset:%s", tokenHash)
+}
+
+func passwordResetUserKey(userID uuid.UUID) string {
+	return fmt.Sprintf("password_reset_user:%s", userID)
+}
+
+// passwordResetSendKey counts requests per email, hashed so that Redis holds
+// no addresses.
+func passwordResetSendKey(email string) string {
+	return fmt.Sprintf("password_reset_send:%s", utils.HashSHA256(email))
+}
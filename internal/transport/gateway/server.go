@@ -0,0 +1,174 @@
+package gateway
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/textproto"
+	"strings"
+	"unicode"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"github.com/vagonaizer/authenitfication-service/api/proto/generated"
+	"github.com/vagonaizer/authenitfication-service/internal/config"
+	"github.com/vagonaizer/authenitfication-service/internal/dto/response"
+	grpcserver "github.com/vagonaizer/authenitfication-service/internal/transport/grpc"
+	"github.com/vagonaizer/authenitfication-service/pkg/auth"
+	"github.com/vagonaizer/authenitfication-service/pkg/logger"
+	"github.com/vagonaizer/authenitfication-service/pkg/tracing"
+)
+
+// Server serves the REST façade generated from the HTTP annotations of the
+// proto services. Calls are forwarded to the gRPC server of this process, so
+// they pass the same interceptors as native gRPC clients and both surfaces
+// share one implementation.
+type Server struct {
+	server *http.Server
+	conn   *grpc.ClientConn
+	logger *logger.Logger
+}
+
+func NewServer(cfg *config.Config, log *logger.Logger) (*Server, error) {
+	creds, err := grpcserver.NewLoopbackCredentials(&cfg.Server)
+	if err != nil {
+		return nil, err
+	}
+
+	dialOpts := []grpc.DialOption{grpc.WithTransportCredentials(creds)}
+	// Ответы ограничены размером, который сервер готов отправить
+	if cfg.Server.GRPCMaxSendMsgSize > 0 {
+		dialOpts = append(dialOpts, grpc.WithDefaultCallOptions(grpc.MaxCallRecvMsgSize(cfg.Server.GRPCMaxSendMsgSize)))
+	}
+
+	conn, err := grpc.NewClient("localhost:"+cfg.Server.GRPCPort, dialOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create gRPC client: %w", err)
+	}
+
+	mux := runtime.NewServeMux(
+		// Поля в snake_case, как в DTO HTTP API
+		runtime.WithMarshalerOption(runtime.MIMEWildcard, &runtime.JSONPb{
+			MarshalOptions:   protojson.MarshalOptions{UseProtoNames: true, EmitUnpopulated: true},
+			UnmarshalOptions: protojson.UnmarshalOptions{DiscardUnknown: true},
+		}),
+		runtime.WithIncomingHeaderMatcher(headerMatcher),
+		runtime.WithOutgoingHeaderMatcher(outgoingHeaderMatcher),
+		runtime.WithErrorHandler(errorHandler),
+	)
+
+	ctx := context.Background()
+	registrations := []func(context.Context, *runtime.ServeMux, *grpc.ClientConn) error{
+		generated.RegisterAuthServiceHandler,
+		generated.RegisterUserServiceHandler,
+		generated.RegisterRoleServiceHandler,
+	}
+	for _, register := range registrations {
+		if err := register(ctx, mux, conn); err != nil {
+			conn.Close()
+			return nil, fmt.Errorf("failed to register gateway handler: %w", err)
+		}
+	}
+
+	server := &http.Server{
+		Addr:         ":" + cfg.Server.GatewayPort,
+		Handler:      http.MaxBytesHandler(mux, cfg.Server.MaxRequestSize),
+		ReadTimeout:  cfg.Server.ReadTimeout,
+		WriteTimeout: cfg.Server.WriteTimeout,
+	}
+
+	return &Server{
+		server: server,
+		conn:   conn,
+		logger: log,
+	}, nil
+}
+
+func (s *Server) Start() error {
+	s.logger.Infof("gRPC gateway starting on %s", s.server.Addr)
+
+	if err := s.server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		return fmt.Errorf("failed to start gRPC gateway: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Server) Stop(ctx context.Context) error {
+	s.logger.Info("shutting down gRPC gateway")
+
+	err := s.server.Shutdown(ctx)
+	if closeErr := s.conn.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+// headerMatcher forwards the trace context, correlation ID and addressed
+// organization along with the headers the gateway forwards by default.
+func headerMatcher(key string) (string, bool) {
+	switch textproto.CanonicalMIMEHeaderKey(key) {
+	case textproto.CanonicalMIMEHeaderKey(tracing.HeaderTraceparent),
+		textproto.CanonicalMIMEHeaderKey(tracing.HeaderCorrelationID),
+		textproto.CanonicalMIMEHeaderKey(auth.TenantHeader):
+		return strings.ToLower(key), true
+	}
+	return runtime.DefaultHeaderMatcher(key)
+}
+
+// outgoingHeaderMatcher returns the request ID as X-Request-Id and the ETag
+// of profiles as ETag, like the HTTP API, and other header metadata with the
+// default Grpc-Metadata- prefix.
+func outgoingHeaderMatcher(key string) (string, bool) {
+	switch key {
+	case tracing.HeaderCorrelationID:
+		return textproto.CanonicalMIMEHeaderKey(key), true
+	case "etag":
+		return "ETag", true
+	}
+	return runtime.MetadataHeaderPrefix + key, true
+}
+
+// errorHandler writes gRPC errors in the error format of the HTTP API.
+func errorHandler(ctx context.Context, _ *runtime.ServeMux, _ runtime.Marshaler, w http.ResponseWriter, _ *http.Request, err error) {
+	st := status.Convert(err)
+	code := runtime.HTTPStatusFromCode(st.Code())
+	// FailedPrecondition означает устаревший If-Match, как 412 в HTTP API
+	if st.Code() == codes.FailedPrecondition {
+		code = http.StatusPreconditionFailed
+	}
+
+	resp := response.ErrorResponse{
+		Error:   codeName(st.Code().String()),
+		Message: st.Message(),
+		Code:    code,
+	}
+	if md, ok := runtime.ServerMetadataFromContext(ctx); ok {
+		if values := md.HeaderMD.Get(tracing.HeaderCorrelationID); len(values) > 0 {
+			resp.RequestID = values[0]
+			w.Header().Set(tracing.HeaderCorrelationID, values[0])
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+// codeName turns a gRPC code name such as "InvalidArgument" into
+// "INVALID_ARGUMENT".
+func codeName(name string) string {
+	var b strings.Builder
+	for i, r := range name {
+		if i > 0 && unicode.IsUpper(r) {
+			b.WriteByte('_')
+		}
+		b.WriteRune(unicode.ToUpper(r))
+	}
+	return b.String()
+}
@@ -0,0 +1,36 @@
+package graphql
+
+import (
+	"github.com/vagonaizer/authenitfication-service/pkg/errors"
+)
+
+// fieldError is an error of a field. Its code is reported in the
+// "extensions" of the error, with the codes of the HTTP API.
+type fieldError struct {
+	code    string
+	message string
+}
+
+func (e *fieldError) Error() string {
+	return e.message
+}
+
+func (e *fieldError) Extensions() map[string]interface{} {
+	return map[string]interface{}{"code": e.code}
+}
+
+var (
+	errUnauthenticated = &fieldError{code: errors.CodeUnauthorized, message: "Authentication required"}
+	errForbidden       = &fieldError{code: errors.CodeForbidden, message: "Insufficient permissions"}
+	errInternal        = &fieldError{code: errors.CodeInternal, message: "Internal server error"}
+)
+
+// serviceError turns an error of a service into a field error. Only the
+// message of application errors reaches the client.
+func (r *Resolver) serviceError(err error) error {
+	if appErr, ok := err.(*errors.AppError); ok {
+		return &fieldError{code: appErr.Code, message: appErr.Message}
+	}
+	r.logger.WithError(err).Error("GraphQL resolver failed")
+	return errInternal
+}
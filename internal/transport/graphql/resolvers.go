@@ -0,0 +1,221 @@
+package graphql
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	graphqlgo "github.com/graph-gophers/graphql-go"
+
+	"github.com/vagonaizer/authenitfication-service/internal/domain/services"
+	"github.com/vagonaizer/authenitfication-service/internal/dto/request"
+	"github.com/vagonaizer/authenitfication-service/internal/dto/response"
+	"github.com/vagonaizer/authenitfication-service/pkg/errors"
+	"github.com/vagonaizer/authenitfication-service/pkg/logger"
+)
+
+// Resolver resolves the fields of Query.
+type Resolver struct {
+	userService services.UserService
+	authService services.AuthService
+	authorizer  services.Authorizer
+	logger      *logger.Logger
+	rules       map[string]rule
+}
+
+func (r *Resolver) Me(ctx context.Context) (*userResolver, error) {
+	if err := r.authorize(ctx, "Query", "me", uuid.Nil); err != nil {
+		return nil, err
+	}
+
+	user, err := r.userService.GetProfile(ctx, viewerFrom(ctx).UserID)
+	if err != nil {
+		return nil, r.serviceError(err)
+	}
+	return &userResolver{root: r, user: user}, nil
+}
+
+func (r *Resolver) User(ctx context.Context, args struct{ ID graphqlgo.ID }) (*userResolver, error) {
+	if err := r.authorize(ctx, "Query", "user", uuid.Nil); err != nil {
+		return nil, err
+	}
+
+	userID, err := uuid.Parse(string(args.ID))
+	if err != nil {
+		return nil, &fieldError{code: "INVALID_USER_ID", message: "Invalid user ID format"}
+	}
+
+	user, err := r.userService.GetUserByID(ctx, userID)
+	if err != nil {
+		// Отсутствующий пользователь — null, а не ошибка
+		if appErr, ok := err.(*errors.AppError); ok && appErr.Code == errors.CodeUserNotFound {
+			return nil, nil
+		}
+		return nil, r.serviceError(err)
+	}
+	return &userResolver{root: r, user: user}, nil
+}
+
+type usersArgs struct {
+	Page     int32
+	PageSize int32
+	Search   string
+	SortBy   string
+	SortDir  string
+}
+
+func (r *Resolver) Users(ctx context.Context, args usersArgs) (*userPageResolver, error) {
+	if err := r.authorize(ctx, "Query", "users", uuid.Nil); err != nil {
+		return nil, err
+	}
+
+	page := int(args.Page)
+	if page < 1 {
+		page = 1
+	}
+	pageSize := int(args.PageSize)
+	if pageSize < 1 || pageSize > 100 {
+		pageSize = 20
+	}
+
+	req := &request.ListUsersRequest{
+		Page:     page,
+		PageSize: pageSize,
+		Search:   args.Search,
+		SortBy:   args.SortBy,
+		SortDir:  args.SortDir,
+	}
+	if err := request.ValidateStruct(req); err != nil {
+		return nil, &fieldError{code: errors.CodeValidation, message: err.Error()}
+	}
+
+	result, err := r.userService.ListUsers(ctx, req)
+	if err != nil {
+		return nil, r.serviceError(err)
+	}
+	return &userPageResolver{root: r, page: result}, nil
+}
+
+type userResolver struct {
+	root *Resolver
+	user *response.UserResponse
+}
+
+func (u *userResolver) ID() graphqlgo.ID             { return graphqlgo.ID(u.user.ID.String()) }
+func (u *userResolver) Email() string                { return u.user.Email }
+func (u *userResolver) Username() string             { return u.user.Username }
+func (u *userResolver) FirstName() *string           { return u.user.FirstName }
+func (u *userResolver) LastName() *string            { return u.user.LastName }
+func (u *userResolver) AvatarURL() *string           { return u.user.AvatarURL }
+func (u *userResolver) Phone() *string               { return u.user.Phone }
+func (u *userResolver) IsActive() bool               { return u.user.IsActive }
+func (u *userResolver) IsVerified() bool             { return u.user.IsVerified }
+func (u *userResolver) Locale() string               { return u.user.Locale }
+func (u *userResolver) Timezone() string             { return u.user.Timezone }
+func (u *userResolver) LastLoginAt() *graphqlgo.Time { return timePtr(u.user.LastLoginAt) }
+func (u *userResolver) CreatedAt() graphqlgo.Time    { return graphqlgo.Time{Time: u.user.CreatedAt} }
+func (u *userResolver) UpdatedAt() graphqlgo.Time    { return graphqlgo.Time{Time: u.user.UpdatedAt} }
+
+func (u *userResolver) Roles(ctx context.Context, args struct{ ScopeID *graphqlgo.ID }) ([]*roleResolver, error) {
+	if err := u.root.authorize(ctx, "User", "roles", u.user.ID); err != nil {
+		return nil, err
+	}
+
+	var scopeID *uuid.UUID
+	if args.ScopeID != nil {
+		id, err := uuid.Parse(string(*args.ScopeID))
+		if err != nil {
+			return nil, &fieldError{code: "INVALID_SCOPE_ID", message: "Invalid scope ID format"}
+		}
+		scopeID = &id
+	}
+
+	result, err := u.root.userService.GetUserRoles(ctx, u.user.ID, scopeID)
+	if err != nil {
+		return nil, u.root.serviceError(err)
+	}
+
+	roles := make([]*roleResolver, 0, len(result.Roles))
+	for _, role := range result.Roles {
+		roles = append(roles, &roleResolver{role: role})
+	}
+	return roles, nil
+}
+
+func (u *userResolver) Sessions(ctx context.Context) ([]*sessionResolver, error) {
+	if err := u.root.authorize(ctx, "User", "sessions", u.user.ID); err != nil {
+		return nil, err
+	}
+
+	result, err := u.root.authService.ListSessions(ctx, u.user.ID)
+	if err != nil {
+		return nil, u.root.serviceError(err)
+	}
+
+	sessions := make([]*sessionResolver, 0, len(result))
+	for _, session := range result {
+		sessions = append(sessions, &sessionResolver{session: session})
+	}
+	return sessions, nil
+}
+
+type userPageResolver struct {
+	root *Resolver
+	page *response.UsersListResponse
+}
+
+func (p *userPageResolver) Users() []*userResolver {
+	users := make([]*userResolver, 0, len(p.page.Users))
+	for _, user := range p.page.Users {
+		users = append(users, &userResolver{root: p.root, user: user})
+	}
+	return users
+}
+
+func (p *userPageResolver) Total() int32      { return int32(p.page.Total) }
+func (p *userPageResolver) Page() int32       { return int32(p.page.Page) }
+func (p *userPageResolver) PageSize() int32   { return int32(p.page.PageSize) }
+func (p *userPageResolver) TotalPages() int32 { return int32(p.page.TotalPages) }
+
+type roleResolver struct {
+	role *response.RoleResponse
+}
+
+func (r *roleResolver) ID() graphqlgo.ID          { return graphqlgo.ID(r.role.ID.String()) }
+func (r *roleResolver) Name() string              { return r.role.Name }
+func (r *roleResolver) Description() *string      { return r.role.Description }
+func (r *roleResolver) CreatedAt() graphqlgo.Time { return graphqlgo.Time{Time: r.role.CreatedAt} }
+func (r *roleResolver) UpdatedAt() graphqlgo.Time { return graphqlgo.Time{Time: r.role.UpdatedAt} }
+
+type sessionResolver struct {
+	session *response.SessionResponse
+}
+
+func (s *sessionResolver) ID() graphqlgo.ID { return graphqlgo.ID(s.session.ID.String()) }
+
+func (s *sessionResolver) OrganizationID() *graphqlgo.ID {
+	if s.session.OrganizationID == nil {
+		return nil
+	}
+	id := graphqlgo.ID(s.session.OrganizationID.String())
+	return &id
+}
+
+func (s *sessionResolver) UserAgent() string { return s.session.UserAgent }
+func (s *sessionResolver) IPAddress() string { return s.session.IPAddress }
+func (s *sessionResolver) ExpiresAt() graphqlgo.Time {
+	return graphqlgo.Time{Time: s.session.ExpiresAt}
+}
+func (s *sessionResolver) CreatedAt() graphqlgo.Time {
+	return graphqlgo.Time{Time: s.session.CreatedAt}
+}
+func (s *sessionResolver) UpdatedAt() graphqlgo.Time {
+	return graphqlgo.Time{Time: s.session.UpdatedAt}
+}
+
+func timePtr(t *time.Time) *graphqlgo.Time {
+	if t == nil {
+		return nil
+	}
+	return &graphqlgo.Time{Time: *t}
+}
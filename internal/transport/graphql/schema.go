@@ -0,0 +1,137 @@
+// Package graphql exposes users, their roles and sessions as a GraphQL API,
+// so that frontends can fetch the auth data of a page in one round trip.
+// Access rules are declared in schema.graphql with the @auth and
+// @hasPermission directives; each resolver of such a field enforces its rule
+// through Resolver.authorize.
+package graphql
+
+import (
+	"context"
+	_ "embed"
+	"fmt"
+
+	"github.com/google/uuid"
+	graphqlgo "github.com/graph-gophers/graphql-go"
+	"github.com/graph-gophers/graphql-go/ast"
+
+	"github.com/vagonaizer/authenitfication-service/internal/domain/entities"
+	"github.com/vagonaizer/authenitfication-service/internal/domain/services"
+	"github.com/vagonaizer/authenitfication-service/pkg/logger"
+)
+
+//go:embed schema.graphql
+var schemaSDL string
+
+// maxDepth bounds the nesting of queries.
+const maxDepth = 8
+
+// Viewer is the authenticated caller of a query. Roles are its global roles
+// only: like RequirePermission, the schema ignores organization roles.
+type Viewer struct {
+	UserID uuid.UUID
+	Roles  []string
+	OrgID  string
+}
+
+type viewerKey struct{}
+
+// WithViewer returns a context carrying the caller of a query.
+func WithViewer(ctx context.Context, viewer *Viewer) context.Context {
+	return context.WithValue(ctx, viewerKey{}, viewer)
+}
+
+func viewerFrom(ctx context.Context) *Viewer {
+	viewer, _ := ctx.Value(viewerKey{}).(*Viewer)
+	return viewer
+}
+
+// rule is the access rule the directives of a field declare.
+type rule struct {
+	permission string
+	allowSelf  bool
+}
+
+// NewSchema parses the schema and binds it to the services.
+func NewSchema(
+	userService services.UserService,
+	authService services.AuthService,
+	authorizer services.Authorizer,
+	logger *logger.Logger,
+) (*graphqlgo.Schema, error) {
+	r := &Resolver{
+		userService: userService,
+		authService: authService,
+		authorizer:  authorizer,
+		logger:      logger,
+	}
+
+	schema, err := graphqlgo.ParseSchema(schemaSDL, r, graphqlgo.MaxDepth(maxDepth), graphqlgo.UseStringDescriptions())
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse GraphQL schema: %w", err)
+	}
+	r.rules = rules(schema.AST())
+
+	return schema, nil
+}
+
+// rules collects the access rules of the fields of the object types of
+// schema, keyed by "Type.field".
+func rules(schema *ast.Schema) map[string]rule {
+	result := make(map[string]rule)
+	for _, object := range schema.Objects {
+		for _, field := range object.Fields {
+			key := object.Name + "." + field.Name
+
+			if field.Directives.Get("auth") != nil {
+				result[key] = rule{}
+			}
+
+			directive := field.Directives.Get("hasPermission")
+			if directive == nil {
+				continue
+			}
+			var fieldRule rule
+			if value, ok := directive.Arguments.Get("permission"); ok {
+				fieldRule.permission, _ = value.Deserialize(nil).(string)
+			}
+			if value, ok := directive.Arguments.Get("allowSelf"); ok {
+				fieldRule.allowSelf, _ = value.Deserialize(nil).(bool)
+			}
+			result[key] = fieldRule
+		}
+	}
+	return result
+}
+
+// authorize enforces the rule of typeName.field for the caller of ctx. owner
+// is the user the field belongs to, for allowSelf.
+func (r *Resolver) authorize(ctx context.Context, typeName, field string, owner uuid.UUID) error {
+	fieldRule, ok := r.rules[typeName+"."+field]
+	if !ok {
+		return nil
+	}
+
+	viewer := viewerFrom(ctx)
+	if viewer == nil {
+		return errUnauthenticated
+	}
+	if fieldRule.permission == "" || (fieldRule.allowSelf && owner == viewer.UserID) {
+		return nil
+	}
+
+	subject := &services.Subject{
+		UserID: viewer.UserID.String(),
+		Roles:  viewer.Roles,
+		OrgID:  viewer.OrgID,
+	}
+	resource, action := entities.ParsePermission(fieldRule.permission)
+	allowed, err := r.authorizer.Authorize(ctx, subject, action, resource)
+	if err != nil {
+		r.logger.WithContext(ctx).WithError(err).WithField("permission", fieldRule.permission).Error("failed to authorize GraphQL field")
+		return errInternal
+	}
+	if !allowed {
+		return errForbidden
+	}
+	return nil
+}
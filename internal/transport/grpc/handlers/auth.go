@@ -2,16 +2,21 @@ package handlers
 
 import (
 	"context"
+	"strings"
 
+	"github.com/google/uuid"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
 	"github.com/vagonaizer/authenitfication-service/api/proto/generated"
 	"github.com/vagonaizer/authenitfication-service/internal/domain/services"
 	"github.com/vagonaizer/authenitfication-service/internal/dto/request"
+	"github.com/vagonaizer/authenitfication-service/pkg/auth"
 	"github.com/vagonaizer/authenitfication-service/pkg/errors"
 	"github.com/vagonaizer/authenitfication-service/pkg/logger"
+	"github.com/vagonaizer/authenitfication-service/pkg/utils"
 )
 
 type AuthGRPCHandler struct {
@@ -34,11 +39,14 @@ func (h *AuthGRPCHandler) Register(ctx context.Context, req *generated.RegisterR
 		Password:  req.Password,
 		FirstName: req.FirstName,
 		LastName:  req.LastName,
+		ClientID:  req.ClientId,
+
+		InvitationToken: req.InvitationToken,
+		Locale:          req.Locale,
+		Timezone:        req.Timezone,
 	}
 
-	// Для gRPC используем значения по умолчанию
-	ipAddress := "127.0.0.1"
-	userAgent := "gRPC-Client"
+	ipAddress, userAgent := h.clientInfo(ctx)
 
 	result, err := h.authService.Register(ctx, registerReq, ipAddress, userAgent)
 	if err != nil {
@@ -61,24 +69,26 @@ func (h *AuthGRPCHandler) Register(ctx context.Context, req *generated.RegisterR
 			Username:    result.User.Username,
 			FirstName:   h.stringPtrToString(result.User.FirstName),
 			LastName:    h.stringPtrToString(result.User.LastName),
+			AvatarUrl:   h.stringPtrToString(result.User.AvatarURL),
 			IsActive:    result.User.IsActive,
 			IsVerified:  result.User.IsVerified,
 			LastLoginAt: lastLoginAt,
 			CreatedAt:   timestamppb.New(result.User.CreatedAt),
 			UpdatedAt:   timestamppb.New(result.User.UpdatedAt),
+			Locale:      result.User.Locale,
+			Timezone:    result.User.Timezone,
 		},
 	}, nil
 }
 
 func (h *AuthGRPCHandler) Login(ctx context.Context, req *generated.LoginRequest) (*generated.AuthResponse, error) {
 	loginReq := &request.LoginRequest{
-		Email:    req.Email,
-		Password: req.Password,
+		Email:          req.Email,
+		Password:       req.Password,
+		CancelDeletion: req.CancelDeletion,
 	}
 
-	// Для gRPC используем значения по умолчанию
-	ipAddress := "127.0.0.1"
-	userAgent := "gRPC-Client"
+	ipAddress, userAgent := h.clientInfo(ctx)
 
 	result, err := h.authService.Login(ctx, loginReq, ipAddress, userAgent)
 	if err != nil {
@@ -101,11 +111,14 @@ func (h *AuthGRPCHandler) Login(ctx context.Context, req *generated.LoginRequest
 			Username:    result.User.Username,
 			FirstName:   h.stringPtrToString(result.User.FirstName),
 			LastName:    h.stringPtrToString(result.User.LastName),
+			AvatarUrl:   h.stringPtrToString(result.User.AvatarURL),
 			IsActive:    result.User.IsActive,
 			IsVerified:  result.User.IsVerified,
 			LastLoginAt: lastLoginAt,
 			CreatedAt:   timestamppb.New(result.User.CreatedAt),
 			UpdatedAt:   timestamppb.New(result.User.UpdatedAt),
+			Locale:      result.User.Locale,
+			Timezone:    result.User.Timezone,
 		},
 	}, nil
 }
@@ -127,6 +140,24 @@ func (h *AuthGRPCHandler) RefreshToken(ctx context.Context, req *generated.Refre
 	}, nil
 }
 
+func (h *AuthGRPCHandler) ServiceAccountToken(ctx context.Context, req *generated.ServiceAccountTokenRequest) (*generated.TokenResponse, error) {
+	tokenReq := &request.ServiceAccountTokenRequest{
+		KeyID:  req.KeyId,
+		Secret: req.Secret,
+	}
+
+	result, err := h.authService.AuthenticateServiceAccount(ctx, tokenReq)
+	if err != nil {
+		return nil, h.handleError(err)
+	}
+
+	return &generated.TokenResponse{
+		AccessToken: result.AccessToken,
+		TokenType:   result.TokenType,
+		ExpiresIn:   result.ExpiresIn,
+	}, nil
+}
+
 func (h *AuthGRPCHandler) Logout(ctx context.Context, req *generated.LogoutRequest) (*generated.LogoutResponse, error) {
 	logoutReq := &request.LogoutRequest{
 		RefreshToken: req.RefreshToken,
@@ -142,8 +173,80 @@ func (h *AuthGRPCHandler) Logout(ctx context.Context, req *generated.LogoutReque
 	}, nil
 }
 
+func (h *AuthGRPCHandler) LogoutAll(ctx context.Context, req *generated.LogoutAllRequest) (*generated.LogoutResponse, error) {
+	userID, err := h.callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := h.authService.LogoutAll(ctx, userID.String()); err != nil {
+		return nil, h.handleError(err)
+	}
+
+	return &generated.LogoutResponse{
+		Message: "Logged out of all sessions successfully",
+	}, nil
+}
+
+func (h *AuthGRPCHandler) ListSessions(ctx context.Context, req *generated.ListSessionsRequest) (*generated.SessionsListResponse, error) {
+	userID, err := h.callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := h.authService.ListSessions(ctx, userID)
+	if err != nil {
+		return nil, h.handleError(err)
+	}
+
+	sessions := make([]*generated.Session, len(result))
+	for i, session := range result {
+		sessions[i] = &generated.Session{
+			Id:        session.ID.String(),
+			UserAgent: session.UserAgent,
+			IpAddress: session.IPAddress,
+			ExpiresAt: timestamppb.New(session.ExpiresAt),
+			CreatedAt: timestamppb.New(session.CreatedAt),
+			UpdatedAt: timestamppb.New(session.UpdatedAt),
+		}
+		if session.OrganizationID != nil {
+			sessions[i].OrganizationId = session.OrganizationID.String()
+		}
+	}
+
+	return &generated.SessionsListResponse{
+		Sessions: sessions,
+	}, nil
+}
+
+func (h *AuthGRPCHandler) RevokeSession(ctx context.Context, req *generated.RevokeSessionRequest) (*generated.RevokeSessionResponse, error) {
+	userID, err := h.callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	sessionID, err := uuid.Parse(req.SessionId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid session ID format")
+	}
+
+	if err := h.authService.RevokeSession(ctx, userID, sessionID); err != nil {
+		return nil, h.handleError(err)
+	}
+
+	return &generated.RevokeSessionResponse{
+		Message: "Session revoked successfully",
+	}, nil
+}
+
 func (h *AuthGRPCHandler) VerifyToken(ctx context.Context, req *generated.VerifyTokenRequest) (*generated.TokenClaimsResponse, error) {
-	result, err := h.authService.VerifyToken(ctx, req.Token)
+	md, _ := metadata.FromIncomingContext(ctx)
+	var tenant *uuid.UUID
+	if values := md.Get(strings.ToLower(auth.TenantHeader)); len(values) > 0 {
+		tenant = auth.ParseTenant(values[0])
+	}
+
+	result, err := h.authService.VerifyToken(ctx, req.Token, tenant)
 	if err != nil {
 		return nil, h.handleError(err)
 	}
@@ -155,6 +258,47 @@ func (h *AuthGRPCHandler) VerifyToken(ctx context.Context, req *generated.Verify
 		Roles:     result.Roles,
 		ExpiresAt: timestamppb.New(result.ExpiresAt),
 		IssuedAt:  timestamppb.New(result.IssuedAt),
+
+		Permissions:        result.Permissions,
+		PermissionsOmitted: result.PermissionsOmitted,
+		ServiceAccount:     result.ServiceAccount,
+		ScopedRoles:        result.ScopedRoles,
+		OrgId:              result.OrgID,
+	}, nil
+}
+
+func (h *AuthGRPCHandler) CheckAccess(ctx context.Context, req *generated.CheckAccessRequest) (*generated.CheckAccessResponse, error) {
+	userID, err := uuid.Parse(req.UserId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid user ID format")
+	}
+
+	checkReq := &request.CheckAccessRequest{
+		UserID:     userID,
+		Permission: req.Permission,
+		Resource:   req.Resource,
+	}
+
+	if req.OrgId != "" {
+		orgID, err := uuid.Parse(req.OrgId)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, "invalid organization ID format")
+		}
+		checkReq.OrgID = &orgID
+	}
+
+	if err := request.ValidateStruct(checkReq); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	result, err := h.authService.CheckAccess(ctx, checkReq)
+	if err != nil {
+		return nil, h.handleError(err)
+	}
+
+	return &generated.CheckAccessResponse{
+		Allowed: result.Allowed,
+		Reason:  result.Reason,
 	}, nil
 }
 
@@ -175,10 +319,66 @@ func (h *AuthGRPCHandler) ChangePassword(ctx context.Context, req *generated.Cha
 	}, nil
 }
 
+func (h *AuthGRPCHandler) ResetPassword(ctx context.Context, req *generated.ResetPasswordRequest) (*generated.ResetPasswordResponse, error) {
+	if err := h.authService.ResetPassword(ctx, &request.ResetPasswordRequest{Email: req.Email}); err != nil {
+		return nil, h.handleError(err)
+	}
+
+	// The same answer is given whether or not the email is registered.
+	return &generated.ResetPasswordResponse{
+		Message: "If the account exists, a password reset email has been sent",
+	}, nil
+}
+
+func (h *AuthGRPCHandler) ConfirmResetPassword(ctx context.Context, req *generated.ConfirmResetPasswordRequest) (*generated.ResetPasswordResponse, error) {
+	confirmReq := &request.ConfirmResetPasswordRequest{
+		Token:       req.Token,
+		NewPassword: req.NewPassword,
+	}
+
+	if err := h.authService.ConfirmResetPassword(ctx, confirmReq); err != nil {
+		return nil, h.handleError(err)
+	}
+
+	return &generated.ResetPasswordResponse{
+		Message: "Password reset successfully",
+	}, nil
+}
+
+// callerID returns the user the auth interceptor authenticated. Services
+// authenticated by their client certificate have no sessions.
+func (h *AuthGRPCHandler) callerID(ctx context.Context) (uuid.UUID, error) {
+	userIDStr, _ := ctx.Value("user_id").(string)
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		return uuid.Nil, status.Error(codes.Unauthenticated, "a user access token is required")
+	}
+	return userID, nil
+}
+
+// clientInfo returns the IP address and user agent recorded with the
+// sessions of the caller. The IP is the one ClientIPInterceptor resolved;
+// the REST gateway forwards the User-Agent header of its client as
+// grpcgateway-user-agent.
+func (h *AuthGRPCHandler) clientInfo(ctx context.Context) (string, string) {
+	ipAddress := utils.ClientIP(ctx)
+	if ipAddress == "" {
+		ipAddress = "127.0.0.1"
+	}
+
+	md, _ := metadata.FromIncomingContext(ctx)
+	for _, key := range []string{"grpcgateway-user-agent", "user-agent"} {
+		if values := md.Get(key); len(values) > 0 && values[0] != "" {
+			return ipAddress, values[0]
+		}
+	}
+	return ipAddress, "gRPC-Client"
+}
+
 func (h *AuthGRPCHandler) handleError(err error) error {
 	if appErr, ok := err.(*errors.AppError); ok {
 		switch appErr.Code {
-		case errors.CodeValidation:
+		case errors.CodeValidation, errors.CodeWeakPassword:
 			return status.Error(codes.InvalidArgument, appErr.Message)
 		case errors.CodeNotFound:
 			return status.Error(codes.NotFound, appErr.Message)
@@ -194,6 +394,10 @@ func (h *AuthGRPCHandler) handleError(err error) error {
 			return status.Error(codes.Unauthenticated, appErr.Message)
 		case errors.CodeTokenInvalid:
 			return status.Error(codes.Unauthenticated, appErr.Message)
+		case errors.CodeUserBanned:
+			return status.Error(codes.PermissionDenied, appErr.Message)
+		case errors.CodeQuotaExceeded, errors.CodeRateLimitExceeded:
+			return status.Error(codes.ResourceExhausted, appErr.Message)
 		default:
 			return status.Error(codes.Internal, appErr.Message)
 		}
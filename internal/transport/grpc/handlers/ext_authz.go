@@ -0,0 +1,184 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/google/uuid"
+	rpcstatus "google.golang.org/genproto/googleapis/rpc/status"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/vagonaizer/authenitfication-service/api/proto/generated/extauthz"
+	"github.com/vagonaizer/authenitfication-service/internal/domain/services"
+	"github.com/vagonaizer/authenitfication-service/internal/dto/request"
+	"github.com/vagonaizer/authenitfication-service/internal/dto/response"
+	"github.com/vagonaizer/authenitfication-service/pkg/auth"
+	"github.com/vagonaizer/authenitfication-service/pkg/errors"
+	"github.com/vagonaizer/authenitfication-service/pkg/logger"
+)
+
+// Context extensions an ext_authz filter can set per route to require a
+// permission, such as "users:read", on top of a valid token.
+const (
+	extAuthzPermission = "permission"
+	extAuthzResource   = "resource"
+)
+
+// identityHeaders carry the identity of the caller upstream; they are
+// removed from requests that do not set them, so clients cannot forge them.
+// x-user-roles holds the roles that apply everywhere, x-user-scoped-roles
+// those granted only within the organization of x-org-id.
+var identityHeaders = []string{"x-user-id", "x-user-email", "x-user-name", "x-user-roles", "x-user-scoped-roles", "x-org-id"}
+
+// ExtAuthzGRPCHandler implements the Envoy external authorization service,
+// so an Envoy or Istio mesh authorizes requests against this service. A
+// request is allowed with a valid access token, from the Authorization
+// header or the token cookie, whose user holds the permission the route
+// requires, if any. Allowed requests go upstream with the X-User-* headers
+// of the forward-auth endpoint.
+type ExtAuthzGRPCHandler struct {
+	extauthz.UnimplementedAuthorizationServer
+	authService services.AuthService
+	tokenCookie string
+	logger      *logger.Logger
+}
+
+func NewExtAuthzGRPCHandler(authService services.AuthService, tokenCookie string, logger *logger.Logger) *ExtAuthzGRPCHandler {
+	return &ExtAuthzGRPCHandler{
+		authService: authService,
+		tokenCookie: tokenCookie,
+		logger:      logger,
+	}
+}
+
+// Check denies requests with the HTTP response the client gets. It only
+// fails when the decision cannot be made, leaving it to the failure mode of
+// the filter.
+func (h *ExtAuthzGRPCHandler) Check(ctx context.Context, req *extauthz.CheckRequest) (*extauthz.CheckResponse, error) {
+	attributes := req.GetAttributes()
+	headers := attributes.GetRequest().GetHttp().GetHeaders()
+
+	token := h.token(headers)
+	if token == "" {
+		return denied(codes.Unauthenticated, errors.Unauthorized("Authentication required")), nil
+	}
+
+	tenant := auth.ParseTenant(headers[strings.ToLower(auth.TenantHeader)])
+	claims, err := h.authService.VerifyToken(ctx, token, tenant)
+	if err != nil {
+		appErr, ok := err.(*errors.AppError)
+		if !ok {
+			return nil, status.Error(codes.Internal, "failed to verify token")
+		}
+		code := codes.Unauthenticated
+		if appErr.StatusCode == http.StatusForbidden {
+			code = codes.PermissionDenied
+		}
+		return denied(code, appErr), nil
+	}
+
+	if permission := attributes.GetContextExtensions()[extAuthzPermission]; permission != "" {
+		checkReq := &request.CheckAccessRequest{
+			Permission: permission,
+			Resource:   attributes.GetContextExtensions()[extAuthzResource],
+		}
+		checkReq.UserID, _ = uuid.Parse(claims.UserID)
+		if claims.OrgID != "" {
+			if orgID, err := uuid.Parse(claims.OrgID); err == nil {
+				checkReq.OrgID = &orgID
+			}
+		}
+
+		result, err := h.authService.CheckAccess(ctx, checkReq)
+		if err != nil {
+			h.logger.WithContext(ctx).WithError(err).WithField("permission", permission).Error("failed to check access")
+			return nil, status.Error(codes.Internal, "failed to check access")
+		}
+		if !result.Allowed {
+			return denied(codes.PermissionDenied, errors.Forbidden("Insufficient permissions")), nil
+		}
+	}
+
+	return allowed(claims), nil
+}
+
+// token returns the bearer token of the request, else its token cookie.
+// Envoy passes header names in lowercase.
+func (h *ExtAuthzGRPCHandler) token(headers map[string]string) string {
+	if authHeader := headers["authorization"]; authHeader != "" {
+		return strings.TrimPrefix(authHeader, "Bearer ")
+	}
+
+	if h.tokenCookie == "" || headers["cookie"] == "" {
+		return ""
+	}
+	r := http.Request{Header: http.Header{"Cookie": {headers["cookie"]}}}
+	if cookie, err := r.Cookie(h.tokenCookie); err == nil {
+		return cookie.Value
+	}
+	return ""
+}
+
+func allowed(claims *response.TokenClaimsResponse) *extauthz.CheckResponse {
+	values := map[string]string{
+		"x-user-id":    claims.UserID,
+		"x-user-email": claims.Email,
+		"x-user-name":  claims.Username,
+		"x-user-roles": strings.Join(claims.Roles, ","),
+		"x-org-id":     claims.OrgID,
+	}
+	// Роли организации передаются только вместе с x-org-id
+	if claims.OrgID != "" {
+		values["x-user-scoped-roles"] = strings.Join(claims.ScopedRoles, ",")
+	}
+
+	ok := &extauthz.OkHttpResponse{}
+	for _, name := range identityHeaders {
+		if values[name] == "" {
+			ok.HeadersToRemove = append(ok.HeadersToRemove, name)
+			continue
+		}
+		ok.Headers = append(ok.Headers, &extauthz.HeaderValueOption{
+			Header:       &extauthz.HeaderValue{Key: name, Value: values[name]},
+			AppendAction: extauthz.HeaderValueOption_OVERWRITE_IF_EXISTS_OR_ADD,
+		})
+	}
+
+	return &extauthz.CheckResponse{
+		Status:       &rpcstatus.Status{Code: int32(codes.OK)},
+		HttpResponse: &extauthz.CheckResponse_OkResponse{OkResponse: ok},
+	}
+}
+
+// denied answers with the error response the HTTP API would send.
+func denied(code codes.Code, appErr *errors.AppError) *extauthz.CheckResponse {
+	body, _ := json.Marshal(response.ErrorResponse{
+		Error:   appErr.Code,
+		Message: appErr.Message,
+		Code:    appErr.StatusCode,
+		Details: appErr.Details,
+	})
+
+	headers := []*extauthz.HeaderValueOption{{
+		Header:       &extauthz.HeaderValue{Key: "content-type", Value: "application/json"},
+		AppendAction: extauthz.HeaderValueOption_OVERWRITE_IF_EXISTS_OR_ADD,
+	}}
+	if appErr.StatusCode == http.StatusUnauthorized {
+		headers = append(headers, &extauthz.HeaderValueOption{
+			Header:       &extauthz.HeaderValue{Key: "www-authenticate", Value: "Bearer"},
+			AppendAction: extauthz.HeaderValueOption_OVERWRITE_IF_EXISTS_OR_ADD,
+		})
+	}
+
+	return &extauthz.CheckResponse{
+		Status: &rpcstatus.Status{Code: int32(code), Message: appErr.Message},
+		HttpResponse: &extauthz.CheckResponse_DeniedResponse{DeniedResponse: &extauthz.DeniedHttpResponse{
+			Status:  &extauthz.HttpStatus{Code: extauthz.HttpStatus_StatusCode(appErr.StatusCode)},
+			Headers: headers,
+			Body:    string(body),
+		}},
+	}
+}
@@ -0,0 +1,219 @@
+package handlers
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/vagonaizer/authenitfication-service/api/proto/generated"
+	"github.com/vagonaizer/authenitfication-service/internal/domain/services"
+	"github.com/vagonaizer/authenitfication-service/internal/dto/request"
+	"github.com/vagonaizer/authenitfication-service/internal/dto/response"
+	"github.com/vagonaizer/authenitfication-service/pkg/errors"
+	"github.com/vagonaizer/authenitfication-service/pkg/logger"
+)
+
+type RoleGRPCHandler struct {
+	generated.UnimplementedRoleServiceServer
+	roleService services.RoleService
+	logger      *logger.Logger
+}
+
+func NewRoleGRPCHandler(roleService services.RoleService, logger *logger.Logger) *RoleGRPCHandler {
+	return &RoleGRPCHandler{
+		roleService: roleService,
+		logger:      logger,
+	}
+}
+
+func (h *RoleGRPCHandler) CreateRole(ctx context.Context, req *generated.CreateRoleRequest) (*generated.Role, error) {
+	createReq := &request.CreateRoleRequest{
+		Name:        req.Name,
+		Description: req.Description,
+	}
+
+	if err := request.ValidateStruct(createReq); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	result, err := h.roleService.CreateRole(ctx, createReq)
+	if err != nil {
+		return nil, h.handleError(err)
+	}
+
+	return h.toProtoRole(result), nil
+}
+
+func (h *RoleGRPCHandler) GetRole(ctx context.Context, req *generated.GetRoleRequest) (*generated.Role, error) {
+	roleID, err := uuid.Parse(req.RoleId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid role ID format")
+	}
+
+	result, err := h.roleService.GetRole(ctx, roleID)
+	if err != nil {
+		return nil, h.handleError(err)
+	}
+
+	return h.toProtoRole(result), nil
+}
+
+func (h *RoleGRPCHandler) ListRoles(ctx context.Context, req *generated.ListRolesRequest) (*generated.RolesListResponse, error) {
+	result, err := h.roleService.ListRoles(ctx)
+	if err != nil {
+		return nil, h.handleError(err)
+	}
+
+	roles := make([]*generated.Role, len(result.Roles))
+	for i, role := range result.Roles {
+		roles[i] = h.toProtoRole(role)
+	}
+
+	return &generated.RolesListResponse{
+		Roles: roles,
+	}, nil
+}
+
+func (h *RoleGRPCHandler) UpdateRole(ctx context.Context, req *generated.UpdateRoleRequest) (*generated.Role, error) {
+	roleID, err := uuid.Parse(req.RoleId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid role ID format")
+	}
+
+	updateReq := &request.UpdateRoleRequest{
+		RoleID:      roleID,
+		Name:        req.Name,
+		Description: req.Description,
+	}
+
+	if err := request.ValidateStruct(updateReq); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	result, err := h.roleService.UpdateRole(ctx, updateReq)
+	if err != nil {
+		return nil, h.handleError(err)
+	}
+
+	return h.toProtoRole(result), nil
+}
+
+func (h *RoleGRPCHandler) DeleteRole(ctx context.Context, req *generated.DeleteRoleRequest) (*generated.DeleteRoleResponse, error) {
+	roleID, err := uuid.Parse(req.RoleId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid role ID format")
+	}
+
+	if err := h.roleService.DeleteRole(ctx, roleID); err != nil {
+		return nil, h.handleError(err)
+	}
+
+	return &generated.DeleteRoleResponse{
+		Message: "Role deleted successfully",
+	}, nil
+}
+
+func (h *RoleGRPCHandler) GetRolePermissions(ctx context.Context, req *generated.GetRolePermissionsRequest) (*generated.RolePermissionsResponse, error) {
+	roleID, err := uuid.Parse(req.RoleId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid role ID format")
+	}
+
+	result, err := h.roleService.GetRolePermissions(ctx, roleID)
+	if err != nil {
+		return nil, h.handleError(err)
+	}
+
+	permissions := make([]*generated.PermissionGrant, len(result.Permissions))
+	for i, grant := range result.Permissions {
+		permissions[i] = &generated.PermissionGrant{
+			Permission: grant.Permission,
+			Condition:  grant.Condition,
+		}
+	}
+
+	return &generated.RolePermissionsResponse{
+		RoleId:      result.RoleID.String(),
+		Permissions: permissions,
+	}, nil
+}
+
+func (h *RoleGRPCHandler) GrantPermission(ctx context.Context, req *generated.GrantPermissionRequest) (*generated.GrantPermissionResponse, error) {
+	roleID, err := uuid.Parse(req.RoleId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid role ID format")
+	}
+
+	grantReq := &request.GrantPermissionRequest{
+		RoleID:     roleID,
+		Permission: req.Permission,
+		Condition:  req.Condition,
+	}
+
+	if err := request.ValidateStruct(grantReq); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	if err := h.roleService.GrantPermission(ctx, grantReq); err != nil {
+		return nil, h.handleError(err)
+	}
+
+	return &generated.GrantPermissionResponse{
+		Message: "Permission granted successfully",
+	}, nil
+}
+
+func (h *RoleGRPCHandler) RevokePermission(ctx context.Context, req *generated.RevokePermissionRequest) (*generated.RevokePermissionResponse, error) {
+	roleID, err := uuid.Parse(req.RoleId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid role ID format")
+	}
+
+	if err := h.roleService.RevokePermission(ctx, roleID, req.Permission); err != nil {
+		return nil, h.handleError(err)
+	}
+
+	return &generated.RevokePermissionResponse{
+		Message: "Permission revoked successfully",
+	}, nil
+}
+
+func (h *RoleGRPCHandler) toProtoRole(role *response.RoleResponse) *generated.Role {
+	return &generated.Role{
+		Id:          role.ID.String(),
+		Name:        role.Name,
+		Description: h.stringPtrToString(role.Description),
+		CreatedAt:   timestamppb.New(role.CreatedAt),
+		UpdatedAt:   timestamppb.New(role.UpdatedAt),
+	}
+}
+
+func (h *RoleGRPCHandler) handleError(err error) error {
+	if appErr, ok := err.(*errors.AppError); ok {
+		switch appErr.Code {
+		case errors.CodeValidation:
+			return status.Error(codes.InvalidArgument, appErr.Message)
+		case errors.CodeNotFound:
+			return status.Error(codes.NotFound, appErr.Message)
+		case errors.CodeAlreadyExists, errors.CodeRoleExists:
+			return status.Error(codes.AlreadyExists, appErr.Message)
+		case errors.CodeUnauthorized:
+			return status.Error(codes.Unauthenticated, appErr.Message)
+		case errors.CodeForbidden:
+			return status.Error(codes.PermissionDenied, appErr.Message)
+		default:
+			return status.Error(codes.Internal, appErr.Message)
+		}
+	}
+	return status.Error(codes.Internal, "Internal server error")
+}
+
+func (h *RoleGRPCHandler) stringPtrToString(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
@@ -2,32 +2,100 @@ package handlers
 
 import (
 	"context"
+	"sync"
 
 	"github.com/google/uuid"
+	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
 	"github.com/vagonaizer/authenitfication-service/api/proto/generated"
+	"github.com/vagonaizer/authenitfication-service/internal/domain/entities"
 	"github.com/vagonaizer/authenitfication-service/internal/domain/services"
 	"github.com/vagonaizer/authenitfication-service/internal/dto/request"
 	"github.com/vagonaizer/authenitfication-service/pkg/errors"
 	"github.com/vagonaizer/authenitfication-service/pkg/logger"
 )
 
+// maxWatchedUsers bounds the users a single WatchUserEvents call subscribes
+// to.
+const maxWatchedUsers = 1000
+
+// UserGRPCHandler serves the user service. userEvents is nil when event
+// streaming is disabled, and WatchUserEvents is then unavailable.
 type UserGRPCHandler struct {
 	generated.UnimplementedUserServiceServer
 	userService services.UserService
+	userEvents  services.UserEventStream
 	logger      *logger.Logger
 }
 
-func NewUserGRPCHandler(userService services.UserService, logger *logger.Logger) *UserGRPCHandler {
+func NewUserGRPCHandler(userService services.UserService, userEvents services.UserEventStream, logger *logger.Logger) *UserGRPCHandler {
 	return &UserGRPCHandler{
 		userService: userService,
+		userEvents:  userEvents,
 		logger:      logger,
 	}
 }
 
+func (h *UserGRPCHandler) CreateUser(ctx context.Context, req *generated.CreateUserRequest) (*generated.CreateUserResponse, error) {
+	roleIDs := make([]uuid.UUID, len(req.RoleIds))
+	for i, id := range req.RoleIds {
+		roleID, err := uuid.Parse(id)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, "invalid role ID format")
+		}
+		roleIDs[i] = roleID
+	}
+
+	createReq := &request.CreateUserRequest{
+		ActorID:    h.actorID(ctx),
+		ActorRoles: h.actorRoles(ctx),
+		Email:      req.Email,
+		Username:   req.Username,
+		Password:   req.Password,
+		FirstName:  req.FirstName,
+		LastName:   req.LastName,
+		RoleIDs:    roleIDs,
+		IsVerified: req.IsVerified,
+
+		RequirePasswordChange: req.RequirePasswordChange,
+	}
+
+	if err := request.ValidateStruct(createReq); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	result, err := h.userService.CreateUser(ctx, createReq)
+	if err != nil {
+		return nil, h.handleError(err)
+	}
+
+	return &generated.CreateUserResponse{
+		User: &generated.UserResponse{
+			Id:         result.User.ID.String(),
+			Email:      result.User.Email,
+			Username:   result.User.Username,
+			FirstName:  h.stringPtrToString(result.User.FirstName),
+			LastName:   h.stringPtrToString(result.User.LastName),
+			AvatarUrl:  h.stringPtrToString(result.User.AvatarURL),
+			IsActive:   result.User.IsActive,
+			IsVerified: result.User.IsVerified,
+			CreatedAt:  timestamppb.New(result.User.CreatedAt),
+			UpdatedAt:  timestamppb.New(result.User.UpdatedAt),
+			Locale:     result.User.Locale,
+			Timezone:   result.User.Timezone,
+		},
+		Roles:                  result.Roles,
+		TemporaryPassword:      result.TemporaryPassword,
+		PasswordChangeRequired: result.User.PasswordChangeRequired,
+	}, nil
+}
+
+// GetProfile returns the ETag of the profile in the etag header, to send
+// back as if-match metadata to UpdateProfile.
 func (h *UserGRPCHandler) GetProfile(ctx context.Context, req *generated.GetProfileRequest) (*generated.UserResponse, error) {
 	userID, err := uuid.Parse(req.UserId)
 	if err != nil {
@@ -38,6 +106,7 @@ func (h *UserGRPCHandler) GetProfile(ctx context.Context, req *generated.GetProf
 	if err != nil {
 		return nil, h.handleError(err)
 	}
+	_ = grpc.SetHeader(ctx, metadata.Pairs("etag", result.ETag()))
 
 	var lastLoginAt *timestamppb.Timestamp
 	if result.LastLoginAt != nil {
@@ -50,6 +119,10 @@ func (h *UserGRPCHandler) GetProfile(ctx context.Context, req *generated.GetProf
 		Username:    result.Username,
 		FirstName:   h.stringPtrToString(result.FirstName),
 		LastName:    h.stringPtrToString(result.LastName),
+		AvatarUrl:   h.stringPtrToString(result.AvatarURL),
+		Phone:       h.stringPtrToString(result.Phone),
+		Locale:      result.Locale,
+		Timezone:    result.Timezone,
 		IsActive:    result.IsActive,
 		IsVerified:  result.IsVerified,
 		LastLoginAt: lastLoginAt,
@@ -65,7 +138,8 @@ func (h *UserGRPCHandler) UpdateProfile(ctx context.Context, req *generated.Upda
 	}
 
 	updateReq := &request.UpdateUserRequest{
-		UserID: userID,
+		UserID:  userID,
+		IfMatch: h.ifMatch(ctx),
 	}
 
 	if req.FirstName != nil {
@@ -77,11 +151,18 @@ func (h *UserGRPCHandler) UpdateProfile(ctx context.Context, req *generated.Upda
 	if req.Username != nil {
 		updateReq.Username = req.Username
 	}
+	if req.Locale != nil {
+		updateReq.Locale = req.Locale
+	}
+	if req.Timezone != nil {
+		updateReq.Timezone = req.Timezone
+	}
 
 	result, err := h.userService.UpdateProfile(ctx, updateReq)
 	if err != nil {
 		return nil, h.handleError(err)
 	}
+	_ = grpc.SetHeader(ctx, metadata.Pairs("etag", result.ETag()))
 
 	var lastLoginAt *timestamppb.Timestamp
 	if result.LastLoginAt != nil {
@@ -94,6 +175,10 @@ func (h *UserGRPCHandler) UpdateProfile(ctx context.Context, req *generated.Upda
 		Username:    result.Username,
 		FirstName:   h.stringPtrToString(result.FirstName),
 		LastName:    h.stringPtrToString(result.LastName),
+		AvatarUrl:   h.stringPtrToString(result.AvatarURL),
+		Phone:       h.stringPtrToString(result.Phone),
+		Locale:      result.Locale,
+		Timezone:    result.Timezone,
 		IsActive:    result.IsActive,
 		IsVerified:  result.IsVerified,
 		LastLoginAt: lastLoginAt,
@@ -145,6 +230,10 @@ func (h *UserGRPCHandler) ListUsers(ctx context.Context, req *generated.ListUser
 			Username:    user.Username,
 			FirstName:   h.stringPtrToString(user.FirstName),
 			LastName:    h.stringPtrToString(user.LastName),
+			AvatarUrl:   h.stringPtrToString(user.AvatarURL),
+			Phone:       h.stringPtrToString(user.Phone),
+			Locale:      user.Locale,
+			Timezone:    user.Timezone,
 			IsActive:    user.IsActive,
 			IsVerified:  user.IsVerified,
 			LastLoginAt: lastLoginAt,
@@ -184,6 +273,10 @@ func (h *UserGRPCHandler) GetUserByID(ctx context.Context, req *generated.GetUse
 		Username:    result.Username,
 		FirstName:   h.stringPtrToString(result.FirstName),
 		LastName:    h.stringPtrToString(result.LastName),
+		AvatarUrl:   h.stringPtrToString(result.AvatarURL),
+		Phone:       h.stringPtrToString(result.Phone),
+		Locale:      result.Locale,
+		Timezone:    result.Timezone,
 		IsActive:    result.IsActive,
 		IsVerified:  result.IsVerified,
 		LastLoginAt: lastLoginAt,
@@ -198,7 +291,7 @@ func (h *UserGRPCHandler) ActivateUser(ctx context.Context, req *generated.Activ
 		return nil, status.Error(codes.InvalidArgument, "invalid user ID format")
 	}
 
-	err = h.userService.ActivateUser(ctx, userID)
+	err = h.userService.ActivateUser(ctx, h.actorID(ctx), userID)
 	if err != nil {
 		return nil, h.handleError(err)
 	}
@@ -214,7 +307,7 @@ func (h *UserGRPCHandler) DeactivateUser(ctx context.Context, req *generated.Dea
 		return nil, status.Error(codes.InvalidArgument, "invalid user ID format")
 	}
 
-	err = h.userService.DeactivateUser(ctx, userID)
+	err = h.userService.DeactivateUser(ctx, h.actorID(ctx), userID)
 	if err != nil {
 		return nil, h.handleError(err)
 	}
@@ -235,9 +328,21 @@ func (h *UserGRPCHandler) AssignRole(ctx context.Context, req *generated.AssignR
 		return nil, status.Error(codes.InvalidArgument, "invalid role ID format")
 	}
 
+	scopeID, err := h.parseScopeID(req.ScopeId)
+	if err != nil {
+		return nil, err
+	}
+
 	assignReq := &request.AssignRoleRequest{
-		UserID: userID,
-		RoleID: roleID,
+		ActorID: h.actorID(ctx),
+		UserID:  userID,
+		RoleID:  roleID,
+		ScopeID: scopeID,
+		Reason:  req.Reason,
+	}
+	if req.ExpiresAt != nil {
+		expiresAt := req.ExpiresAt.AsTime()
+		assignReq.ExpiresAt = &expiresAt
 	}
 
 	err = h.userService.AssignRole(ctx, assignReq)
@@ -261,9 +366,17 @@ func (h *UserGRPCHandler) RemoveRole(ctx context.Context, req *generated.RemoveR
 		return nil, status.Error(codes.InvalidArgument, "invalid role ID format")
 	}
 
+	scopeID, err := h.parseScopeID(req.ScopeId)
+	if err != nil {
+		return nil, err
+	}
+
 	removeReq := &request.RemoveRoleRequest{
-		UserID: userID,
-		RoleID: roleID,
+		ActorID: h.actorID(ctx),
+		UserID:  userID,
+		RoleID:  roleID,
+		ScopeID: scopeID,
+		Reason:  req.Reason,
 	}
 
 	err = h.userService.RemoveRole(ctx, removeReq)
@@ -282,7 +395,12 @@ func (h *UserGRPCHandler) GetUserRoles(ctx context.Context, req *generated.GetUs
 		return nil, status.Error(codes.InvalidArgument, "invalid user ID format")
 	}
 
-	result, err := h.userService.GetUserRoles(ctx, userID)
+	scopeID, err := h.parseScopeID(req.ScopeId)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := h.userService.GetUserRoles(ctx, userID, scopeID)
 	if err != nil {
 		return nil, h.handleError(err)
 	}
@@ -294,13 +412,163 @@ func (h *UserGRPCHandler) GetUserRoles(ctx context.Context, req *generated.GetUs
 			Name:        role.Name,
 			Description: h.stringPtrToString(role.Description),
 			CreatedAt:   timestamppb.New(role.CreatedAt),
+			UpdatedAt:   timestamppb.New(role.UpdatedAt),
 		}
 	}
 
-	return &generated.UserRolesResponse{
+	resp := &generated.UserRolesResponse{
 		UserId: result.UserID.String(),
 		Roles:  roles,
-	}, nil
+	}
+	if result.ScopeID != nil {
+		resp.ScopeId = result.ScopeID.String()
+	}
+
+	return resp, nil
+}
+
+func (h *UserGRPCHandler) WatchUserEvents(req *generated.WatchUserEventsRequest, stream grpc.ServerStreamingServer[generated.UserEvent]) error {
+	if h.userEvents == nil {
+		return status.Error(codes.Unimplemented, "user event streaming is disabled")
+	}
+	if len(req.UserIds) == 0 {
+		return status.Error(codes.InvalidArgument, "at least one user ID is required")
+	}
+	if len(req.UserIds) > maxWatchedUsers {
+		return status.Errorf(codes.InvalidArgument, "at most %d user IDs can be watched", maxWatchedUsers)
+	}
+
+	userIDs := make(map[uuid.UUID]struct{}, len(req.UserIds))
+	for _, id := range req.UserIds {
+		userID, err := uuid.Parse(id)
+		if err != nil {
+			return status.Error(codes.InvalidArgument, "invalid user ID format")
+		}
+		userIDs[userID] = struct{}{}
+	}
+
+	var types map[string]bool
+	if len(req.Types) > 0 {
+		types = make(map[string]bool, len(req.Types))
+		for _, eventType := range req.Types {
+			types[eventType] = true
+		}
+	}
+
+	ctx := stream.Context()
+	events, cancel := h.subscribe(ctx, userIDs)
+	defer cancel()
+
+	// Заголовки уходят сразу, а не с первым событием
+	if err := stream.SendHeader(metadata.MD{}); err != nil {
+		return err
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case event, ok := <-events:
+			if !ok {
+				return status.Error(codes.Unavailable, "user event stream closed")
+			}
+			if types != nil && !types[event.Type] {
+				continue
+			}
+			if err := stream.Send(&generated.UserEvent{
+				UserId: event.UserID.String(),
+				Type:   event.Type,
+				Data:   event.Data,
+			}); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// subscribe merges the events of userIDs into one channel, which is closed
+// once every subscription has ended, as it does when the stream shuts down.
+// cancel ends the subscriptions.
+func (h *UserGRPCHandler) subscribe(ctx context.Context, userIDs map[uuid.UUID]struct{}) (<-chan *entities.UserEvent, func()) {
+	merged := make(chan *entities.UserEvent)
+	ctx, stop := context.WithCancel(ctx)
+
+	var wg sync.WaitGroup
+	cancels := make([]func(), 0, len(userIDs))
+	for userID := range userIDs {
+		events, cancel := h.userEvents.Subscribe(userID)
+		cancels = append(cancels, cancel)
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for event := range events {
+				select {
+				case merged <- event:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+	}
+
+	go func() {
+		wg.Wait()
+		close(merged)
+	}()
+
+	return merged, func() {
+		stop()
+		for _, cancel := range cancels {
+			cancel()
+		}
+	}
+}
+
+func (h *UserGRPCHandler) parseScopeID(scope string) (*uuid.UUID, error) {
+	if scope == "" {
+		return nil, nil
+	}
+
+	scopeID, err := uuid.Parse(scope)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid scope ID format")
+	}
+
+	return &scopeID, nil
+}
+
+// actorID returns the authenticated caller set by the auth interceptor.
+func (h *UserGRPCHandler) actorID(ctx context.Context) *uuid.UUID {
+	userIDStr, ok := ctx.Value("user_id").(string)
+	if !ok {
+		return nil
+	}
+
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		return nil
+	}
+
+	return &userID
+}
+
+// actorRoles returns the roles the caller holds outside any organization.
+func (h *UserGRPCHandler) actorRoles(ctx context.Context) []string {
+	roles, _ := ctx.Value("roles").([]string)
+	return roles
+}
+
+// ifMatch returns the if-match metadata of the call, which the gateway
+// forwards from the If-Match header as grpcgateway-if-match.
+func (h *UserGRPCHandler) ifMatch(ctx context.Context) string {
+	md, _ := metadata.FromIncomingContext(ctx)
+	for _, key := range []string{"if-match", "grpcgateway-if-match"} {
+		if values := md.Get(key); len(values) > 0 {
+			return values[0]
+		}
+	}
+	return ""
 }
 
 func (h *UserGRPCHandler) handleError(err error) error {
@@ -310,12 +578,14 @@ func (h *UserGRPCHandler) handleError(err error) error {
 			return status.Error(codes.InvalidArgument, appErr.Message)
 		case errors.CodeNotFound:
 			return status.Error(codes.NotFound, appErr.Message)
-		case errors.CodeAlreadyExists:
+		case errors.CodeAlreadyExists, errors.CodeEmailExists, errors.CodeUsernameExists:
 			return status.Error(codes.AlreadyExists, appErr.Message)
 		case errors.CodeUnauthorized:
 			return status.Error(codes.Unauthenticated, appErr.Message)
 		case errors.CodeForbidden:
 			return status.Error(codes.PermissionDenied, appErr.Message)
+		case errors.CodePreconditionFailed:
+			return status.Error(codes.FailedPrecondition, appErr.Message)
 		default:
 			return status.Error(codes.Internal, appErr.Message)
 		}
@@ -0,0 +1,94 @@
+package grpc
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+
+	"github.com/vagonaizer/authenitfication-service/pkg/logger"
+)
+
+// healthService serves the gRPC health checking protocol
+// (grpc.health.v1.Health) for Kubernetes gRPC probes and client-side load
+// balancing. The server as a whole, under "", and each of its services are
+// SERVING while check, the readiness check of the HTTP API, passes; it is
+// run every interval, and watchers are told when the status changes.
+type healthService struct {
+	server   *health.Server
+	check    func(ctx context.Context) error
+	services []string
+	interval time.Duration
+	serving  bool
+	logger   *logger.Logger
+
+	stop     chan struct{}
+	stopOnce sync.Once
+}
+
+func newHealthService(check func(ctx context.Context) error, services []string, interval time.Duration, logger *logger.Logger) *healthService {
+	h := &healthService{
+		server:   health.NewServer(),
+		check:    check,
+		services: append([]string{""}, services...),
+		interval: interval,
+		logger:   logger,
+		stop:     make(chan struct{}),
+	}
+	// До первой проверки сервер не принимает трафик
+	h.set(healthpb.HealthCheckResponse_NOT_SERVING)
+	return h
+}
+
+// run checks the dependencies every interval until shutdown.
+func (h *healthService) run() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		<-h.stop
+		cancel()
+	}()
+
+	ticker := time.NewTicker(h.interval)
+	defer ticker.Stop()
+
+	for {
+		checkCtx, cancelCheck := context.WithTimeout(ctx, h.interval)
+		err := h.check(checkCtx)
+		cancelCheck()
+
+		if ctx.Err() != nil {
+			return
+		}
+		switch {
+		case err != nil && h.serving:
+			h.logger.WithError(err).Warn("gRPC services not serving")
+			h.set(healthpb.HealthCheckResponse_NOT_SERVING)
+		case err == nil && !h.serving:
+			h.logger.Info("gRPC services serving")
+			h.set(healthpb.HealthCheckResponse_SERVING)
+		}
+		h.serving = err == nil
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// shutdown reports every service as NOT_SERVING from now on and stops
+// checking.
+func (h *healthService) shutdown() {
+	h.server.Shutdown()
+	h.stopOnce.Do(func() { close(h.stop) })
+}
+
+func (h *healthService) set(status healthpb.HealthCheckResponse_ServingStatus) {
+	for _, service := range h.services {
+		h.server.SetServingStatus(service, status)
+	}
+}
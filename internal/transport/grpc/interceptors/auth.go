@@ -3,25 +3,47 @@ package interceptors
 import (
 	"context"
 	"strings"
+	"time"
 
+	"github.com/google/uuid"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	reflectionv1 "google.golang.org/grpc/reflection/grpc_reflection_v1"
+	reflectionv1alpha "google.golang.org/grpc/reflection/grpc_reflection_v1alpha"
 	"google.golang.org/grpc/status"
 
+	"github.com/vagonaizer/authenitfication-service/api/proto/generated/extauthz"
+	"github.com/vagonaizer/authenitfication-service/internal/domain/entities"
+	"github.com/vagonaizer/authenitfication-service/internal/domain/services"
 	"github.com/vagonaizer/authenitfication-service/pkg/auth"
+	"github.com/vagonaizer/authenitfication-service/pkg/i18n"
 	"github.com/vagonaizer/authenitfication-service/pkg/logger"
 )
 
+// AuthInterceptor authenticates calls with the bearer access token of their
+// authorization metadata. Calls without one are authenticated by their
+// client certificate instead when it was verified and one of its subject
+// alternative names is a key of clientIdentities: the caller is then the
+// service of that name, holding the roles it maps to.
 type AuthInterceptor struct {
-	jwtManager *auth.JWTManager
-	logger     *logger.Logger
+	jwtManager       *auth.JWTManager
+	authorizer       services.Authorizer
+	revocations      services.TokenRevocationService
+	clientIdentities map[string][]string
+	logger           *logger.Logger
 }
 
-func NewAuthInterceptor(jwtManager *auth.JWTManager, logger *logger.Logger) *AuthInterceptor {
+func NewAuthInterceptor(jwtManager *auth.JWTManager, authorizer services.Authorizer, revocations services.TokenRevocationService, clientIdentities map[string][]string, logger *logger.Logger) *AuthInterceptor {
 	return &AuthInterceptor{
-		jwtManager: jwtManager,
-		logger:     logger,
+		jwtManager:       jwtManager,
+		authorizer:       authorizer,
+		revocations:      revocations,
+		clientIdentities: clientIdentities,
+		logger:           logger,
 	}
 }
 
@@ -31,17 +53,10 @@ func (i *AuthInterceptor) Unary() grpc.UnaryServerInterceptor {
 			return handler(ctx, req)
 		}
 
-		token, err := i.extractToken(ctx)
+		ctx, err := i.authenticate(ctx, info.FullMethod)
 		if err != nil {
-			return nil, status.Error(codes.Unauthenticated, "missing or invalid token")
+			return nil, err
 		}
-
-		claims, err := i.jwtManager.ValidateAccessToken(token)
-		if err != nil {
-			return nil, status.Error(codes.Unauthenticated, "invalid token")
-		}
-
-		ctx = i.setUserContext(ctx, claims)
 		return handler(ctx, req)
 	}
 }
@@ -52,23 +67,81 @@ func (i *AuthInterceptor) Stream() grpc.StreamServerInterceptor {
 			return handler(srv, ss)
 		}
 
-		token, err := i.extractToken(ss.Context())
+		ctx, err := i.authenticate(ss.Context(), info.FullMethod)
 		if err != nil {
-			return status.Error(codes.Unauthenticated, "missing or invalid token")
+			return err
 		}
+		wrapped := &wrappedStream{ServerStream: ss, ctx: ctx}
+		return handler(srv, wrapped)
+	}
+}
 
-		claims, err := i.jwtManager.ValidateAccessToken(token)
-		if err != nil {
-			return status.Error(codes.Unauthenticated, "invalid token")
+// authenticate authorizes a call to method and returns its context with
+// the caller set.
+func (i *AuthInterceptor) authenticate(ctx context.Context, method string) (context.Context, error) {
+	token, err := extractToken(ctx)
+	if err != nil {
+		if identity, roles, ok := i.peerIdentity(ctx); ok {
+			subject := &services.Subject{UserID: identity, Roles: roles}
+			if err := i.authorizeSubject(ctx, method, subject); err != nil {
+				return nil, err
+			}
+			return i.setServiceContext(ctx, identity, roles), nil
 		}
+		return nil, status.Error(codes.Unauthenticated, "missing or invalid token")
+	}
 
-		ctx := i.setUserContext(ss.Context(), claims)
-		wrapped := &wrappedStream{ServerStream: ss, ctx: ctx}
-		return handler(srv, wrapped)
+	claims, err := i.jwtManager.ValidateAccessToken(token, requestTenant(ctx))
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, "invalid token")
+	}
+
+	if i.isRevoked(ctx, claims) {
+		return nil, status.Error(codes.Unauthenticated, "token has been revoked")
+	}
+	i18n.SetPreference(ctx, claims.Locale)
+
+	if err := i.authorize(ctx, method, claims); err != nil {
+		return nil, err
 	}
+
+	return i.setUserContext(ctx, claims), nil
 }
 
-func (i *AuthInterceptor) extractToken(ctx context.Context) (string, error) {
+// peerIdentity returns the service identity mapped to a subject alternative
+// name of the verified client certificate of the call.
+func (i *AuthInterceptor) peerIdentity(ctx context.Context) (string, []string, bool) {
+	if len(i.clientIdentities) == 0 {
+		return "", nil, false
+	}
+
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return "", nil, false
+	}
+	tlsInfo, ok := p.AuthInfo.(credentials.TLSInfo)
+	// Сертификат без проверенной цепочки ничего не доказывает
+	if !ok || len(tlsInfo.State.VerifiedChains) == 0 || len(tlsInfo.State.PeerCertificates) == 0 {
+		return "", nil, false
+	}
+
+	cert := tlsInfo.State.PeerCertificates[0]
+	names := make([]string, 0, len(cert.URIs)+len(cert.DNSNames)+len(cert.EmailAddresses))
+	for _, uri := range cert.URIs {
+		names = append(names, uri.String())
+	}
+	names = append(names, cert.DNSNames...)
+	names = append(names, cert.EmailAddresses...)
+
+	for _, name := range names {
+		if roles, ok := i.clientIdentities[name]; ok {
+			return name, roles, true
+		}
+	}
+	return "", nil, false
+}
+
+func extractToken(ctx context.Context) (string, error) {
 	md, ok := metadata.FromIncomingContext(ctx)
 	if !ok {
 		return "", status.Error(codes.Unauthenticated, "missing metadata")
@@ -87,20 +160,147 @@ func (i *AuthInterceptor) extractToken(ctx context.Context) (string, error) {
 	return authHeader[7:], nil
 }
 
+// requestTenant returns the organization a call addresses by its
+// x-organization-id metadata, nil when it names none.
+func requestTenant(ctx context.Context) *uuid.UUID {
+	md, _ := metadata.FromIncomingContext(ctx)
+	if values := md.Get(strings.ToLower(auth.TenantHeader)); len(values) > 0 {
+		return auth.ParseTenant(values[0])
+	}
+	return nil
+}
+
+// isRevoked fails open when revocations cannot be checked.
+func (i *AuthInterceptor) isRevoked(ctx context.Context, claims *auth.AccessTokenClaims) bool {
+	var issuedAt time.Time
+	if claims.IssuedAt != nil {
+		issuedAt = claims.IssuedAt.Time
+	}
+
+	revoked, err := i.revocations.IsRevoked(ctx, claims.UserID, claims.ID, issuedAt)
+	if err != nil {
+		i.logger.WithContext(ctx).WithError(err).WithField("user_id", claims.UserID).Warn("failed to check token revocation")
+		return false
+	}
+	return revoked
+}
+
 func (i *AuthInterceptor) setUserContext(ctx context.Context, claims *auth.AccessTokenClaims) context.Context {
 	ctx = context.WithValue(ctx, "user_id", claims.UserID.String())
 	ctx = context.WithValue(ctx, "email", claims.Email)
 	ctx = context.WithValue(ctx, "username", claims.Username)
-	ctx = context.WithValue(ctx, "roles", claims.Roles)
+	// Org-scoped roles grant nothing over gRPC, see authorize.
+	ctx = context.WithValue(ctx, "roles", claims.GlobalRoles())
+	if claims.OrgID != nil {
+		ctx = context.WithValue(ctx, "org_id", claims.OrgID.String())
+	}
+	return ctx
+}
+
+// setServiceContext sets the service identity of a client certificate as
+// the caller; it is no user, so handlers record no actor for it.
+func (i *AuthInterceptor) setServiceContext(ctx context.Context, identity string, roles []string) context.Context {
+	ctx = context.WithValue(ctx, "user_id", identity)
+	ctx = context.WithValue(ctx, "roles", roles)
 	return ctx
 }
 
+func (i *AuthInterceptor) authorize(ctx context.Context, method string, claims *auth.AccessTokenClaims) error {
+	// Restricted tokens of users who must change their password grant nothing else.
+	if claims.PasswordChangeOnly && method != "/auth.v1.AuthService/ChangePassword" {
+		return status.Error(codes.PermissionDenied, "password change required")
+	}
+
+	// Org-scoped roles are only honoured by the HTTP delegated admin routes.
+	subject := &services.Subject{
+		UserID: claims.UserID.String(),
+		Roles:  claims.GlobalRoles(),
+	}
+	if claims.OrgID != nil {
+		subject.OrgID = claims.OrgID.String()
+	}
+
+	return i.authorizeSubject(ctx, method, subject)
+}
+
+// authorizeSubject checks the permission method requires. Methods that
+// neither require one nor are open to any authenticated caller are denied.
+func (i *AuthInterceptor) authorizeSubject(ctx context.Context, method string, subject *services.Subject) error {
+	permission, ok := methodPermissions[method]
+	if !ok {
+		if authenticatedMethods[method] {
+			return nil
+		}
+		i.logger.WithContext(ctx).WithField("method", method).Warn("method has no permission mapping")
+		return status.Error(codes.PermissionDenied, "insufficient permissions")
+	}
+
+	resource, action := entities.ParsePermission(permission)
+	allowed, err := i.authorizer.Authorize(ctx, subject, action, resource)
+	if err != nil {
+		i.logger.WithContext(ctx).WithError(err).WithField("permission", permission).Error("failed to authorize request")
+		return status.Error(codes.Internal, "failed to authorize request")
+	}
+
+	if !allowed {
+		return status.Error(codes.PermissionDenied, "insufficient permissions")
+	}
+
+	return nil
+}
+
+var methodPermissions = map[string]string{
+	"/auth.v1.AuthService/CheckAccess":        entities.PermissionAccessCheck,
+	"/user.v1.UserService/ListUsers":          entities.PermissionUsersRead,
+	"/user.v1.UserService/CreateUser":         entities.PermissionUsersManage,
+	"/user.v1.UserService/ActivateUser":       entities.PermissionUsersActivate,
+	"/user.v1.UserService/DeactivateUser":     entities.PermissionUsersDeactivate,
+	"/user.v1.UserService/AssignRole":         entities.PermissionRolesAssign,
+	"/user.v1.UserService/RemoveRole":         entities.PermissionRolesAssign,
+	"/user.v1.UserService/WatchUserEvents":    entities.PermissionUsersRead,
+	"/role.v1.RoleService/GetRole":            entities.PermissionRolesRead,
+	"/role.v1.RoleService/ListRoles":          entities.PermissionRolesRead,
+	"/role.v1.RoleService/CreateRole":         entities.PermissionRolesManage,
+	"/role.v1.RoleService/UpdateRole":         entities.PermissionRolesManage,
+	"/role.v1.RoleService/DeleteRole":         entities.PermissionRolesManage,
+	"/role.v1.RoleService/GetRolePermissions": entities.PermissionRolesRead,
+	"/role.v1.RoleService/GrantPermission":    entities.PermissionRolesManage,
+	"/role.v1.RoleService/RevokePermission":   entities.PermissionRolesManage,
+}
+
+// authenticatedMethods are open to any authenticated caller: they act on the
+// caller's own account, or are open to every user as on the HTTP API.
+var authenticatedMethods = map[string]bool{
+	"/auth.v1.AuthService/Logout":         true,
+	"/auth.v1.AuthService/LogoutAll":      true,
+	"/auth.v1.AuthService/ListSessions":   true,
+	"/auth.v1.AuthService/RevokeSession":  true,
+	"/auth.v1.AuthService/ChangePassword": true,
+	"/user.v1.UserService/GetProfile":     true,
+	"/user.v1.UserService/UpdateProfile":  true,
+	"/user.v1.UserService/DeleteAccount":  true,
+	"/user.v1.UserService/GetUserByID":    true,
+	"/user.v1.UserService/GetUserRoles":   true,
+	// Описание сервисов для grpcurl и подобных клиентов
+	reflectionv1.ServerReflection_ServerReflectionInfo_FullMethodName:      true,
+	reflectionv1alpha.ServerReflection_ServerReflectionInfo_FullMethodName: true,
+}
+
 func (i *AuthInterceptor) isPublicMethod(method string) bool {
 	publicMethods := []string{
 		"/auth.v1.AuthService/Register",
 		"/auth.v1.AuthService/Login",
 		"/auth.v1.AuthService/RefreshToken",
+		"/auth.v1.AuthService/ServiceAccountToken",
 		"/auth.v1.AuthService/VerifyToken",
+		"/auth.v1.AuthService/ResetPassword",
+		"/auth.v1.AuthService/ConfirmResetPassword",
+		// Envoy authenticates the requests it checks, not itself
+		extauthz.Authorization_Check_FullMethodName,
+		// Пробы Kubernetes и балансировщики приходят без токена
+		healthpb.Health_Check_FullMethodName,
+		healthpb.Health_List_FullMethodName,
+		healthpb.Health_Watch_FullMethodName,
 	}
 
 	for _, publicMethod := range publicMethods {
@@ -0,0 +1,36 @@
+package interceptors
+
+import (
+	"testing"
+
+	"google.golang.org/grpc"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+
+	"github.com/vagonaizer/authenitfication-service/api/proto/generated"
+	"github.com/vagonaizer/authenitfication-service/api/proto/generated/extauthz"
+)
+
+// TestEveryMethodIsMapped fails when a registered method is neither public,
+// open to any authenticated caller nor mapped to a permission: the
+// interceptor denies such methods.
+func TestEveryMethodIsMapped(t *testing.T) {
+	server := grpc.NewServer()
+	generated.RegisterAuthServiceServer(server, generated.UnimplementedAuthServiceServer{})
+	generated.RegisterUserServiceServer(server, generated.UnimplementedUserServiceServer{})
+	generated.RegisterRoleServiceServer(server, generated.UnimplementedRoleServiceServer{})
+	extauthz.RegisterAuthorizationServer(server, extauthz.UnimplementedAuthorizationServer{})
+	healthpb.RegisterHealthServer(server, healthpb.UnimplementedHealthServer{})
+	reflection.Register(server)
+
+	interceptor := &AuthInterceptor{}
+	for service, info := range server.GetServiceInfo() {
+		for _, method := range info.Methods {
+			fullMethod := "/" + service + "/" + method.Name
+			_, mapped := methodPermissions[fullMethod]
+			if !mapped && !authenticatedMethods[fullMethod] && !interceptor.isPublicMethod(fullMethod) {
+				t.Errorf("%s has no permission mapping", fullMethod)
+			}
+		}
+	}
+}
@@ -0,0 +1,44 @@
+package interceptors
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+
+	"github.com/vagonaizer/authenitfication-service/pkg/utils"
+)
+
+// ClientIPInterceptor records the client IP of calls in their context, for
+// utils.ClientIP. The x-forwarded-for metadata, which the REST gateway sets
+// to the address of its client, is only believed from trusted proxies. It
+// must run before the interceptors and handlers reading the IP.
+type ClientIPInterceptor struct {
+	resolver *utils.ClientIPResolver
+}
+
+func NewClientIPInterceptor(resolver *utils.ClientIPResolver) *ClientIPInterceptor {
+	return &ClientIPInterceptor{resolver: resolver}
+}
+
+func (i *ClientIPInterceptor) Unary() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		return handler(i.withClientIP(ctx), req)
+	}
+}
+
+func (i *ClientIPInterceptor) Stream() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return handler(srv, &wrappedStream{ServerStream: ss, ctx: i.withClientIP(ss.Context())})
+	}
+}
+
+func (i *ClientIPInterceptor) withClientIP(ctx context.Context) context.Context {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return ctx
+	}
+	forwardedFor := metadata.ValueFromIncomingContext(ctx, "x-forwarded-for")
+	return utils.WithClientIP(ctx, i.resolver.Resolve(p.Addr.String(), forwardedFor))
+}
@@ -0,0 +1,76 @@
+package interceptors
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// DeadlineInterceptor gives calls arriving without a deadline the timeout
+// of their method, so that a slow query is cancelled, through the context
+// the handlers pass to the database and Redis, instead of holding a worker.
+// Deadlines set by callers are kept. Unary calls default to defaultTimeout;
+// streams, which may be long-lived like WatchUserEvents, only get a
+// deadline when methodTimeouts names them. Calls failing because their
+// deadline passed return DEADLINE_EXCEEDED.
+type DeadlineInterceptor struct {
+	defaultTimeout time.Duration
+	methodTimeouts map[string]time.Duration
+}
+
+func NewDeadlineInterceptor(defaultTimeout time.Duration, methodTimeouts map[string]time.Duration) *DeadlineInterceptor {
+	return &DeadlineInterceptor{
+		defaultTimeout: defaultTimeout,
+		methodTimeouts: methodTimeouts,
+	}
+}
+
+func (i *DeadlineInterceptor) Unary() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx, cancel := i.withDeadline(ctx, info.FullMethod, i.defaultTimeout)
+		defer cancel()
+
+		resp, err := handler(ctx, req)
+		return resp, deadlineError(ctx, err)
+	}
+}
+
+func (i *DeadlineInterceptor) Stream() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, cancel := i.withDeadline(ss.Context(), info.FullMethod, 0)
+		defer cancel()
+
+		err := handler(srv, &wrappedStream{ServerStream: ss, ctx: ctx})
+		return deadlineError(ctx, err)
+	}
+}
+
+func (i *DeadlineInterceptor) withDeadline(ctx context.Context, method string, fallback time.Duration) (context.Context, context.CancelFunc) {
+	if _, ok := ctx.Deadline(); ok {
+		return ctx, func() {}
+	}
+
+	timeout, ok := i.methodTimeouts[method]
+	if !ok {
+		timeout = fallback
+	}
+	if timeout <= 0 {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, timeout)
+}
+
+// deadlineError replaces the error of a call whose deadline passed, which
+// handlers report as whatever the cancelled query failed with.
+func deadlineError(ctx context.Context, err error) error {
+	if err == nil || ctx.Err() != context.DeadlineExceeded {
+		return err
+	}
+	if status.Code(err) == codes.DeadlineExceeded {
+		return err
+	}
+	return status.Error(codes.DeadlineExceeded, "Request timed out")
+}
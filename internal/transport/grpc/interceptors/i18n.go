@@ -0,0 +1,76 @@
+package interceptors
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/vagonaizer/authenitfication-service/pkg/i18n"
+)
+
+// LocalizationInterceptor translates the messages of the errors returned to
+// clients into the locale of the authenticated user, else the language of
+// their accept-language metadata. The gateway forwards the Accept-Language
+// header as grpcgateway-accept-language. It must run before
+// AuthInterceptor, which records the locale of the user.
+type LocalizationInterceptor struct {
+	translator *i18n.Translator
+}
+
+func NewLocalizationInterceptor(translator *i18n.Translator) *LocalizationInterceptor {
+	return &LocalizationInterceptor{translator: translator}
+}
+
+func (i *LocalizationInterceptor) Unary() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx = i18n.WithPreference(ctx)
+		resp, err := handler(ctx, req)
+		if err != nil {
+			err = i.localize(ctx, err)
+		}
+		return resp, err
+	}
+}
+
+func (i *LocalizationInterceptor) Stream() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx := i18n.WithPreference(ss.Context())
+		err := handler(srv, &wrappedStream{ServerStream: ss, ctx: ctx})
+		if err != nil {
+			err = i.localize(ctx, err)
+		}
+		return err
+	}
+}
+
+func (i *LocalizationInterceptor) localize(ctx context.Context, err error) error {
+	st, ok := status.FromError(err)
+	if !ok {
+		return err
+	}
+
+	md, _ := metadata.FromIncomingContext(ctx)
+	acceptLanguage := firstValue(md, "accept-language")
+	if acceptLanguage == "" {
+		acceptLanguage = firstValue(md, "grpcgateway-accept-language")
+	}
+
+	lang := i.translator.Language(i18n.Preference(ctx), acceptLanguage)
+	if lang == i18n.DefaultLanguage {
+		return err
+	}
+
+	// Детали статуса сохраняются, меняется только сообщение
+	proto := st.Proto()
+	proto.Message = i.translator.Translate(lang, proto.Message)
+	return status.ErrorProto(proto)
+}
+
+func firstValue(md metadata.MD, key string) string {
+	if values := md.Get(key); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
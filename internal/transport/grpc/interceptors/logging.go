@@ -8,6 +8,7 @@ import (
 	"google.golang.org/grpc/status"
 
 	"github.com/vagonaizer/authenitfication-service/pkg/logger"
+	"github.com/vagonaizer/authenitfication-service/pkg/tracing"
 )
 
 type LoggingInterceptor struct {
@@ -30,9 +31,11 @@ func (i *LoggingInterceptor) Unary() grpc.UnaryServerInterceptor {
 		statusCode := status.Code(err)
 
 		fields := logger.Fields{
-			"method":   info.FullMethod,
-			"duration": duration.String(),
-			"status":   statusCode.String(),
+			"method":     info.FullMethod,
+			"duration":   duration.String(),
+			"status":     statusCode.String(),
+			"trace_id":   tracing.TraceID(ctx),
+			"request_id": tracing.CorrelationID(ctx),
 		}
 
 		if userID := ctx.Value("user_id"); userID != nil {
@@ -60,9 +63,11 @@ func (i *LoggingInterceptor) Stream() grpc.StreamServerInterceptor {
 		statusCode := status.Code(err)
 
 		fields := logger.Fields{
-			"method":   info.FullMethod,
-			"duration": duration.String(),
-			"status":   statusCode.String(),
+			"method":     info.FullMethod,
+			"duration":   duration.String(),
+			"status":     statusCode.String(),
+			"trace_id":   tracing.TraceID(ss.Context()),
+			"request_id": tracing.CorrelationID(ss.Context()),
 		}
 
 		if userID := ss.Context().Value("user_id"); userID != nil {
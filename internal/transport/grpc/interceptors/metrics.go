@@ -0,0 +1,75 @@
+package interceptors
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+
+	"github.com/vagonaizer/authenitfication-service/internal/infrastructure/metrics"
+)
+
+// MetricsInterceptor exports the count, duration and status code of every
+// gRPC call on /metrics. It runs first, so calls refused by the rate limiter
+// or the auth interceptor are counted with their codes too.
+type MetricsInterceptor struct{}
+
+func NewMetricsInterceptor() *MetricsInterceptor {
+	return &MetricsInterceptor{}
+}
+
+func (i *MetricsInterceptor) Unary() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		done := i.start(info.FullMethod, "unary")
+		resp, err := handler(ctx, req)
+		done(err)
+		return resp, err
+	}
+}
+
+func (i *MetricsInterceptor) Stream() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		done := i.start(info.FullMethod, streamType(info))
+		err := handler(srv, ss)
+		done(err)
+		return err
+	}
+}
+
+// start records a call to fullMethod as in flight; the returned func records
+// its outcome.
+func (i *MetricsInterceptor) start(fullMethod, callType string) func(err error) {
+	service, method := splitMethod(fullMethod)
+	inFlight := metrics.GRPCRequestsInFlight.WithLabelValues(service, method)
+	inFlight.Inc()
+	start := time.Now()
+
+	return func(err error) {
+		inFlight.Dec()
+		metrics.GRPCRequestDuration.WithLabelValues(service, method, callType).Observe(time.Since(start).Seconds())
+		metrics.GRPCRequests.WithLabelValues(service, method, callType, status.Code(err).String()).Inc()
+	}
+}
+
+func streamType(info *grpc.StreamServerInfo) string {
+	switch {
+	case info.IsClientStream && info.IsServerStream:
+		return "bidi_stream"
+	case info.IsClientStream:
+		return "client_stream"
+	default:
+		return "server_stream"
+	}
+}
+
+// splitMethod splits "/user.v1.UserService/GetProfile" into its service and
+// method.
+func splitMethod(fullMethod string) (string, string) {
+	service, method, ok := strings.Cut(strings.TrimPrefix(fullMethod, "/"), "/")
+	if !ok {
+		return "unknown", fullMethod
+	}
+	return service, method
+}
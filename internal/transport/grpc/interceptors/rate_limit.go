@@ -0,0 +1,131 @@
+package interceptors
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+
+	"github.com/vagonaizer/authenitfication-service/api/proto/generated/extauthz"
+	"github.com/vagonaizer/authenitfication-service/internal/config"
+	"github.com/vagonaizer/authenitfication-service/internal/infrastructure/database/redis"
+	"github.com/vagonaizer/authenitfication-service/pkg/auth"
+	"github.com/vagonaizer/authenitfication-service/pkg/logger"
+	"github.com/vagonaizer/authenitfication-service/pkg/utils"
+)
+
+// RateLimitInterceptor applies the Redis rate limit of the HTTP API to gRPC
+// calls, counting them per client IP, as recorded by ClientIPInterceptor, or
+// per user when perUser is set and the call carries a valid access token.
+// Calls are let through when Redis cannot be reached. Methods matching one
+// of policies are limited by it instead, in buckets of their own; policies
+// with an HTTP method never match. Envoy ext_authz checks are not limited:
+// they come from the proxies on behalf of every client of the mesh, and
+// neither are health checks.
+type RateLimitInterceptor struct {
+	limiter    *redis.RateLimiter
+	policies   []config.RateLimitPolicy
+	limiters   []*redis.RateLimiter
+	jwtManager *auth.JWTManager
+	perUser    bool
+	logger     *logger.Logger
+}
+
+func NewRateLimitInterceptor(
+	limiter *redis.RateLimiter,
+	policies []config.RateLimitPolicy,
+	jwtManager *auth.JWTManager,
+	perUser bool,
+	logger *logger.Logger,
+) (*RateLimitInterceptor, error) {
+	limiters := make([]*redis.RateLimiter, len(policies))
+	for i, policy := range policies {
+		policyLimiter, err := limiter.WithLimit(policy.Rate, policy.Burst)
+		if err != nil {
+			return nil, fmt.Errorf("rate limit policy %s: %w", policy.Route, err)
+		}
+		limiters[i] = policyLimiter
+	}
+
+	return &RateLimitInterceptor{
+		limiter:    limiter,
+		policies:   policies,
+		limiters:   limiters,
+		jwtManager: jwtManager,
+		perUser:    perUser,
+		logger:     logger,
+	}, nil
+}
+
+func (i *RateLimitInterceptor) Unary() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if err := i.allow(ctx, info.FullMethod); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+func (i *RateLimitInterceptor) Stream() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := i.allow(ss.Context(), info.FullMethod); err != nil {
+			return err
+		}
+		return handler(srv, ss)
+	}
+}
+
+func (i *RateLimitInterceptor) allow(ctx context.Context, method string) error {
+	if method == extauthz.Authorization_Check_FullMethodName || strings.HasPrefix(method, "/"+healthpb.Health_ServiceDesc.ServiceName+"/") {
+		return nil
+	}
+
+	limiter, key := i.limiter, ""
+	perUser := i.perUser
+	for n, policy := range i.policies {
+		if policy.Method != "" {
+			continue
+		}
+		if ok, _ := path.Match(policy.Route, method); !ok {
+			continue
+		}
+		limiter = i.limiters[n]
+		key = "route:" + policy.Route + ":"
+		if policy.Identity != "" {
+			perUser = policy.Identity == "user"
+		}
+		break
+	}
+	key += i.key(ctx, perUser)
+
+	result, err := limiter.Allow(ctx, key)
+	if err != nil {
+		i.logger.WithContext(ctx).WithError(err).WithField("key", key).Warn("failed to check rate limit, allowing call")
+		return nil
+	}
+	if !result.Allowed {
+		return status.Errorf(codes.ResourceExhausted, "rate limit exceeded, retry in %s", result.RetryAfter)
+	}
+	return nil
+}
+
+func (i *RateLimitInterceptor) key(ctx context.Context, perUser bool) string {
+	if perUser {
+		// Интерцептор стоит перед аутентификацией, поэтому токен проверяем сами
+		if token, err := extractToken(ctx); err == nil {
+			if claims, err := i.jwtManager.ValidateAccessToken(token, requestTenant(ctx)); err == nil {
+				return "user:" + claims.UserID.String()
+			}
+		}
+	}
+
+	if ip := utils.ClientIP(ctx); ip != "" {
+		return "ip:" + ip
+	}
+	return "ip:unknown"
+}
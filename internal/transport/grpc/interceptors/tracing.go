@@ -0,0 +1,76 @@
+package interceptors
+
+import (
+	"context"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/vagonaizer/authenitfication-service/pkg/tracing"
+)
+
+// TracingInterceptor starts a span for each call, continuing the trace of
+// its traceparent metadata when it has one, and keeps its x-request-id as
+// the correlation ID, so the events it publishes carry both. Calls without
+// an x-request-id get a new one. The ID is returned in the x-request-id
+// header metadata and, on errors, as a RequestInfo detail of the status.
+type TracingInterceptor struct{}
+
+func NewTracingInterceptor() *TracingInterceptor {
+	return &TracingInterceptor{}
+}
+
+func (i *TracingInterceptor) Unary() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx = i.extract(ctx)
+		_ = grpc.SetHeader(ctx, metadata.Pairs(tracing.HeaderCorrelationID, tracing.CorrelationID(ctx)))
+
+		resp, err := handler(ctx, req)
+		if err != nil {
+			err = withRequestInfo(ctx, err)
+		}
+		return resp, err
+	}
+}
+
+func (i *TracingInterceptor) Stream() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx := i.extract(ss.Context())
+		_ = ss.SetHeader(metadata.Pairs(tracing.HeaderCorrelationID, tracing.CorrelationID(ctx)))
+
+		err := handler(srv, &wrappedStream{ServerStream: ss, ctx: ctx})
+		if err != nil {
+			err = withRequestInfo(ctx, err)
+		}
+		return err
+	}
+}
+
+func (i *TracingInterceptor) extract(ctx context.Context) context.Context {
+	md, _ := metadata.FromIncomingContext(ctx)
+	ctx = tracing.Extract(ctx, func(key string) string {
+		if values := md.Get(key); len(values) > 0 {
+			return values[0]
+		}
+		return ""
+	})
+	if tracing.CorrelationID(ctx) == "" {
+		ctx = tracing.WithCorrelationID(ctx, tracing.NewCorrelationID())
+	}
+	return ctx
+}
+
+// withRequestInfo attaches the request ID of ctx to the status of err.
+func withRequestInfo(ctx context.Context, err error) error {
+	st, ok := status.FromError(err)
+	if !ok {
+		return err
+	}
+	detailed, detailsErr := st.WithDetails(&errdetails.RequestInfo{RequestId: tracing.CorrelationID(ctx)})
+	if detailsErr != nil {
+		return err
+	}
+	return detailed.Err()
+}
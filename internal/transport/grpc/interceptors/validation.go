@@ -0,0 +1,258 @@
+package interceptors
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	"github.com/vagonaizer/authenitfication-service/api/proto/generated/validate"
+	"github.com/vagonaizer/authenitfication-service/internal/dto/request"
+)
+
+// ValidationInterceptor rejects requests that break the validate.rules
+// annotated on their messages with INVALID_ARGUMENT before they reach the
+// handlers. Each broken rule is described as the HTTP API describes it, in a
+// BadRequest detail keyed by the field path, such as "role_ids[2]"; the
+// status message names the first one.
+type ValidationInterceptor struct{}
+
+func NewValidationInterceptor() *ValidationInterceptor {
+	return &ValidationInterceptor{}
+}
+
+func (i *ValidationInterceptor) Unary() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if err := validateRequest(req); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+func (i *ValidationInterceptor) Stream() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return handler(srv, &validatingStream{ServerStream: ss})
+	}
+}
+
+// validatingStream validates each message the client sends.
+type validatingStream struct {
+	grpc.ServerStream
+}
+
+func (s *validatingStream) RecvMsg(m interface{}) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+	return validateRequest(m)
+}
+
+func validateRequest(req interface{}) error {
+	msg, ok := req.(proto.Message)
+	if !ok {
+		return nil
+	}
+
+	var violations []*errdetails.BadRequest_FieldViolation
+	validateMessage(msg.ProtoReflect(), "", &violations)
+	if len(violations) == 0 {
+		return nil
+	}
+
+	st := status.New(codes.InvalidArgument, violations[0].Field+" "+violations[0].Description)
+	if detailed, err := st.WithDetails(&errdetails.BadRequest{FieldViolations: violations}); err == nil {
+		st = detailed
+	}
+	return st.Err()
+}
+
+// validateMessage appends the rules m breaks to violations; fields are named
+// as in the JSON of the HTTP API, below prefix.
+func validateMessage(m protoreflect.Message, prefix string, violations *[]*errdetails.BadRequest_FieldViolation) {
+	violate := func(field, description string) {
+		*violations = append(*violations, &errdetails.BadRequest_FieldViolation{Field: field, Description: description})
+	}
+
+	fields := m.Descriptor().Fields()
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		rules, _ := proto.GetExtension(fd.Options(), validate.E_Rules).(*validate.FieldRules)
+		path := prefix + string(fd.Name())
+
+		switch {
+		case fd.IsList():
+			list := m.Get(fd).List()
+			if description := checkList(list, rules.GetRepeated()); description != "" {
+				violate(path, description)
+				continue
+			}
+			for j := 0; j < list.Len(); j++ {
+				itemPath := fmt.Sprintf("%s[%d]", path, j)
+				if fd.Kind() == protoreflect.MessageKind {
+					validateMessage(list.Get(j).Message(), itemPath+".", violations)
+				} else if description := checkValue(fd, list.Get(j), rules.GetRepeated().GetItems()); description != "" {
+					violate(itemPath, description)
+				}
+			}
+
+		case fd.IsMap():
+			// Правил для map нет
+
+		case fd.Kind() == protoreflect.MessageKind:
+			if !m.Has(fd) {
+				if rules.GetMessage().GetRequired() {
+					violate(path, "is required")
+				}
+				continue
+			}
+			if !rules.GetMessage().GetSkip() {
+				validateMessage(m.Get(fd).Message(), path+".", violations)
+			}
+
+		default:
+			// Незаданное optional-поле не проверяется
+			if fd.HasPresence() && !m.Has(fd) {
+				continue
+			}
+			if description := checkValue(fd, m.Get(fd), rules); description != "" {
+				violate(path, description)
+			}
+		}
+	}
+}
+
+func checkList(list protoreflect.List, rules *validate.RepeatedRules) string {
+	n := uint64(list.Len())
+	if rules == nil || (n == 0 && rules.GetIgnoreEmpty()) {
+		return ""
+	}
+
+	switch {
+	case rules.MinItems != nil && n < rules.GetMinItems():
+		if n == 0 {
+			return "is required"
+		}
+		return fmt.Sprintf("must be at least %d items", rules.GetMinItems())
+	case rules.MaxItems != nil && n > rules.GetMaxItems():
+		return fmt.Sprintf("must be at most %d items", rules.GetMaxItems())
+	case rules.GetUnique():
+		seen := make(map[interface{}]bool, list.Len())
+		for i := 0; i < list.Len(); i++ {
+			item := list.Get(i).Interface()
+			if seen[item] {
+				return "must not contain duplicates"
+			}
+			seen[item] = true
+		}
+	}
+	return ""
+}
+
+// checkValue describes the rule a scalar value breaks, or returns "".
+func checkValue(fd protoreflect.FieldDescriptor, value protoreflect.Value, rules *validate.FieldRules) string {
+	switch fd.Kind() {
+	case protoreflect.StringKind:
+		return checkString(value.String(), rules.GetString_())
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		if r := rules.GetInt32(); r != nil {
+			return checkInt(value.Int(), intRules{
+				lt: int64Ptr(r.Lt), lte: int64Ptr(r.Lte), gt: int64Ptr(r.Gt), gte: int64Ptr(r.Gte),
+				in: int64Slice(r.In), ignoreEmpty: r.GetIgnoreEmpty(),
+			})
+		}
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		if r := rules.GetInt64(); r != nil {
+			return checkInt(value.Int(), intRules{
+				lt: r.Lt, lte: r.Lte, gt: r.Gt, gte: r.Gte,
+				in: r.In, ignoreEmpty: r.GetIgnoreEmpty(),
+			})
+		}
+	}
+	return ""
+}
+
+func checkString(value string, rules *validate.StringRules) string {
+	if rules == nil {
+		return ""
+	}
+	if value == "" {
+		if rules.GetIgnoreEmpty() {
+			return ""
+		}
+		if rules.GetMinLen() > 0 || rules.GetEmail() || rules.GetUuid() || len(rules.In) > 0 {
+			return "is required"
+		}
+		return ""
+	}
+
+	n := uint64(utf8.RuneCountInString(value))
+	switch {
+	case rules.MinLen != nil && n < rules.GetMinLen():
+		return fmt.Sprintf("must be at least %d characters", rules.GetMinLen())
+	case rules.MaxLen != nil && n > rules.GetMaxLen():
+		return fmt.Sprintf("must be at most %d characters", rules.GetMaxLen())
+	case len(rules.In) > 0 && !slices.Contains(rules.In, value):
+		return "must be one of: " + strings.Join(rules.In, ", ")
+	// Те же проверки, что у валидатора HTTP API
+	case rules.GetEmail() && request.GetValidator().Var(value, "email") != nil:
+		return "must be a valid email address"
+	case rules.GetUuid() && request.GetValidator().Var(value, "uuid") != nil:
+		return "must be a valid UUID"
+	}
+	return ""
+}
+
+type intRules struct {
+	lt, lte, gt, gte *int64
+	in               []int64
+	ignoreEmpty      bool
+}
+
+func checkInt(value int64, rules intRules) string {
+	if value == 0 && rules.ignoreEmpty {
+		return ""
+	}
+
+	switch {
+	case rules.gte != nil && value < *rules.gte:
+		return fmt.Sprintf("must be at least %d", *rules.gte)
+	case rules.gt != nil && value <= *rules.gt:
+		return fmt.Sprintf("must be greater than %d", *rules.gt)
+	case rules.lte != nil && value > *rules.lte:
+		return fmt.Sprintf("must be at most %d", *rules.lte)
+	case rules.lt != nil && value >= *rules.lt:
+		return fmt.Sprintf("must be less than %d", *rules.lt)
+	case len(rules.in) > 0 && !slices.Contains(rules.in, value):
+		values := make([]string, len(rules.in))
+		for i, v := range rules.in {
+			values[i] = fmt.Sprint(v)
+		}
+		return "must be one of: " + strings.Join(values, ", ")
+	}
+	return ""
+}
+
+func int64Ptr(v *int32) *int64 {
+	if v == nil {
+		return nil
+	}
+	n := int64(*v)
+	return &n
+}
+
+func int64Slice(values []int32) []int64 {
+	out := make([]int64, len(values))
+	for i, v := range values {
+		out[i] = int64(v)
+	}
+	return out
+}
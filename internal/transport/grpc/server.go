@@ -1,12 +1,17 @@
 package grpc
 
 import (
+	"context"
 	"net"
+	"time"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/reflection"
 
 	"github.com/vagonaizer/authenitfication-service/api/proto/generated"
+	"github.com/vagonaizer/authenitfication-service/api/proto/generated/extauthz"
 	"github.com/vagonaizer/authenitfication-service/internal/transport/grpc/handlers"
 	"github.com/vagonaizer/authenitfication-service/internal/transport/grpc/interceptors"
 	"github.com/vagonaizer/authenitfication-service/pkg/logger"
@@ -14,8 +19,10 @@ import (
 
 type Server struct {
 	server          *grpc.Server
+	health          *healthService
 	authHandler     *handlers.AuthGRPCHandler
 	userHandler     *handlers.UserGRPCHandler
+	roleHandler     *handlers.RoleGRPCHandler
 	authInterceptor *interceptors.AuthInterceptor
 	logInterceptor  *interceptors.LoggingInterceptor
 	logger          *logger.Logger
@@ -24,30 +31,64 @@ type Server struct {
 func NewServer(
 	authHandler *handlers.AuthGRPCHandler,
 	userHandler *handlers.UserGRPCHandler,
+	roleHandler *handlers.RoleGRPCHandler,
+	extAuthzHandler *handlers.ExtAuthzGRPCHandler,
 	authInterceptor *interceptors.AuthInterceptor,
 	logInterceptor *interceptors.LoggingInterceptor,
+	tracingInterceptor *interceptors.TracingInterceptor,
+	localizationInterceptor *interceptors.LocalizationInterceptor,
+	rateLimitInterceptor *interceptors.RateLimitInterceptor,
+	clientIPInterceptor *interceptors.ClientIPInterceptor,
+	validationInterceptor *interceptors.ValidationInterceptor,
+	metricsInterceptor *interceptors.MetricsInterceptor,
+	deadlineInterceptor *interceptors.DeadlineInterceptor,
+	healthCheck func(ctx context.Context) error,
+	healthInterval time.Duration,
+	creds credentials.TransportCredentials,
+	opts []grpc.ServerOption,
 	logger *logger.Logger,
 ) *Server {
-	server := grpc.NewServer(
-		grpc.ChainUnaryInterceptor(
-			logInterceptor.Unary(),
-			authInterceptor.Unary(),
-		),
-		grpc.ChainStreamInterceptor(
-			logInterceptor.Stream(),
-			authInterceptor.Stream(),
-		),
+	unary := []grpc.UnaryServerInterceptor{metricsInterceptor.Unary(), clientIPInterceptor.Unary(), tracingInterceptor.Unary(), logInterceptor.Unary(), localizationInterceptor.Unary(), deadlineInterceptor.Unary()}
+	stream := []grpc.StreamServerInterceptor{metricsInterceptor.Stream(), clientIPInterceptor.Stream(), tracingInterceptor.Stream(), logInterceptor.Stream(), localizationInterceptor.Stream(), deadlineInterceptor.Stream()}
+	if rateLimitInterceptor != nil {
+		unary = append(unary, rateLimitInterceptor.Unary())
+		stream = append(stream, rateLimitInterceptor.Stream())
+	}
+	unary = append(unary, authInterceptor.Unary(), validationInterceptor.Unary())
+	stream = append(stream, authInterceptor.Stream(), validationInterceptor.Stream())
+
+	opts = append(opts,
+		grpc.Creds(creds),
+		grpc.ChainUnaryInterceptor(unary...),
+		grpc.ChainStreamInterceptor(stream...),
 	)
+	server := grpc.NewServer(opts...)
 
 	generated.RegisterAuthServiceServer(server, authHandler)
 	generated.RegisterUserServiceServer(server, userHandler)
+	generated.RegisterRoleServiceServer(server, roleHandler)
+	services := []string{
+		generated.AuthService_ServiceDesc.ServiceName,
+		generated.UserService_ServiceDesc.ServiceName,
+		generated.RoleService_ServiceDesc.ServiceName,
+	}
+	if extAuthzHandler != nil {
+		extauthz.RegisterAuthorizationServer(server, extAuthzHandler)
+		services = append(services, extauthz.Authorization_ServiceDesc.ServiceName)
+	}
+
+	// Статус зависит от тех же зависимостей, что и /ready
+	health := newHealthService(healthCheck, services, healthInterval, logger)
+	healthpb.RegisterHealthServer(server, health.server)
 
 	reflection.Register(server)
 
 	return &Server{
 		server:          server,
+		health:          health,
 		authHandler:     authHandler,
 		userHandler:     userHandler,
+		roleHandler:     roleHandler,
 		authInterceptor: authInterceptor,
 		logInterceptor:  logInterceptor,
 		logger:          logger,
@@ -60,11 +101,34 @@ func (s *Server) Start(address string) error {
 		return err
 	}
 
+	go s.health.run()
+
 	s.logger.Infof("gRPC server starting on %s", address)
 	return s.server.Serve(listener)
 }
 
-func (s *Server) Stop() {
+// Drain reports every service as NOT_SERVING, so health-checking clients
+// and probes move away before the server stops.
+func (s *Server) Drain() {
+	s.health.shutdown()
+}
+
+// Stop waits for running RPCs to complete until ctx is done, then cancels
+// the remaining ones.
+func (s *Server) Stop(ctx context.Context) {
 	s.logger.Info("shutting down gRPC server")
-	s.server.GracefulStop()
+	s.health.shutdown()
+
+	done := make(chan struct{})
+	go func() {
+		s.server.GracefulStop()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-ctx.Done():
+		s.logger.Warn("gRPC shutdown timeout exceeded, cancelling running RPCs")
+		s.server.Stop()
+	}
 }
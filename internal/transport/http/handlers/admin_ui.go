@@ -0,0 +1,41 @@
+package handlers
+
+import (
+	"embed"
+	"io/fs"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+//go:embed adminui
+var adminUIFiles embed.FS
+
+// AdminUIHandler serves the operator console at /admin, a static page
+// embedded in the binary. The page holds no data: it signs the operator in
+// and calls the admin API with their token, so every action goes through the
+// permission checks of the API.
+type AdminUIHandler struct {
+	files http.Handler
+}
+
+func NewAdminUIHandler() *AdminUIHandler {
+	sub, _ := fs.Sub(adminUIFiles, "adminui")
+	return &AdminUIHandler{
+		files: http.StripPrefix("/admin", http.FileServer(http.FS(sub))),
+	}
+}
+
+func (h *AdminUIHandler) Serve(c echo.Context) error {
+	// Консоль работает с токенами оператора: ни встраивания, ни чужих скриптов
+	header := c.Response().Header()
+	header.Set("Content-Security-Policy", "default-src 'self'; frame-ancestors 'none'")
+	header.Set("X-Frame-Options", "DENY")
+	header.Set("Referrer-Policy", "no-referrer")
+
+	if c.Request().URL.Path == "/admin" {
+		return c.Redirect(http.StatusMovedPermanently, "/admin/")
+	}
+	h.files.ServeHTTP(c.Response(), c.Request())
+	return nil
+}
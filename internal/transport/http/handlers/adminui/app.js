@@ -0,0 +1,447 @@
+// Operator console of the authentication service. It holds no data of its
+// own: every view calls the admin API with the token of the signed-in
+// operator, and the API checks their permissions like for any client.
+"use strict";
+
+const api = "/api/v1";
+const pageSize = 20;
+
+const state = {
+  usersPage: 1,
+  usersQuery: "",
+  auditPage: 1,
+  user: null,
+  role: null,
+  roles: [],
+};
+
+const $ = (selector) => document.querySelector(selector);
+
+// Session
+
+function tokens() {
+  return {
+    access: sessionStorage.getItem("access_token"),
+    refresh: sessionStorage.getItem("refresh_token"),
+  };
+}
+
+function saveTokens(access, refresh) {
+  sessionStorage.setItem("access_token", access);
+  if (refresh) {
+    sessionStorage.setItem("refresh_token", refresh);
+  }
+}
+
+function clearTokens() {
+  sessionStorage.removeItem("access_token");
+  sessionStorage.removeItem("refresh_token");
+}
+
+async function refresh() {
+  const { refresh } = tokens();
+  if (!refresh) {
+    return false;
+  }
+  const res = await fetch(api + "/auth/refresh", {
+    method: "POST",
+    headers: { "Content-Type": "application/json" },
+    body: JSON.stringify({ refresh_token: refresh }),
+  });
+  if (!res.ok) {
+    return false;
+  }
+  const body = await res.json();
+  saveTokens(body.access_token);
+  return true;
+}
+
+// call sends a request to the API, refreshing the access token once when it
+// has expired, and returns the decoded body. Failures throw the message of
+// the error response.
+async function call(method, path, body, retried) {
+  const headers = { Authorization: "Bearer " + tokens().access };
+  if (body !== undefined) {
+    headers["Content-Type"] = "application/json";
+  }
+
+  const res = await fetch(api + path, {
+    method,
+    headers,
+    body: body === undefined ? undefined : JSON.stringify(body),
+  });
+
+  if (res.status === 401 && !retried) {
+    if (await refresh()) {
+      return call(method, path, body, true);
+    }
+    signOut();
+    throw new Error("Your session has expired, sign in again");
+  }
+
+  const data = res.headers.get("Content-Type")?.startsWith("application/json") ? await res.json() : null;
+  if (!res.ok) {
+    throw new Error(data?.message || res.statusText);
+  }
+  return data;
+}
+
+function query(params) {
+  const search = new URLSearchParams();
+  for (const [key, value] of Object.entries(params)) {
+    if (value !== "" && value !== undefined && value !== null) {
+      search.set(key, value);
+    }
+  }
+  return search.toString();
+}
+
+// Rendering
+
+function show(text, isError) {
+  const message = $("#message");
+  message.textContent = text;
+  message.classList.toggle("error", Boolean(isError));
+  message.hidden = false;
+  clearTimeout(show.timer);
+  show.timer = setTimeout(() => { message.hidden = true; }, 5000);
+}
+
+// run reports the outcome of an action in the message bar.
+async function run(action, success) {
+  try {
+    await action();
+    if (success) {
+      show(success);
+    }
+  } catch (err) {
+    show(err.message, true);
+  }
+}
+
+function cell(row, value) {
+  const td = document.createElement("td");
+  td.textContent = value ?? "";
+  row.appendChild(td);
+  return td;
+}
+
+function date(value) {
+  return value ? new Date(value).toLocaleString() : "";
+}
+
+function button(label, className, onClick) {
+  const b = document.createElement("button");
+  b.type = "button";
+  b.textContent = label;
+  b.className = className;
+  b.addEventListener("click", onClick);
+  return b;
+}
+
+function pager(selector, page, totalPages, go) {
+  const el = $(selector);
+  el.querySelector("span").textContent = totalPages ? `Page ${page} of ${totalPages}` : "";
+  el.querySelector('[data-page="prev"]').disabled = page <= 1;
+  el.querySelector('[data-page="next"]').disabled = !totalPages || page >= totalPages;
+  el.querySelector('[data-page="prev"]').onclick = () => go(page - 1);
+  el.querySelector('[data-page="next"]').onclick = () => go(page + 1);
+}
+
+function selectTab(name) {
+  document.querySelectorAll("#tabs [data-tab]").forEach((b) => b.classList.toggle("active", b.dataset.tab === name));
+  document.querySelectorAll(".tab").forEach((section) => { section.hidden = section.id !== name; });
+  ({ users: loadUsers, roles: loadRoles, audit: loadAudit })[name]();
+}
+
+// Users
+
+async function loadUsers() {
+  const rows = $("#user-rows");
+  let users;
+  let totalPages = 0;
+
+  // Поиск по началу слов работает от трёх символов, короче — фильтр списка
+  if (state.usersQuery.length >= 3) {
+    users = (await call("GET", "/admin/users/search?" + query({ q: state.usersQuery, limit: 50 }))).users;
+  } else {
+    const page = await call("GET", "/admin/users?" + query({ page: state.usersPage, page_size: pageSize, search: state.usersQuery }));
+    users = page.users;
+    totalPages = page.total_pages;
+  }
+
+  rows.replaceChildren();
+  for (const user of users) {
+    const row = document.createElement("tr");
+    row.className = "selectable";
+    cell(row, user.email);
+    cell(row, user.username);
+    cell(row, user.is_active ? "yes" : "no");
+    cell(row, user.is_verified ? "yes" : "no");
+    cell(row, date(user.last_login_at));
+    row.addEventListener("click", () => run(() => loadUser(user.id)));
+    rows.appendChild(row);
+  }
+  pager("#user-pager", state.usersPage, totalPages, (page) => {
+    state.usersPage = page;
+    run(loadUsers);
+  });
+}
+
+async function loadUser(id) {
+  const [user, roles, activity] = await Promise.all([
+    call("GET", "/admin/users/" + id),
+    call("GET", "/users/" + id + "/roles"),
+    call("GET", "/admin/users/" + id + "/activity?" + query({ page_size: pageSize })),
+  ]);
+  state.user = user;
+
+  $("#user-title").textContent = user.email;
+  const fields = $("#user-fields");
+  fields.replaceChildren();
+  for (const [label, value] of [
+    ["ID", user.id],
+    ["Username", user.username],
+    ["Name", [user.first_name, user.last_name].filter(Boolean).join(" ")],
+    ["Active", user.is_active ? "yes" : "no"],
+    ["Verified", user.is_verified ? "yes" : "no"],
+    ["Password change required", user.password_change_required ? "yes" : "no"],
+    ["Created", date(user.created_at)],
+    ["Last login", date(user.last_login_at)],
+  ]) {
+    const dt = document.createElement("dt");
+    dt.textContent = label;
+    const dd = document.createElement("dd");
+    dd.textContent = value || "—";
+    fields.append(dt, dd);
+  }
+  $("#user-activate").hidden = user.is_active;
+  $("#user-deactivate").hidden = !user.is_active;
+
+  const list = $("#user-roles");
+  list.replaceChildren();
+  for (const role of roles.roles) {
+    const li = document.createElement("li");
+    li.textContent = role.name;
+    li.appendChild(button("Remove", "danger", () => run(async () => {
+      await call("DELETE", "/admin/users/roles/remove", { user_id: user.id, role_id: role.id });
+      await loadUser(user.id);
+    }, "Role removed")));
+    list.appendChild(li);
+  }
+
+  if (!state.roles.length) {
+    state.roles = (await call("GET", "/admin/roles")).roles;
+  }
+  const select = $("#user-assign select");
+  select.replaceChildren();
+  for (const role of state.roles) {
+    select.appendChild(new Option(role.name, role.id));
+  }
+
+  const rows = $("#user-activity");
+  rows.replaceChildren();
+  for (const entry of activity.activities) {
+    const row = document.createElement("tr");
+    cell(row, date(entry.created_at));
+    cell(row, entry.type);
+    cell(row, entry.actor_id);
+    cell(row, entry.ip_address);
+    rows.appendChild(row);
+  }
+
+  $("#user-detail").hidden = false;
+}
+
+function userAction(method, path, confirmText, success) {
+  return () => {
+    if (confirmText && !confirm(confirmText)) {
+      return;
+    }
+    run(async () => {
+      await call(method, path(state.user.id));
+      await loadUser(state.user.id);
+    }, success);
+  };
+}
+
+// Roles
+
+async function loadRoles() {
+  state.roles = (await call("GET", "/admin/roles")).roles;
+
+  const rows = $("#role-rows");
+  rows.replaceChildren();
+  for (const role of state.roles) {
+    const row = document.createElement("tr");
+    row.className = "selectable";
+    cell(row, role.name);
+    cell(row, role.description);
+    cell(row, "").appendChild(button("Delete", "danger", (event) => {
+      event.stopPropagation();
+      if (!confirm(`Delete the role ${role.name}?`)) {
+        return;
+      }
+      run(async () => {
+        await call("DELETE", "/admin/roles/" + role.id);
+        $("#role-detail").hidden = true;
+        await loadRoles();
+      }, "Role deleted");
+    }));
+    row.addEventListener("click", () => run(() => loadRole(role)));
+    rows.appendChild(row);
+  }
+}
+
+async function loadRole(role) {
+  const grants = await call("GET", "/admin/roles/" + role.id + "/permissions");
+  state.role = role;
+
+  $("#role-title").textContent = role.name;
+  const list = $("#role-permissions");
+  list.replaceChildren();
+  for (const grant of grants.permissions) {
+    const li = document.createElement("li");
+    li.textContent = grant.condition ? `${grant.permission} when ${grant.condition}` : grant.permission;
+    li.appendChild(button("Revoke", "danger", () => run(async () => {
+      await call("DELETE", "/admin/roles/" + role.id + "/permissions/" + encodeURIComponent(grant.permission));
+      await loadRole(role);
+    }, "Permission revoked")));
+    list.appendChild(li);
+  }
+  $("#role-detail").hidden = false;
+}
+
+// Audit
+
+async function loadAudit() {
+  const form = $("#audit-filter");
+  const page = await call("GET", "/admin/users/roles/audit?" + query({
+    page: state.auditPage,
+    page_size: pageSize,
+    action: form.action.value,
+    user_id: form.user_id.value.trim(),
+  }));
+
+  const rows = $("#audit-rows");
+  rows.replaceChildren();
+  for (const entry of page.entries) {
+    const row = document.createElement("tr");
+    cell(row, date(entry.created_at));
+    cell(row, entry.action);
+    cell(row, entry.role_name);
+    cell(row, entry.user_id);
+    cell(row, entry.actor_id);
+    cell(row, entry.reason);
+    rows.appendChild(row);
+  }
+  pager("#audit-pager", state.auditPage, page.total_pages, (next) => {
+    state.auditPage = next;
+    run(loadAudit);
+  });
+}
+
+// Start
+
+function signIn() {
+  $("#login").hidden = true;
+  $("#tabs").hidden = false;
+  selectTab("users");
+}
+
+function signOut() {
+  const { refresh } = tokens();
+  if (refresh) {
+    fetch(api + "/auth/logout", {
+      method: "POST",
+      headers: { "Content-Type": "application/json" },
+      body: JSON.stringify({ refresh_token: refresh }),
+    }).catch(() => {});
+  }
+  clearTokens();
+  state.roles = [];
+  $("#tabs").hidden = true;
+  document.querySelectorAll(".tab").forEach((section) => { section.hidden = true; });
+  $("#login").hidden = false;
+}
+
+document.addEventListener("DOMContentLoaded", () => {
+  $("#login-form").addEventListener("submit", (event) => {
+    event.preventDefault();
+    const form = event.target;
+    run(async () => {
+      const res = await fetch(api + "/auth/login", {
+        method: "POST",
+        headers: { "Content-Type": "application/json" },
+        body: JSON.stringify({ email: form.email.value, password: form.password.value }),
+      });
+      const body = await res.json();
+      if (!res.ok) {
+        throw new Error(body.message || res.statusText);
+      }
+      saveTokens(body.access_token, body.refresh_token);
+      form.reset();
+      signIn();
+    });
+  });
+
+  $("#sign-out").addEventListener("click", signOut);
+  document.querySelectorAll("#tabs [data-tab]").forEach((b) => {
+    b.addEventListener("click", () => run(() => selectTab(b.dataset.tab)));
+  });
+
+  $("#user-search").addEventListener("submit", (event) => {
+    event.preventDefault();
+    state.usersQuery = event.target.q.value.trim();
+    state.usersPage = 1;
+    run(loadUsers);
+  });
+  $("#user-activate").addEventListener("click", userAction("POST", (id) => `/admin/users/${id}/activate`, null, "User activated"));
+  $("#user-deactivate").addEventListener("click", userAction("POST", (id) => `/admin/users/${id}/deactivate`, "Deactivate this user? They are signed out everywhere.", "User deactivated"));
+  $("#user-revoke").addEventListener("click", userAction("DELETE", (id) => `/admin/users/${id}/sessions`, "Sign this user out of every session?", "Sessions revoked"));
+  $("#user-assign").addEventListener("submit", (event) => {
+    event.preventDefault();
+    const form = event.target;
+    run(async () => {
+      await call("POST", "/admin/users/roles/assign", {
+        user_id: state.user.id,
+        role_id: form.role_id.value,
+        reason: form.reason.value.trim() || undefined,
+      });
+      form.reason.value = "";
+      await loadUser(state.user.id);
+    }, "Role assigned");
+  });
+
+  $("#role-create").addEventListener("submit", (event) => {
+    event.preventDefault();
+    const form = event.target;
+    run(async () => {
+      await call("POST", "/admin/roles", {
+        name: form.name.value.trim(),
+        description: form.description.value.trim() || undefined,
+      });
+      form.reset();
+      await loadRoles();
+    }, "Role created");
+  });
+  $("#role-grant").addEventListener("submit", (event) => {
+    event.preventDefault();
+    const form = event.target;
+    run(async () => {
+      await call("POST", "/admin/roles/" + state.role.id + "/permissions", { permission: form.permission.value.trim() });
+      form.reset();
+      await loadRole(state.role);
+    }, "Permission granted");
+  });
+
+  $("#audit-filter").addEventListener("submit", (event) => {
+    event.preventDefault();
+    state.auditPage = 1;
+    run(loadAudit);
+  });
+
+  if (tokens().access) {
+    signIn();
+  }
+});
@@ -2,11 +2,13 @@ package handlers
 
 import (
 	"net/http"
+	"strings"
 
 	"github.com/labstack/echo/v4"
 	"github.com/vagonaizer/authenitfication-service/internal/domain/services"
 	"github.com/vagonaizer/authenitfication-service/internal/dto/request"
 	"github.com/vagonaizer/authenitfication-service/internal/dto/response"
+	"github.com/vagonaizer/authenitfication-service/pkg/auth"
 	"github.com/vagonaizer/authenitfication-service/pkg/errors"
 	"github.com/vagonaizer/authenitfication-service/pkg/logger"
 )
@@ -14,12 +16,18 @@ import (
 type AuthHandler struct {
 	authService services.AuthService
 	logger      *logger.Logger
+	// countryHeader is set by a trusted proxy to the client's country code.
+	countryHeader string
+	// tokenCookie carries the access token of browsers to Forward.
+	tokenCookie string
 }
 
-func NewAuthHandler(authService services.AuthService, logger *logger.Logger) *AuthHandler {
+func NewAuthHandler(authService services.AuthService, logger *logger.Logger, countryHeader, tokenCookie string) *AuthHandler {
 	return &AuthHandler{
-		authService: authService,
-		logger:      logger,
+		authService:   authService,
+		logger:        logger,
+		countryHeader: countryHeader,
+		tokenCookie:   tokenCookie,
 	}
 }
 
@@ -38,6 +46,7 @@ func (h *AuthHandler) Register(c echo.Context) error {
 			Error:   "VALIDATION_ERROR",
 			Message: err.Error(),
 			Code:    http.StatusBadRequest,
+			Details: request.ValidationDetails(err),
 		})
 	}
 
@@ -83,6 +92,7 @@ func (h *AuthHandler) Login(c echo.Context) error {
 			Error:   "VALIDATION_ERROR",
 			Message: err.Error(),
 			Code:    http.StatusBadRequest,
+			Details: request.ValidationDetails(err),
 		})
 	}
 
@@ -93,6 +103,10 @@ func (h *AuthHandler) Login(c echo.Context) error {
 	}
 	userAgent := c.Request().UserAgent()
 
+	if h.countryHeader != "" {
+		req.Country = c.Request().Header.Get(h.countryHeader)
+	}
+
 	result, err := h.authService.Login(c.Request().Context(), &req, ipAddress, userAgent)
 	if err != nil {
 		if appErr, ok := err.(*errors.AppError); ok {
@@ -151,6 +165,44 @@ func (h *AuthHandler) RefreshToken(c echo.Context) error {
 	return c.JSON(http.StatusOK, result)
 }
 
+func (h *AuthHandler) ServiceAccountToken(c echo.Context) error {
+	var req request.ServiceAccountTokenRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, response.ErrorResponse{
+			Error:   "INVALID_REQUEST",
+			Message: "Invalid request format",
+			Code:    http.StatusBadRequest,
+		})
+	}
+
+	if err := request.ValidateStruct(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, response.ErrorResponse{
+			Error:   "VALIDATION_ERROR",
+			Message: err.Error(),
+			Code:    http.StatusBadRequest,
+		})
+	}
+
+	result, err := h.authService.AuthenticateServiceAccount(c.Request().Context(), &req)
+	if err != nil {
+		if appErr, ok := err.(*errors.AppError); ok {
+			return c.JSON(appErr.StatusCode, response.ErrorResponse{
+				Error:   appErr.Code,
+				Message: appErr.Message,
+				Code:    appErr.StatusCode,
+				Details: appErr.Details,
+			})
+		}
+		return c.JSON(http.StatusInternalServerError, response.ErrorResponse{
+			Error:   "INTERNAL_ERROR",
+			Message: "Internal server error",
+			Code:    http.StatusInternalServerError,
+		})
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
+
 func (h *AuthHandler) Logout(c echo.Context) error {
 	var req request.LogoutRequest
 	if err := c.Bind(&req); err != nil {
@@ -191,6 +243,21 @@ func (h *AuthHandler) Logout(c echo.Context) error {
 	})
 }
 
+// CSRFToken returns the CSRF token of the browser, issuing its cookie if it
+// has none yet.
+func (h *AuthHandler) CSRFToken(c echo.Context) error {
+	token, ok := c.Get("csrf").(string)
+	if !ok {
+		return c.JSON(http.StatusNotFound, response.ErrorResponse{
+			Error:   "CSRF_DISABLED",
+			Message: "CSRF protection is disabled",
+			Code:    http.StatusNotFound,
+		})
+	}
+
+	return c.JSON(http.StatusOK, response.CSRFTokenResponse{Token: token})
+}
+
 func (h *AuthHandler) VerifyToken(c echo.Context) error {
 	authHeader := c.Request().Header.Get("Authorization")
 	if authHeader == "" {
@@ -206,7 +273,8 @@ func (h *AuthHandler) VerifyToken(c echo.Context) error {
 		token = authHeader[7:]
 	}
 
-	result, err := h.authService.VerifyToken(c.Request().Context(), token)
+	tenant := auth.ParseTenant(c.Request().Header.Get(auth.TenantHeader))
+	result, err := h.authService.VerifyToken(c.Request().Context(), token, tenant)
 	if err != nil {
 		if appErr, ok := err.(*errors.AppError); ok {
 			return c.JSON(appErr.StatusCode, response.ErrorResponse{
@@ -226,6 +294,69 @@ func (h *AuthHandler) VerifyToken(c echo.Context) error {
 	return c.JSON(http.StatusOK, result)
 }
 
+// Forward authenticates a request on behalf of a reverse proxy, as Traefik
+// forwardAuth and nginx auth_request expect: it answers 200 with the
+// identity of the caller in X-User-* headers for the proxy to pass upstream,
+// or the error that denies the request. X-User-Roles carries the roles that
+// apply everywhere; roles granted only within the organization of X-Org-Id
+// come in X-User-Scoped-Roles. The access token comes from the
+// Authorization header, else from the token cookie.
+func (h *AuthHandler) Forward(c echo.Context) error {
+	// Прокси не должен кешировать решение
+	c.Response().Header().Set("Cache-Control", "no-store")
+
+	token := ""
+	if authHeader := c.Request().Header.Get("Authorization"); authHeader != "" {
+		token = strings.TrimPrefix(authHeader, "Bearer ")
+	} else if cookie, err := c.Cookie(h.tokenCookie); err == nil && h.tokenCookie != "" {
+		token = cookie.Value
+	}
+	if token == "" {
+		c.Response().Header().Set(echo.HeaderWWWAuthenticate, "Bearer")
+		return c.JSON(http.StatusUnauthorized, response.ErrorResponse{
+			Error:   "MISSING_TOKEN",
+			Message: "Authentication required",
+			Code:    http.StatusUnauthorized,
+		})
+	}
+
+	tenant := auth.ParseTenant(c.Request().Header.Get(auth.TenantHeader))
+	result, err := h.authService.VerifyToken(c.Request().Context(), token, tenant)
+	if err != nil {
+		if appErr, ok := err.(*errors.AppError); ok {
+			if appErr.StatusCode == http.StatusUnauthorized {
+				c.Response().Header().Set(echo.HeaderWWWAuthenticate, `Bearer error="invalid_token"`)
+			}
+			return c.JSON(appErr.StatusCode, response.ErrorResponse{
+				Error:   appErr.Code,
+				Message: appErr.Message,
+				Code:    appErr.StatusCode,
+				Details: appErr.Details,
+			})
+		}
+		return c.JSON(http.StatusInternalServerError, response.ErrorResponse{
+			Error:   "INTERNAL_ERROR",
+			Message: "Internal server error",
+			Code:    http.StatusInternalServerError,
+		})
+	}
+
+	header := c.Response().Header()
+	header.Set("X-User-Id", result.UserID)
+	header.Set("X-User-Email", result.Email)
+	header.Set("X-User-Name", result.Username)
+	header.Set("X-User-Roles", strings.Join(result.Roles, ","))
+	if result.OrgID != "" {
+		header.Set("X-Org-Id", result.OrgID)
+		// Роли организации действуют только вместе с X-Org-Id
+		if len(result.ScopedRoles) > 0 {
+			header.Set("X-User-Scoped-Roles", strings.Join(result.ScopedRoles, ","))
+		}
+	}
+
+	return c.NoContent(http.StatusOK)
+}
+
 func (h *AuthHandler) ChangePassword(c echo.Context) error {
 	userID := c.Get("user_id").(string)
 
@@ -269,3 +400,124 @@ func (h *AuthHandler) ChangePassword(c echo.Context) error {
 		Message: "Password changed successfully",
 	})
 }
+
+func (h *AuthHandler) ResetPassword(c echo.Context) error {
+	var req request.ResetPasswordRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, response.ErrorResponse{
+			Error:   "INVALID_REQUEST",
+			Message: "Invalid request format",
+			Code:    http.StatusBadRequest,
+		})
+	}
+
+	if err := request.ValidateStruct(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, response.ErrorResponse{
+			Error:   "VALIDATION_ERROR",
+			Message: err.Error(),
+			Code:    http.StatusBadRequest,
+		})
+	}
+
+	if err := h.authService.ResetPassword(c.Request().Context(), &req); err != nil {
+		if appErr, ok := err.(*errors.AppError); ok {
+			return c.JSON(appErr.StatusCode, response.ErrorResponse{
+				Error:   appErr.Code,
+				Message: appErr.Message,
+				Code:    appErr.StatusCode,
+				Details: appErr.Details,
+			})
+		}
+		return c.JSON(http.StatusInternalServerError, response.ErrorResponse{
+			Error:   "INTERNAL_ERROR",
+			Message: "Internal server error",
+			Code:    http.StatusInternalServerError,
+		})
+	}
+
+	// The same answer is given whether or not the email is registered.
+	return c.JSON(http.StatusAccepted, response.SuccessResponse{
+		Message: "If the account exists, a password reset email has been sent",
+	})
+}
+
+func (h *AuthHandler) ConfirmResetPassword(c echo.Context) error {
+	var req request.ConfirmResetPasswordRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, response.ErrorResponse{
+			Error:   "INVALID_REQUEST",
+			Message: "Invalid request format",
+			Code:    http.StatusBadRequest,
+		})
+	}
+
+	if err := request.ValidateStruct(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, response.ErrorResponse{
+			Error:   "VALIDATION_ERROR",
+			Message: err.Error(),
+			Code:    http.StatusBadRequest,
+		})
+	}
+
+	if err := h.authService.ConfirmResetPassword(c.Request().Context(), &req); err != nil {
+		if appErr, ok := err.(*errors.AppError); ok {
+			return c.JSON(appErr.StatusCode, response.ErrorResponse{
+				Error:   appErr.Code,
+				Message: appErr.Message,
+				Code:    appErr.StatusCode,
+				Details: appErr.Details,
+			})
+		}
+		return c.JSON(http.StatusInternalServerError, response.ErrorResponse{
+			Error:   "INTERNAL_ERROR",
+			Message: "Internal server error",
+			Code:    http.StatusInternalServerError,
+		})
+	}
+
+	return c.JSON(http.StatusOK, response.SuccessResponse{
+		Message: "Password reset successfully",
+	})
+}
+
+func (h *AuthHandler) SwitchOrganization(c echo.Context) error {
+	userID := c.Get("user_id").(string)
+
+	var req request.SwitchOrganizationRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, response.ErrorResponse{
+			Error:   "INVALID_REQUEST",
+			Message: "Invalid request format",
+			Code:    http.StatusBadRequest,
+		})
+	}
+
+	req.UserID = userID
+
+	if err := request.ValidateStruct(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, response.ErrorResponse{
+			Error:   "VALIDATION_ERROR",
+			Message: err.Error(),
+			Code:    http.StatusBadRequest,
+		})
+	}
+
+	result, err := h.authService.SwitchOrganization(c.Request().Context(), &req)
+	if err != nil {
+		if appErr, ok := err.(*errors.AppError); ok {
+			return c.JSON(appErr.StatusCode, response.ErrorResponse{
+				Error:   appErr.Code,
+				Message: appErr.Message,
+				Code:    appErr.StatusCode,
+				Details: appErr.Details,
+			})
+		}
+		return c.JSON(http.StatusInternalServerError, response.ErrorResponse{
+			Error:   "INTERNAL_ERROR",
+			Message: "Internal server error",
+			Code:    http.StatusInternalServerError,
+		})
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
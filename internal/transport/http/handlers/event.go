@@ -0,0 +1,66 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/vagonaizer/authenitfication-service/internal/domain/services"
+	"github.com/vagonaizer/authenitfication-service/internal/dto/request"
+	"github.com/vagonaizer/authenitfication-service/internal/dto/response"
+	"github.com/vagonaizer/authenitfication-service/pkg/errors"
+	"github.com/vagonaizer/authenitfication-service/pkg/logger"
+)
+
+type EventHandler struct {
+	eventReplayService services.EventReplayService
+	logger             *logger.Logger
+}
+
+func NewEventHandler(eventReplayService services.EventReplayService, logger *logger.Logger) *EventHandler {
+	return &EventHandler{
+		eventReplayService: eventReplayService,
+		logger:             logger,
+	}
+}
+
+func (h *EventHandler) ReplayEvents(c echo.Context) error {
+	var req request.ReplayEventsRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, response.ErrorResponse{
+			Error:   "INVALID_REQUEST",
+			Message: "Invalid request format",
+			Code:    http.StatusBadRequest,
+		})
+	}
+
+	if err := request.ValidateStruct(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, response.ErrorResponse{
+			Error:   "VALIDATION_ERROR",
+			Message: err.Error(),
+			Code:    http.StatusBadRequest,
+		})
+	}
+
+	result, err := h.eventReplayService.ReplayEvents(c.Request().Context(), &req)
+	if err != nil {
+		return h.handleError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
+
+func (h *EventHandler) handleError(c echo.Context, err error) error {
+	if appErr, ok := err.(*errors.AppError); ok {
+		return c.JSON(appErr.StatusCode, response.ErrorResponse{
+			Error:   appErr.Code,
+			Message: appErr.Message,
+			Code:    appErr.StatusCode,
+			Details: appErr.Details,
+		})
+	}
+	return c.JSON(http.StatusInternalServerError, response.ErrorResponse{
+		Error:   "INTERNAL_ERROR",
+		Message: "Internal server error",
+		Code:    http.StatusInternalServerError,
+	})
+}
@@ -0,0 +1,71 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/google/uuid"
+	graphqlgo "github.com/graph-gophers/graphql-go"
+	"github.com/labstack/echo/v4"
+	"github.com/vagonaizer/authenitfication-service/internal/dto/request"
+	"github.com/vagonaizer/authenitfication-service/internal/dto/response"
+	"github.com/vagonaizer/authenitfication-service/internal/transport/graphql"
+	"github.com/vagonaizer/authenitfication-service/pkg/auth"
+	"github.com/vagonaizer/authenitfication-service/pkg/logger"
+)
+
+// GraphQLHandler executes GraphQL queries for authenticated callers. Errors
+// of fields are reported in the response body with status 200, as GraphQL
+// clients expect.
+type GraphQLHandler struct {
+	schema *graphqlgo.Schema
+	logger *logger.Logger
+}
+
+func NewGraphQLHandler(schema *graphqlgo.Schema, logger *logger.Logger) *GraphQLHandler {
+	return &GraphQLHandler{
+		schema: schema,
+		logger: logger,
+	}
+}
+
+func (h *GraphQLHandler) Query(c echo.Context) error {
+	var req request.GraphQLRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, response.ErrorResponse{
+			Error:   "INVALID_REQUEST",
+			Message: "Invalid request format",
+			Code:    http.StatusBadRequest,
+		})
+	}
+
+	if err := request.ValidateStruct(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, response.ErrorResponse{
+			Error:   "VALIDATION_ERROR",
+			Message: err.Error(),
+			Code:    http.StatusBadRequest,
+		})
+	}
+
+	userIDStr, _ := c.Get("user_id").(string)
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, response.ErrorResponse{
+			Error:   "UNAUTHORIZED",
+			Message: "Invalid user ID",
+			Code:    http.StatusUnauthorized,
+		})
+	}
+
+	roles, _ := c.Get("roles").([]string)
+	scopedRoles, _ := c.Get("scoped_roles").([]string)
+	orgID, _ := c.Get("org_id").(string)
+
+	ctx := graphql.WithViewer(c.Request().Context(), &graphql.Viewer{
+		UserID: userID,
+		Roles:  auth.WithoutRoles(roles, scopedRoles),
+		OrgID:  orgID,
+	})
+
+	result := h.schema.Exec(ctx, req.Query, req.OperationName, req.Variables)
+	return c.JSON(http.StatusOK, result)
+}
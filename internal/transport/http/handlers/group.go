@@ -0,0 +1,284 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/vagonaizer/authenitfication-service/internal/domain/services"
+	"github.com/vagonaizer/authenitfication-service/internal/dto/request"
+	"github.com/vagonaizer/authenitfication-service/internal/dto/response"
+	"github.com/vagonaizer/authenitfication-service/pkg/errors"
+	"github.com/vagonaizer/authenitfication-service/pkg/logger"
+)
+
+type GroupHandler struct {
+	groupService services.GroupService
+	logger       *logger.Logger
+}
+
+func NewGroupHandler(groupService services.GroupService, logger *logger.Logger) *GroupHandler {
+	return &GroupHandler{
+		groupService: groupService,
+		logger:       logger,
+	}
+}
+
+func (h *GroupHandler) CreateGroup(c echo.Context) error {
+	var req request.CreateGroupRequest
+	if err := c.Bind(&req); err != nil {
+		return h.invalidRequest(c)
+	}
+
+	if err := request.ValidateStruct(&req); err != nil {
+		return h.validationError(c, err)
+	}
+
+	result, err := h.groupService.CreateGroup(c.Request().Context(), &req)
+	if err != nil {
+		return h.handleError(c, err)
+	}
+
+	return c.JSON(http.StatusCreated, result)
+}
+
+func (h *GroupHandler) ListGroups(c echo.Context) error {
+	result, err := h.groupService.ListGroups(c.Request().Context())
+	if err != nil {
+		return h.handleError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
+
+func (h *GroupHandler) GetGroup(c echo.Context) error {
+	groupID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return h.invalidGroupID(c)
+	}
+
+	result, err := h.groupService.GetGroup(c.Request().Context(), groupID)
+	if err != nil {
+		return h.handleError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
+
+func (h *GroupHandler) UpdateGroup(c echo.Context) error {
+	groupID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return h.invalidGroupID(c)
+	}
+
+	var req request.UpdateGroupRequest
+	if err := c.Bind(&req); err != nil {
+		return h.invalidRequest(c)
+	}
+
+	req.GroupID = groupID
+
+	if err := request.ValidateStruct(&req); err != nil {
+		return h.validationError(c, err)
+	}
+
+	result, err := h.groupService.UpdateGroup(c.Request().Context(), &req)
+	if err != nil {
+		return h.handleError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
+
+func (h *GroupHandler) DeleteGroup(c echo.Context) error {
+	groupID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return h.invalidGroupID(c)
+	}
+
+	if err := h.groupService.DeleteGroup(c.Request().Context(), groupID); err != nil {
+		return h.handleError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, response.SuccessResponse{
+		Message: "Group deleted successfully",
+	})
+}
+
+func (h *GroupHandler) ListMembers(c echo.Context) error {
+	groupID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return h.invalidGroupID(c)
+	}
+
+	result, err := h.groupService.ListMembers(c.Request().Context(), groupID)
+	if err != nil {
+		return h.handleError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
+
+func (h *GroupHandler) AddMember(c echo.Context) error {
+	groupID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return h.invalidGroupID(c)
+	}
+
+	var req request.GroupMemberRequest
+	if err := c.Bind(&req); err != nil {
+		return h.invalidRequest(c)
+	}
+
+	req.GroupID = groupID
+
+	if err := request.ValidateStruct(&req); err != nil {
+		return h.validationError(c, err)
+	}
+
+	if err := h.groupService.AddMember(c.Request().Context(), &req); err != nil {
+		return h.handleError(c, err)
+	}
+
+	return c.JSON(http.StatusCreated, response.SuccessResponse{
+		Message: "Member added successfully",
+	})
+}
+
+func (h *GroupHandler) RemoveMember(c echo.Context) error {
+	groupID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return h.invalidGroupID(c)
+	}
+
+	userID, err := uuid.Parse(c.Param("user_id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, response.ErrorResponse{
+			Error:   "INVALID_USER_ID",
+			Message: "Invalid user ID format",
+			Code:    http.StatusBadRequest,
+		})
+	}
+
+	req := &request.GroupMemberRequest{
+		GroupID: groupID,
+		UserID:  userID,
+	}
+
+	if err := h.groupService.RemoveMember(c.Request().Context(), req); err != nil {
+		return h.handleError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, response.SuccessResponse{
+		Message: "Member removed successfully",
+	})
+}
+
+func (h *GroupHandler) GetGroupRoles(c echo.Context) error {
+	groupID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return h.invalidGroupID(c)
+	}
+
+	result, err := h.groupService.GetGroupRoles(c.Request().Context(), groupID)
+	if err != nil {
+		return h.handleError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
+
+func (h *GroupHandler) AssignRole(c echo.Context) error {
+	groupID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return h.invalidGroupID(c)
+	}
+
+	var req request.GroupRoleRequest
+	if err := c.Bind(&req); err != nil {
+		return h.invalidRequest(c)
+	}
+
+	req.GroupID = groupID
+
+	if err := request.ValidateStruct(&req); err != nil {
+		return h.validationError(c, err)
+	}
+
+	if err := h.groupService.AssignRole(c.Request().Context(), &req); err != nil {
+		return h.handleError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, response.SuccessResponse{
+		Message: "Role assigned successfully",
+	})
+}
+
+func (h *GroupHandler) RemoveRole(c echo.Context) error {
+	groupID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return h.invalidGroupID(c)
+	}
+
+	roleID, err := uuid.Parse(c.Param("role_id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, response.ErrorResponse{
+			Error:   "INVALID_ROLE_ID",
+			Message: "Invalid role ID format",
+			Code:    http.StatusBadRequest,
+		})
+	}
+
+	req := &request.GroupRoleRequest{
+		GroupID: groupID,
+		RoleID:  roleID,
+	}
+
+	if err := h.groupService.RemoveRole(c.Request().Context(), req); err != nil {
+		return h.handleError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, response.SuccessResponse{
+		Message: "Role removed successfully",
+	})
+}
+
+func (h *GroupHandler) invalidGroupID(c echo.Context) error {
+	return c.JSON(http.StatusBadRequest, response.ErrorResponse{
+		Error:   "INVALID_GROUP_ID",
+		Message: "Invalid group ID format",
+		Code:    http.StatusBadRequest,
+	})
+}
+
+func (h *GroupHandler) invalidRequest(c echo.Context) error {
+	return c.JSON(http.StatusBadRequest, response.ErrorResponse{
+		Error:   "INVALID_REQUEST",
+		Message: "Invalid request format",
+		Code:    http.StatusBadRequest,
+	})
+}
+
+func (h *GroupHandler) validationError(c echo.Context, err error) error {
+	return c.JSON(http.StatusBadRequest, response.ErrorResponse{
+		Error:   "VALIDATION_ERROR",
+		Message: err.Error(),
+		Code:    http.StatusBadRequest,
+	})
+}
+
+func (h *GroupHandler) handleError(c echo.Context, err error) error {
+	if appErr, ok := err.(*errors.AppError); ok {
+		return c.JSON(appErr.StatusCode, response.ErrorResponse{
+			Error:   appErr.Code,
+			Message: appErr.Message,
+			Code:    appErr.StatusCode,
+			Details: appErr.Details,
+		})
+	}
+	return c.JSON(http.StatusInternalServerError, response.ErrorResponse{
+		Error:   "INTERNAL_ERROR",
+		Message: "Internal server error",
+		Code:    http.StatusInternalServerError,
+	})
+}
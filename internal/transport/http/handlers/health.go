@@ -1,75 +1,174 @@
 package handlers
 
 import (
+	"context"
+	"errors"
 	"net/http"
+	"runtime"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/labstack/echo/v4"
 	"github.com/vagonaizer/authenitfication-service/internal/dto/response"
-	"github.com/vagonaizer/authenitfication-service/internal/infrastructure/database/postgres"
-	"github.com/vagonaizer/authenitfication-service/internal/infrastructure/database/redis"
 	"github.com/vagonaizer/authenitfication-service/pkg/logger"
 )
 
+var (
+	errDraining  = errors.New("replica is draining")
+	errUnhealthy = errors.New("required dependency is down")
+)
+
+// healthCheckTimeout bounds each dependency check, so a hanging dependency
+// is reported as unhealthy instead of timing out the probe.
+const healthCheckTimeout = 2 * time.Second
+
+// HealthCheck is a dependency checked by /health and /ready. The service is
+// not ready while a Required dependency fails; an optional one, such as the
+// message broker events are published to on a best effort basis, only
+// degrades it.
+type HealthCheck struct {
+	Name     string
+	Required bool
+	Check    func(ctx context.Context) error
+}
+
 type HealthHandler struct {
-	db     *postgres.DB
-	redis  *redis.Client
-	logger *logger.Logger
+	checks    []HealthCheck
+	build     response.BuildInfo
+	startedAt time.Time
+	draining  atomic.Bool
+	logger    *logger.Logger
 }
 
-func NewHealthHandler(db *postgres.DB, redis *redis.Client, logger *logger.Logger) *HealthHandler {
+func NewHealthHandler(checks []HealthCheck, version, buildTime string, logger *logger.Logger) *HealthHandler {
 	return &HealthHandler{
-		db:     db,
-		redis:  redis,
-		logger: logger,
+		checks: checks,
+		build: response.BuildInfo{
+			Version:   version,
+			BuildTime: buildTime,
+			GoVersion: runtime.Version(),
+		},
+		startedAt: time.Now(),
+		logger:    logger,
 	}
 }
 
+// Health checks every dependency and reports the build. It answers 503 when
+// a required dependency is down.
 func (h *HealthHandler) Health(c echo.Context) error {
-	services := make(map[string]string)
+	result, statusCode := h.check(c.Request().Context(), h.checks)
 
-	if err := h.db.Health(); err != nil {
-		services["database"] = "unhealthy"
-		h.logger.WithError(err).Error("database health check failed")
-	} else {
-		services["database"] = "healthy"
-	}
+	build := h.build
+	build.Uptime = time.Since(h.startedAt).Round(time.Second).String()
+	result.Build = &build
 
-	if err := h.redis.Health(); err != nil {
-		services["redis"] = "unhealthy"
-		h.logger.WithError(err).Error("redis health check failed")
-	} else {
-		services["redis"] = "healthy"
-	}
+	return c.JSON(statusCode, result)
+}
 
-	status := "healthy"
-	statusCode := http.StatusOK
+// Drain makes Ready fail from now on, so load balancers take the replica
+// out of rotation before it shuts down.
+func (h *HealthHandler) Drain() {
+	h.draining.Store(true)
+}
 
-	for _, serviceStatus := range services {
-		if serviceStatus == "unhealthy" {
-			status = "unhealthy"
-			statusCode = http.StatusServiceUnavailable
-			break
-		}
+// Ready checks the required dependencies only: a replica that cannot reach
+// them cannot serve requests and should get no traffic. It fails once the
+// replica drains.
+func (h *HealthHandler) Ready(c echo.Context) error {
+	if h.draining.Load() {
+		return c.JSON(http.StatusServiceUnavailable, response.HealthResponse{
+			Status:    "draining",
+			Timestamp: time.Now().Format(time.RFC3339),
+			Services:  map[string]response.DependencyHealth{},
+		})
 	}
 
-	healthResponse := response.HealthResponse{
-		Status:    status,
-		Timestamp: time.Now().Format(time.RFC3339),
-		Services:  services,
-	}
+	result, statusCode := h.check(c.Request().Context(), h.required())
+	return c.JSON(statusCode, result)
+}
 
-	return c.JSON(statusCode, healthResponse)
+// CheckReady fails when Ready would: while the replica drains or when a
+// required dependency is down. The gRPC health service reports it.
+func (h *HealthHandler) CheckReady(ctx context.Context) error {
+	if h.draining.Load() {
+		return errDraining
+	}
+	if result, _ := h.check(ctx, h.required()); result.Status == "unhealthy" {
+		return errUnhealthy
+	}
+	return nil
 }
 
-func (h *HealthHandler) Ready(c echo.Context) error {
-	return c.JSON(http.StatusOK, map[string]string{
-		"status": "ready",
-	})
+func (h *HealthHandler) required() []HealthCheck {
+	var required []HealthCheck
+	for _, check := range h.checks {
+		if check.Required {
+			required = append(required, check)
+		}
+	}
+	return required
 }
 
+// Live reports that the process serves requests. It checks no dependency,
+// so an outage of one does not get every replica restarted.
 func (h *HealthHandler) Live(c echo.Context) error {
 	return c.JSON(http.StatusOK, map[string]string{
 		"status": "alive",
 	})
 }
+
+// check runs checks concurrently and returns their results with the status
+// code of the response.
+func (h *HealthHandler) check(ctx context.Context, checks []HealthCheck) (response.HealthResponse, int) {
+	results := make([]response.DependencyHealth, len(checks))
+
+	var wg sync.WaitGroup
+	for i, check := range checks {
+		wg.Add(1)
+		go func(i int, check HealthCheck) {
+			defer wg.Done()
+
+			ctx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+			defer cancel()
+
+			start := time.Now()
+			err := check.Check(ctx)
+			latency := time.Since(start)
+
+			status := "healthy"
+			if err != nil {
+				status = "unhealthy"
+				h.logger.WithContext(ctx).WithError(err).WithField("dependency", check.Name).Error("health check failed")
+			}
+			results[i] = response.DependencyHealth{
+				Status:    status,
+				Required:  check.Required,
+				LatencyMs: float64(latency.Microseconds()) / 1000,
+			}
+		}(i, check)
+	}
+	wg.Wait()
+
+	result := response.HealthResponse{
+		Status:    "healthy",
+		Timestamp: time.Now().Format(time.RFC3339),
+		Services:  make(map[string]response.DependencyHealth, len(checks)),
+	}
+	statusCode := http.StatusOK
+
+	for i, check := range checks {
+		result.Services[check.Name] = results[i]
+		if results[i].Status == "healthy" {
+			continue
+		}
+		if check.Required {
+			result.Status = "unhealthy"
+			statusCode = http.StatusServiceUnavailable
+		} else if result.Status == "healthy" {
+			result.Status = "degraded"
+		}
+	}
+
+	return result, statusCode
+}
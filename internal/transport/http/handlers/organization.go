@@ -0,0 +1,428 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/vagonaizer/authenitfication-service/internal/domain/services"
+	"github.com/vagonaizer/authenitfication-service/internal/dto/request"
+	"github.com/vagonaizer/authenitfication-service/internal/dto/response"
+	"github.com/vagonaizer/authenitfication-service/pkg/errors"
+	"github.com/vagonaizer/authenitfication-service/pkg/logger"
+)
+
+type OrganizationHandler struct {
+	orgService services.OrganizationService
+	logger     *logger.Logger
+}
+
+func NewOrganizationHandler(orgService services.OrganizationService, logger *logger.Logger) *OrganizationHandler {
+	return &OrganizationHandler{
+		orgService: orgService,
+		logger:     logger,
+	}
+}
+
+func (h *OrganizationHandler) CreateOrganization(c echo.Context) error {
+	userID, err := uuid.Parse(c.Get("user_id").(string))
+	if err != nil {
+		return h.invalidUserID(c)
+	}
+
+	var req request.CreateOrganizationRequest
+	if err := c.Bind(&req); err != nil {
+		return h.invalidRequest(c)
+	}
+
+	req.OwnerID = userID
+
+	if err := request.ValidateStruct(&req); err != nil {
+		return h.validationError(c, err)
+	}
+
+	result, err := h.orgService.CreateOrganization(c.Request().Context(), &req)
+	if err != nil {
+		return h.handleError(c, err)
+	}
+
+	return c.JSON(http.StatusCreated, result)
+}
+
+func (h *OrganizationHandler) ListOrganizations(c echo.Context) error {
+	userID, err := uuid.Parse(c.Get("user_id").(string))
+	if err != nil {
+		return h.invalidUserID(c)
+	}
+
+	result, err := h.orgService.ListUserOrganizations(c.Request().Context(), userID)
+	if err != nil {
+		return h.handleError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
+
+func (h *OrganizationHandler) GetOrganization(c echo.Context) error {
+	userID, err := uuid.Parse(c.Get("user_id").(string))
+	if err != nil {
+		return h.invalidUserID(c)
+	}
+
+	orgID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return h.invalidOrganizationID(c)
+	}
+
+	result, err := h.orgService.GetOrganization(c.Request().Context(), userID, orgID)
+	if err != nil {
+		return h.handleError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
+
+func (h *OrganizationHandler) UpdateOrganization(c echo.Context) error {
+	userID, err := uuid.Parse(c.Get("user_id").(string))
+	if err != nil {
+		return h.invalidUserID(c)
+	}
+
+	orgID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return h.invalidOrganizationID(c)
+	}
+
+	var req request.UpdateOrganizationRequest
+	if err := c.Bind(&req); err != nil {
+		return h.invalidRequest(c)
+	}
+
+	req.ActorID = userID
+	req.OrganizationID = orgID
+
+	if err := request.ValidateStruct(&req); err != nil {
+		return h.validationError(c, err)
+	}
+
+	result, err := h.orgService.UpdateOrganization(c.Request().Context(), &req)
+	if err != nil {
+		return h.handleError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
+
+func (h *OrganizationHandler) DeleteOrganization(c echo.Context) error {
+	userID, err := uuid.Parse(c.Get("user_id").(string))
+	if err != nil {
+		return h.invalidUserID(c)
+	}
+
+	orgID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return h.invalidOrganizationID(c)
+	}
+
+	if err := h.orgService.DeleteOrganization(c.Request().Context(), userID, orgID); err != nil {
+		return h.handleError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, response.SuccessResponse{
+		Message: "Organization deleted successfully",
+	})
+}
+
+func (h *OrganizationHandler) ListMembers(c echo.Context) error {
+	userID, err := uuid.Parse(c.Get("user_id").(string))
+	if err != nil {
+		return h.invalidUserID(c)
+	}
+
+	orgID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return h.invalidOrganizationID(c)
+	}
+
+	result, err := h.orgService.ListMembers(c.Request().Context(), userID, orgID)
+	if err != nil {
+		return h.handleError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
+
+func (h *OrganizationHandler) AddMember(c echo.Context) error {
+	userID, err := uuid.Parse(c.Get("user_id").(string))
+	if err != nil {
+		return h.invalidUserID(c)
+	}
+
+	orgID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return h.invalidOrganizationID(c)
+	}
+
+	var req request.AddOrganizationMemberRequest
+	if err := c.Bind(&req); err != nil {
+		return h.invalidRequest(c)
+	}
+
+	req.ActorID = userID
+	req.OrganizationID = orgID
+
+	if err := request.ValidateStruct(&req); err != nil {
+		return h.validationError(c, err)
+	}
+
+	if err := h.orgService.AddMember(c.Request().Context(), &req); err != nil {
+		return h.handleError(c, err)
+	}
+
+	return c.JSON(http.StatusCreated, response.SuccessResponse{
+		Message: "Member added successfully",
+	})
+}
+
+func (h *OrganizationHandler) UpdateMember(c echo.Context) error {
+	userID, err := uuid.Parse(c.Get("user_id").(string))
+	if err != nil {
+		return h.invalidUserID(c)
+	}
+
+	orgID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return h.invalidOrganizationID(c)
+	}
+
+	memberID, err := uuid.Parse(c.Param("user_id"))
+	if err != nil {
+		return h.invalidUserID(c)
+	}
+
+	var req request.UpdateOrganizationMemberRequest
+	if err := c.Bind(&req); err != nil {
+		return h.invalidRequest(c)
+	}
+
+	req.ActorID = userID
+	req.OrganizationID = orgID
+	req.UserID = memberID
+
+	if err := request.ValidateStruct(&req); err != nil {
+		return h.validationError(c, err)
+	}
+
+	if err := h.orgService.UpdateMemberRole(c.Request().Context(), &req); err != nil {
+		return h.handleError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, response.SuccessResponse{
+		Message: "Member role updated successfully",
+	})
+}
+
+func (h *OrganizationHandler) RemoveMember(c echo.Context) error {
+	userID, err := uuid.Parse(c.Get("user_id").(string))
+	if err != nil {
+		return h.invalidUserID(c)
+	}
+
+	orgID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return h.invalidOrganizationID(c)
+	}
+
+	memberID, err := uuid.Parse(c.Param("user_id"))
+	if err != nil {
+		return h.invalidUserID(c)
+	}
+
+	req := &request.RemoveOrganizationMemberRequest{
+		ActorID:        userID,
+		OrganizationID: orgID,
+		UserID:         memberID,
+	}
+
+	if err := h.orgService.RemoveMember(c.Request().Context(), req); err != nil {
+		return h.handleError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, response.SuccessResponse{
+		Message: "Member removed successfully",
+	})
+}
+
+func (h *OrganizationHandler) ListInvitations(c echo.Context) error {
+	userID, err := uuid.Parse(c.Get("user_id").(string))
+	if err != nil {
+		return h.invalidUserID(c)
+	}
+
+	orgID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return h.invalidOrganizationID(c)
+	}
+
+	result, err := h.orgService.ListInvitations(c.Request().Context(), userID, orgID)
+	if err != nil {
+		return h.handleError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
+
+func (h *OrganizationHandler) CreateInvitation(c echo.Context) error {
+	userID, err := uuid.Parse(c.Get("user_id").(string))
+	if err != nil {
+		return h.invalidUserID(c)
+	}
+
+	orgID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return h.invalidOrganizationID(c)
+	}
+
+	var req request.CreateInvitationRequest
+	if err := c.Bind(&req); err != nil {
+		return h.invalidRequest(c)
+	}
+
+	req.ActorID = userID
+	req.OrganizationID = orgID
+
+	if err := request.ValidateStruct(&req); err != nil {
+		return h.validationError(c, err)
+	}
+
+	result, err := h.orgService.CreateInvitation(c.Request().Context(), &req)
+	if err != nil {
+		return h.handleError(c, err)
+	}
+
+	return c.JSON(http.StatusCreated, result)
+}
+
+func (h *OrganizationHandler) RevokeInvitation(c echo.Context) error {
+	userID, err := uuid.Parse(c.Get("user_id").(string))
+	if err != nil {
+		return h.invalidUserID(c)
+	}
+
+	orgID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return h.invalidOrganizationID(c)
+	}
+
+	invitationID, err := uuid.Parse(c.Param("invitation_id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, response.ErrorResponse{
+			Error:   "INVALID_INVITATION_ID",
+			Message: "Invalid invitation ID format",
+			Code:    http.StatusBadRequest,
+		})
+	}
+
+	if err := h.orgService.RevokeInvitation(c.Request().Context(), userID, orgID, invitationID); err != nil {
+		return h.handleError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, response.SuccessResponse{
+		Message: "Invitation revoked successfully",
+	})
+}
+
+func (h *OrganizationHandler) AcceptInvitation(c echo.Context) error {
+	userID, err := uuid.Parse(c.Get("user_id").(string))
+	if err != nil {
+		return h.invalidUserID(c)
+	}
+
+	var req request.InvitationTokenRequest
+	if err := c.Bind(&req); err != nil {
+		return h.invalidRequest(c)
+	}
+
+	if err := request.ValidateStruct(&req); err != nil {
+		return h.validationError(c, err)
+	}
+
+	result, err := h.orgService.AcceptInvitation(c.Request().Context(), userID, req.Token)
+	if err != nil {
+		return h.handleError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
+
+func (h *OrganizationHandler) DeclineInvitation(c echo.Context) error {
+	userID, err := uuid.Parse(c.Get("user_id").(string))
+	if err != nil {
+		return h.invalidUserID(c)
+	}
+
+	var req request.InvitationTokenRequest
+	if err := c.Bind(&req); err != nil {
+		return h.invalidRequest(c)
+	}
+
+	if err := request.ValidateStruct(&req); err != nil {
+		return h.validationError(c, err)
+	}
+
+	if err := h.orgService.DeclineInvitation(c.Request().Context(), userID, req.Token); err != nil {
+		return h.handleError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, response.SuccessResponse{
+		Message: "Invitation declined successfully",
+	})
+}
+
+func (h *OrganizationHandler) invalidUserID(c echo.Context) error {
+	return c.JSON(http.StatusBadRequest, response.ErrorResponse{
+		Error:   "INVALID_USER_ID",
+		Message: "Invalid user ID format",
+		Code:    http.StatusBadRequest,
+	})
+}
+
+func (h *OrganizationHandler) invalidOrganizationID(c echo.Context) error {
+	return c.JSON(http.StatusBadRequest, response.ErrorResponse{
+		Error:   "INVALID_ORGANIZATION_ID",
+		Message: "Invalid organization ID format",
+		Code:    http.StatusBadRequest,
+	})
+}
+
+func (h *OrganizationHandler) invalidRequest(c echo.Context) error {
+	return c.JSON(http.StatusBadRequest, response.ErrorResponse{
+		Error:   "INVALID_REQUEST",
+		Message: "Invalid request format",
+		Code:    http.StatusBadRequest,
+	})
+}
+
+func (h *OrganizationHandler) validationError(c echo.Context, err error) error {
+	return c.JSON(http.StatusBadRequest, response.ErrorResponse{
+		Error:   "VALIDATION_ERROR",
+		Message: err.Error(),
+		Code:    http.StatusBadRequest,
+	})
+}
+
+func (h *OrganizationHandler) handleError(c echo.Context, err error) error {
+	if appErr, ok := err.(*errors.AppError); ok {
+		return c.JSON(appErr.StatusCode, response.ErrorResponse{
+			Error:   appErr.Code,
+			Message: appErr.Message,
+			Code:    appErr.StatusCode,
+			Details: appErr.Details,
+		})
+	}
+	return c.JSON(http.StatusInternalServerError, response.ErrorResponse{
+		Error:   "INTERNAL_ERROR",
+		Message: "Internal server error",
+		Code:    http.StatusInternalServerError,
+	})
+}
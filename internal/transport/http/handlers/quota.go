@@ -0,0 +1,106 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/vagonaizer/authenitfication-service/internal/domain/services"
+	"github.com/vagonaizer/authenitfication-service/internal/dto/request"
+	"github.com/vagonaizer/authenitfication-service/internal/dto/response"
+	"github.com/vagonaizer/authenitfication-service/pkg/errors"
+	"github.com/vagonaizer/authenitfication-service/pkg/logger"
+)
+
+type QuotaHandler struct {
+	quotaService services.QuotaService
+	logger       *logger.Logger
+}
+
+func NewQuotaHandler(quotaService services.QuotaService, logger *logger.Logger) *QuotaHandler {
+	return &QuotaHandler{
+		quotaService: quotaService,
+		logger:       logger,
+	}
+}
+
+func (h *QuotaHandler) GetQuota(c echo.Context) error {
+	orgID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return h.invalidOrganizationID(c)
+	}
+
+	result, err := h.quotaService.GetQuota(c.Request().Context(), orgID)
+	if err != nil {
+		return h.handleError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
+
+func (h *QuotaHandler) UpdateQuota(c echo.Context) error {
+	actorID, err := uuid.Parse(c.Get("user_id").(string))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, response.ErrorResponse{
+			Error:   "INVALID_USER_ID",
+			Message: "Invalid user ID format",
+			Code:    http.StatusBadRequest,
+		})
+	}
+
+	orgID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return h.invalidOrganizationID(c)
+	}
+
+	var req request.UpdateOrganizationQuotaRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, response.ErrorResponse{
+			Error:   "INVALID_REQUEST",
+			Message: "Invalid request format",
+			Code:    http.StatusBadRequest,
+		})
+	}
+
+	req.ActorID = actorID
+	req.OrganizationID = orgID
+
+	if err := request.ValidateStruct(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, response.ErrorResponse{
+			Error:   "VALIDATION_ERROR",
+			Message: err.Error(),
+			Code:    http.StatusBadRequest,
+		})
+	}
+
+	result, err := h.quotaService.UpdateQuota(c.Request().Context(), &req)
+	if err != nil {
+		return h.handleError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
+
+func (h *QuotaHandler) invalidOrganizationID(c echo.Context) error {
+	return c.JSON(http.StatusBadRequest, response.ErrorResponse{
+		Error:   "INVALID_ORGANIZATION_ID",
+		Message: "Invalid organization ID format",
+		Code:    http.StatusBadRequest,
+	})
+}
+
+func (h *QuotaHandler) handleError(c echo.Context, err error) error {
+	if appErr, ok := err.(*errors.AppError); ok {
+		return c.JSON(appErr.StatusCode, response.ErrorResponse{
+			Error:   appErr.Code,
+			Message: appErr.Message,
+			Code:    appErr.StatusCode,
+			Details: appErr.Details,
+		})
+	}
+	return c.JSON(http.StatusInternalServerError, response.ErrorResponse{
+		Error:   "INTERNAL_ERROR",
+		Message: "Internal server error",
+		Code:    http.StatusInternalServerError,
+	})
+}
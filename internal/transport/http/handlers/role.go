@@ -0,0 +1,225 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/vagonaizer/authenitfication-service/internal/domain/services"
+	"github.com/vagonaizer/authenitfication-service/internal/dto/request"
+	"github.com/vagonaizer/authenitfication-service/internal/dto/response"
+	"github.com/vagonaizer/authenitfication-service/pkg/errors"
+	"github.com/vagonaizer/authenitfication-service/pkg/logger"
+)
+
+type RoleHandler struct {
+	roleService services.RoleService
+	logger      *logger.Logger
+}
+
+func NewRoleHandler(roleService services.RoleService, logger *logger.Logger) *RoleHandler {
+	return &RoleHandler{
+		roleService: roleService,
+		logger:      logger,
+	}
+}
+
+func (h *RoleHandler) CreateRole(c echo.Context) error {
+	var req request.CreateRoleRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, response.ErrorResponse{
+			Error:   "INVALID_REQUEST",
+			Message: "Invalid request format",
+			Code:    http.StatusBadRequest,
+		})
+	}
+
+	if err := request.ValidateStruct(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, response.ErrorResponse{
+			Error:   "VALIDATION_ERROR",
+			Message: err.Error(),
+			Code:    http.StatusBadRequest,
+		})
+	}
+
+	result, err := h.roleService.CreateRole(c.Request().Context(), &req)
+	if err != nil {
+		return h.handleError(c, err)
+	}
+
+	return c.JSON(http.StatusCreated, result)
+}
+
+func (h *RoleHandler) GetRole(c echo.Context) error {
+	roleID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, response.ErrorResponse{
+			Error:   "INVALID_ROLE_ID",
+			Message: "Invalid role ID format",
+			Code:    http.StatusBadRequest,
+		})
+	}
+
+	result, err := h.roleService.GetRole(c.Request().Context(), roleID)
+	if err != nil {
+		return h.handleError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
+
+func (h *RoleHandler) ListRoles(c echo.Context) error {
+	result, err := h.roleService.ListRoles(c.Request().Context())
+	if err != nil {
+		return h.handleError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
+
+func (h *RoleHandler) UpdateRole(c echo.Context) error {
+	roleID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, response.ErrorResponse{
+			Error:   "INVALID_ROLE_ID",
+			Message: "Invalid role ID format",
+			Code:    http.StatusBadRequest,
+		})
+	}
+
+	var req request.UpdateRoleRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, response.ErrorResponse{
+			Error:   "INVALID_REQUEST",
+			Message: "Invalid request format",
+			Code:    http.StatusBadRequest,
+		})
+	}
+
+	req.RoleID = roleID
+
+	if err := request.ValidateStruct(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, response.ErrorResponse{
+			Error:   "VALIDATION_ERROR",
+			Message: err.Error(),
+			Code:    http.StatusBadRequest,
+		})
+	}
+
+	result, err := h.roleService.UpdateRole(c.Request().Context(), &req)
+	if err != nil {
+		return h.handleError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
+
+func (h *RoleHandler) DeleteRole(c echo.Context) error {
+	roleID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, response.ErrorResponse{
+			Error:   "INVALID_ROLE_ID",
+			Message: "Invalid role ID format",
+			Code:    http.StatusBadRequest,
+		})
+	}
+
+	if err := h.roleService.DeleteRole(c.Request().Context(), roleID); err != nil {
+		return h.handleError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, response.SuccessResponse{
+		Message: "Role deleted successfully",
+	})
+}
+
+func (h *RoleHandler) GetRolePermissions(c echo.Context) error {
+	roleID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, response.ErrorResponse{
+			Error:   "INVALID_ROLE_ID",
+			Message: "Invalid role ID format",
+			Code:    http.StatusBadRequest,
+		})
+	}
+
+	result, err := h.roleService.GetRolePermissions(c.Request().Context(), roleID)
+	if err != nil {
+		return h.handleError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
+
+func (h *RoleHandler) GrantPermission(c echo.Context) error {
+	roleID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, response.ErrorResponse{
+			Error:   "INVALID_ROLE_ID",
+			Message: "Invalid role ID format",
+			Code:    http.StatusBadRequest,
+		})
+	}
+
+	var req request.GrantPermissionRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, response.ErrorResponse{
+			Error:   "INVALID_REQUEST",
+			Message: "Invalid request format",
+			Code:    http.StatusBadRequest,
+		})
+	}
+
+	req.RoleID = roleID
+
+	if err := request.ValidateStruct(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, response.ErrorResponse{
+			Error:   "VALIDATION_ERROR",
+			Message: err.Error(),
+			Code:    http.StatusBadRequest,
+		})
+	}
+
+	if err := h.roleService.GrantPermission(c.Request().Context(), &req); err != nil {
+		return h.handleError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, response.SuccessResponse{
+		Message: "Permission granted successfully",
+	})
+}
+
+func (h *RoleHandler) RevokePermission(c echo.Context) error {
+	roleID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, response.ErrorResponse{
+			Error:   "INVALID_ROLE_ID",
+			Message: "Invalid role ID format",
+			Code:    http.StatusBadRequest,
+		})
+	}
+
+	if err := h.roleService.RevokePermission(c.Request().Context(), roleID, c.Param("permission")); err != nil {
+		return h.handleError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, response.SuccessResponse{
+		Message: "Permission revoked successfully",
+	})
+}
+
+func (h *RoleHandler) handleError(c echo.Context, err error) error {
+	if appErr, ok := err.(*errors.AppError); ok {
+		return c.JSON(appErr.StatusCode, response.ErrorResponse{
+			Error:   appErr.Code,
+			Message: appErr.Message,
+			Code:    appErr.StatusCode,
+			Details: appErr.Details,
+		})
+	}
+	return c.JSON(http.StatusInternalServerError, response.ErrorResponse{
+		Error:   "INTERNAL_ERROR",
+		Message: "Internal server error",
+		Code:    http.StatusInternalServerError,
+	})
+}
@@ -0,0 +1,210 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/vagonaizer/authenitfication-service/internal/domain/services"
+	"github.com/vagonaizer/authenitfication-service/internal/dto/request"
+	"github.com/vagonaizer/authenitfication-service/internal/dto/response"
+	"github.com/vagonaizer/authenitfication-service/pkg/errors"
+	"github.com/vagonaizer/authenitfication-service/pkg/logger"
+)
+
+type ServiceAccountHandler struct {
+	serviceAccountService services.ServiceAccountService
+	logger                *logger.Logger
+}
+
+func NewServiceAccountHandler(serviceAccountService services.ServiceAccountService, logger *logger.Logger) *ServiceAccountHandler {
+	return &ServiceAccountHandler{
+		serviceAccountService: serviceAccountService,
+		logger:                logger,
+	}
+}
+
+func (h *ServiceAccountHandler) CreateServiceAccount(c echo.Context) error {
+	actorID, err := uuid.Parse(c.Get("user_id").(string))
+	if err != nil {
+		return h.invalidUserID(c)
+	}
+
+	var req request.CreateServiceAccountRequest
+	if err := c.Bind(&req); err != nil {
+		return h.invalidRequest(c)
+	}
+
+	req.ActorID = actorID
+
+	if err := request.ValidateStruct(&req); err != nil {
+		return h.validationError(c, err)
+	}
+
+	result, err := h.serviceAccountService.CreateServiceAccount(c.Request().Context(), &req)
+	if err != nil {
+		return h.handleError(c, err)
+	}
+
+	return c.JSON(http.StatusCreated, result)
+}
+
+func (h *ServiceAccountHandler) ListServiceAccounts(c echo.Context) error {
+	page, _ := strconv.Atoi(c.QueryParam("page"))
+	pageSize, _ := strconv.Atoi(c.QueryParam("page_size"))
+
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 || pageSize > 100 {
+		pageSize = 20
+	}
+
+	result, err := h.serviceAccountService.ListServiceAccounts(c.Request().Context(), page, pageSize)
+	if err != nil {
+		return h.handleError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
+
+func (h *ServiceAccountHandler) GetServiceAccount(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return h.invalidServiceAccountID(c)
+	}
+
+	result, err := h.serviceAccountService.GetServiceAccount(c.Request().Context(), id)
+	if err != nil {
+		return h.handleError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
+
+func (h *ServiceAccountHandler) DeleteServiceAccount(c echo.Context) error {
+	actorID, err := uuid.Parse(c.Get("user_id").(string))
+	if err != nil {
+		return h.invalidUserID(c)
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return h.invalidServiceAccountID(c)
+	}
+
+	if err := h.serviceAccountService.DeleteServiceAccount(c.Request().Context(), actorID, id); err != nil {
+		return h.handleError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, response.SuccessResponse{
+		Message: "Service account deleted successfully",
+	})
+}
+
+func (h *ServiceAccountHandler) CreateKey(c echo.Context) error {
+	actorID, err := uuid.Parse(c.Get("user_id").(string))
+	if err != nil {
+		return h.invalidUserID(c)
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return h.invalidServiceAccountID(c)
+	}
+
+	result, err := h.serviceAccountService.CreateKey(c.Request().Context(), actorID, id)
+	if err != nil {
+		return h.handleError(c, err)
+	}
+
+	return c.JSON(http.StatusCreated, result)
+}
+
+func (h *ServiceAccountHandler) RotateKey(c echo.Context) error {
+	actorID, err := uuid.Parse(c.Get("user_id").(string))
+	if err != nil {
+		return h.invalidUserID(c)
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return h.invalidServiceAccountID(c)
+	}
+
+	result, err := h.serviceAccountService.RotateKey(c.Request().Context(), actorID, id)
+	if err != nil {
+		return h.handleError(c, err)
+	}
+
+	return c.JSON(http.StatusCreated, result)
+}
+
+func (h *ServiceAccountHandler) RevokeKey(c echo.Context) error {
+	actorID, err := uuid.Parse(c.Get("user_id").(string))
+	if err != nil {
+		return h.invalidUserID(c)
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return h.invalidServiceAccountID(c)
+	}
+
+	if err := h.serviceAccountService.RevokeKey(c.Request().Context(), actorID, id, c.Param("key_id")); err != nil {
+		return h.handleError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, response.SuccessResponse{
+		Message: "Key revoked successfully",
+	})
+}
+
+func (h *ServiceAccountHandler) invalidUserID(c echo.Context) error {
+	return c.JSON(http.StatusBadRequest, response.ErrorResponse{
+		Error:   "INVALID_USER_ID",
+		Message: "Invalid user ID format",
+		Code:    http.StatusBadRequest,
+	})
+}
+
+func (h *ServiceAccountHandler) invalidServiceAccountID(c echo.Context) error {
+	return c.JSON(http.StatusBadRequest, response.ErrorResponse{
+		Error:   "INVALID_SERVICE_ACCOUNT_ID",
+		Message: "Invalid service account ID format",
+		Code:    http.StatusBadRequest,
+	})
+}
+
+func (h *ServiceAccountHandler) invalidRequest(c echo.Context) error {
+	return c.JSON(http.StatusBadRequest, response.ErrorResponse{
+		Error:   "INVALID_REQUEST",
+		Message: "Invalid request format",
+		Code:    http.StatusBadRequest,
+	})
+}
+
+func (h *ServiceAccountHandler) validationError(c echo.Context, err error) error {
+	return c.JSON(http.StatusBadRequest, response.ErrorResponse{
+		Error:   "VALIDATION_ERROR",
+		Message: err.Error(),
+		Code:    http.StatusBadRequest,
+	})
+}
+
+func (h *ServiceAccountHandler) handleError(c echo.Context, err error) error {
+	if appErr, ok := err.(*errors.AppError); ok {
+		return c.JSON(appErr.StatusCode, response.ErrorResponse{
+			Error:   appErr.Code,
+			Message: appErr.Message,
+			Code:    appErr.StatusCode,
+			Details: appErr.Details,
+		})
+	}
+	return c.JSON(http.StatusInternalServerError, response.ErrorResponse{
+		Error:   "INTERNAL_ERROR",
+		Message: "Internal server error",
+		Code:    http.StatusInternalServerError,
+	})
+}
@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"io"
 	"net/http"
 	"strconv"
 
@@ -9,6 +10,7 @@ import (
 	"github.com/vagonaizer/authenitfication-service/internal/domain/services"
 	"github.com/vagonaizer/authenitfication-service/internal/dto/request"
 	"github.com/vagonaizer/authenitfication-service/internal/dto/response"
+	"github.com/vagonaizer/authenitfication-service/pkg/auth"
 	"github.com/vagonaizer/authenitfication-service/pkg/errors"
 	"github.com/vagonaizer/authenitfication-service/pkg/logger"
 )
@@ -25,6 +27,13 @@ func NewUserHandler(userService services.UserService, logger *logger.Logger) *Us
 	}
 }
 
+const (
+	headerETag    = "ETag"
+	headerIfMatch = "If-Match"
+)
+
+// GetProfile returns the caller's profile with an ETag to send back in the
+// If-Match header of UpdateProfile.
 func (h *UserHandler) GetProfile(c echo.Context) error {
 	userIDStr := c.Get("user_id").(string)
 	userID, err := uuid.Parse(userIDStr)
@@ -53,9 +62,13 @@ func (h *UserHandler) GetProfile(c echo.Context) error {
 		})
 	}
 
+	c.Response().Header().Set(headerETag, result.ETag())
 	return c.JSON(http.StatusOK, result)
 }
 
+// UpdateProfile applies the update only while the profile still has the
+// ETag named by If-Match, answering 412 otherwise. Without If-Match the last
+// write wins.
 func (h *UserHandler) UpdateProfile(c echo.Context) error {
 	userIDStr := c.Get("user_id").(string)
 	userID, err := uuid.Parse(userIDStr)
@@ -77,12 +90,14 @@ func (h *UserHandler) UpdateProfile(c echo.Context) error {
 	}
 
 	req.UserID = userID
+	req.IfMatch = c.Request().Header.Get(headerIfMatch)
 
 	if err := request.ValidateStruct(&req); err != nil {
 		return c.JSON(http.StatusBadRequest, response.ErrorResponse{
 			Error:   "VALIDATION_ERROR",
 			Message: err.Error(),
 			Code:    http.StatusBadRequest,
+			Details: request.ValidationDetails(err),
 		})
 	}
 
@@ -103,9 +118,101 @@ func (h *UserHandler) UpdateProfile(c echo.Context) error {
 		})
 	}
 
+	c.Response().Header().Set(headerETag, result.ETag())
 	return c.JSON(http.StatusOK, result)
 }
 
+// UploadAvatar replaces the caller's avatar with the multipart "avatar" file.
+func (h *UserHandler) UploadAvatar(c echo.Context) error {
+	userID, err := uuid.Parse(c.Get("user_id").(string))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, response.ErrorResponse{
+			Error:   "INVALID_USER_ID",
+			Message: "Invalid user ID format",
+			Code:    http.StatusBadRequest,
+		})
+	}
+
+	file, err := c.FormFile("avatar")
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, response.ErrorResponse{
+			Error:   "INVALID_REQUEST",
+			Message: "Missing avatar file",
+			Code:    http.StatusBadRequest,
+		})
+	}
+
+	src, err := file.Open()
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, response.ErrorResponse{
+			Error:   "INVALID_REQUEST",
+			Message: "Failed to read avatar file",
+			Code:    http.StatusBadRequest,
+		})
+	}
+	defer src.Close()
+
+	data, err := io.ReadAll(src)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, response.ErrorResponse{
+			Error:   "INVALID_REQUEST",
+			Message: "Failed to read avatar file",
+			Code:    http.StatusBadRequest,
+		})
+	}
+
+	result, err := h.userService.UploadAvatar(c.Request().Context(), userID, data)
+	if err != nil {
+		if appErr, ok := err.(*errors.AppError); ok {
+			return c.JSON(appErr.StatusCode, response.ErrorResponse{
+				Error:   appErr.Code,
+				Message: appErr.Message,
+				Code:    appErr.StatusCode,
+				Details: appErr.Details,
+			})
+		}
+		return c.JSON(http.StatusInternalServerError, response.ErrorResponse{
+			Error:   "INTERNAL_ERROR",
+			Message: "Internal server error",
+			Code:    http.StatusInternalServerError,
+		})
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
+
+func (h *UserHandler) DeleteAvatar(c echo.Context) error {
+	userID, err := uuid.Parse(c.Get("user_id").(string))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, response.ErrorResponse{
+			Error:   "INVALID_USER_ID",
+			Message: "Invalid user ID format",
+			Code:    http.StatusBadRequest,
+		})
+	}
+
+	err = h.userService.DeleteAvatar(c.Request().Context(), userID)
+	if err != nil {
+		if appErr, ok := err.(*errors.AppError); ok {
+			return c.JSON(appErr.StatusCode, response.ErrorResponse{
+				Error:   appErr.Code,
+				Message: appErr.Message,
+				Code:    appErr.StatusCode,
+				Details: appErr.Details,
+			})
+		}
+		return c.JSON(http.StatusInternalServerError, response.ErrorResponse{
+			Error:   "INTERNAL_ERROR",
+			Message: "Internal server error",
+			Code:    http.StatusInternalServerError,
+		})
+	}
+
+	return c.JSON(http.StatusOK, response.SuccessResponse{
+		Message: "Avatar removed successfully",
+	})
+}
+
 func (h *UserHandler) DeleteAccount(c echo.Context) error {
 	userIDStr := c.Get("user_id").(string)
 	userID, err := uuid.Parse(userIDStr)
@@ -139,6 +246,107 @@ func (h *UserHandler) DeleteAccount(c echo.Context) error {
 	})
 }
 
+func (h *UserHandler) PurgeAccount(c echo.Context) error {
+	userID, err := uuid.Parse(c.Get("user_id").(string))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, response.ErrorResponse{
+			Error:   "INVALID_USER_ID",
+			Message: "Invalid user ID format",
+			Code:    http.StatusBadRequest,
+		})
+	}
+
+	var req request.PurgeAccountRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, response.ErrorResponse{
+			Error:   "INVALID_REQUEST",
+			Message: "Invalid request format",
+			Code:    http.StatusBadRequest,
+		})
+	}
+
+	req.UserID = userID
+
+	if err := request.ValidateStruct(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, response.ErrorResponse{
+			Error:   "VALIDATION_ERROR",
+			Message: err.Error(),
+			Code:    http.StatusBadRequest,
+		})
+	}
+
+	err = h.userService.PurgeAccount(c.Request().Context(), &req)
+	if err != nil {
+		if appErr, ok := err.(*errors.AppError); ok {
+			return c.JSON(appErr.StatusCode, response.ErrorResponse{
+				Error:   appErr.Code,
+				Message: appErr.Message,
+				Code:    appErr.StatusCode,
+				Details: appErr.Details,
+			})
+		}
+		return c.JSON(http.StatusInternalServerError, response.ErrorResponse{
+			Error:   "INTERNAL_ERROR",
+			Message: "Internal server error",
+			Code:    http.StatusInternalServerError,
+		})
+	}
+
+	return c.JSON(http.StatusOK, response.SuccessResponse{
+		Message: "Account purged successfully",
+	})
+}
+
+func (h *UserHandler) CreateUser(c echo.Context) error {
+	actorID, err := uuid.Parse(c.Get("user_id").(string))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, response.ErrorResponse{
+			Error:   "INVALID_USER_ID",
+			Message: "Invalid user ID format",
+			Code:    http.StatusBadRequest,
+		})
+	}
+
+	var req request.CreateUserRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, response.ErrorResponse{
+			Error:   "INVALID_REQUEST",
+			Message: "Invalid request format",
+			Code:    http.StatusBadRequest,
+		})
+	}
+
+	req.ActorID = &actorID
+	req.ActorRoles = actorRoles(c)
+
+	if err := request.ValidateStruct(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, response.ErrorResponse{
+			Error:   "VALIDATION_ERROR",
+			Message: err.Error(),
+			Code:    http.StatusBadRequest,
+		})
+	}
+
+	result, err := h.userService.CreateUser(c.Request().Context(), &req)
+	if err != nil {
+		if appErr, ok := err.(*errors.AppError); ok {
+			return c.JSON(appErr.StatusCode, response.ErrorResponse{
+				Error:   appErr.Code,
+				Message: appErr.Message,
+				Code:    appErr.StatusCode,
+				Details: appErr.Details,
+			})
+		}
+		return c.JSON(http.StatusInternalServerError, response.ErrorResponse{
+			Error:   "INTERNAL_ERROR",
+			Message: "Internal server error",
+			Code:    http.StatusInternalServerError,
+		})
+	}
+
+	return c.JSON(http.StatusCreated, result)
+}
+
 func (h *UserHandler) ListUsers(c echo.Context) error {
 	page, _ := strconv.Atoi(c.QueryParam("page"))
 	pageSize, _ := strconv.Atoi(c.QueryParam("page_size"))
@@ -195,6 +403,46 @@ func (h *UserHandler) ListUsers(c echo.Context) error {
 	return c.JSON(http.StatusOK, result)
 }
 
+// SearchUsers returns the users best matching q, up to limit (default 20).
+func (h *UserHandler) SearchUsers(c echo.Context) error {
+	limit, _ := strconv.Atoi(c.QueryParam("limit"))
+	if limit < 1 || limit > 100 {
+		limit = 20
+	}
+
+	req := &request.SearchUsersRequest{
+		Query: c.QueryParam("q"),
+		Limit: limit,
+	}
+
+	if err := request.ValidateStruct(req); err != nil {
+		return c.JSON(http.StatusBadRequest, response.ErrorResponse{
+			Error:   "VALIDATION_ERROR",
+			Message: err.Error(),
+			Code:    http.StatusBadRequest,
+		})
+	}
+
+	result, err := h.userService.SearchUsers(c.Request().Context(), req)
+	if err != nil {
+		if appErr, ok := err.(*errors.AppError); ok {
+			return c.JSON(appErr.StatusCode, response.ErrorResponse{
+				Error:   appErr.Code,
+				Message: appErr.Message,
+				Code:    appErr.StatusCode,
+				Details: appErr.Details,
+			})
+		}
+		return c.JSON(http.StatusInternalServerError, response.ErrorResponse{
+			Error:   "INTERNAL_ERROR",
+			Message: "Internal server error",
+			Code:    http.StatusInternalServerError,
+		})
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
+
 func (h *UserHandler) GetUserByID(c echo.Context) error {
 	userIDStr := c.Param("id")
 	userID, err := uuid.Parse(userIDStr)
@@ -226,7 +474,100 @@ func (h *UserHandler) GetUserByID(c echo.Context) error {
 	return c.JSON(http.StatusOK, result)
 }
 
+func (h *UserHandler) ActivateUser(c echo.Context) error {
+	actorID, err := uuid.Parse(c.Get("user_id").(string))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, response.ErrorResponse{
+			Error:   "INVALID_USER_ID",
+			Message: "Invalid user ID format",
+			Code:    http.StatusBadRequest,
+		})
+	}
+
+	userIDStr := c.Param("id")
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, response.ErrorResponse{
+			Error:   "INVALID_USER_ID",
+			Message: "Invalid user ID format",
+			Code:    http.StatusBadRequest,
+		})
+	}
+
+	err = h.userService.ActivateUser(c.Request().Context(), &actorID, userID)
+	if err != nil {
+		if appErr, ok := err.(*errors.AppError); ok {
+			return c.JSON(appErr.StatusCode, response.ErrorResponse{
+				Error:   appErr.Code,
+				Message: appErr.Message,
+				Code:    appErr.StatusCode,
+				Details: appErr.Details,
+			})
+		}
+		return c.JSON(http.StatusInternalServerError, response.ErrorResponse{
+			Error:   "INTERNAL_ERROR",
+			Message: "Internal server error",
+			Code:    http.StatusInternalServerError,
+		})
+	}
+
+	return c.JSON(http.StatusOK, response.SuccessResponse{
+		Message: "User activated successfully",
+	})
+}
+
+func (h *UserHandler) DeactivateUser(c echo.Context) error {
+	actorID, err := uuid.Parse(c.Get("user_id").(string))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, response.ErrorResponse{
+			Error:   "INVALID_USER_ID",
+			Message: "Invalid user ID format",
+			Code:    http.StatusBadRequest,
+		})
+	}
+
+	userIDStr := c.Param("id")
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, response.ErrorResponse{
+			Error:   "INVALID_USER_ID",
+			Message: "Invalid user ID format",
+			Code:    http.StatusBadRequest,
+		})
+	}
+
+	err = h.userService.DeactivateUser(c.Request().Context(), &actorID, userID)
+	if err != nil {
+		if appErr, ok := err.(*errors.AppError); ok {
+			return c.JSON(appErr.StatusCode, response.ErrorResponse{
+				Error:   appErr.Code,
+				Message: appErr.Message,
+				Code:    appErr.StatusCode,
+				Details: appErr.Details,
+			})
+		}
+		return c.JSON(http.StatusInternalServerError, response.ErrorResponse{
+			Error:   "INTERNAL_ERROR",
+			Message: "Internal server error",
+			Code:    http.StatusInternalServerError,
+		})
+	}
+
+	return c.JSON(http.StatusOK, response.SuccessResponse{
+		Message: "User deactivated successfully",
+	})
+}
+
 func (h *UserHandler) AssignRole(c echo.Context) error {
+	actorID, err := uuid.Parse(c.Get("user_id").(string))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, response.ErrorResponse{
+			Error:   "INVALID_USER_ID",
+			Message: "Invalid user ID format",
+			Code:    http.StatusBadRequest,
+		})
+	}
+
 	var req request.AssignRoleRequest
 	if err := c.Bind(&req); err != nil {
 		return c.JSON(http.StatusBadRequest, response.ErrorResponse{
@@ -236,6 +577,8 @@ func (h *UserHandler) AssignRole(c echo.Context) error {
 		})
 	}
 
+	req.ActorID = &actorID
+
 	if err := request.ValidateStruct(&req); err != nil {
 		return c.JSON(http.StatusBadRequest, response.ErrorResponse{
 			Error:   "VALIDATION_ERROR",
@@ -244,7 +587,7 @@ func (h *UserHandler) AssignRole(c echo.Context) error {
 		})
 	}
 
-	err := h.userService.AssignRole(c.Request().Context(), &req)
+	err = h.userService.AssignRole(c.Request().Context(), &req)
 	if err != nil {
 		if appErr, ok := err.(*errors.AppError); ok {
 			return c.JSON(appErr.StatusCode, response.ErrorResponse{
@@ -267,6 +610,15 @@ func (h *UserHandler) AssignRole(c echo.Context) error {
 }
 
 func (h *UserHandler) RemoveRole(c echo.Context) error {
+	actorID, err := uuid.Parse(c.Get("user_id").(string))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, response.ErrorResponse{
+			Error:   "INVALID_USER_ID",
+			Message: "Invalid user ID format",
+			Code:    http.StatusBadRequest,
+		})
+	}
+
 	var req request.RemoveRoleRequest
 	if err := c.Bind(&req); err != nil {
 		return c.JSON(http.StatusBadRequest, response.ErrorResponse{
@@ -276,6 +628,8 @@ func (h *UserHandler) RemoveRole(c echo.Context) error {
 		})
 	}
 
+	req.ActorID = &actorID
+
 	if err := request.ValidateStruct(&req); err != nil {
 		return c.JSON(http.StatusBadRequest, response.ErrorResponse{
 			Error:   "VALIDATION_ERROR",
@@ -284,7 +638,7 @@ func (h *UserHandler) RemoveRole(c echo.Context) error {
 		})
 	}
 
-	err := h.userService.RemoveRole(c.Request().Context(), &req)
+	err = h.userService.RemoveRole(c.Request().Context(), &req)
 	if err != nil {
 		if appErr, ok := err.(*errors.AppError); ok {
 			return c.JSON(appErr.StatusCode, response.ErrorResponse{
@@ -306,6 +660,104 @@ func (h *UserHandler) RemoveRole(c echo.Context) error {
 	})
 }
 
+func (h *UserHandler) BulkAssignRole(c echo.Context) error {
+	actorID, err := uuid.Parse(c.Get("user_id").(string))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, response.ErrorResponse{
+			Error:   "INVALID_USER_ID",
+			Message: "Invalid user ID format",
+			Code:    http.StatusBadRequest,
+		})
+	}
+
+	var req request.BulkAssignRoleRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, response.ErrorResponse{
+			Error:   "INVALID_REQUEST",
+			Message: "Invalid request format",
+			Code:    http.StatusBadRequest,
+		})
+	}
+
+	req.ActorID = &actorID
+
+	if err := request.ValidateStruct(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, response.ErrorResponse{
+			Error:   "VALIDATION_ERROR",
+			Message: err.Error(),
+			Code:    http.StatusBadRequest,
+		})
+	}
+
+	result, err := h.userService.BulkAssignRole(c.Request().Context(), &req)
+	if err != nil {
+		if appErr, ok := err.(*errors.AppError); ok {
+			return c.JSON(appErr.StatusCode, response.ErrorResponse{
+				Error:   appErr.Code,
+				Message: appErr.Message,
+				Code:    appErr.StatusCode,
+				Details: appErr.Details,
+			})
+		}
+		return c.JSON(http.StatusInternalServerError, response.ErrorResponse{
+			Error:   "INTERNAL_ERROR",
+			Message: "Internal server error",
+			Code:    http.StatusInternalServerError,
+		})
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
+
+func (h *UserHandler) BulkRemoveRole(c echo.Context) error {
+	actorID, err := uuid.Parse(c.Get("user_id").(string))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, response.ErrorResponse{
+			Error:   "INVALID_USER_ID",
+			Message: "Invalid user ID format",
+			Code:    http.StatusBadRequest,
+		})
+	}
+
+	var req request.BulkRemoveRoleRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, response.ErrorResponse{
+			Error:   "INVALID_REQUEST",
+			Message: "Invalid request format",
+			Code:    http.StatusBadRequest,
+		})
+	}
+
+	req.ActorID = &actorID
+
+	if err := request.ValidateStruct(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, response.ErrorResponse{
+			Error:   "VALIDATION_ERROR",
+			Message: err.Error(),
+			Code:    http.StatusBadRequest,
+		})
+	}
+
+	result, err := h.userService.BulkRemoveRole(c.Request().Context(), &req)
+	if err != nil {
+		if appErr, ok := err.(*errors.AppError); ok {
+			return c.JSON(appErr.StatusCode, response.ErrorResponse{
+				Error:   appErr.Code,
+				Message: appErr.Message,
+				Code:    appErr.StatusCode,
+				Details: appErr.Details,
+			})
+		}
+		return c.JSON(http.StatusInternalServerError, response.ErrorResponse{
+			Error:   "INTERNAL_ERROR",
+			Message: "Internal server error",
+			Code:    http.StatusInternalServerError,
+		})
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
+
 func (h *UserHandler) GetUserRoles(c echo.Context) error {
 	userIDStr := c.Param("id")
 	userID, err := uuid.Parse(userIDStr)
@@ -317,7 +769,89 @@ func (h *UserHandler) GetUserRoles(c echo.Context) error {
 		})
 	}
 
-	result, err := h.userService.GetUserRoles(c.Request().Context(), userID)
+	var scopeID *uuid.UUID
+	if scope := c.QueryParam("scope_id"); scope != "" {
+		id, err := uuid.Parse(scope)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, response.ErrorResponse{
+				Error:   "INVALID_SCOPE_ID",
+				Message: "Invalid scope ID format",
+				Code:    http.StatusBadRequest,
+			})
+		}
+		scopeID = &id
+	}
+
+	result, err := h.userService.GetUserRoles(c.Request().Context(), userID, scopeID)
+	if err != nil {
+		if appErr, ok := err.(*errors.AppError); ok {
+			return c.JSON(appErr.StatusCode, response.ErrorResponse{
+				Error:   appErr.Code,
+				Message: appErr.Message,
+				Code:    appErr.StatusCode,
+				Details: appErr.Details,
+			})
+		}
+		return c.JSON(http.StatusInternalServerError, response.ErrorResponse{
+			Error:   "INTERNAL_ERROR",
+			Message: "Internal server error",
+			Code:    http.StatusInternalServerError,
+		})
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
+
+func (h *UserHandler) ListRoleAudit(c echo.Context) error {
+	page, _ := strconv.Atoi(c.QueryParam("page"))
+	pageSize, _ := strconv.Atoi(c.QueryParam("page_size"))
+
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 || pageSize > 100 {
+		pageSize = 20
+	}
+
+	req := &request.ListRoleAuditRequest{
+		Action:   c.QueryParam("action"),
+		Page:     page,
+		PageSize: pageSize,
+	}
+
+	if userIDStr := c.QueryParam("user_id"); userIDStr != "" {
+		userID, err := uuid.Parse(userIDStr)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, response.ErrorResponse{
+				Error:   "INVALID_USER_ID",
+				Message: "Invalid user ID format",
+				Code:    http.StatusBadRequest,
+			})
+		}
+		req.UserID = &userID
+	}
+
+	if roleIDStr := c.QueryParam("role_id"); roleIDStr != "" {
+		roleID, err := uuid.Parse(roleIDStr)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, response.ErrorResponse{
+				Error:   "INVALID_ROLE_ID",
+				Message: "Invalid role ID format",
+				Code:    http.StatusBadRequest,
+			})
+		}
+		req.RoleID = &roleID
+	}
+
+	if err := request.ValidateStruct(req); err != nil {
+		return c.JSON(http.StatusBadRequest, response.ErrorResponse{
+			Error:   "VALIDATION_ERROR",
+			Message: err.Error(),
+			Code:    http.StatusBadRequest,
+		})
+	}
+
+	result, err := h.userService.ListRoleAudit(c.Request().Context(), req)
 	if err != nil {
 		if appErr, ok := err.(*errors.AppError); ok {
 			return c.JSON(appErr.StatusCode, response.ErrorResponse{
@@ -336,3 +870,11 @@ func (h *UserHandler) GetUserRoles(c echo.Context) error {
 
 	return c.JSON(http.StatusOK, result)
 }
+
+// actorRoles returns the roles the caller holds outside the active
+// organization.
+func actorRoles(c echo.Context) []string {
+	roles, _ := c.Get("roles").([]string)
+	scopedRoles, _ := c.Get("scoped_roles").([]string)
+	return auth.WithoutRoles(roles, scopedRoles)
+}
@@ -0,0 +1,86 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/vagonaizer/authenitfication-service/internal/dto/request"
+	"github.com/vagonaizer/authenitfication-service/internal/dto/response"
+	"github.com/vagonaizer/authenitfication-service/pkg/errors"
+)
+
+// ListMyActivity returns the caller's own activity timeline.
+func (h *UserHandler) ListMyActivity(c echo.Context) error {
+	userID, err := uuid.Parse(c.Get("user_id").(string))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, response.ErrorResponse{
+			Error:   "INVALID_USER_ID",
+			Message: "Invalid user ID format",
+			Code:    http.StatusBadRequest,
+		})
+	}
+
+	return h.listActivity(c, userID)
+}
+
+// ListUserActivity returns the activity timeline of the user in the path.
+func (h *UserHandler) ListUserActivity(c echo.Context) error {
+	userID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, response.ErrorResponse{
+			Error:   "INVALID_USER_ID",
+			Message: "Invalid user ID format",
+			Code:    http.StatusBadRequest,
+		})
+	}
+
+	return h.listActivity(c, userID)
+}
+
+func (h *UserHandler) listActivity(c echo.Context, userID uuid.UUID) error {
+	page, _ := strconv.Atoi(c.QueryParam("page"))
+	pageSize, _ := strconv.Atoi(c.QueryParam("page_size"))
+
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 || pageSize > 100 {
+		pageSize = 20
+	}
+
+	req := &request.ListUserActivityRequest{
+		UserID:   userID,
+		Type:     c.QueryParam("type"),
+		Page:     page,
+		PageSize: pageSize,
+	}
+
+	if err := request.ValidateStruct(req); err != nil {
+		return c.JSON(http.StatusBadRequest, response.ErrorResponse{
+			Error:   "VALIDATION_ERROR",
+			Message: err.Error(),
+			Code:    http.StatusBadRequest,
+		})
+	}
+
+	result, err := h.userService.ListActivity(c.Request().Context(), req)
+	if err != nil {
+		if appErr, ok := err.(*errors.AppError); ok {
+			return c.JSON(appErr.StatusCode, response.ErrorResponse{
+				Error:   appErr.Code,
+				Message: appErr.Message,
+				Code:    appErr.StatusCode,
+				Details: appErr.Details,
+			})
+		}
+		return c.JSON(http.StatusInternalServerError, response.ErrorResponse{
+			Error:   "INTERNAL_ERROR",
+			Message: "Internal server error",
+			Code:    http.StatusInternalServerError,
+		})
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
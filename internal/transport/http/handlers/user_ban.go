@@ -0,0 +1,102 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/vagonaizer/authenitfication-service/internal/dto/request"
+	"github.com/vagonaizer/authenitfication-service/internal/dto/response"
+	"github.com/vagonaizer/authenitfication-service/pkg/errors"
+)
+
+func (h *UserHandler) BanUser(c echo.Context) error {
+	actorID, err := uuid.Parse(c.Get("user_id").(string))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, response.ErrorResponse{
+			Error:   "INVALID_USER_ID",
+			Message: "Invalid user ID format",
+			Code:    http.StatusBadRequest,
+		})
+	}
+
+	userID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, response.ErrorResponse{
+			Error:   "INVALID_USER_ID",
+			Message: "Invalid user ID format",
+			Code:    http.StatusBadRequest,
+		})
+	}
+
+	var req request.BanUserRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, response.ErrorResponse{
+			Error:   "INVALID_REQUEST",
+			Message: "Invalid request format",
+			Code:    http.StatusBadRequest,
+		})
+	}
+
+	req.ActorID = actorID
+	req.UserID = userID
+
+	if err := request.ValidateStruct(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, response.ErrorResponse{
+			Error:   "VALIDATION_ERROR",
+			Message: err.Error(),
+			Code:    http.StatusBadRequest,
+		})
+	}
+
+	result, err := h.userService.BanUser(c.Request().Context(), &req)
+	if err != nil {
+		return h.banError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
+
+func (h *UserHandler) UnbanUser(c echo.Context) error {
+	actorID, err := uuid.Parse(c.Get("user_id").(string))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, response.ErrorResponse{
+			Error:   "INVALID_USER_ID",
+			Message: "Invalid user ID format",
+			Code:    http.StatusBadRequest,
+		})
+	}
+
+	userID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, response.ErrorResponse{
+			Error:   "INVALID_USER_ID",
+			Message: "Invalid user ID format",
+			Code:    http.StatusBadRequest,
+		})
+	}
+
+	if err := h.userService.UnbanUser(c.Request().Context(), actorID, userID); err != nil {
+		return h.banError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, response.SuccessResponse{
+		Message: "User unbanned successfully",
+	})
+}
+
+func (h *UserHandler) banError(c echo.Context, err error) error {
+	if appErr, ok := err.(*errors.AppError); ok {
+		return c.JSON(appErr.StatusCode, response.ErrorResponse{
+			Error:   appErr.Code,
+			Message: appErr.Message,
+			Code:    appErr.StatusCode,
+			Details: appErr.Details,
+		})
+	}
+	return c.JSON(http.StatusInternalServerError, response.ErrorResponse{
+		Error:   "INTERNAL_ERROR",
+		Message: "Internal server error",
+		Code:    http.StatusInternalServerError,
+	})
+}
@@ -0,0 +1,108 @@
+package handlers
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/vagonaizer/authenitfication-service/internal/domain/entities"
+	"github.com/vagonaizer/authenitfication-service/internal/domain/services"
+	"github.com/vagonaizer/authenitfication-service/internal/dto/response"
+	"github.com/vagonaizer/authenitfication-service/pkg/logger"
+)
+
+// UserEventHandler streams the session and security events of the caller as
+// server-sent events, so a client learns at once that its session was
+// revoked elsewhere.
+type UserEventHandler struct {
+	stream    services.UserEventStream
+	keepAlive time.Duration
+	logger    *logger.Logger
+}
+
+func NewUserEventHandler(stream services.UserEventStream, keepAlive time.Duration, logger *logger.Logger) *UserEventHandler {
+	return &UserEventHandler{
+		stream:    stream,
+		keepAlive: keepAlive,
+		logger:    logger,
+	}
+}
+
+// Stream sends each event of the caller as an SSE event named after its type,
+// such as user.session_revoked, with the event payload as data. The stream
+// lasts until the client disconnects or the server shuts down; events
+// published while no stream is open are not replayed.
+func (h *UserEventHandler) Stream(c echo.Context) error {
+	userID, err := uuid.Parse(c.Get("user_id").(string))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, response.ErrorResponse{
+			Error:   "INVALID_USER_ID",
+			Message: "Invalid user ID format",
+			Code:    http.StatusBadRequest,
+		})
+	}
+
+	events, cancel := h.stream.Subscribe(userID)
+	defer cancel()
+
+	// Поток живёт дольше WriteTimeout сервера
+	if err := http.NewResponseController(c.Response()).SetWriteDeadline(time.Time{}); err != nil {
+		h.logger.WithContext(c.Request().Context()).WithError(err).Warn("failed to clear write deadline of event stream")
+	}
+
+	w := c.Response()
+	w.Header().Set(echo.HeaderContentType, "text/event-stream")
+	w.Header().Set(echo.HeaderCacheControl, "no-cache")
+	w.Header().Set(echo.HeaderConnection, "keep-alive")
+	// Nginx иначе буферизует ответ
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+	w.Flush()
+
+	var keepAlive <-chan time.Time
+	if h.keepAlive > 0 {
+		ticker := time.NewTicker(h.keepAlive)
+		defer ticker.Stop()
+		keepAlive = ticker.C
+	}
+
+	ctx := c.Request().Context()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case event, ok := <-events:
+			if !ok {
+				return nil
+			}
+			if err := writeEvent(w, event); err != nil {
+				return nil
+			}
+		case <-keepAlive:
+			if _, err := io.WriteString(w, ": keep-alive\n\n"); err != nil {
+				return nil
+			}
+		}
+		w.Flush()
+	}
+}
+
+// writeEvent writes event in the SSE format, one data line per line of the
+// payload.
+func writeEvent(w io.Writer, event *entities.UserEvent) error {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "event: %s\n", event.Type)
+	for _, line := range bytes.Split(event.Data, []byte("\n")) {
+		b.WriteString("data: ")
+		b.Write(line)
+		b.WriteByte('\n')
+	}
+	b.WriteByte('\n')
+
+	_, err := w.Write(b.Bytes())
+	return err
+}
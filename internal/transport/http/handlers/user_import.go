@@ -0,0 +1,174 @@
+package handlers
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"path/filepath"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/vagonaizer/authenitfication-service/internal/dto/request"
+	"github.com/vagonaizer/authenitfication-service/internal/dto/response"
+	"github.com/vagonaizer/authenitfication-service/pkg/errors"
+)
+
+// ImportUsers accepts a JSON body ({"users": [...]}), a text/csv body, or a
+// multipart upload in the "file" field with a .csv or .json name.
+func (h *UserHandler) ImportUsers(c echo.Context) error {
+	actorID, err := uuid.Parse(c.Get("user_id").(string))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, response.ErrorResponse{
+			Error:   "INVALID_USER_ID",
+			Message: "Invalid user ID format",
+			Code:    http.StatusBadRequest,
+		})
+	}
+
+	records, err := h.readImportRecords(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, response.ErrorResponse{
+			Error:   "INVALID_REQUEST",
+			Message: err.Error(),
+			Code:    http.StatusBadRequest,
+		})
+	}
+
+	req := &request.ImportUsersRequest{
+		ActorID:    &actorID,
+		ActorRoles: actorRoles(c),
+		Users:      records,
+	}
+
+	if err := request.ValidateStruct(req); err != nil {
+		return c.JSON(http.StatusBadRequest, response.ErrorResponse{
+			Error:   "VALIDATION_ERROR",
+			Message: err.Error(),
+			Code:    http.StatusBadRequest,
+		})
+	}
+
+	result, err := h.userService.ImportUsers(c.Request().Context(), req)
+	if err != nil {
+		if appErr, ok := err.(*errors.AppError); ok {
+			return c.JSON(appErr.StatusCode, response.ErrorResponse{
+				Error:   appErr.Code,
+				Message: appErr.Message,
+				Code:    appErr.StatusCode,
+				Details: appErr.Details,
+			})
+		}
+		h.logger.WithContext(c.Request().Context()).WithError(err).Error("user import failed")
+		return c.JSON(http.StatusInternalServerError, response.ErrorResponse{
+			Error:   "INTERNAL_ERROR",
+			Message: "Internal server error",
+			Code:    http.StatusInternalServerError,
+		})
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
+
+func (h *UserHandler) readImportRecords(c echo.Context) ([]*request.ImportUserRecord, error) {
+	contentType := c.Request().Header.Get(echo.HeaderContentType)
+
+	switch {
+	case strings.HasPrefix(contentType, echo.MIMEMultipartForm):
+		file, err := c.FormFile("file")
+		if err != nil {
+			return nil, fmt.Errorf("missing import file")
+		}
+		src, err := file.Open()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read import file")
+		}
+		defer src.Close()
+
+		switch strings.ToLower(filepath.Ext(file.Filename)) {
+		case ".csv":
+			return parseImportCSV(src)
+		case ".json":
+			return parseImportJSON(src)
+		default:
+			return nil, fmt.Errorf("import file must be .csv or .json")
+		}
+	case strings.HasPrefix(contentType, "text/csv"):
+		return parseImportCSV(c.Request().Body)
+	default:
+		return parseImportJSON(c.Request().Body)
+	}
+}
+
+func parseImportJSON(r io.Reader) ([]*request.ImportUserRecord, error) {
+	var body struct {
+		Users []*request.ImportUserRecord `json:"users"`
+	}
+	if err := json.NewDecoder(r).Decode(&body); err != nil {
+		return nil, fmt.Errorf("invalid JSON import")
+	}
+	return body.Users, nil
+}
+
+// parseImportCSV reads a CSV with a header row. email and username columns
+// are required; first_name, last_name, roles (separated by ";") and
+// password_hash are optional.
+func parseImportCSV(r io.Reader) ([]*request.ImportUserRecord, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("invalid CSV import: missing header")
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, required := range []string{"email", "username"} {
+		if _, ok := columns[required]; !ok {
+			return nil, fmt.Errorf("invalid CSV import: missing %q column", required)
+		}
+	}
+
+	field := func(row []string, name string) string {
+		if i, ok := columns[name]; ok && i < len(row) {
+			return strings.TrimSpace(row[i])
+		}
+		return ""
+	}
+	optional := func(row []string, name string) *string {
+		if value := field(row, name); value != "" {
+			return &value
+		}
+		return nil
+	}
+
+	var records []*request.ImportUserRecord
+	for {
+		row, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid CSV import: %v", err)
+		}
+
+		record := &request.ImportUserRecord{
+			Email:        field(row, "email"),
+			Username:     field(row, "username"),
+			FirstName:    optional(row, "first_name"),
+			LastName:     optional(row, "last_name"),
+			PasswordHash: field(row, "password_hash"),
+		}
+		if roles := field(row, "roles"); roles != "" {
+			record.Roles = strings.Split(roles, ";")
+		}
+		records = append(records, record)
+	}
+
+	return records, nil
+}
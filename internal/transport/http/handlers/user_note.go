@@ -0,0 +1,153 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/vagonaizer/authenitfication-service/internal/dto/request"
+	"github.com/vagonaizer/authenitfication-service/internal/dto/response"
+	"github.com/vagonaizer/authenitfication-service/pkg/errors"
+)
+
+func (h *UserHandler) GetUserDetail(c echo.Context) error {
+	userID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return h.invalidUserID(c)
+	}
+
+	result, err := h.userService.GetUserDetail(c.Request().Context(), userID)
+	if err != nil {
+		return h.noteError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
+
+func (h *UserHandler) ListNotes(c echo.Context) error {
+	userID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return h.invalidUserID(c)
+	}
+
+	notes, err := h.userService.ListNotes(c.Request().Context(), userID)
+	if err != nil {
+		return h.noteError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, notes)
+}
+
+func (h *UserHandler) CreateNote(c echo.Context) error {
+	return h.saveNote(c, false)
+}
+
+func (h *UserHandler) UpdateNote(c echo.Context) error {
+	return h.saveNote(c, true)
+}
+
+func (h *UserHandler) DeleteNote(c echo.Context) error {
+	userID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return h.invalidUserID(c)
+	}
+
+	noteID, err := uuid.Parse(c.Param("note_id"))
+	if err != nil {
+		return h.invalidNoteID(c)
+	}
+
+	if err := h.userService.DeleteNote(c.Request().Context(), userID, noteID); err != nil {
+		return h.noteError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, response.SuccessResponse{
+		Message: "Note deleted successfully",
+	})
+}
+
+// saveNote creates a note, or edits the one in the note_id path parameter
+// when update is set.
+func (h *UserHandler) saveNote(c echo.Context, update bool) error {
+	actorID, err := uuid.Parse(c.Get("user_id").(string))
+	if err != nil {
+		return h.invalidUserID(c)
+	}
+
+	userID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return h.invalidUserID(c)
+	}
+
+	var req request.UserNoteRequest
+	if update {
+		if req.NoteID, err = uuid.Parse(c.Param("note_id")); err != nil {
+			return h.invalidNoteID(c)
+		}
+	}
+
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, response.ErrorResponse{
+			Error:   "INVALID_REQUEST",
+			Message: "Invalid request format",
+			Code:    http.StatusBadRequest,
+		})
+	}
+
+	req.ActorID = actorID
+	req.UserID = userID
+
+	if err := request.ValidateStruct(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, response.ErrorResponse{
+			Error:   "VALIDATION_ERROR",
+			Message: err.Error(),
+			Code:    http.StatusBadRequest,
+		})
+	}
+
+	if !update {
+		result, err := h.userService.CreateNote(c.Request().Context(), &req)
+		if err != nil {
+			return h.noteError(c, err)
+		}
+		return c.JSON(http.StatusCreated, result)
+	}
+
+	result, err := h.userService.UpdateNote(c.Request().Context(), &req)
+	if err != nil {
+		return h.noteError(c, err)
+	}
+	return c.JSON(http.StatusOK, result)
+}
+
+func (h *UserHandler) invalidNoteID(c echo.Context) error {
+	return c.JSON(http.StatusBadRequest, response.ErrorResponse{
+		Error:   "INVALID_NOTE_ID",
+		Message: "Invalid note ID format",
+		Code:    http.StatusBadRequest,
+	})
+}
+
+func (h *UserHandler) invalidUserID(c echo.Context) error {
+	return c.JSON(http.StatusBadRequest, response.ErrorResponse{
+		Error:   "INVALID_USER_ID",
+		Message: "Invalid user ID format",
+		Code:    http.StatusBadRequest,
+	})
+}
+
+func (h *UserHandler) noteError(c echo.Context, err error) error {
+	if appErr, ok := err.(*errors.AppError); ok {
+		return c.JSON(appErr.StatusCode, response.ErrorResponse{
+			Error:   appErr.Code,
+			Message: appErr.Message,
+			Code:    appErr.StatusCode,
+			Details: appErr.Details,
+		})
+	}
+	return c.JSON(http.StatusInternalServerError, response.ErrorResponse{
+		Error:   "INTERNAL_ERROR",
+		Message: "Internal server error",
+		Code:    http.StatusInternalServerError,
+	})
+}
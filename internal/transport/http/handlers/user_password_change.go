@@ -0,0 +1,61 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/vagonaizer/authenitfication-service/internal/dto/response"
+	"github.com/vagonaizer/authenitfication-service/pkg/errors"
+)
+
+func (h *UserHandler) RequirePasswordChange(c echo.Context) error {
+	return h.setPasswordChangeRequired(c, true)
+}
+
+func (h *UserHandler) WaivePasswordChange(c echo.Context) error {
+	return h.setPasswordChangeRequired(c, false)
+}
+
+func (h *UserHandler) setPasswordChangeRequired(c echo.Context, required bool) error {
+	actorID, err := uuid.Parse(c.Get("user_id").(string))
+	if err != nil {
+		return h.invalidUserID(c)
+	}
+
+	userID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return h.invalidUserID(c)
+	}
+
+	message := "Password change waived successfully"
+	if required {
+		err = h.userService.RequirePasswordChange(c.Request().Context(), actorID, userID)
+		message = "Password change required successfully"
+	} else {
+		err = h.userService.WaivePasswordChange(c.Request().Context(), actorID, userID)
+	}
+	if err != nil {
+		return h.passwordChangeError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, response.SuccessResponse{
+		Message: message,
+	})
+}
+
+func (h *UserHandler) passwordChangeError(c echo.Context, err error) error {
+	if appErr, ok := err.(*errors.AppError); ok {
+		return c.JSON(appErr.StatusCode, response.ErrorResponse{
+			Error:   appErr.Code,
+			Message: appErr.Message,
+			Code:    appErr.StatusCode,
+			Details: appErr.Details,
+		})
+	}
+	return c.JSON(http.StatusInternalServerError, response.ErrorResponse{
+		Error:   "INTERNAL_ERROR",
+		Message: "Internal server error",
+		Code:    http.StatusInternalServerError,
+	})
+}
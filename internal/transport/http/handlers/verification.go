@@ -0,0 +1,167 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/vagonaizer/authenitfication-service/internal/domain/services"
+	"github.com/vagonaizer/authenitfication-service/internal/dto/request"
+	"github.com/vagonaizer/authenitfication-service/internal/dto/response"
+	"github.com/vagonaizer/authenitfication-service/pkg/errors"
+	"github.com/vagonaizer/authenitfication-service/pkg/logger"
+)
+
+type VerificationHandler struct {
+	verificationService services.VerificationService
+	logger              *logger.Logger
+}
+
+func NewVerificationHandler(verificationService services.VerificationService, logger *logger.Logger) *VerificationHandler {
+	return &VerificationHandler{
+		verificationService: verificationService,
+		logger:              logger,
+	}
+}
+
+func (h *VerificationHandler) ResendVerification(c echo.Context) error {
+	var req request.ResendVerificationRequest
+	if err := c.Bind(&req); err != nil {
+		return h.invalidRequest(c)
+	}
+
+	if err := request.ValidateStruct(&req); err != nil {
+		return h.validationError(c, err)
+	}
+
+	if err := h.verificationService.ResendVerification(c.Request().Context(), req.Email); err != nil {
+		return h.handleError(c, err)
+	}
+
+	// The same answer is given whether or not the email is registered.
+	return c.JSON(http.StatusAccepted, response.SuccessResponse{
+		Message: "If the account exists and is not verified, a verification email has been sent",
+	})
+}
+
+func (h *VerificationHandler) VerifyEmail(c echo.Context) error {
+	var req request.VerifyEmailRequest
+	if err := c.Bind(&req); err != nil {
+		return h.invalidRequest(c)
+	}
+
+	if err := request.ValidateStruct(&req); err != nil {
+		return h.validationError(c, err)
+	}
+
+	if err := h.verificationService.VerifyEmail(c.Request().Context(), req.Token); err != nil {
+		return h.handleError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, response.SuccessResponse{
+		Message: "Email verified successfully",
+	})
+}
+
+func (h *VerificationHandler) StartPhoneVerification(c echo.Context) error {
+	userID, err := uuid.Parse(c.Get("user_id").(string))
+	if err != nil {
+		return h.invalidUserID(c)
+	}
+
+	var req request.StartPhoneVerificationRequest
+	if err := c.Bind(&req); err != nil {
+		return h.invalidRequest(c)
+	}
+
+	if err := request.ValidateStruct(&req); err != nil {
+		return h.validationError(c, err)
+	}
+
+	if err := h.verificationService.StartPhoneVerification(c.Request().Context(), userID, req.Phone); err != nil {
+		return h.handleError(c, err)
+	}
+
+	return c.JSON(http.StatusAccepted, response.SuccessResponse{
+		Message: "Verification code sent",
+	})
+}
+
+func (h *VerificationHandler) VerifyPhone(c echo.Context) error {
+	userID, err := uuid.Parse(c.Get("user_id").(string))
+	if err != nil {
+		return h.invalidUserID(c)
+	}
+
+	var req request.VerifyPhoneRequest
+	if err := c.Bind(&req); err != nil {
+		return h.invalidRequest(c)
+	}
+
+	if err := request.ValidateStruct(&req); err != nil {
+		return h.validationError(c, err)
+	}
+
+	if err := h.verificationService.VerifyPhone(c.Request().Context(), userID, req.Code); err != nil {
+		return h.handleError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, response.SuccessResponse{
+		Message: "Phone number verified successfully",
+	})
+}
+
+func (h *VerificationHandler) RemovePhone(c echo.Context) error {
+	userID, err := uuid.Parse(c.Get("user_id").(string))
+	if err != nil {
+		return h.invalidUserID(c)
+	}
+
+	if err := h.verificationService.RemovePhone(c.Request().Context(), userID); err != nil {
+		return h.handleError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, response.SuccessResponse{
+		Message: "Phone number removed successfully",
+	})
+}
+
+func (h *VerificationHandler) invalidUserID(c echo.Context) error {
+	return c.JSON(http.StatusBadRequest, response.ErrorResponse{
+		Error:   "INVALID_USER_ID",
+		Message: "Invalid user ID format",
+		Code:    http.StatusBadRequest,
+	})
+}
+
+func (h *VerificationHandler) invalidRequest(c echo.Context) error {
+	return c.JSON(http.StatusBadRequest, response.ErrorResponse{
+		Error:   "INVALID_REQUEST",
+		Message: "Invalid request format",
+		Code:    http.StatusBadRequest,
+	})
+}
+
+func (h *VerificationHandler) validationError(c echo.Context, err error) error {
+	return c.JSON(http.StatusBadRequest, response.ErrorResponse{
+		Error:   "VALIDATION_ERROR",
+		Message: err.Error(),
+		Code:    http.StatusBadRequest,
+	})
+}
+
+func (h *VerificationHandler) handleError(c echo.Context, err error) error {
+	if appErr, ok := err.(*errors.AppError); ok {
+		return c.JSON(appErr.StatusCode, response.ErrorResponse{
+			Error:   appErr.Code,
+			Message: appErr.Message,
+			Code:    appErr.StatusCode,
+			Details: appErr.Details,
+		})
+	}
+	return c.JSON(http.StatusInternalServerError, response.ErrorResponse{
+		Error:   "INTERNAL_ERROR",
+		Message: "Internal server error",
+		Code:    http.StatusInternalServerError,
+	})
+}
@@ -0,0 +1,111 @@
+package handlers
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/vagonaizer/authenitfication-service/internal/dto/response"
+)
+
+// accessTokenClaims are the claims access tokens may carry.
+var accessTokenClaims = []string{
+	"iss", "aud", "sub", "exp", "nbf", "iat", "jti",
+	"user_id", "email", "username", "roles", "org_id", "groups", "scoped_roles",
+	"permissions", "permissions_omitted", "service_account", "locale", "timezone",
+}
+
+// WellKnownHandler serves the /.well-known documents through which clients
+// and scanners discover the service. Access tokens are signed with a shared
+// HMAC secret, which is never published, so the key set is empty: resource
+// servers verify tokens with /api/v1/auth/verify or the forward-auth
+// endpoint instead.
+type WellKnownHandler struct {
+	issuer            string
+	baseURL           string
+	changePasswordURL string
+	docs              bool
+	securityTxt       []string
+}
+
+// NewWellKnownHandler serves the discovery document of tokens issued by
+// issuer, linking to baseURL, or to the host of the request when empty, and
+// to /docs when docs is set. security.txt lists contacts and policy and
+// expires at securityTxtExpires.
+func NewWellKnownHandler(
+	issuer string,
+	baseURL string,
+	changePasswordURL string,
+	docs bool,
+	contacts []string,
+	policy string,
+	securityTxtExpires time.Time,
+) *WellKnownHandler {
+	var securityTxt []string
+	if len(contacts) > 0 {
+		for _, contact := range contacts {
+			securityTxt = append(securityTxt, "Contact: "+contact)
+		}
+		securityTxt = append(securityTxt, "Expires: "+securityTxtExpires.UTC().Format(time.RFC3339))
+		if policy != "" {
+			securityTxt = append(securityTxt, "Policy: "+policy)
+		}
+		if baseURL != "" {
+			securityTxt = append(securityTxt, "Canonical: "+strings.TrimSuffix(baseURL, "/")+"/.well-known/security.txt")
+		}
+	}
+
+	return &WellKnownHandler{
+		issuer:            issuer,
+		baseURL:           strings.TrimSuffix(baseURL, "/"),
+		changePasswordURL: changePasswordURL,
+		docs:              docs,
+		securityTxt:       securityTxt,
+	}
+}
+
+func (h *WellKnownHandler) OpenIDConfiguration(c echo.Context) error {
+	baseURL := h.baseURL
+	if baseURL == "" {
+		baseURL = c.Scheme() + "://" + c.Request().Host
+	}
+
+	document := response.OpenIDConfigurationResponse{
+		Issuer:                           h.issuer,
+		JWKSURI:                          baseURL + "/.well-known/jwks.json",
+		TokenEndpoint:                    baseURL + "/api/v1/auth/login",
+		UserinfoEndpoint:                 baseURL + "/api/v1/users/profile",
+		SubjectTypesSupported:            []string{"public"},
+		IDTokenSigningAlgValuesSupported: []string{"HS256"},
+		ClaimsSupported:                  accessTokenClaims,
+	}
+	if h.docs {
+		document.ServiceDocumentation = baseURL + "/docs"
+	}
+
+	c.Response().Header().Set("Cache-Control", "public, max-age=3600")
+	return c.JSON(http.StatusOK, document)
+}
+
+func (h *WellKnownHandler) JWKS(c echo.Context) error {
+	c.Response().Header().Set("Cache-Control", "public, max-age=3600")
+	return c.JSON(http.StatusOK, response.JWKSResponse{Keys: []map[string]string{}})
+}
+
+// ChangePassword redirects to the page where users change their password,
+// for password managers.
+func (h *WellKnownHandler) ChangePassword(c echo.Context) error {
+	if h.changePasswordURL == "" {
+		return echo.ErrNotFound
+	}
+	return c.Redirect(http.StatusFound, h.changePasswordURL)
+}
+
+// SecurityTxt serves security.txt (RFC 9116), which requires a contact.
+func (h *WellKnownHandler) SecurityTxt(c echo.Context) error {
+	if len(h.securityTxt) == 0 {
+		return echo.ErrNotFound
+	}
+	return c.String(http.StatusOK, strings.Join(h.securityTxt, "\n")+"\n")
+}
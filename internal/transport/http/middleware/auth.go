@@ -3,26 +3,81 @@ package middleware
 import (
 	"net/http"
 	"strings"
+	"time"
 
+	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
+	"github.com/vagonaizer/authenitfication-service/internal/domain/entities"
+	"github.com/vagonaizer/authenitfication-service/internal/domain/services"
 	"github.com/vagonaizer/authenitfication-service/internal/dto/response"
 	"github.com/vagonaizer/authenitfication-service/pkg/auth"
+	"github.com/vagonaizer/authenitfication-service/pkg/errors"
 	"github.com/vagonaizer/authenitfication-service/pkg/logger"
 )
 
+// privilegedPermissions are the permissions that make a user out of reach of
+// org-scoped admins when granted by a global role.
+var privilegedPermissions = []string{
+	entities.PermissionUsersRead,
+	entities.PermissionUsersManage,
+	entities.PermissionUsersActivate,
+	entities.PermissionUsersDeactivate,
+	entities.PermissionUsersBan,
+	entities.PermissionUsersNotes,
+	entities.PermissionRolesRead,
+	entities.PermissionRolesManage,
+	entities.PermissionRolesAssign,
+	entities.PermissionGroupsRead,
+	entities.PermissionGroupsManage,
+	entities.PermissionAccessCheck,
+	entities.PermissionServiceAccountsRead,
+	entities.PermissionServiceAccountsManage,
+	entities.PermissionQuotasRead,
+	entities.PermissionQuotasManage,
+	entities.PermissionEventsReplay,
+}
+
 type AuthMiddleware struct {
-	jwtManager *auth.JWTManager
-	logger     *logger.Logger
+	jwtManager   *auth.JWTManager
+	authorizer   services.Authorizer
+	orgService   services.OrganizationService
+	userService  services.UserService
+	groupService services.GroupService
+	revocations  services.TokenRevocationService
+	logger       *logger.Logger
 }
 
-func NewAuthMiddleware(jwtManager *auth.JWTManager, logger *logger.Logger) *AuthMiddleware {
+func NewAuthMiddleware(
+	jwtManager *auth.JWTManager,
+	authorizer services.Authorizer,
+	orgService services.OrganizationService,
+	userService services.UserService,
+	groupService services.GroupService,
+	revocations services.TokenRevocationService,
+	logger *logger.Logger,
+) *AuthMiddleware {
 	return &AuthMiddleware{
-		jwtManager: jwtManager,
-		logger:     logger,
+		jwtManager:   jwtManager,
+		authorizer:   authorizer,
+		orgService:   orgService,
+		userService:  userService,
+		groupService: groupService,
+		revocations:  revocations,
+		logger:       logger,
 	}
 }
 
 func (m *AuthMiddleware) RequireAuth() echo.MiddlewareFunc {
+	return m.authenticate(false)
+}
+
+// AllowPasswordChange is RequireAuth that also accepts the restricted token
+// issued to users who must change their password.
+func (m *AuthMiddleware) AllowPasswordChange() echo.MiddlewareFunc {
+	return m.authenticate(true)
+}
+
+func (m *AuthMiddleware) authenticate(allowPasswordChange bool) echo.MiddlewareFunc {
 	return func(next echo.HandlerFunc) echo.HandlerFunc {
 		return func(c echo.Context) error {
 			authHeader := c.Request().Header.Get("Authorization")
@@ -43,7 +98,7 @@ func (m *AuthMiddleware) RequireAuth() echo.MiddlewareFunc {
 				})
 			}
 
-			claims, err := m.jwtManager.ValidateAccessToken(token)
+			claims, err := m.jwtManager.ValidateAccessToken(token, requestTenant(c))
 			if err != nil {
 				return c.JSON(http.StatusUnauthorized, response.ErrorResponse{
 					Error:   "INVALID_TOKEN",
@@ -52,10 +107,31 @@ func (m *AuthMiddleware) RequireAuth() echo.MiddlewareFunc {
 				})
 			}
 
+			if m.isRevoked(c, claims) {
+				return c.JSON(http.StatusUnauthorized, response.ErrorResponse{
+					Error:   "TOKEN_REVOKED",
+					Message: "Token has been revoked",
+					Code:    http.StatusUnauthorized,
+				})
+			}
+
+			if claims.PasswordChangeOnly && !allowPasswordChange {
+				return c.JSON(http.StatusForbidden, response.ErrorResponse{
+					Error:   errors.CodePasswordChangeRequired,
+					Message: "Password change required",
+					Code:    http.StatusForbidden,
+				})
+			}
+
 			c.Set("user_id", claims.UserID.String())
 			c.Set("email", claims.Email)
 			c.Set("username", claims.Username)
 			c.Set("roles", claims.Roles)
+			c.Set("scoped_roles", claims.ScopedRoles)
+			c.Set("locale", claims.Locale)
+			if claims.OrgID != nil {
+				c.Set("org_id", claims.OrgID.String())
+			}
 
 			return next(c)
 		}
@@ -65,7 +141,7 @@ func (m *AuthMiddleware) RequireAuth() echo.MiddlewareFunc {
 func (m *AuthMiddleware) RequireRole(requiredRole string) echo.MiddlewareFunc {
 	return func(next echo.HandlerFunc) echo.HandlerFunc {
 		return func(c echo.Context) error {
-			roles, ok := c.Get("roles").([]string)
+			roles, ok := globalRoles(c)
 			if !ok {
 				return c.JSON(http.StatusForbidden, response.ErrorResponse{
 					Error:   "INSUFFICIENT_PERMISSIONS",
@@ -98,7 +174,7 @@ func (m *AuthMiddleware) RequireRole(requiredRole string) echo.MiddlewareFunc {
 func (m *AuthMiddleware) RequireAnyRole(requiredRoles ...string) echo.MiddlewareFunc {
 	return func(next echo.HandlerFunc) echo.HandlerFunc {
 		return func(c echo.Context) error {
-			roles, ok := c.Get("roles").([]string)
+			roles, ok := globalRoles(c)
 			if !ok {
 				return c.JSON(http.StatusForbidden, response.ErrorResponse{
 					Error:   "INSUFFICIENT_PERMISSIONS",
@@ -133,6 +209,211 @@ func (m *AuthMiddleware) RequireAnyRole(requiredRoles ...string) echo.Middleware
 	}
 }
 
+func (m *AuthMiddleware) RequirePermission(permission string) echo.MiddlewareFunc {
+	resource, action := entities.ParsePermission(permission)
+	return m.Authorize(action, resource)
+}
+
+// Authorize allows the request only if the authorizer grants action on resource
+// to the authenticated subject. Roles granted only within the active
+// organization are ignored; see RequireDelegatedPermission.
+func (m *AuthMiddleware) Authorize(action, resource string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			roles, ok := globalRoles(c)
+			if !ok {
+				return m.insufficientPermissions(c)
+			}
+
+			allowed, err := m.authorize(c, roles, action, resource)
+			if err != nil {
+				return m.authorizationError(c, action, resource, err)
+			}
+
+			if !allowed {
+				return m.insufficientPermissions(c)
+			}
+
+			return next(c)
+		}
+	}
+}
+
+// RequireDelegatedPermission behaves like RequirePermission, but additionally
+// accepts roles granted within the active organization as long as the user
+// named by the targetParam path parameter is a member of that organization
+// and holds no global role granting any permission. This lets org admins
+// manage their own members without global rights, but not global admins who
+// joined their organization.
+func (m *AuthMiddleware) RequireDelegatedPermission(permission, targetParam string) echo.MiddlewareFunc {
+	resource, action := entities.ParsePermission(permission)
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			roles, ok := c.Get("roles").([]string)
+			if !ok {
+				return m.insufficientPermissions(c)
+			}
+
+			scopedRoles, _ := c.Get("scoped_roles").([]string)
+			allowed, err := m.authorize(c, auth.WithoutRoles(roles, scopedRoles), action, resource)
+			if err != nil {
+				return m.authorizationError(c, action, resource, err)
+			}
+			if allowed {
+				return next(c)
+			}
+
+			orgIDStr, _ := c.Get("org_id").(string)
+			if orgIDStr == "" || len(scopedRoles) == 0 {
+				return m.insufficientPermissions(c)
+			}
+
+			allowed, err = m.authorize(c, roles, action, resource)
+			if err != nil {
+				return m.authorizationError(c, action, resource, err)
+			}
+			if !allowed {
+				return m.insufficientPermissions(c)
+			}
+
+			orgID, err := uuid.Parse(orgIDStr)
+			if err != nil {
+				return m.insufficientPermissions(c)
+			}
+
+			targetID, err := uuid.Parse(c.Param(targetParam))
+			if err != nil {
+				return c.JSON(http.StatusBadRequest, response.ErrorResponse{
+					Error:   "INVALID_USER_ID",
+					Message: "Invalid user ID format",
+					Code:    http.StatusBadRequest,
+				})
+			}
+
+			if _, err := m.orgService.GetMembership(c.Request().Context(), orgID, targetID); err != nil {
+				if appErr, ok := err.(*errors.AppError); ok && appErr.Code == errors.CodeNotFound {
+					return c.JSON(http.StatusForbidden, response.ErrorResponse{
+						Error:   "TARGET_OUTSIDE_ORGANIZATION",
+						Message: "User is not a member of the active organization",
+						Code:    http.StatusForbidden,
+					})
+				}
+				return m.authorizationError(c, action, resource, err)
+			}
+
+			privileged, err := m.isPrivileged(c, targetID)
+			if err != nil {
+				return m.authorizationError(c, action, resource, err)
+			}
+			if privileged {
+				return c.JSON(http.StatusForbidden, response.ErrorResponse{
+					Error:   "TARGET_HAS_GLOBAL_PERMISSIONS",
+					Message: "User holds global permissions",
+					Code:    http.StatusForbidden,
+				})
+			}
+
+			return next(c)
+		}
+	}
+}
+
+// isPrivileged reports whether the global roles of the user, direct or
+// inherited from their groups as in their tokens, grant any of the
+// privilegedPermissions.
+func (m *AuthMiddleware) isPrivileged(c echo.Context, userID uuid.UUID) (bool, error) {
+	ctx := c.Request().Context()
+	direct, err := m.userService.GetUserRoles(ctx, userID, nil)
+	if err != nil {
+		return false, err
+	}
+	inherited, err := m.groupService.GetUserGroupRoles(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+
+	roles := make([]string, 0, len(direct.Roles)+len(inherited.Roles))
+	for _, role := range direct.Roles {
+		roles = append(roles, role.Name)
+	}
+	for _, role := range inherited.Roles {
+		roles = append(roles, role.Name)
+	}
+	if len(roles) == 0 {
+		return false, nil
+	}
+	subject := &services.Subject{UserID: userID.String(), Roles: roles}
+
+	for _, permission := range privilegedPermissions {
+		resource, action := entities.ParsePermission(permission)
+		allowed, err := m.authorizer.Authorize(ctx, subject, action, resource)
+		if err != nil {
+			return false, err
+		}
+		if allowed {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (m *AuthMiddleware) authorize(c echo.Context, roles []string, action, resource string) (bool, error) {
+	userID, _ := c.Get("user_id").(string)
+	orgID, _ := c.Get("org_id").(string)
+	subject := &services.Subject{
+		UserID: userID,
+		Roles:  roles,
+		OrgID:  orgID,
+	}
+
+	return m.authorizer.Authorize(c.Request().Context(), subject, action, resource)
+}
+
+func (m *AuthMiddleware) insufficientPermissions(c echo.Context) error {
+	return c.JSON(http.StatusForbidden, response.ErrorResponse{
+		Error:   "INSUFFICIENT_PERMISSIONS",
+		Message: "Insufficient permissions",
+		Code:    http.StatusForbidden,
+	})
+}
+
+func (m *AuthMiddleware) authorizationError(c echo.Context, action, resource string, err error) error {
+	m.logger.WithContext(c.Request().Context()).WithFields(logger.Fields{
+		"action":   action,
+		"resource": resource,
+	}).WithError(err).Error("failed to authorize request")
+	return c.JSON(http.StatusInternalServerError, response.ErrorResponse{
+		Error:   "INTERNAL_ERROR",
+		Message: "Internal server error",
+		Code:    http.StatusInternalServerError,
+	})
+}
+
+// organizationRoute is the route segment of the organization a route addresses.
+const organizationRoute = "/organizations/:id"
+
+// requestTenant returns the organization a request addresses: the one its
+// route names, as in /organizations/:id, else the one of its TenantHeader.
+// The route wins, so a token of another organization is refused there
+// whatever header the client sends.
+func requestTenant(c echo.Context) *uuid.UUID {
+	if strings.Contains(c.Path(), organizationRoute) {
+		return auth.ParseTenant(c.Param("id"))
+	}
+	return auth.ParseTenant(c.Request().Header.Get(auth.TenantHeader))
+}
+
+// globalRoles returns the roles of the authenticated user that apply outside
+// the active organization; roles granted only within it grant nothing here.
+func globalRoles(c echo.Context) ([]string, bool) {
+	roles, ok := c.Get("roles").([]string)
+	if !ok {
+		return nil, false
+	}
+	scopedRoles, _ := c.Get("scoped_roles").([]string)
+	return auth.WithoutRoles(roles, scopedRoles), true
+}
+
 func (m *AuthMiddleware) OptionalAuth() echo.MiddlewareFunc {
 	return func(next echo.HandlerFunc) echo.HandlerFunc {
 		return func(c echo.Context) error {
@@ -146,7 +427,7 @@ func (m *AuthMiddleware) OptionalAuth() echo.MiddlewareFunc {
 			}
 
 			token := authHeader[7:]
-			claims, err := m.jwtManager.ValidateAccessToken(token)
+			claims, err := m.jwtManager.ValidateAccessToken(token, requestTenant(c))
 			if err != nil {
 				return next(c)
 			}
@@ -155,8 +436,29 @@ func (m *AuthMiddleware) OptionalAuth() echo.MiddlewareFunc {
 			c.Set("email", claims.Email)
 			c.Set("username", claims.Username)
 			c.Set("roles", claims.Roles)
+			c.Set("scoped_roles", claims.ScopedRoles)
+			c.Set("locale", claims.Locale)
+			if claims.OrgID != nil {
+				c.Set("org_id", claims.OrgID.String())
+			}
 
 			return next(c)
 		}
 	}
 }
+
+// isRevoked lets the request through when revocations cannot be checked, so
+// a Redis outage does not lock everyone out.
+func (m *AuthMiddleware) isRevoked(c echo.Context, claims *auth.AccessTokenClaims) bool {
+	var issuedAt time.Time
+	if claims.IssuedAt != nil {
+		issuedAt = claims.IssuedAt.Time
+	}
+
+	revoked, err := m.revocations.IsRevoked(c.Request().Context(), claims.UserID, claims.ID, issuedAt)
+	if err != nil {
+		m.logger.WithContext(c.Request().Context()).WithError(err).WithField("user_id", claims.UserID).Warn("failed to check token revocation")
+		return false
+	}
+	return revoked
+}
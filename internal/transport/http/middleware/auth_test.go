@@ -0,0 +1,39 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/vagonaizer/authenitfication-service/pkg/auth"
+)
+
+func TestRequestTenantPrefersTheRoute(t *testing.T) {
+	routeOrg, headerOrg := uuid.New(), uuid.New()
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/organizations/"+routeOrg.String()+"/members", nil)
+	req.Header.Set(auth.TenantHeader, headerOrg.String())
+	c := echo.New().NewContext(req, httptest.NewRecorder())
+	c.SetPath("/api/v1/organizations/:id/members")
+	c.SetParamNames("id")
+	c.SetParamValues(routeOrg.String())
+
+	if tenant := requestTenant(c); tenant == nil || *tenant != routeOrg {
+		t.Fatalf("got tenant %v, want the organization of the route %s", tenant, routeOrg)
+	}
+}
+
+func TestRequestTenantFallsBackToTheHeader(t *testing.T) {
+	headerOrg := uuid.New()
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/users/profile", nil)
+	req.Header.Set(auth.TenantHeader, headerOrg.String())
+	c := echo.New().NewContext(req, httptest.NewRecorder())
+	c.SetPath("/api/v1/users/profile")
+
+	if tenant := requestTenant(c); tenant == nil || *tenant != headerOrg {
+		t.Fatalf("got tenant %v, want the organization of the header %s", tenant, headerOrg)
+	}
+}
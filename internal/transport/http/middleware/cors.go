@@ -21,11 +21,17 @@ func CORS() echo.MiddlewareFunc {
 			echo.HeaderContentType,
 			echo.HeaderAccept,
 			echo.HeaderAuthorization,
+			echo.HeaderXCSRFToken,
 			"X-Requested-With",
+			"If-Match",
 		},
 		ExposeHeaders: []string{
 			echo.HeaderContentLength,
 			echo.HeaderContentType,
+			"ETag",
+			HeaderDeprecation,
+			HeaderSunset,
+			HeaderLink,
 		},
 		AllowCredentials: true,
 		MaxAge:           86400,
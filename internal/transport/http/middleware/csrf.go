@@ -0,0 +1,46 @@
+package middleware
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/echo/v4/middleware"
+	"github.com/vagonaizer/authenitfication-service/internal/config"
+	"github.com/vagonaizer/authenitfication-service/internal/dto/response"
+)
+
+// CSRF protects browser clients with a double-submit token: the token is set
+// as a cookie, and POST, PUT, PATCH and DELETE requests must repeat it in
+// the X-CSRF-Token header. Requests with an Authorization header or without
+// cookies are let through, since they carry no credentials a browser would
+// attach on its own.
+func CSRF(cfg *config.CSRFConfig) echo.MiddlewareFunc {
+	return middleware.CSRFWithConfig(middleware.CSRFConfig{
+		Skipper: func(c echo.Context) bool {
+			// Безопасные методы не пропускаем: на них выдаётся cookie с токеном
+			req := c.Request()
+			switch req.Method {
+			case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodTrace:
+				return false
+			}
+			return req.Header.Get(echo.HeaderAuthorization) != "" || len(req.Cookies()) == 0
+		},
+		TokenLookup:    "header:" + echo.HeaderXCSRFToken,
+		ContextKey:     "csrf",
+		CookieName:     cfg.CookieName,
+		CookieDomain:   cfg.CookieDomain,
+		CookiePath:     "/",
+		CookieMaxAge:   int(cfg.CookieMaxAge / time.Second),
+		CookieSecure:   cfg.CookieSecure,
+		CookieHTTPOnly: true,
+		CookieSameSite: http.SameSiteStrictMode,
+		ErrorHandler: func(err error, c echo.Context) error {
+			return c.JSON(http.StatusForbidden, response.ErrorResponse{
+				Error:   "CSRF_TOKEN_INVALID",
+				Message: "Missing or invalid CSRF token",
+				Code:    http.StatusForbidden,
+			})
+		},
+	})
+}
@@ -0,0 +1,53 @@
+package middleware
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/vagonaizer/authenitfication-service/internal/dto/response"
+)
+
+const (
+	HeaderDeprecation = "Deprecation"
+	HeaderSunset      = "Sunset"
+	HeaderLink        = "Link"
+)
+
+// Deprecation announces on every response that the routes it wraps are
+// deprecated from deprecatedAt (RFC 9745) and removed at sunset (RFC 8594),
+// linking to successor, the path of the API replacing them. Zero dates are
+// not announced. From sunset on, the routes answer 410 Gone.
+func Deprecation(deprecatedAt, sunset time.Time, successor string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			header := c.Response().Header()
+			if !deprecatedAt.IsZero() {
+				header.Set(HeaderDeprecation, "@"+strconv.FormatInt(deprecatedAt.Unix(), 10))
+			}
+			if successor != "" {
+				header.Add(HeaderLink, "<"+successor+`>; rel="successor-version"`)
+			}
+			if sunset.IsZero() {
+				return next(c)
+			}
+
+			header.Set(HeaderSunset, sunset.UTC().Format(http.TimeFormat))
+			if time.Now().Before(sunset) {
+				return next(c)
+			}
+
+			var details map[string]string
+			if successor != "" {
+				details = map[string]string{"successor": successor}
+			}
+			return c.JSON(http.StatusGone, response.ErrorResponse{
+				Error:   "API_VERSION_SUNSET",
+				Message: "This API version is no longer served",
+				Code:    http.StatusGone,
+				Details: details,
+			})
+		}
+	}
+}
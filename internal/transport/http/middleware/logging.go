@@ -5,6 +5,7 @@ import (
 
 	"github.com/labstack/echo/v4"
 	"github.com/vagonaizer/authenitfication-service/pkg/logger"
+	"github.com/vagonaizer/authenitfication-service/pkg/tracing"
 )
 
 func Logging(log *logger.Logger) echo.MiddlewareFunc {
@@ -24,6 +25,8 @@ func Logging(log *logger.Logger) echo.MiddlewareFunc {
 				"latency":    time.Since(start).String(),
 				"user_agent": req.UserAgent(),
 				"remote_ip":  c.RealIP(),
+				"trace_id":   tracing.TraceID(req.Context()),
+				"request_id": tracing.CorrelationID(req.Context()),
 			}
 
 			if userID := c.Get("user_id"); userID != nil {
@@ -0,0 +1,115 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/vagonaizer/authenitfication-service/internal/domain/services"
+	"github.com/vagonaizer/authenitfication-service/internal/dto/response"
+	"github.com/vagonaizer/authenitfication-service/pkg/auth"
+	"github.com/vagonaizer/authenitfication-service/pkg/errors"
+	"github.com/vagonaizer/authenitfication-service/pkg/logger"
+)
+
+const OrganizationHeader = auth.TenantHeader
+
+type OrganizationMiddleware struct {
+	orgService services.OrganizationService
+	logger     *logger.Logger
+}
+
+func NewOrganizationMiddleware(orgService services.OrganizationService, logger *logger.Logger) *OrganizationMiddleware {
+	return &OrganizationMiddleware{
+		orgService: orgService,
+		logger:     logger,
+	}
+}
+
+// ResolveOrganization resolves the active organization from the token claim,
+// falling back to the X-Organization-ID header, and verifies membership.
+// Requests without an organization pass through untouched.
+func (m *OrganizationMiddleware) ResolveOrganization() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			orgIDStr, _ := c.Get("org_id").(string)
+			if orgIDStr == "" {
+				orgIDStr = c.Request().Header.Get(OrganizationHeader)
+			}
+			if orgIDStr == "" {
+				return next(c)
+			}
+
+			orgID, err := uuid.Parse(orgIDStr)
+			if err != nil {
+				return c.JSON(http.StatusBadRequest, response.ErrorResponse{
+					Error:   "INVALID_ORGANIZATION_ID",
+					Message: "Invalid organization ID format",
+					Code:    http.StatusBadRequest,
+				})
+			}
+
+			userIDStr, _ := c.Get("user_id").(string)
+			userID, err := uuid.Parse(userIDStr)
+			if err != nil {
+				return c.JSON(http.StatusUnauthorized, response.ErrorResponse{
+					Error:   "UNAUTHORIZED",
+					Message: "Authentication required",
+					Code:    http.StatusUnauthorized,
+				})
+			}
+
+			member, err := m.orgService.GetMembership(c.Request().Context(), orgID, userID)
+			if err != nil {
+				return m.membershipError(c, orgID, err)
+			}
+
+			c.Set("org_id", orgID.String())
+			c.Set("org_role", member.Role)
+
+			return next(c)
+		}
+	}
+}
+
+func (m *OrganizationMiddleware) RequireOrganization() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if role, ok := c.Get("org_role").(string); !ok || role == "" {
+				return c.JSON(http.StatusBadRequest, response.ErrorResponse{
+					Error:   "ORGANIZATION_REQUIRED",
+					Message: "An active organization is required",
+					Code:    http.StatusBadRequest,
+				})
+			}
+
+			return next(c)
+		}
+	}
+}
+
+func (m *OrganizationMiddleware) membershipError(c echo.Context, orgID uuid.UUID, err error) error {
+	appErr, ok := err.(*errors.AppError)
+	if ok && appErr.Code == errors.CodeNotFound {
+		return c.JSON(http.StatusForbidden, response.ErrorResponse{
+			Error:   "NOT_ORGANIZATION_MEMBER",
+			Message: "Not a member of the organization",
+			Code:    http.StatusForbidden,
+		})
+	}
+
+	if ok && appErr.StatusCode < http.StatusInternalServerError {
+		return c.JSON(appErr.StatusCode, response.ErrorResponse{
+			Error:   appErr.Code,
+			Message: appErr.Message,
+			Code:    appErr.StatusCode,
+		})
+	}
+
+	m.logger.WithContext(c.Request().Context()).WithError(err).WithField("organization_id", orgID).Error("failed to resolve organization membership")
+	return c.JSON(http.StatusInternalServerError, response.ErrorResponse{
+		Error:   "INTERNAL_ERROR",
+		Message: "Internal server error",
+		Code:    http.StatusInternalServerError,
+	})
+}
@@ -2,20 +2,62 @@ package middleware
 
 import (
 	"net/http"
+	"path"
 	"time"
 
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
+	"github.com/vagonaizer/authenitfication-service/internal/config"
 	"github.com/vagonaizer/authenitfication-service/internal/dto/response"
 	"golang.org/x/time/rate"
 )
 
-func RateLimit(rps int) echo.MiddlewareFunc {
+// RateLimit limits requests per client IP in the memory of this replica.
+// Routes matching one of policies are limited by it instead, in buckets of
+// their own; the identity of a policy is ignored.
+func RateLimit(rps, burst int, policies []config.RateLimitPolicy) echo.MiddlewareFunc {
+	global := memoryRateLimit(rate.Limit(rps), burst)
+	routes := make([]echo.MiddlewareFunc, len(policies))
+	for i, policy := range policies {
+		routes[i] = memoryRateLimit(rate.Limit(policy.Rate), policy.Burst)
+	}
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		limited := global(next)
+		routeLimited := make([]echo.HandlerFunc, len(routes))
+		for i, route := range routes {
+			routeLimited[i] = route(next)
+		}
+
+		return func(c echo.Context) error {
+			if i := matchRateLimitPolicy(policies, c); i >= 0 {
+				return routeLimited[i](c)
+			}
+			return limited(c)
+		}
+	}
+}
+
+// matchRateLimitPolicy returns the index of the first policy matching the
+// route of c, or -1.
+func matchRateLimitPolicy(policies []config.RateLimitPolicy, c echo.Context) int {
+	for i, policy := range policies {
+		if policy.Method != "" && policy.Method != c.Request().Method {
+			continue
+		}
+		if ok, _ := path.Match(policy.Route, c.Path()); ok {
+			return i
+		}
+	}
+	return -1
+}
+
+func memoryRateLimit(limit rate.Limit, burst int) echo.MiddlewareFunc {
 	return middleware.RateLimiterWithConfig(middleware.RateLimiterConfig{
 		Store: middleware.NewRateLimiterMemoryStoreWithConfig(
 			middleware.RateLimiterMemoryStoreConfig{
-				Rate:      rate.Limit(rps),
-				Burst:     rps * 2,
+				Rate:      limit,
+				Burst:     burst,
 				ExpiresIn: time.Hour,
 			},
 		),
@@ -0,0 +1,34 @@
+package middleware
+
+import (
+	"github.com/labstack/echo/v4"
+	"github.com/vagonaizer/authenitfication-service/pkg/tracing"
+)
+
+// Tracing starts a span for the request, continuing the trace of its
+// traceparent header when it has one, and keeps its request ID as the
+// correlation ID, so the events it publishes carry both. It must run after
+// the RequestID middleware.
+func Tracing() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			req := c.Request()
+
+			// RequestID кладёт идентификатор в заголовок ответа
+			requestID := c.Response().Header().Get(echo.HeaderXRequestID)
+			if requestID == "" {
+				requestID = req.Header.Get(echo.HeaderXRequestID)
+			}
+
+			ctx := tracing.Extract(req.Context(), func(key string) string {
+				if key == tracing.HeaderCorrelationID {
+					return requestID
+				}
+				return req.Header.Get(key)
+			})
+			c.SetRequest(req.WithContext(ctx))
+
+			return next(c)
+		}
+	}
+}
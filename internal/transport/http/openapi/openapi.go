@@ -0,0 +1,418 @@
+// Package openapi describes the HTTP API as an OpenAPI 3 document. The
+// operations are listed by hand in Routes, next to the routes themselves;
+// the schemas of their bodies are derived from the request and response DTOs,
+// so they follow the structs as they change.
+package openapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/vagonaizer/authenitfication-service/internal/dto/response"
+)
+
+// Document is an OpenAPI 3.0 document, reduced to the parts the service uses.
+type Document struct {
+	OpenAPI    string                          `json:"openapi"`
+	Info       Info                            `json:"info"`
+	Paths      map[string]map[string]Operation `json:"paths"`
+	Components Components                      `json:"components"`
+}
+
+type Info struct {
+	Title   string `json:"title"`
+	Version string `json:"version"`
+}
+
+type Components struct {
+	Schemas         map[string]*Schema        `json:"schemas"`
+	SecuritySchemes map[string]SecurityScheme `json:"securitySchemes"`
+}
+
+type SecurityScheme struct {
+	Type         string `json:"type"`
+	Scheme       string `json:"scheme"`
+	BearerFormat string `json:"bearerFormat,omitempty"`
+}
+
+type Operation struct {
+	Tags        []string              `json:"tags,omitempty"`
+	Summary     string                `json:"summary,omitempty"`
+	Description string                `json:"description,omitempty"`
+	OperationID string                `json:"operationId"`
+	Parameters  []Parameter           `json:"parameters,omitempty"`
+	RequestBody *RequestBody          `json:"requestBody,omitempty"`
+	Responses   map[string]Response   `json:"responses"`
+	Security    []map[string][]string `json:"security,omitempty"`
+	Deprecated  bool                  `json:"deprecated,omitempty"`
+}
+
+type Parameter struct {
+	Name        string  `json:"name"`
+	In          string  `json:"in"`
+	Description string  `json:"description,omitempty"`
+	Required    bool    `json:"required,omitempty"`
+	Schema      *Schema `json:"schema"`
+}
+
+type RequestBody struct {
+	Required bool                 `json:"required"`
+	Content  map[string]MediaType `json:"content"`
+}
+
+type Response struct {
+	Description string               `json:"description"`
+	Content     map[string]MediaType `json:"content,omitempty"`
+}
+
+type MediaType struct {
+	Schema *Schema `json:"schema"`
+}
+
+type Schema struct {
+	Ref                  string             `json:"$ref,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Nullable             bool               `json:"nullable,omitempty"`
+	Enum                 []string           `json:"enum,omitempty"`
+	MinLength            *int               `json:"minLength,omitempty"`
+	MaxLength            *int               `json:"maxLength,omitempty"`
+	Minimum              *float64           `json:"minimum,omitempty"`
+	Maximum              *float64           `json:"maximum,omitempty"`
+	MinItems             *int               `json:"minItems,omitempty"`
+	MaxItems             *int               `json:"maxItems,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+}
+
+// Build returns the document describing routes.
+func Build(title, version string, routes []Route) *Document {
+	b := &builder{schemas: make(map[string]*Schema), types: make(map[string]reflect.Type)}
+
+	doc := &Document{
+		OpenAPI: "3.0.3",
+		Info:    Info{Title: title, Version: version},
+		Paths:   make(map[string]map[string]Operation),
+		Components: Components{
+			Schemas: b.schemas,
+			SecuritySchemes: map[string]SecurityScheme{
+				"bearerAuth": {Type: "http", Scheme: "bearer", BearerFormat: "JWT"},
+			},
+		},
+	}
+
+	for _, route := range routes {
+		path := openAPIPath(route.Path)
+		if doc.Paths[path] == nil {
+			doc.Paths[path] = make(map[string]Operation)
+		}
+		doc.Paths[path][strings.ToLower(route.Method)] = b.operation(route)
+	}
+
+	return doc
+}
+
+// JSON returns the document encoded as JSON.
+func (d *Document) JSON() ([]byte, error) {
+	return json.Marshal(d)
+}
+
+// Undocumented returns the "METHOD path" of those of routes, given as echo
+// method and path pairs, that the document does not describe.
+func (d *Document) Undocumented(routes [][2]string) []string {
+	var missing []string
+	for _, route := range routes {
+		if _, ok := d.Paths[openAPIPath(route[1])][strings.ToLower(route[0])]; !ok {
+			missing = append(missing, route[0]+" "+route[1])
+		}
+	}
+	sort.Strings(missing)
+	return missing
+}
+
+type builder struct {
+	schemas map[string]*Schema
+	types   map[string]reflect.Type
+}
+
+func (b *builder) operation(route Route) Operation {
+	op := Operation{
+		Tags:        []string{route.Tag},
+		Summary:     route.Summary,
+		OperationID: operationID(route.Method, route.Path),
+		Responses:   make(map[string]Response),
+		Deprecated:  route.Deprecated,
+	}
+
+	if route.Permission != "" {
+		op.Description = "Requires the " + route.Permission + " permission."
+	}
+	if route.Auth || route.Permission != "" {
+		op.Security = []map[string][]string{{"bearerAuth": {}}}
+	}
+
+	for _, name := range pathParams(route.Path) {
+		schema := &Schema{Type: "string"}
+		if strings.HasSuffix(name, "id") {
+			schema.Format = "uuid"
+		}
+		op.Parameters = append(op.Parameters, Parameter{Name: name, In: "path", Required: true, Schema: schema})
+	}
+	for _, query := range route.Query {
+		op.Parameters = append(op.Parameters, Parameter{
+			Name:        query.Name,
+			In:          "query",
+			Description: query.Description,
+			Schema:      &Schema{Type: query.Type},
+		})
+	}
+
+	if route.Body != nil {
+		op.RequestBody = &RequestBody{
+			Required: true,
+			Content:  map[string]MediaType{"application/json": {Schema: b.schema(reflect.TypeOf(route.Body))}},
+		}
+	}
+	if route.Upload != "" {
+		op.RequestBody = &RequestBody{
+			Required: true,
+			Content: map[string]MediaType{"multipart/form-data": {Schema: &Schema{
+				Type:       "object",
+				Properties: map[string]*Schema{route.Upload: {Type: "string", Format: "binary"}},
+				Required:   []string{route.Upload},
+			}}},
+		}
+	}
+
+	status := route.Status
+	if status == 0 {
+		status = http.StatusOK
+	}
+	result := Response{Description: http.StatusText(status)}
+	if route.Response != nil {
+		mediaType := "application/json"
+		if route.Stream {
+			mediaType = "text/event-stream"
+		}
+		result.Content = map[string]MediaType{mediaType: {Schema: b.schema(reflect.TypeOf(route.Response))}}
+	}
+	op.Responses[strconv.Itoa(status)] = result
+
+	errorContent := map[string]MediaType{"application/json": {Schema: b.schema(reflect.TypeOf(response.ErrorResponse{}))}}
+	op.Responses["default"] = Response{Description: "Error", Content: errorContent}
+
+	return op
+}
+
+var (
+	timeType = reflect.TypeOf(time.Time{})
+	uuidType = reflect.TypeOf(uuid.UUID{})
+	rawType  = reflect.TypeOf(json.RawMessage{})
+)
+
+// schema returns the schema of t, registering the structs it refers to as
+// components.
+func (b *builder) schema(t reflect.Type) *Schema {
+	switch t {
+	case timeType:
+		return &Schema{Type: "string", Format: "date-time"}
+	case uuidType:
+		return &Schema{Type: "string", Format: "uuid"}
+	case rawType:
+		return &Schema{}
+	}
+
+	switch t.Kind() {
+	case reflect.Pointer:
+		schema := *b.schema(t.Elem())
+		if schema.Ref != "" {
+			return &schema
+		}
+		schema.Nullable = true
+		return &schema
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &Schema{Type: "integer"}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number"}
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &Schema{Type: "string", Format: "byte"}
+		}
+		return &Schema{Type: "array", Items: b.schema(t.Elem())}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: b.schema(t.Elem())}
+	case reflect.Struct:
+		return b.component(t)
+	}
+
+	// interface{} и прочее принимают любое значение
+	return &Schema{}
+}
+
+func (b *builder) component(t reflect.Type) *Schema {
+	name := t.Name()
+	if existing, ok := b.types[name]; ok && existing != t {
+		name = strings.ReplaceAll(t.PkgPath()[strings.LastIndex(t.PkgPath(), "/")+1:], "_", "") + name
+	}
+	ref := &Schema{Ref: "#/components/schemas/" + name}
+	if _, ok := b.types[name]; ok {
+		return ref
+	}
+
+	// Регистрируем до обхода полей, чтобы рекурсивные типы ссылались сами на себя
+	schema := &Schema{Type: "object", Properties: make(map[string]*Schema)}
+	b.types[name] = t
+	b.schemas[name] = schema
+	b.fields(t, schema)
+
+	return ref
+}
+
+func (b *builder) fields(t reflect.Type, schema *Schema) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name := strings.Split(tag, ",")[0]
+
+		// Встроенные структуры без имени в JSON раскрываются в родителя
+		if field.Anonymous && name == "" {
+			embedded := field.Type
+			if embedded.Kind() == reflect.Pointer {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				b.fields(embedded, schema)
+				continue
+			}
+		}
+
+		if name == "" {
+			name = field.Name
+		}
+
+		property := b.schema(field.Type)
+		if applyValidation(property, field.Tag.Get("validate")) {
+			schema.Required = append(schema.Required, name)
+		}
+		schema.Properties[name] = property
+	}
+}
+
+// applyValidation carries the validate rules of a field over to its schema
+// and reports whether the field is required.
+func applyValidation(schema *Schema, tag string) bool {
+	if tag == "" || schema.Ref != "" {
+		return strings.Contains(tag, "required")
+	}
+
+	required := false
+	for _, rule := range strings.Split(tag, ",") {
+		name, param, _ := strings.Cut(rule, "=")
+		switch name {
+		case "required":
+			required = true
+		case "email":
+			schema.Format = "email"
+		case "uuid":
+			schema.Format = "uuid"
+		case "oneof":
+			schema.Enum = strings.Fields(param)
+		case "min", "max", "len":
+			n, err := strconv.Atoi(param)
+			if err != nil {
+				continue
+			}
+			setBound(schema, name, n)
+		}
+	}
+	return required
+}
+
+func setBound(schema *Schema, rule string, n int) {
+	value := float64(n)
+	switch schema.Type {
+	case "string":
+		if rule != "max" {
+			schema.MinLength = &n
+		}
+		if rule != "min" {
+			schema.MaxLength = &n
+		}
+	case "array":
+		if rule != "max" {
+			schema.MinItems = &n
+		}
+		if rule != "min" {
+			schema.MaxItems = &n
+		}
+	case "integer", "number":
+		if rule != "max" {
+			schema.Minimum = &value
+		}
+		if rule != "min" {
+			schema.Maximum = &value
+		}
+	}
+}
+
+// openAPIPath turns the echo parameters of path, ":id", into "{id}".
+func openAPIPath(path string) string {
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		if strings.HasPrefix(segment, ":") {
+			segments[i] = "{" + segment[1:] + "}"
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+func pathParams(path string) []string {
+	var params []string
+	for _, segment := range strings.Split(path, "/") {
+		if strings.HasPrefix(segment, ":") {
+			params = append(params, segment[1:])
+		}
+	}
+	return params
+}
+
+// operationID derives a unique ID from the method and path, e.g.
+// "post_api_v1_auth_login".
+func operationID(method, path string) string {
+	id := strings.ToLower(method)
+	for _, segment := range strings.Split(path, "/") {
+		segment = strings.TrimPrefix(segment, ":")
+		if segment == "" {
+			continue
+		}
+		id += "_" + strings.NewReplacer("-", "_", ".", "_").Replace(segment)
+	}
+	return id
+}
+
+// Param is a query parameter of an operation.
+type Param struct {
+	Name        string
+	Type        string
+	Description string
+}
@@ -0,0 +1,203 @@
+package openapi
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/vagonaizer/authenitfication-service/internal/domain/entities"
+	"github.com/vagonaizer/authenitfication-service/internal/dto/request"
+	"github.com/vagonaizer/authenitfication-service/internal/dto/response"
+)
+
+// Route describes one operation of the API. Path uses the echo syntax of
+// routes.SetupRoutes; Body and Response are zero values of the DTOs sent and
+// returned. Status defaults to 200. Stream marks routes answering with
+// server-sent events, whose data is Response. Deprecated marks the routes of
+// a version whose retirement is scheduled.
+type Route struct {
+	Method     string
+	Path       string
+	Tag        string
+	Summary    string
+	Auth       bool
+	Permission string
+	Query      []Param
+	Body       interface{}
+	Upload     string
+	Status     int
+	Response   interface{}
+	Stream     bool
+	Deprecated bool
+}
+
+// Version is a version of the API, served under /api/<Name>.
+type Version struct {
+	Name       string
+	Deprecated bool
+}
+
+// Versioned returns routes, whose API paths are written for v1, with their
+// API routes served under each of versions. Build keeps the last of routes
+// sharing a method and path, so a route changed in a later version is
+// listed after those Versioned returns.
+func Versioned(routes []Route, versions []Version) []Route {
+	const v1Prefix = "/api/v1/"
+
+	var result []Route
+	for _, route := range routes {
+		if !strings.HasPrefix(route.Path, v1Prefix) {
+			result = append(result, route)
+		}
+	}
+	for _, version := range versions {
+		for _, route := range routes {
+			if !strings.HasPrefix(route.Path, v1Prefix) {
+				continue
+			}
+			route.Path = "/api/" + version.Name + "/" + strings.TrimPrefix(route.Path, v1Prefix)
+			route.Deprecated = version.Deprecated
+			result = append(result, route)
+		}
+	}
+	return result
+}
+
+var paging = []Param{
+	{Name: "page", Type: "integer", Description: "Page number, from 1"},
+	{Name: "page_size", Type: "integer", Description: "Items per page, up to 100"},
+}
+
+// Routes lists the operations of the API, with the paths of v1. A route
+// added to SetupRoutes belongs here too; the server logs those missing on
+// start.
+var Routes = []Route{
+	{Method: http.MethodGet, Path: "/health", Tag: "health", Summary: "Check every dependency and report the build", Response: response.HealthResponse{}},
+	{Method: http.MethodGet, Path: "/ready", Tag: "health", Summary: "Readiness probe, checking the required dependencies", Response: response.HealthResponse{}},
+	{Method: http.MethodGet, Path: "/live", Tag: "health", Summary: "Liveness probe", Response: map[string]string{}},
+
+	{Method: http.MethodGet, Path: "/.well-known/openid-configuration", Tag: "discovery", Summary: "Discovery document of the endpoints and tokens", Response: response.OpenIDConfigurationResponse{}},
+	{Method: http.MethodGet, Path: "/.well-known/jwks.json", Tag: "discovery", Summary: "Public signing keys, none while tokens are signed with HMAC", Response: response.JWKSResponse{}},
+	{Method: http.MethodGet, Path: "/.well-known/change-password", Tag: "discovery", Summary: "Redirect to the page for changing passwords", Status: http.StatusFound},
+	{Method: http.MethodGet, Path: "/.well-known/security.txt", Tag: "discovery", Summary: "Security contacts (RFC 9116), as text/plain"},
+
+	{Method: http.MethodPost, Path: "/api/v1/graphql", Tag: "graphql", Summary: "Run a GraphQL query over users, roles and sessions", Auth: true, Body: request.GraphQLRequest{}, Response: map[string]interface{}{}},
+
+	{Method: http.MethodPost, Path: "/api/v1/auth/register", Tag: "auth", Summary: "Register an account", Body: request.RegisterRequest{}, Status: http.StatusCreated, Response: response.AuthResponse{}},
+	{Method: http.MethodPost, Path: "/api/v1/auth/login", Tag: "auth", Summary: "Log in with email and password", Body: request.LoginRequest{}, Response: response.AuthResponse{}},
+	{Method: http.MethodPost, Path: "/api/v1/auth/refresh", Tag: "auth", Summary: "Exchange a refresh token for new tokens", Body: request.RefreshTokenRequest{}, Response: response.TokenResponse{}},
+	{Method: http.MethodPost, Path: "/api/v1/auth/token", Tag: "auth", Summary: "Issue a token to a service account", Body: request.ServiceAccountTokenRequest{}, Response: response.TokenResponse{}},
+	{Method: http.MethodPost, Path: "/api/v1/auth/logout", Tag: "auth", Summary: "Revoke a refresh token", Body: request.LogoutRequest{}, Response: response.SuccessResponse{}},
+	{Method: http.MethodGet, Path: "/api/v1/auth/verify", Tag: "auth", Summary: "Validate an access token", Auth: true, Response: response.TokenClaimsResponse{}},
+	{Method: http.MethodGet, Path: "/api/v1/auth/forward", Tag: "auth", Summary: "Authenticate a request for a reverse proxy (forwardAuth, auth_request)", Auth: true},
+	{Method: http.MethodGet, Path: "/api/v1/auth/csrf", Tag: "auth", Summary: "Get the CSRF token of the browser", Response: response.CSRFTokenResponse{}},
+	{Method: http.MethodPost, Path: "/api/v1/auth/resend-verification", Tag: "auth", Summary: "Resend the verification email", Body: request.ResendVerificationRequest{}, Status: http.StatusAccepted, Response: response.SuccessResponse{}},
+	{Method: http.MethodPost, Path: "/api/v1/auth/verify-email", Tag: "auth", Summary: "Verify an email address", Body: request.VerifyEmailRequest{}, Response: response.SuccessResponse{}},
+	{Method: http.MethodPost, Path: "/api/v1/auth/reset-password", Tag: "auth", Summary: "Email a password reset token", Body: request.ResetPasswordRequest{}, Status: http.StatusAccepted, Response: response.SuccessResponse{}},
+	{Method: http.MethodPost, Path: "/api/v1/auth/reset-password/confirm", Tag: "auth", Summary: "Set a new password with a reset token", Body: request.ConfirmResetPasswordRequest{}, Response: response.SuccessResponse{}},
+	{Method: http.MethodPost, Path: "/api/v1/auth/change-password", Tag: "auth", Summary: "Change the caller's password", Auth: true, Body: request.ChangePasswordRequest{}, Response: response.SuccessResponse{}},
+	{Method: http.MethodPost, Path: "/api/v1/auth/switch-organization", Tag: "auth", Summary: "Switch the active organization", Auth: true, Body: request.SwitchOrganizationRequest{}, Response: response.TokenResponse{}},
+
+	{Method: http.MethodGet, Path: "/api/v1/users/profile", Tag: "profile", Summary: "Get the caller's profile", Auth: true, Response: response.UserResponse{}},
+	{Method: http.MethodPut, Path: "/api/v1/users/profile", Tag: "profile", Summary: "Update the caller's profile", Auth: true, Body: request.UpdateUserRequest{}, Response: response.UserResponse{}},
+	{Method: http.MethodDelete, Path: "/api/v1/users/profile", Tag: "profile", Summary: "Delete the caller's account", Auth: true, Response: response.SuccessResponse{}},
+	{Method: http.MethodPut, Path: "/api/v1/users/profile/avatar", Tag: "profile", Summary: "Upload the caller's avatar", Auth: true, Upload: "avatar", Response: response.UserResponse{}},
+	{Method: http.MethodDelete, Path: "/api/v1/users/profile/avatar", Tag: "profile", Summary: "Remove the caller's avatar", Auth: true, Response: response.SuccessResponse{}},
+	{Method: http.MethodPost, Path: "/api/v1/users/profile/purge", Tag: "profile", Summary: "Purge the caller's account at once", Auth: true, Body: request.PurgeAccountRequest{}, Response: response.SuccessResponse{}},
+	{Method: http.MethodPost, Path: "/api/v1/users/profile/deletion", Tag: "profile", Summary: "Schedule the caller's account for deletion", Auth: true, Body: request.ScheduleDeletionRequest{}, Status: http.StatusAccepted, Response: response.DeletionScheduleResponse{}},
+	{Method: http.MethodDelete, Path: "/api/v1/users/profile/deletion", Tag: "profile", Summary: "Cancel the scheduled deletion", Auth: true, Response: response.SuccessResponse{}},
+	{Method: http.MethodPost, Path: "/api/v1/users/profile/merge", Tag: "profile", Summary: "Merge another account of the caller into this one", Auth: true, Body: request.MergeOwnAccountRequest{}, Response: response.UserMergeResponse{}},
+	{Method: http.MethodPut, Path: "/api/v1/users/profile/phone", Tag: "profile", Summary: "Start verifying a phone number", Auth: true, Body: request.StartPhoneVerificationRequest{}, Status: http.StatusAccepted, Response: response.SuccessResponse{}},
+	{Method: http.MethodPost, Path: "/api/v1/users/profile/phone/verify", Tag: "profile", Summary: "Confirm the phone number with its code", Auth: true, Body: request.VerifyPhoneRequest{}, Response: response.SuccessResponse{}},
+	{Method: http.MethodDelete, Path: "/api/v1/users/profile/phone", Tag: "profile", Summary: "Remove the phone number", Auth: true, Response: response.SuccessResponse{}},
+	{Method: http.MethodGet, Path: "/api/v1/users/activity", Tag: "profile", Summary: "List the caller's activity", Auth: true, Query: append(paging, Param{Name: "type", Type: "string", Description: "Only activity of this type"}), Response: response.UserActivityListResponse{}},
+	{Method: http.MethodGet, Path: "/api/v1/users/events", Tag: "profile", Summary: "Stream the caller's session and security events", Auth: true, Response: map[string]interface{}{}, Stream: true},
+	{Method: http.MethodGet, Path: "/api/v1/users/logins", Tag: "profile", Summary: "List the caller's login attempts", Auth: true, Query: paging, Response: response.LoginHistoryResponse{}},
+	{Method: http.MethodGet, Path: "/api/v1/users/:id", Tag: "users", Summary: "Get a user", Auth: true, Response: response.UserResponse{}},
+	{Method: http.MethodGet, Path: "/api/v1/users/:id/roles", Tag: "users", Summary: "List the roles of a user", Auth: true, Query: []Param{{Name: "scope_id", Type: "string", Description: "Only roles of this organization"}}, Response: response.UserRolesResponse{}},
+
+	{Method: http.MethodPost, Path: "/api/v1/organizations", Tag: "organizations", Summary: "Create an organization", Auth: true, Body: request.CreateOrganizationRequest{}, Status: http.StatusCreated, Response: response.OrganizationResponse{}},
+	{Method: http.MethodGet, Path: "/api/v1/organizations", Tag: "organizations", Summary: "List the caller's organizations", Auth: true, Response: response.OrganizationsListResponse{}},
+	{Method: http.MethodGet, Path: "/api/v1/organizations/:id", Tag: "organizations", Summary: "Get an organization", Auth: true, Response: response.OrganizationResponse{}},
+	{Method: http.MethodPut, Path: "/api/v1/organizations/:id", Tag: "organizations", Summary: "Update an organization", Auth: true, Body: request.UpdateOrganizationRequest{}, Response: response.OrganizationResponse{}},
+	{Method: http.MethodDelete, Path: "/api/v1/organizations/:id", Tag: "organizations", Summary: "Delete an organization", Auth: true, Response: response.SuccessResponse{}},
+	{Method: http.MethodGet, Path: "/api/v1/organizations/:id/members", Tag: "organizations", Summary: "List the members of an organization", Auth: true, Response: response.OrganizationMembersResponse{}},
+	{Method: http.MethodPost, Path: "/api/v1/organizations/:id/members", Tag: "organizations", Summary: "Add a member without an invitation", Permission: entities.PermissionUsersManage, Body: request.AddOrganizationMemberRequest{}, Status: http.StatusCreated, Response: response.SuccessResponse{}},
+	{Method: http.MethodPut, Path: "/api/v1/organizations/:id/members/:user_id", Tag: "organizations", Summary: "Change the role of a member", Auth: true, Body: request.UpdateOrganizationMemberRequest{}, Response: response.SuccessResponse{}},
+	{Method: http.MethodDelete, Path: "/api/v1/organizations/:id/members/:user_id", Tag: "organizations", Summary: "Remove a member", Auth: true, Response: response.SuccessResponse{}},
+	{Method: http.MethodGet, Path: "/api/v1/organizations/:id/invitations", Tag: "organizations", Summary: "List pending invitations", Auth: true, Response: response.InvitationsListResponse{}},
+	{Method: http.MethodPost, Path: "/api/v1/organizations/:id/invitations", Tag: "organizations", Summary: "Invite someone by email", Auth: true, Body: request.CreateInvitationRequest{}, Status: http.StatusCreated, Response: response.InvitationResponse{}},
+	{Method: http.MethodDelete, Path: "/api/v1/organizations/:id/invitations/:invitation_id", Tag: "organizations", Summary: "Revoke an invitation", Auth: true, Response: response.SuccessResponse{}},
+	{Method: http.MethodPost, Path: "/api/v1/invitations/accept", Tag: "organizations", Summary: "Accept an invitation", Auth: true, Body: request.InvitationTokenRequest{}, Response: response.OrganizationResponse{}},
+	{Method: http.MethodPost, Path: "/api/v1/invitations/decline", Tag: "organizations", Summary: "Decline an invitation", Auth: true, Body: request.InvitationTokenRequest{}, Response: response.SuccessResponse{}},
+
+	{Method: http.MethodGet, Path: "/api/v1/admin/users", Tag: "admin: users", Summary: "List users", Permission: entities.PermissionUsersRead, Query: append(paging,
+		Param{Name: "search", Type: "string", Description: "Match email, username or name"},
+		Param{Name: "sort_by", Type: "string", Description: "created_at, updated_at, email or username"},
+		Param{Name: "sort_dir", Type: "string", Description: "asc or desc"},
+	), Response: response.UsersListResponse{}},
+	{Method: http.MethodPost, Path: "/api/v1/admin/users", Tag: "admin: users", Summary: "Create a user", Permission: entities.PermissionUsersManage, Body: request.CreateUserRequest{}, Status: http.StatusCreated, Response: response.CreateUserResponse{}},
+	{Method: http.MethodPost, Path: "/api/v1/admin/users/import", Tag: "admin: users", Summary: "Import users from JSON or CSV", Permission: entities.PermissionUsersManage, Body: request.ImportUsersRequest{}, Response: response.ImportUsersResponse{}},
+	{Method: http.MethodGet, Path: "/api/v1/admin/users/stats", Tag: "admin: users", Summary: "Get user statistics", Permission: entities.PermissionUsersRead, Query: []Param{{Name: "days", Type: "integer", Description: "Days of daily counts"}}, Response: response.UserStatsResponse{}},
+	{Method: http.MethodGet, Path: "/api/v1/admin/users/search", Tag: "admin: users", Summary: "Search users", Permission: entities.PermissionUsersRead, Query: []Param{
+		{Name: "q", Type: "string", Description: "Search query"},
+		{Name: "limit", Type: "integer", Description: "Maximum number of results"},
+	}, Response: response.UserSearchResponse{}},
+	{Method: http.MethodGet, Path: "/api/v1/admin/users/:id", Tag: "admin: users", Summary: "Get a user with admin details", Permission: entities.PermissionUsersRead, Response: response.AdminUserResponse{}},
+	{Method: http.MethodGet, Path: "/api/v1/admin/users/:id/notes", Tag: "admin: users", Summary: "List the notes on a user", Permission: entities.PermissionUsersRead, Response: []response.UserNoteResponse{}},
+	{Method: http.MethodPost, Path: "/api/v1/admin/users/:id/notes", Tag: "admin: users", Summary: "Add a note on a user", Permission: entities.PermissionUsersNotes, Body: request.UserNoteRequest{}, Status: http.StatusCreated, Response: response.UserNoteResponse{}},
+	{Method: http.MethodPut, Path: "/api/v1/admin/users/:id/notes/:note_id", Tag: "admin: users", Summary: "Edit a note", Permission: entities.PermissionUsersNotes, Body: request.UserNoteRequest{}, Response: response.UserNoteResponse{}},
+	{Method: http.MethodDelete, Path: "/api/v1/admin/users/:id/notes/:note_id", Tag: "admin: users", Summary: "Delete a note", Permission: entities.PermissionUsersNotes, Response: response.SuccessResponse{}},
+	{Method: http.MethodPost, Path: "/api/v1/admin/users/:id/activate", Tag: "admin: users", Summary: "Activate a user", Permission: entities.PermissionUsersActivate, Response: response.SuccessResponse{}},
+	{Method: http.MethodPost, Path: "/api/v1/admin/users/:id/deactivate", Tag: "admin: users", Summary: "Deactivate a user", Permission: entities.PermissionUsersDeactivate, Response: response.SuccessResponse{}},
+	{Method: http.MethodGet, Path: "/api/v1/admin/users/:id/activity", Tag: "admin: users", Summary: "List the activity of a user", Permission: entities.PermissionUsersRead, Query: append(paging, Param{Name: "type", Type: "string", Description: "Only activity of this type"}), Response: response.UserActivityListResponse{}},
+	{Method: http.MethodGet, Path: "/api/v1/admin/users/:id/logins", Tag: "admin: users", Summary: "List the login attempts of a user", Permission: entities.PermissionUsersRead, Query: paging, Response: response.LoginHistoryResponse{}},
+	{Method: http.MethodDelete, Path: "/api/v1/admin/users/:id/sessions", Tag: "admin: users", Summary: "Sign a user out of every session", Permission: entities.PermissionUsersManage, Response: response.SuccessResponse{}},
+	{Method: http.MethodPost, Path: "/api/v1/admin/users/:id/merge", Tag: "admin: users", Summary: "Merge a duplicate into a user; its roles are only moved when the caller holds roles:assign", Permission: entities.PermissionUsersManage, Body: request.MergeUsersRequest{}, Response: response.UserMergeResponse{}},
+	{Method: http.MethodPost, Path: "/api/v1/admin/users/:id/ban", Tag: "admin: users", Summary: "Ban a user", Permission: entities.PermissionUsersBan, Body: request.BanUserRequest{}, Response: response.UserBanResponse{}},
+	{Method: http.MethodDelete, Path: "/api/v1/admin/users/:id/ban", Tag: "admin: users", Summary: "Lift the ban of a user", Permission: entities.PermissionUsersBan, Response: response.SuccessResponse{}},
+	{Method: http.MethodPost, Path: "/api/v1/admin/users/:id/password-change", Tag: "admin: users", Summary: "Require a password change", Permission: entities.PermissionUsersManage, Response: response.SuccessResponse{}},
+	{Method: http.MethodDelete, Path: "/api/v1/admin/users/:id/password-change", Tag: "admin: users", Summary: "Waive a required password change", Permission: entities.PermissionUsersManage, Response: response.SuccessResponse{}},
+	{Method: http.MethodPost, Path: "/api/v1/admin/users/roles/assign", Tag: "admin: users", Summary: "Assign a role to a user", Permission: entities.PermissionRolesAssign, Body: request.AssignRoleRequest{}, Response: response.SuccessResponse{}},
+	{Method: http.MethodDelete, Path: "/api/v1/admin/users/roles/remove", Tag: "admin: users", Summary: "Remove a role from a user", Permission: entities.PermissionRolesAssign, Body: request.RemoveRoleRequest{}, Response: response.SuccessResponse{}},
+	{Method: http.MethodPost, Path: "/api/v1/admin/users/roles/bulk-assign", Tag: "admin: users", Summary: "Assign a role to many users", Permission: entities.PermissionRolesAssign, Body: request.BulkAssignRoleRequest{}, Response: response.BulkRoleResponse{}},
+	{Method: http.MethodPost, Path: "/api/v1/admin/users/roles/bulk-remove", Tag: "admin: users", Summary: "Remove a role from many users", Permission: entities.PermissionRolesAssign, Body: request.BulkRemoveRoleRequest{}, Response: response.BulkRoleResponse{}},
+	{Method: http.MethodGet, Path: "/api/v1/admin/users/roles/audit", Tag: "admin: users", Summary: "List role assignment changes", Permission: entities.PermissionRolesRead, Query: append(paging,
+		Param{Name: "action", Type: "string", Description: "assigned or removed"},
+		Param{Name: "user_id", Type: "string", Description: "Only changes of this user"},
+		Param{Name: "role_id", Type: "string", Description: "Only changes of this role"},
+	), Response: response.RoleAuditListResponse{}},
+
+	{Method: http.MethodGet, Path: "/api/v1/admin/roles", Tag: "admin: roles", Summary: "List roles", Permission: entities.PermissionRolesRead, Response: response.RolesListResponse{}},
+	{Method: http.MethodGet, Path: "/api/v1/admin/roles/:id", Tag: "admin: roles", Summary: "Get a role", Permission: entities.PermissionRolesRead, Response: response.RoleResponse{}},
+	{Method: http.MethodPost, Path: "/api/v1/admin/roles", Tag: "admin: roles", Summary: "Create a role", Permission: entities.PermissionRolesManage, Body: request.CreateRoleRequest{}, Status: http.StatusCreated, Response: response.RoleResponse{}},
+	{Method: http.MethodPut, Path: "/api/v1/admin/roles/:id", Tag: "admin: roles", Summary: "Update a role", Permission: entities.PermissionRolesManage, Body: request.UpdateRoleRequest{}, Response: response.RoleResponse{}},
+	{Method: http.MethodDelete, Path: "/api/v1/admin/roles/:id", Tag: "admin: roles", Summary: "Delete a role", Permission: entities.PermissionRolesManage, Response: response.SuccessResponse{}},
+	{Method: http.MethodGet, Path: "/api/v1/admin/roles/:id/permissions", Tag: "admin: roles", Summary: "List the permissions of a role", Permission: entities.PermissionRolesRead, Response: response.RolePermissionsResponse{}},
+	{Method: http.MethodPost, Path: "/api/v1/admin/roles/:id/permissions", Tag: "admin: roles", Summary: "Grant a permission to a role", Permission: entities.PermissionRolesManage, Body: request.GrantPermissionRequest{}, Response: response.SuccessResponse{}},
+	{Method: http.MethodDelete, Path: "/api/v1/admin/roles/:id/permissions/:permission", Tag: "admin: roles", Summary: "Revoke a permission from a role", Permission: entities.PermissionRolesManage, Response: response.SuccessResponse{}},
+
+	{Method: http.MethodGet, Path: "/api/v1/admin/groups", Tag: "admin: groups", Summary: "List groups", Permission: entities.PermissionGroupsRead, Response: response.GroupsListResponse{}},
+	{Method: http.MethodGet, Path: "/api/v1/admin/groups/:id", Tag: "admin: groups", Summary: "Get a group", Permission: entities.PermissionGroupsRead, Response: response.GroupResponse{}},
+	{Method: http.MethodPost, Path: "/api/v1/admin/groups", Tag: "admin: groups", Summary: "Create a group", Permission: entities.PermissionGroupsManage, Body: request.CreateGroupRequest{}, Status: http.StatusCreated, Response: response.GroupResponse{}},
+	{Method: http.MethodPut, Path: "/api/v1/admin/groups/:id", Tag: "admin: groups", Summary: "Update a group", Permission: entities.PermissionGroupsManage, Body: request.UpdateGroupRequest{}, Response: response.GroupResponse{}},
+	{Method: http.MethodDelete, Path: "/api/v1/admin/groups/:id", Tag: "admin: groups", Summary: "Delete a group", Permission: entities.PermissionGroupsManage, Response: response.SuccessResponse{}},
+	{Method: http.MethodGet, Path: "/api/v1/admin/groups/:id/members", Tag: "admin: groups", Summary: "List the members of a group", Permission: entities.PermissionGroupsRead, Response: response.GroupMembersResponse{}},
+	{Method: http.MethodPost, Path: "/api/v1/admin/groups/:id/members", Tag: "admin: groups", Summary: "Add a member to a group", Permission: entities.PermissionGroupsManage, Body: request.GroupMemberRequest{}, Status: http.StatusCreated, Response: response.SuccessResponse{}},
+	{Method: http.MethodDelete, Path: "/api/v1/admin/groups/:id/members/:user_id", Tag: "admin: groups", Summary: "Remove a member from a group", Permission: entities.PermissionGroupsManage, Response: response.SuccessResponse{}},
+	{Method: http.MethodGet, Path: "/api/v1/admin/groups/:id/roles", Tag: "admin: groups", Summary: "List the roles of a group", Permission: entities.PermissionGroupsRead, Response: response.GroupRolesResponse{}},
+	{Method: http.MethodPost, Path: "/api/v1/admin/groups/:id/roles", Tag: "admin: groups", Summary: "Assign a role to a group", Permission: entities.PermissionGroupsManage, Body: request.GroupRoleRequest{}, Response: response.SuccessResponse{}},
+	{Method: http.MethodDelete, Path: "/api/v1/admin/groups/:id/roles/:role_id", Tag: "admin: groups", Summary: "Remove a role from a group", Permission: entities.PermissionGroupsManage, Response: response.SuccessResponse{}},
+
+	{Method: http.MethodGet, Path: "/api/v1/admin/organizations/:id/quotas", Tag: "admin: organizations", Summary: "Get the quotas of an organization", Permission: entities.PermissionQuotasRead, Response: response.OrganizationQuotaResponse{}},
+	{Method: http.MethodPut, Path: "/api/v1/admin/organizations/:id/quotas", Tag: "admin: organizations", Summary: "Update the quotas of an organization", Permission: entities.PermissionQuotasManage, Body: request.UpdateOrganizationQuotaRequest{}, Response: response.OrganizationQuotaResponse{}},
+
+	{Method: http.MethodGet, Path: "/api/v1/admin/service-accounts", Tag: "admin: service accounts", Summary: "List service accounts", Permission: entities.PermissionServiceAccountsRead, Query: paging, Response: response.ServiceAccountsListResponse{}},
+	{Method: http.MethodGet, Path: "/api/v1/admin/service-accounts/:id", Tag: "admin: service accounts", Summary: "Get a service account", Permission: entities.PermissionServiceAccountsRead, Response: response.ServiceAccountResponse{}},
+	{Method: http.MethodPost, Path: "/api/v1/admin/service-accounts", Tag: "admin: service accounts", Summary: "Create a service account", Permission: entities.PermissionServiceAccountsManage, Body: request.CreateServiceAccountRequest{}, Status: http.StatusCreated, Response: response.ServiceAccountCredentialsResponse{}},
+	{Method: http.MethodDelete, Path: "/api/v1/admin/service-accounts/:id", Tag: "admin: service accounts", Summary: "Delete a service account", Permission: entities.PermissionServiceAccountsManage, Response: response.SuccessResponse{}},
+	{Method: http.MethodPost, Path: "/api/v1/admin/service-accounts/:id/keys", Tag: "admin: service accounts", Summary: "Create a key", Permission: entities.PermissionServiceAccountsManage, Status: http.StatusCreated, Response: response.ServiceAccountCredentialsResponse{}},
+	{Method: http.MethodPost, Path: "/api/v1/admin/service-accounts/:id/keys/rotate", Tag: "admin: service accounts", Summary: "Replace the keys with a new one", Permission: entities.PermissionServiceAccountsManage, Status: http.StatusCreated, Response: response.ServiceAccountCredentialsResponse{}},
+	{Method: http.MethodDelete, Path: "/api/v1/admin/service-accounts/:id/keys/:key_id", Tag: "admin: service accounts", Summary: "Revoke a key", Permission: entities.PermissionServiceAccountsManage, Response: response.SuccessResponse{}},
+
+	{Method: http.MethodPost, Path: "/api/v1/admin/events/replay", Tag: "admin: events", Summary: "Republish logged events", Permission: entities.PermissionEventsReplay, Body: request.ReplayEventsRequest{}, Response: response.ReplayEventsResponse{}},
+}
@@ -2,6 +2,8 @@ package routes
 
 import (
 	"github.com/labstack/echo/v4"
+	"github.com/vagonaizer/authenitfication-service/internal/domain/entities"
+	"github.com/vagonaizer/authenitfication-service/internal/infrastructure/metrics"
 	"github.com/vagonaizer/authenitfication-service/internal/transport/http/handlers"
 	"github.com/vagonaizer/authenitfication-service/internal/transport/http/middleware"
 )
@@ -10,50 +12,215 @@ func SetupRoutes(
 	e *echo.Echo,
 	authHandler *handlers.AuthHandler,
 	userHandler *handlers.UserHandler,
+	roleHandler *handlers.RoleHandler,
+	orgHandler *handlers.OrganizationHandler,
+	groupHandler *handlers.GroupHandler,
+	serviceAccountHandler *handlers.ServiceAccountHandler,
+	quotaHandler *handlers.QuotaHandler,
+	verificationHandler *handlers.VerificationHandler,
+	statsHandler *handlers.StatsHandler,
+	eventHandler *handlers.EventHandler,
 	healthHandler *handlers.HealthHandler,
+	docsHandler *handlers.DocsHandler,
+	adminUIHandler *handlers.AdminUIHandler,
+	wellKnownHandler *handlers.WellKnownHandler,
+	graphqlHandler *handlers.GraphQLHandler,
+	userEventHandler *handlers.UserEventHandler,
 	authMiddleware *middleware.AuthMiddleware,
+	orgMiddleware *middleware.OrganizationMiddleware,
+	versions []Version,
 ) {
 	// Health check routes
 	e.GET("/health", healthHandler.Health)
 	e.GET("/ready", healthHandler.Ready)
 	e.GET("/live", healthHandler.Live)
 
-	// API v1 routes
-	v1 := e.Group("/api/v1")
-
-	// Auth routes (public)
-	auth := v1.Group("/auth")
-	{
-		auth.POST("/register", authHandler.Register)
-		auth.POST("/login", authHandler.Login)
-		auth.POST("/refresh", authHandler.RefreshToken)
-		auth.POST("/logout", authHandler.Logout)
-		auth.GET("/verify", authHandler.VerifyToken)
+	// Prometheus metrics
+	e.GET("/metrics", echo.WrapHandler(metrics.Handler()))
+
+	// API documentation, when enabled
+	if docsHandler != nil {
+		e.GET("/docs", docsHandler.UI)
+		e.GET("/docs/openapi.json", docsHandler.Spec)
+	}
+
+	// Discovery documents, when enabled
+	if wellKnownHandler != nil {
+		e.GET("/.well-known/openid-configuration", wellKnownHandler.OpenIDConfiguration)
+		e.GET("/.well-known/jwks.json", wellKnownHandler.JWKS)
+		e.GET("/.well-known/change-password", wellKnownHandler.ChangePassword)
+		e.GET("/.well-known/security.txt", wellKnownHandler.SecurityTxt)
+	}
+
+	// Operator console, when enabled
+	if adminUIHandler != nil {
+		e.GET("/admin", adminUIHandler.Serve)
+		e.GET("/admin/*", adminUIHandler.Serve)
 	}
 
-	// Protected auth routes
-	authProtected := v1.Group("/auth", authMiddleware.RequireAuth())
-	{
-		authProtected.POST("/change-password", authHandler.ChangePassword)
+	// Routes of v1. Breaking changes to a DTO ship in v2: its handler is
+	// registered after these in the v2 registration, replacing the v1 one
+	// at the same path there, while v1 keeps serving the old contract.
+	v1 := func(api *echo.Group) {
+		// GraphQL, when enabled
+		if graphqlHandler != nil {
+			api.POST("/graphql", graphqlHandler.Query, authMiddleware.RequireAuth())
+		}
+
+		// Auth routes (public)
+		auth := api.Group("/auth")
+		{
+			auth.POST("/register", authHandler.Register)
+			auth.POST("/login", authHandler.Login)
+			auth.POST("/refresh", authHandler.RefreshToken)
+			auth.POST("/token", authHandler.ServiceAccountToken)
+			auth.POST("/logout", authHandler.Logout)
+			auth.GET("/verify", authHandler.VerifyToken)
+			auth.GET("/forward", authHandler.Forward)
+			auth.GET("/csrf", authHandler.CSRFToken)
+			auth.POST("/resend-verification", verificationHandler.ResendVerification)
+			auth.POST("/verify-email", verificationHandler.VerifyEmail)
+			auth.POST("/reset-password", authHandler.ResetPassword)
+			auth.POST("/reset-password/confirm", authHandler.ConfirmResetPassword)
+			auth.POST("/change-password", authHandler.ChangePassword, authMiddleware.AllowPasswordChange())
+		}
+
+		// Protected auth routes
+		authProtected := api.Group("/auth", authMiddleware.RequireAuth())
+		{
+			authProtected.POST("/switch-organization", authHandler.SwitchOrganization)
+		}
+
+		// User routes (protected)
+		users := api.Group("/users", authMiddleware.RequireAuth(), orgMiddleware.ResolveOrganization())
+		{
+			users.GET("/profile", userHandler.GetProfile)
+			users.PUT("/profile", userHandler.UpdateProfile)
+			users.DELETE("/profile", userHandler.DeleteAccount)
+			users.PUT("/profile/avatar", userHandler.UploadAvatar)
+			users.DELETE("/profile/avatar", userHandler.DeleteAvatar)
+			users.POST("/profile/purge", userHandler.PurgeAccount)
+			users.POST("/profile/deletion", userHandler.ScheduleDeletion)
+			users.DELETE("/profile/deletion", userHandler.CancelDeletion)
+			users.POST("/profile/merge", userHandler.MergeOwnAccount)
+			users.PUT("/profile/phone", verificationHandler.StartPhoneVerification)
+			users.POST("/profile/phone/verify", verificationHandler.VerifyPhone)
+			users.DELETE("/profile/phone", verificationHandler.RemovePhone)
+			users.GET("/activity", userHandler.ListMyActivity)
+			users.GET("/logins", userHandler.ListMyLogins)
+			if userEventHandler != nil {
+				users.GET("/events", userEventHandler.Stream)
+			}
+			users.GET("/:id", userHandler.GetUserByID)
+			users.GET("/:id/roles", userHandler.GetUserRoles)
+		}
+
+		// Organization routes (protected, membership checked by the service).
+		// Adding a member directly needs global rights; org admins invite
+		// users instead, who join by accepting.
+		orgs := api.Group("/organizations", authMiddleware.RequireAuth())
+		{
+			orgs.POST("", orgHandler.CreateOrganization)
+			orgs.GET("", orgHandler.ListOrganizations)
+			orgs.GET("/:id", orgHandler.GetOrganization)
+			orgs.PUT("/:id", orgHandler.UpdateOrganization)
+			orgs.DELETE("/:id", orgHandler.DeleteOrganization)
+			orgs.GET("/:id/members", orgHandler.ListMembers)
+			orgs.POST("/:id/members", orgHandler.AddMember, authMiddleware.RequirePermission(entities.PermissionUsersManage))
+			orgs.PUT("/:id/members/:user_id", orgHandler.UpdateMember)
+			orgs.DELETE("/:id/members/:user_id", orgHandler.RemoveMember)
+			orgs.GET("/:id/invitations", orgHandler.ListInvitations)
+			orgs.POST("/:id/invitations", orgHandler.CreateInvitation)
+			orgs.DELETE("/:id/invitations/:invitation_id", orgHandler.RevokeInvitation)
+		}
+
+		// Invitation routes (protected, the invitee is matched by email)
+		invitations := api.Group("/invitations", authMiddleware.RequireAuth())
+		{
+			invitations.POST("/accept", orgHandler.AcceptInvitation)
+			invitations.POST("/decline", orgHandler.DeclineInvitation)
+		}
+
+		// Admin routes (require permission). RequirePermission only honours global
+		// roles; RequireDelegatedPermission also lets org-scoped admins act on
+		// members of their active organization.
+		admin := api.Group("/admin", authMiddleware.RequireAuth())
+		{
+			admin.GET("/users", userHandler.ListUsers, authMiddleware.RequirePermission(entities.PermissionUsersRead))
+			admin.POST("/users", userHandler.CreateUser, authMiddleware.RequirePermission(entities.PermissionUsersManage))
+			admin.POST("/users/import", userHandler.ImportUsers, authMiddleware.RequirePermission(entities.PermissionUsersManage))
+			admin.GET("/users/stats", statsHandler.GetUserStats, authMiddleware.RequirePermission(entities.PermissionUsersRead))
+			admin.GET("/users/search", userHandler.SearchUsers, authMiddleware.RequirePermission(entities.PermissionUsersRead))
+			admin.GET("/users/:id", userHandler.GetUserDetail, authMiddleware.RequireDelegatedPermission(entities.PermissionUsersRead, "id"))
+			admin.GET("/users/:id/notes", userHandler.ListNotes, authMiddleware.RequireDelegatedPermission(entities.PermissionUsersRead, "id"))
+			admin.POST("/users/:id/notes", userHandler.CreateNote, authMiddleware.RequireDelegatedPermission(entities.PermissionUsersNotes, "id"))
+			admin.PUT("/users/:id/notes/:note_id", userHandler.UpdateNote, authMiddleware.RequireDelegatedPermission(entities.PermissionUsersNotes, "id"))
+			admin.DELETE("/users/:id/notes/:note_id", userHandler.DeleteNote, authMiddleware.RequireDelegatedPermission(entities.PermissionUsersNotes, "id"))
+			admin.POST("/users/:id/activate", userHandler.ActivateUser, authMiddleware.RequireDelegatedPermission(entities.PermissionUsersActivate, "id"))
+			admin.POST("/users/:id/deactivate", userHandler.DeactivateUser, authMiddleware.RequireDelegatedPermission(entities.PermissionUsersDeactivate, "id"))
+			admin.GET("/users/:id/activity", userHandler.ListUserActivity, authMiddleware.RequireDelegatedPermission(entities.PermissionUsersRead, "id"))
+			admin.GET("/users/:id/logins", userHandler.ListUserLogins, authMiddleware.RequireDelegatedPermission(entities.PermissionUsersRead, "id"))
+			admin.DELETE("/users/:id/sessions", userHandler.RevokeSessions, authMiddleware.RequireDelegatedPermission(entities.PermissionUsersManage, "id"))
+			admin.POST("/users/:id/merge", userHandler.MergeUsers, authMiddleware.RequirePermission(entities.PermissionUsersManage))
+			admin.POST("/users/:id/ban", userHandler.BanUser, authMiddleware.RequireDelegatedPermission(entities.PermissionUsersBan, "id"))
+			admin.DELETE("/users/:id/ban", userHandler.UnbanUser, authMiddleware.RequireDelegatedPermission(entities.PermissionUsersBan, "id"))
+			admin.POST("/users/:id/password-change", userHandler.RequirePasswordChange, authMiddleware.RequireDelegatedPermission(entities.PermissionUsersManage, "id"))
+			admin.DELETE("/users/:id/password-change", userHandler.WaivePasswordChange, authMiddleware.RequireDelegatedPermission(entities.PermissionUsersManage, "id"))
+			admin.POST("/users/roles/assign", userHandler.AssignRole, authMiddleware.RequirePermission(entities.PermissionRolesAssign))
+			admin.DELETE("/users/roles/remove", userHandler.RemoveRole, authMiddleware.RequirePermission(entities.PermissionRolesAssign))
+			admin.POST("/users/roles/bulk-assign", userHandler.BulkAssignRole, authMiddleware.RequirePermission(entities.PermissionRolesAssign))
+			admin.POST("/users/roles/bulk-remove", userHandler.BulkRemoveRole, authMiddleware.RequirePermission(entities.PermissionRolesAssign))
+			admin.GET("/users/roles/audit", userHandler.ListRoleAudit, authMiddleware.RequirePermission(entities.PermissionRolesRead))
+
+			admin.GET("/roles", roleHandler.ListRoles, authMiddleware.RequirePermission(entities.PermissionRolesRead))
+			admin.GET("/roles/:id", roleHandler.GetRole, authMiddleware.RequirePermission(entities.PermissionRolesRead))
+			admin.POST("/roles", roleHandler.CreateRole, authMiddleware.RequirePermission(entities.PermissionRolesManage))
+			admin.PUT("/roles/:id", roleHandler.UpdateRole, authMiddleware.RequirePermission(entities.PermissionRolesManage))
+			admin.DELETE("/roles/:id", roleHandler.DeleteRole, authMiddleware.RequirePermission(entities.PermissionRolesManage))
+			admin.GET("/roles/:id/permissions", roleHandler.GetRolePermissions, authMiddleware.RequirePermission(entities.PermissionRolesRead))
+			admin.POST("/roles/:id/permissions", roleHandler.GrantPermission, authMiddleware.RequirePermission(entities.PermissionRolesManage))
+			admin.DELETE("/roles/:id/permissions/:permission", roleHandler.RevokePermission, authMiddleware.RequirePermission(entities.PermissionRolesManage))
+
+			admin.GET("/groups", groupHandler.ListGroups, authMiddleware.RequirePermission(entities.PermissionGroupsRead))
+			admin.GET("/groups/:id", groupHandler.GetGroup, authMiddleware.RequirePermission(entities.PermissionGroupsRead))
+			admin.POST("/groups", groupHandler.CreateGroup, authMiddleware.RequirePermission(entities.PermissionGroupsManage))
+			admin.PUT("/groups/:id", groupHandler.UpdateGroup, authMiddleware.RequirePermission(entities.PermissionGroupsManage))
+			admin.DELETE("/groups/:id", groupHandler.DeleteGroup, authMiddleware.RequirePermission(entities.PermissionGroupsManage))
+			admin.GET("/groups/:id/members", groupHandler.ListMembers, authMiddleware.RequirePermission(entities.PermissionGroupsRead))
+			admin.POST("/groups/:id/members", groupHandler.AddMember, authMiddleware.RequirePermission(entities.PermissionGroupsManage))
+			admin.DELETE("/groups/:id/members/:user_id", groupHandler.RemoveMember, authMiddleware.RequirePermission(entities.PermissionGroupsManage))
+			admin.GET("/groups/:id/roles", groupHandler.GetGroupRoles, authMiddleware.RequirePermission(entities.PermissionGroupsRead))
+			admin.POST("/groups/:id/roles", groupHandler.AssignRole, authMiddleware.RequirePermission(entities.PermissionGroupsManage))
+			admin.DELETE("/groups/:id/roles/:role_id", groupHandler.RemoveRole, authMiddleware.RequirePermission(entities.PermissionGroupsManage))
+
+			admin.GET("/organizations/:id/quotas", quotaHandler.GetQuota, authMiddleware.RequirePermission(entities.PermissionQuotasRead))
+			admin.PUT("/organizations/:id/quotas", quotaHandler.UpdateQuota, authMiddleware.RequirePermission(entities.PermissionQuotasManage))
+
+			// Service accounts are users, so their roles are managed through /users/roles.
+			admin.GET("/service-accounts", serviceAccountHandler.ListServiceAccounts, authMiddleware.RequirePermission(entities.PermissionServiceAccountsRead))
+			admin.GET("/service-accounts/:id", serviceAccountHandler.GetServiceAccount, authMiddleware.RequirePermission(entities.PermissionServiceAccountsRead))
+			admin.POST("/service-accounts", serviceAccountHandler.CreateServiceAccount, authMiddleware.RequirePermission(entities.PermissionServiceAccountsManage))
+			admin.DELETE("/service-accounts/:id", serviceAccountHandler.DeleteServiceAccount, authMiddleware.RequirePermission(entities.PermissionServiceAccountsManage))
+			admin.POST("/service-accounts/:id/keys", serviceAccountHandler.CreateKey, authMiddleware.RequirePermission(entities.PermissionServiceAccountsManage))
+			admin.POST("/service-accounts/:id/keys/rotate", serviceAccountHandler.RotateKey, authMiddleware.RequirePermission(entities.PermissionServiceAccountsManage))
+			admin.DELETE("/service-accounts/:id/keys/:key_id", serviceAccountHandler.RevokeKey, authMiddleware.RequirePermission(entities.PermissionServiceAccountsManage))
+
+			admin.POST("/events/replay", eventHandler.ReplayEvents, authMiddleware.RequirePermission(entities.PermissionEventsReplay))
+		}
 	}
 
-	// User routes (protected)
-	users := v1.Group("/users", authMiddleware.RequireAuth())
-	{
-		users.GET("/profile", userHandler.GetProfile)
-		users.PUT("/profile", userHandler.UpdateProfile)
-		users.DELETE("/profile", userHandler.DeleteAccount)
-		users.GET("/:id", userHandler.GetUserByID)
-		users.GET("/:id/roles", userHandler.GetUserRoles)
+	registrations := map[string]func(api *echo.Group){
+		"v1": v1,
+		// Пока несовместимых изменений нет, v2 совпадает с v1
+		"v2": v1,
 	}
 
-	// Admin routes (require admin role)
-	admin := v1.Group("/admin", authMiddleware.RequireAuth(), authMiddleware.RequireRole("admin"))
-	{
-		admin.GET("/users", userHandler.ListUsers)
-		//admin.POST("/users/:id/activate", userHandler.ActivateUser)
-		//admin.POST("/users/:id/deactivate", userHandler.DeactivateUser)
-		admin.POST("/users/roles/assign", userHandler.AssignRole)
-		admin.DELETE("/users/roles/remove", userHandler.RemoveRole)
+	for _, version := range versions {
+		var successor string
+		if version.Successor != "" {
+			successor = "/api/" + version.Successor
+		}
+		api := e.Group("/api/"+version.Name, middleware.Deprecation(version.Deprecated, version.Sunset, successor))
+		registrations[version.Name](api)
 	}
 }
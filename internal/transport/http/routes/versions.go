@@ -0,0 +1,31 @@
+package routes
+
+import (
+	"time"
+
+	"github.com/vagonaizer/authenitfication-service/internal/config"
+)
+
+// Version is a major version of the HTTP API, served under /api/<Name>.
+// Deprecated and Sunset, when set, are announced on its responses along
+// with Successor, the version clients should move to; from Sunset on the
+// version answers 410 Gone.
+type Version struct {
+	Name       string
+	Deprecated time.Time
+	Sunset     time.Time
+	Successor  string
+}
+
+// IsDeprecated reports whether the retirement of the version is scheduled.
+func (v Version) IsDeprecated() bool {
+	return !v.Deprecated.IsZero() || !v.Sunset.IsZero()
+}
+
+// Versions lists the versions of the API, oldest first.
+func Versions(cfg *config.APIConfig) []Version {
+	return []Version{
+		{Name: "v1", Deprecated: cfg.V1DeprecatedAt, Sunset: cfg.V1SunsetAt, Successor: "v2"},
+		{Name: "v2"},
+	}
+}
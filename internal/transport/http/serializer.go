@@ -0,0 +1,58 @@
+package http
+
+import (
+	"github.com/labstack/echo/v4"
+
+	"github.com/vagonaizer/authenitfication-service/internal/dto/response"
+	"github.com/vagonaizer/authenitfication-service/pkg/errors"
+	"github.com/vagonaizer/authenitfication-service/pkg/i18n"
+	"github.com/vagonaizer/authenitfication-service/pkg/tracing"
+)
+
+// errorSerializer completes error responses as they are encoded, so that
+// handlers need not: it adds the request ID and translates the messages into
+// the language of the client, handlers writing them in English.
+type errorSerializer struct {
+	echo.DefaultJSONSerializer
+	translator *i18n.Translator
+}
+
+func (s *errorSerializer) Serialize(c echo.Context, i interface{}, indent string) error {
+	switch resp := i.(type) {
+	case response.ErrorResponse:
+		i = s.complete(c, resp)
+	case *response.ErrorResponse:
+		if resp != nil {
+			i = s.complete(c, *resp)
+		}
+	}
+	return s.DefaultJSONSerializer.Serialize(c, i, indent)
+}
+
+func (s *errorSerializer) complete(c echo.Context, resp response.ErrorResponse) response.ErrorResponse {
+	if resp.RequestID == "" {
+		resp.RequestID = tracing.CorrelationID(c.Request().Context())
+	}
+
+	header := c.Response().Header()
+	header.Add(echo.HeaderVary, "Accept-Language")
+
+	locale, _ := c.Get("locale").(string)
+	lang := s.translator.Language(locale, c.Request().Header.Get("Accept-Language"))
+	header.Set("Content-Language", lang)
+	if lang == i18n.DefaultLanguage {
+		return resp
+	}
+
+	resp.Message = s.translator.Translate(lang, resp.Message)
+
+	// Подробности прочих ошибок — данные, а не текст для перевода
+	if resp.Error == errors.CodeValidation && resp.Details != nil {
+		details := make(map[string]string, len(resp.Details))
+		for field, message := range resp.Details {
+			details[field] = s.translator.Translate(lang, message)
+		}
+		resp.Details = details
+	}
+	return resp
+}
@@ -4,33 +4,60 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"net/url"
+	"strings"
+	"time"
 
 	"github.com/labstack/echo/v4"
 	echomiddleware "github.com/labstack/echo/v4/middleware"
+	"golang.org/x/crypto/acme/autocert"
 
 	"github.com/vagonaizer/authenitfication-service/internal/config"
 	"github.com/vagonaizer/authenitfication-service/internal/transport/http/handlers"
 	"github.com/vagonaizer/authenitfication-service/internal/transport/http/middleware"
+	"github.com/vagonaizer/authenitfication-service/internal/transport/http/openapi"
 	"github.com/vagonaizer/authenitfication-service/internal/transport/http/routes"
+	"github.com/vagonaizer/authenitfication-service/pkg/i18n"
 	"github.com/vagonaizer/authenitfication-service/pkg/logger"
+	"github.com/vagonaizer/authenitfication-service/pkg/utils"
 )
 
 type Server struct {
 	echo          *echo.Echo
 	server        *http.Server
+	tls           *config.TLSConfig
+	redirect      *http.Server
 	logger        *logger.Logger
 	authHandler   *handlers.AuthHandler
 	userHandler   *handlers.UserHandler
+	roleHandler   *handlers.RoleHandler
+	orgHandler    *handlers.OrganizationHandler
+	groupHandler  *handlers.GroupHandler
 	healthHandler *handlers.HealthHandler
 	authMW        *middleware.AuthMiddleware
+	orgMW         *middleware.OrganizationMiddleware
 }
 
 func NewServer(
 	cfg *config.Config,
 	authHandler *handlers.AuthHandler,
 	userHandler *handlers.UserHandler,
+	roleHandler *handlers.RoleHandler,
+	orgHandler *handlers.OrganizationHandler,
+	groupHandler *handlers.GroupHandler,
+	serviceAccountHandler *handlers.ServiceAccountHandler,
+	quotaHandler *handlers.QuotaHandler,
+	verificationHandler *handlers.VerificationHandler,
+	statsHandler *handlers.StatsHandler,
+	eventHandler *handlers.EventHandler,
 	healthHandler *handlers.HealthHandler,
+	graphqlHandler *handlers.GraphQLHandler,
+	userEventHandler *handlers.UserEventHandler,
 	authMW *middleware.AuthMiddleware,
+	orgMW *middleware.OrganizationMiddleware,
+	rateLimitMW *middleware.RateLimitMiddleware,
+	clientIP *utils.ClientIPResolver,
+	translator *i18n.Translator,
 	log *logger.Logger,
 ) *Server {
 	e := echo.New()
@@ -38,18 +65,39 @@ func NewServer(
 	// Hide Echo banner
 	e.HideBanner = true
 
+	// c.RealIP() believes X-Forwarded-For from trusted proxies only
+	e.IPExtractor = func(req *http.Request) string {
+		return clientIP.Resolve(req.RemoteAddr, req.Header.Values(echo.HeaderXForwardedFor))
+	}
+
+	// Error responses carry the request ID, in the language of the client
+	e.JSONSerializer = &errorSerializer{translator: translator}
+
 	// Basic middleware
 	e.Use(echomiddleware.Recover())
 	e.Use(echomiddleware.RequestID())
+	e.Use(middleware.Tracing())
 
 	// CORS middleware
 	if cfg.Server.EnableCORS {
 		e.Use(middleware.CORS())
 	}
 
+	// Response compression
+	if cfg.Compression.Enabled {
+		e.Use(middleware.Compress(&cfg.Compression))
+	}
+
 	// Rate limiting
-	if cfg.Server.EnableRateLimit {
-		e.Use(middleware.RateLimit(cfg.Server.RateLimitRPS))
+	if rateLimitMW != nil {
+		e.Use(rateLimitMW.Limit())
+	} else if cfg.Server.EnableRateLimit {
+		e.Use(middleware.RateLimit(cfg.Server.RateLimitRPS, cfg.Server.RateLimitBurst, cfg.Server.RateLimitPolicies))
+	}
+
+	// CSRF protection of browser clients
+	if cfg.CSRF.Enabled {
+		e.Use(middleware.CSRF(&cfg.CSRF))
 	}
 
 	// Logging middleware
@@ -58,8 +106,55 @@ func NewServer(
 	// Request size limit
 	e.Use(echomiddleware.BodyLimit(fmt.Sprintf("%d", cfg.Server.MaxRequestSize)))
 
+	// Serve locally stored uploads under the path of their public URL
+	if cfg.Storage.Driver == "local" {
+		if u, err := url.Parse(cfg.Storage.PublicURL); err == nil && u.Path != "" && u.Path != "/" {
+			e.Static(u.Path, cfg.Storage.LocalDir)
+		}
+	}
+
+	// API documentation
+	var docsHandler *handlers.DocsHandler
+	versions := routes.Versions(&cfg.API)
+	docVersions := make([]openapi.Version, 0, len(versions))
+	for _, version := range versions {
+		docVersions = append(docVersions, openapi.Version{Name: version.Name, Deprecated: version.IsDeprecated()})
+	}
+	doc := openapi.Build("Authentication Service API", "1.0.0", openapi.Versioned(openapi.Routes, docVersions))
+	if cfg.Server.EnableDocs {
+		var err error
+		if docsHandler, err = handlers.NewDocsHandler(doc); err != nil {
+			log.WithError(err).Error("failed to encode OpenAPI document, /docs is disabled")
+		}
+	}
+
+	// Operator console
+	var adminUIHandler *handlers.AdminUIHandler
+	if cfg.Server.EnableAdminUI {
+		adminUIHandler = handlers.NewAdminUIHandler()
+	}
+
+	// Discovery documents
+	var wellKnownHandler *handlers.WellKnownHandler
+	if cfg.WellKnown.Enabled {
+		wellKnownHandler = handlers.NewWellKnownHandler(
+			cfg.JWT.Issuer,
+			cfg.WellKnown.BaseURL,
+			cfg.WellKnown.ChangePasswordURL,
+			docsHandler != nil,
+			cfg.WellKnown.SecurityContacts,
+			cfg.WellKnown.SecurityPolicy,
+			time.Now().Add(cfg.WellKnown.SecurityTxtExpiry),
+		)
+	}
+
 	// Setup routes
-	routes.SetupRoutes(e, authHandler, userHandler, healthHandler, authMW)
+	routes.SetupRoutes(e, authHandler, userHandler, roleHandler, orgHandler, groupHandler, serviceAccountHandler, quotaHandler, verificationHandler, statsHandler, eventHandler, healthHandler, docsHandler, adminUIHandler, wellKnownHandler, graphqlHandler, userEventHandler, authMW, orgMW, versions)
+
+	// Маршрут без описания в openapi.Routes не попадёт к клиентам
+	if missing := doc.Undocumented(apiRoutes(e)); len(missing) > 0 {
+		log.WithField("routes", missing).Warn("routes missing from the OpenAPI document")
+	}
 
 	server := &http.Server{
 		Addr:         ":" + cfg.Server.HTTPPort,
@@ -68,22 +163,94 @@ func NewServer(
 		WriteTimeout: cfg.Server.WriteTimeout,
 	}
 
+	// TLS, when terminated here rather than by a proxy
+	var tlsConfig *config.TLSConfig
+	var redirect *http.Server
+	if cfg.TLS.Enabled {
+		tlsConfig = &cfg.TLS
+		var manager *autocert.Manager
+		server.TLSConfig, manager = newTLSConfig(tlsConfig)
+
+		if cfg.TLS.RedirectPort != "" {
+			handler := redirectHandler(cfg.Server.HTTPPort)
+			if manager != nil {
+				handler = manager.HTTPHandler(handler)
+			}
+			redirect = &http.Server{
+				Addr:         ":" + cfg.TLS.RedirectPort,
+				Handler:      handler,
+				ReadTimeout:  cfg.Server.ReadTimeout,
+				WriteTimeout: cfg.Server.WriteTimeout,
+			}
+		}
+	}
+
 	return &Server{
 		echo:          e,
 		server:        server,
+		tls:           tlsConfig,
+		redirect:      redirect,
 		logger:        log,
 		authHandler:   authHandler,
 		userHandler:   userHandler,
+		roleHandler:   roleHandler,
+		orgHandler:    orgHandler,
+		groupHandler:  groupHandler,
 		healthHandler: healthHandler,
 		authMW:        authMW,
+		orgMW:         orgMW,
 	}
 }
 
+// apiRoutes returns the method and path of the routes of e that belong in
+// the OpenAPI document.
+func apiRoutes(e *echo.Echo) [][2]string {
+	var result [][2]string
+	for _, route := range e.Routes() {
+		switch {
+		case strings.Contains(route.Path, "*"),
+			route.Path == "/metrics",
+			strings.HasPrefix(route.Path, "/docs"),
+			route.Path == "/admin",
+			route.Method == echo.RouteNotFound:
+			continue
+		}
+		result = append(result, [2]string{route.Method, route.Path})
+	}
+	return result
+}
+
 func (s *Server) Start() error {
-	s.logger.Infof("HTTP server starting on %s", s.server.Addr)
+	if s.tls == nil {
+		s.logger.Infof("HTTP server starting on %s", s.server.Addr)
+
+		if err := s.server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			return fmt.Errorf("failed to start HTTP server: %w", err)
+		}
+		return nil
+	}
 
-	if err := s.server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
-		return fmt.Errorf("failed to start HTTP server: %w", err)
+	// С autocert сертификаты выдаёт GetCertificate, файлы не нужны
+	certFile, keyFile := s.tls.CertFile, s.tls.KeyFile
+	if len(s.tls.AutocertDomains) > 0 {
+		certFile, keyFile = "", ""
+	} else if certFile == "" || keyFile == "" {
+		return fmt.Errorf("failed to start HTTPS server: TLS_CERT_FILE and TLS_KEY_FILE, or TLS_AUTOCERT_DOMAINS, are required")
+	}
+
+	if s.redirect != nil {
+		go func() {
+			s.logger.Infof("HTTP redirect server starting on %s", s.redirect.Addr)
+			if err := s.redirect.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+				s.logger.WithError(err).Error("HTTP redirect server error")
+			}
+		}()
+	}
+
+	s.logger.Infof("HTTPS server starting on %s", s.server.Addr)
+
+	if err := s.server.ListenAndServeTLS(certFile, keyFile); err != nil && err != http.ErrServerClosed {
+		return fmt.Errorf("failed to start HTTPS server: %w", err)
 	}
 
 	return nil
@@ -92,7 +259,18 @@ func (s *Server) Start() error {
 func (s *Server) Stop(ctx context.Context) error {
 	s.logger.Info("shutting down HTTP server")
 
-	return s.server.Shutdown(ctx)
+	if s.redirect != nil {
+		if err := s.redirect.Shutdown(ctx); err != nil {
+			s.logger.WithError(err).Warn("failed to shut down HTTP redirect server")
+		}
+	}
+
+	// Запросы, не завершившиеся к таймауту, обрываются
+	if err := s.server.Shutdown(ctx); err != nil {
+		s.server.Close()
+		return err
+	}
+	return nil
 }
 
 func (s *Server) Handler() http.Handler {
@@ -0,0 +1,65 @@
+package http
+
+import (
+	"crypto/tls"
+	"net"
+	"net/http"
+	"strings"
+
+	"golang.org/x/crypto/acme/autocert"
+
+	"github.com/vagonaizer/authenitfication-service/internal/config"
+)
+
+// newTLSConfig returns the TLS settings of the server: TLS 1.2 or later with
+// forward secret AEAD suites only. With autocert domains, certificates come
+// from the returned manager.
+func newTLSConfig(cfg *config.TLSConfig) (*tls.Config, *autocert.Manager) {
+	tlsConfig := &tls.Config{
+		MinVersion:       tls.VersionTLS12,
+		CurvePreferences: []tls.CurveID{tls.X25519, tls.CurveP256},
+		// Наборы TLS 1.3 не настраиваются и все безопасны
+		CipherSuites: []uint16{
+			tls.TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256,
+			tls.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256,
+			tls.TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384,
+			tls.TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384,
+			tls.TLS_ECDHE_ECDSA_WITH_CHACHA20_POLY1305_SHA256,
+			tls.TLS_ECDHE_RSA_WITH_CHACHA20_POLY1305_SHA256,
+		},
+		NextProtos: []string{"h2", "http/1.1"},
+	}
+
+	if len(cfg.AutocertDomains) == 0 {
+		return tlsConfig, nil
+	}
+
+	manager := &autocert.Manager{
+		Prompt:     autocert.AcceptTOS,
+		HostPolicy: autocert.HostWhitelist(cfg.AutocertDomains...),
+		Cache:      autocert.DirCache(cfg.AutocertCacheDir),
+		Email:      cfg.AutocertEmail,
+	}
+	tlsConfig.GetCertificate = manager.GetCertificate
+	// Проверка TLS-ALPN-01 идёт через тот же порт
+	tlsConfig.NextProtos = append(tlsConfig.NextProtos, "acme-tls/1")
+
+	return tlsConfig, manager
+}
+
+// redirectHandler redirects plain HTTP requests to the same URL over HTTPS
+// on httpsPort.
+func redirectHandler(httpsPort string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		host := r.Host
+		if h, _, err := net.SplitHostPort(host); err == nil {
+			host = h
+		}
+		host = strings.TrimSuffix(strings.TrimPrefix(host, "["), "]")
+		// Порт по умолчанию в URL не пишется
+		host = strings.TrimSuffix(net.JoinHostPort(host, httpsPort), ":443")
+
+		target := "https://" + host + r.URL.RequestURI()
+		http.Redirect(w, r, target, http.StatusPermanentRedirect)
+	})
+}
@@ -0,0 +1,57 @@
+package pprof
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/pprof"
+	"time"
+
+	"github.com/vagonaizer/authenitfication-service/internal/config"
+	"github.com/vagonaizer/authenitfication-service/pkg/logger"
+)
+
+// Server serves the runtime profiles of net/http/pprof under /debug/pprof/
+// on a port of its own, so they are never reachable through the API port.
+// The port must not be exposed outside the cluster: profiles reveal memory
+// contents and cost CPU while they are taken.
+type Server struct {
+	server *http.Server
+	logger *logger.Logger
+}
+
+func NewServer(cfg *config.Config, log *logger.Logger) *Server {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/debug/pprof/", pprof.Index)
+	mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+	mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
+	mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+	mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
+
+	// Без WriteTimeout: CPU-профиль и трасса пишутся столько секунд, сколько запрошено
+	server := &http.Server{
+		Addr:              ":" + cfg.Server.PprofPort,
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	return &Server{
+		server: server,
+		logger: log,
+	}
+}
+
+func (s *Server) Start() error {
+	s.logger.Infof("pprof server starting on %s", s.server.Addr)
+
+	if err := s.server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		return fmt.Errorf("failed to start pprof server: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Server) Stop(ctx context.Context) error {
+	s.logger.Info("shutting down pprof server")
+	return s.server.Shutdown(ctx)
+}
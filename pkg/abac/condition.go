@@ -0,0 +1,114 @@
+// Package abac evaluates attribute conditions attached to permission grants.
+//
+// Conditions are boolean expressions over a fixed set of attributes, e.g.
+//
+//	is_verified == true
+//	org_id == '6f1c0e2a-...' && 'admin' IN roles
+//
+// Supported operators are ==, !=, <, <=, >, >=, =~, !~, &&, ||, ! and IN.
+package abac
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/casbin/govaluate"
+)
+
+const (
+	AttrUserID     = "user_id"
+	AttrEmail      = "email"
+	AttrUsername   = "username"
+	AttrRoles      = "roles"
+	AttrOrgID      = "org_id"
+	AttrIsVerified = "is_verified"
+	AttrIsActive   = "is_active"
+)
+
+var knownAttributes = map[string]bool{
+	AttrUserID:     true,
+	AttrEmail:      true,
+	AttrUsername:   true,
+	AttrRoles:      true,
+	AttrOrgID:      true,
+	AttrIsVerified: true,
+	AttrIsActive:   true,
+}
+
+// Attributes are the values a condition is evaluated against.
+type Attributes map[string]interface{}
+
+var compiled sync.Map // expression string -> *govaluate.EvaluableExpression
+
+// Validate reports whether expr parses and only references known attributes.
+func Validate(expr string) error {
+	_, err := compile(expr)
+	return err
+}
+
+// Evaluate returns whether expr holds for attrs. A condition that does not
+// yield a boolean is an error.
+func Evaluate(expr string, attrs Attributes) (bool, error) {
+	expression, err := compile(expr)
+	if err != nil {
+		return false, err
+	}
+
+	params := make(map[string]interface{}, len(knownAttributes))
+	for name := range knownAttributes {
+		params[name] = nil
+	}
+	for name, value := range attrs {
+		params[name] = normalize(value)
+	}
+
+	result, err := expression.Evaluate(params)
+	if err != nil {
+		return false, fmt.Errorf("evaluate condition %q: %w", expr, err)
+	}
+
+	allowed, ok := result.(bool)
+	if !ok {
+		return false, fmt.Errorf("condition %q does not evaluate to a boolean", expr)
+	}
+
+	return allowed, nil
+}
+
+func compile(expr string) (*govaluate.EvaluableExpression, error) {
+	if cached, ok := compiled.Load(expr); ok {
+		return cached.(*govaluate.EvaluableExpression), nil
+	}
+
+	expression, err := govaluate.NewEvaluableExpression(expr)
+	if err != nil {
+		return nil, fmt.Errorf("parse condition %q: %w", expr, err)
+	}
+
+	for _, name := range expression.Vars() {
+		if !knownAttributes[name] {
+			return nil, fmt.Errorf("condition %q references unknown attribute %q", expr, name)
+		}
+	}
+
+	compiled.Store(expr, expression)
+	return expression, nil
+}
+
+// normalize converts values into the shapes govaluate operators expect.
+func normalize(value interface{}) interface{} {
+	switch v := value.(type) {
+	case []string:
+		out := make([]interface{}, len(v))
+		for i, s := range v {
+			out[i] = s
+		}
+		return out
+	case int:
+		return float64(v)
+	case int64:
+		return float64(v)
+	default:
+		return value
+	}
+}
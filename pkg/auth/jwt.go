@@ -2,6 +2,8 @@ package auth
 
 import (
 	"errors"
+	"fmt"
+	"slices"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
@@ -13,31 +15,137 @@ type JWTManager struct {
 	refreshSecret string
 	issuer        string
 	audience      string
+	// multiTenant derives issuer and audience of organization-scoped access
+	// tokens from the organization, so they are only accepted for it.
+	multiTenant bool
 }
 
 type AccessTokenClaims struct {
-	UserID   uuid.UUID `json:"user_id"`
-	Email    string    `json:"email"`
-	Username string    `json:"username"`
-	Roles    []string  `json:"roles"`
+	UserID   uuid.UUID  `json:"user_id"`
+	Email    string     `json:"email"`
+	Username string     `json:"username"`
+	Roles    []string   `json:"roles"`
+	OrgID    *uuid.UUID `json:"org_id,omitempty"`
+	Groups   []string   `json:"groups,omitempty"`
+	// ScopedRoles lists the entries of Roles granted only within OrgID.
+	ScopedRoles []string `json:"scoped_roles,omitempty"`
+	// Permissions holds the unconditional permissions of GlobalRoles. When they
+	// do not fit the configured cap none are embedded and PermissionsOmitted is set.
+	Permissions        []string `json:"permissions,omitempty"`
+	PermissionsOmitted bool     `json:"permissions_omitted,omitempty"`
+	// ServiceAccount is set for tokens issued to non-human accounts.
+	ServiceAccount bool `json:"service_account,omitempty"`
+	// Locale and Timezone are the user's preferences, so downstream services
+	// can format content without looking the user up.
+	Locale   string `json:"locale,omitempty"`
+	Timezone string `json:"timezone,omitempty"`
+	// PasswordChangeOnly marks the restricted token issued at login to users
+	// who must change their password; it is accepted for nothing else.
+	PasswordChangeOnly bool `json:"pwd_change_only,omitempty"`
 	jwt.RegisteredClaims
 }
 
+// AccessTokenOption sets optional claims on an access token before it is signed.
+type AccessTokenOption func(*AccessTokenClaims)
+
+func WithOrgID(orgID uuid.UUID) AccessTokenOption {
+	return func(c *AccessTokenClaims) {
+		c.OrgID = &orgID
+	}
+}
+
+func WithGroups(groups []string) AccessTokenOption {
+	return func(c *AccessTokenClaims) {
+		c.Groups = groups
+	}
+}
+
+func WithScopedRoles(roles []string) AccessTokenOption {
+	return func(c *AccessTokenClaims) {
+		c.ScopedRoles = roles
+	}
+}
+
+// WithPermissions embeds permissions unless there are more than max of them.
+func WithPermissions(permissions []string, max int) AccessTokenOption {
+	return func(c *AccessTokenClaims) {
+		if len(permissions) > max {
+			c.PermissionsOmitted = true
+			return
+		}
+		c.Permissions = permissions
+	}
+}
+
+func WithLocale(locale, timezone string) AccessTokenOption {
+	return func(c *AccessTokenClaims) {
+		c.Locale = locale
+		c.Timezone = timezone
+	}
+}
+
+func WithPasswordChangeOnly() AccessTokenOption {
+	return func(c *AccessTokenClaims) {
+		c.PasswordChangeOnly = true
+	}
+}
+
+func WithServiceAccount() AccessTokenOption {
+	return func(c *AccessTokenClaims) {
+		c.ServiceAccount = true
+	}
+}
+
+// GlobalRoles returns the roles that apply regardless of the active organization.
+func (c *AccessTokenClaims) GlobalRoles() []string {
+	return WithoutRoles(c.Roles, c.ScopedRoles)
+}
+
+// WithoutRoles returns roles minus every entry of excluded.
+func WithoutRoles(roles, excluded []string) []string {
+	if len(excluded) == 0 {
+		return roles
+	}
+
+	skip := make(map[string]bool, len(excluded))
+	for _, role := range excluded {
+		skip[role] = true
+	}
+
+	result := make([]string, 0, len(roles))
+	for _, role := range roles {
+		if !skip[role] {
+			result = append(result, role)
+		}
+	}
+	return result
+}
+
 type RefreshTokenClaims struct {
 	UserID uuid.UUID `json:"user_id"`
 	jwt.RegisteredClaims
 }
 
-func NewJWTManager(accessSecret, refreshSecret, issuer, audience string) *JWTManager {
+func NewJWTManager(accessSecret, refreshSecret, issuer, audience string, multiTenant bool) *JWTManager {
 	return &JWTManager{
 		accessSecret:  accessSecret,
 		refreshSecret: refreshSecret,
 		issuer:        issuer,
 		audience:      audience,
+		multiTenant:   multiTenant,
 	}
 }
 
-func (j *JWTManager) GenerateAccessToken(userID uuid.UUID, email, username string, roles []string, expiry time.Duration) (string, error) {
+// TenantIssuerAudience returns the issuer and audience expected on an access
+// token scoped to orgID (nil for personal tokens).
+func (j *JWTManager) TenantIssuerAudience(orgID *uuid.UUID) (string, string) {
+	if !j.multiTenant || orgID == nil {
+		return j.issuer, j.audience
+	}
+	return fmt.Sprintf("%s/tenants/%s", j.issuer, orgID), fmt.Sprintf("%s/tenants/%s", j.audience, orgID)
+}
+
+func (j *JWTManager) GenerateAccessToken(userID uuid.UUID, email, username string, roles []string, expiry time.Duration, opts ...AccessTokenOption) (string, error) {
 	now := time.Now()
 	claims := &AccessTokenClaims{
 		UserID:   userID,
@@ -55,6 +163,14 @@ func (j *JWTManager) GenerateAccessToken(userID uuid.UUID, email, username strin
 		},
 	}
 
+	for _, opt := range opts {
+		opt(claims)
+	}
+
+	issuer, audience := j.TenantIssuerAudience(claims.OrgID)
+	claims.Issuer = issuer
+	claims.Audience = []string{audience}
+
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
 	return token.SignedString([]byte(j.accessSecret))
 }
@@ -78,7 +194,19 @@ func (j *JWTManager) GenerateRefreshToken(userID uuid.UUID, expiry time.Duration
 	return token.SignedString([]byte(j.refreshSecret))
 }
 
-func (j *JWTManager) ValidateAccessToken(tokenString string) (*AccessTokenClaims, error) {
+// ValidateAccessToken validates an access token presented by a request
+// addressed to tenant, nil when the request names no organization. With
+// multi-tenancy, a token scoped to an organization is only accepted when
+// tenant is that organization, and must carry its issuer and audience, so it
+// cannot be replayed against another tenant. Personal tokens are accepted
+// for any tenant.
+//
+// The check is only as good as tenant: callers must derive it from what the
+// request accesses, such as the organization in its route or the host the
+// tenant is served on. A tenant the client picks freely, like TenantHeader on
+// a route that names no organization, only makes the client state which
+// tenant it means; anyone holding the token can name the token's own.
+func (j *JWTManager) ValidateAccessToken(tokenString string, tenant *uuid.UUID) (*AccessTokenClaims, error) {
 	token, err := jwt.ParseWithClaims(tokenString, &AccessTokenClaims{}, func(token *jwt.Token) (interface{}, error) {
 		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
 			return nil, errors.New("unexpected signing method")
@@ -90,11 +218,28 @@ func (j *JWTManager) ValidateAccessToken(tokenString string) (*AccessTokenClaims
 		return nil, err
 	}
 
-	if claims, ok := token.Claims.(*AccessTokenClaims); ok && token.Valid {
-		return claims, nil
+	claims, ok := token.Claims.(*AccessTokenClaims)
+	if !ok || !token.Valid {
+		return nil, errors.New("invalid token")
+	}
+
+	var expected *uuid.UUID
+	if j.multiTenant && claims.OrgID != nil {
+		if tenant == nil || *tenant != *claims.OrgID {
+			return nil, errors.New("token belongs to another tenant")
+		}
+		expected = tenant
+	}
+
+	issuer, audience := j.TenantIssuerAudience(expected)
+	if claims.Issuer != issuer {
+		return nil, errors.New("invalid token issuer")
+	}
+	if !slices.Contains(claims.Audience, audience) {
+		return nil, errors.New("invalid token audience")
 	}
 
-	return nil, errors.New("invalid token")
+	return claims, nil
 }
 
 func (j *JWTManager) ValidateRefreshToken(tokenString string) (*RefreshTokenClaims, error) {
@@ -116,6 +261,23 @@ func (j *JWTManager) ValidateRefreshToken(tokenString string) (*RefreshTokenClai
 	return nil, errors.New("invalid refresh token")
 }
 
+// TenantHeader is the header, and lower-cased the gRPC metadata key, by
+// which a request addresses an organization.
+const TenantHeader = "X-Organization-ID"
+
+// ParseTenant parses a TenantHeader value; an empty or malformed value
+// addresses no tenant.
+func ParseTenant(value string) *uuid.UUID {
+	if value == "" {
+		return nil
+	}
+	id, err := uuid.Parse(value)
+	if err != nil {
+		return nil
+	}
+	return &id
+}
+
 func (j *JWTManager) ExtractTokenFromHeader(authHeader string) (string, error) {
 	if len(authHeader) < 7 || authHeader[:7] != "Bearer " {
 		return "", errors.New("invalid authorization header format")
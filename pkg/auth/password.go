@@ -85,6 +85,32 @@ func (p *PasswordHasher) VerifyPassword(password, encodedHash string) (bool, err
 	return false, nil
 }
 
+// IsValidHash reports whether encodedHash is an argon2id hash in the format
+// produced by HashPassword, e.g. one exported from another deployment.
+func (p *PasswordHasher) IsValidHash(encodedHash string) bool {
+	vals := strings.Split(encodedHash, "$")
+	if len(vals) != 6 || vals[1] != "argon2id" {
+		return false
+	}
+
+	var version int
+	if _, err := fmt.Sscanf(vals[2], "v=%d", &version); err != nil || version != argon2.Version {
+		return false
+	}
+
+	var memory, iterations uint32
+	var parallelism uint8
+	if _, err := fmt.Sscanf(vals[3], "m=%d,t=%d,p=%d", &memory, &iterations, &parallelism); err != nil {
+		return false
+	}
+
+	if _, err := base64.RawStdEncoding.DecodeString(vals[4]); err != nil {
+		return false
+	}
+	hash, err := base64.RawStdEncoding.DecodeString(vals[5])
+	return err == nil && len(hash) > 0
+}
+
 func (p *PasswordHasher) generateRandomBytes(n uint32) ([]byte, error) {
 	b := make([]byte, n)
 	_, err := rand.Read(b)
@@ -0,0 +1,125 @@
+// Package client is the Go client of the gRPC API of the authentication
+// service. It authenticates as a service account, caching the access token
+// and fetching a new one before it expires, retries calls the server did
+// not get to handle, and returns *Error for the calls the server failed.
+package client
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+
+	"github.com/vagonaizer/authenitfication-service/api/proto/generated"
+)
+
+// Config configures a Client. Only Address is required.
+type Config struct {
+	// Address of the gRPC server, as host:port.
+	Address string
+	// TLS configures the connection; nil connects without TLS. Set
+	// Certificates to authenticate by client certificate.
+	TLS *tls.Config
+	// KeyID and Secret of the service account the client calls as. Without
+	// them calls carry no token, unless the context of a call already has
+	// an authorization header.
+	KeyID  string
+	Secret string
+	// RefreshBefore is how long before it expires the token is replaced;
+	// defaults to a minute.
+	RefreshBefore time.Duration
+	// MaxRetries bounds the retries of a call failing with UNAVAILABLE;
+	// defaults to 3, a negative value disables them.
+	MaxRetries int
+	// RetryBackoff is the wait before the first retry, doubled for each
+	// one after it; defaults to 100ms.
+	RetryBackoff time.Duration
+	// DialOptions are added to the options of the connection.
+	DialOptions []grpc.DialOption
+}
+
+// Client holds a connection to the service. The service clients are safe
+// for concurrent use and share the token of the Client.
+type Client struct {
+	conn   *grpc.ClientConn
+	tokens *tokenCache
+	cfg    Config
+
+	Auth  generated.AuthServiceClient
+	Users generated.UserServiceClient
+	Roles generated.RoleServiceClient
+}
+
+// New connects to the service at cfg.Address. The connection is made
+// lazily, by the first call.
+func New(cfg Config) (*Client, error) {
+	if cfg.Address == "" {
+		return nil, fmt.Errorf("client: address is required")
+	}
+	if (cfg.KeyID == "") != (cfg.Secret == "") {
+		return nil, fmt.Errorf("client: key ID and secret must be set together")
+	}
+	if cfg.RefreshBefore <= 0 {
+		cfg.RefreshBefore = time.Minute
+	}
+	if cfg.MaxRetries == 0 {
+		cfg.MaxRetries = 3
+	}
+	if cfg.RetryBackoff <= 0 {
+		cfg.RetryBackoff = 100 * time.Millisecond
+	}
+
+	creds := insecure.NewCredentials()
+	if cfg.TLS != nil {
+		creds = credentials.NewTLS(cfg.TLS)
+	}
+
+	c := &Client{cfg: cfg}
+	opts := append([]grpc.DialOption{
+		grpc.WithTransportCredentials(creds),
+		grpc.WithChainUnaryInterceptor(c.unaryInterceptor),
+		grpc.WithChainStreamInterceptor(c.streamInterceptor),
+	}, cfg.DialOptions...)
+
+	conn, err := grpc.NewClient(cfg.Address, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("client: failed to create gRPC client: %w", err)
+	}
+
+	c.conn = conn
+	c.Auth = generated.NewAuthServiceClient(conn)
+	c.Users = generated.NewUserServiceClient(conn)
+	c.Roles = generated.NewRoleServiceClient(conn)
+	if cfg.KeyID != "" {
+		c.tokens = newTokenCache(c.Auth, cfg.KeyID, cfg.Secret, cfg.RefreshBefore)
+	}
+	return c, nil
+}
+
+// Close closes the connection.
+func (c *Client) Close() error {
+	return c.conn.Close()
+}
+
+// VerifyToken returns the claims of an access token.
+func (c *Client) VerifyToken(ctx context.Context, token string) (*generated.TokenClaimsResponse, error) {
+	return c.Auth.VerifyToken(ctx, &generated.VerifyTokenRequest{Token: token})
+}
+
+// CheckAccess reports whether a user holds permission on resource; an
+// empty resource checks the permission alone.
+func (c *Client) CheckAccess(ctx context.Context, userID, permission, resource string) (bool, error) {
+	resp, err := c.Auth.CheckAccess(ctx, &generated.CheckAccessRequest{
+		UserId:     userID,
+		Permission: permission,
+		Resource:   resource,
+	})
+	if err != nil {
+		return false, err
+	}
+	return resp.Allowed, nil
+}
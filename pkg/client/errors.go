@@ -0,0 +1,76 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Errors a failed call matches with errors.Is, by its status code.
+var (
+	ErrInvalidArgument   = errors.New("invalid argument")
+	ErrNotFound          = errors.New("not found")
+	ErrAlreadyExists     = errors.New("already exists")
+	ErrUnauthenticated   = errors.New("unauthenticated")
+	ErrPermissionDenied  = errors.New("permission denied")
+	ErrResourceExhausted = errors.New("resource exhausted")
+	ErrUnavailable       = errors.New("service unavailable")
+)
+
+var codeErrors = map[codes.Code]error{
+	codes.InvalidArgument:   ErrInvalidArgument,
+	codes.NotFound:          ErrNotFound,
+	codes.AlreadyExists:     ErrAlreadyExists,
+	codes.Unauthenticated:   ErrUnauthenticated,
+	codes.PermissionDenied:  ErrPermissionDenied,
+	codes.ResourceExhausted: ErrResourceExhausted,
+	codes.Unavailable:       ErrUnavailable,
+}
+
+// Error is a call the server failed.
+type Error struct {
+	Code    codes.Code
+	Message string
+	// Violations maps the fields of an invalid request, such as
+	// "role_ids[2]", to the rule they broke.
+	Violations map[string]string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("%s: %s", e.Code, e.Message)
+}
+
+func (e *Error) Is(target error) bool {
+	return codeErrors[e.Code] == target
+}
+
+// toError converts the status of a failed call to *Error. Errors of the
+// context of the call are returned as they are.
+func toError(ctx context.Context, err error) error {
+	if err == nil {
+		return nil
+	}
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return ctxErr
+	}
+
+	st, ok := status.FromError(err)
+	if !ok {
+		return err
+	}
+
+	e := &Error{Code: st.Code(), Message: st.Message()}
+	for _, detail := range st.Details() {
+		if badRequest, ok := detail.(*errdetails.BadRequest); ok {
+			e.Violations = make(map[string]string, len(badRequest.FieldViolations))
+			for _, violation := range badRequest.FieldViolations {
+				e.Violations[violation.Field] = violation.Description
+			}
+		}
+	}
+	return e
+}
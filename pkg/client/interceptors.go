@@ -0,0 +1,118 @@
+package client
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/vagonaizer/authenitfication-service/api/proto/generated"
+)
+
+// publicMethods are called without a token, as the server does not check one.
+var publicMethods = map[string]bool{
+	generated.AuthService_Register_FullMethodName:             true,
+	generated.AuthService_Login_FullMethodName:                true,
+	generated.AuthService_RefreshToken_FullMethodName:         true,
+	generated.AuthService_ServiceAccountToken_FullMethodName:  true,
+	generated.AuthService_VerifyToken_FullMethodName:          true,
+	generated.AuthService_ResetPassword_FullMethodName:        true,
+	generated.AuthService_ConfirmResetPassword_FullMethodName: true,
+}
+
+func (c *Client) unaryInterceptor(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	backoff := c.cfg.RetryBackoff
+	retries := 0
+	refreshed := false
+
+	for {
+		callCtx, token, err := c.authorize(ctx, method)
+		if err != nil {
+			return toError(ctx, err)
+		}
+
+		err = invoker(callCtx, method, req, reply, cc, opts...)
+		switch status.Code(err) {
+		case codes.OK:
+			return nil
+
+		case codes.Unauthenticated:
+			// Токен мог быть отозван раньше срока: повторяем один раз с новым
+			if token == "" || refreshed {
+				return toError(ctx, err)
+			}
+			c.tokens.Invalidate(token)
+			refreshed = true
+			continue
+
+		case codes.Unavailable:
+			// Сервер не принял вызов, повтор безопасен
+			if retries >= c.cfg.MaxRetries {
+				return toError(ctx, err)
+			}
+			retries++
+
+			timer := time.NewTimer(backoff)
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return ctx.Err()
+			case <-timer.C:
+			}
+			backoff *= 2
+
+		default:
+			return toError(ctx, err)
+		}
+	}
+}
+
+func (c *Client) streamInterceptor(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+	callCtx, _, err := c.authorize(ctx, method)
+	if err != nil {
+		return nil, toError(ctx, err)
+	}
+
+	stream, err := streamer(callCtx, desc, cc, method, opts...)
+	if err != nil {
+		return nil, toError(ctx, err)
+	}
+	return &clientStream{ClientStream: stream, ctx: ctx}, nil
+}
+
+// authorize adds the service account token to the metadata of a call and
+// returns it. Calls to public methods, calls whose context already has an
+// authorization header and calls of a client without a service account are
+// left as they are.
+func (c *Client) authorize(ctx context.Context, method string) (context.Context, string, error) {
+	if c.tokens == nil || publicMethods[method] {
+		return ctx, "", nil
+	}
+	if md, ok := metadata.FromOutgoingContext(ctx); ok && len(md.Get("authorization")) > 0 {
+		return ctx, "", nil
+	}
+
+	token, err := c.tokens.Token(ctx)
+	if err != nil {
+		return nil, "", err
+	}
+	return metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+token), token, nil
+}
+
+// clientStream converts the errors of a stream to *Error.
+type clientStream struct {
+	grpc.ClientStream
+	ctx context.Context
+}
+
+func (s *clientStream) RecvMsg(m interface{}) error {
+	err := s.ClientStream.RecvMsg(m)
+	if err == io.EOF {
+		return err
+	}
+	return toError(s.ctx, err)
+}
@@ -0,0 +1,64 @@
+package client
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/vagonaizer/authenitfication-service/api/proto/generated"
+)
+
+// tokenCache holds the access token of a service account. Callers wait for
+// a single request for a new token instead of each making their own.
+type tokenCache struct {
+	auth          generated.AuthServiceClient
+	keyID         string
+	secret        string
+	refreshBefore time.Duration
+
+	mu        sync.Mutex
+	token     string
+	expiresAt time.Time
+}
+
+func newTokenCache(auth generated.AuthServiceClient, keyID, secret string, refreshBefore time.Duration) *tokenCache {
+	return &tokenCache{
+		auth:          auth,
+		keyID:         keyID,
+		secret:        secret,
+		refreshBefore: refreshBefore,
+	}
+}
+
+// Token returns the cached token, or a new one when it is about to expire.
+func (t *tokenCache) Token(ctx context.Context) (string, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.token != "" && time.Until(t.expiresAt) > t.refreshBefore {
+		return t.token, nil
+	}
+
+	resp, err := t.auth.ServiceAccountToken(ctx, &generated.ServiceAccountTokenRequest{
+		KeyId:  t.keyID,
+		Secret: t.secret,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	t.token = resp.AccessToken
+	t.expiresAt = time.Now().Add(time.Duration(resp.ExpiresIn) * time.Second)
+	return t.token, nil
+}
+
+// Invalidate drops token if it is still the cached one, so that the next
+// call fetches a new one.
+func (t *tokenCache) Invalidate(token string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.token == token {
+		t.token = ""
+	}
+}
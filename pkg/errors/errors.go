@@ -3,6 +3,8 @@ package errors
 import (
 	"fmt"
 	"net/http"
+	"strconv"
+	"time"
 )
 
 type AppError struct {
@@ -102,6 +104,15 @@ func UserNotVerified() *AppError {
 	return New(CodeUserNotVerified, "User account is not verified", http.StatusForbidden)
 }
 
+// UserBanned carries the ban reason and, for temporary bans, when it ends.
+func UserBanned(reason string, until *time.Time) *AppError {
+	details := map[string]string{"reason": reason}
+	if until != nil {
+		details["banned_until"] = until.UTC().Format(time.RFC3339)
+	}
+	return WithDetails(New(CodeUserBanned, "User account is banned", http.StatusForbidden), details)
+}
+
 func EmailExists() *AppError {
 	return New(CodeEmailExists, "Email already exists", http.StatusConflict)
 }
@@ -110,6 +121,20 @@ func UsernameExists() *AppError {
 	return New(CodeUsernameExists, "Username already exists", http.StatusConflict)
 }
 
+// PasswordChangeRequired is returned for requests made with the restricted
+// token issued to users who must change their password first.
+func PasswordChangeRequired() *AppError {
+	return New(CodePasswordChangeRequired, "Password change required", http.StatusForbidden)
+}
+
+func PhoneExists() *AppError {
+	return New(CodePhoneExists, "Phone number already in use", http.StatusConflict)
+}
+
+func RoleExists() *AppError {
+	return New(CodeRoleExists, "Role already exists", http.StatusConflict)
+}
+
 func WeakPassword() *AppError {
 	return New(CodeWeakPassword, "Password does not meet security requirements", http.StatusBadRequest)
 }
@@ -118,6 +143,19 @@ func RateLimitExceeded() *AppError {
 	return New(CodeRateLimitExceeded, "Rate limit exceeded", http.StatusTooManyRequests)
 }
 
+func QuotaExceeded(quota string, limit int) *AppError {
+	return WithDetails(
+		New(CodeQuotaExceeded, "Organization quota exceeded", http.StatusTooManyRequests),
+		map[string]string{"quota": quota, "limit": strconv.Itoa(limit)},
+	)
+}
+
+// PreconditionFailed is returned when a conditional write finds that the
+// resource changed since the client read it.
+func PreconditionFailed() *AppError {
+	return New(CodePreconditionFailed, "Resource was modified since it was read", http.StatusPreconditionFailed)
+}
+
 func DatabaseError(err error) *AppError {
 	return Wrap(err, CodeDatabaseError, "Database operation failed", http.StatusInternalServerError)
 }
@@ -0,0 +1,152 @@
+// Package i18n translates the messages of errors returned to clients.
+// Messages are written in English; a catalog maps them to their translation
+// in one language. The catalogs of locales/*.json are built in, and a
+// directory of catalogs named after their language, such as de.json, adds
+// languages or overrides built-in translations. Messages missing from a
+// catalog are returned in English.
+package i18n
+
+import (
+	"context"
+	"embed"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"sort"
+	"strings"
+
+	"golang.org/x/text/language"
+)
+
+// DefaultLanguage is the language messages are written in.
+const DefaultLanguage = "en"
+
+//go:embed locales/*.json
+var builtin embed.FS
+
+type Translator struct {
+	catalogs  map[string]map[string]string
+	languages []string
+	matcher   language.Matcher
+}
+
+// New loads the built-in catalogs, then those of dir when it is set.
+func New(dir string) (*Translator, error) {
+	t := &Translator{catalogs: make(map[string]map[string]string)}
+
+	if err := t.load(builtin, "locales"); err != nil {
+		return nil, err
+	}
+	if dir != "" {
+		if err := t.load(os.DirFS(dir), "."); err != nil {
+			return nil, err
+		}
+	}
+
+	// Первый язык списка — запасной вариант сопоставления
+	t.languages = []string{DefaultLanguage}
+	for lang := range t.catalogs {
+		if lang != DefaultLanguage {
+			t.languages = append(t.languages, lang)
+		}
+	}
+	sort.Strings(t.languages[1:])
+
+	tags := make([]language.Tag, 0, len(t.languages))
+	for _, lang := range t.languages {
+		tags = append(tags, language.Make(lang))
+	}
+	t.matcher = language.NewMatcher(tags)
+
+	return t, nil
+}
+
+func (t *Translator) load(fsys fs.FS, dir string) error {
+	files, err := fs.Glob(fsys, path.Join(dir, "*.json"))
+	if err != nil {
+		return err
+	}
+
+	for _, file := range files {
+		data, err := fs.ReadFile(fsys, file)
+		if err != nil {
+			return fmt.Errorf("failed to read catalog %s: %w", file, err)
+		}
+
+		var messages map[string]string
+		if err := json.Unmarshal(data, &messages); err != nil {
+			return fmt.Errorf("failed to parse catalog %s: %w", file, err)
+		}
+
+		lang := strings.TrimSuffix(path.Base(file), ".json")
+		if t.catalogs[lang] == nil {
+			t.catalogs[lang] = make(map[string]string)
+		}
+		for message, translation := range messages {
+			t.catalogs[lang][message] = translation
+		}
+	}
+	return nil
+}
+
+// Languages lists the languages messages can be translated into.
+func (t *Translator) Languages() []string {
+	return t.languages
+}
+
+// Language picks the language of a response: the locale the user chose,
+// when one is known and supported, else the best match for the
+// Accept-Language header, else DefaultLanguage.
+func (t *Translator) Language(preferred, acceptLanguage string) string {
+	if preferred != "" {
+		if tag, err := language.Parse(preferred); err == nil {
+			if _, index, confidence := t.matcher.Match(tag); confidence != language.No {
+				return t.languages[index]
+			}
+		}
+	}
+
+	tags, _, err := language.ParseAcceptLanguage(acceptLanguage)
+	if err != nil || len(tags) == 0 {
+		return DefaultLanguage
+	}
+	if _, index, confidence := t.matcher.Match(tags...); confidence != language.No {
+		return t.languages[index]
+	}
+	return DefaultLanguage
+}
+
+// Translate returns message in lang, or unchanged when lang has no
+// translation for it.
+func (t *Translator) Translate(lang, message string) string {
+	if translation, ok := t.catalogs[lang][message]; ok && translation != "" {
+		return translation
+	}
+	return message
+}
+
+type preferenceKey struct{}
+
+// WithPreference returns a copy of ctx in which SetPreference can record
+// the locale of the caller once it is authenticated, for an interceptor
+// that runs before authentication to read with Preference.
+func WithPreference(ctx context.Context) context.Context {
+	return context.WithValue(ctx, preferenceKey{}, new(string))
+}
+
+// SetPreference records locale as the preference of the caller of ctx.
+func SetPreference(ctx context.Context, locale string) {
+	if preference, ok := ctx.Value(preferenceKey{}).(*string); ok {
+		*preference = locale
+	}
+}
+
+// Preference returns the locale recorded with SetPreference.
+func Preference(ctx context.Context) string {
+	if preference, ok := ctx.Value(preferenceKey{}).(*string); ok {
+		return *preference
+	}
+	return ""
+}
@@ -8,13 +8,15 @@ import (
 
 	"github.com/sirupsen/logrus"
 	"gopkg.in/natefinch/lumberjack.v2"
+
+	"github.com/vagonaizer/authenitfication-service/pkg/tracing"
 )
 
 type Logger struct {
 	*logrus.Logger
 }
 
-type Fields map[string]interface{}
+type Fields = logrus.Fields
 
 func New(level, format, output string, maxSize, maxBackups, maxAge int, compress bool) *Logger {
 	log := logrus.New()
@@ -60,10 +62,32 @@ func New(level, format, output string, maxSize, maxBackups, maxAge int, compress
 	}
 
 	log.SetOutput(writer)
+	log.AddHook(contextHook{})
 
 	return &Logger{Logger: log}
 }
 
+// contextHook adds the trace and request IDs of the context of an entry,
+// set with WithContext, to its fields.
+type contextHook struct{}
+
+func (contextHook) Levels() []logrus.Level {
+	return logrus.AllLevels
+}
+
+func (contextHook) Fire(entry *logrus.Entry) error {
+	if entry.Context == nil {
+		return nil
+	}
+	if traceID := tracing.TraceID(entry.Context); traceID != "" {
+		entry.Data["trace_id"] = traceID
+	}
+	if requestID := tracing.CorrelationID(entry.Context); requestID != "" {
+		entry.Data["request_id"] = requestID
+	}
+	return nil
+}
+
 func (l *Logger) WithFields(fields map[string]interface{}) *logrus.Entry {
 	return l.Logger.WithFields(logrus.Fields(fields))
 }
@@ -0,0 +1,148 @@
+// Package tracing carries the W3C trace context and the correlation ID of a
+// request through contexts, so that the events a request publishes, and the
+// work their consumers do, can be stitched into one trace.
+package tracing
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+// Header names used on HTTP requests, gRPC metadata and broker messages.
+const (
+	HeaderTraceparent   = "traceparent"
+	HeaderCorrelationID = "x-request-id"
+)
+
+// SpanContext identifies a span of a trace, as in the traceparent header.
+type SpanContext struct {
+	TraceID string
+	SpanID  string
+	Sampled bool
+}
+
+type spanKey struct{}
+type correlationIDKey struct{}
+
+// ParseTraceparent parses a version 00 traceparent header.
+func ParseTraceparent(header string) (SpanContext, bool) {
+	parts := strings.Split(strings.TrimSpace(header), "-")
+	if len(parts) != 4 || parts[0] != "00" {
+		return SpanContext{}, false
+	}
+	if !isHex(parts[1], 32) || !isHex(parts[2], 16) || !isHex(parts[3], 2) {
+		return SpanContext{}, false
+	}
+	// Нулевые идентификаторы по спецификации недействительны
+	if strings.Trim(parts[1], "0") == "" || strings.Trim(parts[2], "0") == "" {
+		return SpanContext{}, false
+	}
+
+	return SpanContext{
+		TraceID: parts[1],
+		SpanID:  parts[2],
+		Sampled: parts[3][1]&1 == 1,
+	}, true
+}
+
+// Traceparent formats sc as a traceparent header.
+func (sc SpanContext) Traceparent() string {
+	flags := "00"
+	if sc.Sampled {
+		flags = "01"
+	}
+	return fmt.Sprintf("00-%s-%s-%s", sc.TraceID, sc.SpanID, flags)
+}
+
+// StartSpan returns a context holding a new span: a child of the span of
+// parent when it has one, the root of a new trace otherwise.
+func StartSpan(ctx context.Context, parent *SpanContext) context.Context {
+	span := SpanContext{SpanID: randomHex(8), Sampled: true}
+	if parent != nil {
+		span.TraceID = parent.TraceID
+		span.Sampled = parent.Sampled
+	} else {
+		span.TraceID = randomHex(16)
+	}
+	return context.WithValue(ctx, spanKey{}, span)
+}
+
+// SpanFromContext returns the span of ctx, if any.
+func SpanFromContext(ctx context.Context) (SpanContext, bool) {
+	span, ok := ctx.Value(spanKey{}).(SpanContext)
+	return span, ok
+}
+
+// TraceID returns the trace ID of ctx, or an empty string.
+func TraceID(ctx context.Context) string {
+	span, _ := SpanFromContext(ctx)
+	return span.TraceID
+}
+
+// WithCorrelationID returns a context holding the correlation ID id.
+func WithCorrelationID(ctx context.Context, id string) context.Context {
+	if id == "" {
+		return ctx
+	}
+	return context.WithValue(ctx, correlationIDKey{}, id)
+}
+
+// CorrelationID returns the correlation ID of ctx, or an empty string.
+func CorrelationID(ctx context.Context) string {
+	id, _ := ctx.Value(correlationIDKey{}).(string)
+	return id
+}
+
+// NewCorrelationID returns a random correlation ID for requests that arrive
+// without one.
+func NewCorrelationID() string {
+	return randomHex(16)
+}
+
+// Inject sets the trace context and correlation ID of ctx on headers through
+// set. Nothing is set for values ctx does not have.
+func Inject(ctx context.Context, set func(key, value string)) {
+	if span, ok := SpanFromContext(ctx); ok {
+		set(HeaderTraceparent, span.Traceparent())
+	}
+	if id := CorrelationID(ctx); id != "" {
+		set(HeaderCorrelationID, id)
+	}
+}
+
+// Headers returns the headers Inject sets for ctx.
+func Headers(ctx context.Context) map[string]string {
+	headers := make(map[string]string)
+	Inject(ctx, func(key, value string) {
+		headers[key] = value
+	})
+	return headers
+}
+
+// Extract starts a span continuing the trace found in headers through get,
+// or a new trace when there is none, and keeps their correlation ID.
+func Extract(ctx context.Context, get func(key string) string) context.Context {
+	var parent *SpanContext
+	if span, ok := ParseTraceparent(get(HeaderTraceparent)); ok {
+		parent = &span
+	}
+	ctx = StartSpan(ctx, parent)
+	return WithCorrelationID(ctx, get(HeaderCorrelationID))
+}
+
+func isHex(s string, length int) bool {
+	if len(s) != length || s != strings.ToLower(s) {
+		return false
+	}
+	_, err := hex.DecodeString(s)
+	return err == nil
+}
+
+func randomHex(bytes int) string {
+	b := make([]byte, bytes)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
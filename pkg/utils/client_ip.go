@@ -0,0 +1,100 @@
+package utils
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/netip"
+	"strings"
+)
+
+// ClientIPResolver finds the IP address of the client of a request. The
+// X-Forwarded-For header is only believed as far as it was written by
+// trusted proxies: it is read from the right, starting at the peer of the
+// connection, and the first address not in a trusted range is the client.
+// Without trusted proxies the peer is the client, so clients cannot pick
+// the address they are rate limited and recorded under.
+type ClientIPResolver struct {
+	trusted []netip.Prefix
+}
+
+// NewClientIPResolver trusts the proxies of the given CIDR ranges or single
+// addresses.
+func NewClientIPResolver(trustedProxies []string) (*ClientIPResolver, error) {
+	r := &ClientIPResolver{}
+	for _, proxy := range trustedProxies {
+		prefix, err := netip.ParsePrefix(proxy)
+		if err != nil {
+			addr, addrErr := netip.ParseAddr(proxy)
+			if addrErr != nil {
+				return nil, fmt.Errorf("invalid trusted proxy %q: %w", proxy, err)
+			}
+			prefix = netip.PrefixFrom(addr, addr.BitLen())
+		}
+		r.trusted = append(r.trusted, prefix.Masked())
+	}
+	return r, nil
+}
+
+// Resolve returns the client IP of a request received from remoteAddr, a
+// host with an optional port, carrying the X-Forwarded-For header values
+// forwardedFor.
+func (r *ClientIPResolver) Resolve(remoteAddr string, forwardedFor []string) string {
+	host := remoteAddr
+	if h, _, err := net.SplitHostPort(remoteAddr); err == nil {
+		host = h
+	}
+
+	ip, err := netip.ParseAddr(host)
+	if err != nil {
+		return host
+	}
+	ip = ip.Unmap()
+	if !r.isTrusted(ip) {
+		return ip.String()
+	}
+
+	// Каждый прокси дописывает адрес своего клиента в конец заголовка
+	for i := len(forwardedFor) - 1; i >= 0; i-- {
+		hops := strings.Split(forwardedFor[i], ",")
+		for j := len(hops) - 1; j >= 0; j-- {
+			hop := strings.TrimSpace(hops[j])
+			if hop == "" {
+				continue
+			}
+			next, err := netip.ParseAddr(hop)
+			if err != nil {
+				// Мусор в заголовке: клиентом считаем последний доверенный узел
+				return ip.String()
+			}
+			ip = next.Unmap()
+			if !r.isTrusted(ip) {
+				return ip.String()
+			}
+		}
+	}
+
+	return ip.String()
+}
+
+func (r *ClientIPResolver) isTrusted(ip netip.Addr) bool {
+	for _, prefix := range r.trusted {
+		if prefix.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+type clientIPKey struct{}
+
+// WithClientIP records the resolved client IP of a request in ctx.
+func WithClientIP(ctx context.Context, ip string) context.Context {
+	return context.WithValue(ctx, clientIPKey{}, ip)
+}
+
+// ClientIP returns the client IP recorded by WithClientIP, or "".
+func ClientIP(ctx context.Context) string {
+	ip, _ := ctx.Value(clientIPKey{}).(string)
+	return ip
+}
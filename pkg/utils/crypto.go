@@ -5,6 +5,7 @@ import (
 	"crypto/sha256"
 	"encoding/hex"
 	"fmt"
+	"math/big"
 )
 
 func GenerateRandomString(length int) (string, error) {
@@ -40,3 +41,67 @@ func GenerateAPIKey() (string, error) {
 	}
 	return fmt.Sprintf("%s%s", prefix, randomPart), nil
 }
+
+const (
+	passwordUpper   = "ABCDEFGHJKLMNPQRSTUVWXYZ"
+	passwordLower   = "abcdefghijkmnopqrstuvwxyz"
+	passwordDigits  = "23456789"
+	passwordSymbols = "!@#$%^&*-_=+?"
+)
+
+// GenerateTemporaryPassword returns a random password of the given length
+// that satisfies IsValidPassword. Ambiguous characters are left out so it can
+// be read out to the user.
+func GenerateTemporaryPassword(length int) (string, error) {
+	classes := []string{passwordUpper, passwordLower, passwordDigits, passwordSymbols}
+	if length < len(classes) {
+		return "", fmt.Errorf("password length must be at least %d", len(classes))
+	}
+
+	all := passwordUpper + passwordLower + passwordDigits + passwordSymbols
+	password := make([]byte, length)
+	for i := range password {
+		charset := all
+		if i < len(classes) {
+			charset = classes[i]
+		}
+		c, err := randomChar(charset)
+		if err != nil {
+			return "", err
+		}
+		password[i] = c
+	}
+
+	// Shuffle so the guaranteed characters are not always in front.
+	for i := len(password) - 1; i > 0; i-- {
+		j, err := rand.Int(rand.Reader, big.NewInt(int64(i+1)))
+		if err != nil {
+			return "", err
+		}
+		password[i], password[j.Int64()] = password[j.Int64()], password[i]
+	}
+
+	return string(password), nil
+}
+
+// GenerateNumericCode returns a random code of length decimal digits, as used
+// for one-time passcodes.
+func GenerateNumericCode(length int) (string, error) {
+	code := make([]byte, length)
+	for i := range code {
+		c, err := randomChar("0123456789")
+		if err != nil {
+			return "", err
+		}
+		code[i] = c
+	}
+	return string(code), nil
+}
+
+func randomChar(charset string) (byte, error) {
+	n, err := rand.Int(rand.Reader, big.NewInt(int64(len(charset))))
+	if err != nil {
+		return 0, err
+	}
+	return charset[n.Int64()], nil
+}
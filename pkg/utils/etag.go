@@ -0,0 +1,25 @@
+package utils
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ETag returns the strong entity tag of a resource last modified at updatedAt.
+// Postgres keeps timestamps to the microsecond, so finer precision is dropped.
+func ETag(updatedAt time.Time) string {
+	return `"` + strconv.FormatInt(updatedAt.UnixMicro(), 36) + `"`
+}
+
+// ETagMatches reports whether the If-Match header value ifMatch lists etag
+// or is "*". Weak tags never match, as If-Match uses strong comparison.
+func ETagMatches(ifMatch, etag string) bool {
+	for _, candidate := range strings.Split(ifMatch, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || candidate == etag {
+			return true
+		}
+	}
+	return false
+}
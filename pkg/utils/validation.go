@@ -3,12 +3,18 @@ package utils
 import (
 	"regexp"
 	"strings"
+	"time"
 	"unicode"
+
+	"golang.org/x/text/language"
 )
 
 var (
 	emailRegex    = regexp.MustCompile(`^[a-zA-Z0-9._%+-]+@[a-zA-Z0-9.-]+\.[a-zA-Z]{2,}$`)
 	usernameRegex = regexp.MustCompile(`^[a-zA-Z0-9_-]{3,50}$`)
+	roleNameRegex = regexp.MustCompile(`^[a-z][a-z0-9_-]{1,49}$`)
+	slugRegex     = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+	phoneRegex    = regexp.MustCompile(`^\+[1-9][0-9]{7,14}$`)
 )
 
 func IsValidEmail(email string) bool {
@@ -19,6 +25,19 @@ func IsValidUsername(username string) bool {
 	return usernameRegex.MatchString(username)
 }
 
+func IsValidRoleName(name string) bool {
+	return roleNameRegex.MatchString(name)
+}
+
+func IsValidSlug(slug string) bool {
+	return len(slug) >= 3 && len(slug) <= 63 && slugRegex.MatchString(slug)
+}
+
+// IsValidPhone accepts E.164 numbers such as +14155550123.
+func IsValidPhone(phone string) bool {
+	return phoneRegex.MatchString(phone)
+}
+
 func IsValidPassword(password string) bool {
 	if len(password) < 8 {
 		return false
@@ -59,6 +78,35 @@ func NormalizeUsername(username string) string {
 	return strings.ToLower(strings.TrimSpace(username))
 }
 
+// NormalizePhone strips the spaces, dashes, dots and parentheses people
+// commonly type into phone numbers.
+func NormalizePhone(phone string) string {
+	return strings.NewReplacer(" ", "", "-", "", ".", "", "(", "", ")", "").Replace(strings.TrimSpace(phone))
+}
+
+// NormalizeLocale returns the canonical form of a BCP 47 tag, e.g. "pt-BR"
+// for "pt_br", and false when locale is not a well-formed tag.
+func NormalizeLocale(locale string) (string, bool) {
+	tag, err := language.Parse(strings.ReplaceAll(strings.TrimSpace(locale), "_", "-"))
+	if err != nil {
+		return "", false
+	}
+	return tag.String(), true
+}
+
+// IsValidTimezone accepts IANA zone names such as Europe/Berlin.
+func IsValidTimezone(timezone string) bool {
+	if timezone == "" || timezone == "Local" {
+		return false
+	}
+	_, err := time.LoadLocation(timezone)
+	return err == nil
+}
+
+func NormalizeRoleName(name string) string {
+	return strings.ToLower(strings.TrimSpace(name))
+}
+
 func IsEmptyOrWhitespace(s string) bool {
 	return len(strings.TrimSpace(s)) == 0
 }